@@ -4,3 +4,6 @@ import "embed"
 
 //go:embed applications
 var ApplicationFS embed.FS
+
+//go:embed gateway
+var GatewayFS embed.FS
@@ -0,0 +1,32 @@
+package apikey
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// APIKeyCmd represents the apikey command
+var APIKeyCmd = &cobra.Command{
+	Use:   "apikey",
+	Short: "Manage the API keys of the inference endpoints",
+	Long: `API keys are stored as podman secrets and required by the serving containers of the application,
+so that the inference endpoints on shared networks are not open to everyone.
+
+Keys are applied to the serving containers when the application pods are created, hence create the keys
+before 'ai-services application create' or pass --api-key to create.`,
+	Example: `  # Create an API key for application 'rag'
+  ai-services apikey create rag --name team-a
+
+  # List and revoke the API keys
+  ai-services apikey list rag
+  ai-services apikey revoke 3f2a9c1d`,
+	Args: cobra.MaximumNArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmd.Help()
+	},
+}
+
+func init() {
+	APIKeyCmd.AddCommand(createCmd)
+	APIKeyCmd.AddCommand(listCmd)
+	APIKeyCmd.AddCommand(revokeCmd)
+}
@@ -0,0 +1,55 @@
+package apikey
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/apikeys"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/podman"
+)
+
+var keyName string
+
+var createCmd = &cobra.Command{
+	Use:   "create [name]",
+	Short: "Generates an API key for an application",
+	Long: `Generates an API key for the application. The key is printed only once, store it safely.
+
+Arguments
+  [name]: Application name (required)`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		appName := args[0]
+
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		runtimeClient, err := podman.NewPodmanClient()
+		if err != nil {
+			return fmt.Errorf("failed to connect to podman: %w", err)
+		}
+
+		apiKey, key, err := apikeys.Create(runtimeClient, appName, keyName)
+		if err != nil {
+			return fmt.Errorf("failed to create API key: %w", err)
+		}
+
+		machine.MarkChanged()
+		machine.SetData(map[string]any{"key": key, "apiKey": apiKey})
+
+		logger.Infof("API key %s created for application '%s':\n", key.ID, appName)
+		logger.Infoln(apiKey)
+		logger.Warningf("Store the API key safely, it cannot be retrieved later\n")
+
+		logger.Infof("If the application '%s' is already running, recreate it for the key to apply to the serving containers\n", appName)
+
+		return nil
+	},
+}
+
+func init() {
+	createCmd.Flags().StringVar(&keyName, "name", "", "Name describing the owner or purpose of the key")
+}
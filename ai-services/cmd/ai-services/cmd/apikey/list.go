@@ -0,0 +1,56 @@
+package apikey
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/apikeys"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+)
+
+var listCmd = &cobra.Command{
+	Use:   "list [name]",
+	Short: "Lists the API keys of all or specified application",
+	Long: `Lists the API keys of all the applications if no name is provided
+
+Arguments
+  [name]: Application name (optional)`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var appName string
+		if len(args) > 0 {
+			appName = args[0]
+		}
+
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		keys, err := apikeys.List(appName)
+		if err != nil {
+			return fmt.Errorf("failed to list API keys: %w", err)
+		}
+		machine.SetData(keys)
+
+		if len(keys) == 0 {
+			logger.Infoln("No API keys found")
+			return nil
+		}
+
+		p := utils.NewTableWriter()
+		defer p.CloseTableWriter()
+		p.SetHeaders("ID", "APPLICATION NAME", "NAME", "CREATED", "STATUS")
+		for _, k := range keys {
+			status := "active"
+			if k.RevokedAt != nil {
+				status = "revoked " + k.RevokedAt.Format(time.RFC3339)
+			}
+			p.AppendRow(k.ID, k.Application, k.Name, k.CreatedAt.Format(time.RFC3339), status)
+		}
+
+		return nil
+	},
+}
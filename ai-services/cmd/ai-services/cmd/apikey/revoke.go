@@ -0,0 +1,44 @@
+package apikey
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/apikeys"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/podman"
+)
+
+var revokeCmd = &cobra.Command{
+	Use:   "revoke [id]",
+	Short: "Revokes an API key",
+	Long: `Removes the API key from the secret of its application
+
+Arguments
+  [id]: API key ID as listed by 'ai-services apikey list' (required)`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		runtimeClient, err := podman.NewPodmanClient()
+		if err != nil {
+			return fmt.Errorf("failed to connect to podman: %w", err)
+		}
+
+		key, err := apikeys.Revoke(runtimeClient, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to revoke API key: %w", err)
+		}
+
+		machine.MarkChanged()
+		machine.SetData(key)
+
+		logger.Infof("API key %s of application '%s' revoked\n", key.ID, key.Application)
+		logger.Infof("Recreate the application '%s' for the revocation to apply to the running serving containers\n", key.Application)
+
+		return nil
+	},
+}
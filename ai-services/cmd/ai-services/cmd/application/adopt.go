@@ -0,0 +1,98 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+	"github.com/project-ai-services/ai-services/pkg/aiservices"
+)
+
+var (
+	rawAdoptSelector []string
+	adoptSelector    map[string]string
+	adoptTemplate    string
+	adoptDryRun      bool
+)
+
+var adoptCmd = &cobra.Command{
+	Use:   "adopt [name]",
+	Short: "Brings pre-existing unmanaged pods under ai-services management",
+	Long: `Adopts the pods matching the selector which are not managed by ai-services, Eg:- created by hand with
+'podman kube play', as the application, for a gradual migration to ai-services.
+
+The labels of a podman pod cannot be changed, hence each pod is recreated from its 'podman kube generate' YAML
+with the ai-services labels: its containers restart, its named volumes are kept. A pod failing to be recreated is
+restored unmanaged. The adopted pods are recorded as a revision of the application, and are listed, stopped,
+deleted and rolled back like the pods deployed by 'application create'.
+
+Use --dry-run to list the pods which would be adopted.
+
+Arguments
+  [name]: Name of the application to adopt the pods into (required)`,
+	Example: `  # List the unmanaged pods labeled app=rag
+  ai-services application adopt rag --selector app=rag --dry-run
+
+  # Adopt them as application 'rag'
+  ai-services application adopt rag --selector app=rag`,
+	Args: cobra.ExactArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		var err error
+		adoptSelector, err = utils.ParseKeyValues(rawAdoptSelector)
+		if err != nil {
+			return fmt.Errorf("error validating selector flag: %v", err)
+		}
+		if len(adoptSelector) == 0 {
+			return fmt.Errorf("at least one --selector is required")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		applicationName := args[0]
+
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		client, err := aiservices.NewClient(context.Background())
+		if err != nil {
+			return err
+		}
+
+		pods, err := client.Adopt(context.Background(), applicationName, aiservices.AdoptOptions{
+			Selector: adoptSelector,
+			Template: adoptTemplate,
+			DryRun:   adoptDryRun,
+		})
+		if len(pods) > 0 {
+			if !adoptDryRun {
+				machine.MarkChanged()
+			}
+			machine.SetData(pods)
+
+			p := utils.NewTableWriter()
+			p.SetHeaders("POD NAME", "STATUS", "ADOPTED")
+			for _, pod := range pods {
+				p.AppendRow(pod.Name, pod.Status, fmt.Sprintf("%t", !adoptDryRun))
+			}
+			p.CloseTableWriter()
+		}
+		if err != nil {
+			return fmt.Errorf("failed to adopt pods: %w", err)
+		}
+
+		if !adoptDryRun {
+			logger.Infof("Adopted %d pods as application '%s'\n", len(pods), applicationName)
+		}
+		return nil
+	},
+}
+
+func init() {
+	adoptCmd.Flags().StringArrayVar(&rawAdoptSelector, "selector", []string{}, "Label the pods to adopt must match, Eg:- app=rag. Repeatable, the pods must match all the labels")
+	adoptCmd.Flags().StringVar(&adoptTemplate, "template", aiservices.AdoptedTemplate, "Template recorded for the application")
+	adoptCmd.Flags().BoolVar(&adoptDryRun, "dry-run", false, "List the pods which would be adopted, without adopting them")
+}
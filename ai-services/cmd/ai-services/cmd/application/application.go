@@ -3,8 +3,10 @@ package application
 import (
 	"github.com/spf13/cobra"
 
+	"github.com/project-ai-services/ai-services/cmd/ai-services/cmd/application/config"
 	"github.com/project-ai-services/ai-services/cmd/ai-services/cmd/application/image"
 	"github.com/project-ai-services/ai-services/cmd/ai-services/cmd/application/model"
+	"github.com/project-ai-services/ai-services/cmd/ai-services/cmd/application/schedule"
 	"github.com/project-ai-services/ai-services/internal/pkg/vars"
 )
 
@@ -23,9 +25,29 @@ func init() {
 	ApplicationCmd.AddCommand(image.ImageCmd)
 	ApplicationCmd.AddCommand(stopCmd)
 	ApplicationCmd.AddCommand(startCmd)
+	ApplicationCmd.AddCommand(pauseCmd)
+	ApplicationCmd.AddCommand(unpauseCmd)
 	ApplicationCmd.AddCommand(infoCmd)
 	ApplicationCmd.AddCommand(logsCmd)
 	ApplicationCmd.AddCommand(model.ModelCmd)
+	ApplicationCmd.AddCommand(endpointsCmd)
+	ApplicationCmd.AddCommand(smokeTestCmd)
+	ApplicationCmd.AddCommand(benchCmd)
+	ApplicationCmd.AddCommand(sbomCmd)
+	ApplicationCmd.AddCommand(scanCmd)
+	ApplicationCmd.AddCommand(config.ConfigCmd)
+	ApplicationCmd.AddCommand(rollbackCmd)
+	ApplicationCmd.AddCommand(historyCmd)
+	ApplicationCmd.AddCommand(diffCmd)
+	ApplicationCmd.AddCommand(migrateCmd)
+	ApplicationCmd.AddCommand(adoptCmd)
+	ApplicationCmd.AddCommand(standbyCmd)
+	ApplicationCmd.AddCommand(failoverCmd)
+	ApplicationCmd.AddCommand(scaleCmd)
+	ApplicationCmd.AddCommand(autoscaleCmd)
+	ApplicationCmd.AddCommand(enableOnBootCmd)
+	ApplicationCmd.AddCommand(disableOnBootCmd)
+	ApplicationCmd.AddCommand(schedule.ScheduleCmd)
 	ApplicationCmd.PersistentFlags().StringVar(&vars.ToolImage, "tool-image", vars.ToolImage, "Tool image to use for downloading the model(only for the development purpose)")
 	_ = ApplicationCmd.PersistentFlags().MarkHidden("tool-image")
 }
@@ -0,0 +1,104 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/heartbeat"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+	"github.com/project-ai-services/ai-services/pkg/aiservices"
+)
+
+var (
+	autoscaleInterval  time.Duration
+	autoscaleOnce      bool
+	autoscaleHeartbeat string
+)
+
+var autoscaleCmd = &cobra.Command{
+	Use:   "autoscale [name]",
+	Short: "Autoscale the components of an application on their queue depth",
+	Long: `Scales the components of an application declaring 'autoscale' in the metadata of the template, on the queue
+depth scraped from the Prometheus metrics of their pods (vllm:num_requests_waiting by default).
+
+A component is scaled to ceil(queue depth / targetPerReplica) replicas, between its minReplicas and maxReplicas.
+Scaling up is bounded by the free Spyre cards, scaling down removes a single replica per evaluation.
+
+The components are evaluated every --interval until interrupted, or once with --once.
+
+Arguments
+  [name]: Application name (required)`,
+	Example: `  ai-services application autoscale rag --interval 30s
+  ai-services application autoscale rag --once`,
+	Args: cobra.ExactArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if autoscaleInterval < 5*time.Second {
+			return fmt.Errorf("invalid interval %s, must be at least 5s", autoscaleInterval)
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		applicationName := args[0]
+
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		client, err := aiservices.NewClient(context.Background())
+		if err != nil {
+			return err
+		}
+
+		if autoscaleOnce {
+			decisions, err := client.AutoscaleOnce(context.Background(), applicationName)
+			for _, d := range decisions {
+				printDecision(d)
+			}
+			machine.SetData(decisions)
+			if err != nil {
+				return fmt.Errorf("failed to autoscale application: %w", err)
+			}
+			return nil
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		hb, err := heartbeat.Start(autoscaleHeartbeat, "application autoscale "+applicationName, 0)
+		if err != nil {
+			return err
+		}
+
+		logger.Infof("Autoscaling application %s every %s\n", applicationName, autoscaleInterval)
+		err = client.Autoscale(ctx, applicationName, autoscaleInterval, func(d aiservices.AutoscaleDecision) {
+			printDecision(d)
+			hb.Progress("evaluated component " + d.Component)
+		})
+		hb.Stop(err)
+		return err
+	},
+}
+
+func printDecision(d aiservices.AutoscaleDecision) {
+	if d.Error != "" {
+		logger.Warningf("%s component %s: %s\n", d.Time.Format(time.RFC3339), d.Component, d.Error)
+		return
+	}
+	msg := fmt.Sprintf("%s component %s: queue %g, replicas %d, desired %d", d.Time.Format(time.RFC3339), d.Component, d.Queue, d.Replicas, d.Desired)
+	if d.Reason != "" {
+		msg += " (" + d.Reason + ")"
+	}
+	logger.Infoln(msg)
+}
+
+func init() {
+	autoscaleCmd.Flags().DurationVar(&autoscaleInterval, "interval", 30*time.Second, "Interval between the evaluations of the components")
+	autoscaleCmd.Flags().BoolVar(&autoscaleOnce, "once", false, "Evaluate the components once and exit")
+	autoscaleCmd.Flags().StringVar(&autoscaleHeartbeat, "heartbeat-file", "", "File the status of the autoscaler is written to every 15s, for the supervisors to detect a hung autoscaler")
+}
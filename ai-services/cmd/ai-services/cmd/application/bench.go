@@ -0,0 +1,132 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/apikeys"
+	"github.com/project-ai-services/ai-services/internal/pkg/bench"
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/helpers"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/podman"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+)
+
+var (
+	benchEndpoint    string
+	benchModel       string
+	benchPrompt      string
+	benchMaxTokens   int
+	benchConcurrency int
+	benchDuration    time.Duration
+)
+
+var benchCmd = &cobra.Command{
+	Use:   "bench [name]",
+	Short: "Benchmarks the OpenAI compatible endpoint of an application",
+	Long: `Fires OpenAI compatible completion requests at the serving endpoint of the application with the given
+concurrency and reports the throughput, latency percentiles and error rate, to help size the Spyre capacity.
+
+Arguments
+  [name]: Application name (required)`,
+	Example: `  ai-services application bench rag --endpoint instruct --concurrency 8 --duration 60s`,
+	Args:    cobra.ExactArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if benchConcurrency < 1 {
+			return fmt.Errorf("--concurrency must be at least 1")
+		}
+		if benchDuration <= 0 {
+			return fmt.Errorf("--duration must be positive")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		appName := args[0]
+
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		runtimeClient, err := podman.NewPodmanClient()
+		if err != nil {
+			return fmt.Errorf("failed to connect to podman: %w", err)
+		}
+
+		endpoints, err := helpers.ListEndpoints(runtimeClient, appName)
+		if err != nil {
+			return fmt.Errorf("failed to fetch endpoints: %w", err)
+		}
+
+		idx := slices.IndexFunc(endpoints, func(ep helpers.Endpoint) bool {
+			if benchEndpoint != "" {
+				return ep.Name == benchEndpoint
+			}
+			// defaults to the first OpenAI compatible endpoint
+			return strings.HasSuffix(ep.URL, "/v1")
+		})
+		if idx == -1 {
+			return fmt.Errorf("no OpenAI compatible endpoint found for application '%s', list them with 'ai-services application endpoints %s'", appName, appName)
+		}
+		ep := endpoints[idx]
+		if ep.URL == "" || !ep.Ready {
+			return fmt.Errorf("endpoint '%s' of application '%s' is not ready", ep.Name, appName)
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		logger.Infof("Benchmarking endpoint '%s' (%s) with concurrency %d for %s...\n", ep.Name, ep.URL, benchConcurrency, benchDuration)
+		report, err := bench.Run(ctx, bench.Options{
+			BaseURL:     bench.BaseURL(ep.URL),
+			APIKey:      apikeys.Token(runtimeClient, appName),
+			Model:       benchModel,
+			Prompt:      benchPrompt,
+			MaxTokens:   benchMaxTokens,
+			Concurrency: benchConcurrency,
+			Duration:    benchDuration,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to run benchmark: %w", err)
+		}
+
+		machine.SetData(report)
+		printBenchReport(report)
+		return nil
+	},
+}
+
+func init() {
+	benchCmd.Flags().StringVar(&benchEndpoint, "endpoint", "", "Endpoint to benchmark (default: first OpenAI compatible endpoint)")
+	benchCmd.Flags().StringVar(&benchModel, "model", "", "Served model name (default: first model served by the endpoint)")
+	benchCmd.Flags().StringVar(&benchPrompt, "prompt", "Explain the benefits of running AI inference on IBM Power.", "Prompt sent with each request")
+	benchCmd.Flags().IntVar(&benchMaxTokens, "max-tokens", 64, "Maximum tokens generated per request")
+	benchCmd.Flags().IntVarP(&benchConcurrency, "concurrency", "c", 1, "Number of concurrent requests")
+	benchCmd.Flags().DurationVarP(&benchDuration, "duration", "d", 60*time.Second, "Duration of the benchmark")
+}
+
+func printBenchReport(report *bench.Report) {
+	p := utils.NewTableWriter()
+	p.SetHeaders("METRIC", "VALUE")
+	p.AppendRow("Model", report.Model)
+	p.AppendRow("Duration", report.Duration.Round(time.Millisecond).String())
+	p.AppendRow("Requests", fmt.Sprintf("%d", report.Requests))
+	p.AppendRow("Errors", fmt.Sprintf("%d (%.2f%%)", report.Errors, report.ErrorRate()))
+	p.AppendRow("Throughput", fmt.Sprintf("%.2f req/s", report.Throughput()))
+	p.AppendRow("Generated tokens", fmt.Sprintf("%d (%.2f tokens/s)", report.CompletionTokens, report.TokensPerSecond()))
+	for _, pct := range []float64{50, 90, 95, 99} {
+		p.AppendRow(fmt.Sprintf("Latency p%.0f", pct), report.Percentile(pct).Round(time.Millisecond).String())
+	}
+	p.CloseTableWriter()
+
+	for msg, count := range report.ErrorKinds {
+		logger.Warningf("%d requests failed with: %s\n", count, msg)
+	}
+}
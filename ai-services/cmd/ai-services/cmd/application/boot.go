@@ -0,0 +1,92 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/audit"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+	"github.com/project-ai-services/ai-services/pkg/aiservices"
+)
+
+var enableOnBootCmd = &cobra.Command{
+	Use:   "enable-on-boot [name]",
+	Short: "Start an application on boot",
+	Long: `Installs the systemd units starting the pods of an application on boot.
+
+A oneshot service is generated per layer of the last deployed revision, starting the pods of the layer once the
+previous layer is started, and a target pulling in the services of the application is enabled. The pods deployed
+outside of the revision, Eg:- the replicas, are started in a last layer. Running it again regenerates the units, Eg:-
+after scaling the application.
+
+The units are installed in /etc/systemd/system, or in the user units of the rootless user.
+
+Arguments
+  [name]: Application name (required)`,
+	Example: `  ai-services application enable-on-boot rag`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		applicationName := args[0]
+
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		client, err := aiservices.NewClient(context.Background())
+		if err != nil {
+			return err
+		}
+
+		units, err := client.EnableOnBoot(context.Background(), applicationName)
+		if err != nil {
+			return fmt.Errorf("failed to enable the application on boot: %w", err)
+		}
+		machine.SetData(units)
+		machine.MarkChanged()
+
+		if err := audit.Record(audit.Entry{Application: applicationName, Action: "enable-on-boot",
+			Details: strings.Join(units, ", ")}); err != nil {
+			logger.Warningf("failed to record the enablement in the audit history: %v\n", err)
+		}
+
+		logger.Infof("Application %s will be started on boot by %s\n", applicationName, units[len(units)-1])
+		return nil
+	},
+}
+
+var disableOnBootCmd = &cobra.Command{
+	Use:   "disable-on-boot [name]",
+	Short: "Stop starting an application on boot",
+	Long: `Disables and removes the systemd units starting an application on boot. The running pods are left untouched.
+
+Arguments
+  [name]: Application name (required)`,
+	Example: `  ai-services application disable-on-boot rag`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		applicationName := args[0]
+
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		client, err := aiservices.NewClient(context.Background())
+		if err != nil {
+			return err
+		}
+
+		if err := client.DisableOnBoot(context.Background(), applicationName); err != nil {
+			return fmt.Errorf("failed to disable the application on boot: %w", err)
+		}
+		machine.MarkChanged()
+
+		if err := audit.Record(audit.Entry{Application: applicationName, Action: "disable-on-boot"}); err != nil {
+			logger.Warningf("failed to record the disablement in the audit history: %v\n", err)
+		}
+
+		logger.Infof("Application %s will no longer be started on boot\n", applicationName)
+		return nil
+	},
+}
@@ -0,0 +1,24 @@
+package config
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var ConfigCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage the runtime config of an application",
+	Long: `Manages the runtime config of an application, Eg:- tuning parameters of the model servers.
+
+The config is stored in a podman volume, one file per key, mounted read-only at /etc/ai-services/config
+in the containers declaring the keys they read with the 'ai-services.io/config/<container>' pod annotation.
+Changing a key restarts only the containers reading it.`,
+	Args: cobra.MaximumNArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmd.Help()
+	},
+}
+
+func init() {
+	ConfigCmd.AddCommand(setCmd)
+	ConfigCmd.AddCommand(showCmd)
+}
@@ -0,0 +1,64 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+	"github.com/project-ai-services/ai-services/pkg/aiservices"
+)
+
+var setCmd = &cobra.Command{
+	Use:   "set [name] key=value...",
+	Short: "Set runtime config keys of an application",
+	Long: `Sets runtime config keys of an application, and restarts the containers reading the changed keys.
+An empty value (key=) removes the key. The change is recorded in the audit history.
+
+Arguments
+  [name]: Application name (required)
+  key=value: Config keys to set (at least one)`,
+	Args: cobra.MinimumNArgs(2),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		for _, kv := range args[1:] {
+			if !strings.Contains(kv, "=") {
+				return fmt.Errorf("invalid config '%s', expected key=value", kv)
+			}
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		applicationName := args[0]
+
+		values := map[string]string{}
+		for _, kv := range args[1:] {
+			key, value, _ := strings.Cut(kv, "=")
+			values[strings.TrimSpace(key)] = value
+		}
+
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		client, err := aiservices.NewClient(context.Background())
+		if err != nil {
+			return err
+		}
+
+		restarted, err := client.SetConfig(context.Background(), applicationName, values)
+		machine.MarkChanged()
+		machine.SetData(map[string]any{"application": applicationName, "restarted": restarted})
+		if err != nil {
+			return fmt.Errorf("failed to set the application config: %w", err)
+		}
+
+		for _, container := range restarted {
+			logger.Infof("Restarted container: %s\n", container)
+		}
+		logger.Infof("Successfully updated the config of application: %s\n", applicationName)
+
+		return nil
+	},
+}
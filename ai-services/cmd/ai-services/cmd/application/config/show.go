@@ -0,0 +1,108 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/audit"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+	"github.com/project-ai-services/ai-services/pkg/aiservices"
+)
+
+var showHistory bool
+
+var showCmd = &cobra.Command{
+	Use:   "show [name]",
+	Short: "Show the runtime config of an application",
+	Long: `Shows the runtime config keys of an application, along with the containers reading them.
+
+Arguments
+  [name]: Application name (required)`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		applicationName := args[0]
+
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		client, err := aiservices.NewClient(context.Background())
+		if err != nil {
+			return err
+		}
+
+		cfg, err := client.GetConfig(context.Background(), applicationName)
+		if err != nil {
+			return fmt.Errorf("failed to get the application config: %w", err)
+		}
+
+		if showHistory {
+			entries, err := audit.List(applicationName)
+			if err != nil {
+				return fmt.Errorf("failed to read the audit history: %w", err)
+			}
+			machine.SetData(entries)
+			return printHistory(entries)
+		}
+
+		machine.SetData(cfg)
+		return printConfig(cfg)
+	},
+}
+
+func init() {
+	showCmd.Flags().BoolVar(&showHistory, "history", false, "Show the audit history of the application instead")
+}
+
+func printConfig(cfg *aiservices.AppConfig) error {
+	if len(cfg.Values) == 0 {
+		logger.Infoln("No config set")
+		return nil
+	}
+
+	p := utils.NewTableWriter()
+	defer p.CloseTableWriter()
+	p.SetHeaders("KEY", "VALUE", "READ BY")
+	keys := utils.ExtractMapKeys(cfg.Values)
+	sort.Strings(keys)
+	for _, key := range keys {
+		p.AppendRow(key, cfg.Values[key], strings.Join(consumersOf(cfg, key), ", "))
+	}
+
+	return nil
+}
+
+func consumersOf(cfg *aiservices.AppConfig, key string) []string {
+	var consumers []string
+	for container, keys := range cfg.Consumers {
+		for _, k := range keys {
+			if k == key || k == "*" {
+				consumers = append(consumers, container)
+				break
+			}
+		}
+	}
+	sort.Strings(consumers)
+	return consumers
+}
+
+func printHistory(entries []audit.Entry) error {
+	if len(entries) == 0 {
+		logger.Infoln("No audit history")
+		return nil
+	}
+
+	p := utils.NewTableWriter()
+	defer p.CloseTableWriter()
+	p.SetHeaders("TIME", "USER", "ACTION", "DETAILS")
+	for _, e := range entries {
+		p.AppendRow(e.Time.Local().Format("2006-01-02 15:04:05"), e.User, e.Action, e.Details)
+	}
+
+	return nil
+}
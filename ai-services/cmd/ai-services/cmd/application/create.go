@@ -1,17 +1,9 @@
 package application
 
 import (
-	"bytes"
 	"context"
-	"errors"
 	"fmt"
-	"io"
-	"os/exec"
-	"slices"
-	"strconv"
 	"strings"
-	"sync"
-	"text/template"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -19,23 +11,13 @@ import (
 	"github.com/project-ai-services/ai-services/cmd/ai-services/cmd/bootstrap"
 	"github.com/project-ai-services/ai-services/internal/pkg/cli/helpers"
 	"github.com/project-ai-services/ai-services/internal/pkg/cli/templates"
-	"github.com/project-ai-services/ai-services/internal/pkg/constants"
+	"github.com/project-ai-services/ai-services/internal/pkg/heartbeat"
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
-	"github.com/project-ai-services/ai-services/internal/pkg/models"
-	"github.com/project-ai-services/ai-services/internal/pkg/runtime"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+	"github.com/project-ai-services/ai-services/internal/pkg/presets"
 	"github.com/project-ai-services/ai-services/internal/pkg/runtime/podman"
-	"github.com/project-ai-services/ai-services/internal/pkg/specs"
-	"github.com/project-ai-services/ai-services/internal/pkg/spinner"
 	"github.com/project-ai-services/ai-services/internal/pkg/utils"
-	"github.com/project-ai-services/ai-services/internal/pkg/validators"
-	"github.com/project-ai-services/ai-services/internal/pkg/vars"
-)
-
-var (
-	extraContainerReadinessTimeout = 5 * time.Minute
-	envMutex                       sync.Mutex
-	retryCount                     = 3
-	retryInterval                  = 5 * time.Second
+	"github.com/project-ai-services/ai-services/pkg/aiservices"
 )
 
 // Variables for flags placeholder
@@ -43,10 +25,39 @@ var (
 	templateName      string
 	skipModelDownload bool
 	skipImageDownload bool
+	skipSmokeTests    bool
 	skipChecks        []string
 	rawArgParams      []string
 	argParams         map[string]string
 	valuesFiles       []string
+	environment       string
+	presetName        string
+	savePresetName    string
+	heartbeatFile     string
+	enableTLS         bool
+	tlsCertFile       string
+	tlsKeyFile        string
+	tlsHosts          []string
+	generateAPIKey    bool
+	signaturePolicy   string
+	forceSMTLevel     bool
+	forceRecreate     bool
+	skipResourceCheck bool
+	allowedHostPaths  []string
+	readinessTimeout  time.Duration
+	healthInterval    time.Duration
+	rawHealthDisabled []string
+	healthDisabled    []string
+	retries           int
+	retryBackoff      time.Duration
+	retry             *aiservices.RetryPolicy
+	rawLabels         []string
+	labels            map[string]string
+	rawAnnotations    []string
+	annotations       map[string]string
+	scanImages        bool
+	scanFailOn        string
+	acceptLicense     bool
 )
 
 var createCmd = &cobra.Command{
@@ -67,6 +78,54 @@ var createCmd = &cobra.Command{
 			}
 		}
 
+		// validate label and annotation flags
+		if labels, err = utils.ParseKeyValues(rawLabels); err != nil {
+			return fmt.Errorf("error validating label flag: %v", err)
+		}
+		if annotations, err = utils.ParseKeyValues(rawAnnotations); err != nil {
+			return fmt.Errorf("error validating annotation flag: %v", err)
+		}
+
+		// validate TLS flags
+		if (tlsCertFile == "") != (tlsKeyFile == "") {
+			return fmt.Errorf("--tls-cert and --tls-key must be provided together")
+		}
+
+		// validate health check flags
+		healthDisabled = nil
+		for _, raw := range rawHealthDisabled {
+			container, ok := strings.CutPrefix(raw, "container=")
+			if !ok || container == "" {
+				return fmt.Errorf("invalid --disable-health-check value %q, expected container=<name>", raw)
+			}
+			healthDisabled = append(healthDisabled, container)
+		}
+
+		// validate retry flags, the retry policy of the template applies unless overridden
+		retry = nil
+		if cmd.Flags().Changed("retries") || cmd.Flags().Changed("retry-backoff") {
+			if retries < 0 {
+				return fmt.Errorf("invalid --retries %d, must not be negative", retries)
+			}
+			if retryBackoff <= 0 {
+				return fmt.Errorf("invalid --retry-backoff %s, must be positive", retryBackoff)
+			}
+			retry = &aiservices.RetryPolicy{Attempts: retries, Backoff: retryBackoff}
+		}
+
+		if signaturePolicy != "" && !utils.FileExists(signaturePolicy) {
+			return fmt.Errorf("signature policy '%s' does not exist", signaturePolicy)
+		}
+
+		// validate preset flags
+		for _, name := range []string{presetName, savePresetName} {
+			if name != "" {
+				if err := presets.ValidateName(name); err != nil {
+					return err
+				}
+			}
+		}
+
 		// validate values files
 		for _, vf := range valuesFiles {
 			if !utils.FileExists(vf) {
@@ -76,13 +135,24 @@ var createCmd = &cobra.Command{
 
 		return nil
 	},
-	RunE: func(cmd *cobra.Command, args []string) error {
+	RunE: func(cmd *cobra.Command, args []string) (runErr error) {
 		appName := args[0]
 		ctx := context.Background()
 
 		// Once precheck passes, silence usage for any *later* internal errors.
 		cmd.SilenceUsage = true
 
+		if hostsTargeted() {
+			return runOnHosts(cmd, args)
+		}
+
+		hb, err := heartbeat.Start(heartbeatFile, "application create "+appName, 0)
+		if err != nil {
+			return err
+		}
+		defer func() { hb.Stop(runErr) }()
+		hb.Progress("validate")
+
 		skip := helpers.ParseSkipChecks(skipChecks)
 		if len(skip) > 0 {
 			logger.Warningf("Skipping validation checks (skipped: %v)\n", skipChecks)
@@ -90,7 +160,7 @@ var createCmd = &cobra.Command{
 
 		// Validate the LPAR before creating the application
 		logger.Infof("Validating the LPAR environment before creating application '%s'...\n", appName)
-		err := bootstrap.RunValidateCmd(skip)
+		err = bootstrap.RunValidateCmd(skip)
 		if err != nil {
 			return fmt.Errorf("bootstrap validation failed: %w", err)
 		}
@@ -101,116 +171,91 @@ var createCmd = &cobra.Command{
 			return fmt.Errorf("failed to connect to podman: %w", err)
 		}
 
-		// Proceed to create application
-		logger.Infof("Creating application '%s' using template '%s'\n", appName, templateName)
-
-		// set SMT level to target value, assuming it is running with root privileges (part of validation in bootstrap)
-		s := spinner.New("Checking SMT level")
-		s.Start(ctx)
-		err = setSMTLevel()
-		if err != nil {
-			s.Fail("failed to set SMT level")
-			return fmt.Errorf("failed to set SMT level: %w", err)
-		}
-		s.Stop("SMT level configured successfully")
-
-		tp := templates.NewEmbedTemplateProvider(templates.EmbedOptions{})
-
-		// validate whether the provided template name is correct
-		if err := validators.ValidateAppTemplateExist(tp, templateName); err != nil {
-			return err
-		}
-
-		tmpls, err := tp.LoadAllTemplates(templateName + "/templates")
-		if err != nil {
-			return fmt.Errorf("failed to parse the templates: %w", err)
-		}
-
-		// load metadata.yml to read the app metadata
-		appMetadata, err := tp.LoadMetadata(templateName)
-		if err != nil {
-			return fmt.Errorf("failed to read the app metadata: %w", err)
-		}
-
-		if err := verifyPodTemplateExists(tmpls, appMetadata); err != nil {
-			return fmt.Errorf("failed to verify pod template: %w", err)
-		}
-
-		// ---- Validate Spyre card Requirements ----
-
-		// calculate the required spyre cards of only those pods which are not deployed yet
-		reqSpyreCardsCount, err := calculateReqSpyreCards(runtime, tp, utils.ExtractMapKeys(tmpls), templateName, appName)
+		existingPods, err := helpers.CheckExistingPodsForApplication(runtime, appName)
 		if err != nil {
-			return fmt.Errorf("failed to calculateReqSpyreCards: %w", err)
+			return fmt.Errorf("failed while checking existing pods for application: %w", err)
 		}
 
-		var pciAddresses []string
-		if reqSpyreCardsCount > 0 {
-			// calculate the actual available spyre cards
-			pciAddresses, err = helpers.FindFreeSpyreCards()
+		// the values of the preset are applied beneath the values files and params
+		createValuesFiles := valuesFiles
+		if presetName != "" {
+			preset, err := presets.Load(presetName)
 			if err != nil {
-				return fmt.Errorf("failed to find free Spyre Cards: %w", err)
+				return err
 			}
-			actualSpyreCardsCount := len(pciAddresses)
-
-			// validate spyre card requirements
-			if err := validateSpyreCardRequirements(reqSpyreCardsCount, actualSpyreCardsCount); err != nil {
+			if preset.Template != templateName {
+				return fmt.Errorf("preset '%s' was saved for template '%s', not '%s'", presetName, preset.Template, templateName)
+			}
+			file, cleanup, err := preset.ValuesFile()
+			if err != nil {
 				return err
 			}
+			defer cleanup()
+			createValuesFiles = append([]string{file}, valuesFiles...)
+			logger.Infof("Applying the values of preset '%s'\n", presetName)
 		}
 
-		// ---- Download Container Images ----
-		if err := downloadImagesForTemplate(runtime, templateName, appName); err != nil {
+		// Proceed to create application
+		client, err := aiservices.NewClient(ctx)
+		if err != nil {
 			return err
 		}
-
-		// Download models if flag is set to true(default: true)
-		if !skipModelDownload {
-			s = spinner.New("Downloading models as part of application creation...")
-			s.Start(ctx)
-			models, err := helpers.ListModels(templateName, appName)
-			if err != nil {
-				s.Fail("failed to list models")
-				return err
-			}
-			logger.Infoln("Downloading models required for application template " + templateName + ":")
-			for _, model := range models {
-				s.UpdateMessage("Downloading model: " + model + "...")
-				err = utils.Retry(retryCount, retryInterval, nil, func() error {
-					return helpers.DownloadModel(model, vars.ModelDirectory)
-				})
-				if err != nil {
-					s.Fail("failed to download model: " + model)
-					return fmt.Errorf("failed to download model: %w", err)
-				}
+		result, err := client.Create(ctx, aiservices.CreateOptions{
+			Name:               appName,
+			Template:           templateName,
+			Environment:        environment,
+			ValuesFiles:        createValuesFiles,
+			Params:             argParams,
+			Labels:             labels,
+			Annotations:        annotations,
+			SkipImageDownload:  skipImageDownload,
+			SkipModelDownload:  skipModelDownload,
+			SkipSmokeTests:     skipSmokeTests,
+			AcceptModelLicense: acceptLicense,
+			SignaturePolicy:    signaturePolicy,
+			ScanImages:         scanImages || scanFailOn != "",
+			ScanFailOn:         scanFailOn,
+			ForceSMTLevel:      forceSMTLevel,
+			Force:              forceRecreate,
+			SkipResourceCheck:  skipResourceCheck,
+			AllowedHostPaths:   allowedHostPaths,
+			Health: aiservices.HealthOverrides{
+				ReadinessTimeout: readinessTimeout,
+				Interval:         healthInterval,
+				Disabled:         healthDisabled,
+			},
+			TLS: aiservices.TLSOptions{
+				// providing the certificate enables TLS
+				Enabled:  enableTLS || tlsCertFile != "",
+				CertFile: tlsCertFile,
+				KeyFile:  tlsKeyFile,
+				Hosts:    tlsHosts,
+			},
+			GenerateAPIKey: generateAPIKey,
+			Retry:          retry,
+			Progress: func(event aiservices.ProgressEvent) {
+				hb.Progress(fmt.Sprintf("%s: %s", event.Stage, event.Message))
+			},
+		})
+		if err != nil {
+			if result != nil {
+				printDeploymentResult(result)
 			}
-			s.Stop("Model download completed.")
+			return err
 		}
 
-		// ---- ! ----
-
-		// Loop through all pod templates, render and run kube play
-		logger.Infof("Total Pod Templates to be processed: %d\n", len(tmpls))
-
-		/*
-			Pod Execution Logic:
-			1. Check if pods already exists with the given application name
-			2. If doesn't exists, proceed to create all pods
-			3. Else, skip existing pods, and create missing pods
-		*/
-
-		existingPods, err := helpers.CheckExistingPodsForApplication(runtime, appName)
-		if err != nil {
-			return fmt.Errorf("failed while checking existing pods for application: %w", err)
+		// re-running create for an application already fully deployed doesn't change anything
+		if app, err := client.GetApplication(ctx, appName); err == nil {
+			if len(app.Pods) > len(existingPods) {
+				machine.MarkChanged()
+			}
+			machine.SetData(app)
 		}
 
-		s = spinner.New("Deploying application '" + appName + "'...")
-		s.Start(ctx)
-		// execute the pod Templates
-		if err := executePodTemplates(runtime, tp, appName, appMetadata, tmpls, pciAddresses, existingPods); err != nil {
-			return err
+		if err := savePreset(createValuesFiles); err != nil {
+			// do not want to fail the overall create if the preset cannot be saved
+			logger.Warningf("Failed to save the preset: %v\n", err)
 		}
-		s.Stop("Application '" + appName + "' deployed successfully")
 
 		logger.Infoln("-------")
 
@@ -225,61 +270,8 @@ var createCmd = &cobra.Command{
 	},
 }
 
-func downloadImagesForTemplate(runtime runtime.Runtime, templateName, appName string) error {
-	// Fetch all images required for a given template
-	images, err := helpers.ListImages(templateName, appName)
-	if err != nil {
-		return fmt.Errorf("failed to list container images: %w", err)
-	}
-
-	if !skipImageDownload {
-		// Download container images if flag is set to false (default: false)
-		logger.Infoln("Downloading container images required for application template " + templateName + ":")
-		for _, image := range images {
-			logger.Infoln("Downloading image: " + image + "...")
-			if err := utils.Retry(retryCount, retryInterval, nil, func() error {
-				return runtime.PullImage(image, nil)
-			}); err != nil {
-				return fmt.Errorf("failed to download image: %w", err)
-			}
-		}
-		logger.Infoln("Downloading container images completed.")
-	} else {
-		logger.Infoln("Skipping container image download as per the flag --skip-image-download=true")
-		// Verify that images exist locally
-		lImages, err := runtime.ListImages()
-		if err != nil {
-			return fmt.Errorf("failed to list local images: %w", err)
-		}
-		// Populate a map with all existing local images (tags and digests)
-		existingImages := make(map[string]bool)
-
-		for _, lImage := range lImages {
-			for _, tag := range lImage.RepoTags {
-				existingImages[tag] = true
-			}
-			for _, digest := range lImage.RepoDigests {
-				existingImages[digest] = true
-			}
-		}
-
-		// Filter the requested images against the map
-		var notfoundImages []string
-
-		for _, image := range images {
-			if !existingImages[image] {
-				notfoundImages = append(notfoundImages, image)
-			}
-		}
-		if len(notfoundImages) > 0 {
-			return fmt.Errorf("some required images are not present locally: %v. Either pull the image manually or rerun create command without --skip-image-download flag", notfoundImages)
-		}
-		logger.Infoln("All required container images are present locally.")
-	}
-	return nil
-}
-
 func init() {
+	addHostsFlags(createCmd)
 	createCmd.Flags().StringSliceVar(&skipChecks, "skip-validation", []string{},
 		"Skip specific validation checks (comma-separated: root,rhel,rhn,power,rhaiis,numa)")
 	createCmd.Flags().StringVarP(&templateName, "template", "t", "", "Application template to use (required)")
@@ -307,6 +299,36 @@ func init() {
 			"- If set to true and models are missing → command will fail\n"+
 			"- If left false in air-gapped environments → download attempt will fail\n",
 	)
+	createCmd.Flags().BoolVar(&enableTLS, "tls", false, "Enable TLS for the exposed services using a self-signed certificate, unless --tls-cert and --tls-key are provided")
+	createCmd.Flags().StringVar(&tlsCertFile, "tls-cert", "", "Path to the PEM encoded TLS certificate (implies --tls)")
+	createCmd.Flags().StringVar(&tlsKeyFile, "tls-key", "", "Path to the PEM encoded private key of the TLS certificate")
+	createCmd.Flags().StringSliceVar(&tlsHosts, "tls-hosts", []string{}, "DNS names and IP addresses the self-signed certificate is valid for (default: hostname and host IP)")
+	createCmd.Flags().BoolVar(&generateAPIKey, "api-key", false, "Generate an API key required by the serving endpoints. Keys are managed with 'ai-services apikey'")
+	createCmd.Flags().BoolVar(&skipSmokeTests, "skip-smoke-test", false, "Skip running the smoke tests declared by the application template once the application is deployed")
+	createCmd.Flags().BoolVar(&skipResourceCheck, "skip-resource-check", false, "Deploy even if the CPU and memory requests of the application exceed the available host capacity")
+	createCmd.Flags().StringArrayVar(&allowedHostPaths, "allow-host-path", []string{}, "Allow the template to bind mount a sensitive host path and the paths beneath, Eg:- /dev/infiniband. Repeatable")
+	createCmd.Flags().DurationVar(&readinessTimeout, "readiness-timeout", 0, "Readiness timeout of all the containers, overriding the timeouts of the template, Eg:- 45m")
+	createCmd.Flags().DurationVar(&healthInterval, "health-interval", 0, "Interval of the health checks of the containers, overriding the template, Eg:- 10s")
+	createCmd.Flags().StringSliceVar(&rawHealthDisabled, "disable-health-check", []string{}, "Remove the health check of a container, considered ready once running, Eg:- container=instruct. Repeatable")
+	createCmd.Flags().IntVar(&retries, "retries", 2, "Retries of the pods failing to deploy on a transient error, Eg:- an image pull timeout, overriding the template. 0 disables them")
+	createCmd.Flags().DurationVar(&retryBackoff, "retry-backoff", 10*time.Second, "Delay before the first retry of a pod, doubled at each retry, overriding the template")
+	createCmd.Flags().StringArrayVar(&rawLabels, "label", []string{}, "Label merged into all the pods of the application, Eg:- cost-center=ai-42. Repeatable")
+	createCmd.Flags().StringArrayVar(&rawAnnotations, "annotation", []string{}, "Annotation merged into all the pods of the application, Eg:- inventory.example.com/owner=team-a. Repeatable")
+	createCmd.Flags().BoolVar(&forceRecreate, "force", false, "Recreate the existing pods of the application whose definition changed since the last deployment, instead of skipping them.\n"+
+		"The unchanged pods are kept, a simpler alternative to an upgrade for the dev loops on a template")
+	createCmd.Flags().BoolVar(&forceSMTLevel, "force-smt", false, "Change the SMT level required by the template even if deployed applications require another SMT level, degrading them")
+	createCmd.Flags().StringVar(&signaturePolicy, "policy", "", "Path of a containers signature policy (policy.json) all the template images must satisfy, Eg:- signed by trusted keys.\n"+
+		"The signatures are verified against the registries, even with --skip-image-download")
+	createCmd.Flags().BoolVar(&scanImages, "scan", false, "Scan the images for vulnerabilities with the 'imageScan' scanner of the CLI config file before deploying")
+	createCmd.Flags().StringVar(&scanFailOn, "scan-fail-on", "", "Lowest severity of the vulnerabilities failing the deployment (unknown, low, medium, high, critical), implies --scan")
+	createCmd.Flags().BoolVar(&acceptLicense, "accept-license", false, "Accept the license of the gated models being downloaded (one-time acknowledgment per model)")
+	createCmd.Flags().StringVar(&environment, "environment", "", "Environment overlay of the template (values-<environment>.yaml), Eg:- dev or prod, merged beneath --values and --params.\n"+
+		"The environments of the templates are listed by 'application templates'")
+	createCmd.Flags().StringVar(&presetName, "preset", "", "Preset of values saved by a previous create with --save-preset, applied beneath --values and --params")
+	createCmd.Flags().StringVar(&savePresetName, "save-preset", "", "Save the values customized by this create as a named preset, Eg:- prod-8card, reusable with --preset.\n"+
+		"The presets are stored in the presets directory beneath the CLI config directory")
+	createCmd.Flags().StringVar(&heartbeatFile, "heartbeat-file", "", "File the status of the deployment is written to every 15s, for the supervisors to detect a hung deployment.\n"+
+		"The file records the last progress and the final outcome of the deployment")
 	createCmd.Flags().StringArrayVarP(
 		&valuesFiles,
 		"values",
@@ -335,466 +357,69 @@ func init() {
 	)
 }
 
-func getSMTLevel(output string) (int, error) {
-	out := strings.TrimSpace(output)
-
-	if !strings.HasPrefix(out, "SMT=") {
-		return 0, fmt.Errorf("unexpected output: %s", out)
-	}
-
-	SMTLevelStr := strings.TrimPrefix(out, "SMT=")
-	SMTlevel, err := strconv.Atoi(SMTLevelStr)
-	if err != nil {
-		return 0, fmt.Errorf("failed to parse SMT level: %w", err)
-	}
-	return SMTlevel, nil
-}
-
-func setSMTLevel() error {
-
-	/*
-		1. Fetch current SMT level
-		2. Fetch the target SMT level
-		3. Check if SMT level is already set to target value
-		4. If not, set it to target value
-		5. Verify again
-	*/
-
-	// 1. Fetch Current SMT level
-	cmd := exec.Command("ppc64_cpu", "--smt")
-	out, err := cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("failed to check current SMT level: %v, output: %s", err, string(out))
-	}
-
-	currentSMTlevel, err := getSMTLevel(string(out))
-	if err != nil {
-		return fmt.Errorf("failed to get current SMT level: %w", err)
-	}
-
-	// 2. Fetch the target SMT level
-	targetSMTLevel, err := getTargetSMTLevel()
-	if err != nil {
-		return fmt.Errorf("failed to get target SMT level: %w", err)
-	}
-
-	if targetSMTLevel == nil {
-		// No SMT level specified in metadata.yaml
-		logger.Infof("No SMT level specified in metadata.yaml. Keeping it to current level: %d\n", currentSMTlevel)
+// savePreset saves the values customized by the create as the --save-preset preset. Without --save-preset, an
+// interactive create customizing the values offers to save them.
+func savePreset(files []string) error {
+	if savePresetName == "" && (presetName != "" || !utils.Interactive()) {
 		return nil
 	}
 
-	// 3. Check if SMT level is already set to target value
-	if currentSMTlevel == *targetSMTLevel {
-		// already set
-		logger.Infof("SMT level is already set to %d\n", *targetSMTLevel)
-		return nil
-	}
-
-	// 4. Set SMT level to target value
-	arg := "--smt=" + strconv.Itoa(*targetSMTLevel)
-	cmd = exec.Command("ppc64_cpu", arg)
-	out, err = cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("failed to set SMT level: %v, output: %s", err, string(out))
-	}
-
-	// 5. Verify again
-	cmd = exec.Command("ppc64_cpu", "--smt")
-	out, err = cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("failed to verify SMT level: %v, output: %s", err, string(out))
-	}
-
-	currentSMTlevel, err = getSMTLevel(string(out))
-	if err != nil {
-		return fmt.Errorf("failed to get SMT level after updating: %w", err)
-	}
-
-	if currentSMTlevel != *targetSMTLevel {
-		return fmt.Errorf("SMT level verification failed: expected %d, got %d", targetSMTLevel, currentSMTlevel)
-	}
-	return nil
-}
-
-func getTargetSMTLevel() (*int, error) {
 	tp := templates.NewEmbedTemplateProvider(templates.EmbedOptions{})
-
-	// validate whether the provided template name is correct
-	if err := validators.ValidateAppTemplateExist(tp, templateName); err != nil {
-		return nil, err
-	}
-
-	// load metadata.yml to read the app metadata
-	appMetadata, err := tp.LoadMetadata(templateName)
+	defaults, err := tp.LoadValues(templateName, "", nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read the app metadata: %w", err)
+		return err
 	}
-
-	return appMetadata.SMTLevel, nil
-}
-
-func verifyPodTemplateExists(tmpls map[string]*template.Template, appMetadata *templates.AppMetadata) error {
-	flattenPodTemplateExecutions := utils.FlattenArray(appMetadata.PodTemplateExecutions)
-
-	if len(flattenPodTemplateExecutions) != len(tmpls) {
-		return errors.New("number of values specified in podTemplateExecutions under metadata.yml is mismatched. Please ensure all the pod template file names are specified")
-	}
-
-	// Make sure the podTemplateExecution mentioned in metadata.yaml is valid (corresponding pod template is present)
-	for _, podTemplate := range flattenPodTemplateExecutions {
-		if _, ok := tmpls[podTemplate]; !ok {
-			return fmt.Errorf("value: %s specified in podTemplateExecutions under metadata.yml is invalid. Please ensure corresponding template file exists", podTemplate)
-		}
-	}
-
-	return nil
-}
-
-func executePodTemplates(runtime runtime.Runtime, tp templates.Template, appName string, appMetadata *templates.AppMetadata,
-	tmpls map[string]*template.Template, pciAddresses []string, existingPods []string) error {
-	values, err := tp.LoadValues(templateName, valuesFiles, argParams)
+	resolved, err := tp.LoadValues(templateName, environment, files, argParams)
 	if err != nil {
-		return fmt.Errorf("failed to load params for application: %w", err)
+		return err
 	}
-	globalParams := map[string]any{
-		"AppName":         appName,
-		"AppTemplateName": appMetadata.Name,
-		"Version":         appMetadata.Version,
-		"Values":          values,
-		// Key -> container name
-		// Value -> range of key-value env pairs
-		"env": map[string]map[string]string{},
-	}
-
-	// looping over each layer of podTemplateExecutions
-	for i, layer := range appMetadata.PodTemplateExecutions {
-		logger.Infof("\n Executing Layer %d: %v\n", i+1, layer)
-		logger.Infoln("-------")
-		var wg sync.WaitGroup
-		errCh := make(chan error, len(layer))
-
-		// for each layer, fetch all the pod Template Names and do the pod deploy
-		for _, podTemplateName := range layer {
-			wg.Add(1)
-			go func(t string) {
-				defer wg.Done()
-				logger.Infof("Processing template: %s...\n", podTemplateName)
-
-				// Shallow Copy globalParams Map
-				params := utils.CopyMap(globalParams)
-
-				// fetch pod Spec
-				podSpec, err := fetchPodSpec(tp, templateName, podTemplateName, appName)
-				if err != nil {
-					errCh <- err
-				}
+	changed := presets.Changed(defaults, resolved)
 
-				if slices.Contains(existingPods, podSpec.Name) {
-					logger.Infof("Skipping pod: %s as it already exists", podSpec.Name)
-					return
-				}
-
-				// fetch annotations from pod Spec
-				podAnnotations := fetchPodAnnotations(podSpec)
-
-				// get the env params for a given pod
-				env, err := returnEnvParamsForPod(podSpec, podAnnotations, &pciAddresses)
-				if err != nil {
-					errCh <- err
-				}
-				params["env"] = env
-
-				podTemplate := tmpls[podTemplateName]
-
-				var rendered bytes.Buffer
-				if err := podTemplate.Execute(&rendered, params); err != nil {
-					errCh <- err
-				}
-
-				// Wrap the bytes in a bytes.Reader
-				reader := bytes.NewReader(rendered.Bytes())
-
-				// Deploy the Pod and do Readiness check
-				if err := deployPodAndReadinessCheck(runtime, podTemplateName, reader, constructPodDeployOptions(podAnnotations)); err != nil {
-					errCh <- err
-				}
-			}(podTemplateName)
-		}
-
-		wg.Wait()
-		close(errCh)
-
-		// collect all errors for this layer
-		var errs []error
-		for e := range errCh {
-			errs = append(errs, fmt.Errorf("layer %d: %w", i+1, e))
+	name := savePresetName
+	if name == "" {
+		if len(changed) == 0 {
+			return nil
 		}
-
-		// If an error exist for a given layer, then return (do not process further layers)
-		if len(errs) > 0 {
-			return errors.Join(errs...)
+		if name, err = utils.PromptInput("Save the values as a preset for later creates? Enter a preset name, or leave empty to skip"); err != nil || name == "" {
+			return err
 		}
-
-		logger.Infof("Layer %d completed\n", i+1)
-	}
-
-	return nil
-}
-
-func deployPodAndReadinessCheck(runtime runtime.Runtime, name string, body io.Reader, opts map[string]string) error {
-
-	kubeReport, err := podman.RunPodmanKubePlay(body, opts)
-	if err != nil {
-		return fmt.Errorf("failed pod creation: %w", err)
-	}
-
-	logger.Infof("Successfully ran podman kube play for %s\n", name)
-
-	for _, pod := range kubeReport.Pods {
-		logger.Infof("Performing Pod Readiness check...: %s\n", pod.ID)
-		for _, container := range pod.Containers {
-			logger.Infof("Doing Container Readiness check...: %s\n", container.ID)
-
-			// getting the Start Period set for a container
-			startPeriod, err := helpers.FetchContainerStartPeriod(runtime, container.ID)
-			if err != nil {
-				return fmt.Errorf("fetching container start period failed: %w", err)
-			}
-
-			if startPeriod == -1 {
-				logger.Infoln("No container health check is set. Hence skipping readiness check")
-				continue
-			}
-
-			// configure readiness timeout by appending start period with additional extra timeout
-			readinessTimeout := startPeriod + extraContainerReadinessTimeout
-
-			logger.Infof("Setting the Waiting Readiness Timeout: %s\n", readinessTimeout)
-
-			if err := helpers.WaitForContainerReadiness(runtime, container.ID, readinessTimeout); err != nil {
-				return fmt.Errorf("readiness check failed!: %w", err)
-			}
-			logger.Infof("Container: %s is ready\n", container.ID)
-			logger.Infoln("-------")
+		if err := presets.ValidateName(name); err != nil {
+			return err
 		}
-		logger.Infof("Pod: %s has been successfully deployed and ready!\n", pod.ID)
-		logger.Infoln("-------")
 	}
 
-	logger.Infoln("-------\n-------")
-	return nil
-}
-
-func validateSpyreCardRequirements(req int, actual int) error {
-	if actual < req {
-		return fmt.Errorf("insufficient spyre cards. Require: %d spyre cards to proceed", req)
+	if err := presets.Save(&presets.Preset{
+		Name:        name,
+		Template:    templateName,
+		Created:     time.Now().UTC(),
+		Environment: environment,
+		Values:      changed,
+	}); err != nil {
+		return err
 	}
+	logger.Infof("Values saved as preset '%s', reuse them with 'create --template %s --preset %s'\n", name, templateName, name)
 	return nil
 }
 
-func calculateReqSpyreCards(client *podman.PodmanClient, tp templates.Template, podTemplateFileNames []string, appTemplateName, appName string) (int, error) {
-	totalReqSpyreCounts := 0
-
-	// Calculate Req Spyre Counts
-	for _, podTemplateFileName := range podTemplateFileNames {
-		// fetch pod spec
-		podSpec, err := fetchPodSpec(tp, appTemplateName, podTemplateFileName, appName)
-		if err != nil {
-			return totalReqSpyreCounts, fmt.Errorf("failed to load pod Template: '%s' for appTemplate: '%s' with error: %w", podTemplateFileName, appTemplateName, err)
-		}
-
-		// check if pod already exists and skip counting if it does exists
-		exists, err := client.PodExists(podSpec.Name)
-		if err != nil {
-			return totalReqSpyreCounts, fmt.Errorf("failed to check pod status: %w", err)
-		}
-
-		if exists {
-			logger.Infof("Pod %s already exists, skipping spyre cards calculation", podSpec.Name, 2)
-			continue
-		}
-
-		// fetch the spyreCount for all containers from the annotations
-		spyreCount, _, err := fetchSpyreCardsFromPodAnnotations(podSpec.Annotations)
-		if err != nil {
-			return totalReqSpyreCounts, err
-		}
-
-		totalReqSpyreCounts += spyreCount
-	}
-
-	return totalReqSpyreCounts, nil
-}
-
-func fetchSpyreCardsFromPodAnnotations(annotations map[string]string) (int, map[string]int, error) {
-	var spyreCards int
-	// spyreCardContainerMap: Key -> containerName, Value -> SpyreCardCounts
-	spyreCardContainerMap := map[string]int{}
-
-	isSpyreCardAnnotation := func(annotation string) (string, bool) {
-		matches := vars.SpyreCardAnnotationRegex.FindStringSubmatch(annotation)
-		if matches == nil {
-			return "", false
-		}
-		return matches[1], true
-	}
-
-	for annotationKey, val := range annotations {
-		if containerName, ok := isSpyreCardAnnotation(annotationKey); ok {
-			valInt, err := strconv.Atoi(val)
-			if err != nil {
-				return 0, spyreCardContainerMap, fmt.Errorf("failed to convert to int. Provided val: %s is not of int type", val)
+// printDeploymentResult prints the status of the pods of a failed deployment, the pods not deployed included
+func printDeploymentResult(result *aiservices.DeploymentResult) {
+	if len(result.Pods) == 0 {
+		return
+	}
+	p := utils.NewTableWriter()
+	p.SetHeaders("LAYER", "POD TEMPLATE", "POD", "STATUS", "ATTEMPTS", "ERROR")
+	for _, pod := range result.Pods {
+		attempts := ""
+		if pod.Attempts > 0 {
+			attempts = fmt.Sprintf("%d", pod.Attempts)
+		}
+		podErr := pod.Error
+		for _, c := range pod.Containers {
+			if c.Error != "" && podErr == "" {
+				podErr = fmt.Sprintf("%s: %s", c.Name, c.Error)
 			}
-			// Replace with container name
-			spyreCardContainerMap[containerName] = valInt
-			spyreCards += valInt
-		}
-	}
-
-	return spyreCards, spyreCardContainerMap, nil
-}
-
-func fetchPodSpec(tp templates.Template, appTemplateName, podTemplateFileName, appName string) (*models.PodSpec, error) {
-	podSpec, err := tp.LoadPodTemplateWithValues(appTemplateName, podTemplateFileName, appName, valuesFiles, argParams)
-	if err != nil {
-		return nil, fmt.Errorf("failed to load pod Template: '%s' for appTemplate: '%s' with error: %w", podTemplateFileName, appTemplateName, err)
-	}
-
-	return podSpec, nil
-}
-
-func fetchPodAnnotations(podSpec *models.PodSpec) map[string]string {
-	return specs.FetchPodAnnotations(*podSpec)
-}
-
-func returnEnvParamsForPod(podSpec *models.PodSpec, podAnnotations map[string]string, pciAddresses *[]string) (map[string]map[string]string, error) {
-
-	env := map[string]map[string]string{}
-	podContainerNames := specs.FetchContainerNames(*podSpec)
-
-	// populate env with empty map
-	for _, containerName := range podContainerNames {
-		env[containerName] = map[string]string{}
-	}
-
-	// fetch the spyre cards and spyre card count required for each container in a pod
-	spyreCards, spyreCardContainerMap, err := fetchSpyreCardsFromPodAnnotations(podAnnotations)
-	if err != nil {
-		return env, err
-	}
-
-	if spyreCards == 0 {
-		// The pod doesnt require any spyre cards. // populate the given container with empty map
-		return env, nil
-	}
-
-	// Construct env for a given pod
-	// Since this is a critical section as both requires pciAddresses and modifies -> wrap it in mutex
-	envMutex.Lock()
-	for container, spyreCount := range spyreCardContainerMap {
-		if spyreCount != 0 {
-			env[container] = map[string]string{string(constants.PCIAddressKey): utils.JoinAndRemove(pciAddresses, spyreCount, " ")}
-		}
-	}
-	envMutex.Unlock()
-
-	return env, nil
-}
-
-func checkForPodStartAnnotation(podAnnotations map[string]string) string {
-	if val, ok := podAnnotations[constants.PodStartAnnotationkey]; ok {
-		if val == constants.PodStartOff || val == constants.PodStartOn {
-			return val
 		}
+		p.AppendRow(fmt.Sprintf("%d", pod.Layer), pod.PodTemplate, pod.Pod, pod.Status, attempts, podErr)
 	}
-	return ""
-}
-
-// fetchHostPortMappingFromAnnotation returns the hostPortMappings from the pod port annotations for a given pod template
-// Returns:
-//
-//	hostPortMapping: Key -> containerPort, Value -> hostPort
-//
-// port annotation takes comma seperated values of 'hostPort:containerPort' combination
-// port annotation syntax: 'ai-services.io/ports': "<hostPart1>:<containerPort1>,<hostPart2>:<containerPort2>"
-//
-// Below are the hostPortMapping values based on different combinations
-//  1. 'ai-services.io/ports': "8000:3000"
-//     hostPortMapping = {"3000": "8000"}
-//  2. 'ai-services.io/ports': "8000:3000, 8001:3001"
-//     hostPortMapping = {"3000": "8000", "3001": "8001"}
-//  3. 'ai-services.io/ports': ":3000"
-//     hostPortMapping = {"3000": ""}
-//  4. 'ai-services.io/ports': "3000:"
-//     hostPortMapping = {} // Skip such values
-//  5. 'ai-services.io/ports': "3000"
-//     hostPortMapping = {"3000": ""}
-func fetchHostPortMappingFromAnnotation(podAnnotations map[string]string) map[string]string {
-	// key -> containerPort and value -> hostPort
-	hostPortMapping := map[string]string{}
-
-	portMappings, ok := podAnnotations[constants.PodPortsAnnotationKey]
-	if !ok {
-		// return empty map if port annotation is not present
-		return hostPortMapping
-	}
-
-	portMapping := strings.SplitSeq(portMappings, ",")
-	for p := range portMapping {
-		p = strings.TrimSpace(p)
-		if p == "" {
-			continue
-		}
-
-		// Find colon
-		i := strings.Index(p, ":")
-		if i == -1 {
-			// No colon → whole thing is the containerPort
-			hostPortMapping[p] = ""
-			continue
-		}
-
-		// Before colon string is hostPort
-		hostPort := strings.TrimSpace(p[:i])
-		// After colon string is containerPort
-		containerPort := strings.TrimSpace(p[i+1:])
-
-		// If colon exists but NO value after the colon (containerPort) → then skip
-		if containerPort == "" {
-			continue
-		}
-
-		hostPortMapping[containerPort] = hostPort
-	}
-
-	return hostPortMapping
-}
-
-func constructPodDeployOptions(podAnnotations map[string]string) map[string]string {
-	podStart := checkForPodStartAnnotation(podAnnotations)
-
-	// construct start option
-	podDeployOptions := map[string]string{}
-	if podStart != "" {
-		podDeployOptions["start"] = podStart
-	}
-
-	// construct publish option
-	hostPortMappings := fetchHostPortMappingFromAnnotation(podAnnotations)
-	podDeployOptions["publish"] = ""
-
-	// loop over each of the hostPortMappings to construct the 'publish' option
-	for containerPort, hostPort := range hostPortMappings {
-		if hostPort != "" {
-			// if the host port is present
-			podDeployOptions["publish"] += hostPort + ":" + containerPort
-		} else {
-			// else just populate the containerPort, so that dynamically podman will populate
-			podDeployOptions["publish"] += containerPort
-		}
-		podDeployOptions["publish"] += ","
-	}
-
-	return podDeployOptions
+	p.CloseTableWriter()
 }
@@ -1,15 +1,26 @@
 package application
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
-	"github.com/containers/podman/v5/pkg/domain/entities/types"
 	"github.com/spf13/cobra"
 
+	"github.com/project-ai-services/ai-services/internal/pkg/i18n"
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
-	"github.com/project-ai-services/ai-services/internal/pkg/runtime/podman"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+	"github.com/project-ai-services/ai-services/internal/pkg/specs"
 	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+	"github.com/project-ai-services/ai-services/pkg/aiservices"
+)
+
+var (
+	keepSMTLevel bool
+	purge        bool
+	gracePeriod  time.Duration
 )
 
 var deleteCmd = &cobra.Command{
@@ -17,9 +28,26 @@ var deleteCmd = &cobra.Command{
 	Short: "Delete an application",
 	Long: `Deletes an application and all associated resources.
 
+Once the last application requiring an SMT level is deleted, the SMT level the host had before the first
+deployment is restored, unless --keep-smt is provided.
+
+The volumes of the PersistentVolumeClaims of the application are kept to preserve its data, unless --purge is
+provided. The ConfigMaps are not persisted by podman, they are gone with the pods.
+
+The containers are given --grace-period to exit on SIGTERM before they are killed. A pod failing to delete is
+removed by force with retries, then its containers are removed by force one by one. The pods still failing to
+delete are reported with the stage they failed at, the containers left behind and their mounts still mounted on the
+host, which usually hold the pod.
+
 Arguments
   [name]: Application name (required)`,
 	Args: cobra.ExactArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if gracePeriod <= 0 {
+			return fmt.Errorf("invalid --grace-period %s, must be positive", gracePeriod)
+		}
+		return nil
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		applicationName := args[0]
 
@@ -27,12 +55,12 @@ Arguments
 		cmd.SilenceUsage = true
 
 		// podman connectivity
-		runtimeClient, err := podman.NewPodmanClient()
+		client, err := aiservices.NewClient(context.Background())
 		if err != nil {
-			return fmt.Errorf("failed to connect to podman: %w", err)
+			return err
 		}
 
-		err = deleteApplication(runtimeClient, applicationName)
+		err = deleteApplication(client, applicationName)
 		if err != nil {
 			return fmt.Errorf("failed to delete application: %w", err)
 		}
@@ -42,33 +70,40 @@ Arguments
 	},
 }
 
-func deleteApplication(client *podman.PodmanClient, appName string) error {
-	resp, err := client.ListPods(map[string][]string{
-		"label": {fmt.Sprintf("ai-services.io/application=%s", appName)},
-	})
-	if err != nil {
-		return fmt.Errorf("failed to list pods: %w", err)
-	}
-
-	// TODO: Avoid doing the type assertion and importing types package from podman
-
-	var pods []*types.ListPodsReport
-	if val, ok := resp.([]*types.ListPodsReport); ok {
-		pods = val
-	}
+func init() {
+	deleteCmd.Flags().BoolVar(&keepSMTLevel, "keep-smt", false, "Keep the SMT level of the host instead of restoring the original SMT level")
+	deleteCmd.Flags().BoolVar(&purge, "purge", false, "Remove the volumes of the claims of the application too")
+	deleteCmd.Flags().DurationVar(&gracePeriod, "grace-period", aiservices.DefaultGracePeriod, "Time the containers are given to exit on SIGTERM before they are killed")
+}
 
-	if len(pods) == 0 {
+func deleteApplication(client *aiservices.Client, appName string) error {
+	app, err := client.GetApplication(context.Background(), appName)
+	if errors.Is(err, aiservices.ErrApplicationNotFound) {
 		logger.Infof("No pods found with given application: %s\n", appName)
 		return nil
 	}
+	if err != nil {
+		return err
+	}
 
-	logger.Infof("Found %d pods for given applicationName: %s.\n", len(pods), appName)
+	logger.Infof("Found %d pods for given applicationName: %s.\n", len(app.Pods), appName)
 	logger.Infoln("Below are the list of pods to be deleted")
-	for _, pod := range pods {
+	for _, pod := range app.Pods {
 		logger.Infof("\t-> %s\n", pod.Name)
 	}
+	if purge {
+		objects, err := client.ApplicationObjects(context.Background(), appName)
+		if err != nil {
+			logger.Warningf("failed to list the objects of the application: %v\n", err)
+		}
+		for _, obj := range objects {
+			if obj.Kind == specs.KindPersistentVolumeClaim && obj.Exists {
+				logger.Infof("\t-> volume %s\n", obj.Name)
+			}
+		}
+	}
 
-	confirmDelete, err := utils.ConfirmAction("Are you sure you want to delete above pods? ")
+	confirmDelete, err := utils.ConfirmAction(i18n.T("prompt.delete"))
 	if err != nil {
 		return fmt.Errorf("failed to take user input: %w", err)
 	}
@@ -80,22 +115,28 @@ func deleteApplication(client *podman.PodmanClient, appName string) error {
 
 	logger.Infof("Proceeding with deletion...\n")
 
-	// Loop over each of the pods and call delete
-	var errors []string
-	for _, pod := range pods {
-		logger.Infof("Deleting the pod: %s\n", pod.Name)
-		if err := client.DeletePod(pod.Id, utils.BoolPtr(true)); err != nil {
-			errMsg := fmt.Sprintf("%s: %v", pod.Name, err)
-			errors = append(errors, errMsg)
-			continue
-		}
-		logger.Infof("Successfully removed the pod: %s\n", pod.Name)
+	err = client.DeleteApplication(context.Background(), appName, aiservices.DeleteOptions{KeepSMTLevel: keepSMTLevel, Purge: purge, GracePeriod: gracePeriod})
+	machine.MarkChanged()
+	var deleteErr *aiservices.DeleteError
+	if errors.As(err, &deleteErr) {
+		machine.SetData(deleteErr)
+		printDeleteFailures(deleteErr)
+		return fmt.Errorf("%d pod(s) could not be deleted", len(deleteErr.Failures))
 	}
-
-	// Aggregate errors at the end
-	if len(errors) > 0 {
-		return fmt.Errorf("failed to remove pods: \n%s", strings.Join(errors, "\n"))
+	machine.SetData(app)
+	if err != nil {
+		return fmt.Errorf("failed to remove pods: \n%w", err)
 	}
+	logger.Infof("Successfully removed the application: %s\n", appName)
 
 	return nil
 }
+
+func printDeleteFailures(e *aiservices.DeleteError) {
+	p := utils.NewTableWriter()
+	defer p.CloseTableWriter()
+	p.SetHeaders("POD", "STAGE", "ATTEMPTS", "REASON", "CONTAINERS LEFT", "STUCK MOUNTS")
+	for _, f := range e.Failures {
+		p.AppendRow(f.Pod, f.Stage, fmt.Sprint(f.Attempts), f.Reason, strings.Join(f.Containers, ", "), strings.Join(f.Mounts, ", "))
+	}
+}
@@ -0,0 +1,113 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+	"github.com/project-ai-services/ai-services/pkg/aiservices"
+)
+
+var (
+	diffRevision    int
+	diffTemplate    string
+	diffValuesFiles []string
+	diffEnvironment string
+	diffRawParams   []string
+	diffParams      map[string]string
+	diffOutput      string
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff [name]",
+	Short: "Show the changes to the deployed manifests of an application",
+	Long: `Shows a unified diff between the manifests and values of the deployed revision of an application and either:
+  - a render of the template with the provided --values and --params, as an upgrade would deploy them (default)
+  - a historical revision, with --revision, as a rollback would deploy them
+
+The application is not changed. The Spyre cards, pinned cores and secrets of the deployed revision are kept in
+the render, so that only the changes of the template and values show up.
+
+Arguments
+  [name]: Application name (required)`,
+	Example: `  ai-services application diff my-app --params ui.port=3001
+  ai-services application diff my-app -f custom.yaml
+  ai-services application diff my-app --revision 2`,
+	Args: cobra.ExactArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if diffOutput != "" && diffOutput != "json" {
+			return fmt.Errorf("unsupported output format %q, supported formats: json", diffOutput)
+		}
+		if diffRevision != 0 && (diffTemplate != "" || diffEnvironment != "" || len(diffValuesFiles) > 0 || len(diffRawParams) > 0) {
+			return fmt.Errorf("--revision cannot be combined with --template, --environment, --values or --params")
+		}
+
+		var err error
+		if len(diffRawParams) > 0 {
+			diffParams, err = utils.ParseKeyValues(diffRawParams)
+			if err != nil {
+				return fmt.Errorf("error validating params flag: %v", err)
+			}
+		}
+		for _, vf := range diffValuesFiles {
+			if !utils.FileExists(vf) {
+				return fmt.Errorf("values file '%s' does not exist", vf)
+			}
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		applicationName := args[0]
+
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		client, err := aiservices.NewClient(context.Background())
+		if err != nil {
+			return err
+		}
+
+		diff, err := client.Diff(context.Background(), applicationName, aiservices.DiffOptions{
+			Revision:    diffRevision,
+			Template:    diffTemplate,
+			Environment: diffEnvironment,
+			ValuesFiles: diffValuesFiles,
+			Params:      diffParams,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to diff application: %w", err)
+		}
+		machine.SetData(diff)
+
+		if diffOutput == "json" {
+			out, err := json.MarshalIndent(diff, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal diff: %w", err)
+			}
+			fmt.Println(string(out))
+			return nil
+		}
+
+		if diff.Empty() {
+			logger.Infof("No changes to application %s compared to %s\n", applicationName, diff.Compared)
+			return nil
+		}
+		fmt.Print(diff.String())
+
+		return nil
+	},
+}
+
+func init() {
+	diffCmd.Flags().IntVar(&diffRevision, "revision", 0, "Revision to compare the deployed manifests with (see 'application history')")
+	diffCmd.Flags().StringVarP(&diffTemplate, "template", "t", "", "Application template to render (default: template of the deployed revision)")
+	diffCmd.Flags().StringVar(&diffEnvironment, "environment", "", "Environment overlay of the template (values-<environment>.yaml) of the render, merged beneath --values and --params")
+	diffCmd.Flags().StringArrayVarP(&diffValuesFiles, "values", "f", []string{}, "values.yaml files overriding the default template values of the render, later files override earlier ones")
+	diffCmd.Flags().StringSliceVar(&diffRawParams, "params", []string{}, "Comma-separated key=value pairs overriding the template values of the render, taking precedence over --values")
+	diffCmd.Flags().StringVarP(&diffOutput, "output", "o", "", "Output format (json)")
+}
@@ -0,0 +1,76 @@
+package application
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/helpers"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/podman"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+)
+
+var endpointsCmd = &cobra.Command{
+	Use:   "endpoints [name]",
+	Short: "Lists the endpoints exposed by all or specified application(s)",
+	Long: `Lists the HTTP/gRPC endpoints exposed by the application pods along with their readiness.
+
+Endpoints are derived from the 'ai-services.io/endpoints' pod annotation, if present,
+otherwise from the published ports. Endpoints which are not published on the host are
+reachable only from the podman network and are marked as internal, the endpoints published
+on the loopback interface only are marked as local. The host ports assigned by ai-services
+to the ports the template declares 'auto' are marked as assigned.
+
+Arguments
+  [name]: Application name (optional)
+`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		var applicationName string
+		if len(args) > 0 {
+			applicationName = args[0]
+		}
+
+		// podman connectivity
+		runtimeClient, err := podman.NewPodmanClient()
+		if err != nil {
+			return fmt.Errorf("failed to connect to podman: %w", err)
+		}
+
+		endpoints, err := helpers.ListEndpoints(runtimeClient, applicationName)
+		if err != nil {
+			return fmt.Errorf("failed to fetch endpoints: %w", err)
+		}
+		machine.SetData(endpoints)
+
+		if len(endpoints) == 0 {
+			logger.Infoln("No endpoints found")
+			return nil
+		}
+
+		p := utils.NewTableWriter()
+		defer p.CloseTableWriter()
+		p.SetHeaders("APPLICATION NAME", "POD NAME", "ENDPOINT", "PROTOCOL", "URL", "READY")
+
+		for _, ep := range endpoints {
+			url := ep.URL
+			if url == "" {
+				url = "--"
+			} else if ep.Internal {
+				url += " (internal)"
+			} else if ep.Local {
+				url += " (local)"
+			} else if ep.Assigned {
+				url += " (assigned)"
+			}
+			p.AppendRow(ep.Application, ep.Pod, ep.Name, ep.Protocol, url, fmt.Sprintf("%v", ep.Ready))
+		}
+
+		return nil
+	},
+}
@@ -0,0 +1,174 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/audit"
+	"github.com/project-ai-services/ai-services/internal/pkg/hosts"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/podman"
+	"github.com/project-ai-services/ai-services/pkg/aiservices"
+)
+
+var (
+	failoverLocal    bool
+	failoverSkipSync bool
+)
+
+var failoverCmd = &cobra.Command{
+	Use:   "failover [name]",
+	Short: "Promotes the warm standby of an application",
+	Long: `Promotes the warm standby of the application, enabled with 'application standby enable'.
+
+Run on the active host, the failover is planned:
+  1. stops the application on this host
+  2. synchronizes the volumes to the standby host a last time, unless --skip-sync, so that no write is lost.
+     The application is restarted on this host if the synchronization fails
+  3. starts the application on the standby host, restarting it on this host if the standby fails to start
+  4. moves the gateway routes of the application to the gateway of the standby host
+
+Run on the standby host with --local, once the active host is down, the application is started on this host. The
+clients and the routes of the gateway are then to be pointed to this host.
+
+Once promoted, enable a new standby from the promoted host to restore the redundancy.
+
+Arguments
+  [name]: Application name (required)`,
+	Example: `  # Planned failover, from the active host
+  ai-services application failover rag
+
+  # The active host is down, from the standby host
+  ai-services application failover rag --local`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		applicationName := args[0]
+
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		runtimeClient, err := podman.NewPodmanClient()
+		if err != nil {
+			return fmt.Errorf("failed to connect to podman: %w", err)
+		}
+		ctx := context.Background()
+		client, err := aiservices.NewClient(ctx)
+		if err != nil {
+			return err
+		}
+
+		if failoverLocal {
+			return promoteLocal(ctx, client, runtimeClient, applicationName)
+		}
+
+		pair, err := aiservices.GetStandby(applicationName)
+		if err != nil {
+			return err
+		}
+		if pair.State == aiservices.StandbyPromoted {
+			return fmt.Errorf("the standby of application '%s' on %s is already promoted", applicationName, pair.Host)
+		}
+		target, err := standbyTarget(pair.Host)
+		if err != nil {
+			return err
+		}
+		export, err := client.ExportApplication(ctx, applicationName)
+		if err != nil {
+			return fmt.Errorf("failed to export application: %w", err)
+		}
+		app, err := client.GetApplication(ctx, applicationName)
+		if err != nil {
+			return err
+		}
+
+		// the application is stopped before the last synchronization, so that its writes are not lost
+		logger.Infof("Stopping application %s on this host...\n", applicationName)
+		for _, pod := range app.Pods {
+			if err := runtimeClient.StopPod(pod.ID); err != nil {
+				restartPods(runtimeClient, app)
+				return fmt.Errorf("failed to stop pod %s on this host: %w", pod.Name, err)
+			}
+		}
+		machine.MarkChanged()
+
+		if !failoverSkipSync {
+			if err := syncStandby(ctx, client, pair, target); err != nil {
+				logger.Warningf("the standby failed to synchronize, restarting application %s on this host\n", applicationName)
+				restartPods(runtimeClient, app)
+				return fmt.Errorf("failed to synchronize the standby, use --skip-sync to fail over without synchronizing: %w", err)
+			}
+		}
+
+		logger.Infof("Starting application %s on %s...\n", applicationName, pair.Host)
+		if err := runOnTarget(ctx, target, hosts.Command{Args: []string{"application", "start", applicationName, "--skip-logs"}}); err != nil {
+			logger.Warningf("the standby failed to start, restarting application %s on this host\n", applicationName)
+			restartPods(runtimeClient, app)
+			return fmt.Errorf("failed to start the standby on %s: %w", pair.Host, err)
+		}
+
+		if err := cutoverRoutes(ctx, runtimeClient, target, export.Routes); err != nil {
+			return err
+		}
+
+		if err := aiservices.UpdateStandby(applicationName, func(p *aiservices.StandbyPair) {
+			p.State, p.Promoted = aiservices.StandbyPromoted, time.Now().UTC()
+			*pair = *p
+		}); err != nil {
+			logger.Warningf("failed to record the promotion of the standby: %v\n", err)
+		}
+		if err := audit.Record(audit.Entry{Application: applicationName, Action: "failover", Details: "to " + pair.Host}); err != nil {
+			logger.Warningf("failed to record the failover in the audit history: %v\n", err)
+		}
+		machine.SetData(pair)
+
+		logger.Infof("Application %s failed over to %s\n", applicationName, pair.Host)
+		if export.APIKeys {
+			logger.Warningf("the clients of application %s require the API key generated on %s\n", applicationName, pair.Host)
+		}
+		return nil
+	},
+}
+
+// restartPods starts the pods of the application again on this host, once the failover failed
+func restartPods(runtimeClient *podman.PodmanClient, app *aiservices.Application) {
+	for _, pod := range app.Pods {
+		if err := runtimeClient.StartPod(pod.ID); err != nil {
+			logger.Warningf("failed to restart pod %s on this host: %v\n", pod.Name, err)
+		}
+	}
+}
+
+// promoteLocal starts the standby application on this host, the active host being down
+func promoteLocal(ctx context.Context, client *aiservices.Client, runtimeClient *podman.PodmanClient, appName string) error {
+	app, err := client.GetApplication(ctx, appName)
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("Starting application %s on this host...\n", appName)
+	for _, pod := range app.Pods {
+		if err := runtimeClient.StartPod(pod.ID); err != nil {
+			return fmt.Errorf("failed to start pod %s: %w", pod.Name, err)
+		}
+	}
+	machine.MarkChanged()
+
+	if err := audit.Record(audit.Entry{Application: appName, Action: "failover", Details: "to this host"}); err != nil {
+		logger.Warningf("failed to record the failover in the audit history: %v\n", err)
+	}
+	machine.SetData(app)
+
+	logger.Infof("Application %s promoted on this host\n", appName)
+	logger.Warningf("point the clients and the gateway routes of application %s to this host\n", appName)
+	return nil
+}
+
+func init() {
+	failoverCmd.Flags().BoolVar(&failoverLocal, "local", false, "Start the standby on this host, the active host being down")
+	failoverCmd.Flags().BoolVar(&failoverSkipSync, "skip-sync", false, "Fail over without synchronizing the volumes to the standby host a last time")
+	failoverCmd.Flags().StringVar(&standbyInventory, "inventory", "", "Path of the hosts inventory (default: $"+hosts.InventoryEnv+" or "+hosts.DefaultInventory+")")
+}
@@ -0,0 +1,92 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+	"github.com/project-ai-services/ai-services/pkg/aiservices"
+)
+
+var historyOutput string
+
+var historyCmd = &cobra.Command{
+	Use:   "history [name]",
+	Short: "List the revisions of an application",
+	Long: `Lists the revisions of an application, oldest first, with their template version, the changes of the
+template values compared to the previous revision, and their outcome:
+  deployed:    deployed by create
+  rolled-back: deployed by rollback, from the listed revision
+  failed:      the deployment failed
+
+Arguments
+  [name]: Application name (required)`,
+	Example: `  ai-services application history my-app
+  ai-services application history my-app --output json`,
+	Args: cobra.ExactArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if historyOutput != "" && historyOutput != "json" {
+			return fmt.Errorf("unsupported output format %q, supported formats: json", historyOutput)
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		applicationName := args[0]
+
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		client, err := aiservices.NewClient(context.Background())
+		if err != nil {
+			return err
+		}
+
+		revisions, err := client.History(context.Background(), applicationName)
+		if err != nil {
+			return fmt.Errorf("failed to list revisions: %w", err)
+		}
+		machine.SetData(revisions)
+
+		if historyOutput == "json" {
+			out, err := json.MarshalIndent(revisions, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal revisions: %w", err)
+			}
+			fmt.Println(string(out))
+			return nil
+		}
+
+		if len(revisions) == 0 {
+			logger.Infof("No revisions recorded for application: %s\n", applicationName)
+			return nil
+		}
+
+		p := utils.NewTableWriter()
+		defer p.CloseTableWriter()
+		p.SetHeaders("REVISION", "DEPLOYED", "TEMPLATE", "VERSION", "OUTCOME", "VALUES CHANGED")
+		for _, rev := range revisions {
+			outcome := rev.Outcome
+			if rev.RolledBackFrom != 0 {
+				outcome += fmt.Sprintf(" (from %d)", rev.RolledBackFrom)
+			}
+			if rev.Error != "" {
+				outcome += ": " + rev.Error
+			}
+			p.AppendRow(strconv.Itoa(rev.Number), rev.Time.Local().Format("2006-01-02 15:04:05"), rev.Template, rev.Version,
+				outcome, strings.Join(rev.ValuesDiff, ", "))
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	historyCmd.Flags().StringVarP(&historyOutput, "output", "o", "", "Output format (json)")
+}
@@ -0,0 +1,103 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/hosts"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+)
+
+var (
+	targetHosts    []string
+	allHosts       bool
+	hostsInventory string
+	hostsParallel  bool
+)
+
+// hostsFlags are the flags selecting the hosts, not passed on to the hosts
+var hostsFlags = []string{"host", "all-hosts", "inventory", "parallel", "machine"}
+
+// hostFileFlags are the flags taking a local file path, the files are copied to the hosts
+var hostFileFlags = []string{"values", "tls-cert", "tls-key", "policy"}
+
+// addHostsFlags adds the flags running the command on the hosts of the hosts inventory
+func addHostsFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&targetHosts, "host", []string{}, "Run on the named hosts of the hosts inventory instead of this host")
+	cmd.Flags().BoolVar(&allHosts, "all-hosts", false, "Run on all the hosts of the hosts inventory instead of this host")
+	cmd.Flags().StringVar(&hostsInventory, "inventory", "", "Path of the hosts inventory (default: $"+hosts.InventoryEnv+" or "+hosts.DefaultInventory+")")
+	cmd.Flags().BoolVar(&hostsParallel, "parallel", false, "Run on the hosts in parallel instead of one host after the other")
+	cmd.MarkFlagsMutuallyExclusive("host", "all-hosts")
+}
+
+// hostsTargeted reports whether the command runs on the hosts of the inventory instead of this host
+func hostsTargeted() bool {
+	return allHosts || len(targetHosts) > 0
+}
+
+// runOnHosts runs the command with its flags and arguments on the targeted hosts, over SSH with the CLI installed
+// on each host. The output of each host is printed, followed by a summary of the results per host
+func runOnHosts(cmd *cobra.Command, args []string) error {
+	inventory, err := hosts.Load(hostsInventory)
+	if err != nil {
+		return err
+	}
+	selected, err := inventory.Select(allHosts, targetHosts)
+	if err != nil {
+		return err
+	}
+
+	// the command path without the root command, Eg:- application create
+	cliArgs := strings.Fields(cmd.CommandPath())[1:]
+	var files []string
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		if slices.Contains(hostsFlags, f.Name) {
+			return
+		}
+		values := []string{f.Value.String()}
+		if sv, ok := f.Value.(pflag.SliceValue); ok {
+			values = sv.GetSlice()
+		}
+		for _, v := range values {
+			cliArgs = append(cliArgs, "--"+f.Name+"="+v)
+			if slices.Contains(hostFileFlags, f.Name) {
+				files = append(files, v)
+			}
+		}
+	})
+	cliArgs = append(cliArgs, args...)
+
+	results := hosts.Run(context.Background(), selected, hosts.Command{Args: cliArgs, Files: files, Parallel: hostsParallel})
+	machine.SetData(results)
+
+	for _, r := range results {
+		if r.Changed {
+			machine.MarkChanged()
+		}
+		fmt.Printf("==> %s\n", r.Host)
+		fmt.Print(r.Output)
+		fmt.Println()
+	}
+
+	p := utils.NewTableWriter()
+	p.SetHeaders("HOST", "STATUS", "ERROR")
+	for _, r := range results {
+		status := "OK"
+		if !r.Success {
+			status = "FAILED"
+		}
+		p.AppendRow(r.Host, status, r.Error)
+	}
+	p.CloseTableWriter()
+
+	if failed := hosts.Failed(results); len(failed) > 0 {
+		return fmt.Errorf("failed on %d of %d hosts: %s", len(failed), len(results), strings.Join(failed, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package image
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/helpers"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/podman"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+)
+
+var (
+	precacheDryRun bool
+	precacheYes    bool
+)
+
+// CachedImage is an image of the template along with its download size, zero once present locally
+type CachedImage struct {
+	Image  string `json:"image"`
+	Cached bool   `json:"cached"`
+	Size   int64  `json:"size"`
+	// Error is set when the size of the image cannot be resolved from its registry
+	Error string `json:"error,omitempty"`
+}
+
+// PrecacheReport lists the images of the template and the total size left to download
+type PrecacheReport struct {
+	Template  string        `json:"template"`
+	Images    []CachedImage `json:"images"`
+	TotalSize int64         `json:"totalSize"`
+	Pulled    []string      `json:"pulled,omitempty"`
+}
+
+var precacheCmd = &cobra.Command{
+	Use:   "precache",
+	Short: "Pre-pulls the container images of a template ahead of its deployment",
+	Long: `Resolves the container images of the application template, reports the images already present locally and
+the total size left to download from the registries, then pulls the missing images.
+
+Run it ahead of a maintenance window, so that the create only deploys the pods and its duration is predictable.`,
+	Example: `  ai-services application image precache -t RAG
+  ai-services application image precache -t RAG --dry-run`,
+	Args: cobra.MaximumNArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+		return precache(context.Background(), templateName)
+	},
+}
+
+func init() {
+	precacheCmd.Flags().BoolVar(&precacheDryRun, "dry-run", false, "Only report the images and the download size, without pulling them")
+	precacheCmd.Flags().BoolVarP(&precacheYes, "yes", "y", false, "Pull the missing images without confirmation")
+}
+
+func precache(ctx context.Context, template string) error {
+	images, err := helpers.ListImages(template, "")
+	if err != nil {
+		return fmt.Errorf("error listing images: %w", err)
+	}
+
+	runtimeClient, err := podman.NewPodmanClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to podman: %w", err)
+	}
+
+	report := PrecacheReport{Template: template}
+	for _, img := range images {
+		entry := CachedImage{Image: img}
+		if _, err := runtimeClient.InspectImage(img); err == nil {
+			entry.Cached = true
+		} else if entry.Size, err = helpers.ImageDownloadSize(ctx, img); err != nil {
+			entry.Error = err.Error()
+		}
+		report.TotalSize += entry.Size
+		report.Images = append(report.Images, entry)
+	}
+	machine.SetData(&report)
+
+	p := utils.NewTableWriter()
+	p.SetHeaders("IMAGE", "STATUS", "DOWNLOAD SIZE")
+	var missing []string
+	unknown := false
+	for _, entry := range report.Images {
+		switch {
+		case entry.Cached:
+			p.AppendRow(entry.Image, "cached", "--")
+		case entry.Error != "":
+			unknown = true
+			missing = append(missing, entry.Image)
+			p.AppendRow(entry.Image, "missing", "unknown")
+		default:
+			missing = append(missing, entry.Image)
+			p.AppendRow(entry.Image, "missing", formatSize(entry.Size))
+		}
+	}
+	p.CloseTableWriter()
+
+	for _, entry := range report.Images {
+		if entry.Error != "" {
+			logger.Warningf("Failed to resolve the size of %s: %s\n", entry.Image, entry.Error)
+		}
+	}
+	if len(missing) == 0 {
+		logger.Infof("All the %d images of template '%s' are cached\n", len(images), template)
+		return nil
+	}
+	total := formatSize(report.TotalSize)
+	if unknown {
+		total = "at least " + total
+	}
+	logger.Infof("%d of %d images to download, %s in total\n", len(missing), len(images), total)
+
+	if precacheDryRun {
+		return nil
+	}
+	if !precacheYes && !machine.Enabled {
+		confirmed, err := utils.ConfirmAction("Pull the missing images?")
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			logger.Infoln("Precache cancelled")
+			return nil
+		}
+	}
+
+	for i, img := range missing {
+		logger.Infof("Pulling image %d/%d: %s\n", i+1, len(missing), img)
+		if err := runtimeClient.PullImage(img, nil); err != nil {
+			return fmt.Errorf("failed to pull the image %s: %w", img, err)
+		}
+		report.Pulled = append(report.Pulled, img)
+		machine.MarkChanged()
+	}
+	logger.Infof("Cached the images of template '%s'\n", template)
+
+	return nil
+}
+
+// formatSize formats the bytes in GiB or MiB
+func formatSize(b int64) string {
+	if b >= 1<<30 {
+		return fmt.Sprintf("%.2f GiB", float64(b)/(1<<30))
+	}
+	return fmt.Sprintf("%.0f MiB", float64(b)/(1<<20))
+}
@@ -1,6 +1,7 @@
 package application
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/containers/podman/v5/pkg/domain/entities/types"
@@ -9,7 +10,9 @@ import (
 	"github.com/project-ai-services/ai-services/internal/pkg/cli/helpers"
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
 	"github.com/project-ai-services/ai-services/internal/pkg/runtime/podman"
+	"github.com/project-ai-services/ai-services/internal/pkg/specs"
 	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+	"github.com/project-ai-services/ai-services/pkg/aiservices"
 )
 
 var infoCmd = &cobra.Command{
@@ -27,6 +30,10 @@ var infoCmd = &cobra.Command{
 		// Once precheck passes, silence usage for any *later* internal errors.
 		cmd.SilenceUsage = true
 
+		if hostsTargeted() {
+			return runOnHosts(cmd, args)
+		}
+
 		runtimeClient, err := podman.NewPodmanClient()
 		if err != nil {
 			return fmt.Errorf("failed to connect to podman: %w", err)
@@ -41,6 +48,10 @@ var infoCmd = &cobra.Command{
 	},
 }
 
+func init() {
+	addHostsFlags(infoCmd)
+}
+
 func runInfoCommamd(client *podman.PodmanClient, appName string) error {
 	// Step1: Do List pods and filter for given application name
 
@@ -76,7 +87,10 @@ func runInfoCommamd(client *podman.PodmanClient, appName string) error {
 	version := pods[0].Labels[string(vars.VersionLabel)]
 	logger.Infoln("Version: " + version)
 
-	// Step3: Read and print the info.md file
+	// Step3: List the ConfigMaps and PersistentVolumeClaims played along with the pods
+	printObjects(appName)
+
+	// Step4: Read and print the info.md file
 
 	if err := helpers.PrintInfo(client, appName, appTemplate); err != nil {
 		// not failing if overall info command, if we cannot display Info
@@ -86,3 +100,32 @@ func runInfoCommamd(client *podman.PodmanClient, appName string) error {
 
 	return nil
 }
+
+// printObjects prints the ConfigMaps and PersistentVolumeClaims of the application, recorded by its last revision
+func printObjects(appName string) {
+	svc, err := aiservices.NewClient(context.Background())
+	if err != nil {
+		logger.Infof("Unable to list the objects of the application: %v\n", err, 2)
+		return
+	}
+	objects, err := svc.ApplicationObjects(context.Background(), appName)
+	if err != nil {
+		logger.Infof("Unable to list the objects of the application: %v\n", err, 2)
+		return
+	}
+	if len(objects) == 0 {
+		return
+	}
+
+	logger.Infoln("Objects:")
+	for _, obj := range objects {
+		status := "played with pod template " + obj.PodTemplate
+		if obj.Kind == specs.KindPersistentVolumeClaim {
+			status = "volume missing"
+			if obj.Exists {
+				status = "volume present"
+			}
+		}
+		logger.Infof("\t-> %s %s (%s)\n", obj.Kind, obj.Name, status)
+	}
+}
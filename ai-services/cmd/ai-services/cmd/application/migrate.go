@@ -0,0 +1,273 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/audit"
+	"github.com/project-ai-services/ai-services/internal/pkg/gateway"
+	"github.com/project-ai-services/ai-services/internal/pkg/hosts"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/podman"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+	"github.com/project-ai-services/ai-services/pkg/aiservices"
+)
+
+var (
+	migrateTo           string
+	migrateInventory    string
+	migrateDeleteSource bool
+)
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate [name]",
+	Short: "Moves an application to another host of the hosts inventory",
+	Long: `Moves the application to another host, Eg:- to evacuate a Power LPAR for maintenance:
+  1. checks the target host has the free Spyre cards, CPU and memory required by the template
+  2. deploys the application on the target host with the values of its last deployed revision, its TLS certificate
+     and its runtime config
+  3. moves the gateway routes of the application to the gateway of the target host
+  4. stops the application on this host, or deletes it with --delete-source
+
+The API keys are not migrated, a new API key is generated on the target host if the application requires API keys.
+
+Arguments
+  [name]: Application name (required)`,
+	Example: `  ai-services application migrate my-app --to lpar2
+  ai-services application migrate my-app --to lpar2 --delete-source`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		applicationName := args[0]
+
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		inventory, err := hosts.Load(migrateInventory)
+		if err != nil {
+			return err
+		}
+		target, err := inventory.Select(false, []string{migrateTo})
+		if err != nil {
+			return err
+		}
+
+		runtimeClient, err := podman.NewPodmanClient()
+		if err != nil {
+			return fmt.Errorf("failed to connect to podman: %w", err)
+		}
+		ctx := context.Background()
+		client, err := aiservices.NewClient(ctx)
+		if err != nil {
+			return err
+		}
+
+		export, err := client.ExportApplication(ctx, applicationName)
+		if err != nil {
+			return fmt.Errorf("failed to export application: %w", err)
+		}
+
+		dir, err := os.MkdirTemp("", "ai-services-migrate-")
+		if err != nil {
+			return fmt.Errorf("failed to create temporary directory: %w", err)
+		}
+		defer os.RemoveAll(dir)
+
+		files, err := writeExportFiles(dir, export)
+		if err != nil {
+			return err
+		}
+
+		logger.Infof("Checking the capacity of %s for template %s...\n", migrateTo, export.Template)
+		if err := checkTargetCapacity(ctx, target, export.Template, files.values); err != nil {
+			return err
+		}
+
+		if err := deployOnTarget(ctx, target, export, files); err != nil {
+			return err
+		}
+
+		if err := cutoverRoutes(ctx, runtimeClient, target, export.Routes); err != nil {
+			return err
+		}
+
+		if migrateDeleteSource {
+			logger.Infof("Deleting application %s on this host...\n", applicationName)
+			if err := client.DeleteApplication(ctx, applicationName, aiservices.DeleteOptions{}); err != nil {
+				return fmt.Errorf("application migrated but failed to delete it on this host: %w", err)
+			}
+		} else {
+			logger.Infof("Stopping application %s on this host...\n", applicationName)
+			app, err := client.GetApplication(ctx, applicationName)
+			if err != nil {
+				return err
+			}
+			for _, pod := range app.Pods {
+				if err := runtimeClient.StopPod(pod.ID); err != nil {
+					return fmt.Errorf("application migrated but failed to stop pod %s on this host: %w", pod.Name, err)
+				}
+			}
+		}
+
+		if err := audit.Record(audit.Entry{Application: applicationName, Action: "migrate", Details: "to " + migrateTo}); err != nil {
+			logger.Warningf("failed to record the migration in the audit history: %v\n", err)
+		}
+		machine.SetData(map[string]any{"application": applicationName, "to": migrateTo, "routes": export.Routes})
+
+		logger.Infof("Application %s migrated to %s\n", applicationName, migrateTo)
+		if export.APIKeys {
+			logger.Warningf("the clients of application %s require the API key generated on %s\n", applicationName, migrateTo)
+		}
+		return nil
+	},
+}
+
+// exportFiles are the files of the exported application copied to the target host
+type exportFiles struct {
+	values  string
+	tlsCert string
+	tlsKey  string
+}
+
+func writeExportFiles(dir string, export *aiservices.ApplicationExport) (*exportFiles, error) {
+	files := &exportFiles{values: filepath.Join(dir, "values.yaml")}
+
+	values, err := yaml.Marshal(export.Values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the values of the application: %w", err)
+	}
+	if err := os.WriteFile(files.values, values, 0o600); err != nil {
+		return nil, fmt.Errorf("failed to write the values of the application: %w", err)
+	}
+
+	if len(export.TLSCert) > 0 {
+		files.tlsCert, files.tlsKey = filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+		if err := os.WriteFile(files.tlsCert, export.TLSCert, 0o600); err != nil {
+			return nil, fmt.Errorf("failed to write the TLS certificate: %w", err)
+		}
+		if err := os.WriteFile(files.tlsKey, export.TLSKey, 0o600); err != nil {
+			return nil, fmt.Errorf("failed to write the TLS key: %w", err)
+		}
+	}
+
+	return files, nil
+}
+
+// deployOnTarget deploys the exported application on the target host, with its values, TLS certificate and runtime
+// config
+func deployOnTarget(ctx context.Context, target []hosts.Host, export *aiservices.ApplicationExport, files *exportFiles) error {
+	host := target[0].Name
+	logger.Infof("Deploying application %s on %s...\n", export.Name, host)
+	createArgs := []string{"application", "create", export.Name, "--template=" + export.Template, "--values=" + files.values}
+	cmdFiles := []string{files.values}
+	if files.tlsCert != "" {
+		createArgs = append(createArgs, "--tls-cert="+files.tlsCert, "--tls-key="+files.tlsKey)
+		cmdFiles = append(cmdFiles, files.tlsCert, files.tlsKey)
+	}
+	if export.APIKeys {
+		createArgs = append(createArgs, "--api-key")
+	}
+	if err := runOnTarget(ctx, target, hosts.Command{Args: createArgs, Files: cmdFiles}); err != nil {
+		return fmt.Errorf("failed to deploy application on %s: %w", host, err)
+	}
+	machine.MarkChanged()
+
+	if len(export.Config) > 0 {
+		logger.Infof("Applying the runtime config on %s...\n", host)
+		configArgs := []string{"application", "config", "set", export.Name}
+		keys := utils.ExtractMapKeys(export.Config)
+		sort.Strings(keys)
+		for _, key := range keys {
+			configArgs = append(configArgs, key+"="+export.Config[key])
+		}
+		if err := runOnTarget(ctx, target, hosts.Command{Args: configArgs}); err != nil {
+			return fmt.Errorf("failed to apply the runtime config on %s: %w", host, err)
+		}
+	}
+	return nil
+}
+
+// checkTargetCapacity checks the target host can fit the template, with the values of the application
+func checkTargetCapacity(ctx context.Context, target []hosts.Host, template, valuesFile string) error {
+	results := hosts.Run(ctx, target, hosts.Command{
+		Args:  []string{"hosts", "capacity", "--local", "--fit=" + template, "--values=" + valuesFile},
+		Files: []string{valuesFile},
+	})
+	r := results[0]
+	if !r.Success {
+		return fmt.Errorf("failed to check the capacity of %s: %s", r.Host, r.Error)
+	}
+
+	var report struct {
+		Hosts []struct {
+			Capacity *aiservices.HostCapacity `json:"capacity"`
+		} `json:"hosts"`
+	}
+	if err := json.Unmarshal(r.Data, &report); err != nil || len(report.Hosts) != 1 || report.Hosts[0].Capacity == nil {
+		return fmt.Errorf("invalid capacity report of %s", r.Host)
+	}
+	fit := report.Hosts[0].Capacity.Fit
+	if fit == nil || !fit.Fits {
+		var reasons []string
+		if fit != nil {
+			reasons = fit.Reasons
+		}
+		return fmt.Errorf("%s cannot fit template %s: %v", r.Host, template, reasons)
+	}
+	return nil
+}
+
+// cutoverRoutes moves the gateway routes of the application to the gateway of the target host
+func cutoverRoutes(ctx context.Context, runtimeClient *podman.PodmanClient, target []hosts.Host, routes []gateway.Route) error {
+	if len(routes) == 0 {
+		return nil
+	}
+
+	logger.Infof("Moving %d gateway routes to %s...\n", len(routes), target[0].Name)
+	for _, route := range routes {
+		args := []string{"gateway", "route", "add", route.Application, "--endpoint=" + route.Endpoint, "--path=" + route.Path}
+		if route.Host != "" {
+			args = append(args, "--host="+route.Host)
+		}
+		if err := runOnTarget(ctx, target, hosts.Command{Args: args}); err != nil {
+			return fmt.Errorf("failed to add gateway route %s%s on %s: %w", route.Host, route.Path, target[0].Name, err)
+		}
+	}
+
+	cfg, err := gateway.Update(func(cfg *gateway.Config) error {
+		cfg.Routes = slices.DeleteFunc(cfg.Routes, func(r gateway.Route) bool { return slices.Contains(routes, r) })
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update gateway configuration: %w", err)
+	}
+	if err := gateway.Reload(runtimeClient, cfg); err != nil {
+		return fmt.Errorf("failed to reload gateway: %w", err)
+	}
+	return nil
+}
+
+// runOnTarget runs the command on the target host, printing its output
+func runOnTarget(ctx context.Context, target []hosts.Host, cmd hosts.Command) error {
+	r := hosts.Run(ctx, target, cmd)[0]
+	fmt.Print(r.Output)
+	if !r.Success {
+		return fmt.Errorf("%s", r.Error)
+	}
+	return nil
+}
+
+func init() {
+	migrateCmd.Flags().StringVar(&migrateTo, "to", "", "Host of the hosts inventory to move the application to (required)")
+	migrateCmd.Flags().StringVar(&migrateInventory, "inventory", "", "Path of the hosts inventory (default: $"+hosts.InventoryEnv+" or "+hosts.DefaultInventory+")")
+	migrateCmd.Flags().BoolVar(&migrateDeleteSource, "delete-source", false, "Delete the application on this host once migrated, instead of stopping it")
+	_ = migrateCmd.MarkFlagRequired("to")
+}
@@ -0,0 +1,61 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/helpers"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+)
+
+var describeCmd = &cobra.Command{
+	Use:   "describe [model]",
+	Short: "Shows the provenance of a downloaded model",
+	Long: `Shows the provenance of a downloaded model i.e. source, SHA256 digest, license
+and license acknowledgment recorded when the model was downloaded
+
+Arguments
+  [model]: Model name as listed by 'ai-services application model list' (required)`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+		return describe(args[0])
+	},
+}
+
+func describe(model string) error {
+	records, err := helpers.ModelRecords()
+	if err != nil {
+		return fmt.Errorf("failed to read model records: %w", err)
+	}
+
+	record, ok := records[model]
+	if !ok {
+		return fmt.Errorf("no record found for model %s. Please download the model first", model)
+	}
+
+	logger.Infoln("Name: " + record.Name)
+	logger.Infoln("Source: " + record.Source)
+	logger.Infoln("Path: " + record.Path)
+	logger.Infof("SHA256: %s (%d files)\n", valueOrNone(record.SHA256), record.Files, 0)
+	logger.Infoln("License: " + valueOrNone(record.License))
+	logger.Infof("Gated: %v\n", record.Gated)
+	if record.LicenseAcceptedAt != nil {
+		logger.Infoln("License Accepted At: " + record.LicenseAcceptedAt.Format(time.RFC3339))
+	}
+	if !record.DownloadedAt.IsZero() {
+		logger.Infoln("Downloaded At: " + record.DownloadedAt.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+func valueOrNone(v string) string {
+	if v == "" {
+		return "none"
+	}
+	return v
+}
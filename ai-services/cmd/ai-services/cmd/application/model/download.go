@@ -4,16 +4,26 @@ import (
 	"fmt"
 
 	"github.com/project-ai-services/ai-services/internal/pkg/cli/helpers"
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/templates"
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/podman"
 	"github.com/project-ai-services/ai-services/internal/pkg/vars"
 	"github.com/spf13/cobra"
 )
 
+var (
+	acceptLicense bool
+	appName       string
+)
+
 var downloadCmd = &cobra.Command{
 	Use:   "download",
 	Short: "Download models for a given application template",
-	Long:  ``,
-	Args:  cobra.MaximumNArgs(0),
+	Long: `Downloads the models of the application template into the model directory, or into the model volume shared
+across the applications for the templates storing their models there, like 'application create' does.
+
+With --app, the models are resolved for the deployed application, Eg:- to refresh them before restarting it.`,
+	Args: cobra.MaximumNArgs(0),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Once precheck passes, silence usage for any *later* internal errors.
 		cmd.SilenceUsage = true
@@ -24,19 +34,25 @@ var downloadCmd = &cobra.Command{
 func init() {
 	downloadCmd.Flags().StringVarP(&templateName, "template", "t", "", "Application template name(Required)")
 	_ = downloadCmd.MarkFlagRequired("template")
+	downloadCmd.Flags().StringVar(&appName, "app", "", "Application the models are downloaded for")
 	downloadCmd.Flags().StringVar(&vars.ToolImage, "tool-image", vars.ToolImage, "Tool container image used for downloading the model (for development purposes only)")
 	_ = downloadCmd.Flags().MarkHidden("tool-image")
-	downloadCmd.Flags().StringVar(&vars.ModelDirectory, "dir", vars.ModelDirectory, "Directory to download the model files")
+	downloadCmd.Flags().StringVar(&vars.ModelDirectory, "dir", vars.ModelDirectory, "Directory to download the model files (default: the shared model volume for the templates using it)")
+	downloadCmd.Flags().BoolVar(&acceptLicense, "accept-license", false, "Accept the license of the gated models being downloaded")
 }
 
 func download(cmd *cobra.Command) error {
-	models, err := models(templateName)
+	models, err := models(templateName, appName)
+	if err != nil {
+		return err
+	}
+	modelDirectory, err := modelDirectory(cmd)
 	if err != nil {
 		return err
 	}
 	logger.Infoln("Downloaded Models in application template" + templateName + ":")
 	for _, model := range models {
-		err := helpers.DownloadModel(model, vars.ModelDirectory)
+		err := helpers.DownloadModel(model, modelDirectory, acceptLicense)
 		if err != nil {
 			return fmt.Errorf("failed to download model: %w", err)
 		}
@@ -44,3 +60,30 @@ func download(cmd *cobra.Command) error {
 
 	return nil
 }
+
+// modelDirectory returns the directory the models of the template are stored in: the shared model volume if the
+// template uses it and --dir is not set, the model directory otherwise
+func modelDirectory(cmd *cobra.Command) (string, error) {
+	if cmd.Flags().Changed("dir") {
+		return vars.ModelDirectory, nil
+	}
+
+	tp := templates.NewEmbedTemplateProvider(templates.EmbedOptions{})
+	appMetadata, err := tp.LoadMetadata(templateName)
+	if err != nil {
+		return "", fmt.Errorf("failed to load the metadata of the template: %w", err)
+	}
+	if !appMetadata.SharedModelVolume {
+		return vars.ModelDirectory, nil
+	}
+
+	runtimeClient, err := podman.NewPodmanClient()
+	if err != nil {
+		return "", fmt.Errorf("failed to connect to podman: %w", err)
+	}
+	mountpoint, err := helpers.EnsureSharedModelVolume(runtimeClient)
+	if err != nil {
+		return "", fmt.Errorf("failed to provision shared model volume: %w", err)
+	}
+	return mountpoint, nil
+}
@@ -27,7 +27,7 @@ func init() {
 }
 
 func list(cmd *cobra.Command) error {
-	models, err := models(templateName)
+	models, err := models(templateName, "")
 	if err != nil {
 		return fmt.Errorf("failed to list the models, err: %w", err)
 	}
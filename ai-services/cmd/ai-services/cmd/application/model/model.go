@@ -22,9 +22,11 @@ var ModelCmd = &cobra.Command{
 func init() {
 	ModelCmd.AddCommand(listCmd)
 	ModelCmd.AddCommand(downloadCmd)
+	ModelCmd.AddCommand(describeCmd)
 }
 
-func models(template string) ([]string, error) {
+// models returns the models of the template, rendered with the name of the application if any, like on create
+func models(template, appName string) ([]string, error) {
 	tp := templates.NewEmbedTemplateProvider(templates.EmbedOptions{})
 	apps, err := tp.ListApplications()
 	if err != nil {
@@ -34,5 +36,5 @@ func models(template string) ([]string, error) {
 	if !slices.Contains(apps, template) {
 		return nil, fmt.Errorf("application template %s does not exist", template)
 	}
-	return helpers.ListModels(template, "")
+	return helpers.ListModels(template, appName)
 }
@@ -0,0 +1,218 @@
+package application
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/containers/podman/v5/pkg/domain/entities/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/i18n"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/podman"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pausePodNames   []string
+	unpausePodNames []string
+)
+
+var pauseCmd = &cobra.Command{
+	Use:   "pause [name]",
+	Short: "Pauses the running application",
+	Long: `Pauses a running application by name, freezing its containers.
+
+Unlike stop, the containers keep their memory state and Spyre card assignment, and resume where they left off
+with unpause. Useful to temporarily free the CPUs during host maintenance.
+
+Arguments
+  [name]: Application name (required)
+`,
+	Args: cobra.ExactArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		var err error
+		pausePodNames, err = cmd.Flags().GetStringSlice("pod")
+		if err != nil {
+			return fmt.Errorf("failed to parse --pod flag: %w", err)
+		}
+
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		applicationName := args[0]
+
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		runtimeClient, err := podman.NewPodmanClient()
+		if err != nil {
+			return fmt.Errorf("failed to connect to podman: %w", err)
+		}
+
+		return pauseApplication(runtimeClient, applicationName, pausePodNames)
+	},
+}
+
+var unpauseCmd = &cobra.Command{
+	Use:   "unpause [name]",
+	Short: "Unpauses the paused application",
+	Long: `Unpauses an application paused with pause, resuming its containers.
+
+Arguments
+  [name]: Application name (required)
+`,
+	Args: cobra.ExactArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		var err error
+		unpausePodNames, err = cmd.Flags().GetStringSlice("pod")
+		if err != nil {
+			return fmt.Errorf("failed to parse --pod flag: %w", err)
+		}
+
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		applicationName := args[0]
+
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		runtimeClient, err := podman.NewPodmanClient()
+		if err != nil {
+			return fmt.Errorf("failed to connect to podman: %w", err)
+		}
+
+		return unpauseApplication(runtimeClient, applicationName, unpausePodNames)
+	},
+}
+
+func init() {
+	pauseCmd.Flags().StringSlice("pod", []string{}, "Specific pod name(s) to pause (optional)\nCan be specified multiple times: --pod pod1 --pod pod2\nOr comma-separated: --pod pod1,pod2")
+	unpauseCmd.Flags().StringSlice("pod", []string{}, "Specific pod name(s) to unpause (optional)\nCan be specified multiple times: --pod pod1 --pod pod2\nOr comma-separated: --pod pod1,pod2")
+}
+
+// pauseApplication pauses the running pods of the given application
+func pauseApplication(client *podman.PodmanClient, appName string, podNames []string) error {
+	podsToPause, err := selectApplicationPods(client, appName, podNames)
+	if err != nil || len(podsToPause) == 0 {
+		return err
+	}
+
+	logger.Infof("Found %d pods for given applicationName: %s.\n", len(podsToPause), appName)
+	logger.Infoln("Below pods will be paused:")
+	for _, pod := range podsToPause {
+		logger.Infof("\t-> %s\n", pod.Name)
+	}
+
+	confirmPause, err := utils.ConfirmAction(i18n.T("prompt.pause"))
+	if err != nil {
+		return fmt.Errorf("failed to take user input: %w", err)
+	}
+
+	if !confirmPause {
+		logger.Infof("Skipping pausing of pods\n")
+		return nil
+	}
+
+	logger.Infof("Proceeding to pause pods...\n")
+
+	var errors []string
+	for _, pod := range podsToPause {
+		if pod.Status != "Running" && pod.Status != "Degraded" {
+			logger.Infof("Pod %s is %s. Skipping...\n", pod.Name, strings.ToLower(pod.Status))
+			continue
+		}
+		logger.Infof("Pausing the pod: %s\n", pod.Name)
+		if err := client.PausePod(pod.Id); err != nil {
+			errors = append(errors, fmt.Sprintf("%s: %v", pod.Name, err))
+			continue
+		}
+		machine.MarkChanged()
+		logger.Infof("Successfully paused the pod: %s\n", pod.Name)
+	}
+
+	if len(errors) > 0 {
+		return fmt.Errorf("failed to pause pods: \n%s", strings.Join(errors, "\n"))
+	}
+
+	return nil
+}
+
+// unpauseApplication unpauses the paused pods of the given application
+func unpauseApplication(client *podman.PodmanClient, appName string, podNames []string) error {
+	podsToUnpause, err := selectApplicationPods(client, appName, podNames)
+	if err != nil || len(podsToUnpause) == 0 {
+		return err
+	}
+
+	var errors []string
+	for _, pod := range podsToUnpause {
+		if pod.Status != "Paused" {
+			logger.Infof("Pod %s is not paused. Skipping...\n", pod.Name)
+			continue
+		}
+		logger.Infof("Unpausing the pod: %s\n", pod.Name)
+		if err := client.UnpausePod(pod.Id); err != nil {
+			errors = append(errors, fmt.Sprintf("%s: %v", pod.Name, err))
+			continue
+		}
+		machine.MarkChanged()
+		logger.Infof("Successfully unpaused the pod: %s\n", pod.Name)
+	}
+
+	if len(errors) > 0 {
+		return fmt.Errorf("failed to unpause pods: \n%s", strings.Join(errors, "\n"))
+	}
+
+	return nil
+}
+
+// selectApplicationPods returns the pods of the application, restricted to podNames if provided.
+// The pod names which are not found are skipped with a warning.
+func selectApplicationPods(client *podman.PodmanClient, appName string, podNames []string) ([]*types.ListPodsReport, error) {
+	resp, err := client.ListPods(map[string][]string{
+		"label": {fmt.Sprintf("ai-services.io/application=%s", appName)},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	var pods []*types.ListPodsReport
+	if val, ok := resp.([]*types.ListPodsReport); ok {
+		pods = val
+	}
+
+	if len(pods) == 0 {
+		logger.Infof("No pods found with given application: %s\n", appName)
+		return nil, nil
+	}
+
+	if len(podNames) == 0 {
+		return pods, nil
+	}
+
+	podMap := make(map[string]*types.ListPodsReport)
+	for _, pod := range pods {
+		podMap[pod.Name] = pod
+	}
+
+	var selected []*types.ListPodsReport
+	var notFound []string
+	for _, podname := range podNames {
+		if pod, exists := podMap[podname]; exists {
+			selected = append(selected, pod)
+		} else {
+			notFound = append(notFound, podname)
+		}
+	}
+
+	if len(notFound) > 0 {
+		logger.Warningf("The following specified pods were not found and will be skipped: %s\n", strings.Join(notFound, ", "))
+	}
+	if len(selected) == 0 {
+		logger.Infof("No valid pods found for application: %s\n", appName)
+	}
+
+	return selected, nil
+}
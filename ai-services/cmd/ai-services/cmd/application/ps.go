@@ -1,7 +1,9 @@
 package application
 
 import (
+	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/containers/podman/v5/pkg/domain/entities/types"
@@ -9,10 +11,15 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
 	"github.com/project-ai-services/ai-services/internal/pkg/runtime/podman"
+	"github.com/project-ai-services/ai-services/pkg/aiservices"
 )
 
-var output string
+var (
+	output     string
+	showImages bool
+)
 
 func init() {
 	psCmd.Flags().StringVarP(
@@ -22,6 +29,8 @@ func init() {
 		"",
 		"Output format (e.g., wide)",
 	)
+	psCmd.Flags().BoolVar(&showImages, "images", false, "List the images run by the containers with their digests, and whether they differ from the images of the template")
+	addHostsFlags(psCmd)
 }
 
 func isOutputWide() bool {
@@ -29,8 +38,9 @@ func isOutputWide() bool {
 }
 
 var psCmd = &cobra.Command{
-	Use:   "ps [name]",
-	Short: "Lists all or specified running application(s)",
+	Use:     "ps [name]",
+	Aliases: []string{"list"},
+	Short:   "Lists all or specified running application(s)",
 	Long: `Retrieves information about all the running applications if no name is provided
 Lists information about a specific application if the name is provided
 Arguments
@@ -41,6 +51,10 @@ Arguments
 		// Once precheck passes, silence usage for any *later* internal errors.
 		cmd.SilenceUsage = true
 
+		if hostsTargeted() {
+			return runOnHosts(cmd, args)
+		}
+
 		var applicationName string
 		if len(args) > 0 {
 			applicationName = args[0]
@@ -52,7 +66,11 @@ Arguments
 			return fmt.Errorf("failed to connect to podman: %w", err)
 		}
 
-		err = runPsCmd(runtimeClient, applicationName)
+		if showImages {
+			err = runPsImagesCmd(applicationName)
+		} else {
+			err = runPsCmd(runtimeClient, applicationName)
+		}
 		if err != nil {
 			return fmt.Errorf("failed to fetch application: %w", err)
 		}
@@ -87,6 +105,9 @@ func runPsCmd(client *podman.PodmanClient, appName string) error {
 	p := utils.NewTableWriter()
 	defer p.CloseTableWriter()
 
+	var entries []psEntry
+	defer func() { machine.SetData(entries) }()
+
 	if isOutputWide() {
 		p.SetHeaders("APPLICATION NAME", "POD ID", "POD NAME", "STATUS", "EXPOSED")
 	} else {
@@ -112,6 +133,14 @@ func runPsCmd(client *podman.PodmanClient, appName string) error {
 			}
 		}
 
+		entries = append(entries, psEntry{
+			Application: fetchPodNameFromLabels(pod.Labels),
+			PodID:       pod.Id,
+			PodName:     pod.Name,
+			Status:      pod.Status,
+			Exposed:     slices.Clone(podPorts),
+		})
+
 		if len(podPorts) == 0 {
 			podPorts = []string{"none"}
 		}
@@ -135,6 +164,82 @@ func runPsCmd(client *podman.PodmanClient, appName string) error {
 	return nil
 }
 
+// runPsImagesCmd lists the images run by the containers of the applications, so that operators can audit the
+// versions live and whether they differ from the template
+func runPsImagesCmd(appName string) error {
+	ctx := context.Background()
+	svc, err := aiservices.NewClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	var apps []aiservices.Application
+	if appName != "" {
+		app, err := svc.GetApplication(ctx, appName)
+		if err != nil {
+			return err
+		}
+		apps = append(apps, *app)
+	} else {
+		apps, err = svc.ListApplications(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	var entries []psImagesEntry
+	defer func() { machine.SetData(entries) }()
+
+	p := utils.NewTableWriter()
+	defer p.CloseTableWriter()
+	p.SetHeaders("APPLICATION NAME", "POD NAME", "CONTAINER", "IMAGE", "DIGEST", "TEMPLATE IMAGE")
+
+	for _, app := range apps {
+		images, err := svc.ApplicationImages(ctx, &app)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, psImagesEntry{Application: app.Name, Template: app.Template, Images: images})
+
+		for _, image := range images {
+			declared := "unknown"
+			if image.Declared != nil {
+				declared = "yes"
+				if !*image.Declared {
+					declared = "differs"
+				}
+			}
+			p.AppendRow(app.Name, image.Pod, image.Container, image.Image, shortDigest(image.Digest), declared)
+		}
+	}
+	return nil
+}
+
+// shortDigest abbreviates the digest like the image IDs, Eg:- sha256:0123456789ab
+func shortDigest(digest string) string {
+	algorithm, hex, ok := strings.Cut(digest, ":")
+	if !ok || len(hex) <= 12 {
+		return digest
+	}
+	return algorithm + ":" + hex[:12]
+}
+
+// psImagesEntry is the machine readable entry of the images of an application
+type psImagesEntry struct {
+	Application string                      `json:"application"`
+	Template    string                      `json:"template"`
+	Images      []aiservices.ContainerImage `json:"images"`
+}
+
+// psEntry is the machine readable entry of a pod
+type psEntry struct {
+	Application string   `json:"application"`
+	PodID       string   `json:"podId"`
+	PodName     string   `json:"podName"`
+	Status      string   `json:"status"`
+	Exposed     []string `json:"exposed,omitempty"`
+}
+
 func fetchPodNameFromLabels(labels map[string]string) string {
 	return labels["ai-services.io/application"]
 }
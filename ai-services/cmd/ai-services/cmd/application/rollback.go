@@ -0,0 +1,75 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/i18n"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+	"github.com/project-ai-services/ai-services/pkg/aiservices"
+)
+
+var rollbackRevision int
+
+var rollbackCmd = &cobra.Command{
+	Use:   "rollback [name]",
+	Short: "Roll back an application to a previous revision",
+	Long: `Rolls back an application to a previous revision.
+
+Each deployment of an application is recorded as a revision, with the manifests as rendered and deployed.
+The most recent revisions are kept, see 'application history'. Rolling back removes the pods of the application and redeploys the
+manifests of the previous revision (or of --revision), layer by layer. The rollback is recorded as a new revision.
+
+Arguments
+  [name]: Application name (required)`,
+	Args: cobra.ExactArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if rollbackRevision < 0 {
+			return fmt.Errorf("invalid revision %d", rollbackRevision)
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		applicationName := args[0]
+
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		client, err := aiservices.NewClient(context.Background())
+		if err != nil {
+			return err
+		}
+
+		target := "the previous revision"
+		if rollbackRevision > 0 {
+			target = "revision " + strconv.Itoa(rollbackRevision)
+		}
+		confirmRollback, err := utils.ConfirmAction(i18n.T("prompt.rollback", applicationName, target))
+		if err != nil {
+			return fmt.Errorf("failed to take user input: %w", err)
+		}
+		if !confirmRollback {
+			logger.Infof("Skipping the rollback\n")
+			return nil
+		}
+
+		rev, err := client.Rollback(context.Background(), applicationName, aiservices.RollbackOptions{Revision: rollbackRevision})
+		machine.MarkChanged()
+		if err != nil {
+			return fmt.Errorf("failed to roll back application: %w", err)
+		}
+		machine.SetData(rev)
+		logger.Infof("Successfully rolled back application %s from revision %d, deployed as revision %d\n", applicationName, rev.RolledBackFrom, rev.Number, 0)
+
+		return nil
+	},
+}
+
+func init() {
+	rollbackCmd.Flags().IntVar(&rollbackRevision, "revision", 0, "Revision to roll back to, defaults to the previous revision")
+}
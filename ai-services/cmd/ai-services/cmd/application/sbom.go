@@ -0,0 +1,80 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/podman"
+	"github.com/project-ai-services/ai-services/internal/pkg/sbom"
+)
+
+var (
+	sbomFormat       string
+	sbomOutput       string
+	sbomAttestations bool
+)
+
+var sbomCmd = &cobra.Command{
+	Use:   "sbom [name]",
+	Short: "Generates the SBOM and image provenance report of an application",
+	Long: `Collects the images of every container of the application with their digests and labels, along with the
+SBOMs and attestations attached to the images in their registries (cosign attach sbom / cosign attest), and
+renders them as an SPDX 2.3 or CycloneDX 1.5 JSON document.
+
+Arguments
+  [name]: Application name (required)`,
+	Example: `  ai-services application sbom rag-dev
+  ai-services application sbom rag-dev --format cyclonedx --output rag-dev.cdx.json`,
+	Args: cobra.ExactArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if sbomFormat != sbom.FormatSPDX && sbomFormat != sbom.FormatCycloneDX {
+			return fmt.Errorf("unsupported format %q, supported formats: %s, %s", sbomFormat, sbom.FormatSPDX, sbom.FormatCycloneDX)
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		appName := args[0]
+
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		runtimeClient, err := podman.NewPodmanClient()
+		if err != nil {
+			return fmt.Errorf("failed to connect to podman: %w", err)
+		}
+
+		report, err := sbom.Collect(context.Background(), runtimeClient, appName, sbom.Options{Attestations: sbomAttestations})
+		if err != nil {
+			return fmt.Errorf("failed to collect the images of application '%s': %w", appName, err)
+		}
+		machine.SetData(report)
+
+		doc, err := sbom.Render(report, sbomFormat)
+		if err != nil {
+			return err
+		}
+
+		if sbomOutput == "" {
+			fmt.Println(string(doc))
+			return nil
+		}
+
+		if err := os.WriteFile(sbomOutput, append(doc, '\n'), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", sbomOutput, err)
+		}
+		logger.Infof("SBOM of application '%s' written to %s\n", appName, sbomOutput)
+
+		return nil
+	},
+}
+
+func init() {
+	sbomCmd.Flags().StringVar(&sbomFormat, "format", sbom.FormatSPDX, "SBOM format ("+sbom.FormatSPDX+", "+sbom.FormatCycloneDX+")")
+	sbomCmd.Flags().StringVarP(&sbomOutput, "output", "o", "", "File to write the SBOM to (default: stdout)")
+	sbomCmd.Flags().BoolVar(&sbomAttestations, "attestations", true, "Look up the SBOMs and attestations attached to the images in their registries")
+}
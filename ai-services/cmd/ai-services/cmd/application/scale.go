@@ -0,0 +1,72 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+	"github.com/project-ai-services/ai-services/pkg/aiservices"
+)
+
+var (
+	scaleComponent string
+	scaleReplicas  int
+)
+
+var scaleCmd = &cobra.Command{
+	Use:   "scale [name]",
+	Short: "Scale a component of an application",
+	Long: `Scales a component of an application to the given number of replicas.
+
+The components which can be scaled are declared under 'scalable' in the metadata of the template. The replicas
+include the pod deployed by create. The additional replicas are rendered from the pod template of the component with
+the values of the deployed revision, named <pod>-<replica>, and get their own Spyre cards. Their ports are published
+on random host ports. Scaling down deletes the highest replicas first.
+
+The gateway is reloaded to balance the routed endpoints of the component across its replicas.
+
+Arguments
+  [name]: Application name (required)`,
+	Example: `  ai-services application scale rag --component worker --replicas 3`,
+	Args:    cobra.ExactArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if scaleReplicas < 1 {
+			return fmt.Errorf("invalid replicas %d, must be at least 1", scaleReplicas)
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		applicationName := args[0]
+
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		client, err := aiservices.NewClient(context.Background())
+		if err != nil {
+			return err
+		}
+
+		result, err := client.Scale(context.Background(), applicationName,
+			aiservices.ScaleOptions{Component: scaleComponent, Replicas: scaleReplicas})
+		if result != nil && (len(result.Added) > 0 || len(result.Removed) > 0) {
+			machine.MarkChanged()
+		}
+		if err != nil {
+			return fmt.Errorf("failed to scale application: %w", err)
+		}
+		machine.SetData(result)
+		logger.Infof("Component %s of application %s scaled to %d replicas\n", result.Component, applicationName, result.Replicas, 0)
+
+		return nil
+	},
+}
+
+func init() {
+	scaleCmd.Flags().StringVar(&scaleComponent, "component", "", "Scalable component of the application")
+	scaleCmd.Flags().IntVar(&scaleReplicas, "replicas", 1, "Number of replicas of the component, including the pod deployed by create")
+	_ = scaleCmd.MarkFlagRequired("component")
+	_ = scaleCmd.MarkFlagRequired("replicas")
+}
@@ -0,0 +1,146 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/imagescan"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+	"github.com/project-ai-services/ai-services/pkg/aiservices"
+)
+
+var (
+	scanThreshold string
+	scanDetails   bool
+)
+
+var scanCmd = &cobra.Command{
+	Use:   "scan [name]",
+	Short: "Scans the images of an application for vulnerabilities",
+	Long: `Resolves the images run by the containers of the application to their digests in the registries and scans
+them with the scanner configured in the 'imageScan' of the CLI config file, reporting the CVEs by severity.
+
+The scan fails once an image has vulnerabilities of the --fail-on severity or higher, defaulting to the
+'imageScan.failOn' of the CLI config file.
+
+Arguments
+  [name]: Application name (required)`,
+	Example: `  ai-services application scan rag-dev
+  ai-services application scan rag-dev --fail-on critical --details`,
+	Args: cobra.ExactArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if scanThreshold != "" {
+			return imagescan.ValidateSeverity(scanThreshold)
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		appName := args[0]
+
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		cfg, err := imagescan.Load()
+		if err != nil {
+			return err
+		}
+		if cfg == nil {
+			return fmt.Errorf("no image scanner configured, set the 'imageScan' of %s", vars.ConfigFile)
+		}
+		if scanThreshold == "" {
+			scanThreshold = cfg.FailOn
+		}
+
+		ctx := context.Background()
+		client, err := aiservices.NewClient(ctx)
+		if err != nil {
+			return err
+		}
+
+		app, err := client.GetApplication(ctx, appName)
+		if err != nil {
+			return err
+		}
+		ctrImages, err := client.ApplicationImages(ctx, app)
+		if err != nil {
+			return err
+		}
+		var images []string
+		for _, img := range ctrImages {
+			if !slices.Contains(images, img.Image) {
+				images = append(images, img.Image)
+			}
+		}
+
+		report, err := imagescan.Scan(ctx, cfg, images)
+		if err != nil {
+			return err
+		}
+		machine.SetData(report)
+		printScanReport(report)
+
+		failed := 0
+		for _, img := range report.Images {
+			if img.Error != "" {
+				failed++
+				logger.Warningf("Failed to scan %s: %s\n", img.Image, img.Error)
+			}
+		}
+		if scanThreshold != "" {
+			if err := report.Check(scanThreshold); err != nil {
+				return err
+			}
+		}
+		if failed > 0 {
+			return fmt.Errorf("failed to scan %d of %d images", failed, len(report.Images))
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	scanCmd.Flags().StringVar(&scanThreshold, "fail-on", "", "Lowest severity of the vulnerabilities failing the scan (unknown, low, medium, high, critical)")
+	scanCmd.Flags().BoolVar(&scanDetails, "details", false, "List the vulnerabilities of every image")
+}
+
+func printScanReport(report *imagescan.Report) {
+	p := utils.NewTableWriter()
+	p.SetHeaders("IMAGE", "CRITICAL", "HIGH", "MEDIUM", "LOW", "UNKNOWN")
+	for _, img := range report.Images {
+		if img.Error != "" {
+			p.AppendRow(img.Image, "--", "--", "--", "--", "--")
+			continue
+		}
+		p.AppendRow(img.Image,
+			strconv.Itoa(img.Counts[imagescan.SeverityCritical]),
+			strconv.Itoa(img.Counts[imagescan.SeverityHigh]),
+			strconv.Itoa(img.Counts[imagescan.SeverityMedium]),
+			strconv.Itoa(img.Counts[imagescan.SeverityLow]),
+			strconv.Itoa(img.Counts[imagescan.SeverityUnknown]))
+	}
+	p.CloseTableWriter()
+
+	if !scanDetails {
+		return
+	}
+	for _, img := range report.Images {
+		if len(img.Vulnerabilities) == 0 {
+			continue
+		}
+		logger.Infof("\n%s (%s):\n", img.Image, img.Digest)
+		p := utils.NewTableWriter()
+		p.SetHeaders("ID", "SEVERITY", "PACKAGE", "INSTALLED", "FIXED")
+		for _, v := range img.Vulnerabilities {
+			p.AppendRow(v.ID, v.Severity, v.Package, v.InstalledVersion, v.FixedVersion)
+		}
+		p.CloseTableWriter()
+	}
+}
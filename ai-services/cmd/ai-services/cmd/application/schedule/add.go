@@ -0,0 +1,97 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/audit"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+	"github.com/project-ai-services/ai-services/internal/pkg/schedule"
+	"github.com/project-ai-services/ai-services/pkg/aiservices"
+)
+
+var (
+	action   string
+	calendar string
+)
+
+var addCmd = &cobra.Command{
+	Use:   "add [name]",
+	Short: "Schedule a recurring action on an application",
+	Long: `Schedules a recurring action on an application:
+  start:         starts the application
+  stop:          stops the application, freeing its Spyre cards
+  restart:       stops then starts the application
+  model-refresh: downloads the models of the application again, into the shared model volume if its template uses
+                 it, then restarts the application
+
+The runs are scheduled with a systemd calendar event, see 'man systemd.time'.
+
+Arguments
+  [name]: Application name (required)`,
+	Example: `  # restart nightly
+  ai-services application schedule add my-app --action restart --on "*-*-* 02:00"
+
+  # free the Spyre cards off-hours
+  ai-services application schedule add my-app --action stop --on "Mon..Fri 20:00"
+  ai-services application schedule add my-app --action start --on "Mon..Fri 07:00"
+
+  # refresh the models weekly
+  ai-services application schedule add my-app --action model-refresh --on "Sun 03:00"`,
+	Args: cobra.ExactArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if action == "" || calendar == "" {
+			return fmt.Errorf("--action and --on are required")
+		}
+		return schedule.ValidateCalendar(calendar)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		applicationName := args[0]
+
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		client, err := aiservices.NewClient(context.Background())
+		if err != nil {
+			return err
+		}
+
+		app, err := client.GetApplication(context.Background(), applicationName)
+		if err != nil {
+			return err
+		}
+
+		s, err := schedule.Add(schedule.Schedule{
+			Application: applicationName,
+			Action:      strings.ToLower(action),
+			Template:    app.Template,
+			Calendar:    calendar,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to add schedule: %w", err)
+		}
+		machine.SetData(s)
+		machine.MarkChanged()
+
+		if err := audit.Record(audit.Entry{Application: applicationName, Action: "schedule add",
+			Details: fmt.Sprintf("%s on %s", s.Action, s.Calendar)}); err != nil {
+			logger.Warningf("failed to record the schedule in the audit history: %v\n", err)
+		}
+
+		logger.Infof("Schedule %s added: %s of application %s on %s\n", s.ID, s.Action, applicationName, s.Calendar)
+		if next := schedule.NextRun(*s); next != "" {
+			logger.Infof("Next run: %s\n", next)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	addCmd.Flags().StringVar(&action, "action", "", "Action to run: "+strings.Join(schedule.Actions, ", ")+" (required)")
+	addCmd.Flags().StringVar(&calendar, "on", "", "systemd calendar event of the runs, Eg:- daily, 'Sat 02:00' or '*-*-* 22:00' (required)")
+}
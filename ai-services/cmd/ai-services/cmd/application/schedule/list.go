@@ -0,0 +1,74 @@
+package schedule
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+	"github.com/project-ai-services/ai-services/internal/pkg/schedule"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+)
+
+var listOutput string
+
+var listCmd = &cobra.Command{
+	Use:   "list [name]",
+	Short: "List the scheduled actions",
+	Long: `Lists the scheduled actions of an application, or of all the applications if no name is provided, with
+their next run.
+
+Arguments
+  [name]: Application name (optional)`,
+	Args: cobra.MaximumNArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if listOutput != "" && listOutput != "json" {
+			return fmt.Errorf("unsupported output format %q, supported formats: json", listOutput)
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var applicationName string
+		if len(args) > 0 {
+			applicationName = args[0]
+		}
+
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		schedules, err := schedule.List(applicationName)
+		if err != nil {
+			return fmt.Errorf("failed to list schedules: %w", err)
+		}
+		machine.SetData(schedules)
+
+		if listOutput == "json" {
+			out, err := json.MarshalIndent(schedules, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal schedules: %w", err)
+			}
+			fmt.Println(string(out))
+			return nil
+		}
+
+		if len(schedules) == 0 {
+			logger.Infoln("No schedules found")
+			return nil
+		}
+
+		p := utils.NewTableWriter()
+		defer p.CloseTableWriter()
+		p.SetHeaders("ID", "APPLICATION", "ACTION", "ON", "NEXT RUN")
+		for _, s := range schedules {
+			p.AppendRow(s.ID, s.Application, s.Action, s.Calendar, schedule.NextRun(s))
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	listCmd.Flags().StringVarP(&listOutput, "output", "o", "", "Output format (json)")
+}
@@ -0,0 +1,40 @@
+package schedule
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/audit"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+	"github.com/project-ai-services/ai-services/internal/pkg/schedule"
+)
+
+var removeCmd = &cobra.Command{
+	Use:   "remove [id]",
+	Short: "Remove a scheduled action",
+	Long: `Removes a scheduled action along with its systemd timer.
+
+Arguments
+  [id]: Schedule ID, as listed by 'ai-services application schedule list' (required)`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		s, err := schedule.Remove(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to remove schedule: %w", err)
+		}
+		machine.MarkChanged()
+
+		if err := audit.Record(audit.Entry{Application: s.Application, Action: "schedule remove",
+			Details: fmt.Sprintf("%s on %s", s.Action, s.Calendar)}); err != nil {
+			logger.Warningf("failed to record the schedule removal in the audit history: %v\n", err)
+		}
+
+		logger.Infof("Schedule %s removed\n", s.ID)
+		return nil
+	},
+}
@@ -0,0 +1,25 @@
+package schedule
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var ScheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "Manage the recurring actions of the applications",
+	Long: `Manages recurring actions on the applications, Eg:- a nightly restart, a weekly model refresh or stopping
+off-hours to free the Spyre cards.
+
+Each schedule is executed by a generated systemd timer (ai-services-schedule-<id>.timer) running the CLI in
+machine mode, the output of the runs is available with 'journalctl -u ai-services-schedule-<id>'.`,
+	Args: cobra.MaximumNArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmd.Help()
+	},
+}
+
+func init() {
+	ScheduleCmd.AddCommand(addCmd)
+	ScheduleCmd.AddCommand(listCmd)
+	ScheduleCmd.AddCommand(removeCmd)
+}
@@ -0,0 +1,72 @@
+package application
+
+import (
+	"fmt"
+
+	"github.com/containers/podman/v5/pkg/domain/entities/types"
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/helpers"
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/templates"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/podman"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+var smokeTestCmd = &cobra.Command{
+	Use:   "smoke-test [name]",
+	Short: "Runs the smoke tests of an application",
+	Long: `Runs the smoke tests declared by the application template against the running application
+and reports the results. Smoke tests are run automatically at the end of create.
+
+Arguments
+  [name]: Application name (required)`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		appName := args[0]
+
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		runtimeClient, err := podman.NewPodmanClient()
+		if err != nil {
+			return fmt.Errorf("failed to connect to podman: %w", err)
+		}
+
+		resp, err := runtimeClient.ListPods(map[string][]string{
+			"label": {fmt.Sprintf("ai-services.io/application=%s", appName)},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list pods: %w", err)
+		}
+
+		var pods []*types.ListPodsReport
+		if val, ok := resp.([]*types.ListPodsReport); ok {
+			pods = val
+		}
+
+		if len(pods) == 0 {
+			return fmt.Errorf("application '%s' does not exist", appName)
+		}
+
+		appTemplate := pods[0].Labels[string(vars.TemplateLabel)]
+
+		tp := templates.NewEmbedTemplateProvider(templates.EmbedOptions{})
+		appMetadata, err := tp.LoadMetadata(appTemplate)
+		if err != nil {
+			return fmt.Errorf("failed to read the app metadata: %w", err)
+		}
+
+		if len(appMetadata.SmokeTests) == 0 {
+			logger.Infof("No smoke tests declared by the application template '%s'\n", appTemplate)
+			return nil
+		}
+
+		logger.Infof("Running smoke tests for application '%s'...\n", appName)
+		results := helpers.RunSmokeTests(runtimeClient, tp, appTemplate, appName, appMetadata.SmokeTests)
+		machine.SetData(results)
+
+		return helpers.PrintSmokeTestResults(results)
+	},
+}
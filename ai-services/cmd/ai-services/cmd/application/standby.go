@@ -0,0 +1,308 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/audit"
+	"github.com/project-ai-services/ai-services/internal/pkg/hosts"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+	"github.com/project-ai-services/ai-services/pkg/aiservices"
+)
+
+var (
+	standbyHost      string
+	standbySync      string
+	standbyHook      string
+	standbyInventory string
+)
+
+var standbyCmd = &cobra.Command{
+	Use:   "standby",
+	Short: "Manage the warm standby of the applications on a second host",
+	Long: `Deploys an application in active/standby across this host and a standby host of the hosts inventory, for a
+higher availability of the critical inference services.
+
+The application is deployed on the standby host with the values of its last deployed revision and stopped there, so
+that its images and pods are ready to start. The model directory and the volumes of the application are synchronized
+to the standby host with rsync over SSH, or by a hook for the volumes on a storage shared by both hosts.
+'application failover' promotes the standby host.`,
+	Example: `  # Deploy a warm standby of application 'rag' on lpar2
+  ai-services application standby enable rag --host lpar2
+
+  # Synchronize the volumes to the standby, Eg:- from an 'application schedule'
+  ai-services application standby sync rag
+
+  # Promote the standby
+  ai-services application failover rag`,
+	Args: cobra.MaximumNArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmd.Help()
+	},
+}
+
+var standbyEnableCmd = &cobra.Command{
+	Use:   "enable [name]",
+	Short: "Deploys a warm standby of an application on a standby host",
+	Long: `Deploys the application on the standby host with the values of its last deployed revision, its TLS certificate
+and its runtime config, stops it there and synchronizes its volumes.
+
+Arguments
+  [name]: Application name (required)`,
+	Example: `  ai-services application standby enable rag --host lpar2
+  ai-services application standby enable rag --host lpar2 --sync shared --sync-hook '/usr/local/bin/snapshot.sh'`,
+	Args: cobra.ExactArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := aiservices.ValidateSyncMethod(standbySync); err != nil {
+			return err
+		}
+		if standbySync == aiservices.SyncShared && standbyHook == "" {
+			return fmt.Errorf("--sync-hook is required with --sync %s", aiservices.SyncShared)
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		applicationName := args[0]
+
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		target, err := standbyTarget(standbyHost)
+		if err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+		client, err := aiservices.NewClient(ctx)
+		if err != nil {
+			return err
+		}
+
+		export, err := client.ExportApplication(ctx, applicationName)
+		if err != nil {
+			return fmt.Errorf("failed to export application: %w", err)
+		}
+
+		dir, err := os.MkdirTemp("", "ai-services-standby-")
+		if err != nil {
+			return fmt.Errorf("failed to create temporary directory: %w", err)
+		}
+		defer os.RemoveAll(dir)
+
+		files, err := writeExportFiles(dir, export)
+		if err != nil {
+			return err
+		}
+
+		logger.Infof("Checking the capacity of %s for template %s...\n", standbyHost, export.Template)
+		if err := checkTargetCapacity(ctx, target, export.Template, files.values); err != nil {
+			return err
+		}
+		if err := deployOnTarget(ctx, target, export, files); err != nil {
+			return err
+		}
+
+		// the standby is kept warm: deployed, but stopped until promoted
+		logger.Infof("Stopping application %s on the standby host %s...\n", applicationName, standbyHost)
+		if err := runOnTarget(ctx, target, hosts.Command{Args: []string{"application", "stop", applicationName}}); err != nil {
+			return fmt.Errorf("failed to stop the standby on %s: %w", standbyHost, err)
+		}
+
+		pair := aiservices.StandbyPair{
+			Application: applicationName,
+			Host:        standbyHost,
+			Sync:        standbySync,
+			Hook:        standbyHook,
+			State:       aiservices.StandbyWarm,
+			Enabled:     time.Now().UTC(),
+		}
+		if err := aiservices.RecordStandby(pair); err != nil {
+			return fmt.Errorf("failed to record the standby: %w", err)
+		}
+		if err := syncStandby(ctx, client, &pair, target); err != nil {
+			return err
+		}
+
+		if err := audit.Record(audit.Entry{Application: applicationName, Action: "standby enable", Details: "on " + standbyHost}); err != nil {
+			logger.Warningf("failed to record the standby in the audit history: %v\n", err)
+		}
+		machine.SetData(pair)
+		logger.Infof("Application %s has a warm standby on %s\n", applicationName, standbyHost)
+		return nil
+	},
+}
+
+var standbySyncCmd = &cobra.Command{
+	Use:   "sync [name]",
+	Short: "Synchronizes the volumes of an application to its standby host",
+	Long: `Synchronizes the model directory and the volumes of the application to its standby host, with rsync over SSH or
+with the hook of the shared storage
+
+Arguments
+  [name]: Application name (required)`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		applicationName := args[0]
+
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		pair, err := aiservices.GetStandby(applicationName)
+		if err != nil {
+			return err
+		}
+		if pair.State == aiservices.StandbyPromoted {
+			return fmt.Errorf("the standby of application '%s' on %s was promoted, enable a new standby from %s", applicationName, pair.Host, pair.Host)
+		}
+		target, err := standbyTarget(pair.Host)
+		if err != nil {
+			return err
+		}
+
+		client, err := aiservices.NewClient(context.Background())
+		if err != nil {
+			return err
+		}
+		if err := syncStandby(context.Background(), client, pair, target); err != nil {
+			return err
+		}
+		machine.SetData(pair)
+		logger.Infof("Volumes of application %s synchronized to %s\n", applicationName, pair.Host)
+		return nil
+	},
+}
+
+var standbyStatusCmd = &cobra.Command{
+	Use:   "status [name]",
+	Short: "Shows the standby of all or specified application(s)",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		pairs, err := aiservices.ListStandby()
+		if err != nil {
+			return err
+		}
+		if len(args) > 0 {
+			pair, err := aiservices.GetStandby(args[0])
+			if err != nil {
+				return err
+			}
+			pairs = []aiservices.StandbyPair{*pair}
+		}
+		machine.SetData(pairs)
+
+		p := utils.NewTableWriter()
+		defer p.CloseTableWriter()
+		p.SetHeaders("APPLICATION", "STANDBY HOST", "STATE", "SYNC", "LAST SYNC", "SYNC ERROR")
+		for _, pair := range pairs {
+			lastSync := "never"
+			if !pair.LastSync.IsZero() {
+				lastSync = pair.LastSync.Local().Format(time.RFC3339)
+			}
+			p.AppendRow(pair.Application, pair.Host, pair.State, pair.Sync, lastSync, pair.SyncError)
+		}
+		return nil
+	},
+}
+
+var standbyDisableCmd = &cobra.Command{
+	Use:   "disable [name]",
+	Short: "Forgets the standby of an application",
+	Long: `Forgets the standby of the application. The application deployed on the standby host is kept, delete it there
+with 'application delete' if no longer needed.
+
+Arguments
+  [name]: Application name (required)`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		applicationName := args[0]
+
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		pair, err := aiservices.GetStandby(applicationName)
+		if err != nil {
+			return err
+		}
+		if err := aiservices.ReleaseStandby(applicationName); err != nil {
+			return err
+		}
+		machine.MarkChanged()
+		logger.Infof("Standby of application %s on %s disabled\n", applicationName, pair.Host)
+		return nil
+	},
+}
+
+// standbyTarget returns the standby host of the hosts inventory
+func standbyTarget(name string) ([]hosts.Host, error) {
+	inventory, err := hosts.Load(standbyInventory)
+	if err != nil {
+		return nil, err
+	}
+	return inventory.Select(false, []string{name})
+}
+
+// syncStandby synchronizes the volumes of the application to the standby host and records the outcome
+func syncStandby(ctx context.Context, client *aiservices.Client, pair *aiservices.StandbyPair, target []hosts.Host) error {
+	paths, err := client.StandbySyncPaths(ctx, pair.Application)
+	if err != nil {
+		return fmt.Errorf("failed to list the volumes of the application: %w", err)
+	}
+
+	logger.Infof("Synchronizing %d paths to %s (%s)...\n", len(paths), pair.Host, pair.Sync)
+	switch pair.Sync {
+	case aiservices.SyncShared:
+		// the hook is a shell command of the administrator, read from the state directory only its owner can write to
+		hook := exec.CommandContext(ctx, "sh", "-c", pair.Hook)
+		hook.Env = append(os.Environ(),
+			"AI_SERVICES_APPLICATION="+pair.Application,
+			"AI_SERVICES_STANDBY_HOST="+pair.Host,
+			"AI_SERVICES_SYNC_PATHS="+strings.Join(paths, ":"),
+		)
+		if out, hookErr := hook.CombinedOutput(); hookErr != nil {
+			err = fmt.Errorf("sync hook failed: %w: %s", hookErr, strings.TrimSpace(string(out)))
+		}
+	default:
+		err = target[0].Mirror(ctx, paths)
+	}
+
+	syncErr := ""
+	if err != nil {
+		syncErr = err.Error()
+	}
+	if updateErr := aiservices.UpdateStandby(pair.Application, func(p *aiservices.StandbyPair) {
+		p.SyncError = syncErr
+		if err == nil {
+			p.LastSync = time.Now().UTC()
+		}
+		*pair = *p
+	}); updateErr != nil {
+		logger.Warningf("failed to record the synchronization: %v\n", updateErr)
+	}
+	return err
+}
+
+func init() {
+	standbyCmd.PersistentFlags().StringVar(&standbyInventory, "inventory", "", "Path of the hosts inventory (default: $"+hosts.InventoryEnv+" or "+hosts.DefaultInventory+")")
+	standbyEnableCmd.Flags().StringVar(&standbyHost, "host", "", "Host of the hosts inventory to deploy the standby on (required)")
+	standbyEnableCmd.Flags().StringVar(&standbySync, "sync", aiservices.SyncRsync, "Synchronization method of the volumes: rsync over SSH, or shared for the volumes on a shared storage")
+	standbyEnableCmd.Flags().StringVar(&standbyHook, "sync-hook", "", "Shell command synchronizing the volumes with --sync shared, run with AI_SERVICES_APPLICATION, AI_SERVICES_STANDBY_HOST\n"+
+		"and AI_SERVICES_SYNC_PATHS (colon-separated) in its environment. It is stored in the state directory and run\n"+
+		"with 'sh -c' as the user running the synchronizations and failovers, Eg:- root")
+	_ = standbyEnableCmd.MarkFlagRequired("host")
+
+	standbyCmd.AddCommand(standbyEnableCmd)
+	standbyCmd.AddCommand(standbySyncCmd)
+	standbyCmd.AddCommand(standbyStatusCmd)
+	standbyCmd.AddCommand(standbyDisableCmd)
+}
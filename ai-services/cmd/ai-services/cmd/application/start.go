@@ -5,7 +5,9 @@ import (
 	"strings"
 
 	"github.com/containers/podman/v5/pkg/domain/entities/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/i18n"
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
 	"github.com/project-ai-services/ai-services/internal/pkg/runtime/podman"
 	"github.com/project-ai-services/ai-services/internal/pkg/utils"
 	"github.com/spf13/cobra"
@@ -120,12 +122,13 @@ func startApplication(cmd *cobra.Command, client *podman.PodmanClient, appName s
 		logger.Infof("\t-> %s\n", pod.Name)
 	}
 
-	printLogs := len(podsToStart) == 1 && !skipLogs
+	// machine mode is non-interactive, logs would be followed forever
+	printLogs := len(podsToStart) == 1 && !skipLogs && !machine.Enabled
 	if printLogs {
 		logger.Infoln("Note: After starting the pod, logs will be displayed. Press Ctrl+C to exit the logs and return to the terminal.")
 	}
 
-	confirmStart, err := utils.ConfirmAction("Are you sure you want to start above pods? ")
+	confirmStart, err := utils.ConfirmAction(i18n.T("prompt.start"))
 	if err != nil {
 		return fmt.Errorf("failed to take user input: %w", err)
 	}
@@ -157,6 +160,7 @@ func startApplication(cmd *cobra.Command, client *podman.PodmanClient, appName s
 			errors = append(errors, errMsg)
 			continue
 		}
+		machine.MarkChanged()
 		logger.Infof("Successfully started the pod: %s\n", pod.Name)
 	}
 
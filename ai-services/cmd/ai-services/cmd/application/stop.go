@@ -5,7 +5,9 @@ import (
 	"strings"
 
 	"github.com/containers/podman/v5/pkg/domain/entities/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/i18n"
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
 	"github.com/project-ai-services/ai-services/internal/pkg/runtime/podman"
 	"github.com/project-ai-services/ai-services/internal/pkg/utils"
 	"github.com/spf13/cobra"
@@ -116,7 +118,7 @@ func stopApplication(cmd *cobra.Command, client *podman.PodmanClient, appName st
 		logger.Infof("\t-> %s\n", pod.Name)
 	}
 
-	confirmStop, err := utils.ConfirmAction("Are you sure you want to stop the above pods? ")
+	confirmStop, err := utils.ConfirmAction(i18n.T("prompt.stop"))
 	if err != nil {
 		return fmt.Errorf("failed to take user input: %w", err)
 	}
@@ -131,12 +133,17 @@ func stopApplication(cmd *cobra.Command, client *podman.PodmanClient, appName st
 	// 3. Proceed to stop only the valid pods
 	var errors []string
 	for _, pod := range podsToStop {
+		if pod.Status == "Exited" || pod.Status == "Stopped" {
+			logger.Infof("Pod %s is already stopped. Skipping...\n", pod.Name)
+			continue
+		}
 		logger.Infof("Stopping the pod: %s\n", pod.Name)
 		if err := client.StopPod(pod.Id); err != nil {
 			errMsg := fmt.Sprintf("%s: %v", pod.Name, err)
 			errors = append(errors, errMsg)
 			continue
 		}
+		machine.MarkChanged()
 		logger.Infof("Successfully stopped the pod: %s\n", pod.Name)
 	}
 
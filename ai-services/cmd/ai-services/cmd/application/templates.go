@@ -3,11 +3,13 @@ package application
 import (
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/project-ai-services/ai-services/internal/pkg/cli/templates"
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
 )
 
 var templatesCmd = &cobra.Command{
@@ -39,12 +41,19 @@ var templatesCmd = &cobra.Command{
 		sort.Strings(appTemplateNames)
 
 		logger.Infoln("Available application templates:")
+		data := map[string]map[string]string{}
+		defer machine.SetData(data)
 		for _, name := range appTemplateNames {
 			appTemplatesParametersWithDescription, err := tp.ListApplicationTemplateValues(name)
 			if err != nil {
 				return fmt.Errorf("failed to list application template values: %w", err)
 			}
-			logger.Infof("- %s\n    Supported Parameters:\n", name)
+			data[name] = appTemplatesParametersWithDescription
+			logger.Infof("- %s\n", name)
+			if envs, err := tp.ListEnvironments(name); err == nil && len(envs) > 0 {
+				logger.Infof("    Environments: %s\n", strings.Join(envs, ", "))
+			}
+			logger.Infoln("    Supported Parameters:")
 			for k, v := range appTemplatesParametersWithDescription {
 				logger.Infoln("\t" + k + "\t\t-- " + v)
 			}
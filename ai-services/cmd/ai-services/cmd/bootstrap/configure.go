@@ -10,12 +10,15 @@ import (
 	"time"
 
 	"github.com/project-ai-services/ai-services/internal/pkg/cli/helpers"
+	"github.com/project-ai-services/ai-services/internal/pkg/firewall"
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/registries"
 	"github.com/project-ai-services/ai-services/internal/pkg/spinner"
 	"github.com/project-ai-services/ai-services/internal/pkg/validators"
 	"github.com/project-ai-services/ai-services/internal/pkg/validators/root"
 	"github.com/project-ai-services/ai-services/internal/pkg/validators/spyre"
 	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+	"github.com/project-ai-services/ai-services/pkg/aiservices"
 	"github.com/spf13/cobra"
 	"k8s.io/klog/v2"
 )
@@ -82,6 +85,31 @@ func RunConfigureCmd() error {
 		s.Stop("Podman already configured")
 	}
 
+	s = spinner.New("Configuring registry mirrors")
+	s.Start(ctx)
+	// 1.3 Configure the registry mirrors the images are pulled from, required by the disconnected hosts
+	if changed, err := registries.Configure(); err != nil {
+		s.Fail("failed to configure registry mirrors")
+		return err
+	} else if changed {
+		s.Stop("Registry mirrors configured in " + registries.DropInFile)
+	} else {
+		s.Stop("Registry mirrors already configured")
+	}
+
+	// 1.4 Open the ports exposed externally by the applications, when the firewall is managed by ai-services
+	if cfg, err := firewall.Load(); err != nil {
+		return err
+	} else if cfg.Manage {
+		s = spinner.New("Configuring firewall")
+		s.Start(ctx)
+		if err := configureFirewall(ctx); err != nil {
+			s.Fail("failed to configure firewall")
+			return err
+		}
+		s.Stop("Firewall configured successfully")
+	}
+
 	s = spinner.New("Checking spyre card configuration")
 	s.Start(ctx)
 	// 2. Spyre cards – run servicereport tool to validate and repair spyre configurations
@@ -96,6 +124,14 @@ func RunConfigureCmd() error {
 	return nil
 }
 
+func configureFirewall(ctx context.Context) error {
+	client, err := aiservices.NewClient(ctx)
+	if err != nil {
+		return err
+	}
+	return client.SyncFirewall(ctx)
+}
+
 func runServiceReport() error {
 	// validate spyre attachment first before running servicereport
 	spyreCheck := spyre.NewSpyreRule()
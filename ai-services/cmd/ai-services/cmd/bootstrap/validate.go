@@ -2,33 +2,58 @@ package bootstrap
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"os/signal"
+	"slices"
 	"strings"
+	"syscall"
+	"time"
 
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/audit"
 	"github.com/project-ai-services/ai-services/internal/pkg/cli/helpers"
+	"github.com/project-ai-services/ai-services/internal/pkg/compliance"
 	"github.com/project-ai-services/ai-services/internal/pkg/constants"
+	"github.com/project-ai-services/ai-services/internal/pkg/heartbeat"
+	"github.com/project-ai-services/ai-services/internal/pkg/i18n"
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
 	"github.com/project-ai-services/ai-services/internal/pkg/spinner"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
 	"github.com/project-ai-services/ai-services/internal/pkg/validators"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
 	"github.com/spf13/cobra"
 )
 
 // Validation check types
 const (
-	CheckRoot   = "root"
-	CheckRHEL   = "rhel"
-	CheckRHN    = "rhn"
-	CheckPower  = "power"
-	CheckRHAIIS = "rhaiis"
-	CheckNUMA   = "numa"
+	CheckRoot     = "root"
+	CheckRHEL     = "rhel"
+	CheckRHN      = "rhn"
+	CheckPower    = "power"
+	CheckRHAIIS   = "rhaiis"
+	CheckNUMA     = "numa"
+	CheckSpyre    = "spyre"
+	CheckFirewall = "firewall"
 )
 
+// rootOnlyChecks are the checks skipped in rootless mode, as the steps they validate are skipped
+var rootOnlyChecks = []string{CheckRoot, CheckRHN, CheckSpyre}
+
 const troubleshootingGuide = "https://www.ibm.com/docs/aiservices?topic=services-troubleshooting"
 
 // validateCmd represents the validate subcommand of bootstrap
 func validateCmd() *cobra.Command {
 
 	var skipChecks []string
+	var output string
+	var watch bool
+	var interval time.Duration
+	var heartbeatFile string
 
 	cmd := &cobra.Command{
 		Use:   "validate",
@@ -58,7 +83,8 @@ Available checks to skip:
   rhn             - Red Hat Network registration check
   power  		  - Power architecture check
   rhaiis   		  - RHAIIS license check
-  numa			  - NUMA node check`,
+  numa			  - NUMA node check
+  firewall        - Published ports of the applications open in firewalld check`,
 		Example: `  # Run all validation checks
   aiservices bootstrap validate
 
@@ -69,75 +95,343 @@ Available checks to skip:
   aiservices bootstrap validate --skip-validation rhn,power
   
   # Run with verbose output
-  aiservices bootstrap validate --verbose`,
+  aiservices bootstrap validate --verbose
+
+  # Print the validation summary as JSON
+  aiservices bootstrap validate -o json
+
+  # Validate the host every 30 minutes, alerting when it drifts out of its validated configuration
+  aiservices bootstrap validate --watch --interval 30m`,
 		Hidden: true,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if output != "" && output != "json" {
+				return fmt.Errorf("unsupported output format %q, supported formats: json", output)
+			}
+			if watch && interval < time.Minute {
+				return fmt.Errorf("--interval must be at least 1m")
+			}
+			return nil
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Once precheck passes, silence usage for any *later* internal errors.
 			cmd.SilenceUsage = true
 
-			logger.Infoln("Running bootstrap validation...")
-
 			skip := helpers.ParseSkipChecks(skipChecks)
+
+			if watch {
+				return watchValidation(cmd.Context(), skip, interval, heartbeatFile)
+			}
+
+			if output == "json" {
+				summary, err := Validate(skip, false)
+				recordCompliance(summary)
+				machine.SetData(summary)
+				out, marshalErr := json.MarshalIndent(summary, "", "  ")
+				if marshalErr != nil {
+					return fmt.Errorf("failed to marshal validation summary: %w", marshalErr)
+				}
+				fmt.Println(string(out))
+				if err != nil {
+					return fmt.Errorf("bootstrap validation failed: %w", err)
+				}
+				return nil
+			}
+
+			logger.Infoln(i18n.T("validate.running"))
+
 			if len(skip) > 0 {
-				logger.Warningln("Skipping validation checks: " + strings.Join(skipChecks, ", "))
+				logger.Warningln(i18n.T("validate.skipping", strings.Join(skipChecks, ", ")))
 			}
 
-			err := RunValidateCmd(skip)
+			summary, err := Validate(skip, true)
+			recordCompliance(summary)
+			machine.SetData(summary)
+			printValidationSummary(summary)
 			if err != nil {
-				logger.Infof("Please refer to troubleshooting guide for more information: %s", troubleshootingGuide)
+				logger.Infoln(i18n.T("validate.troubleshooting", troubleshootingGuide))
 				return fmt.Errorf("bootstrap validation failed: %w", err)
 			}
 
+			logger.Infoln(i18n.T("validate.passed"))
+
 			return nil
 		},
 	}
 
 	cmd.Flags().StringSliceVar(&skipChecks, "skip-validation", []string{},
-		"Skip specific validation checks (comma-separated: root,rhel,rhn,power,rhaiis,numa)")
+		"Skip specific validation checks (comma-separated: root,rhel,rhn,power,rhaiis,numa,firewall)")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Output format of the validation summary (json)")
+	cmd.Flags().BoolVar(&watch, "watch", false, "Validate the host periodically until interrupted, recording the results and alerting when the host drifts out of its validated configuration")
+	cmd.Flags().DurationVar(&interval, "interval", time.Hour, "Interval of the validations with --watch")
+	cmd.Flags().StringVar(&heartbeatFile, "heartbeat-file", "", "File the status of the --watch daemon is written to every 15s, for the supervisors to detect a hung daemon")
 
 	return cmd
 }
 
+// watchValidation validates the host every interval until the context is cancelled or interrupted, recording each
+// validation in the heartbeat file, if any.
+func watchValidation(ctx context.Context, skip map[string]bool, interval time.Duration, heartbeatFile string) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	hb, err := heartbeat.Start(heartbeatFile, "bootstrap validate --watch", 0)
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("Validating the host every %s\n", interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	validate := func() {
+		summary, err := Validate(skip, false)
+		recordCompliance(summary)
+		if err != nil {
+			logger.Infof("%s validation: %d passed, %d failed (%s)\n", time.Now().Format(time.RFC3339),
+				summary.Total.Passed, summary.Total.Failed, strings.Join(summary.failedChecks(), ", "))
+			hb.Progress(fmt.Sprintf("validation: %d failed (%s)", summary.Total.Failed, strings.Join(summary.failedChecks(), ", ")))
+		} else {
+			logger.Infof("%s validation: %d passed\n", time.Now().Format(time.RFC3339), summary.Total.Passed, 0)
+			hb.Progress(fmt.Sprintf("validation: %d passed", summary.Total.Passed))
+		}
+	}
+
+	validate()
+	for {
+		select {
+		case <-ctx.Done():
+			hb.Stop(nil)
+			return nil
+		case <-ticker.C:
+			validate()
+		}
+	}
+}
+
+// recordCompliance records the validation in the compliance history, alerting when the host drifted out of its
+// validated configuration
+func recordCompliance(summary *ValidationSummary) {
+	if summary == nil {
+		return
+	}
+	failed := summary.failedChecks()
+	drifted, err := compliance.Add(compliance.Record{
+		Passed:       summary.Total.Passed,
+		Failed:       summary.Total.Failed,
+		Warnings:     summary.Total.Warnings,
+		FailedChecks: failed,
+	})
+	if err != nil {
+		logger.Warningf("failed to record the validation in the compliance history: %v\n", err)
+		return
+	}
+	if !drifted {
+		return
+	}
+
+	logger.Errorf("host drifted out of its validated configuration, failed checks: %s\n", strings.Join(failed, ", "))
+	if err := audit.Record(audit.Entry{Action: "compliance drift", Details: strings.Join(failed, ",")}); err != nil {
+		logger.Warningf("failed to record the drift in the audit history: %v\n", err)
+	}
+}
+
+// Validation check statuses
+const (
+	StatusPass = "pass"
+	StatusFail = "fail"
+	StatusWarn = "warn"
+	StatusSkip = "skip"
+)
+
+// CheckResult is the result of a validation check
+type CheckResult struct {
+	Name     string                       `json:"name"`
+	Category constants.ValidationCategory `json:"category"`
+	Status   string                       `json:"status"`
+	Message  string                       `json:"message,omitempty"`
+	Hint     string                       `json:"hint,omitempty"`
+	Duration time.Duration                `json:"durationNanoseconds"`
+}
+
+// CategorySummary counts the results of the checks of a category
+type CategorySummary struct {
+	Category constants.ValidationCategory `json:"category"`
+	Passed   int                          `json:"passed"`
+	Failed   int                          `json:"failed"`
+	Warnings int                          `json:"warnings"`
+	Skipped  int                          `json:"skipped"`
+}
+
+func (c *CategorySummary) count(status string) {
+	switch status {
+	case StatusPass:
+		c.Passed++
+	case StatusFail:
+		c.Failed++
+	case StatusWarn:
+		c.Warnings++
+	case StatusSkip:
+		c.Skipped++
+	}
+}
+
+// ValidationSummary is the result of the validation checks, grouped by category
+type ValidationSummary struct {
+	Checks     []CheckResult     `json:"checks"`
+	Categories []CategorySummary `json:"categories"`
+	Total      CategorySummary   `json:"total"`
+}
+
+// failedChecks returns the names of the failed checks
+func (s *ValidationSummary) failedChecks() []string {
+	var failed []string
+	for _, c := range s.Checks {
+		if c.Status == StatusFail {
+			failed = append(failed, c.Name)
+		}
+	}
+	return failed
+}
+
+// RunValidateCmd runs the validation checks not skipped, printing their progress and a summary table
 func RunValidateCmd(skip map[string]bool) error {
-	var validationErrors []error
+	summary, err := Validate(skip, true)
+	printValidationSummary(summary)
+	if err != nil {
+		return err
+	}
+
+	logger.Infoln(i18n.T("validate.passed"))
+
+	return nil
+}
+
+// Validate runs the validation checks not skipped and returns their summary, along with an error if any failed.
+// The progress of the checks is printed when verbose
+func Validate(skip map[string]bool, verbose bool) (*ValidationSummary, error) {
+	summary := &ValidationSummary{Total: CategorySummary{Category: "total"}}
 	ctx := context.Background()
 
+	var err error
 	for _, rule := range validators.DefaultRegistry.Rules() {
-		ruleName := rule.Name()
-		if skip[ruleName] {
-			logger.Warningf("%s check skipped; Proceeding without validation may result in deployment failure.", ruleName)
+		result := CheckResult{Name: rule.Name(), Category: rule.Category()}
+		if skip[result.Name] {
+			result.Status = StatusSkip
+			if verbose {
+				logger.Warningln(i18n.T("validate.skipped", result.Name))
+			}
+			summary.add(result)
+			continue
+		}
+		if vars.Rootless && slices.Contains(rootOnlyChecks, result.Name) {
+			result.Status, result.Message = StatusSkip, i18n.T("validate.rootless", result.Name)
+			if verbose {
+				logger.Infoln(result.Message)
+			}
+			summary.add(result)
 			continue
 		}
 
-		s := spinner.New("Validating " + ruleName + " ...")
-		s.Start(ctx)
-		err := rule.Verify()
-
-		if err != nil {
-			s.Fail(err.Error())
-			s.StopWithHint(err.Error(), rule.Hint())
+		var s *spinner.Spinner
+		if verbose {
+			s = spinner.New(i18n.T("validate.checking", result.Name))
+			s.Start(ctx)
+		}
+		start := time.Now()
+		verifyErr := rule.Verify()
+		result.Duration = time.Since(start)
 
-			// exit right away if user is not root as other check require root privileges
-			if ruleName == CheckRoot {
-				return fmt.Errorf("root privileges are required for validation")
+		switch {
+		case verifyErr == nil:
+			result.Status, result.Message = StatusPass, rule.Message()
+			if verbose {
+				s.Stop(result.Message)
 			}
-			switch rule.Level() {
-			case constants.ValidationLevelError:
-				s.Fail(err.Error())
-				validationErrors = append(validationErrors, fmt.Errorf("%s: %w", ruleName, err))
-			case constants.ValidationLevelWarning:
-				s.Stop("Warning: " + err.Error())
+		case rule.Level() == constants.ValidationLevelWarning && result.Name != CheckRoot:
+			result.Status, result.Message, result.Hint = StatusWarn, verifyErr.Error(), rule.Hint()
+			if verbose {
+				s.Stop(i18n.T("validate.warning", verifyErr.Error()))
 			}
-		} else {
-			s.Stop(rule.Message())
+		default:
+			result.Status, result.Message, result.Hint = StatusFail, verifyErr.Error(), rule.Hint()
+			if verbose {
+				s.StopWithHint(verifyErr.Error(), rule.Hint())
+			}
+		}
+		summary.add(result)
+
+		// exit right away if user is not root as other check require root privileges
+		if result.Status == StatusFail && result.Name == CheckRoot {
+			err = errors.New(i18n.T("validate.root-required"))
+			break
 		}
 	}
 
-	if len(validationErrors) > 0 {
-		return fmt.Errorf("%d validation check(s) failed", len(validationErrors))
+	if err == nil && summary.Total.Failed > 0 {
+		err = errors.New(i18n.T("validate.failed", summary.Total.Failed))
+	}
+	if err != nil {
+		return summary, machine.WithExitCode(machine.ExitValidationFailed, err)
 	}
 
-	logger.Infoln("All validations passed")
+	return summary, nil
+}
 
-	return nil
+func (s *ValidationSummary) add(result CheckResult) {
+	s.Checks = append(s.Checks, result)
+	s.Total.count(result.Status)
+
+	i := slices.IndexFunc(s.Categories, func(c CategorySummary) bool { return c.Category == result.Category })
+	if i < 0 {
+		s.Categories = append(s.Categories, CategorySummary{Category: result.Category})
+		i = len(s.Categories) - 1
+	}
+	s.Categories[i].count(result.Status)
+}
+
+var (
+	passStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
+	failStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("1")).Bold(true)
+	warnStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("3"))
+	skipStyle = lipgloss.NewStyle().Faint(true)
+)
+
+// printValidationSummary prints the checks grouped by category, followed by the colored counts of each category
+func printValidationSummary(summary *ValidationSummary) {
+	checks := slices.Clone(summary.Checks)
+	order := func(c constants.ValidationCategory) int {
+		return slices.IndexFunc(summary.Categories, func(s CategorySummary) bool { return s.Category == c })
+	}
+	slices.SortStableFunc(checks, func(a, b CheckResult) int { return order(a.Category) - order(b.Category) })
+
+	logger.Infoln("")
+	p := utils.NewTableWriter()
+	p.SetHeaders("CATEGORY", "CHECK", "STATUS", "DURATION", "DETAILS")
+	for _, c := range checks {
+		duration := "-"
+		if c.Status != StatusSkip {
+			duration = c.Duration.Round(time.Millisecond).String()
+		}
+		p.AppendRow(string(c.Category), c.Name, strings.ToUpper(c.Status), duration, c.Message)
+	}
+	p.CloseTableWriter()
+
+	for _, c := range append(summary.Categories, summary.Total) {
+		logger.Infof("%-12s %s  %s  %s  %s\n", string(c.Category)+":",
+			passStyle.Render(fmt.Sprintf("%d passed", c.Passed)),
+			styleIf(failStyle, c.Failed > 0).Render(fmt.Sprintf("%d failed", c.Failed)),
+			styleIf(warnStyle, c.Warnings > 0).Render(fmt.Sprintf("%d warnings", c.Warnings)),
+			skipStyle.Render(fmt.Sprintf("%d skipped", c.Skipped)))
+	}
+}
+
+// styleIf returns the style when set, the unstyled text drawing the attention to the non-zero counts only
+func styleIf(style lipgloss.Style, set bool) lipgloss.Style {
+	if set {
+		return style
+	}
+	return lipgloss.NewStyle()
 }
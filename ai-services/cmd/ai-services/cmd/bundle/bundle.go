@@ -0,0 +1,27 @@
+package bundle
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// BundleCmd represents the bundle command
+var BundleCmd = &cobra.Command{
+	Use:   "bundle",
+	Short: "Export and import offline bundles for air-gapped deployments",
+	Long: `Export all the container images and models required by an application template into a
+single tarball on a connected host, and import it on a disconnected Power host.`,
+	Example: `  # On a connected host
+  ai-services bundle export --template RAG --output rag-bundle.tar
+
+  # On the air-gapped host
+  ai-services bundle import rag-bundle.tar`,
+	Args: cobra.MaximumNArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmd.Help()
+	},
+}
+
+func init() {
+	BundleCmd.AddCommand(exportCmd)
+	BundleCmd.AddCommand(importCmd)
+}
@@ -0,0 +1,111 @@
+package bundle
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/bundle"
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/helpers"
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/templates"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/podman"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+	"github.com/project-ai-services/ai-services/internal/pkg/validators"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+var (
+	templateName  string
+	output        string
+	acceptLicense bool
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Exports the images and models of an application template into a bundle",
+	Long: `Pulls all the container images and downloads all the models required by the given
+application template and packs them into a single tarball which can be moved to an air-gapped host.`,
+	Args: cobra.MaximumNArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+		return export()
+	},
+}
+
+func init() {
+	exportCmd.Flags().StringVarP(&templateName, "template", "t", "", "Application template name (Required)")
+	_ = exportCmd.MarkFlagRequired("template")
+	exportCmd.Flags().StringVarP(&output, "output", "o", "", "Path of the bundle file to be created (default: <template>-bundle.tar)")
+	exportCmd.Flags().StringVar(&vars.ModelDirectory, "dir", vars.ModelDirectory, "Directory used to download the model files")
+	exportCmd.Flags().BoolVar(&acceptLicense, "accept-license", false, "Accept the license of the gated models being downloaded")
+}
+
+func export() error {
+	tp := templates.NewEmbedTemplateProvider(templates.EmbedOptions{})
+	if err := validators.ValidateAppTemplateExist(tp, templateName); err != nil {
+		return err
+	}
+
+	appMetadata, err := tp.LoadMetadata(templateName)
+	if err != nil {
+		return fmt.Errorf("failed to read the app metadata: %w", err)
+	}
+
+	images, err := helpers.ListImages(templateName, "")
+	if err != nil {
+		return fmt.Errorf("failed to list container images: %w", err)
+	}
+
+	modelNames, err := helpers.ListModels(templateName, "")
+	if err != nil {
+		return fmt.Errorf("failed to list models: %w", err)
+	}
+
+	runtimeClient, err := podman.NewPodmanClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to podman: %w", err)
+	}
+
+	logger.Infoln("Pulling container images required for application template " + templateName + ":")
+	for _, image := range images {
+		if err := runtimeClient.PullImage(image, nil); err != nil {
+			return fmt.Errorf("failed to pull image: %w", err)
+		}
+	}
+
+	logger.Infoln("Downloading models required for application template " + templateName + ":")
+	for _, model := range modelNames {
+		if utils.FileExists(filepath.Join(vars.ModelDirectory, models.ModelPath(model))) {
+			logger.Infof("Model %s is already present, skipping download\n", model)
+			continue
+		}
+		if err := helpers.DownloadModel(model, vars.ModelDirectory, acceptLicense); err != nil {
+			return fmt.Errorf("failed to download model: %w", err)
+		}
+	}
+
+	if output == "" {
+		output = templateName + "-bundle.tar"
+	}
+
+	logger.Infof("Creating bundle %s...\n", output)
+	if err := bundle.Export(bundle.ExportOptions{
+		Manifest: bundle.Manifest{
+			Template: templateName,
+			Version:  appMetadata.Version,
+			Images:   images,
+			Models:   modelNames,
+		},
+		ModelDirectory: vars.ModelDirectory,
+		Output:         output,
+	}); err != nil {
+		return fmt.Errorf("failed to export bundle: %w", err)
+	}
+
+	logger.Infof("Bundle %s created successfully\n", output)
+	return nil
+}
@@ -0,0 +1,63 @@
+package bundle
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/bundle"
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/helpers"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import [bundle]",
+	Short: "Imports the images and models from a bundle",
+	Long: `Loads the container images into podman storage and copies the models into the model
+directory from a bundle created with 'ai-services bundle export'. The models are verified against the digests
+recorded on export before they replace the models of the same name.
+
+Once imported, create the application with --skip-image-download and --skip-model-download.
+
+Arguments
+  [bundle]: Path to the bundle file (required)`,
+	Args: cobra.ExactArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if !utils.FileExists(args[0]) {
+			return fmt.Errorf("bundle file '%s' does not exist", args[0])
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		logger.Infof("Importing bundle %s...\n", args[0])
+		manifest, err := bundle.Import(args[0], vars.ModelDirectory)
+		if err != nil {
+			return fmt.Errorf("failed to import bundle: %w", err)
+		}
+
+		logger.Infof("Bundle for application template '%s' imported successfully\n", manifest.Template)
+		logger.Infoln("Images:")
+		for _, image := range manifest.Images {
+			logger.Infoln("- " + image)
+		}
+		logger.Infoln("Models:")
+		for _, model := range manifest.Models {
+			logger.Infoln("- " + model)
+			digest := manifest.Digests[model]
+			if err := helpers.RecordModelDigest(model, vars.ModelDirectory, "bundle", digest.SHA256, digest.Files); err != nil {
+				logger.Warningf("failed to record provenance of model %s: %v\n", model, err)
+			}
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	importCmd.Flags().StringVar(&vars.ModelDirectory, "dir", vars.ModelDirectory, "Directory to import the model files into")
+}
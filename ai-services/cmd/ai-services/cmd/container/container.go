@@ -0,0 +1,45 @@
+package container
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var appName string
+
+// ContainerCmd represents the container command
+var ContainerCmd = &cobra.Command{
+	Use:   "container",
+	Short: "Act on the individual containers of an application",
+	Long: `Thin wrappers around podman scoped to the containers of an application, to act on an individual misbehaving
+container without the podman filters on the ai-services labels.
+
+The containers are referred to by their name within their pod, Eg:- vllm-server, by their podman name
+<pod>-<container> when the name is shared by several pods, or by a prefix of their ID.`,
+	Example: `  # List the containers of application 'rag'
+  ai-services container list --app rag
+
+  # Restart a container of application 'rag'
+  ai-services container restart vllm-server --app rag`,
+	Args: cobra.MaximumNArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmd.Help()
+	},
+}
+
+func init() {
+	ContainerCmd.PersistentFlags().StringVar(&appName, "app", "", "Name of the application (required)")
+	ContainerCmd.AddCommand(listCmd)
+	ContainerCmd.AddCommand(inspectCmd)
+	ContainerCmd.AddCommand(logsCmd)
+	ContainerCmd.AddCommand(restartCmd)
+}
+
+// requireApp checks the application of the container subcommands is specified
+func requireApp(cmd *cobra.Command, args []string) error {
+	if appName == "" {
+		return fmt.Errorf("application name must be specified using --app flag")
+	}
+	return nil
+}
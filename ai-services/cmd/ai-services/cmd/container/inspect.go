@@ -0,0 +1,49 @@
+package container
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+)
+
+var inspectCmd = &cobra.Command{
+	Use:   "inspect [container]",
+	Short: "Displays the inspect data of a container of an application",
+	Long: `Displays the inspect data of a container of the application as JSON: its state, command, labels, mounts and
+last health checks. The environment of the container is left out, as it commonly carries credentials.
+
+Arguments
+  [container]: Name of the container within its pod, <pod>-<container> or ID prefix (required)`,
+	Example: `  # Inspect the vllm-server container of application 'rag'
+  ai-services container inspect vllm-server --app rag`,
+	Args:    cobra.ExactArgs(1),
+	PreRunE: requireApp,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		client, err := aiservices.NewClient(context.Background())
+		if err != nil {
+			return err
+		}
+		info, err := client.InspectContainer(context.Background(), appName, args[0])
+		if err != nil {
+			return err
+		}
+		machine.SetData(info)
+		if machine.Enabled {
+			return nil
+		}
+
+		data, err := json.MarshalIndent(info, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal the container inspect data: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	},
+}
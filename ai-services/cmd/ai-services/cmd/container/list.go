@@ -0,0 +1,47 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+)
+
+var listCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "Lists the containers of an application",
+	Long:    `Lists the containers of the pods of the application with their state, health and restarts`,
+	Args:    cobra.NoArgs,
+	PreRunE: requireApp,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		client, err := aiservices.NewClient(context.Background())
+		if err != nil {
+			return err
+		}
+		containers, err := client.ListContainers(context.Background(), appName)
+		if err != nil {
+			return err
+		}
+		machine.SetData(containers)
+
+		p := utils.NewTableWriter()
+		defer p.CloseTableWriter()
+		p.SetHeaders("POD", "CONTAINER", "ID", "IMAGE", "STATE", "HEALTH", "RESTARTS", "STARTED")
+		for _, ctr := range containers {
+			started := ""
+			if !ctr.StartedAt.IsZero() {
+				started = time.Since(ctr.StartedAt).Round(time.Second).String() + " ago"
+			}
+			p.AppendRow(ctr.Pod, ctr.Name, ctr.ID[:12], ctr.Image, ctr.State, ctr.Health, fmt.Sprintf("%d", ctr.Restarts), started)
+		}
+		return nil
+	},
+}
@@ -0,0 +1,62 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/pkg/aiservices"
+)
+
+var follow bool
+
+var logsCmd = &cobra.Command{
+	Use:   "logs [container]",
+	Short: "Shows the logs of a container of an application",
+	Long: `Shows the logs of a container of the application, the stderr lines on stderr
+
+Arguments
+  [container]: Name of the container within its pod, <pod>-<container> or ID prefix (required)`,
+	Example: `  # Follow the logs of the vllm-server container of application 'rag'
+  ai-services container logs vllm-server --app rag -f`,
+	Args:    cobra.ExactArgs(1),
+	PreRunE: requireApp,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		client, err := aiservices.NewClient(context.Background())
+		if err != nil {
+			return err
+		}
+		ctr, err := client.FindContainer(ctx, appName, args[0])
+		if err != nil {
+			return err
+		}
+
+		err = client.StreamLogs(ctx, appName, aiservices.LogsOptions{Pod: ctr.Pod, Container: ctr.ID, Follow: follow}, func(line aiservices.LogLine) error {
+			out := os.Stdout
+			if line.Stream == "stderr" {
+				out = os.Stderr
+			}
+			_, err := fmt.Fprintln(out, line.Line)
+			return err
+		})
+		if ctx.Err() != nil {
+			// interrupted while following the logs
+			return nil
+		}
+		return err
+	},
+}
+
+func init() {
+	logsCmd.Flags().BoolVarP(&follow, "follow", "f", false, "Keep streaming the new log lines")
+}
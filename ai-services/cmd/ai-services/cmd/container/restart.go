@@ -0,0 +1,41 @@
+package container
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+)
+
+var restartCmd = &cobra.Command{
+	Use:   "restart [container]",
+	Short: "Restarts a container of an application",
+	Long: `Restarts a container of the application, the other containers of its pod keep running
+
+Arguments
+  [container]: Name of the container within its pod, <pod>-<container> or ID prefix (required)`,
+	Example: `  # Restart the vllm-server container of application 'rag'
+  ai-services container restart vllm-server --app rag`,
+	Args:    cobra.ExactArgs(1),
+	PreRunE: requireApp,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		client, err := aiservices.NewClient(context.Background())
+		if err != nil {
+			return err
+		}
+		ctr, err := client.RestartContainer(context.Background(), appName, args[0])
+		if err != nil {
+			return err
+		}
+		machine.MarkChanged()
+		machine.SetData(ctr)
+
+		logger.Infof("Container %s of pod %s restarted\n", ctr.Name, ctr.Pod)
+		return nil
+	},
+}
@@ -0,0 +1,21 @@
+package debug
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// DebugCmd represents the debug command
+var DebugCmd = &cobra.Command{
+	Use:   "debug",
+	Short: "Troubleshooting and resilience testing facilities",
+	Long: `Troubleshooting and resilience testing facilities. Not meant for production hosts: the injected faults
+disrupt the deployed applications.`,
+	Args: cobra.MaximumNArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmd.Help()
+	},
+}
+
+func init() {
+	DebugCmd.AddCommand(injectCmd)
+}
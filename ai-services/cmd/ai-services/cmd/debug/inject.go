@@ -0,0 +1,217 @@
+package debug
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/faults"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/podman"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+)
+
+var (
+	faultDuration time.Duration
+	killSignal    string
+	spyreCount    int
+	faultsOutput  string
+)
+
+var injectCmd = &cobra.Command{
+	Use:   "inject",
+	Short: "Inject failures to validate the resilience of the applications",
+	Long: `Injects failures to validate that healing, alerts and rollback behave correctly before a production rollout.
+
+The simulated faults (spyre-unavailable, readiness-delay) are active until they expire (--duration) or are
+cleared, and affect the subsequent ai-services commands on this host.`,
+	Example: `  # kill a container of an application
+  ai-services debug inject kill my-app--vllm-server-instruct
+
+  # report 2 free Spyre cards as in use for the next 30 minutes
+  ai-services debug inject spyre-unavailable --count 2 --duration 30m
+
+  # delay the readiness checks of the containers by 5 minutes
+  ai-services debug inject readiness-delay 5m
+
+  ai-services debug inject list
+  ai-services debug inject clear`,
+	Args: cobra.MaximumNArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmd.Help()
+	},
+}
+
+var killCmd = &cobra.Command{
+	Use:   "kill [container]",
+	Short: "Kill a container",
+	Long: `Kills a container with the signal, SIGKILL by default, to validate its restart policy and health monitoring.
+
+Arguments
+  [container]: Container name or ID (required)`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		container := args[0]
+
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		runtimeClient, err := podman.NewPodmanClient()
+		if err != nil {
+			return fmt.Errorf("failed to connect to podman: %w", err)
+		}
+
+		if err := runtimeClient.KillContainer(container, killSignal); err != nil {
+			return err
+		}
+		machine.MarkChanged()
+		logger.Infof("Container %s killed with %s\n", container, killSignal)
+
+		return nil
+	},
+}
+
+var spyreUnavailableCmd = &cobra.Command{
+	Use:   "spyre-unavailable",
+	Short: "Simulate Spyre cards being detached",
+	Long: `Reports the given number of free Spyre cards as in use, Eg:- to validate the behaviour of create and rollback
+when the cards are missing.`,
+	Args: cobra.MaximumNArgs(0),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if spyreCount < 1 {
+			return fmt.Errorf("--count must be at least 1")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		return inject(faults.Fault{Kind: faults.KindSpyreUnavailable, Count: spyreCount})
+	},
+}
+
+var readinessDelayCmd = &cobra.Command{
+	Use:   "readiness-delay [delay]",
+	Short: "Delay the readiness checks of the containers",
+	Long: `Delays the readiness checks of the containers being deployed, Eg:- to validate the behaviour of create and
+rollback with slow loading model servers.
+
+Arguments
+  [delay]: Delay of the readiness checks, Eg:- 90s or 5m (required)`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		delay, err := time.ParseDuration(args[0])
+		if err != nil || delay <= 0 {
+			return fmt.Errorf("invalid delay '%s', Eg:- 90s or 5m", args[0])
+		}
+
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		return inject(faults.Fault{Kind: faults.KindReadinessDelay, Delay: delay})
+	},
+}
+
+var listFaultsCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the active faults",
+	Args:  cobra.MaximumNArgs(0),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if faultsOutput != "" && faultsOutput != "json" {
+			return fmt.Errorf("unsupported output format %q, supported formats: json", faultsOutput)
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		active, err := faults.List()
+		if err != nil {
+			return fmt.Errorf("failed to list faults: %w", err)
+		}
+		machine.SetData(active)
+
+		if faultsOutput == "json" {
+			out, err := json.MarshalIndent(active, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal faults: %w", err)
+			}
+			fmt.Println(string(out))
+			return nil
+		}
+
+		if len(active) == 0 {
+			logger.Infoln("No active faults")
+			return nil
+		}
+
+		p := utils.NewTableWriter()
+		defer p.CloseTableWriter()
+		p.SetHeaders("FAULT", "EXPIRES")
+		for _, f := range active {
+			p.AppendRow(f.String(), f.Expires.Local().Format(time.DateTime))
+		}
+
+		return nil
+	},
+}
+
+var clearFaultsCmd = &cobra.Command{
+	Use:   "clear [fault]",
+	Short: "Clear the active faults",
+	Long: `Clears the active fault of the kind, or all the faults if no kind is provided.
+
+Arguments
+  [fault]: Fault kind, spyre-unavailable or readiness-delay (optional)`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var kind string
+		if len(args) > 0 {
+			kind = args[0]
+		}
+
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		if err := faults.Clear(kind); err != nil {
+			return fmt.Errorf("failed to clear faults: %w", err)
+		}
+		machine.MarkChanged()
+		logger.Infoln("Faults cleared")
+
+		return nil
+	},
+}
+
+// inject activates the simulated fault until it expires
+func inject(fault faults.Fault) error {
+	if faultDuration <= 0 {
+		return fmt.Errorf("--duration must be positive")
+	}
+	fault.Expires = time.Now().Add(faultDuration)
+	if err := faults.Inject(fault); err != nil {
+		return fmt.Errorf("failed to inject fault: %w", err)
+	}
+	machine.MarkChanged()
+	logger.Infof("Fault %s injected until %s\n", fault, fault.Expires.Local().Format(time.DateTime))
+
+	return nil
+}
+
+func init() {
+	injectCmd.PersistentFlags().DurationVar(&faultDuration, "duration", 10*time.Minute, "Time after which the simulated fault expires")
+	killCmd.Flags().StringVar(&killSignal, "signal", "SIGKILL", "Signal sent to the container")
+	spyreUnavailableCmd.Flags().IntVar(&spyreCount, "count", 1, "Number of free Spyre cards reported as in use")
+	listFaultsCmd.Flags().StringVarP(&faultsOutput, "output", "o", "", "Output format (json)")
+
+	injectCmd.AddCommand(killCmd)
+	injectCmd.AddCommand(spyreUnavailableCmd)
+	injectCmd.AddCommand(readinessDelayCmd)
+	injectCmd.AddCommand(listFaultsCmd)
+	injectCmd.AddCommand(clearFaultsCmd)
+}
@@ -0,0 +1,101 @@
+package doctor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+	"github.com/project-ai-services/ai-services/pkg/aiservices"
+)
+
+var (
+	output     string
+	since      string
+	skipChecks []string
+)
+
+// DoctorCmd represents the doctor command
+var DoctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnoses the host and the applications",
+	Long: `Diagnoses the host and the applications in one shot, the single entry point when something is broken. It
+inspects the podman runtime, runs the validation checks of the host, verifies the state documents, the Spyre cards
+of the ledger and the containers of the applications, and analyzes the recent events of the audit history (log
+alerts, admission denials, compliance drifts and failovers).
+
+The detected problems are ranked by severity, critical first, each with the command remediating it. The command
+fails when a critical problem or an error is detected, the warnings alone don't fail it.`,
+	Example: `  ai-services doctor
+  ai-services doctor --since 7d --skip-validation rhn
+  ai-services doctor -o json`,
+	Args: cobra.MaximumNArgs(0),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if output != "" && output != "json" {
+			return fmt.Errorf("unsupported output format %q, supported formats: json", output)
+		}
+		if d, err := utils.ParseDuration(since); err != nil || d <= 0 {
+			return fmt.Errorf("invalid --since %q, Eg:- 7d or 12h", since)
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		period, _ := utils.ParseDuration(since)
+
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		diagnosis, err := aiservices.Diagnose(ctx, aiservices.DiagnoseOptions{Skip: skipChecks, Since: period})
+		if err != nil {
+			return fmt.Errorf("failed to diagnose the host: %w", err)
+		}
+		machine.SetData(diagnosis)
+
+		if output == "json" {
+			out, err := json.MarshalIndent(diagnosis, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal the diagnosis: %w", err)
+			}
+			fmt.Println(string(out))
+		} else {
+			printDiagnosis(diagnosis)
+		}
+
+		if !diagnosis.Healthy() {
+			return errors.New("problems detected, see the remediations above")
+		}
+		return nil
+	},
+}
+
+func init() {
+	DoctorCmd.Flags().StringVarP(&output, "output", "o", "", "Output format (json)")
+	DoctorCmd.Flags().StringVar(&since, "since", "24h", "Period of the audit history analyzed for recent events, Eg:- 7d or 12h")
+	DoctorCmd.Flags().StringSliceVar(&skipChecks, "skip-validation", []string{}, "Validation checks to skip, see 'ai-services bootstrap validate --help'")
+}
+
+func printDiagnosis(d *aiservices.Diagnosis) {
+	if len(d.Problems) == 0 {
+		logger.Infoln("No problem detected")
+		return
+	}
+
+	p := utils.NewTableWriter()
+	defer p.CloseTableWriter()
+	p.SetHeaders("#", "SEVERITY", "AREA", "PROBLEM", "REMEDIATION")
+	for i, problem := range d.Problems {
+		p.AppendRow(fmt.Sprint(i+1), strings.ToUpper(problem.Severity), problem.Area, problem.Summary, problem.Remediation)
+	}
+}
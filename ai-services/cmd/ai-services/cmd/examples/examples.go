@@ -0,0 +1,120 @@
+package examples
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/templates"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+)
+
+var exampleName string
+
+// ExamplesCmd represents the examples command
+var ExamplesCmd = &cobra.Command{
+	Use:   "examples [template]",
+	Short: "Prints the guides and sample values files shipped with the application templates",
+	Long: `Prints the examples embedded with the application templates: guides with the end-to-end command sequences
+and sample values files, available without internet access, Eg:- on an air-gapped host.
+
+Without a template, the examples of all the templates are listed.
+
+Arguments
+  [template]: Application template name (optional)`,
+	Example: `  # List the examples of all the templates
+  ai-services examples
+
+  # Print the guides and sample values files of the RAG template
+  ai-services examples RAG
+
+  # Save a sample values file, to be passed to 'application create -f'
+  ai-services examples RAG --example values-custom.yaml > values-custom.yaml`,
+	Args: cobra.MaximumNArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if exampleName != "" && len(args) == 0 {
+			return fmt.Errorf("--example requires the template")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		tp := templates.NewEmbedTemplateProvider(templates.EmbedOptions{})
+		names, err := tp.ListApplications()
+		if err != nil {
+			return fmt.Errorf("failed to list application templates: %w", err)
+		}
+		sort.Strings(names)
+
+		if len(args) == 0 {
+			return listExamples(tp, names)
+		}
+
+		template := args[0]
+		if !slices.Contains(names, template) {
+			return fmt.Errorf("template '%s' not found, available templates: %s", template, strings.Join(names, ", "))
+		}
+		examples, err := tp.LoadExamples(template)
+		if err != nil {
+			return err
+		}
+		if exampleName != "" {
+			i := slices.IndexFunc(examples, func(e templates.Example) bool { return e.Name == exampleName })
+			if i < 0 {
+				return fmt.Errorf("example '%s' not found in template %s", exampleName, template)
+			}
+			examples = examples[i : i+1]
+		}
+		machine.SetData(examples)
+
+		if len(examples) == 0 {
+			logger.Infof("Template %s ships no example\n", template)
+			return nil
+		}
+		// a single example is printed as is, to be redirected into a file
+		if exampleName != "" {
+			fmt.Print(examples[0].Content)
+			return nil
+		}
+		for i, e := range examples {
+			if i > 0 {
+				fmt.Println()
+			}
+			fmt.Printf("==> %s (%s) <==\n", e.Name, e.Kind)
+			fmt.Print(e.Content)
+		}
+		return nil
+	},
+}
+
+func init() {
+	ExamplesCmd.Flags().StringVarP(&exampleName, "example", "e", "", "Print only the example of this name, Eg:- values-custom.yaml")
+}
+
+// listExamples prints the examples of all the templates
+func listExamples(tp templates.Template, names []string) error {
+	data := map[string][]templates.Example{}
+	defer machine.SetData(data)
+
+	p := utils.NewTableWriter()
+	defer p.CloseTableWriter()
+	p.SetHeaders("TEMPLATE", "EXAMPLE", "KIND", "DESCRIPTION")
+	for _, name := range names {
+		examples, err := tp.LoadExamples(name)
+		if err != nil {
+			return err
+		}
+		data[name] = examples
+		for _, e := range examples {
+			p.AppendRow(name, e.Name, e.Kind, e.Description)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package facts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+	"github.com/project-ai-services/ai-services/pkg/aiservices"
+)
+
+var output string
+
+// FactsCmd represents the facts command
+var FactsCmd = &cobra.Command{
+	Use:   "facts",
+	Short: "Prints the inventory of the host",
+	Long: `Prints the inventory of the host: CPU model, cores and SMT level, memory, NUMA topology, Spyre cards, podman
+version and storage of the ai-services directories.
+
+The same facts are available to the application templates as .Facts, Eg:- {{ .Facts.CPU.Cores }}. The facts which
+cannot be collected are left empty and listed under errors.
+
+The CPU sizes are the limits the templates get for the abstract sizes of the values on this host, Eg:-
+{{ cpuLimit .Values.vllm.size }} and {{ memoryLimit .Values.vllm.size }}, resolved for the Power10 or Power11
+cores and the SMT level.`,
+	Example: `  ai-services facts
+  ai-services facts -o yaml`,
+	Args: cobra.MaximumNArgs(0),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if output != "json" && output != "yaml" {
+			return fmt.Errorf("unsupported output format %q, supported formats: json, yaml", output)
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		client, err := aiservices.NewClient(context.Background())
+		if err != nil {
+			return err
+		}
+
+		facts, err := client.Facts(context.Background())
+		if err != nil {
+			return fmt.Errorf("failed to collect the host facts: %w", err)
+		}
+		machine.SetData(facts)
+		for _, e := range facts.Errors {
+			logger.Infof("Unable to collect %s\n", e, 2)
+		}
+
+		var out []byte
+		if output == "yaml" {
+			out, err = yaml.Marshal(facts)
+		} else {
+			out, err = json.MarshalIndent(facts, "", "  ")
+		}
+		if err != nil {
+			return fmt.Errorf("failed to marshal the host facts: %w", err)
+		}
+		fmt.Println(string(out))
+
+		return nil
+	},
+}
+
+func init() {
+	FactsCmd.Flags().StringVarP(&output, "output", "o", "json", "Output format (json, yaml)")
+}
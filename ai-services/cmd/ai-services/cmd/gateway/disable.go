@@ -0,0 +1,43 @@
+package gateway
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/gateway"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/podman"
+)
+
+var disableCmd = &cobra.Command{
+	Use:   "disable",
+	Short: "Removes the gateway pod",
+	Long:  `Removes the managed gateway pod. The routes are retained and applied when the gateway is enabled again.`,
+	Args:  cobra.MaximumNArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		runtimeClient, err := podman.NewPodmanClient()
+		if err != nil {
+			return fmt.Errorf("failed to connect to podman: %w", err)
+		}
+
+		if err := gateway.Remove(runtimeClient); err != nil {
+			return fmt.Errorf("failed to remove gateway: %w", err)
+		}
+
+		if _, err := gateway.Update(func(cfg *gateway.Config) error {
+			cfg.Enabled = false
+			return nil
+		}); err != nil {
+			return fmt.Errorf("failed to update gateway configuration: %w", err)
+		}
+
+		machine.MarkChanged()
+		logger.Infoln("Gateway disabled")
+		return nil
+	},
+}
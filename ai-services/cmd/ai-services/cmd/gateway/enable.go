@@ -0,0 +1,104 @@
+package gateway
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/gateway"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/podman"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+)
+
+var (
+	httpPort  int
+	httpsPort int
+	tlsCert   string
+	tlsKey    string
+	htpasswd  string
+	image     string
+)
+
+var enableCmd = &cobra.Command{
+	Use:   "enable",
+	Short: "Deploys the gateway pod",
+	Long: `Deploys the managed gateway pod listening on the host network. Rerun to update the ports,
+TLS certificate or the authentication file of an enabled gateway.`,
+	Args: cobra.MaximumNArgs(0),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if (tlsCert == "") != (tlsKey == "") {
+			return fmt.Errorf("--tls-cert and --tls-key must be provided together")
+		}
+		for _, f := range []string{tlsCert, tlsKey, htpasswd} {
+			if f != "" && !utils.FileExists(f) {
+				return fmt.Errorf("file '%s' does not exist", f)
+			}
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		runtimeClient, err := podman.NewPodmanClient()
+		if err != nil {
+			return fmt.Errorf("failed to connect to podman: %w", err)
+		}
+
+		cfg, err := gateway.Update(func(cfg *gateway.Config) error {
+			cfg.Enabled = true
+			if cmd.Flags().Changed("port") {
+				cfg.HTTPPort = httpPort
+			}
+			if cmd.Flags().Changed("https-port") {
+				cfg.HTTPSPort = httpsPort
+			}
+			if cmd.Flags().Changed("image") {
+				cfg.Image = image
+			}
+			if tlsCert != "" {
+				if err := gateway.InstallFile(tlsCert, "tls.crt"); err != nil {
+					return err
+				}
+				if err := gateway.InstallFile(tlsKey, "tls.key"); err != nil {
+					return err
+				}
+				cfg.TLS = true
+			}
+			if htpasswd != "" {
+				if err := gateway.InstallFile(htpasswd, "htpasswd"); err != nil {
+					return err
+				}
+				cfg.Auth = true
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update gateway configuration: %w", err)
+		}
+
+		logger.Infoln("Deploying gateway...")
+		if err := gateway.Deploy(runtimeClient, cfg); err != nil {
+			return fmt.Errorf("failed to deploy gateway: %w", err)
+		}
+
+		machine.MarkChanged()
+		logger.Infof("Gateway enabled, listening on port %d\n", cfg.HTTPPort, 0)
+		if cfg.TLS {
+			logger.Infof("TLS is terminated on port %d\n", cfg.HTTPSPort, 0)
+		}
+		return nil
+	},
+}
+
+func init() {
+	enableCmd.Flags().IntVar(&httpPort, "port", 80, "HTTP port on which the gateway listens")
+	enableCmd.Flags().IntVar(&httpsPort, "https-port", 443, "HTTPS port on which the gateway listens, when TLS is configured")
+	enableCmd.Flags().StringVar(&tlsCert, "tls-cert", "", "Path to the PEM encoded certificate used to terminate TLS")
+	enableCmd.Flags().StringVar(&tlsKey, "tls-key", "", "Path to the PEM encoded private key of the certificate")
+	enableCmd.Flags().StringVar(&htpasswd, "htpasswd", "", "Path to the htpasswd file used to authenticate the requests (basic authentication)")
+	enableCmd.Flags().StringVar(&image, "image", gateway.DefaultImage, "Gateway image to use (only for the development purpose)")
+	_ = enableCmd.Flags().MarkHidden("image")
+}
@@ -0,0 +1,28 @@
+package gateway
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// GatewayCmd represents the gateway command
+var GatewayCmd = &cobra.Command{
+	Use:   "gateway",
+	Short: "Manage the gateway routing requests to the applications",
+	Long: `The gateway is an optional managed pod which routes hostnames/paths to the application
+endpoints, centralizing TLS termination and authentication for all the applications on the host.`,
+	Example: `  # Enable the gateway with TLS and basic authentication
+  ai-services gateway enable --tls-cert server.crt --tls-key server.key --htpasswd users.htpasswd
+
+  # Route chat.example.com to the UI of application 'rag'
+  ai-services gateway route add rag --endpoint ui --host chat.example.com`,
+	Args: cobra.MaximumNArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmd.Help()
+	},
+}
+
+func init() {
+	GatewayCmd.AddCommand(enableCmd)
+	GatewayCmd.AddCommand(disableCmd)
+	GatewayCmd.AddCommand(routeCmd)
+}
@@ -0,0 +1,175 @@
+package gateway
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/gateway"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/podman"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+)
+
+// errNoRoute aborts the update of the gateway configuration when the route to remove doesn't exist
+var errNoRoute = errors.New("no route found")
+
+var (
+	routeHost     string
+	routePath     string
+	routeEndpoint string
+)
+
+var routeCmd = &cobra.Command{
+	Use:   "route",
+	Short: "Manage the gateway routes",
+	Args:  cobra.MaximumNArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmd.Help()
+	},
+}
+
+var routeAddCmd = &cobra.Command{
+	Use:   "add [name]",
+	Short: "Routes a hostname/path to an application endpoint",
+	Long: `Routes the requests matching the hostname and path to an endpoint of the application.
+Endpoints are listed by 'ai-services application endpoints'. The path prefix is stripped when proxying.
+
+Arguments
+  [name]: Application name (required)`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		route := gateway.Route{
+			Host:        routeHost,
+			Path:        gateway.NormalizePath(routePath),
+			Application: args[0],
+			Endpoint:    routeEndpoint,
+		}
+		if err := gateway.ValidateRoute(route); err != nil {
+			return err
+		}
+
+		// reloaded before the configuration is saved, so that a route rejected by nginx is not persisted
+		_, err := gateway.Update(func(cfg *gateway.Config) error {
+			// a route for the same host and path is replaced
+			cfg.Routes = slices.DeleteFunc(cfg.Routes, func(r gateway.Route) bool {
+				return r.Host == route.Host && r.Path == route.Path
+			})
+			cfg.Routes = append(cfg.Routes, route)
+			return reload(cfg)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update gateway configuration: %w", err)
+		}
+
+		machine.MarkChanged()
+		logger.Infof("Route %s%s -> %s/%s added\n", hostOrAny(route.Host), route.Path, route.Application, route.Endpoint)
+		return nil
+	},
+}
+
+var routeRemoveCmd = &cobra.Command{
+	Use:   "remove",
+	Short: "Removes the route of a hostname/path",
+	Args:  cobra.MaximumNArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		path := gateway.NormalizePath(routePath)
+		_, err := gateway.Update(func(cfg *gateway.Config) error {
+			n := len(cfg.Routes)
+			cfg.Routes = slices.DeleteFunc(cfg.Routes, func(r gateway.Route) bool {
+				return r.Host == routeHost && r.Path == path
+			})
+			if n == len(cfg.Routes) {
+				return errNoRoute
+			}
+			return reload(cfg)
+		})
+		if errors.Is(err, errNoRoute) {
+			return fmt.Errorf("no route found for %s%s", hostOrAny(routeHost), path)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to update gateway configuration: %w", err)
+		}
+
+		machine.MarkChanged()
+		logger.Infof("Route %s%s removed\n", hostOrAny(routeHost), path)
+		return nil
+	},
+}
+
+var routeListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the gateway routes",
+	Args:  cobra.MaximumNArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		cfg, err := gateway.Load()
+		if err != nil {
+			return fmt.Errorf("failed to read gateway configuration: %w", err)
+		}
+
+		machine.SetData(map[string]any{"enabled": cfg.Enabled, "routes": cfg.Routes})
+		logger.Infof("Gateway enabled: %v\n", cfg.Enabled)
+		if len(cfg.Routes) == 0 {
+			logger.Infoln("No routes found")
+			return nil
+		}
+
+		p := utils.NewTableWriter()
+		defer p.CloseTableWriter()
+		p.SetHeaders("HOST", "PATH", "APPLICATION NAME", "ENDPOINT")
+		for _, r := range cfg.Routes {
+			p.AppendRow(hostOrAny(r.Host), r.Path, r.Application, r.Endpoint)
+		}
+		return nil
+	},
+}
+
+func init() {
+	routeAddCmd.Flags().StringVar(&routeEndpoint, "endpoint", "", "Application endpoint to route to (required)")
+	_ = routeAddCmd.MarkFlagRequired("endpoint")
+	for _, c := range []*cobra.Command{routeAddCmd, routeRemoveCmd} {
+		c.Flags().StringVar(&routeHost, "host", "", "Hostname to match (default: any)")
+		c.Flags().StringVar(&routePath, "path", "/", "Path prefix to match")
+	}
+
+	routeCmd.AddCommand(routeAddCmd)
+	routeCmd.AddCommand(routeRemoveCmd)
+	routeCmd.AddCommand(routeListCmd)
+}
+
+// reload applies the routes to the gateway, if enabled
+func reload(cfg *gateway.Config) error {
+	if !cfg.Enabled {
+		logger.Infoln("Gateway is not enabled, route is applied once enabled with 'ai-services gateway enable'")
+		return nil
+	}
+
+	runtimeClient, err := podman.NewPodmanClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to podman: %w", err)
+	}
+
+	if err := gateway.Reload(runtimeClient, cfg); err != nil {
+		return fmt.Errorf("failed to reload gateway: %w", err)
+	}
+	return nil
+}
+
+func hostOrAny(host string) string {
+	if host == "" {
+		return "*"
+	}
+	return host
+}
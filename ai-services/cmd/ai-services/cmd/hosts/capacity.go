@@ -0,0 +1,247 @@
+package hosts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/hosts"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+	"github.com/project-ai-services/ai-services/pkg/aiservices"
+)
+
+var (
+	capacityFit         string
+	capacityValuesFiles []string
+	capacityRawParams   []string
+	capacityParams      map[string]string
+	capacityHosts       []string
+	capacityInventory   string
+	capacityLocal       bool
+	capacityOutput      string
+)
+
+// HostCapacity is the capacity report of a host of the inventory
+type HostCapacity struct {
+	Name     string                   `json:"name"`
+	Capacity *aiservices.HostCapacity `json:"capacity,omitempty"`
+	Error    string                   `json:"error,omitempty"`
+}
+
+// CapacityReport is the capacity of the hosts, and the host recommended for the template
+type CapacityReport struct {
+	Hosts       []HostCapacity `json:"hosts"`
+	Recommended string         `json:"recommended,omitempty"`
+}
+
+var capacityCmd = &cobra.Command{
+	Use:   "capacity",
+	Short: "Report the free capacity of the hosts",
+	Long: `Queries each host of the inventory for its free Spyre cards, CPU, memory and model directory disk space.
+
+With --fit, checks which hosts can fit all the pods of the template and recommends the host left with the most
+free Spyre cards, then the most free memory, once the template is deployed.`,
+	Example: `  ai-services hosts capacity
+  ai-services hosts capacity --fit RAG
+  ai-services hosts capacity --fit RAG --host lpar1 --host lpar2`,
+	Args: cobra.MaximumNArgs(0),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if capacityOutput != "" && capacityOutput != "json" {
+			return fmt.Errorf("unsupported output format %q, supported formats: json", capacityOutput)
+		}
+
+		var err error
+		if len(capacityRawParams) > 0 {
+			capacityParams, err = utils.ParseKeyValues(capacityRawParams)
+			if err != nil {
+				return fmt.Errorf("error validating params flag: %v", err)
+			}
+		}
+		for _, vf := range capacityValuesFiles {
+			if !utils.FileExists(vf) {
+				return fmt.Errorf("values file '%s' does not exist", vf)
+			}
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		var report *CapacityReport
+		var err error
+		if capacityLocal {
+			report, err = localCapacity()
+		} else {
+			report, err = inventoryCapacity()
+		}
+		if err != nil {
+			return err
+		}
+		machine.SetData(report)
+
+		if capacityOutput == "json" {
+			out, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal capacity report: %w", err)
+			}
+			fmt.Println(string(out))
+			return nil
+		}
+
+		printCapacity(report)
+		return nil
+	},
+}
+
+// localCapacity reports the capacity of this host
+func localCapacity() (*CapacityReport, error) {
+	client, err := aiservices.NewClient(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	capacity, err := client.Capacity(context.Background(), aiservices.CapacityOptions{
+		Fit:         capacityFit,
+		ValuesFiles: capacityValuesFiles,
+		Params:      capacityParams,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to report capacity: %w", err)
+	}
+
+	report := &CapacityReport{Hosts: []HostCapacity{{Name: capacity.Host, Capacity: capacity}}}
+	report.Recommended = recommend(report.Hosts)
+	return report, nil
+}
+
+// inventoryCapacity queries the capacity of the hosts of the inventory in parallel
+func inventoryCapacity() (*CapacityReport, error) {
+	inventory, err := hosts.Load(capacityInventory)
+	if err != nil {
+		return nil, err
+	}
+	selected, err := inventory.Select(len(capacityHosts) == 0, capacityHosts)
+	if err != nil {
+		return nil, err
+	}
+
+	cliArgs := []string{"hosts", "capacity", "--local"}
+	if capacityFit != "" {
+		cliArgs = append(cliArgs, "--fit="+capacityFit)
+	}
+	for _, vf := range capacityValuesFiles {
+		cliArgs = append(cliArgs, "--values="+vf)
+	}
+	for _, p := range capacityRawParams {
+		cliArgs = append(cliArgs, "--params="+p)
+	}
+
+	results := hosts.Run(context.Background(), selected, hosts.Command{Args: cliArgs, Files: capacityValuesFiles, Parallel: true})
+
+	report := &CapacityReport{}
+	for _, r := range results {
+		hc := HostCapacity{Name: r.Host, Error: r.Error}
+		if r.Success {
+			var local CapacityReport
+			switch err := json.Unmarshal(r.Data, &local); {
+			case err != nil:
+				hc.Error = fmt.Sprintf("invalid capacity report: %v", err)
+			case len(local.Hosts) != 1:
+				hc.Error = "invalid capacity report: expected a single host"
+			default:
+				hc.Capacity = local.Hosts[0].Capacity
+			}
+		}
+		report.Hosts = append(report.Hosts, hc)
+	}
+	report.Recommended = recommend(report.Hosts)
+	return report, nil
+}
+
+// recommend returns the host fitting the template left with the most free Spyre cards, then the most free memory
+func recommend(reports []HostCapacity) string {
+	var best *aiservices.HostCapacity
+	var name string
+	for _, r := range reports {
+		c := r.Capacity
+		if c == nil || c.Fit == nil || !c.Fit.Fits {
+			continue
+		}
+		if best == nil || leftSpyreCards(c) > leftSpyreCards(best) ||
+			leftSpyreCards(c) == leftSpyreCards(best) && leftMemory(c) > leftMemory(best) {
+			best, name = c, r.Name
+		}
+	}
+	return name
+}
+
+func leftSpyreCards(c *aiservices.HostCapacity) int {
+	return c.FreeSpyreCards - c.Fit.SpyreCards
+}
+
+func leftMemory(c *aiservices.HostCapacity) int64 {
+	return c.Available.Memory - c.Fit.Required.Memory
+}
+
+func printCapacity(report *CapacityReport) {
+	p := utils.NewTableWriter()
+	headers := []string{"HOST", "SPYRE (FREE/TOTAL)", "CPU (AVAILABLE/TOTAL)", "MEMORY (AVAILABLE/TOTAL)", "DISK (FREE/TOTAL)"}
+	if capacityFit != "" {
+		headers = append(headers, "FITS "+capacityFit)
+	}
+	p.SetHeaders(headers...)
+	for _, h := range report.Hosts {
+		c := h.Capacity
+		if c == nil {
+			row := []string{h.Name, "error: " + h.Error, "", "", ""}
+			if capacityFit != "" {
+				row = append(row, "")
+			}
+			p.AppendRow(row...)
+			continue
+		}
+		row := []string{
+			h.Name,
+			fmt.Sprintf("%d/%d", c.FreeSpyreCards, c.SpyreCards),
+			aiservices.FormatMilliCPU(c.Available.MilliCPU) + "/" + aiservices.FormatMilliCPU(c.Capacity.MilliCPU),
+			aiservices.FormatBytes(c.Available.Memory) + "/" + aiservices.FormatBytes(c.Capacity.Memory),
+			aiservices.FormatBytes(c.DiskAvailable) + "/" + aiservices.FormatBytes(c.DiskTotal),
+		}
+		if c.Fit != nil {
+			fits := "yes"
+			if !c.Fit.Fits {
+				fits = "no: " + strings.Join(c.Fit.Reasons, ", ")
+			}
+			row = append(row, fits)
+		}
+		p.AppendRow(row...)
+	}
+	p.CloseTableWriter()
+
+	if capacityFit == "" {
+		return
+	}
+	if report.Recommended == "" {
+		logger.Infof("No host can fit template %s\n", capacityFit)
+		return
+	}
+	logger.Infof("Recommended host for template %s: %s\n", capacityFit, report.Recommended)
+}
+
+func init() {
+	capacityCmd.Flags().StringVar(&capacityFit, "fit", "", "Application template to check the hosts can fit, recommending a host")
+	capacityCmd.Flags().StringArrayVarP(&capacityValuesFiles, "values", "f", []string{}, "values.yaml files overriding the default values of the template checked with --fit")
+	capacityCmd.Flags().StringSliceVar(&capacityRawParams, "params", []string{}, "Comma-separated key=value pairs overriding the values of the template checked with --fit")
+	capacityCmd.Flags().StringSliceVar(&capacityHosts, "host", []string{}, "Hosts of the inventory to query (default: all the hosts)")
+	capacityCmd.Flags().StringVar(&capacityInventory, "inventory", "", "Path of the hosts inventory (default: $"+hosts.InventoryEnv+" or "+hosts.DefaultInventory+")")
+	capacityCmd.Flags().BoolVar(&capacityLocal, "local", false, "Report the capacity of this host only, without the hosts inventory")
+	capacityCmd.Flags().StringVarP(&capacityOutput, "output", "o", "", "Output format (json)")
+	capacityCmd.MarkFlagsMutuallyExclusive("local", "host")
+	capacityCmd.MarkFlagsMutuallyExclusive("local", "inventory")
+}
@@ -0,0 +1,29 @@
+package hosts
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// HostsCmd represents the hosts command
+var HostsCmd = &cobra.Command{
+	Use:   "hosts",
+	Short: "Manage the hosts of the hosts inventory",
+	Long: `Manages the Power LPARs listed in the hosts inventory, /etc/ai-services/hosts.yaml unless overridden by
+--inventory or the AI_SERVICES_INVENTORY environment variable. The commands run on each host over SSH, with the
+CLI installed on the host.
+
+  hosts:
+    - name: lpar1
+      address: root@lpar1.example.com
+      identityFile: ~/.ssh/id_ed25519
+    - name: lpar2
+      address: root@lpar2.example.com`,
+	Args: cobra.MaximumNArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmd.Help()
+	},
+}
+
+func init() {
+	HostsCmd.AddCommand(capacityCmd)
+}
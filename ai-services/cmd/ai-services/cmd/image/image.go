@@ -0,0 +1,56 @@
+package image
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// ImageCmd represents the image command
+var ImageCmd = &cobra.Command{
+	Use:   "image",
+	Short: "Manage the local images used by ai-services",
+	Long: `Manages the images of the local podman storage referenced by ai-services: the images run by the deployed
+applications and the images of the templates cached ahead of their deployment.
+
+Use 'ai-services application image' to list, pull or precache the images of a template.`,
+	Args: cobra.MaximumNArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmd.Help()
+	},
+}
+
+func init() {
+	ImageCmd.AddCommand(listCmd)
+	ImageCmd.AddCommand(pruneCmd)
+}
+
+func formatSize(b int64) string {
+	if b >= 1<<30 {
+		return fmt.Sprintf("%.2f GiB", float64(b)/(1<<30))
+	}
+	return fmt.Sprintf("%.0f MiB", float64(b)/(1<<20))
+}
+
+func shortID(id string) string {
+	id = strings.TrimPrefix(id, "sha256:")
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
+
+func orNone(values []string) string {
+	if len(values) == 0 {
+		return "-"
+	}
+	return strings.Join(values, ", ")
+}
+
+func imageName(names []string) string {
+	if len(names) == 0 {
+		return "<none>"
+	}
+	return names[0]
+}
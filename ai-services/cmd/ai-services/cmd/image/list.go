@@ -0,0 +1,51 @@
+package image
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+	"github.com/project-ai-services/ai-services/pkg/aiservices"
+)
+
+var listCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "Lists the local images used by the applications and the templates",
+	Long: `Lists the local images run by the deployed applications or declared by the templates, with their size and
+the number of their layers shared with the other images listed. The shared layers are stored once, the space
+used by the images is less than the sum of their sizes.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		client, err := aiservices.NewClient(context.Background())
+		if err != nil {
+			return err
+		}
+		images, err := client.ListImages(context.Background())
+		if err != nil {
+			return err
+		}
+		machine.SetData(images)
+
+		if len(images) == 0 {
+			logger.Infoln("No image used by ai-services")
+			return nil
+		}
+
+		p := utils.NewTableWriter()
+		defer p.CloseTableWriter()
+		p.SetHeaders("IMAGE", "ID", "SIZE", "SHARED LAYERS", "APPLICATIONS", "TEMPLATES")
+		for _, img := range images {
+			p.AppendRow(imageName(img.Names), shortID(img.ID), formatSize(img.Size),
+				fmt.Sprintf("%d/%d", img.SharedLayers, img.Layers), orNone(img.Applications), orNone(img.Templates))
+		}
+		return nil
+	},
+}
@@ -0,0 +1,101 @@
+package image
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+	"github.com/project-ai-services/ai-services/pkg/aiservices"
+)
+
+var (
+	pruneDryRun bool
+	pruneYes    bool
+)
+
+var pruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Removes the images no longer used by the applications nor the templates",
+	Long: `Removes the local images of the repositories of the templates which are neither run by a deployed
+application nor declared by a template anymore, Eg:- the images of the previous versions of the templates.
+
+The images of the other repositories and the images used by any container are kept.`,
+	Example: `  # Show the images which would be removed
+  ai-services image prune --dry-run
+
+  # Remove them without confirmation
+  ai-services image prune --yes`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		ctx := context.Background()
+		client, err := aiservices.NewClient(ctx)
+		if err != nil {
+			return err
+		}
+
+		candidates, err := client.PruneImages(ctx, true)
+		if err != nil {
+			return err
+		}
+		if len(candidates.Removed) == 0 {
+			machine.SetData(candidates)
+			logger.Infoln("No image to prune")
+			return nil
+		}
+		printImages(candidates)
+		if pruneDryRun {
+			machine.SetData(candidates)
+			return nil
+		}
+
+		if !pruneYes && !machine.Enabled {
+			confirmed, err := utils.ConfirmAction("Remove these images?")
+			if err != nil {
+				return err
+			}
+			if !confirmed {
+				logger.Infoln("Prune cancelled")
+				return nil
+			}
+		}
+
+		result, err := client.PruneImages(ctx, false)
+		if err != nil {
+			return err
+		}
+		if len(result.Removed) > 0 {
+			machine.MarkChanged()
+		}
+		machine.SetData(result)
+		for id, reason := range result.Errors {
+			logger.Warningf("failed to remove image %s: %s\n", shortID(id), reason)
+		}
+		logger.Infof("Removed %d image(s), reclaimed %s\n", len(result.Removed), formatSize(result.Reclaimed))
+		if len(result.Errors) > 0 {
+			return fmt.Errorf("failed to remove %d image(s)", len(result.Errors))
+		}
+		return nil
+	},
+}
+
+func init() {
+	pruneCmd.Flags().BoolVar(&pruneDryRun, "dry-run", false, "Only list the images which would be removed")
+	pruneCmd.Flags().BoolVarP(&pruneYes, "yes", "y", false, "Remove the images without confirmation")
+}
+
+func printImages(result *aiservices.PruneResult) {
+	p := utils.NewTableWriter()
+	p.SetHeaders("IMAGE", "ID", "SIZE")
+	for _, img := range result.Removed {
+		p.AppendRow(imageName(img.Names), shortID(img.ID), formatSize(img.Size))
+	}
+	p.CloseTableWriter()
+	logger.Infof("%d image(s) to remove, %s to reclaim\n", len(result.Removed), formatSize(result.Reclaimed))
+}
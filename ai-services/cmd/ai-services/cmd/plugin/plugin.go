@@ -0,0 +1,92 @@
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+	"github.com/project-ai-services/ai-services/internal/pkg/plugins"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+)
+
+var output string
+
+// PluginCmd represents the plugin command
+var PluginCmd = &cobra.Command{
+	Use:   "plugin",
+	Short: "Manage the plugins of the CLI",
+	Long: `Plugins add site-specific subcommands without forking the CLI. Any executable named ai-services-<name> on
+the PATH is invoked as 'ai-services <name> [args...]', unless <name> is a built-in command.
+
+The plugins receive their context as environment variables:
+  AI_SERVICES_BIN        path of the CLI, to call back into it (preferably with --machine)
+  AI_SERVICES_VERSION    version of the CLI
+  AI_SERVICES_STATE_DIR  state directory of the CLI
+  AI_SERVICES_MODEL_DIR  model directory
+  AI_SERVICES_MACHINE    'true' when invoked in machine mode, the plugin is expected to emit a JSON result document
+  CONTAINER_HOST         URI of the podman socket`,
+	Args: cobra.MaximumNArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmd.Help()
+	},
+}
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the plugins found on the PATH",
+	Args:  cobra.MaximumNArgs(0),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if output != "" && output != "json" {
+			return fmt.Errorf("unsupported output format %q, supported formats: json", output)
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		found := plugins.List()
+		for i := range found {
+			// built-in commands take precedence over the plugins
+			if c, _, err := cmd.Root().Find([]string{found[i].Name}); err == nil && c != cmd.Root() {
+				found[i].Shadowed = true
+			}
+		}
+		machine.SetData(found)
+
+		if output == "json" {
+			out, err := json.MarshalIndent(found, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal plugins: %w", err)
+			}
+			fmt.Println(string(out))
+			return nil
+		}
+
+		if len(found) == 0 {
+			logger.Infoln("No plugins found on the PATH")
+			return nil
+		}
+
+		p := utils.NewTableWriter()
+		defer p.CloseTableWriter()
+		p.SetHeaders("NAME", "PATH", "STATUS")
+		for _, plugin := range found {
+			status := "active"
+			if plugin.Shadowed {
+				status = "shadowed"
+			}
+			p.AppendRow(plugin.Name, plugin.Path, status)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	listCmd.Flags().StringVarP(&output, "output", "o", "", "Output format (json)")
+	PluginCmd.AddCommand(listCmd)
+}
@@ -0,0 +1,91 @@
+package report
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+	"github.com/project-ai-services/ai-services/pkg/aiservices"
+)
+
+var (
+	chargebackMonth  string
+	chargebackBy     string
+	chargebackOutput string
+)
+
+var chargebackCmd = &cobra.Command{
+	Use:   "chargeback",
+	Short: "Attributes the resource usage of a month to the applications or projects",
+	Long: `Attributes the CPU-hours, memory GiB-hours and Spyre-card-hours of the month to the applications, or to their
+projects, for the internal cost allocation. The usage is computed from the usage history sampled by
+'ai-services watch --usage-interval', each sample accounting for the usage until the next one for at most an hour.
+
+The project of an application is its '` + aiservices.ProjectLabel + `' label, set with 'application create --label ` + aiservices.ProjectLabel + `=<name>'.`,
+	Example: `  ai-services report chargeback --month 2025-09
+  ai-services report chargeback --month 2025-09 --by project --output csv > chargeback-2025-09.csv`,
+	Args: cobra.MaximumNArgs(0),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if _, err := time.Parse("2006-01", chargebackMonth); err != nil {
+			return fmt.Errorf("invalid --month %q, expected YYYY-MM", chargebackMonth)
+		}
+		if chargebackBy != aiservices.ChargebackByApplication && chargebackBy != aiservices.ChargebackByProject {
+			return fmt.Errorf("invalid --by %q, supported: %s, %s", chargebackBy, aiservices.ChargebackByApplication, aiservices.ChargebackByProject)
+		}
+		if chargebackOutput != "" && chargebackOutput != "csv" {
+			return fmt.Errorf("unsupported output format %q, supported formats: csv", chargebackOutput)
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		month, _ := time.Parse("2006-01", chargebackMonth)
+
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		lines, err := aiservices.Chargeback(month, chargebackBy)
+		if err != nil {
+			return err
+		}
+		machine.SetData(lines)
+
+		if chargebackOutput == "csv" {
+			w := csv.NewWriter(os.Stdout)
+			_ = w.Write([]string{"month", chargebackBy, "cpu_hours", "memory_gib_hours", "spyre_card_hours"})
+			for _, line := range lines {
+				_ = w.Write([]string{chargebackMonth, line.Owner, formatHours(line.CPUHours),
+					formatHours(line.MemoryGiBHours), formatHours(line.SpyreCardHours)})
+			}
+			w.Flush()
+			return w.Error()
+		}
+
+		p := utils.NewTableWriter()
+		defer p.CloseTableWriter()
+		p.SetHeaders(fmt.Sprintf("%s (%s)", chargebackBy, chargebackMonth), "CPU HOURS", "MEMORY GIB HOURS", "SPYRE CARD HOURS")
+		for _, line := range lines {
+			owner := line.Owner
+			if owner == "" {
+				owner = "(none)"
+			}
+			p.AppendRow(owner, formatHours(line.CPUHours), formatHours(line.MemoryGiBHours), formatHours(line.SpyreCardHours))
+		}
+		return nil
+	},
+}
+
+func formatHours(hours float64) string {
+	return strconv.FormatFloat(hours, 'f', 2, 64)
+}
+
+func init() {
+	chargebackCmd.Flags().StringVar(&chargebackMonth, "month", time.Now().UTC().Format("2006-01"), "Month of the report, YYYY-MM")
+	chargebackCmd.Flags().StringVar(&chargebackBy, "by", aiservices.ChargebackByApplication, "Attribute the usage to each application or project")
+	chargebackCmd.Flags().StringVarP(&chargebackOutput, "output", "o", "", "Output format (csv)")
+}
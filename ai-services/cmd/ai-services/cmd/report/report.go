@@ -0,0 +1,20 @@
+package report
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// ReportCmd represents the report command
+var ReportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Reports on the history recorded on this host",
+	Args:  cobra.MaximumNArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmd.Help()
+	},
+}
+
+func init() {
+	ReportCmd.AddCommand(usageCmd)
+	ReportCmd.AddCommand(chargebackCmd)
+}
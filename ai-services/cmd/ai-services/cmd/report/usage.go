@@ -0,0 +1,68 @@
+package report
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+	"github.com/project-ai-services/ai-services/pkg/aiservices"
+)
+
+var usageSince string
+
+var usageCmd = &cobra.Command{
+	Use:   "usage",
+	Short: "Summarizes the resource usage history of the applications",
+	Long: `Summarizes the CPU, memory and Spyre cards used by the applications over the period, from the samples recorded
+by 'ai-services watch' every --usage-interval, for the capacity planning of shared LPARs.
+
+The usage is compared with the resources reserved by the applications. The trends are the change of the average
+usage from the first to the second half of the period.`,
+	Example: `  ai-services report usage --since 7d
+  ai-services report usage --since 12h`,
+	Args: cobra.MaximumNArgs(0),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		since, err := utils.ParseDuration(usageSince)
+		if err != nil || since <= 0 {
+			return fmt.Errorf("invalid --since %q, Eg:- 7d or 12h", usageSince)
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		since, _ := utils.ParseDuration(usageSince)
+
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		summaries, err := aiservices.UsageReport(since)
+		if err != nil {
+			return err
+		}
+		machine.SetData(summaries)
+
+		p := utils.NewTableWriter()
+		defer p.CloseTableWriter()
+		p.SetHeaders("APPLICATION", "SAMPLES", "CPU AVG/PEAK", "CPU RESERVED", "CPU TREND", "MEMORY AVG/PEAK", "MEMORY RESERVED", "MEMORY TREND", "SPYRE AVG/PEAK", "SINCE")
+		for _, s := range summaries {
+			p.AppendRow(s.Application, fmt.Sprint(s.Samples),
+				aiservices.FormatMilliCPU(s.AvgMilliCPU)+"/"+aiservices.FormatMilliCPU(s.PeakMilliCPU),
+				aiservices.FormatMilliCPU(s.Reserved.MilliCPU), formatTrend(s.CPUTrend),
+				aiservices.FormatBytes(s.AvgMemory)+"/"+aiservices.FormatBytes(s.PeakMemory),
+				aiservices.FormatBytes(s.Reserved.Memory), formatTrend(s.MemoryTrend),
+				fmt.Sprintf("%.1f/%d", s.AvgSpyreCards, s.PeakSpyreCards),
+				s.From.Local().Format(time.RFC3339))
+		}
+		return nil
+	},
+}
+
+func formatTrend(percent float64) string {
+	return fmt.Sprintf("%+.1f%%", percent)
+}
+
+func init() {
+	usageCmd.Flags().StringVar(&usageSince, "since", "7d", "Period of the report ending now, Eg:- 7d or 12h")
+}
@@ -1,17 +1,47 @@
 package cmd
 
 import (
+	"errors"
 	"flag"
+	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"k8s.io/klog/v2"
 
 	"github.com/spf13/cobra"
 
+	"github.com/project-ai-services/ai-services/cmd/ai-services/cmd/apikey"
 	"github.com/project-ai-services/ai-services/cmd/ai-services/cmd/application"
 	"github.com/project-ai-services/ai-services/cmd/ai-services/cmd/bootstrap"
+	"github.com/project-ai-services/ai-services/cmd/ai-services/cmd/bundle"
+	"github.com/project-ai-services/ai-services/cmd/ai-services/cmd/container"
+	"github.com/project-ai-services/ai-services/cmd/ai-services/cmd/debug"
+	"github.com/project-ai-services/ai-services/cmd/ai-services/cmd/doctor"
+	"github.com/project-ai-services/ai-services/cmd/ai-services/cmd/examples"
+	"github.com/project-ai-services/ai-services/cmd/ai-services/cmd/facts"
+	"github.com/project-ai-services/ai-services/cmd/ai-services/cmd/gateway"
+	"github.com/project-ai-services/ai-services/cmd/ai-services/cmd/hosts"
+	"github.com/project-ai-services/ai-services/cmd/ai-services/cmd/image"
+	"github.com/project-ai-services/ai-services/cmd/ai-services/cmd/plugin"
+	"github.com/project-ai-services/ai-services/cmd/ai-services/cmd/report"
+	"github.com/project-ai-services/ai-services/cmd/ai-services/cmd/runtime"
+	"github.com/project-ai-services/ai-services/cmd/ai-services/cmd/secret"
+	"github.com/project-ai-services/ai-services/cmd/ai-services/cmd/selfupdate"
+	"github.com/project-ai-services/ai-services/cmd/ai-services/cmd/serve"
+	"github.com/project-ai-services/ai-services/cmd/ai-services/cmd/smt"
+	"github.com/project-ai-services/ai-services/cmd/ai-services/cmd/spyre"
+	"github.com/project-ai-services/ai-services/cmd/ai-services/cmd/status"
+	"github.com/project-ai-services/ai-services/cmd/ai-services/cmd/telemetry"
 	"github.com/project-ai-services/ai-services/cmd/ai-services/cmd/version"
+	"github.com/project-ai-services/ai-services/cmd/ai-services/cmd/watch"
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+	"github.com/project-ai-services/ai-services/internal/pkg/plugins"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/podman"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+	"github.com/project-ai-services/ai-services/pkg/aiservices"
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -23,6 +53,10 @@ var RootCmd = &cobra.Command{
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
 		// Ensures logs flush after each command run
 		klog.V(2).Info("Logger initialized (PersistentPreRun)")
+		if machine.Enabled {
+			machine.Init()
+			cmd.Root().SilenceErrors = true
+		}
 	},
 }
 
@@ -30,16 +64,99 @@ var RootCmd = &cobra.Command{
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
 	defer logger.Flush()
-	err := RootCmd.Execute()
+	if err := runPlugin(os.Args[1:]); err != nil {
+		logger.Errorln(err.Error())
+		logger.Flush()
+		os.Exit(1)
+	}
+	start := time.Now()
+	cmd, err := RootCmd.ExecuteC()
+	code := exitCode(cmd, err)
+	telemetry.ReportUsage(cmd, code, time.Since(start))
+	if machine.Enabled {
+		logger.Flush()
+		os.Exit(machine.Emit(cmd.CommandPath(), err, code))
+	}
 	if err != nil {
 		os.Exit(1)
 	}
 }
 
+// runPlugin replaces the CLI with the plugin ai-services-<name> when invoked as 'ai-services [--machine] <name>',
+// unless <name> is a built-in command
+func runPlugin(args []string) error {
+	machineMode := false
+	for len(args) > 0 && args[0] == "--machine" {
+		machineMode = true
+		args = args[1:]
+	}
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return nil
+	}
+	if _, _, err := RootCmd.Find(args); err == nil {
+		return nil
+	}
+	p := plugins.Find(args[0])
+	if p == nil {
+		return nil
+	}
+
+	binary, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate the ai-services binary: %w", err)
+	}
+	return plugins.Exec(p, args[1:], plugins.Handshake{
+		Binary:         binary,
+		Version:        version.GetVersion(),
+		ContainerHost:  podman.ConnectionURI(),
+		StateDirectory: vars.StateDirectory,
+		ModelDirectory: vars.ModelDirectory,
+		Machine:        machineMode,
+	})
+}
+
+// exitCode maps the error of the command to the stable exit codes of the machine mode
+func exitCode(cmd *cobra.Command, err error) int {
+	switch {
+	case err == nil:
+		return machine.ExitOK
+	case !cmd.SilenceUsage:
+		// commands silence the usage once the arguments and flags are validated
+		return machine.ExitUsage
+	case errors.Is(err, aiservices.ErrApplicationNotFound):
+		return machine.ExitNotFound
+	default:
+		return machine.ExitCode(err, machine.ExitError)
+	}
+}
+
 func init() {
 	logger.Init()
+	vars.CLIVersion = version.GetVersion()
 	RootCmd.PersistentFlags().AddGoFlagSet(flag.CommandLine)
+	RootCmd.PersistentFlags().BoolVar(&machine.Enabled, "machine", false, "Machine mode: non-interactive, emits a single JSON result document on stdout and uses stable exit codes")
 	RootCmd.AddCommand(version.VersionCmd)
 	RootCmd.AddCommand(bootstrap.BootstrapCmd())
 	RootCmd.AddCommand(application.ApplicationCmd)
+	RootCmd.AddCommand(bundle.BundleCmd)
+	RootCmd.AddCommand(gateway.GatewayCmd)
+	RootCmd.AddCommand(apikey.APIKeyCmd)
+	RootCmd.AddCommand(serve.ServeCmd)
+	RootCmd.AddCommand(selfupdate.SelfUpdateCmd)
+	RootCmd.AddCommand(smt.SMTCmd)
+	RootCmd.AddCommand(hosts.HostsCmd)
+	RootCmd.AddCommand(debug.DebugCmd)
+	RootCmd.AddCommand(plugin.PluginCmd)
+	RootCmd.AddCommand(telemetry.TelemetryCmd)
+	RootCmd.AddCommand(spyre.SpyreCmd)
+	RootCmd.AddCommand(secret.SecretCmd)
+	RootCmd.AddCommand(container.ContainerCmd)
+	RootCmd.AddCommand(status.StatusCmd)
+	RootCmd.AddCommand(facts.FactsCmd)
+	RootCmd.AddCommand(runtime.RuntimeCmd)
+	RootCmd.AddCommand(report.ReportCmd)
+	RootCmd.AddCommand(doctor.DoctorCmd)
+	RootCmd.AddCommand(image.ImageCmd)
+	RootCmd.AddCommand(examples.ExamplesCmd)
+	RootCmd.AddCommand(watch.WatchCmd)
 }
@@ -0,0 +1,116 @@
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+	"github.com/project-ai-services/ai-services/pkg/aiservices"
+)
+
+var output string
+
+var infoCmd = &cobra.Command{
+	Use:   "info",
+	Short: "Shows the podman runtime",
+	Long: `Shows the podman runtime driven by ai-services: the resolved podman connection, the server and client versions,
+the storage driver and available disk, whether podman runs rootless, and the kube play flags supported by the local
+podman CLI.
+
+It is the first troubleshooting step, and a health probe for scripts: it fails when podman is unreachable or its
+kube play lacks a flag required by the deployments.`,
+	Example: `  ai-services runtime info
+  ai-services runtime info -o json`,
+	Args: cobra.MaximumNArgs(0),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if output != "" && output != "json" {
+			return fmt.Errorf("unsupported output format %q, supported formats: json", output)
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		info, err := aiservices.InspectRuntime(context.Background())
+		if err != nil {
+			return fmt.Errorf("failed to inspect the runtime: %w", err)
+		}
+		machine.SetData(info)
+
+		if output == "json" {
+			out, err := json.MarshalIndent(info, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal the runtime info: %w", err)
+			}
+			fmt.Println(string(out))
+		} else {
+			printInfo(info)
+		}
+
+		if !info.Healthy() {
+			return errors.New("the runtime is not usable, see the errors above")
+		}
+		return nil
+	},
+}
+
+func init() {
+	infoCmd.Flags().StringVarP(&output, "output", "o", "", "Output format (json)")
+}
+
+func printInfo(info *aiservices.RuntimeInfo) {
+	mode := "rootful"
+	if info.Rootless {
+		mode = "rootless"
+	}
+	connected := "connected"
+	if !info.Connected {
+		connected = "unreachable"
+	} else if info.Remote {
+		connected = "connected, remote"
+	}
+
+	logger.Infof("Connection:     %s (%s)\n", info.Connection, connected)
+	logger.Infof("Mode:           %s\n", mode)
+	logger.Infof("Server version: %s (API %s)\n", orUnknown(info.ServerVersion), orUnknown(info.APIVersion))
+	logger.Infof("Client version: %s\n", orUnknown(info.ClientVersion))
+	logger.Infof("Storage driver: %s\n", orUnknown(info.StorageDriver))
+	if info.GraphRoot != "" {
+		logger.Infof("Storage:        %s, %.1fGi free of %.1fGi\n", info.GraphRoot,
+			float64(info.DiskFree)/(1<<30), float64(info.DiskTotal)/(1<<30))
+	}
+
+	if info.KubePlay.Available {
+		var flags []string
+		for flag, supported := range info.KubePlay.Flags {
+			mark := "+"
+			if !supported {
+				mark = "-"
+			}
+			flags = append(flags, mark+flag)
+		}
+		slices.Sort(flags)
+		logger.Infof("Kube play:      %s\n", strings.Join(flags, " "))
+	} else {
+		logger.Infoln("Kube play:      unavailable")
+	}
+
+	for _, e := range info.Errors {
+		logger.Infof("Error: %s\n", e)
+	}
+}
+
+func orUnknown(s string) string {
+	if s == "" {
+		return "unknown"
+	}
+	return s
+}
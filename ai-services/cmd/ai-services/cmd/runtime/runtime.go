@@ -0,0 +1,16 @@
+package runtime
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// RuntimeCmd represents the runtime command
+var RuntimeCmd = &cobra.Command{
+	Use:   "runtime",
+	Short: "Inspect the container runtime",
+	Long:  `The runtime command helps you inspect the podman runtime driven by ai-services`,
+}
+
+func init() {
+	RuntimeCmd.AddCommand(infoCmd)
+}
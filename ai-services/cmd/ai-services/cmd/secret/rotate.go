@@ -0,0 +1,114 @@
+package secret
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+	"github.com/project-ai-services/ai-services/pkg/aiservices"
+)
+
+var (
+	fromFiles        []string
+	fromLiterals     []string
+	removeKeys       []string
+	readinessTimeout time.Duration
+
+	secretData map[string][]byte
+)
+
+var rotateCmd = &cobra.Command{
+	Use:   "rotate [name]",
+	Short: "Updates a secret and restarts the containers referencing it",
+	Long: `Updates the keys of the podman secret, the other keys are kept, and performs a rolling restart of the
+containers referencing it: each container is restarted once the previous one is ready again.
+
+Only the containers of applications deployed after the secret references were recorded are restarted, re-deploy the
+older applications to record them. Use 'ai-services apikey' to rotate the API keys.
+
+Arguments
+  [name]: Name of the podman secret (required)`,
+	Example: `  # Rotate the TLS certificate of application 'rag'
+  ai-services secret rotate rag--tls --from-file tls.crt=new.crt --from-file tls.key=new.key
+
+  # Update a credential
+  ai-services secret rotate rag--db --from-literal password=s3cr3t`,
+	Args: cobra.ExactArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		secretData = map[string][]byte{}
+
+		files, err := utils.ParseKeyValues(fromFiles)
+		if err != nil {
+			return fmt.Errorf("error validating from-file flag: %v", err)
+		}
+		for key, path := range files {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", path, err)
+			}
+			secretData[key] = data
+		}
+
+		literals, err := utils.ParseKeyValues(fromLiterals)
+		if err != nil {
+			return fmt.Errorf("error validating from-literal flag: %v", err)
+		}
+		for key, val := range literals {
+			if _, ok := secretData[key]; ok {
+				return fmt.Errorf("key '%s' provided with both --from-file and --from-literal", key)
+			}
+			secretData[key] = []byte(val)
+		}
+
+		if len(secretData) == 0 && len(removeKeys) == 0 {
+			return fmt.Errorf("at least one of --from-file, --from-literal or --remove-key is required")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		client, err := aiservices.NewClient(context.Background())
+		if err != nil {
+			return err
+		}
+
+		result, err := client.RotateSecret(context.Background(), name, aiservices.RotateSecretOptions{
+			Data:             secretData,
+			Remove:           removeKeys,
+			ReadinessTimeout: readinessTimeout,
+		})
+		if result != nil {
+			// the secret is replaced even if the rollout fails
+			machine.MarkChanged()
+			machine.SetData(result)
+		}
+		if err != nil {
+			return err
+		}
+
+		logger.Infof("Secret %s rotated (keys: %s)\n", name, strings.Join(result.Keys, ", "))
+		if len(result.Restarted) > 0 {
+			logger.Infof("Restarted containers: %s\n", strings.Join(result.Restarted, ", "))
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rotateCmd.Flags().StringArrayVar(&fromFiles, "from-file", []string{}, "Key of the secret to set from the content of a file, Eg:- tls.crt=./new.crt. Repeatable")
+	rotateCmd.Flags().StringArrayVar(&fromLiterals, "from-literal", []string{}, "Key of the secret to set to a value, Eg:- password=s3cr3t. Repeatable")
+	rotateCmd.Flags().StringSliceVar(&removeKeys, "remove-key", []string{}, "Keys to remove from the secret")
+	rotateCmd.Flags().DurationVar(&readinessTimeout, "readiness-timeout", 10*time.Minute, "Time to wait for a restarted container to be ready before restarting the next one")
+}
@@ -0,0 +1,24 @@
+package secret
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// SecretCmd represents the secret command
+var SecretCmd = &cobra.Command{
+	Use:   "secret",
+	Short: "Manage the podman secrets of the applications",
+	Long: `Manages the podman secrets provisioned by ai-services for the applications, Eg:- TLS certificates and
+credentials referenced by the pod templates.
+
+The containers referencing each secret are recorded when the application is deployed, so that rotating a secret
+only restarts them instead of re-deploying the application.`,
+	Args: cobra.MaximumNArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmd.Help()
+	},
+}
+
+func init() {
+	SecretCmd.AddCommand(rotateCmd)
+}
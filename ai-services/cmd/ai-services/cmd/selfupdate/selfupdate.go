@@ -0,0 +1,73 @@
+package selfupdate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/cmd/ai-services/cmd/version"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+	"github.com/project-ai-services/ai-services/internal/pkg/updater"
+)
+
+var (
+	endpoint  string
+	checkOnly bool
+)
+
+// SelfUpdateCmd represents the self-update command
+var SelfUpdateCmd = &cobra.Command{
+	Use:   "self-update",
+	Short: "Updates the ai-services binary to the latest release",
+	Long: `Checks for a newer release of ai-services and updates the binary.
+
+When the binary is installed by the RPM package, the update goes through the configured RPM repositories
+and dnf verifies the package signatures. Otherwise the release manifest is fetched from the release endpoint,
+and the downloaded binary is verified against its checksum and its ed25519 signature before replacing the
+running binary. The signature binds the binary to the version of the release, verified against the public key
+built into ai-services, and the releases not newer than the running version are refused.
+
+Use --check-only to only report whether a newer release is available, Eg:- on air-gapped hosts where the
+update is installed from a bundle.`,
+	Example: `  ai-services self-update --check-only
+  ai-services self-update --endpoint https://example.com/ai-services/latest.json`,
+	Args: cobra.MaximumNArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		ctx := context.Background()
+		opts := updater.Options{Endpoint: endpoint}
+
+		release, err := updater.Check(ctx, version.GetVersion(), opts)
+		if err != nil {
+			return fmt.Errorf("failed to check for updates: %w", err)
+		}
+		machine.SetData(release)
+
+		if !release.UpdateAvailable {
+			logger.Infof("ai-services %s is the latest release\n", release.Current)
+			return nil
+		}
+
+		logger.Infof("A newer release is available: %s (current: %s)\n", release.Latest, release.Current)
+		if checkOnly {
+			return nil
+		}
+
+		if err := updater.Update(ctx, release); err != nil {
+			return fmt.Errorf("failed to update: %w", err)
+		}
+		machine.MarkChanged()
+
+		logger.Infof("ai-services updated to %s\n", release.Latest)
+		return nil
+	},
+}
+
+func init() {
+	SelfUpdateCmd.Flags().StringVar(&endpoint, "endpoint", "", "URL of the release manifest (default: the release endpoint of the build)")
+	SelfUpdateCmd.Flags().BoolVar(&checkOnly, "check-only", false, "Only check whether a newer release is available")
+}
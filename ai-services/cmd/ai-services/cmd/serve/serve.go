@@ -0,0 +1,253 @@
+package serve
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/certs"
+	"github.com/project-ai-services/ai-services/internal/pkg/heartbeat"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/server"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+	"github.com/project-ai-services/ai-services/pkg/aiservices"
+)
+
+var (
+	listenAddr    string
+	grpcAddr      string
+	tokenFile     string
+	tlsCertFile   string
+	tlsKeyFile    string
+	collectLogs   bool
+	logDir        string
+	logMaxSize    int
+	logMaxFiles   int
+	heartbeatFile string
+)
+
+// ServeCmd represents the serve command
+var ServeCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serves the ai-services operations over REST and gRPC APIs",
+	Long: `Serves the operations on the applications, templates and validation over an authenticated REST API,
+enabling a web UI or remote management of the host.
+
+Every request requires the API token as bearer token. Unless --token-file is provided, the token is generated
+on first use, printed once on the standard output and stored in the ai-services state directory.
+
+The create requests cannot name files of the host (valuesFiles, signaturePolicy, tls.certFile, tls.keyFile) nor
+allow more host paths to be mounted (allowedHostPaths). The finished operations are kept for an hour.
+
+Endpoints
+  GET    /api/v1/applications         List the applications
+  POST   /api/v1/applications         Deploy an application, returns the operation
+  GET    /api/v1/applications/{name}  Status of the application
+  DELETE /api/v1/applications/{name}  Delete the application, returns the operation (?keepSMT=true, ?purge=true
+                                      to remove its volumes, ?gracePeriod=<duration>)
+  GET    /api/v1/operations/{id}      Status of the operation
+  GET    /api/v1/templates            List the application templates
+  GET    /api/v1/templates/{name}     Parameters of the application template
+  POST   /api/v1/validate             Validate the host (?skip=<check>)
+  GET    /healthz                     Liveness of the server and progress of the running operation, unauthenticated
+
+With --grpc-listen, the same operations are served by the gRPC service '` + server.ServiceName + `',
+along with the server-streaming RPCs CreateApplication, WatchOperation and StreamLogs reporting the deployment
+progress and the container logs live. The service is defined by pkg/api/v1/management.proto.
+
+With --collect-logs, the logs of the containers of all the applications are continuously collected into
+<log-dir>/<application>/<container>.log and rotated, so that they survive the recreation of the containers and
+can be shipped by the existing log agents.
+
+With --heartbeat-file, the liveness of the server is also written to a file every 15s, for the supervisors
+without network access to the server.
+
+When the 'healthAggregator' section of the CLI config enables it, the health of all the applications is also
+served unauthenticated over plain HTTP, for the external load balancers and monitors to probe a single URL per
+host. The response is 200 while the applications are serving and 503 otherwise, with the health of each component:
+  GET    /health                      Health of all the applications
+  GET    /health/{name}               Health of the application
+
+  healthAggregator:
+    enabled: true
+    listen: ":8090"
+    cacheTTL: 5s`,
+	Example: `  ai-services serve --listen :8443 --grpc-listen :8444
+  ai-services serve --collect-logs --log-max-size 100
+
+  curl -k -H "Authorization: Bearer $TOKEN" https://localhost:8443/api/v1/applications`,
+	Args: cobra.MaximumNArgs(0),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if (tlsCertFile == "") != (tlsKeyFile == "") {
+			return fmt.Errorf("--tls-cert and --tls-key must be provided together")
+		}
+		if logMaxSize <= 0 || logMaxFiles <= 0 {
+			return fmt.Errorf("--log-max-size and --log-max-files must be positive")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) (runErr error) {
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		client, err := aiservices.NewClient(ctx)
+		if err != nil {
+			return err
+		}
+
+		token, generated, err := server.LoadToken(tokenFile)
+		if err != nil {
+			return fmt.Errorf("failed to load API token: %w", err)
+		}
+		if generated {
+			// printed once on the standard output, and not logged
+			fmt.Printf("Generated API token: %s\n", token)
+			logger.Warningf("Store the API token safely, it is required on every request\n")
+		}
+
+		tlsConfig, err := serverTLSConfig()
+		if err != nil {
+			return err
+		}
+
+		hb, err := heartbeat.Start(heartbeatFile, "serve", 0)
+		if err != nil {
+			return err
+		}
+		defer func() { hb.Stop(runErr) }()
+		hb.Progress("serving")
+
+		apiServer := server.New(ctx, client, token)
+		srv := &http.Server{
+			Addr:              listenAddr,
+			Handler:           apiServer.Handler(),
+			TLSConfig:         tlsConfig,
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+
+		aggregatorCfg, err := server.LoadAggregatorConfig()
+		if err != nil {
+			return err
+		}
+
+		errCh := make(chan error, 4)
+		if collectLogs {
+			go func() {
+				logger.Infof("Collecting the application logs into %s\n", logDir)
+				if err := client.CollectLogs(ctx, aiservices.LogCollectOptions{
+					Directory: logDir,
+					MaxSize:   int64(logMaxSize) << 20,
+					MaxFiles:  logMaxFiles,
+				}); err != nil {
+					errCh <- fmt.Errorf("log collection: %w", err)
+				}
+			}()
+		}
+
+		go func() {
+			logger.Infof("Serving the REST API on %s\n", listenAddr)
+			errCh <- srv.ListenAndServeTLS("", "")
+		}()
+
+		var aggregatorServer *http.Server
+		if aggregatorCfg.Enabled {
+			aggregatorServer = &http.Server{
+				Addr:              aggregatorCfg.Listen,
+				Handler:           server.NewAggregator(client, aggregatorCfg).Handler(),
+				ReadHeaderTimeout: 10 * time.Second,
+			}
+			go func() {
+				logger.Infof("Serving the health of the applications on %s\n", aggregatorCfg.Listen)
+				errCh <- aggregatorServer.ListenAndServe()
+			}()
+		}
+
+		var grpcServer *grpc.Server
+		if grpcAddr != "" {
+			lis, err := net.Listen("tcp", grpcAddr)
+			if err != nil {
+				return fmt.Errorf("failed to listen on %s: %w", grpcAddr, err)
+			}
+			grpcServer = apiServer.GRPCServer(grpc.Creds(credentials.NewTLS(tlsConfig)))
+			go func() {
+				logger.Infof("Serving the gRPC API on %s\n", grpcAddr)
+				errCh <- grpcServer.Serve(lis)
+			}()
+			defer grpcServer.Stop()
+		}
+
+		select {
+		case err := <-errCh:
+			if !errors.Is(err, http.ErrServerClosed) {
+				return fmt.Errorf("failed to serve: %w", err)
+			}
+		case <-ctx.Done():
+			logger.Infoln("Shutting down the server...")
+			if grpcServer != nil {
+				grpcServer.GracefulStop()
+			}
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			if aggregatorServer != nil {
+				if err := aggregatorServer.Shutdown(shutdownCtx); err != nil {
+					logger.Warningf("failed to shutdown the health aggregator: %v\n", err)
+				}
+			}
+			if err := srv.Shutdown(shutdownCtx); err != nil {
+				return fmt.Errorf("failed to shutdown the server: %w", err)
+			}
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	ServeCmd.Flags().StringVar(&listenAddr, "listen", ":8443", "Address to listen on")
+	ServeCmd.Flags().StringVar(&grpcAddr, "grpc-listen", "", "Address to serve the gRPC API on (default: disabled)")
+	ServeCmd.Flags().StringVar(&tokenFile, "token-file", "", "File holding the API token required as bearer token (default: generated token)")
+	ServeCmd.Flags().StringVar(&tlsCertFile, "tls-cert", "", "Path to the PEM encoded TLS certificate (default: self-signed certificate)")
+	ServeCmd.Flags().StringVar(&tlsKeyFile, "tls-key", "", "Path to the PEM encoded private key of the TLS certificate")
+	ServeCmd.Flags().BoolVar(&collectLogs, "collect-logs", false, "Collect the container logs of all the applications into files, one directory per application")
+	ServeCmd.Flags().StringVar(&logDir, "log-dir", vars.AppLogDirectory, "Directory the application logs are collected into")
+	ServeCmd.Flags().IntVar(&logMaxSize, "log-max-size", 50, "Size in MiB a collected log file is rotated at")
+	ServeCmd.Flags().IntVar(&logMaxFiles, "log-max-files", 5, "Number of rotated log files kept per container")
+	ServeCmd.Flags().StringVar(&heartbeatFile, "heartbeat-file", "", "File the liveness of the server is written to every 15s, for the supervisors to detect a hung server")
+}
+
+// serverTLSConfig loads the provided certificate, or generates a self-signed one for the host
+func serverTLSConfig() (*tls.Config, error) {
+	var certPEM, keyPEM []byte
+	var err error
+	if tlsCertFile != "" {
+		certPEM, keyPEM, err = certs.LoadPEM(tlsCertFile, tlsKeyFile)
+	} else {
+		hosts := certs.DefaultHosts()
+		logger.Infof("Generating self-signed TLS certificate for hosts: %v\n", hosts)
+		certPEM, keyPEM, err = certs.GenerateSelfSigned("ai-services", hosts, 0)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+
+	return &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}, nil
+}
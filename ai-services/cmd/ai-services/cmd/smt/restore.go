@@ -0,0 +1,50 @@
+package smt
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+	"github.com/project-ai-services/ai-services/pkg/aiservices"
+)
+
+var dryRun bool
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Restores the SMT level the host had before ai-services changed it",
+	Long: `Restores the SMT level the host had before ai-services changed it, Eg:- after deleting the applications
+with --keep-smt. Running applications requiring another SMT level may be degraded.`,
+	Args: cobra.MaximumNArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		restored, err := aiservices.RestoreSMTLevel(context.Background(), dryRun)
+		if err != nil {
+			return fmt.Errorf("failed to restore SMT level: %w", err)
+		}
+
+		if restored == 0 {
+			logger.Infoln("No SMT level to restore, ai-services did not change the SMT level of the host")
+			return nil
+		}
+
+		if dryRun {
+			logger.Infof("[dry-run] The original SMT level %d would be restored\n", restored, 0)
+			return nil
+		}
+
+		machine.MarkChanged()
+		machine.SetData(map[string]int{"restored": restored})
+		logger.Infof("Restored the original SMT level: %d\n", restored, 0)
+		return nil
+	},
+}
+
+func init() {
+	restoreCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Only report the SMT level that would be restored")
+}
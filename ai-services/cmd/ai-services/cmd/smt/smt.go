@@ -0,0 +1,28 @@
+package smt
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// SMTCmd represents the smt command
+var SMTCmd = &cobra.Command{
+	Use:   "smt",
+	Short: "Manage the SMT level of the host",
+	Long: `Application templates may require an SMT level, which 'ai-services application create' sets on the host.
+The SMT level the host had before the first change is recorded, and restored once the last application
+requiring an SMT level is deleted.`,
+	Example: `  # Show the current SMT level and the levels required by the applications
+  ai-services smt status
+
+  # Restore the SMT level the host had before ai-services changed it
+  ai-services smt restore`,
+	Args: cobra.MaximumNArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmd.Help()
+	},
+}
+
+func init() {
+	SMTCmd.AddCommand(statusCmd)
+	SMTCmd.AddCommand(restoreCmd)
+}
@@ -0,0 +1,55 @@
+package smt
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+	"github.com/project-ai-services/ai-services/pkg/aiservices"
+)
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Shows the SMT level of the host and the levels required by the applications",
+	Args:  cobra.MaximumNArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		st, current, err := aiservices.SMTStatus(context.Background())
+		if err != nil {
+			return fmt.Errorf("failed to fetch SMT status: %w", err)
+		}
+		machine.SetData(map[string]any{"current": current, "original": st.Original, "applications": st.Applications})
+
+		logger.Infof("Current SMT level: %d\n", current, 0)
+		if st.Original != 0 {
+			logger.Infof("Original SMT level: %d\n", st.Original, 0)
+		}
+
+		if len(st.Applications) == 0 {
+			logger.Infoln("No application requires an SMT level")
+			return nil
+		}
+
+		apps := make([]string, 0, len(st.Applications))
+		for app := range st.Applications {
+			apps = append(apps, app)
+		}
+		sort.Strings(apps)
+
+		p := utils.NewTableWriter()
+		defer p.CloseTableWriter()
+		p.SetHeaders("APPLICATION NAME", "SMT LEVEL")
+		for _, app := range apps {
+			p.AppendRow(app, fmt.Sprintf("%d", st.Applications[app]))
+		}
+
+		return nil
+	},
+}
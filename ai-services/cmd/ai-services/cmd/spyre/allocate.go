@@ -0,0 +1,126 @@
+package spyre
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/audit"
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/helpers"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+	"github.com/project-ai-services/ai-services/internal/pkg/spyre"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+)
+
+var (
+	owner  string
+	reason string
+)
+
+var allocateCmd = &cobra.Command{
+	Use:   "allocate [pci-address]",
+	Short: "Allocate a Spyre card to an external workload",
+	Long: `Marks the Spyre card as allocated to a workload not managed by ai-services, so that it is never assigned to the
+applications until deallocated.
+
+Arguments
+  [pci-address]: PCI address of the card as listed by 'lspci -d 1014:06a7' (required)`,
+	Example: `  ai-services spyre allocate 0381:50:00.0 --owner batch-inference --reason "benchmarks until Friday"`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		address := spyre.NormalizeAddress(args[0])
+
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		cards, err := helpers.ListSpyreCards()
+		if err != nil {
+			return fmt.Errorf("failed to list the spyre cards: %w", err)
+		}
+		if !slices.Contains(cards, address) {
+			return machine.WithExitCode(machine.ExitNotFound, fmt.Errorf("spyre card %s is not attached to the host", address))
+		}
+
+		confirmed, err := utils.ConfirmAction(fmt.Sprintf("Spyre card %s will not be assigned to any application until deallocated. Are you sure? ", address))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			logger.Infoln("Allocation cancelled")
+			return nil
+		}
+
+		a, err := spyre.Allocate(address, owner, reason)
+		if err != nil {
+			return fmt.Errorf("failed to allocate spyre card: %w", err)
+		}
+		machine.SetData(a)
+		machine.MarkChanged()
+
+		if err := audit.Record(audit.Entry{Action: "spyre allocate", Details: fmt.Sprintf("%s to %s", a.Address, a.Owner)}); err != nil {
+			logger.Warningf("failed to record the allocation in the audit history: %v\n", err)
+		}
+
+		logger.Infof("Spyre card %s allocated to %s\n", a.Address, a.Owner)
+
+		return nil
+	},
+}
+
+var deallocateCmd = &cobra.Command{
+	Use:   "deallocate [pci-address]",
+	Short: "Release a Spyre card allocated manually",
+	Long: `Removes the Spyre card from the ledger, making it available to the applications again. Also releases the stale
+allocations of the cards no longer attached to the host.
+
+Arguments
+  [pci-address]: PCI address of the card (required)`,
+	Example: `  ai-services spyre deallocate 0381:50:00.0`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		address := spyre.NormalizeAddress(args[0])
+
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		ledger, err := spyre.List()
+		if err != nil {
+			return fmt.Errorf("failed to read the spyre ledger: %w", err)
+		}
+		if !spyre.Allocated(ledger, address) {
+			return machine.WithExitCode(machine.ExitNotFound, fmt.Errorf("spyre card %s is not allocated", address))
+		}
+
+		confirmed, err := utils.ConfirmAction(fmt.Sprintf("Spyre card %s may be assigned to the applications once deallocated. Are you sure? ", address))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			logger.Infoln("Deallocation cancelled")
+			return nil
+		}
+
+		a, err := spyre.Deallocate(address)
+		if err != nil {
+			return fmt.Errorf("failed to deallocate spyre card: %w", err)
+		}
+		machine.SetData(a)
+		machine.MarkChanged()
+
+		if err := audit.Record(audit.Entry{Action: "spyre deallocate", Details: fmt.Sprintf("%s from %s", a.Address, a.Owner)}); err != nil {
+			logger.Warningf("failed to record the deallocation in the audit history: %v\n", err)
+		}
+
+		logger.Infof("Spyre card %s deallocated from %s\n", a.Address, a.Owner)
+
+		return nil
+	},
+}
+
+func init() {
+	allocateCmd.Flags().StringVar(&owner, "owner", "", "Workload the card is allocated to (required)")
+	allocateCmd.Flags().StringVar(&reason, "reason", "", "Reason of the allocation, shown in the ledger")
+	_ = allocateCmd.MarkFlagRequired("owner")
+}
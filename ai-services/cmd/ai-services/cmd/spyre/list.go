@@ -0,0 +1,67 @@
+package spyre
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+	"github.com/project-ai-services/ai-services/internal/pkg/spyre"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+)
+
+var output string
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the Spyre cards allocated manually",
+	Args:  cobra.MaximumNArgs(0),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if output != "" && output != "json" {
+			return fmt.Errorf("unsupported output format %q, supported formats: json", output)
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		ledger, err := spyre.List()
+		if err != nil {
+			return fmt.Errorf("failed to read the spyre ledger: %w", err)
+		}
+		machine.SetData(ledger)
+
+		if output == "json" {
+			if ledger == nil {
+				ledger = []spyre.Allocation{}
+			}
+			out, err := json.MarshalIndent(ledger, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal spyre ledger: %w", err)
+			}
+			fmt.Println(string(out))
+			return nil
+		}
+
+		if len(ledger) == 0 {
+			logger.Infoln("No spyre cards allocated manually")
+			return nil
+		}
+
+		p := utils.NewTableWriter()
+		defer p.CloseTableWriter()
+		p.SetHeaders("ADDRESS", "OWNER", "REASON", "ALLOCATED")
+		for _, a := range ledger {
+			p.AppendRow(a.Address, a.Owner, a.Reason, a.Allocated.Local().Format("2006-01-02 15:04"))
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	listCmd.Flags().StringVarP(&output, "output", "o", "", "Output format (json)")
+}
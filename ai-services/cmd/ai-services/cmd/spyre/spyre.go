@@ -0,0 +1,25 @@
+package spyre
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// SpyreCmd represents the spyre command
+var SpyreCmd = &cobra.Command{
+	Use:   "spyre",
+	Short: "Manage the Spyre cards of the host",
+	Long: `Manages the ledger of the Spyre cards allocated manually.
+
+On shared hosts, cards used by workloads not managed by ai-services can be allocated in the ledger so that they are
+never assigned to the applications, and deallocated once released.`,
+	Args: cobra.MaximumNArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmd.Help()
+	},
+}
+
+func init() {
+	SpyreCmd.AddCommand(allocateCmd)
+	SpyreCmd.AddCommand(deallocateCmd)
+	SpyreCmd.AddCommand(listCmd)
+}
@@ -0,0 +1,72 @@
+package status
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/compliance"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+)
+
+var output string
+
+// StatusCmd represents the status command
+var StatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Shows the compliance status of the host",
+	Long: `Shows whether the host is still in its validated configuration, from the validations recorded by
+'ai-services bootstrap validate', run periodically with --watch.
+
+The host is reported as drifted once a validation fails after the host was validated, Eg:- the SMT level was reset
+or a Spyre card was detached.`,
+	Args: cobra.MaximumNArgs(0),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if output != "" && output != "json" {
+			return fmt.Errorf("unsupported output format %q, supported formats: json", output)
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		st, err := compliance.Current()
+		if err != nil {
+			return fmt.Errorf("failed to read the compliance history: %w", err)
+		}
+		machine.SetData(map[string]any{"compliance": st})
+
+		if output == "json" {
+			out, err := json.MarshalIndent(map[string]any{"compliance": st}, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal status: %w", err)
+			}
+			fmt.Println(string(out))
+			return nil
+		}
+
+		if st.Status == compliance.StatusUnknown {
+			logger.Infoln("Compliance: unknown, the host was never validated. Run 'ai-services bootstrap validate'")
+			return nil
+		}
+
+		logger.Infof("Compliance: %s since %s\n", st.Status, st.Since.Local().Format(time.RFC1123))
+		logger.Infof("Last validation: %s, %d passed, %d failed, %d warnings\n",
+			st.Last.Time.Local().Format(time.RFC1123), st.Last.Passed, st.Last.Failed, st.Last.Warnings, 0)
+		if len(st.Last.FailedChecks) > 0 {
+			logger.Infof("Failed checks: %s\n", strings.Join(st.Last.FailedChecks, ", "))
+		}
+		logger.Infof("Validations passed: %.0f%% of %d\n", st.CompliantRatio*100, st.Validations, 0)
+
+		return nil
+	},
+}
+
+func init() {
+	StatusCmd.Flags().StringVarP(&output, "output", "o", "", "Output format (json)")
+}
@@ -0,0 +1,44 @@
+package telemetry
+
+import (
+	"slices"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/cmd/ai-services/cmd/version"
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/templates"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+	"github.com/project-ai-services/ai-services/internal/pkg/telemetry"
+)
+
+// ReportUsage reports the usage of the command if the telemetry is enabled
+func ReportUsage(cmd *cobra.Command, code int, duration time.Duration) {
+	event := telemetry.Event{
+		Command:         cmd.CommandPath(),
+		Success:         code == machine.ExitOK,
+		DurationSeconds: duration.Seconds(),
+		Version:         version.GetVersion(),
+	}
+	switch code {
+	case machine.ExitOK:
+	case machine.ExitUsage:
+		event.FailureCategory = telemetry.FailureUsage
+	case machine.ExitValidationFailed:
+		event.FailureCategory = telemetry.FailureValidation
+	case machine.ExitNotFound:
+		event.FailureCategory = telemetry.FailureNotFound
+	default:
+		event.FailureCategory = telemetry.FailureError
+	}
+
+	// only the names of the embedded templates are reported, never the names of custom templates
+	if f := cmd.Flags().Lookup("template"); f != nil && f.Changed {
+		embedded, err := templates.NewEmbedTemplateProvider(templates.EmbedOptions{}).ListApplications()
+		if err == nil && slices.Contains(embedded, f.Value.String()) {
+			event.Template = f.Value.String()
+		}
+	}
+
+	telemetry.Report(event)
+}
@@ -0,0 +1,122 @@
+package telemetry
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+	"github.com/project-ai-services/ai-services/internal/pkg/telemetry"
+)
+
+var (
+	endpoint string
+	output   string
+)
+
+// TelemetryCmd represents the telemetry command
+var TelemetryCmd = &cobra.Command{
+	Use:   "telemetry",
+	Short: "Manage the opt-in usage telemetry",
+	Long: `Telemetry is disabled unless explicitly enabled. Once enabled, each command reports to the endpoint:
+  - the command path, never its arguments or flags
+  - the name of the embedded template deployed, never custom template names
+  - whether it succeeded, and the failure category: usage, validation, not-found or error
+  - its duration, the CLI version and the platform
+  - a random installation ID, regenerated on every opt-in
+
+Application names, values, hostnames and error messages are never reported. Set ` + telemetry.DisableEnv + `=off to
+disable the telemetry for a single invocation.`,
+	Args: cobra.MaximumNArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmd.Help()
+	},
+}
+
+var onCmd = &cobra.Command{
+	Use:     "on",
+	Short:   "Enable the usage telemetry",
+	Example: `  ai-services telemetry on --endpoint https://telemetry.example.com/v1/events`,
+	Args:    cobra.MaximumNArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		settings, err := telemetry.Enable(endpoint)
+		if err != nil {
+			return fmt.Errorf("failed to enable telemetry: %w", err)
+		}
+		machine.MarkChanged()
+		logger.Infof("Telemetry enabled, reporting to %s\n", settings.Endpoint)
+
+		return nil
+	},
+}
+
+var offCmd = &cobra.Command{
+	Use:   "off",
+	Short: "Disable the usage telemetry",
+	Args:  cobra.MaximumNArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		if err := telemetry.Disable(); err != nil {
+			return fmt.Errorf("failed to disable telemetry: %w", err)
+		}
+		machine.MarkChanged()
+		logger.Infoln("Telemetry disabled")
+
+		return nil
+	},
+}
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show whether the usage telemetry is enabled",
+	Args:  cobra.MaximumNArgs(0),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if output != "" && output != "json" {
+			return fmt.Errorf("unsupported output format %q, supported formats: json", output)
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		settings, err := telemetry.Load()
+		if err != nil {
+			return fmt.Errorf("failed to read telemetry settings: %w", err)
+		}
+		machine.SetData(settings)
+
+		if output == "json" {
+			out, err := json.MarshalIndent(settings, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal telemetry settings: %w", err)
+			}
+			fmt.Println(string(out))
+			return nil
+		}
+
+		if !settings.Enabled {
+			logger.Infoln("Telemetry is disabled")
+			return nil
+		}
+		logger.Infof("Telemetry is enabled, reporting to %s (installation ID %s)\n", settings.Endpoint, settings.InstallID)
+
+		return nil
+	},
+}
+
+func init() {
+	onCmd.Flags().StringVar(&endpoint, "endpoint", "", "URL the usage events are posted to as JSON (default: the endpoint of the previous opt-in)")
+	statusCmd.Flags().StringVarP(&output, "output", "o", "", "Output format (json)")
+
+	TelemetryCmd.AddCommand(onCmd)
+	TelemetryCmd.AddCommand(offCmd)
+	TelemetryCmd.AddCommand(statusCmd)
+}
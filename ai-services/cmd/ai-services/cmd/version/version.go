@@ -1,7 +1,15 @@
 package version
 
 import (
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"sort"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/templates"
+	"github.com/project-ai-services/ai-services/internal/pkg/constants"
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
 	"github.com/spf13/cobra"
 )
 
@@ -10,16 +18,110 @@ var (
 	Version   string = "unknown"
 	GitCommit string = "unknown"
 	BuildDate string = ""
+	// TemplatesVersion is the version of the embedded application templates bundle, defaults to Version
+	TemplatesVersion string = ""
 )
 
+var output string
+
 func GetVersion() string {
 	return Version
 }
 
+// Info is the build metadata and compatibility info of the CLI
+type Info struct {
+	Version          string            `json:"version"`
+	GitCommit        string            `json:"gitCommit"`
+	BuildDate        string            `json:"buildDate"`
+	GoVersion        string            `json:"goVersion"`
+	Platform         string            `json:"platform"`
+	TemplatesVersion string            `json:"templatesVersion"`
+	Templates        map[string]string `json:"templates,omitempty"`
+	MinPodmanVersion string            `json:"minPodmanVersion"`
+	MinRHELVersion   string            `json:"minRHELVersion"`
+}
+
+// GetInfo returns the build metadata along with the versions of the embedded templates
+func GetInfo() Info {
+	info := Info{
+		Version:          Version,
+		GitCommit:        GitCommit,
+		BuildDate:        BuildDate,
+		GoVersion:        runtime.Version(),
+		Platform:         runtime.GOOS + "/" + runtime.GOARCH,
+		TemplatesVersion: TemplatesVersion,
+		Templates:        map[string]string{},
+		MinPodmanVersion: constants.MinPodmanVersion,
+		MinRHELVersion:   constants.MinRHELVersion,
+	}
+	if info.TemplatesVersion == "" {
+		info.TemplatesVersion = Version
+	}
+
+	tp := templates.NewEmbedTemplateProvider(templates.EmbedOptions{})
+	names, err := tp.ListApplications()
+	if err != nil {
+		logger.Warningf("failed to list application templates: %v\n", err)
+		return info
+	}
+	for _, name := range names {
+		md, err := tp.LoadMetadata(name)
+		if err != nil {
+			logger.Warningf("failed to load metadata of template %s: %v\n", name, err)
+			continue
+		}
+		info.Templates[name] = md.Version
+	}
+
+	return info
+}
+
 var VersionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Prints CLI version with more info",
-	Run: func(cmd *cobra.Command, args []string) {
-		logger.Infof("Version: %s\nGitCommit: %s\nBuildDate: %s\n", Version, GitCommit, BuildDate)
+	Long: `Prints the CLI version, git commit and build date, along with the version of the embedded application
+templates and the minimum supported podman and RHEL versions.`,
+	Example: `  ai-services version
+  ai-services version --output json`,
+	Args: cobra.MaximumNArgs(0),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if output != "" && output != "json" {
+			return fmt.Errorf("unsupported output format %q, supported formats: json", output)
+		}
+		return nil
 	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		info := GetInfo()
+		machine.SetData(info)
+
+		if output == "json" {
+			out, err := json.MarshalIndent(info, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal version info: %w", err)
+			}
+			fmt.Println(string(out))
+			return nil
+		}
+
+		logger.Infof("Version: %s\nGitCommit: %s\nBuildDate: %s\n", info.Version, info.GitCommit, info.BuildDate)
+		logger.Infof("GoVersion: %s\nPlatform: %s\n", info.GoVersion, info.Platform)
+		logger.Infof("TemplatesVersion: %s\n", info.TemplatesVersion)
+		names := make([]string, 0, len(info.Templates))
+		for name := range info.Templates {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			logger.Infof("  %s: %s\n", name, info.Templates[name])
+		}
+		logger.Infof("MinPodmanVersion: %s\nMinRHELVersion: %s\n", info.MinPodmanVersion, info.MinRHELVersion)
+		return nil
+	},
+}
+
+func init() {
+	VersionCmd.Flags().StringVarP(&output, "output", "o", "", "Output format (json)")
 }
@@ -0,0 +1,151 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/heartbeat"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/pkg/aiservices"
+)
+
+var (
+	heartbeatFile string
+	usageInterval time.Duration
+	logAlerts     bool
+	restartLimits bool
+)
+
+// WatchCmd represents the watch command
+var WatchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Watches the deployed applications until interrupted",
+	Long: `Runs in the foreground until interrupted, watching the deployed applications with the enabled watchers:
+
+  --usage-interval   Samples the CPU, memory and Spyre utilization of the applications into the usage history,
+                     reported by 'ai-services report usage' and 'ai-services report chargeback'
+  --log-alerts       Matches the container logs against the log alerts of the templates and of the config file,
+                     recording the alerts in the audit history and sending them to the webhooks subscribed to the
+                     log-alert event
+  --restart-limits   Stops the pods of the containers restarted more than the restart limit of their pod template,
+                     recording them in the audit history
+
+The command is meant to be run by a supervisor, Eg:- a systemd service, which can follow its liveness through
+--heartbeat-file.`,
+	Example: `  # Sample the resource usage of the applications every 15 minutes
+  ai-services watch --usage-interval 15m
+
+  # Alert on the log lines of the containers matching the log alerts
+  ai-services watch --log-alerts --heartbeat-file /run/ai-services/watch.json`,
+	Args: cobra.MaximumNArgs(0),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if usageInterval != 0 && usageInterval < time.Minute {
+			return fmt.Errorf("--usage-interval must be at least 1m")
+		}
+		if usageInterval == 0 && !logAlerts && !restartLimits {
+			return errors.New("nothing to watch, enable at least one of --usage-interval, --log-alerts, --restart-limits")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		return run(cmd.Context())
+	},
+}
+
+func init() {
+	WatchCmd.Flags().StringVar(&heartbeatFile, "heartbeat-file", "", "File the status of the watch is written to every 15s, for the supervisors to detect a hung daemon")
+	WatchCmd.Flags().DurationVar(&usageInterval, "usage-interval", 0, "Interval of the samples of the resource usage of the applications (default: disabled)")
+	WatchCmd.Flags().BoolVar(&logAlerts, "log-alerts", false, "Match the container logs against the log alerts of the templates and of the config file")
+	WatchCmd.Flags().BoolVar(&restartLimits, "restart-limits", false, "Stop the pods of the containers exceeding the restart limit of their pod template")
+}
+
+// run runs the enabled watchers until the context is cancelled or interrupted, recording their progress in the
+// heartbeat file, if any
+func run(ctx context.Context) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	client, err := aiservices.NewClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	hb, err := heartbeat.Start(heartbeatFile, "watch", 0)
+	if err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	if logAlerts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := client.WatchLogAlerts(ctx, 10*time.Second, func(alert aiservices.LogAlertEvent) {
+				logger.Warningf("log alert %s of application %s, container %s of pod %s: %s\n", alert.Alert, alert.Application, alert.Container, alert.Pod, alert.Line)
+				hb.Progress("log alert " + alert.Alert + " of application " + alert.Application)
+			})
+			if err != nil {
+				logger.Warningf("the log alerts are not matched: %v\n", err)
+			}
+		}()
+	}
+
+	if restartLimits {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := client.WatchRestarts(ctx, 10*time.Second, func(e aiservices.RestartEvent) {
+				if e.LimitReached {
+					logger.Warningf("container %s of pod %s of application %s exited with code %d and exceeded its limit of %d restarts, the pod is stopped\n",
+						e.Container, e.Pod, e.Application, e.ExitCode, e.MaxRestarts)
+					hb.Progress("restart limit of container " + e.Container + " of application " + e.Application)
+					return
+				}
+				logger.Infof("Container %s of pod %s of application %s restarted, exited with code %d (%d/%d)\n",
+					e.Container, e.Pod, e.Application, e.ExitCode, e.Restarts, e.MaxRestarts, 0)
+			})
+			if err != nil {
+				logger.Warningf("the restart limits are not enforced: %v\n", err)
+			}
+		}()
+	}
+
+	// a nil channel never fires, when the usage is not sampled
+	var usageTick <-chan time.Time
+	if usageInterval > 0 {
+		logger.Infof("Sampling the resource usage of the applications every %s\n", usageInterval)
+		usageTicker := time.NewTicker(usageInterval)
+		defer usageTicker.Stop()
+		usageTick = usageTicker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			hb.Stop(nil)
+			return nil
+		case <-usageTick:
+			samples, err := client.RecordUsage(ctx)
+			if err != nil {
+				logger.Warningf("failed to sample the resource usage: %v\n", err)
+				continue
+			}
+			hb.Progress(fmt.Sprintf("usage: %d applications sampled", len(samples)))
+		}
+	}
+}
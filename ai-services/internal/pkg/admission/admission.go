@@ -0,0 +1,212 @@
+// Package admission evaluates the rendered pods against the site policies of the 'admission' of the CLI config file
+// before they are played: the built-in rules (no privileged container, images from the approved registries only,
+// resource limits present) and an optional OPA rego policy, evaluated with the opa CLI.
+package admission
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/containers/image/v5/docker/reference"
+	v1 "github.com/containers/podman/v5/pkg/k8s.io/api/core/v1"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/config"
+	"github.com/project-ai-services/ai-services/internal/pkg/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+// Enforcement actions of the violations
+const (
+	// EnforceDeny refuses the pods violating the policies
+	EnforceDeny = "deny"
+	// EnforceWarn only reports the violations
+	EnforceWarn = "warn"
+)
+
+// DefaultRegoQuery is the rego query returning the messages of the violations
+const DefaultRegoQuery = "data.aiservices.admission.deny"
+
+// regoTimeout bounds the evaluation of the rego policy of a pod
+const regoTimeout = 30 * time.Second
+
+// Rules of the violations
+const (
+	RulePrivileged        = "privileged"
+	RuleAllowedRegistries = "allowedRegistries"
+	RuleResourceLimits    = "resourceLimits"
+	RuleRego              = "rego"
+)
+
+// RegoConfig is an OPA rego policy, receiving the pod as input. Its query defaults to DefaultRegoQuery
+type RegoConfig = config.AdmissionRego
+
+// Config is the 'admission' of the CLI config file
+type Config config.Admission
+
+// Violation is a policy violated by a pod
+type Violation struct {
+	Rule      string `json:"rule"`
+	Pod       string `json:"pod"`
+	Container string `json:"container,omitempty"`
+	Message   string `json:"message"`
+}
+
+func (v Violation) String() string {
+	if v.Container != "" {
+		return fmt.Sprintf("%s: container %s: %s", v.Rule, v.Container, v.Message)
+	}
+	return fmt.Sprintf("%s: %s", v.Rule, v.Message)
+}
+
+// DeniedError lists the violations of the pod refused by the policies
+type DeniedError struct {
+	Pod        string
+	Violations []Violation
+}
+
+func (e *DeniedError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "pod %s refused by the admission policies of %s:", e.Pod, vars.ConfigFile)
+	for _, v := range e.Violations {
+		fmt.Fprintf(&b, "\n  - %s", v)
+	}
+	return b.String()
+}
+
+// Load returns the 'admission' of the CLI config file, nil when no policy is configured
+func Load() (*Config, error) {
+	c, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	if c.Admission == nil {
+		return nil, nil
+	}
+	cfg := Config(*c.Admission)
+	switch cfg.Enforcement {
+	case "":
+		cfg.Enforcement = EnforceDeny
+	case EnforceDeny, EnforceWarn:
+	default:
+		return nil, fmt.Errorf("invalid admission.enforcement '%s' in %s, supported: %s, %s", cfg.Enforcement, vars.ConfigFile, EnforceDeny, EnforceWarn)
+	}
+	if cfg.Rego != nil && cfg.Rego.Policy == "" {
+		return nil, fmt.Errorf("admission.rego.policy is required in %s", vars.ConfigFile)
+	}
+	return &cfg, nil
+}
+
+// Evaluate returns the violations of the policies by the pod
+func (c *Config) Evaluate(ctx context.Context, podSpec *models.PodSpec) ([]Violation, error) {
+	var violations []Violation
+	add := func(rule, container, format string, args ...any) {
+		violations = append(violations, Violation{Rule: rule, Pod: podSpec.Name, Container: container, Message: fmt.Sprintf(format, args...)})
+	}
+
+	containers := append(append([]v1.Container{}, podSpec.Spec.InitContainers...), podSpec.Spec.Containers...)
+	for _, container := range containers {
+		if c.DenyPrivileged && container.SecurityContext != nil && container.SecurityContext.Privileged != nil && *container.SecurityContext.Privileged {
+			add(RulePrivileged, container.Name, "privileged containers are not allowed")
+		}
+		if len(c.AllowedRegistries) > 0 && !c.allowedImage(container.Image) {
+			add(RuleAllowedRegistries, container.Name, "image %s is not from an approved registry (%s)", container.Image, strings.Join(c.AllowedRegistries, ", "))
+		}
+		if c.RequireResourceLimits {
+			var missing []string
+			for _, resource := range []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory} {
+				if _, ok := container.Resources.Limits[resource]; !ok {
+					missing = append(missing, string(resource))
+				}
+			}
+			if len(missing) > 0 {
+				add(RuleResourceLimits, container.Name, "no %s limit set", strings.Join(missing, " and "))
+			}
+		}
+	}
+
+	if c.Rego != nil {
+		messages, err := c.evaluateRego(ctx, podSpec)
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate the rego policy %s: %w", c.Rego.Policy, err)
+		}
+		for _, msg := range messages {
+			add(RuleRego, "", "%s", msg)
+		}
+	}
+	return violations, nil
+}
+
+// allowedImage reports whether the image is pulled from one of the allowed registries or repository prefixes
+func (c *Config) allowedImage(image string) bool {
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return false
+	}
+	name := named.Name()
+	for _, allowed := range c.AllowedRegistries {
+		allowed = strings.TrimSuffix(allowed, "/")
+		if name == allowed || strings.HasPrefix(name, allowed+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// evaluateRego evaluates the query of the rego policy with the pod as input, returning the messages of the
+// violations
+func (c *Config) evaluateRego(ctx context.Context, podSpec *models.PodSpec) ([]string, error) {
+	query := c.Rego.Query
+	if query == "" {
+		query = DefaultRegoQuery
+	}
+	input, err := json.Marshal(podSpec.Pod)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, regoTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "opa", "eval", "--format", "json", "--stdin-input", "--data", c.Rego.Policy, query)
+	cmd.Stdin = bytes.NewReader(input)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return nil, errors.New("the opa CLI is not installed")
+		}
+		return nil, fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	var result struct {
+		Result []struct {
+			Expressions []struct {
+				Value any `json:"value"`
+			} `json:"expressions"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(out, &result); err != nil {
+		return nil, fmt.Errorf("invalid opa output: %w", err)
+	}
+
+	// an undefined query has no result, the sets of rego are output as arrays
+	var messages []string
+	for _, r := range result.Result {
+		for _, expr := range r.Expressions {
+			values, ok := expr.Value.([]any)
+			if !ok {
+				return nil, fmt.Errorf("query %s must return a set of messages", query)
+			}
+			for _, val := range values {
+				messages = append(messages, fmt.Sprint(val))
+			}
+		}
+	}
+	return messages, nil
+}
@@ -0,0 +1,163 @@
+package admission
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	v1 "github.com/containers/podman/v5/pkg/k8s.io/api/core/v1"
+	"github.com/containers/podman/v5/pkg/k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+func testPod(containers ...v1.Container) *models.PodSpec {
+	podSpec := &models.PodSpec{}
+	podSpec.Name = "app--vllm"
+	podSpec.Spec.Containers = containers
+	return podSpec
+}
+
+func limits(resources ...v1.ResourceName) v1.ResourceRequirements {
+	list := v1.ResourceList{}
+	for _, r := range resources {
+		list[r] = resource.MustParse("1")
+	}
+	return v1.ResourceRequirements{Limits: list}
+}
+
+func TestEvaluate(t *testing.T) {
+	privileged := true
+	unprivileged := false
+
+	tests := []struct {
+		name   string
+		config Config
+		pod    *models.PodSpec
+		want   []string
+	}{
+		{
+			name:   "no policy",
+			config: Config{},
+			pod:    testPod(v1.Container{Name: "vllm", Image: "docker.io/vllm/vllm:latest", SecurityContext: &v1.SecurityContext{Privileged: &privileged}}),
+		},
+		{
+			name:   "privileged container denied",
+			config: Config{DenyPrivileged: true},
+			pod: testPod(
+				v1.Container{Name: "vllm", Image: "icr.io/vllm:1", SecurityContext: &v1.SecurityContext{Privileged: &privileged}},
+				v1.Container{Name: "ui", Image: "icr.io/ui:1", SecurityContext: &v1.SecurityContext{Privileged: &unprivileged}},
+				v1.Container{Name: "proxy", Image: "icr.io/proxy:1"},
+			),
+			want: []string{RulePrivileged + "/vllm"},
+		},
+		{
+			name:   "privileged init container denied",
+			config: Config{DenyPrivileged: true},
+			pod: func() *models.PodSpec {
+				pod := testPod(v1.Container{Name: "vllm", Image: "icr.io/vllm:1"})
+				pod.Spec.InitContainers = []v1.Container{{Name: "setup", Image: "icr.io/setup:1", SecurityContext: &v1.SecurityContext{Privileged: &privileged}}}
+				return pod
+			}(),
+			want: []string{RulePrivileged + "/setup"},
+		},
+		{
+			name:   "images from the allowed registries only",
+			config: Config{AllowedRegistries: []string{"icr.io/ai-services/", "registry.example.com"}},
+			pod: testPod(
+				v1.Container{Name: "vllm", Image: "icr.io/ai-services/vllm:1"},
+				v1.Container{Name: "ui", Image: "registry.example.com/ui@sha256:" + "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"},
+				v1.Container{Name: "other", Image: "icr.io/other/vllm:1"},
+				v1.Container{Name: "prefix", Image: "icr.io/ai-services-fork/vllm:1"},
+				v1.Container{Name: "hub", Image: "vllm/vllm-openai"},
+				v1.Container{Name: "invalid", Image: "INVALID:image:ref"},
+			),
+			want: []string{
+				RuleAllowedRegistries + "/other",
+				RuleAllowedRegistries + "/prefix",
+				RuleAllowedRegistries + "/hub",
+				RuleAllowedRegistries + "/invalid",
+			},
+		},
+		{
+			name:   "docker hub images normalized",
+			config: Config{AllowedRegistries: []string{"docker.io/library"}},
+			pod:    testPod(v1.Container{Name: "redis", Image: "redis:7"}),
+		},
+		{
+			name:   "resource limits required",
+			config: Config{RequireResourceLimits: true},
+			pod: testPod(
+				v1.Container{Name: "vllm", Image: "icr.io/vllm:1", Resources: limits(v1.ResourceCPU, v1.ResourceMemory)},
+				v1.Container{Name: "ui", Image: "icr.io/ui:1", Resources: limits(v1.ResourceCPU)},
+				v1.Container{Name: "proxy", Image: "icr.io/proxy:1"},
+			),
+			want: []string{RuleResourceLimits + "/ui", RuleResourceLimits + "/proxy"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations, err := tt.config.Evaluate(context.Background(), tt.pod)
+			if err != nil {
+				t.Fatalf("Evaluate() unexpected error: %v", err)
+			}
+			var got []string
+			for _, v := range violations {
+				if v.Pod != tt.pod.Name {
+					t.Errorf("violation %s reports pod %s, want %s", v, v.Pod, tt.pod.Name)
+				}
+				got = append(got, v.Rule+"/"+v.Container)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Evaluate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		want    *Config
+		wantErr bool
+	}{
+		{name: "no admission", config: "locale: de\n"},
+		{
+			name:   "deny by default",
+			config: "admission:\n  denyPrivileged: true\n",
+			want:   &Config{Enforcement: EnforceDeny, DenyPrivileged: true},
+		},
+		{
+			name:   "warn",
+			config: "admission:\n  enforcement: warn\n  allowedRegistries: [icr.io]\n",
+			want:   &Config{Enforcement: EnforceWarn, AllowedRegistries: []string{"icr.io"}},
+		},
+		{name: "unknown enforcement", config: "admission:\n  enforcement: audit\n", wantErr: true},
+		{name: "rego without policy", config: "admission:\n  rego:\n    query: data.x.deny\n", wantErr: true},
+	}
+
+	configFile := vars.ConfigFile
+	t.Cleanup(func() { vars.ConfigFile = configFile })
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vars.ConfigFile = filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(vars.ConfigFile, []byte(tt.config), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := Load()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Load() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package apikeys
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime"
+	"github.com/project-ai-services/ai-services/internal/pkg/specs"
+	"github.com/project-ai-services/ai-services/internal/pkg/state"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+const (
+	// stateName is the name of the state document holding the API key records
+	stateName = "apikeys"
+
+	// SecretKey is the key of the secret holding the space separated active API keys,
+	// injected into the serving containers as env and passed to vLLM as '--api-key'
+	SecretKey = "VLLM_API_KEYS"
+
+	keyPrefix = "ais-"
+)
+
+// Key is the record of an API key. The key itself is stored only in the podman secret of the application.
+type Key struct {
+	ID          string     `json:"id"`
+	Application string     `json:"application"`
+	Name        string     `json:"name,omitempty"`
+	SHA256      string     `json:"sha256"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	RevokedAt   *time.Time `json:"revokedAt,omitempty"`
+}
+
+// SecretName returns the name of the podman secret holding the API keys of the application
+func SecretName(appName string) string {
+	return appName + "--api-keys"
+}
+
+// List returns the API key records of the application (all the applications if empty)
+func List(appName string) ([]Key, error) {
+	var keys []Key
+	if err := state.Default().Load(stateName, &keys); err != nil {
+		return nil, err
+	}
+
+	if appName == "" {
+		return keys, nil
+	}
+	return slices.DeleteFunc(keys, func(k Key) bool { return k.Application != appName }), nil
+}
+
+// Create generates a new API key for the application and adds it to the secret of the application
+func Create(runtime runtime.Runtime, appName, name string) (string, *Key, error) {
+	raw := make([]byte, 24)
+	if _, err := rand.Read(raw); err != nil {
+		return "", nil, fmt.Errorf("failed to generate API key: %w", err)
+	}
+	apiKey := keyPrefix + hex.EncodeToString(raw)
+
+	key := Key{
+		ID:          hex.EncodeToString(raw[:4]),
+		Application: appName,
+		Name:        name,
+		SHA256:      digest(apiKey),
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	var keys []Key
+	err := state.Default().Update(stateName, &keys, func() error {
+		active, err := activeKeys(runtime, appName)
+		if err != nil {
+			return err
+		}
+		if err := writeSecret(runtime, appName, append(active, apiKey)); err != nil {
+			return err
+		}
+		keys = append(keys, key)
+		return nil
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	return apiKey, &key, nil
+}
+
+// Revoke removes the API key from the secret of its application
+func Revoke(runtime runtime.Runtime, id string) (*Key, error) {
+	var revoked *Key
+	var keys []Key
+	err := state.Default().Update(stateName, &keys, func() error {
+		idx := slices.IndexFunc(keys, func(k Key) bool { return k.ID == id })
+		if idx == -1 {
+			return fmt.Errorf("API key %s not found", id)
+		}
+		key := &keys[idx]
+		if key.RevokedAt != nil {
+			return fmt.Errorf("API key %s is already revoked", id)
+		}
+
+		active, err := activeKeys(runtime, key.Application)
+		if err != nil {
+			return err
+		}
+		active = slices.DeleteFunc(active, func(k string) bool { return digest(k) == key.SHA256 })
+
+		if len(active) == 0 {
+			exists, err := runtime.SecretExists(SecretName(key.Application))
+			if err != nil {
+				return err
+			}
+			if exists {
+				if err := runtime.RemoveSecret(SecretName(key.Application)); err != nil {
+					return err
+				}
+			}
+		} else if err := writeSecret(runtime, key.Application, active); err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+		key.RevokedAt = &now
+		revoked = key
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return revoked, nil
+}
+
+// Token returns one of the active API keys of the application, to be used by ai-services to reach the
+// serving containers (Eg:- smoke tests). Empty if the application has no API keys.
+func Token(runtime runtime.Runtime, appName string) string {
+	active, err := activeKeys(runtime, appName)
+	if err != nil || len(active) == 0 {
+		return ""
+	}
+	return active[0]
+}
+
+// Enabled returns true if the application has active API keys
+func Enabled(runtime runtime.Runtime, appName string) (bool, error) {
+	return runtime.SecretExists(SecretName(appName))
+}
+
+func activeKeys(runtime runtime.Runtime, appName string) ([]string, error) {
+	exists, err := runtime.SecretExists(SecretName(appName))
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	data, err := runtime.SecretData(SecretName(appName))
+	if err != nil {
+		return nil, err
+	}
+
+	secretData, err := specs.ParseSecretData(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return strings.Fields(string(secretData[SecretKey])), nil
+}
+
+func writeSecret(runtime runtime.Runtime, appName string, keys []string) error {
+	secret, err := specs.MarshalSecret(SecretName(appName), specs.SecretTypeOpaque, map[string][]byte{
+		SecretKey: []byte(strings.Join(keys, " ")),
+	})
+	if err != nil {
+		return err
+	}
+
+	labels := map[string]string{
+		"ai-services.io/application": appName,
+		string(vars.ManagedLabel):    "true",
+	}
+	return runtime.CreateSecret(SecretName(appName), secret, labels)
+}
+
+func digest(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
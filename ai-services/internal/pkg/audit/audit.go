@@ -0,0 +1,65 @@
+// Package audit records the changes made to the deployed applications, so that operators can review who changed
+// what and when
+package audit
+
+import (
+	"os/user"
+	"time"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/state"
+)
+
+// stateName is the name of the state document holding the audit history
+const stateName = "audit"
+
+// maxEntries caps the audit history, the oldest entries are dropped first
+const maxEntries = 1000
+
+// Entry is a change made to an application
+type Entry struct {
+	Time        time.Time `json:"time"`
+	Application string    `json:"application"`
+	Action      string    `json:"action"`
+	Details     string    `json:"details,omitempty"`
+	User        string    `json:"user,omitempty"`
+}
+
+// Record appends the entry to the audit history, the time and the user default to now and the current user
+func Record(entry Entry) error {
+	if entry.Time.IsZero() {
+		entry.Time = time.Now().UTC()
+	}
+	if entry.User == "" {
+		if u, err := user.Current(); err == nil {
+			entry.User = u.Username
+		}
+	}
+
+	var entries []Entry
+	return state.Default().Update(stateName, &entries, func() error {
+		entries = append(entries, entry)
+		if len(entries) > maxEntries {
+			entries = entries[len(entries)-maxEntries:]
+		}
+		return nil
+	})
+}
+
+// List returns the audit history of the application, oldest first. All the applications if app is empty.
+func List(app string) ([]Entry, error) {
+	var entries []Entry
+	if err := state.Default().Load(stateName, &entries); err != nil {
+		return nil, err
+	}
+	if app == "" {
+		return entries, nil
+	}
+
+	var filtered []Entry
+	for _, e := range entries {
+		if e.Application == app {
+			filtered = append(filtered, e)
+		}
+	}
+	return filtered, nil
+}
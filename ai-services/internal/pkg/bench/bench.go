@@ -0,0 +1,254 @@
+package bench
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Options configures the benchmark run against an OpenAI compatible server
+type Options struct {
+	// BaseURL of the OpenAI compatible API, Eg:- http://10.0.0.1:8000/v1
+	BaseURL string
+	APIKey  string
+	// Model is the served model name, defaults to the first model served
+	Model       string
+	Prompt      string
+	MaxTokens   int
+	Concurrency int
+	Duration    time.Duration
+}
+
+// Report is the outcome of a benchmark run
+type Report struct {
+	Model            string
+	Duration         time.Duration
+	Requests         int
+	Errors           int
+	CompletionTokens int
+	// ErrorKinds counts the errors by their message, to surface the dominant failure
+	ErrorKinds map[string]int
+	latencies  []time.Duration
+}
+
+// MarshalJSON encodes the report along with its derived metrics
+func (r *Report) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Model            string         `json:"model"`
+		Duration         float64        `json:"durationSeconds"`
+		Requests         int            `json:"requests"`
+		Errors           int            `json:"errors"`
+		ErrorRate        float64        `json:"errorRate"`
+		CompletionTokens int            `json:"completionTokens"`
+		Throughput       float64        `json:"requestsPerSecond"`
+		TokensPerSecond  float64        `json:"tokensPerSecond"`
+		LatencyP50       float64        `json:"latencyP50Seconds"`
+		LatencyP90       float64        `json:"latencyP90Seconds"`
+		LatencyP99       float64        `json:"latencyP99Seconds"`
+		ErrorKinds       map[string]int `json:"errorKinds,omitempty"`
+	}{
+		Model:            r.Model,
+		Duration:         r.Duration.Seconds(),
+		Requests:         r.Requests,
+		Errors:           r.Errors,
+		ErrorRate:        r.ErrorRate(),
+		CompletionTokens: r.CompletionTokens,
+		Throughput:       r.Throughput(),
+		TokensPerSecond:  r.TokensPerSecond(),
+		LatencyP50:       r.Percentile(50).Seconds(),
+		LatencyP90:       r.Percentile(90).Seconds(),
+		LatencyP99:       r.Percentile(99).Seconds(),
+		ErrorKinds:       r.ErrorKinds,
+	})
+}
+
+// Throughput returns the successful requests per second
+func (r *Report) Throughput() float64 {
+	if r.Duration == 0 {
+		return 0
+	}
+	return float64(r.Requests-r.Errors) / r.Duration.Seconds()
+}
+
+// TokensPerSecond returns the generated tokens per second
+func (r *Report) TokensPerSecond() float64 {
+	if r.Duration == 0 {
+		return 0
+	}
+	return float64(r.CompletionTokens) / r.Duration.Seconds()
+}
+
+// ErrorRate returns the percentage of failed requests
+func (r *Report) ErrorRate() float64 {
+	if r.Requests == 0 {
+		return 0
+	}
+	return float64(r.Errors) * 100 / float64(r.Requests)
+}
+
+// Percentile returns the latency percentile (0-100) of the successful requests
+func (r *Report) Percentile(p float64) time.Duration {
+	if len(r.latencies) == 0 {
+		return 0
+	}
+	idx := int(float64(len(r.latencies)-1) * p / 100)
+	return r.latencies[idx]
+}
+
+type completionRequest struct {
+	Model     string `json:"model"`
+	Prompt    string `json:"prompt"`
+	MaxTokens int    `json:"max_tokens"`
+}
+
+type completionResponse struct {
+	Usage struct {
+		CompletionTokens int `json:"completion_tokens"`
+	} `json:"usage"`
+}
+
+type modelList struct {
+	Data []struct {
+		ID string `json:"id"`
+	} `json:"data"`
+}
+
+// Run fires completion requests with the given concurrency until the duration elapses or ctx is cancelled
+func Run(ctx context.Context, opts Options) (*Report, error) {
+	client := &http.Client{Timeout: 5 * time.Minute}
+
+	if opts.Model == "" {
+		model, err := firstServedModel(ctx, client, opts)
+		if err != nil {
+			return nil, err
+		}
+		opts.Model = model
+	}
+
+	body, err := json.Marshal(completionRequest{Model: opts.Model, Prompt: opts.Prompt, MaxTokens: opts.MaxTokens})
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, opts.Duration)
+	defer cancel()
+
+	report := &Report{Model: opts.Model, ErrorKinds: map[string]int{}}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	start := time.Now()
+	for i := 0; i < opts.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				reqStart := time.Now()
+				tokens, err := complete(ctx, client, opts, body)
+				latency := time.Since(reqStart)
+
+				// requests interrupted by the end of the run are not accounted
+				if ctx.Err() != nil {
+					return
+				}
+
+				mu.Lock()
+				report.Requests++
+				if err != nil {
+					report.Errors++
+					report.ErrorKinds[err.Error()]++
+				} else {
+					report.CompletionTokens += tokens
+					report.latencies = append(report.latencies, latency)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	report.Duration = time.Since(start)
+
+	sort.Slice(report.latencies, func(i, j int) bool { return report.latencies[i] < report.latencies[j] })
+
+	return report, nil
+}
+
+func complete(ctx context.Context, client *http.Client, opts Options, body []byte) (int, error) {
+	req, err := newRequest(ctx, http.MethodPost, opts.BaseURL+"/completions", opts.APIKey, body)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("request failed")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return 0, fmt.Errorf("status %s", resp.Status)
+	}
+
+	var cr completionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&cr); err != nil {
+		return 0, fmt.Errorf("invalid response")
+	}
+	return cr.Usage.CompletionTokens, nil
+}
+
+func firstServedModel(ctx context.Context, client *http.Client, opts Options) (string, error) {
+	req, err := newRequest(ctx, http.MethodGet, opts.BaseURL+"/models", opts.APIKey, nil)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to list served models: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("listing served models returned status: %s", resp.Status)
+	}
+
+	var models modelList
+	if err := json.NewDecoder(resp.Body).Decode(&models); err != nil {
+		return "", fmt.Errorf("failed to decode served models: %w", err)
+	}
+	if len(models.Data) == 0 {
+		return "", fmt.Errorf("no models served at %s", opts.BaseURL)
+	}
+	return models.Data[0].ID, nil
+}
+
+func newRequest(ctx context.Context, method, url, apiKey string, body []byte) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+	return req, nil
+}
+
+// BaseURL returns the OpenAI compatible API base of the endpoint URL
+func BaseURL(endpointURL string) string {
+	u := strings.TrimSuffix(endpointURL, "/")
+	if !strings.HasSuffix(u, "/v1") {
+		u += "/v1"
+	}
+	return u
+}
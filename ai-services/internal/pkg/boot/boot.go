@@ -0,0 +1,192 @@
+// Package boot starts the applications on boot with generated systemd units: a oneshot service per layer starting
+// the pods of the layer once the previous layer is started, all pulled in by a target of the application
+package boot
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+// UnitDirectory is the directory of the generated systemd units
+var UnitDirectory = "/etc/systemd/system"
+
+// userUnitDirectory is the directory of the generated systemd units of the rootless user, relative to its config
+// directory
+const userUnitDirectory = "systemd/user"
+
+// TargetName returns the name of the systemd target starting the application on boot
+func TargetName(app string) string {
+	return "ai-services-" + app + ".target"
+}
+
+// layerUnitName returns the name of the systemd service starting the layer n (1-based) of the application
+func layerUnitName(app string, n int) string {
+	return fmt.Sprintf("ai-services-%s-layer%d.service", app, n)
+}
+
+var layerTemplate = template.Must(template.New("layer").Parse(`# Generated by ai-services, do not edit. Managed with 'ai-services application enable-on-boot'
+[Unit]
+Description=ai-services layer {{ .Layer }} of application {{ .Application }}
+Wants=network-online.target
+After=network-online.target podman.socket
+{{- with .Previous }}
+Requires={{ . }}
+After={{ . }}
+{{- end }}
+PartOf={{ .Target }}
+
+[Service]
+Type=oneshot
+RemainAfterExit=yes
+{{- range .Pods }}
+ExecStart={{ $.Podman }} pod start {{ . }}
+{{- end }}
+`))
+
+var targetTemplate = template.Must(template.New("target").Parse(`# Generated by ai-services, do not edit. Managed with 'ai-services application enable-on-boot'
+[Unit]
+Description=ai-services application {{ .Application }}
+Wants={{ .Wants }}
+
+[Install]
+WantedBy={{ .WantedBy }}
+`))
+
+// Enable installs the systemd units starting the pods of the application on boot, layer by layer, and enables its
+// target. The units of a previous enablement are replaced. Returns the names of the installed units.
+func Enable(app string, layers [][]string) ([]string, error) {
+	if len(layers) == 0 {
+		return nil, fmt.Errorf("application '%s' has no pods to start on boot", app)
+	}
+	podman, err := exec.LookPath("podman")
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate podman: %w", err)
+	}
+	dir, err := unitDirectory()
+	if err != nil {
+		return nil, err
+	}
+	if err := removeUnits(dir, app); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create the unit directory: %w", err)
+	}
+
+	var units []string
+	for i, pods := range layers {
+		data := map[string]any{"Application": app, "Layer": i + 1, "Target": TargetName(app), "Podman": podman, "Pods": pods}
+		if i > 0 {
+			data["Previous"] = layerUnitName(app, i)
+		}
+		unit := layerUnitName(app, i+1)
+		if err := writeUnit(dir, unit, layerTemplate, data); err != nil {
+			return nil, err
+		}
+		units = append(units, unit)
+	}
+
+	wantedBy := "multi-user.target"
+	if vars.Rootless {
+		wantedBy = "default.target"
+	}
+	data := map[string]any{"Application": app, "Wants": strings.Join(units, " "), "WantedBy": wantedBy}
+	if err := writeUnit(dir, TargetName(app), targetTemplate, data); err != nil {
+		return nil, err
+	}
+	units = append(units, TargetName(app))
+
+	if err := systemctl("daemon-reload"); err != nil {
+		return nil, err
+	}
+	return units, systemctl("enable", TargetName(app))
+}
+
+// Disable disables the target of the application and removes its units. The running pods are left untouched.
+// Disabling an application not started on boot is a no-op.
+func Disable(app string) error {
+	dir, err := unitDirectory()
+	if err != nil {
+		return err
+	}
+	if !Enabled(app) {
+		return nil
+	}
+	// the target may already be disabled, removing the units is what matters
+	_ = systemctl("disable", TargetName(app))
+	if err := removeUnits(dir, app); err != nil {
+		return err
+	}
+	return systemctl("daemon-reload")
+}
+
+// Enabled reports whether the units starting the application on boot are installed
+func Enabled(app string) bool {
+	dir, err := unitDirectory()
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(filepath.Join(dir, TargetName(app)))
+	return err == nil
+}
+
+// unitDirectory returns the directory of the units, the user units directory of the rootless user
+func unitDirectory() (string, error) {
+	if !vars.Rootless {
+		return UnitDirectory, nil
+	}
+	config, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate the user config directory: %w", err)
+	}
+	return filepath.Join(config, userUnitDirectory), nil
+}
+
+func writeUnit(dir, name string, tmpl *template.Template, data map[string]any) error {
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, data); err != nil {
+		return fmt.Errorf("failed to render unit %s: %w", name, err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), b.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("failed to write unit %s: %w", name, err)
+	}
+	return nil
+}
+
+// removeUnits removes the target and the layer units of the application
+func removeUnits(dir, app string) error {
+	layers, err := filepath.Glob(filepath.Join(dir, fmt.Sprintf("ai-services-%s-layer*.service", app)))
+	if err != nil {
+		return err
+	}
+	for _, path := range append(layers, filepath.Join(dir, TargetName(app))) {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove unit %s: %w", filepath.Base(path), err)
+		}
+	}
+	return nil
+}
+
+func systemctl(args ...string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if vars.Rootless {
+		args = append([]string{"--user"}, args...)
+	}
+	out, err := exec.CommandContext(ctx, "systemctl", args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to run systemctl %s: %v, output: %s", strings.Join(args, " "), err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
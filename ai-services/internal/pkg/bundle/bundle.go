@@ -0,0 +1,345 @@
+package bundle
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/models"
+)
+
+const (
+	// manifestFile holds the bundle metadata at the root of the bundle archive
+	manifestFile = "manifest.yaml"
+	// imagesArchive is the multi-image archive produced by podman save
+	imagesArchive = "images.tar"
+	// modelsDir is the directory inside the bundle archive holding the model files
+	modelsDir = "models"
+)
+
+// Manifest describes the contents of a bundle
+type Manifest struct {
+	Template string   `yaml:"template"`
+	Version  string   `yaml:"version,omitempty"`
+	Images   []string `yaml:"images"`
+	Models   []string `yaml:"models"`
+	// Digests are the digests of the models keyed by model, verified on import. They are missing from the bundles
+	// exported by the earlier versions.
+	Digests map[string]ModelDigest `yaml:"digests,omitempty"`
+}
+
+// ModelDigest is the digest of the files of a model, see models.Digest
+type ModelDigest struct {
+	SHA256 string `yaml:"sha256"`
+	Files  int    `yaml:"files"`
+}
+
+// ExportOptions holds the inputs required to build a bundle
+type ExportOptions struct {
+	Manifest Manifest
+	// ModelDirectory is the directory where the models listed in manifest are present
+	ModelDirectory string
+	// Output is the path of the bundle archive to be written
+	Output string
+}
+
+// Export writes a tarball containing the manifest, all the container images and models
+func Export(opts ExportOptions) error {
+	workDir, err := os.MkdirTemp(filepath.Dir(opts.Output), ".ai-services-bundle-")
+	if err != nil {
+		return fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	defer os.RemoveAll(workDir)
+
+	// 1. Save all the images into a single multi-image archive
+	if len(opts.Manifest.Images) > 0 {
+		logger.Infof("Saving %d container images...\n", len(opts.Manifest.Images), 0)
+		if err := saveImages(opts.Manifest.Images, filepath.Join(workDir, imagesArchive)); err != nil {
+			return err
+		}
+	}
+
+	// 2. Write the manifest, along with the digests of the models
+	opts.Manifest.Digests = map[string]ModelDigest{}
+	for _, model := range opts.Manifest.Models {
+		src := filepath.Join(opts.ModelDirectory, models.ModelPath(model))
+		if _, err := os.Stat(src); err != nil {
+			return fmt.Errorf("model %s is not present in %s: %w", model, opts.ModelDirectory, err)
+		}
+		digest, files, err := models.Digest(src)
+		if err != nil {
+			return fmt.Errorf("failed to compute digest of model %s: %w", model, err)
+		}
+		opts.Manifest.Digests[model] = ModelDigest{SHA256: digest, Files: files}
+	}
+	data, err := yaml.Marshal(opts.Manifest)
+	if err != nil {
+		return fmt.Errorf("failed to marshal bundle manifest: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(workDir, manifestFile), data, 0o644); err != nil {
+		return fmt.Errorf("failed to write bundle manifest: %w", err)
+	}
+
+	// 3. Pack everything into a tarball staged next to the output, so that a failed export does not leave a
+	// truncated bundle behind
+	staged := filepath.Join(workDir, "bundle.tar")
+	if err := pack(staged, workDir, opts); err != nil {
+		return err
+	}
+	if err := os.Rename(staged, opts.Output); err != nil {
+		return fmt.Errorf("failed to create bundle file: %w", err)
+	}
+
+	return nil
+}
+
+// pack writes the manifest, the images archive staged in workDir and the models into the tarball at path
+func pack(path, workDir string, opts ExportOptions) (err error) {
+	out, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create bundle file: %w", err)
+	}
+	defer func() {
+		if cerr := out.Close(); cerr != nil && err == nil {
+			err = fmt.Errorf("failed to write bundle file: %w", cerr)
+		}
+	}()
+
+	tw := tar.NewWriter(out)
+	if err := addPath(tw, filepath.Join(workDir, manifestFile), manifestFile); err != nil {
+		return err
+	}
+	if len(opts.Manifest.Images) > 0 {
+		if err := addPath(tw, filepath.Join(workDir, imagesArchive), imagesArchive); err != nil {
+			return err
+		}
+	}
+	for _, model := range opts.Manifest.Models {
+		logger.Infof("Adding model %s to the bundle...\n", model)
+		src := filepath.Join(opts.ModelDirectory, models.ModelPath(model))
+		if err := addPath(tw, src, filepath.Join(modelsDir, models.ModelPath(model))); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to finalize bundle: %w", err)
+	}
+
+	return nil
+}
+
+// Import extracts the bundle, loads the images into podman storage and moves the models into modelDirectory. The
+// models are staged in modelDirectory and verified against the digests of the manifest, so that a failed import does
+// not leave partial models behind. The digests of the imported models are returned in the manifest.
+func Import(bundlePath, modelDirectory string) (*Manifest, error) {
+	if err := os.MkdirAll(modelDirectory, os.ModePerm); err != nil {
+		return nil, fmt.Errorf("failed to create target model directory: %w", err)
+	}
+
+	// staged within the model directory, for the models to be renamed into place on the same filesystem
+	workDir, err := os.MkdirTemp(modelDirectory, ".ai-services-bundle-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	defer os.RemoveAll(workDir)
+
+	manifest, err := extract(bundlePath, workDir)
+	if err != nil {
+		return nil, err
+	}
+
+	digests := map[string]ModelDigest{}
+	for _, model := range manifest.Models {
+		digest, files, err := models.Digest(filepath.Join(workDir, modelsDir, models.ModelPath(model)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute digest of model %s: %w", model, err)
+		}
+		if want, ok := manifest.Digests[model]; ok && want.SHA256 != digest {
+			return nil, fmt.Errorf("digest of model %s is %s, expected %s: the bundle is corrupted", model, digest, want.SHA256)
+		}
+		digests[model] = ModelDigest{SHA256: digest, Files: files}
+	}
+
+	if len(manifest.Images) > 0 {
+		logger.Infof("Loading %d container images...\n", len(manifest.Images), 0)
+		if err := loadImages(filepath.Join(workDir, imagesArchive)); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, model := range manifest.Models {
+		if err := replaceModel(filepath.Join(workDir, modelsDir), modelDirectory, workDir, models.ModelPath(model)); err != nil {
+			return nil, fmt.Errorf("failed to import model %s: %w", model, err)
+		}
+	}
+
+	manifest.Digests = digests
+	return manifest, nil
+}
+
+// replaceModel renames the model at path from the staging directory into the model directory, the model it replaces
+// being moved into the trash directory
+func replaceModel(staging, modelDirectory, trash, path string) error {
+	target := filepath.Join(modelDirectory, path)
+	if _, err := os.Lstat(target); err == nil {
+		old, err := os.MkdirTemp(trash, "replaced-")
+		if err != nil {
+			return err
+		}
+		if err := os.Rename(target, filepath.Join(old, filepath.Base(target))); err != nil {
+			return err
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(target), os.ModePerm); err != nil {
+		return err
+	}
+	return os.Rename(filepath.Join(staging, path), target)
+}
+
+func saveImages(images []string, target string) error {
+	args := append([]string{"save", "--multi-image-archive", "--output", target}, images...)
+	out, err := exec.Command("podman", args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to save images: %v, output: %s", err, string(out))
+	}
+	return nil
+}
+
+func loadImages(archive string) error {
+	out, err := exec.Command("podman", "load", "--input", archive).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to load images: %v, output: %s", err, string(out))
+	}
+	logger.Infoln(strings.TrimSpace(string(out)), 2)
+	return nil
+}
+
+// addPath adds a file or a directory tree to the tar writer under the name prefix
+func addPath(tw *tar.Writer, src, name string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+
+		// the symbolic links, Eg:- to the blobs of a download cache, are bundled as the files they link to
+		if info.Mode()&os.ModeSymlink != 0 {
+			info, err = os.Stat(path)
+			if err != nil {
+				return fmt.Errorf("failed to resolve the symbolic link %s: %w", path, err)
+			}
+			if !info.Mode().IsRegular() {
+				return fmt.Errorf("symbolic link %s does not link to a regular file, which is not supported in a bundle", path)
+			}
+		}
+
+		hdr, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		hdr.Name = filepath.ToSlash(filepath.Join(name, rel))
+
+		if err := tw.WriteHeader(hdr); err != nil {
+			return fmt.Errorf("failed to write header for %s: %w", path, err)
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		if _, err := io.Copy(tw, f); err != nil {
+			return fmt.Errorf("failed to add %s to bundle: %w", path, err)
+		}
+		return nil
+	})
+}
+
+// extract writes the manifest, the images archive and the models of the bundle into workDir
+func extract(bundlePath, workDir string) (*Manifest, error) {
+	f, err := os.Open(bundlePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open bundle: %w", err)
+	}
+	defer f.Close()
+
+	var manifest *Manifest
+	tr := tar.NewReader(f)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read bundle: %w", err)
+		}
+
+		name := filepath.Clean(hdr.Name)
+		if filepath.IsAbs(name) || strings.HasPrefix(name, "..") {
+			return nil, fmt.Errorf("invalid path in bundle: %s", hdr.Name)
+		}
+
+		if name == manifestFile {
+			data, err := io.ReadAll(tr)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read bundle manifest: %w", err)
+			}
+			manifest = &Manifest{}
+			if err := yaml.Unmarshal(data, manifest); err != nil {
+				return nil, fmt.Errorf("failed to parse bundle manifest: %w", err)
+			}
+			continue
+		}
+
+		if err := writeEntry(tr, hdr, filepath.Join(workDir, name)); err != nil {
+			return nil, err
+		}
+	}
+
+	if manifest == nil {
+		return nil, errors.New("bundle manifest is missing, not a valid ai-services bundle")
+	}
+
+	return manifest, nil
+}
+
+func writeEntry(tr *tar.Reader, hdr *tar.Header, target string) error {
+	switch hdr.Typeflag {
+	case tar.TypeDir:
+		return os.MkdirAll(target, os.ModePerm)
+	case tar.TypeReg:
+		if err := os.MkdirAll(filepath.Dir(target), os.ModePerm); err != nil {
+			return err
+		}
+		out, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, os.FileMode(hdr.Mode))
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", target, err)
+		}
+		defer out.Close()
+		if _, err := io.Copy(out, tr); err != nil {
+			return fmt.Errorf("failed to extract %s: %w", target, err)
+		}
+	default:
+		// the links are resolved on export, they are not followed from a bundle crafted otherwise
+		return fmt.Errorf("unsupported entry %s in bundle, only directories and regular files are supported", hdr.Name)
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"time"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime"
+	"github.com/project-ai-services/ai-services/internal/pkg/specs"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+const (
+	// DefaultValidity of the generated self-signed certificates
+	DefaultValidity = 365 * 24 * time.Hour
+
+	// CertFile and KeyFile are the names of the files within the mounted TLS secret
+	CertFile = "tls.crt"
+	KeyFile  = "tls.key"
+)
+
+// Options configures the TLS certificate of an application
+type Options struct {
+	// CertFile and KeyFile are the PEM files to use, a self-signed certificate is generated if empty
+	CertFile string
+	KeyFile  string
+	// Hosts are the DNS names and IP addresses the self-signed certificate is valid for
+	Hosts    []string
+	Validity time.Duration
+}
+
+// SecretName returns the name of the podman secret holding the TLS certificate of the application
+func SecretName(appName string) string {
+	return appName + "--tls"
+}
+
+// Provision stores the TLS certificate of the application as a podman secret, and returns the
+// well-known template parameters referring to it
+//
+//	tls.secretName: name of the podman secret, mounted as a secret volume holding tls.crt and tls.key
+func Provision(runtime runtime.Runtime, appName string, opts Options) (map[string]string, error) {
+	var certPEM, keyPEM []byte
+	var err error
+	if opts.CertFile != "" {
+		certPEM, keyPEM, err = LoadPEM(opts.CertFile, opts.KeyFile)
+	} else {
+		certPEM, keyPEM, err = GenerateSelfSigned(appName, opts.Hosts, opts.Validity)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	name := SecretName(appName)
+	secret, err := specs.MarshalSecret(name, specs.SecretTypeTLS, map[string][]byte{
+		CertFile: certPEM,
+		KeyFile:  keyPEM,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	labels := map[string]string{
+		"ai-services.io/application": appName,
+		string(vars.ManagedLabel):    "true",
+	}
+	if err := runtime.CreateSecret(name, secret, labels); err != nil {
+		return nil, err
+	}
+
+	return map[string]string{"tls.secretName": name}, nil
+}
+
+// LoadPEM reads the certificate and the private key, and verifies they form a valid pair
+func LoadPEM(certFile, keyFile string) ([]byte, []byte, error) {
+	certPEM, err := os.ReadFile(certFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read certificate: %w", err)
+	}
+	keyPEM, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read private key: %w", err)
+	}
+
+	pair, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid certificate/key pair: %w", err)
+	}
+
+	leaf, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse certificate: %w", err)
+	}
+	if time.Now().After(leaf.NotAfter) {
+		return nil, nil, fmt.Errorf("certificate expired on %s", leaf.NotAfter.Format(time.RFC3339))
+	}
+
+	return certPEM, keyPEM, nil
+}
+
+// GenerateSelfSigned generates a self-signed ECDSA P-256 certificate valid for the given hosts
+func GenerateSelfSigned(commonName string, hosts []string, validity time.Duration) ([]byte, []byte, error) {
+	if validity == 0 {
+		validity = DefaultValidity
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate private key: %w", err)
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate serial number: %w", err)
+	}
+
+	now := time.Now()
+	tmpl := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: commonName, Organization: []string{"ai-services"}},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(validity),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+	}
+	for _, h := range hosts {
+		if ip := net.ParseIP(h); ip != nil {
+			tmpl.IPAddresses = append(tmpl.IPAddresses, ip)
+		} else {
+			tmpl.DNSNames = append(tmpl.DNSNames, h)
+		}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create certificate: %w", err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal private key: %w", err)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	return certPEM, keyPEM, nil
+}
+
+// DefaultHosts returns the hostname and the IP of the host, used when no hosts are provided for the self-signed certificate
+func DefaultHosts() []string {
+	hosts := []string{"localhost", "127.0.0.1"}
+	if hostname, err := os.Hostname(); err == nil {
+		hosts = append(hosts, hostname)
+	}
+	if ip, err := utils.GetHostIP(); err == nil && ip != "" {
+		hosts = append(hosts, ip)
+	}
+	return hosts
+}
@@ -0,0 +1,229 @@
+package helpers
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/containers/podman/v5/pkg/domain/entities/types"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/templates"
+	"github.com/project-ai-services/ai-services/internal/pkg/constants"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+)
+
+// EndpointSpec is a single entry of the endpoints annotation
+type EndpointSpec struct {
+	Name          string
+	Protocol      string
+	ContainerPort string
+	Path          string
+}
+
+// Endpoint is an endpoint exposed by an application pod
+type Endpoint struct {
+	Application string `json:"application"`
+	Pod         string `json:"pod"`
+	Name        string `json:"name"`
+	Protocol    string `json:"protocol"`
+	URL         string `json:"url"`
+	// Internal is set when the port is not published on the host, hence reachable only from the podman network
+	Internal bool `json:"internal"`
+	// Assigned is set when the host port was assigned by ai-services at deploy time, declared 'auto' by the template
+	Assigned bool `json:"assigned,omitempty"`
+	// Local is set when the port is published on the loopback interface of the host only
+	Local bool `json:"local,omitempty"`
+	Ready bool `json:"ready"`
+}
+
+// ParseExposureAnnotation parses the exposure annotation into the exposures of the container ports
+//
+// exposure annotation takes comma separated values of '<containerPort>=<exposure>'
+// Eg:- 'ai-services.io/exposure': "3000=external,9090=local"
+func ParseExposureAnnotation(val string) map[string]string {
+	exposures := map[string]string{}
+	for entry := range strings.SplitSeq(val, ",") {
+		port, exposure, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok || port == "" || exposure == "" {
+			continue
+		}
+		exposures[strings.TrimSpace(port)] = strings.TrimSpace(exposure)
+	}
+	return exposures
+}
+
+// ParseEndpointsAnnotation parses the endpoints annotation
+//
+// endpoints annotation takes comma separated values of '<name>:<protocol>:<containerPort>[/<path>]'
+// Eg:- 'ai-services.io/endpoints': "ui:http:3000,api:grpc:8000"
+func ParseEndpointsAnnotation(val string) []EndpointSpec {
+	var specs []EndpointSpec
+	for entry := range strings.SplitSeq(val, ",") {
+		entry = strings.TrimSpace(entry)
+		parts := strings.SplitN(entry, ":", 3)
+		if len(parts) != 3 || parts[0] == "" || parts[2] == "" {
+			// skip the malformed entries
+			continue
+		}
+
+		spec := EndpointSpec{Name: parts[0], Protocol: strings.ToLower(parts[1]), ContainerPort: parts[2]}
+		if i := strings.Index(spec.ContainerPort, "/"); i != -1 {
+			spec.Path = spec.ContainerPort[i:]
+			spec.ContainerPort = spec.ContainerPort[:i]
+		}
+		specs = append(specs, spec)
+	}
+	return specs
+}
+
+// ListEndpoints returns the endpoints exposed by the pods of the given application (all the applications if empty)
+//
+// Endpoints are derived from the endpoints annotation, if present. Otherwise each published port is reported as an endpoint.
+func ListEndpoints(runtime runtime.Runtime, appName string) ([]Endpoint, error) {
+	listFilters := map[string][]string{"label": {"ai-services.io/application"}}
+	if appName != "" {
+		listFilters["label"] = []string{fmt.Sprintf("ai-services.io/application=%s", appName)}
+	}
+
+	resp, err := runtime.ListPods(listFilters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	var pods []*types.ListPodsReport
+	if val, ok := resp.([]*types.ListPodsReport); ok {
+		pods = val
+	}
+
+	hostIP, err := utils.GetHostIP()
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch the host IP: %w", err)
+	}
+
+	var endpoints []Endpoint
+	for _, pod := range pods {
+		pInfo, err := runtime.InspectPod(pod.Id)
+		if err != nil {
+			return nil, err
+		}
+
+		portMappings, err := fetchPodPortMapping(pInfo)
+		if err != nil {
+			return nil, err
+		}
+
+		specs := ParseEndpointsAnnotation(fetchPodAnnotation(runtime, pInfo, constants.PodEndpointsAnnotationKey))
+		if len(specs) == 0 {
+			// no endpoints declared, fallback to the published ports
+			for containerPort := range portMappings {
+				specs = append(specs, EndpointSpec{Name: containerPort, Protocol: "tcp", ContainerPort: containerPort})
+			}
+		}
+
+		autoPorts := strings.Split(fetchPodAnnotation(runtime, pInfo, constants.PodAutoPortsAnnotationKey), ",")
+		exposures := ParseExposureAnnotation(fetchPodAnnotation(runtime, pInfo, constants.PortExposureAnnotationKey))
+
+		podIP := ""
+		for _, spec := range specs {
+			ep := Endpoint{
+				Application: pod.Labels["ai-services.io/application"],
+				Pod:         pod.Name,
+				Name:        spec.Name,
+				Protocol:    spec.Protocol,
+			}
+
+			address := ""
+			if hostPort := portMappings[spec.ContainerPort]; hostPort != "" {
+				address = net.JoinHostPort(hostIP, hostPort)
+				ep.Assigned = slices.Contains(autoPorts, spec.ContainerPort)
+				if exposures[spec.ContainerPort] == templates.ExposureLocal {
+					address, ep.Local = net.JoinHostPort("127.0.0.1", hostPort), true
+				}
+			} else {
+				ep.Internal = true
+				if podIP == "" {
+					// pod IP is not available for the pods which are not running
+					podIP, _ = FetchPodIP(runtime, pod.Id)
+				}
+				if podIP != "" {
+					address = net.JoinHostPort(podIP, spec.ContainerPort)
+				}
+			}
+
+			if address != "" {
+				ep.URL = endpointURL(spec.Protocol, address, spec.Path)
+				ep.Ready = checkEndpointReadiness(spec.Protocol, address, ep.URL)
+			}
+			endpoints = append(endpoints, ep)
+		}
+	}
+
+	sort.SliceStable(endpoints, func(i, j int) bool {
+		if endpoints[i].Application != endpoints[j].Application {
+			return endpoints[i].Application < endpoints[j].Application
+		}
+		return endpoints[i].Pod < endpoints[j].Pod
+	})
+
+	return endpoints, nil
+}
+
+// fetchPodAnnotation returns the value of the pod annotation. kube play sets the pod annotations on all its containers
+func fetchPodAnnotation(runtime runtime.Runtime, pInfo *types.PodInspectReport, key string) string {
+	for _, container := range pInfo.Containers {
+		if container.ID == pInfo.InfraContainerID {
+			continue
+		}
+		cInfo, err := runtime.InspectContainer(container.ID)
+		if err != nil || cInfo.Config == nil {
+			continue
+		}
+		if val, ok := cInfo.Config.Annotations[key]; ok {
+			return val
+		}
+	}
+	return ""
+}
+
+func endpointURL(protocol, address, path string) string {
+	switch protocol {
+	case "http", "https":
+		return protocol + "://" + address + path
+	case "grpc":
+		return "grpc://" + address
+	default:
+		return "tcp://" + address
+	}
+}
+
+// checkEndpointReadiness returns true if the HTTP endpoint responds without a server error, or the TCP port accepts connections
+func checkEndpointReadiness(protocol, address, url string) bool {
+	const timeout = 5 * time.Second
+
+	if protocol == "http" || protocol == "https" {
+		client := &http.Client{
+			Timeout: timeout,
+			// only the readiness is checked, the certificate may be self-signed
+			Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}, //nolint:gosec
+		}
+		resp, err := client.Get(url)
+		if err != nil {
+			return false
+		}
+		defer resp.Body.Close()
+		return resp.StatusCode < http.StatusInternalServerError
+	}
+
+	conn, err := net.DialTimeout("tcp", address, timeout)
+	if err != nil {
+		return false
+	}
+	_ = conn.Close()
+	return true
+}
@@ -2,17 +2,19 @@ package helpers
 
 import (
 	"fmt"
-	"log"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/pkg/domain/entities/types"
 
+	"github.com/project-ai-services/ai-services/internal/pkg/faults"
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
 	"github.com/project-ai-services/ai-services/internal/pkg/runtime"
+	"github.com/project-ai-services/ai-services/internal/pkg/spyre"
 )
 
 type HealthStatus string
@@ -73,6 +75,37 @@ func FetchContainerStartPeriod(runtime runtime.Runtime, containerNameOrId string
 	return healthCheck.StartPeriod, nil
 }
 
+// FetchPodIP returns the IP address of the pod on the podman network, which is reachable from the host
+func FetchPodIP(runtime runtime.Runtime, podNameOrID string) (string, error) {
+	pInfo, err := runtime.InspectPod(podNameOrID)
+	if err != nil {
+		return "", err
+	}
+
+	if pInfo.InfraContainerID == "" {
+		return "", fmt.Errorf("pod %s does not have an infra container", podNameOrID)
+	}
+
+	// all the containers of a pod share the network namespace of the infra container
+	infra, err := runtime.InspectContainer(pInfo.InfraContainerID)
+	if err != nil {
+		return "", err
+	}
+
+	if ns := infra.NetworkSettings; ns != nil {
+		if ns.IPAddress != "" {
+			return ns.IPAddress, nil
+		}
+		for _, network := range ns.Networks {
+			if network != nil && network.IPAddress != "" {
+				return network.IPAddress, nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("no IP address found for pod %s", podNameOrID)
+}
+
 func ListSpyreCards() ([]string, error) {
 	spyre_device_ids_list := []string{}
 	cmd := exec.Command("lspci", "-d", "1014:06a7")
@@ -97,11 +130,33 @@ func ListSpyreCards() ([]string, error) {
 	return spyre_device_ids_list, nil
 }
 
-func FindFreeSpyreCards() ([]string, error) {
+// FindFreeSpyreCards returns the PCI addresses of the Spyre cards listed by lspci which are neither opened, nor
+// passed to a running container of any podman workload of the host, nor allocated manually
+func FindFreeSpyreCards(runtime runtime.Runtime) ([]string, error) {
 	free_spyre_dev_id_list := []string{}
 	dev_files, err := os.ReadDir("/dev/vfio")
 	if err != nil {
-		log.Fatalf("failed to check device files under /dev/vfio. Error: %v", err)
+		return free_spyre_dev_id_list, fmt.Errorf("failed to check device files under /dev/vfio: %w", err)
+	}
+
+	ledger, err := spyre.List()
+	if err != nil {
+		return free_spyre_dev_id_list, fmt.Errorf("failed to read the spyre ledger: %w", err)
+	}
+
+	cards, err := ListSpyreCards()
+	if err != nil {
+		return free_spyre_dev_id_list, err
+	}
+	spyreCards := map[string]bool{}
+	for _, card := range cards {
+		spyreCards[fullPCIAddress(card)] = true
+	}
+
+	// the cards are opened by the containers once their workload starts, the cards of the containers not using
+	// them yet would be handed out again otherwise
+	groupsInUse, err := vfioGroupsInUse(runtime)
+	if err != nil {
 		return free_spyre_dev_id_list, err
 	}
 
@@ -109,6 +164,10 @@ func FindFreeSpyreCards() ([]string, error) {
 		if dev_file.Name() == "vfio" {
 			continue
 		}
+		if container, ok := groupsInUse[dev_file.Name()]; ok {
+			logger.Infof("VFIO group %s is passed to container %s, skipping..\n", dev_file.Name(), container, 1)
+			continue
+		}
 		f, err := os.Open("/dev/vfio/" + dev_file.Name())
 		if err != nil {
 			logger.Infoln("Device or resource busy, skipping..", 1)
@@ -125,12 +184,79 @@ func FindFreeSpyreCards() ([]string, error) {
 		if err != nil {
 			return free_spyre_dev_id_list, fmt.Errorf("failed to get pci address for the free spyre device: %v, output: %s", err, string(out))
 		}
-		pci := string(out)
+		pci := strings.TrimSpace(string(out))
+		if !spyreCards[pci] {
+			logger.Infof("VFIO group %s is not a Spyre card, skipping..\n", dev_file.Name(), 1)
+			continue
+		}
+
+		// the cards allocated manually are in use by external workloads, see 'ai-services spyre allocate'
+		if spyre.Allocated(ledger, pci) {
+			logger.Infof("Spyre card %s is allocated manually, skipping..\n", pci, 1)
+			continue
+		}
 		free_spyre_dev_id_list = append(free_spyre_dev_id_list, pci)
 	}
+
+	// simulate cards being detached, see 'ai-services debug inject'
+	if fault := faults.Active(faults.KindSpyreUnavailable); fault != nil {
+		free_spyre_dev_id_list = free_spyre_dev_id_list[:max(0, len(free_spyre_dev_id_list)-fault.Count)]
+	}
 	return free_spyre_dev_id_list, nil
 }
 
+// vfioGroupsInUse returns the VFIO groups passed as devices or bind mounted to the running containers of the host,
+// along with the name of the container
+func vfioGroupsInUse(runtime runtime.Runtime) (map[string]string, error) {
+	resp, err := runtime.ListContainers(map[string][]string{"status": {"running", "paused"}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the running containers: %w", err)
+	}
+	ctrs, _ := resp.([]types.ListContainer)
+
+	groups := map[string]string{}
+	for _, ctr := range ctrs {
+		info, err := runtime.InspectContainer(ctr.ID)
+		if err != nil {
+			// the container exited meanwhile
+			logger.Infof("Unable to inspect container %s: %v\n", ctr.ID, err, 2)
+			continue
+		}
+		var paths []string
+		if info.HostConfig != nil {
+			for _, device := range info.HostConfig.Devices {
+				paths = append(paths, device.PathOnHost)
+			}
+		}
+		for _, mount := range info.Mounts {
+			paths = append(paths, mount.Source)
+		}
+		for _, path := range paths {
+			if group, ok := strings.CutPrefix(path, "/dev/vfio/"); ok && group != "vfio" {
+				groups[group] = info.Name
+			}
+		}
+	}
+	return groups, nil
+}
+
+// fullPCIAddress prefixes the PCI address with the domain lspci omits for the domain 0000
+func fullPCIAddress(address string) string {
+	if strings.Count(address, ":") == 1 {
+		return "0000:" + address
+	}
+	return address
+}
+
+// IOMMUGroup returns the IOMMU group of the PCI device, the VFIO device node of which is /dev/vfio/<group>
+func IOMMUGroup(pciAddress string) (string, error) {
+	link, err := os.Readlink(fmt.Sprintf("/sys/bus/pci/devices/%s/iommu_group", pciAddress))
+	if err != nil {
+		return "", fmt.Errorf("failed to get the iommu group of pci device %s: %w", pciAddress, err)
+	}
+	return filepath.Base(link), nil
+}
+
 func ParseSkipChecks(skipChecks []string) map[string]bool {
 	skipMap := make(map[string]bool)
 	for _, check := range skipChecks {
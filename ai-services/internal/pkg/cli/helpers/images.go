@@ -1,9 +1,14 @@
 package helpers
 
 import (
+	"context"
 	"fmt"
 	"slices"
 
+	"github.com/containers/image/v5/docker"
+	"github.com/containers/image/v5/image"
+	"github.com/containers/image/v5/types"
+
 	"github.com/project-ai-services/ai-services/internal/pkg/cli/templates"
 	"github.com/project-ai-services/ai-services/internal/pkg/utils"
 	"github.com/project-ai-services/ai-services/internal/pkg/vars"
@@ -29,7 +34,7 @@ func ListImages(template, appName string) ([]string, error) {
 	}
 
 	for _, tmpl := range tmpls {
-		ps, err := tp.LoadPodTemplateWithValues(template, tmpl.Name(), appName, nil, nil)
+		ps, err := tp.LoadPodTemplateWithValues(template, tmpl.Name(), appName, "", nil, nil)
 		if err != nil {
 			return nil, fmt.Errorf("error loading pod template: %w", err)
 		}
@@ -40,3 +45,35 @@ func ListImages(template, appName string) ([]string, error) {
 
 	return utils.UniqueSlice(images), nil
 }
+
+// ImageDownloadSize returns the compressed size of the layers and config of the image in its registry, for the
+// platform of the host when the image is a manifest list
+func ImageDownloadSize(ctx context.Context, img string) (int64, error) {
+	ref, err := docker.ParseReference("//" + img)
+	if err != nil {
+		return 0, fmt.Errorf("invalid image reference: %w", err)
+	}
+
+	sys := &types.SystemContext{}
+	src, err := ref.NewImageSource(ctx, sys)
+	if err != nil {
+		return 0, fmt.Errorf("failed to access the image in the registry: %w", err)
+	}
+	defer func() {
+		_ = src.Close()
+	}()
+
+	resolved, err := image.FromUnparsedImage(ctx, sys, image.UnparsedInstance(src, nil))
+	if err != nil {
+		return 0, fmt.Errorf("failed to read the manifest: %w", err)
+	}
+
+	size := resolved.ConfigInfo().Size
+	for _, layer := range resolved.LayerInfos() {
+		if layer.Size < 0 {
+			return 0, fmt.Errorf("the registry does not report the size of layer %s", layer.Digest)
+		}
+		size += layer.Size
+	}
+	return size, nil
+}
@@ -1,15 +1,23 @@
 package helpers
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/project-ai-services/ai-services/internal/pkg/cli/templates"
 	"github.com/project-ai-services/ai-services/internal/pkg/constants"
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
 	"github.com/project-ai-services/ai-services/internal/pkg/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime"
 	"github.com/project-ai-services/ai-services/internal/pkg/vars"
 )
 
@@ -32,7 +40,7 @@ func ListModels(template, appName string) ([]string, error) {
 
 	modelList := []string{}
 	for _, tmpl := range tmpls {
-		ps, err := tp.LoadPodTemplateWithValues(template, tmpl.Name(), appName, nil, nil)
+		ps, err := tp.LoadPodTemplateWithValues(template, tmpl.Name(), appName, "", nil, nil)
 		if err != nil {
 			return nil, fmt.Errorf("error loading pod template: %w", err)
 		}
@@ -42,7 +50,14 @@ func ListModels(template, appName string) ([]string, error) {
 	return modelList, nil
 }
 
-func DownloadModel(model, targetDir string) error {
+// DownloadModel downloads the model into targetDir. The license of a gated model must have been acknowledged
+// before, or be accepted with acceptLicense
+func DownloadModel(model, targetDir string, acceptLicense bool) error {
+	// gated models require their license to be acknowledged before download
+	if err := CheckModelLicense(model, acceptLicense); err != nil {
+		return err
+	}
+
 	// check for target model directory, if not present create it
 	if _, err := os.Stat(targetDir); os.IsNotExist(err) {
 		err := os.MkdirAll(targetDir, os.ModePerm)
@@ -50,6 +65,30 @@ func DownloadModel(model, targetDir string) error {
 			return fmt.Errorf("failed to create target model directory: %w", err)
 		}
 	}
+
+	var err error
+	switch {
+	case strings.HasPrefix(model, models.OCIModelPrefix):
+		err = downloadOCIArtifactModel(strings.TrimPrefix(model, models.OCIModelPrefix), filepath.Join(targetDir, models.ModelPath(model)))
+	case strings.HasPrefix(model, models.ModelCarPrefix):
+		err = downloadModelCarModel(strings.TrimPrefix(model, models.ModelCarPrefix), filepath.Join(targetDir, models.ModelPath(model)))
+	default:
+		err = downloadHuggingFaceModel(model, targetDir)
+	}
+	if err != nil {
+		return err
+	}
+
+	// record the digest and license of the downloaded model
+	if err := RecordModelProvenance(model, targetDir, modelSource(model)); err != nil {
+		// do not fail the download if provenance cannot be recorded
+		logger.Warningf("failed to record provenance of model %s: %v\n", model, err)
+	}
+
+	return nil
+}
+
+func downloadHuggingFaceModel(model, targetDir string) error {
 	logger.Infof("Downloading model %s to %s\n", model, targetDir)
 	command := "podman"
 	// All arguments must be passed as a slice of strings
@@ -76,3 +115,184 @@ func DownloadModel(model, targetDir string) error {
 	logger.Infoln("Model downloaded successfully")
 	return nil
 }
+
+// downloadOCIArtifactModel pulls the model published as OCI artifact and extracts its blobs into target
+func downloadOCIArtifactModel(ref, target string) error {
+	logger.Infof("Pulling OCI model artifact %s\n", ref)
+	if out, err := exec.Command("podman", "artifact", "pull", ref).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to pull model artifact %s: %v, output: %s", ref, err, string(out))
+	}
+
+	if err := os.MkdirAll(target, os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create model directory: %w", err)
+	}
+
+	logger.Infof("Extracting model artifact %s to %s\n", ref, target)
+	if out, err := exec.Command("podman", "artifact", "extract", ref, target).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to extract model artifact %s: %v, output: %s", ref, err, string(out))
+	}
+
+	logger.Infoln("Model downloaded successfully")
+	return nil
+}
+
+// downloadModelCarModel pulls the ModelCar image and copies the model files present under /models into target
+func downloadModelCarModel(image, target string) error {
+	logger.Infof("Pulling ModelCar image %s\n", image)
+	if out, err := exec.Command("podman", "pull", image).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to pull modelcar image %s: %v, output: %s", image, err, string(out))
+	}
+
+	// create (not run) a container to be able to copy the files out of the image
+	out, err := exec.Command("podman", "create", image).Output()
+	if err != nil {
+		return fmt.Errorf("failed to create container from modelcar image %s: %w", image, err)
+	}
+	containerID := strings.TrimSpace(string(out))
+	defer func() {
+		_ = exec.Command("podman", "rm", "-f", containerID).Run()
+	}()
+
+	if err := os.MkdirAll(target, os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create model directory: %w", err)
+	}
+
+	logger.Infof("Copying model files from %s to %s\n", image, target)
+	if out, err := exec.Command("podman", "cp", containerID+":/models/.", target).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to copy model files from modelcar image %s: %v, output: %s", image, err, string(out))
+	}
+
+	logger.Infoln("Model downloaded successfully")
+	return nil
+}
+
+// EnsureSharedModelVolume creates the podman volume shared by applications to store the models, if not present already.
+// Returns the mountpoint of the volume on the host
+func EnsureSharedModelVolume(runtime runtime.Runtime) (string, error) {
+	exists, err := runtime.VolumeExists(constants.SharedModelVolume)
+	if err != nil {
+		return "", fmt.Errorf("failed to check if shared model volume exists: %w", err)
+	}
+
+	if !exists {
+		logger.Infof("Creating shared model volume %s\n", constants.SharedModelVolume)
+		if _, err := runtime.CreateVolume(constants.SharedModelVolume, map[string]string{
+			string(vars.ManagedLabel):    "true",
+			string(vars.VolumeTypeLabel): "models",
+		}); err != nil {
+			return "", err
+		}
+	}
+
+	volume, err := runtime.InspectVolume(constants.SharedModelVolume)
+	if err != nil {
+		return "", err
+	}
+
+	if volume.Mountpoint == "" {
+		return "", fmt.Errorf("shared model volume %s does not have a mountpoint", constants.SharedModelVolume)
+	}
+
+	return volume.Mountpoint, nil
+}
+
+// servedModels is the response of the OpenAI compatible /v1/models endpoint
+type servedModels struct {
+	Data []servedModel `json:"data"`
+}
+
+type servedModel struct {
+	ID string `json:"id"`
+}
+
+// VerifyModelServing runs a quick load test against the OpenAI compatible server serving the model
+//  1. lists the served models and verifies the model is loaded
+//  2. if a prompt is set, requests a single token completion
+func VerifyModelServing(runtime runtime.Runtime, podName, apiKey string, model templates.ModelRequirement) error {
+	if model.Verify == nil {
+		return nil
+	}
+
+	podIP, err := FetchPodIP(runtime, podName)
+	if err != nil {
+		return fmt.Errorf("failed to fetch IP of pod %s: %w", podName, err)
+	}
+
+	servedName := model.Verify.ServedModelName
+	if servedName == "" {
+		servedName = model.Name
+	}
+
+	baseURL := fmt.Sprintf("http://%s:%d/v1", podIP, model.Verify.Port)
+	client := &http.Client{Timeout: 2 * time.Minute}
+
+	// 1. verify the model is loaded
+	req, err := newOpenAIRequest(http.MethodGet, baseURL+"/models", apiKey, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to list served models: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("listing served models returned status: %s", resp.Status)
+	}
+
+	var served servedModels
+	if err := json.NewDecoder(resp.Body).Decode(&served); err != nil {
+		return fmt.Errorf("failed to decode served models: %w", err)
+	}
+
+	if !slices.ContainsFunc(served.Data, func(m servedModel) bool { return m.ID == servedName }) {
+		return fmt.Errorf("model %s is not served by container %s", servedName, model.Container)
+	}
+
+	if model.Verify.Prompt == "" {
+		return nil
+	}
+
+	// 2. quick load test with a single token completion
+	body, err := json.Marshal(map[string]any{
+		"model":      servedName,
+		"prompt":     model.Verify.Prompt,
+		"max_tokens": 1,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err = newOpenAIRequest(http.MethodPost, baseURL+"/completions", apiKey, body)
+	if err != nil {
+		return err
+	}
+	resp, err = client.Do(req)
+	if err != nil {
+		return fmt.Errorf("completion request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		out, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("completion request returned status: %s, response: %s", resp.Status, string(out))
+	}
+
+	return nil
+}
+
+// newOpenAIRequest creates a request to the OpenAI compatible server, authenticated with the API key if set
+func newOpenAIRequest(method, url, apiKey string, body []byte) (*http.Request, error) {
+	req, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+	return req, nil
+}
@@ -0,0 +1,216 @@
+package helpers
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/state"
+)
+
+// modelsState is the name of the state document holding the model provenance records
+const modelsState = "models"
+
+const huggingFaceAPI = "https://huggingface.co/api/models/"
+
+// ModelRecord holds the provenance of a downloaded model
+type ModelRecord struct {
+	Name   string `json:"name"`
+	Path   string `json:"path"`
+	Source string `json:"source"`
+	// SHA256 is the digest computed over the digests of all the files of the model
+	SHA256            string     `json:"sha256,omitempty"`
+	Files             int        `json:"files,omitempty"`
+	License           string     `json:"license,omitempty"`
+	Gated             bool       `json:"gated,omitempty"`
+	LicenseAcceptedAt *time.Time `json:"licenseAcceptedAt,omitempty"`
+	DownloadedAt      time.Time  `json:"downloadedAt"`
+}
+
+// ModelRecords returns all the model provenance records keyed by model name
+func ModelRecords() (map[string]*ModelRecord, error) {
+	records := map[string]*ModelRecord{}
+	if err := state.Default().Load(modelsState, &records); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// CheckModelLicense makes sure the license of a gated model is acknowledged before it is downloaded.
+// The acknowledgment, given with acceptLicense, is recorded in the state store, so it is required only once per model.
+func CheckModelLicense(model string, acceptLicense bool) error {
+	if models.IsOCIModel(model) {
+		// license information is only available for Hugging Face models
+		return nil
+	}
+
+	info, err := fetchHuggingFaceModelInfo(model)
+	if err != nil {
+		// not failing here as the host may not have access to Hugging Face API (Eg:- mirrors)
+		logger.Infof("Unable to fetch license information for model %s: %v\n", model, err, 2)
+		return nil
+	}
+
+	if !info.isGated() {
+		return nil
+	}
+
+	records := map[string]*ModelRecord{}
+	return state.Default().Update(modelsState, &records, func() error {
+		record, ok := records[model]
+		if ok && record.LicenseAcceptedAt != nil {
+			return nil
+		}
+
+		if !acceptLicense {
+			return fmt.Errorf("model %s is gated and requires accepting its license '%s'. Please review the license and rerun with --accept-license", model, info.license())
+		}
+
+		if !ok {
+			record = &ModelRecord{Name: model, Source: "huggingface"}
+			records[model] = record
+		}
+		now := time.Now().UTC()
+		record.Gated = true
+		record.License = info.license()
+		record.LicenseAcceptedAt = &now
+		logger.Infof("License '%s' of model %s accepted\n", record.License, model)
+
+		return nil
+	})
+}
+
+// RecordModelProvenance computes the digest of the model present in targetDir and records it in the state store
+func RecordModelProvenance(model, targetDir, source string) error {
+	digest, files, err := ModelDigest(model, targetDir)
+	if err != nil {
+		return err
+	}
+	return RecordModelDigest(model, targetDir, source, digest, files)
+}
+
+// ModelDigest returns the digest of the model present in targetDir, as recorded in its provenance, along with its
+// number of files
+func ModelDigest(model, targetDir string) (string, int, error) {
+	digest, files, err := models.Digest(filepath.Join(targetDir, models.ModelPath(model)))
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to compute digest of model %s: %w", model, err)
+	}
+	return digest, files, nil
+}
+
+// RecordModelDigest records the provenance of the model present in targetDir, with its digest computed by ModelDigest
+func RecordModelDigest(model, targetDir, source, digest string, files int) error {
+	modelDir := filepath.Join(targetDir, models.ModelPath(model))
+	license := readModelCardLicense(modelDir)
+
+	records := map[string]*ModelRecord{}
+	return state.Default().Update(modelsState, &records, func() error {
+		record, ok := records[model]
+		if !ok {
+			record = &ModelRecord{Name: model}
+			records[model] = record
+		}
+		record.Path = modelDir
+		record.Source = source
+		record.SHA256 = digest
+		record.Files = files
+		if license != "" {
+			record.License = license
+		}
+		record.DownloadedAt = time.Now().UTC()
+		return nil
+	})
+}
+
+// modelSource returns the source from where the model is downloaded
+func modelSource(model string) string {
+	switch {
+	case strings.HasPrefix(model, models.OCIModelPrefix):
+		return "oci"
+	case strings.HasPrefix(model, models.ModelCarPrefix):
+		return "modelcar"
+	}
+	return "huggingface"
+}
+
+// readModelCardLicense reads the license identifier from the front matter of the model card (README.md)
+func readModelCardLicense(modelDir string) string {
+	f, err := os.Open(filepath.Join(modelDir, "README.md"))
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	inFrontMatter := false
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "---" {
+			if inFrontMatter {
+				break
+			}
+			inFrontMatter = true
+			continue
+		}
+		if !inFrontMatter {
+			break
+		}
+		if v, ok := strings.CutPrefix(line, "license:"); ok {
+			return strings.Trim(strings.TrimSpace(v), `"'`)
+		}
+	}
+	return ""
+}
+
+// huggingFaceModelInfo is the subset of the Hugging Face model API response required by ai-services
+type huggingFaceModelInfo struct {
+	// Gated is either false or the gating mode ("auto", "manual")
+	Gated    any `json:"gated"`
+	CardData struct {
+		License string `json:"license"`
+	} `json:"cardData"`
+}
+
+func (i *huggingFaceModelInfo) isGated() bool {
+	switch v := i.Gated.(type) {
+	case bool:
+		return v
+	case string:
+		return v != "" && v != "false"
+	}
+	return false
+}
+
+func (i *huggingFaceModelInfo) license() string {
+	if i.CardData.License == "" {
+		return "unknown"
+	}
+	return i.CardData.License
+}
+
+func fetchHuggingFaceModelInfo(model string) (*huggingFaceModelInfo, error) {
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(huggingFaceAPI + model)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+
+	var info huggingFaceModelInfo
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
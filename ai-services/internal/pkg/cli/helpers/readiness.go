@@ -0,0 +1,270 @@
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/containers/podman/v5/libpod/define"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/faults"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime"
+)
+
+// ReadinessStatusInterval is the interval at which the watchdog reports that it is still waiting
+var ReadinessStatusInterval = time.Minute
+
+// ReadinessOptions configures the readiness watchdog of a container
+type ReadinessOptions struct {
+	// Name of the container, used in the status messages
+	Name string
+	// Timeout is the time after which the container is reported as not ready, unless it is still progressing
+	Timeout time.Duration
+	// Progress if set, log lines of the container matching it are considered as loading progress. Once the timeout
+	// is reached, the watchdog keeps waiting as long as a progress line was logged within the last StallTimeout.
+	Progress *regexp.Regexp
+	// StallTimeout is the time without progress after which a container past its timeout is reported as not ready
+	StallTimeout time.Duration
+	// MaxRestarts is the number of restarts after which the container is reported as crash-looping, 0 disables
+	MaxRestarts int
+}
+
+// crashLoopLogLines is the number of recent log lines reported along with a crash-looping container
+const crashLoopLogLines = 20
+
+// logTail keeps track of the last log line and the last progress of a container
+type logTail struct {
+	mu           sync.Mutex
+	lastLine     string
+	lastProgress time.Time
+	progress     *regexp.Regexp
+}
+
+func (t *logTail) add(line string) {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.lastLine = line
+	if t.progress != nil && t.progress.MatchString(line) {
+		t.lastProgress = time.Now()
+	}
+}
+
+func (t *logTail) get() (string, time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.lastLine, t.lastProgress
+}
+
+// WatchContainerReadiness waits for the container to be healthy like WaitForContainerReadiness, while following
+// its logs to periodically report that it is still waiting along with the last log line, so that long loading
+// model servers are not mistaken for hung ones.
+func WatchContainerReadiness(ctx context.Context, rt runtime.Runtime, containerNameOrId string, opts ReadinessOptions) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// simulate a slow container, see 'ai-services debug inject'
+	if fault := faults.Active(faults.KindReadinessDelay); fault != nil {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(fault.Delay):
+		}
+	}
+
+	tail := &logTail{progress: opts.Progress}
+	stdoutChan := make(chan string, 100)
+	stderrChan := make(chan string, 100)
+	go func() {
+		if err := rt.StreamContainerLogs(ctx, containerNameOrId, true, stdoutChan, stderrChan); err != nil {
+			logger.Infof("Unable to follow the logs of container %s: %v\n", opts.Name, err, 2)
+		}
+	}()
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case line := <-stdoutChan:
+				tail.add(line)
+			case line := <-stderrChan:
+				tail.add(line)
+			}
+		}
+	}()
+
+	start := time.Now()
+	deadline := start.Add(opts.Timeout)
+	nextStatus := start.Add(ReadinessStatusInterval)
+
+	for {
+		// fetch the container status
+		containerStatus, err := rt.InspectContainer(containerNameOrId)
+		if err != nil {
+			return fmt.Errorf("failed to check container status: %w", err)
+		}
+
+		healthStatus := containerStatus.State.Health
+		if healthStatus == nil || healthStatus.Status == string(Ready) {
+			return nil
+		}
+		// abort rather than waiting for the timeout of a container failing on every start
+		if opts.MaxRestarts > 0 && int(containerStatus.RestartCount) > opts.MaxRestarts {
+			return fmt.Errorf("container %s is crash-looping: restarted %d times, last exit code %d, recent logs:\n%s",
+				opts.Name, containerStatus.RestartCount, containerStatus.State.ExitCode,
+				strings.Join(recentLogs(ctx, rt, containerNameOrId, crashLoopLogLines), "\n"))
+		}
+		if !containerStatus.State.Running && !restarting(containerStatus) {
+			lastLine, _ := tail.get()
+			return fmt.Errorf("container %s exited (code %d) before being ready, last log line: %s", opts.Name, containerStatus.State.ExitCode, lastLine)
+		}
+
+		now := time.Now()
+		lastLine, lastProgress := tail.get()
+
+		// if deadline exeeds, stop the readiness check unless the container is still progressing
+		if now.After(deadline) {
+			if opts.Progress == nil || lastProgress.IsZero() || now.Sub(lastProgress) > opts.StallTimeout {
+				return fmt.Errorf("timeout waiting for readiness of container %s after %s, last log line: %s",
+					opts.Name, now.Sub(start).Round(time.Second), lastLine)
+			}
+		}
+
+		if now.After(nextStatus) {
+			msg := fmt.Sprintf("Still waiting for container %s to be ready (%s elapsed, timeout %s)", opts.Name, now.Sub(start).Round(time.Second), opts.Timeout)
+			if !lastProgress.IsZero() {
+				msg += fmt.Sprintf(", last progress %s ago", now.Sub(lastProgress).Round(time.Second))
+			}
+			if lastLine != "" {
+				msg += ", last log line: " + lastLine
+			}
+			logger.Infoln(msg)
+			nextStatus = now.Add(ReadinessStatusInterval)
+		}
+
+		// every 2 seconds inspect the container
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(2 * time.Second):
+		}
+	}
+}
+
+// CompletionOptions configures the wait for the completion of a container run to completion, Eg:- of a job pod
+type CompletionOptions struct {
+	// Name of the container, used in the status messages
+	Name string
+	// Timeout is the time after which the container still running is reported as failed
+	Timeout time.Duration
+	// MaxRestarts is the number of restarts after which the container is reported as failed, 0 disables
+	MaxRestarts int
+}
+
+// WaitForContainerCompletion waits for the container to exit with code 0. A container exiting with another code,
+// unless about to be restarted by its restart policy, fails along with its recent logs.
+func WaitForContainerCompletion(ctx context.Context, rt runtime.Runtime, containerNameOrId string, opts CompletionOptions) error {
+	start := time.Now()
+	nextStatus := start.Add(ReadinessStatusInterval)
+	for {
+		containerStatus, err := rt.InspectContainer(containerNameOrId)
+		if err != nil {
+			return fmt.Errorf("failed to check container status: %w", err)
+		}
+		state := containerStatus.State
+
+		if opts.MaxRestarts > 0 && int(containerStatus.RestartCount) > opts.MaxRestarts {
+			return fmt.Errorf("container %s failed: restarted %d times, last exit code %d, recent logs:\n%s",
+				opts.Name, containerStatus.RestartCount, state.ExitCode,
+				strings.Join(recentLogs(ctx, rt, containerNameOrId, crashLoopLogLines), "\n"))
+		}
+		// FinishedAt is zero while the container created by kube play is not started yet
+		if !state.Running && !state.FinishedAt.IsZero() && !restarting(containerStatus) {
+			if state.ExitCode == 0 {
+				return nil
+			}
+			return fmt.Errorf("container %s failed with exit code %d, recent logs:\n%s", opts.Name, state.ExitCode,
+				strings.Join(recentLogs(ctx, rt, containerNameOrId, crashLoopLogLines), "\n"))
+		}
+
+		now := time.Now()
+		if now.Sub(start) > opts.Timeout {
+			return fmt.Errorf("timeout waiting for the completion of container %s after %s, recent logs:\n%s",
+				opts.Name, now.Sub(start).Round(time.Second), strings.Join(recentLogs(ctx, rt, containerNameOrId, crashLoopLogLines), "\n"))
+		}
+		if now.After(nextStatus) {
+			logger.Infof("Still waiting for container %s to complete (%s elapsed, timeout %s)\n", opts.Name, now.Sub(start).Round(time.Second), opts.Timeout)
+			nextStatus = now.Add(ReadinessStatusInterval)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(2 * time.Second):
+		}
+	}
+}
+
+// restarting reports whether the stopped container is about to be restarted by its restart policy
+func restarting(c *define.InspectContainerData) bool {
+	if c.HostConfig == nil || c.HostConfig.RestartPolicy == nil {
+		return false
+	}
+	policy := c.HostConfig.RestartPolicy
+	switch policy.Name {
+	case define.RestartPolicyAlways, define.RestartPolicyUnlessStopped:
+		return true
+	case define.RestartPolicyOnFailure:
+		return c.State.ExitCode != 0 && (policy.MaximumRetryCount == 0 || uint(c.RestartCount) < policy.MaximumRetryCount)
+	}
+	return false
+}
+
+// recentLogs returns the last n log lines of the container, across its restarts
+func recentLogs(ctx context.Context, rt runtime.Runtime, containerNameOrId string, n int) []string {
+	stdoutChan := make(chan string, 100)
+	stderrChan := make(chan string, 100)
+	done := make(chan struct{})
+
+	var lines []string
+	go func() {
+		defer close(done)
+		stdout, stderr := stdoutChan, stderrChan
+		for stdout != nil || stderr != nil {
+			var line string
+			var ok bool
+			select {
+			case line, ok = <-stdout:
+				if !ok {
+					stdout = nil
+				}
+			case line, ok = <-stderr:
+				if !ok {
+					stderr = nil
+				}
+			}
+			if line = strings.TrimSpace(line); line != "" {
+				lines = append(lines, line)
+				if len(lines) > n {
+					lines = lines[1:]
+				}
+			}
+		}
+	}()
+
+	if err := rt.StreamContainerLogs(ctx, containerNameOrId, false, stdoutChan, stderrChan); err != nil {
+		logger.Infof("Unable to fetch the logs of container %s: %v\n", containerNameOrId, err, 2)
+	}
+	close(stdoutChan)
+	close(stderrChan)
+	<-done
+
+	return lines
+}
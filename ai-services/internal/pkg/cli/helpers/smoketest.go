@@ -0,0 +1,200 @@
+package helpers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/apikeys"
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/templates"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+)
+
+const smokeTestTimeout = 2 * time.Minute
+
+// SmokeTestResult is the outcome of a single smoke test
+type SmokeTestResult struct {
+	Name     string
+	Pod      string
+	Passed   bool
+	Duration time.Duration
+	Err      error
+}
+
+// MarshalJSON encodes the result with the error message, as errors don't marshal to JSON
+func (r SmokeTestResult) MarshalJSON() ([]byte, error) {
+	out := struct {
+		Name     string  `json:"name"`
+		Pod      string  `json:"pod,omitempty"`
+		Passed   bool    `json:"passed"`
+		Duration float64 `json:"durationSeconds"`
+		Error    string  `json:"error,omitempty"`
+	}{Name: r.Name, Pod: r.Pod, Passed: r.Passed, Duration: r.Duration.Seconds()}
+	if r.Err != nil {
+		out.Error = r.Err.Error()
+	}
+	return json.Marshal(out)
+}
+
+// RunSmokeTests runs the smoke tests declared by the application template against the deployed pods
+func RunSmokeTests(runtime runtime.Runtime, tp templates.Template, appTemplate, appName string, tests []templates.SmokeTest) []SmokeTestResult {
+	apiKey := apikeys.Token(runtime, appName)
+
+	results := make([]SmokeTestResult, 0, len(tests))
+	for _, test := range tests {
+		result := SmokeTestResult{Name: test.Name}
+		start := time.Now()
+
+		podSpec, err := tp.LoadPodTemplateWithValues(appTemplate, test.PodTemplate, appName, "", nil, nil)
+		if err != nil {
+			result.Err = fmt.Errorf("failed to load pod template %s: %w", test.PodTemplate, err)
+		} else {
+			result.Pod = podSpec.Name
+			result.Err = runSmokeTest(runtime, podSpec.Name, apiKey, test)
+		}
+
+		result.Duration = time.Since(start)
+		result.Passed = result.Err == nil
+		results = append(results, result)
+	}
+	return results
+}
+
+// PrintSmokeTestResults prints the smoke test results and returns an error if any of the tests failed
+func PrintSmokeTestResults(results []SmokeTestResult) error {
+	p := utils.NewTableWriter()
+	p.SetHeaders("TEST", "POD NAME", "RESULT", "DURATION", "MESSAGE")
+
+	failed := 0
+	for _, r := range results {
+		status, msg := "PASSED", ""
+		if !r.Passed {
+			failed++
+			status, msg = "FAILED", r.Err.Error()
+		}
+		p.AppendRow(r.Name, r.Pod, status, r.Duration.Round(time.Millisecond).String(), msg)
+	}
+	p.CloseTableWriter()
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d smoke tests failed", failed, len(results))
+	}
+	return nil
+}
+
+func runSmokeTest(runtime runtime.Runtime, podName, apiKey string, test templates.SmokeTest) error {
+	podIP, err := FetchPodIP(runtime, podName)
+	if err != nil {
+		return fmt.Errorf("failed to fetch IP of pod %s: %w", podName, err)
+	}
+
+	baseURL := fmt.Sprintf("http://%s:%d", podIP, test.Port)
+	if test.Completion != nil {
+		return runCompletionSmokeTest(baseURL, apiKey, test.Completion)
+	}
+
+	method := test.Method
+	if method == "" {
+		method = http.MethodGet
+		if test.Body != "" {
+			method = http.MethodPost
+		}
+	}
+
+	req, err := http.NewRequest(strings.ToUpper(method), baseURL+test.Path, strings.NewReader(test.Body))
+	if err != nil {
+		return err
+	}
+	if test.Body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+
+	client := &http.Client{Timeout: smokeTestTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read the response: %w", err)
+	}
+
+	expectStatus := test.ExpectStatus
+	if expectStatus == 0 {
+		expectStatus = http.StatusOK
+	}
+	if resp.StatusCode != expectStatus {
+		return fmt.Errorf("expected status %d, got: %s", expectStatus, resp.Status)
+	}
+
+	if test.ExpectPattern != "" {
+		re, err := regexp.Compile(test.ExpectPattern)
+		if err != nil {
+			return fmt.Errorf("invalid expectPattern: %w", err)
+		}
+		if !re.Match(body) {
+			return fmt.Errorf("response does not match the pattern '%s'", test.ExpectPattern)
+		}
+	}
+
+	return nil
+}
+
+type completionResponse struct {
+	Choices []struct {
+		Text string `json:"text"`
+	} `json:"choices"`
+}
+
+func runCompletionSmokeTest(baseURL, apiKey string, completion *templates.SmokeTestCompletion) error {
+	maxTokens := completion.MaxTokens
+	if maxTokens == 0 {
+		maxTokens = 1
+	}
+
+	body, err := json.Marshal(map[string]any{
+		"model":      completion.Model,
+		"prompt":     completion.Prompt,
+		"max_tokens": maxTokens,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := newOpenAIRequest(http.MethodPost, baseURL+"/v1/completions", apiKey, body)
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{Timeout: smokeTestTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("completion request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		out, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("completion request returned status: %s, response: %s", resp.Status, string(out))
+	}
+
+	var cr completionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&cr); err != nil {
+		return fmt.Errorf("failed to decode completion response: %w", err)
+	}
+	if len(cr.Choices) == 0 {
+		return fmt.Errorf("completion response has no choices")
+	}
+
+	return nil
+}
@@ -0,0 +1,198 @@
+package templates
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"text/template"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// CacheEnv enables the on-disk cache of the template metadata, in the directory it is set to. The parsed templates
+// are always cached within the process.
+const CacheEnv = "AI_SERVICES_TEMPLATE_CACHE"
+
+// bundleCache caches the artifacts parsed from the template bundles, keyed by the digest of the bundle so that an
+// updated bundle is never served stale artifacts. It is shared by all the template providers of the process, as the
+// commands create their own providers.
+type bundleCache struct {
+	mu sync.Mutex
+	// digests are the digests of the bundle directories, by file system and directory
+	digests  map[string]string
+	apps     map[string][]string
+	tmpls    map[string]map[string]*template.Template
+	podTmpls map[string]*template.Template
+	metadata map[string]*AppMetadata
+}
+
+var cache = &bundleCache{
+	digests:  map[string]string{},
+	apps:     map[string][]string{},
+	tmpls:    map[string]map[string]*template.Template{},
+	podTmpls: map[string]*template.Template{},
+	metadata: map[string]*AppMetadata{},
+}
+
+// digest returns the sha256 of the paths and contents of the files beneath the directory. The embedded file
+// systems are immutable, hence the digest is computed once per process.
+func (e *embedTemplateProvider) digest(dir string) (string, error) {
+	key := fmt.Sprintf("%p:%s", e.fs, dir)
+	cache.mu.Lock()
+	d, ok := cache.digests[key]
+	cache.mu.Unlock()
+	if ok {
+		return d, nil
+	}
+
+	h := sha256.New()
+	err := fs.WalkDir(e.fs, dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		data, err := e.fs.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(h, "%s\x00%d\x00", strings.TrimPrefix(path, dir), len(data))
+		h.Write(data)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	d = hex.EncodeToString(h.Sum(nil))
+
+	cache.mu.Lock()
+	cache.digests[key] = d
+	cache.mu.Unlock()
+	return d, nil
+}
+
+// bundleKey returns the cache key of the path of an application bundle (Eg:- 'rag/templates'), made of the digest
+// of the bundle and the path within
+func (e *embedTemplateProvider) bundleKey(path string) (string, error) {
+	app, _, _ := strings.Cut(path, "/")
+	d, err := e.digest(fmt.Sprintf("%s/%s", e.root, app))
+	if err != nil {
+		return "", err
+	}
+	return d + ":" + path, nil
+}
+
+// cached returns the value cached under the key, or loads and caches it
+func cached[T any](entries map[string]T, key string, load func() (T, error)) (T, error) {
+	cache.mu.Lock()
+	v, ok := entries[key]
+	cache.mu.Unlock()
+	if ok {
+		return v, nil
+	}
+
+	v, err := load()
+	if err != nil {
+		return v, err
+	}
+	cache.mu.Lock()
+	entries[key] = v
+	cache.mu.Unlock()
+	return v, nil
+}
+
+// ListApplications lists all available application templates
+func (e *embedTemplateProvider) ListApplications() ([]string, error) {
+	d, err := e.digest(e.root)
+	if err != nil {
+		return nil, err
+	}
+	apps, err := cached(cache.apps, d, e.listApplications)
+	return append([]string(nil), apps...), err
+}
+
+// LoadAllTemplates loads all templates for a given application
+func (e *embedTemplateProvider) LoadAllTemplates(path string) (map[string]*template.Template, error) {
+	key, err := e.bundleKey(path)
+	if err != nil {
+		return nil, err
+	}
+	tmpls, err := cached(cache.tmpls, key, func() (map[string]*template.Template, error) {
+		return e.loadAllTemplates(path)
+	})
+	return maps.Clone(tmpls), err
+}
+
+// podTemplate returns the parsed pod template of the application
+func (e *embedTemplateProvider) podTemplate(app, file string) (*template.Template, error) {
+	key, err := e.bundleKey(fmt.Sprintf("%s/templates/%s", app, file))
+	if err != nil {
+		return nil, err
+	}
+	return cached(cache.podTmpls, key, func() (*template.Template, error) {
+		path := fmt.Sprintf("%s/%s/templates/%s", e.root, app, file)
+		data, err := e.fs.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read metadata: %w", err)
+		}
+		tmpl, err := template.New(file).Option(strictOption).Funcs(funcMap).Parse(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("parse template %s: %w", file, err)
+		}
+		return tmpl, nil
+	})
+}
+
+// LoadMetadata loads the metadata for a given application template
+func (e *embedTemplateProvider) LoadMetadata(appTemplateName string) (*AppMetadata, error) {
+	key, err := e.bundleKey(appTemplateName)
+	if err != nil {
+		return nil, err
+	}
+	md, err := cached(cache.metadata, key, func() (*AppMetadata, error) {
+		return e.diskCachedMetadata(key, appTemplateName)
+	})
+	if err != nil {
+		return nil, err
+	}
+	// the callers get their own copy of the top level fields
+	copied := *md
+	return &copied, nil
+}
+
+// diskCachedMetadata loads the metadata from the on-disk cache if enabled with AI_SERVICES_TEMPLATE_CACHE, and
+// stores it there once loaded. The on-disk cache is best effort, its failures fall back to loading the metadata.
+func (e *embedTemplateProvider) diskCachedMetadata(key, appTemplateName string) (*AppMetadata, error) {
+	dir := os.Getenv(CacheEnv)
+	if dir == "" {
+		return e.loadMetadata(appTemplateName)
+	}
+
+	digest, _, _ := strings.Cut(key, ":")
+	path := filepath.Join(dir, fmt.Sprintf("%s-%s-metadata.yaml", appTemplateName, digest))
+	if data, err := os.ReadFile(path); err == nil {
+		var md AppMetadata
+		if err := yaml.Unmarshal(data, &md); err == nil {
+			return &md, nil
+		}
+	}
+
+	md, err := e.loadMetadata(appTemplateName)
+	if err != nil {
+		return nil, err
+	}
+	if data, err := yaml.Marshal(md); err == nil && os.MkdirAll(dir, 0o755) == nil {
+		tmp := path + ".tmp"
+		if os.WriteFile(tmp, data, 0o644) == nil {
+			_ = os.Rename(tmp, path)
+		}
+	}
+	return md, nil
+}
@@ -3,6 +3,7 @@ package templates
 import (
 	"bytes"
 	"embed"
+	"errors"
 	"fmt"
 	"io/fs"
 	"os"
@@ -12,18 +13,23 @@ import (
 
 	"github.com/project-ai-services/ai-services/assets"
 	"github.com/project-ai-services/ai-services/internal/pkg/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/specs"
 	"github.com/project-ai-services/ai-services/internal/pkg/utils"
 	"go.yaml.in/yaml/v3"
 	k8syaml "sigs.k8s.io/yaml"
 )
 
+// strictOption fails the rendering of the pod templates on a missing key, instead of silently substituting an
+// empty value. Optional lookups are done with index, which returns the zero value for a missing key.
+const strictOption = "missingkey=error"
+
 type embedTemplateProvider struct {
 	fs   *embed.FS
 	root string
 }
 
-// ListApplications lists all available application templates
-func (e *embedTemplateProvider) ListApplications() ([]string, error) {
+// listApplications walks the file system for the application templates
+func (e *embedTemplateProvider) listApplications() ([]string, error) {
 	apps := []string{}
 
 	err := fs.WalkDir(e.fs, e.root, func(path string, d fs.DirEntry, err error) error {
@@ -75,8 +81,8 @@ func (e *embedTemplateProvider) ListApplicationTemplateValues(app string) (map[s
 	return parametersWithDescription, nil
 }
 
-// LoadAllTemplates loads all templates for a given application
-func (e *embedTemplateProvider) LoadAllTemplates(path string) (map[string]*template.Template, error) {
+// loadAllTemplates parses all templates for a given application
+func (e *embedTemplateProvider) loadAllTemplates(path string) (map[string]*template.Template, error) {
 	tmpls := make(map[string]*template.Template)
 	completePath := fmt.Sprintf("%s/%s", e.root, path)
 	err := fs.WalkDir(e.fs, completePath, func(path string, d fs.DirEntry, err error) error {
@@ -87,7 +93,7 @@ func (e *embedTemplateProvider) LoadAllTemplates(path string) (map[string]*templ
 			return nil
 		}
 
-		t, err := template.ParseFS(e.fs, path)
+		t, err := template.New(d.Name()).Option(strictOption).Funcs(funcMap).ParseFS(e.fs, path)
 		if err != nil {
 			return fmt.Errorf("parse %s: %w", path, err)
 		}
@@ -102,30 +108,33 @@ func (e *embedTemplateProvider) LoadAllTemplates(path string) (map[string]*templ
 // LoadPodTemplate loads and renders a pod template with the given parameters
 func (e *embedTemplateProvider) LoadPodTemplate(app, file string, params any) (*models.PodSpec, error) {
 	path := fmt.Sprintf("%s/%s/templates/%s", e.root, app, file)
-	data, err := e.fs.ReadFile(path)
+	tmpl, err := e.podTemplate(app, file)
 	if err != nil {
-		return nil, fmt.Errorf("read metadata: %w", err)
+		return nil, err
 	}
 
 	var rendered bytes.Buffer
-	tmpl, err := template.New("podTemplate").Parse(string(data))
-	if err != nil {
-		return nil, fmt.Errorf("parse template %s: %w", file, err)
-	}
 	if err := tmpl.Execute(&rendered, params); err != nil {
 		return nil, fmt.Errorf("failed to execute template %s: %v", path, err)
 	}
+	if err := specs.ValidateKinds(rendered.Bytes(), specs.PodTemplateKinds...); err != nil {
+		return nil, fmt.Errorf("invalid pod template %s: %w", path, err)
+	}
+	pod, _, err := specs.SplitPod(rendered.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("invalid pod template %s: %w", path, err)
+	}
 
 	var spec models.PodSpec
-	if err := k8syaml.Unmarshal(rendered.Bytes(), &spec); err != nil {
+	if err := k8syaml.Unmarshal(pod, &spec); err != nil {
 		return nil, fmt.Errorf("unable to read YAML as Kube Pod: %w", err)
 	}
 
 	return &spec, nil
 }
 
-func (e *embedTemplateProvider) LoadPodTemplateWithValues(app, file, appName string, valuesFileOverrides []string, cliOverrides map[string]string) (*models.PodSpec, error) {
-	values, err := e.LoadValues(app, valuesFileOverrides, cliOverrides)
+func (e *embedTemplateProvider) LoadPodTemplateWithValues(app, file, appName, environment string, valuesFileOverrides []string, cliOverrides map[string]string) (*models.PodSpec, error) {
+	values, err := e.LoadValues(app, environment, valuesFileOverrides, cliOverrides)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load params for application: %w", err)
 	}
@@ -135,11 +144,12 @@ func (e *embedTemplateProvider) LoadPodTemplateWithValues(app, file, appName str
 		"AppName":         appName,
 		"AppTemplateName": "",
 		"Version":         "",
+		"env":             map[string]map[string]string{},
 	}
 	return e.LoadPodTemplate(app, file, params)
 }
 
-func (e *embedTemplateProvider) LoadValues(app string, valuesFileOverrides []string, cliOverrides map[string]string) (map[string]interface{}, error) {
+func (e *embedTemplateProvider) LoadValues(app, environment string, valuesFileOverrides []string, cliOverrides map[string]string) (map[string]interface{}, error) {
 	// Load the default values.yaml
 	valuesPath := fmt.Sprintf("%s/%s/values.yaml", e.root, app)
 	valuesData, err := e.fs.ReadFile(valuesPath)
@@ -151,6 +161,25 @@ func (e *embedTemplateProvider) LoadValues(app string, valuesFileOverrides []str
 		return nil, fmt.Errorf("failed to parse values.yaml: %w", err)
 	}
 
+	// Load the environment overlay shipped by the template, beneath the user provided overrides
+	if environment != "" {
+		envPath := fmt.Sprintf("%s/%s/values-%s.yaml", e.root, app, environment)
+		envData, err := e.fs.ReadFile(envPath)
+		if errors.Is(err, fs.ErrNotExist) {
+			envs, _ := e.ListEnvironments(app)
+			return nil, fmt.Errorf("unknown environment %q for application %s, available environments: %s", environment, app, strings.Join(envs, ", "))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read values-%s.yaml: %w", environment, err)
+		}
+		envValues := map[string]interface{}{}
+		if err := yaml.Unmarshal(envData, &envValues); err != nil {
+			return nil, fmt.Errorf("failed to parse values-%s.yaml: %w", environment, err)
+		}
+		// the overlays set the nested keys only, the sibling keys keep their default values
+		mergeValues(values, envValues)
+	}
+
 	// Load user provided file overrides
 	for _, overridePath := range valuesFileOverrides {
 		overrideData, err := os.ReadFile(overridePath)
@@ -173,8 +202,38 @@ func (e *embedTemplateProvider) LoadValues(app string, valuesFileOverrides []str
 	return values, nil
 }
 
-// LoadMetadata loads the metadata for a given application template
-func (e *embedTemplateProvider) LoadMetadata(appTemplateName string) (*AppMetadata, error) {
+// mergeValues merges the nested maps of src into dst, the other values of src replacing the ones of dst
+func mergeValues(dst, src map[string]interface{}) {
+	for key, val := range src {
+		srcMap, srcIsMap := val.(map[string]interface{})
+		dstMap, dstIsMap := dst[key].(map[string]interface{})
+		if srcIsMap && dstIsMap {
+			mergeValues(dstMap, srcMap)
+			continue
+		}
+		dst[key] = val
+	}
+}
+
+// ListEnvironments lists the environments of the values-<environment>.yaml overlays of the application
+func (e *embedTemplateProvider) ListEnvironments(app string) ([]string, error) {
+	entries, err := fs.ReadDir(e.fs, fmt.Sprintf("%s/%s", e.root, app))
+	if err != nil {
+		return nil, err
+	}
+	var envs []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, "values-") || !strings.HasSuffix(name, ".yaml") {
+			continue
+		}
+		envs = append(envs, strings.TrimSuffix(strings.TrimPrefix(name, "values-"), ".yaml"))
+	}
+	return envs, nil
+}
+
+// loadMetadata reads the metadata for a given application template
+func (e *embedTemplateProvider) loadMetadata(appTemplateName string) (*AppMetadata, error) {
 	path := fmt.Sprintf("%s/%s/metadata.yaml", e.root, appTemplateName)
 	data, err := e.fs.ReadFile(path)
 	if err != nil {
@@ -241,6 +300,44 @@ func (e *embedTemplateProvider) LoadVarsFile(app string, params map[string]strin
 	return &vars, nil
 }
 
+// LoadExamples loads the guides (*.md) and sample values files (*.yaml) of the examples directory of the application
+func (e *embedTemplateProvider) LoadExamples(app string) ([]Example, error) {
+	dir := fmt.Sprintf("%s/%s/examples", e.root, app)
+	entries, err := fs.ReadDir(e.fs, dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read examples: %w", err)
+	}
+
+	var examples []Example
+	for _, entry := range entries {
+		name := entry.Name()
+		var kind, prefix string
+		switch {
+		case entry.IsDir():
+			continue
+		case strings.HasSuffix(name, ".md"):
+			kind, prefix = ExampleKindGuide, "# "
+		case strings.HasSuffix(name, ".yaml"):
+			kind, prefix = ExampleKindValues, "#"
+		default:
+			continue
+		}
+		data, err := e.fs.ReadFile(dir + "/" + name)
+		if err != nil {
+			return nil, fmt.Errorf("read example %s: %w", name, err)
+		}
+		example := Example{Name: name, Kind: kind, Content: string(data)}
+		if first, _, _ := strings.Cut(example.Content, "\n"); strings.HasPrefix(first, prefix) {
+			example.Description = strings.TrimSpace(strings.TrimPrefix(first, prefix))
+		}
+		examples = append(examples, example)
+	}
+	return examples, nil
+}
+
 type EmbedOptions struct {
 	FS   *embed.FS
 	Root string
@@ -0,0 +1,91 @@
+package templates
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeValues(t *testing.T) {
+	tests := []struct {
+		name string
+		dst  map[string]interface{}
+		src  map[string]interface{}
+		want map[string]interface{}
+	}{
+		{
+			name: "new keys are added",
+			dst:  map[string]interface{}{"a": 1},
+			src:  map[string]interface{}{"b": "two"},
+			want: map[string]interface{}{"a": 1, "b": "two"},
+		},
+		{
+			name: "scalars are replaced",
+			dst:  map[string]interface{}{"a": 1, "b": true},
+			src:  map[string]interface{}{"a": 2},
+			want: map[string]interface{}{"a": 2, "b": true},
+		},
+		{
+			name: "nested maps are merged",
+			dst: map[string]interface{}{
+				"vllm": map[string]interface{}{"image": "vllm:1", "resources": map[string]interface{}{"cpu": 4, "memory": "16Gi"}},
+			},
+			src: map[string]interface{}{
+				"vllm": map[string]interface{}{"resources": map[string]interface{}{"cpu": 8}},
+			},
+			want: map[string]interface{}{
+				"vllm": map[string]interface{}{"image": "vllm:1", "resources": map[string]interface{}{"cpu": 8, "memory": "16Gi"}},
+			},
+		},
+		{
+			name: "lists are replaced, not appended",
+			dst:  map[string]interface{}{"models": []interface{}{"granite", "mistral"}},
+			src:  map[string]interface{}{"models": []interface{}{"llama"}},
+			want: map[string]interface{}{"models": []interface{}{"llama"}},
+		},
+		{
+			name: "map replaces a scalar",
+			dst:  map[string]interface{}{"ui": false},
+			src:  map[string]interface{}{"ui": map[string]interface{}{"port": 3000}},
+			want: map[string]interface{}{"ui": map[string]interface{}{"port": 3000}},
+		},
+		{
+			name: "scalar replaces a map",
+			dst:  map[string]interface{}{"ui": map[string]interface{}{"port": 3000}},
+			src:  map[string]interface{}{"ui": false},
+			want: map[string]interface{}{"ui": false},
+		},
+		{
+			name: "nil value replaces the value",
+			dst:  map[string]interface{}{"ui": map[string]interface{}{"port": 3000}, "a": 1},
+			src:  map[string]interface{}{"ui": nil},
+			want: map[string]interface{}{"ui": nil, "a": 1},
+		},
+		{
+			name: "nil value is added",
+			dst:  map[string]interface{}{},
+			src:  map[string]interface{}{"a": nil},
+			want: map[string]interface{}{"a": nil},
+		},
+		{
+			name: "empty src keeps dst",
+			dst:  map[string]interface{}{"a": map[string]interface{}{"b": 1}},
+			src:  map[string]interface{}{},
+			want: map[string]interface{}{"a": map[string]interface{}{"b": 1}},
+		},
+		{
+			name: "nil src keeps dst",
+			dst:  map[string]interface{}{"a": 1},
+			src:  nil,
+			want: map[string]interface{}{"a": 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mergeValues(tt.dst, tt.src)
+			if !reflect.DeepEqual(tt.dst, tt.want) {
+				t.Errorf("mergeValues() = %v, want %v", tt.dst, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package templates
+
+import (
+	"text/template"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/platform"
+)
+
+// funcMap are the functions available to the pod templates. The sizing functions convert an abstract size of the
+// values, Eg:- {{ cpuLimit .Values.vllm.size }}, into the limits of the processor and SMT level of the host, so that
+// the templates are portable across machine sizes.
+var funcMap = template.FuncMap{
+	"cpuLimit": func(size string) (string, error) {
+		r, err := platform.LocalHost().ResourcesOf(size)
+		return r.CPU, err
+	},
+	"memoryLimit": func(size string) (string, error) {
+		r, err := platform.LocalHost().ResourcesOf(size)
+		return r.Memory, err
+	},
+}
@@ -3,6 +3,7 @@ package templates
 import (
 	"text/template"
 
+	"github.com/project-ai-services/ai-services/internal/pkg/config"
 	"github.com/project-ai-services/ai-services/internal/pkg/models"
 )
 
@@ -11,6 +12,315 @@ type AppMetadata struct {
 	Version               string     `yaml:"version,omitempty"`
 	SMTLevel              *int       `yaml:"smtLevel,omitempty"`
 	PodTemplateExecutions [][]string `yaml:"podTemplateExecutions"`
+	// Models declares the models required by the containers of the pod templates
+	Models []ModelRequirement `yaml:"models,omitempty"`
+	// SharedModelVolume if set, the models are stored in a podman volume shared across all the applications
+	SharedModelVolume bool `yaml:"sharedModelVolume,omitempty"`
+	// SmokeTests are run against the application once all the pods are ready
+	SmokeTests []SmokeTest `yaml:"smokeTests,omitempty"`
+	// CPUPinning pins containers of the pod templates to dedicated physical cores
+	CPUPinning []CPUPinning `yaml:"cpuPinning,omitempty"`
+	// Scalable are the pod templates which can be replicated with 'application scale'
+	Scalable []ScalableComponent `yaml:"scalable,omitempty"`
+	// WaitFor are the conditions awaited between the layers, beyond the health of the containers
+	WaitFor []WaitCondition `yaml:"waitFor,omitempty"`
+	// Retry is the retry policy of the pods failing to deploy on a transient error
+	Retry *RetryPolicy `yaml:"retry,omitempty"`
+	// Requires is the host the template is compatible with, checked before the template is deployed
+	Requires *Compatibility `yaml:"requires,omitempty"`
+	// Webhooks are called once the application is ready or failed to deploy, Eg:- to notify the portal which
+	// requested the deployment
+	Webhooks []Webhook `yaml:"webhooks,omitempty"`
+	// LogAlerts are the patterns of the container logs raising an alert, Eg:- the failures the health checks miss
+	LogAlerts []config.LogAlert `yaml:"logAlerts,omitempty"`
+	// RestartPolicies override the restart policy the pod templates hardcode
+	RestartPolicies []RestartPolicy `yaml:"restartPolicies,omitempty"`
+	// Outputs are the values captured from the pods of a layer once deployed, Eg:- a generated admin password,
+	// passed to the pod templates of the later layers as {{ .Outputs.<name> }}
+	Outputs []Output `yaml:"outputs,omitempty"`
+	// Exposure declares the ports of the pod templates exposed outside of the host, only on the host or only on
+	// the application network. The ports not declared are exposed as published by the ports annotation.
+	Exposure []PortExposure `yaml:"exposure,omitempty"`
+	// Dependencies order the pod templates of a layer, deployed concurrently otherwise: a pod template is deployed
+	// once the pod templates it depends on are ready
+	Dependencies []Dependency `yaml:"dependencies,omitempty"`
+}
+
+// Dependency is a soft ordering between pod templates of the same layer
+type Dependency struct {
+	PodTemplate string `yaml:"podTemplate"`
+	// DependsOn are the pod templates of the layer awaited, Eg:- the vector database of the ingestion pod
+	DependsOn []string `yaml:"dependsOn"`
+}
+
+// Exposures of the ports of the pods
+const (
+	// ExposureExternal publishes the port on all the interfaces of the host, and opens it in firewalld when the
+	// firewall is managed by ai-services
+	ExposureExternal = "external"
+	// ExposureLocal publishes the port on the loopback interface of the host only, Eg:- an admin console
+	ExposureLocal = "local"
+	// ExposureInternal doesn't publish the port, it is only reachable by the pods on the application network
+	ExposureInternal = "internal"
+)
+
+// PortExposure is the exposure of a container port of a pod template
+type PortExposure struct {
+	PodTemplate string `yaml:"podTemplate"`
+	// Port is the container port, as declared by the ports annotation of the pod template
+	Port int `yaml:"port"`
+	// Exposure is external, local or internal
+	Exposure string `yaml:"exposure"`
+}
+
+// Output is a value captured from a pod once its layer is ready. Exactly one of Log, Exec and File is set.
+type Output struct {
+	Name        string `yaml:"name"`
+	PodTemplate string `yaml:"podTemplate"`
+	// Timeout is the duration the value is awaited for, defaults to 5m
+	Timeout string      `yaml:"timeout,omitempty"`
+	Log     *LogOutput  `yaml:"log,omitempty"`
+	Exec    *ExecOutput `yaml:"exec,omitempty"`
+	File    *FileOutput `yaml:"file,omitempty"`
+	// Secret if set, the value is stored in a podman secret and the later layers get the name of the secret instead
+	// of the value, Eg:- to mount the generated password
+	Secret bool `yaml:"secret,omitempty"`
+}
+
+// LogOutput captures the value from the logs of a container of the pod
+type LogOutput struct {
+	Container string `yaml:"container"`
+	// Pattern is the regular expression matched against each log line, the value is its first capture group.
+	// Eg:- admin password: (\S+)
+	Pattern string `yaml:"pattern"`
+}
+
+// ExecOutput captures the value from the stdout of a command run in a container of the pod, trimmed of spaces
+type ExecOutput struct {
+	Container string   `yaml:"container"`
+	Command   []string `yaml:"command"`
+}
+
+// FileOutput captures the value from the content of a file in a volume of the pod, trimmed of spaces
+type FileOutput struct {
+	// Volume is the name of the volume in the pod template, either a host path or a claim
+	Volume string `yaml:"volume"`
+	// Path of the file relative to the volume
+	Path string `yaml:"path"`
+}
+
+// Restart policies of the pods
+const (
+	RestartAlways    = "Always"
+	RestartOnFailure = "OnFailure"
+	RestartNever     = "Never"
+)
+
+// RestartPolicy is the restart policy of the pods of a pod template, rewritten into the rendered pods
+type RestartPolicy struct {
+	PodTemplate string `yaml:"podTemplate"`
+	// Policy is Always, OnFailure or Never, the policy of the pod template is kept if empty
+	Policy string `yaml:"policy,omitempty"`
+	// PolicyValue is the key of the template values holding the policy, Eg:- vllm.restartPolicy, taking precedence
+	// over Policy
+	PolicyValue string `yaml:"policyValue,omitempty"`
+	// MaxRestarts caps the restarts of each container by podman. 'ai-services watch --restart-limits' stops the pod
+	// once a container exceeds it. Unlimited if 0
+	MaxRestarts int `yaml:"maxRestarts,omitempty"`
+}
+
+// Events of the application the webhooks are called on
+const (
+	WebhookEventReady  = "ready"
+	WebhookEventFailed = "failed"
+	// WebhookEventLogAlert is raised by a line of the container logs matching a log alert
+	WebhookEventLogAlert = "log-alert"
+)
+
+// Webhook is called with a POST of the application event, signed with HMAC-SHA256 if a secret is set
+type Webhook struct {
+	// URL called on the events, Eg:- https://portal.example.com/hooks/ai-services
+	URL string `yaml:"url,omitempty"`
+	// URLValue is the key of the template values holding the URL, Eg:- webhook.url, taking precedence over URL
+	URLValue string `yaml:"urlValue,omitempty"`
+	// SecretValue is the key of the template values holding the key signing the events, Eg:- webhook.secret
+	SecretValue string `yaml:"secretValue,omitempty"`
+	// Events the URL is called on, ready and failed if empty. The log-alert events are only sent when listed
+	Events []string `yaml:"events,omitempty"`
+}
+
+// Compatibility is the minimum host a template can be deployed on. Unset fields are not checked.
+type Compatibility struct {
+	// CLIVersion is the minimum version of the ai-services CLI, Eg:- 0.4.0
+	CLIVersion string `yaml:"cliVersion,omitempty"`
+	// PodmanVersion is the minimum version of the podman server, Eg:- 5.2
+	PodmanVersion string `yaml:"podmanVersion,omitempty"`
+	// RHELVersion is the minimum RHEL release, Eg:- 9.6
+	RHELVersion string `yaml:"rhelVersion,omitempty"`
+	// AcceleratorGeneration is the minimum generation of the Spyre cards, Eg:- 1
+	AcceleratorGeneration int `yaml:"acceleratorGeneration,omitempty"`
+}
+
+// RetryPolicy retries the deployment of a pod failing on a transient error, Eg:- an image pull timeout
+type RetryPolicy struct {
+	// Attempts is the number of retries, 0 disables them
+	Attempts int `yaml:"attempts"`
+	// Backoff is the delay before the first retry, doubled at each retry. Eg:- 10s
+	Backoff string `yaml:"backoff,omitempty"`
+	// MaxBackoff caps the delay between the retries, Eg:- 2m
+	MaxBackoff string `yaml:"maxBackoff,omitempty"`
+}
+
+// WaitCondition is awaited once a layer is ready, before the next layer is started. Exactly one of HTTP, TCP and
+// File is set.
+type WaitCondition struct {
+	Name string `yaml:"name"`
+	// AfterLayer is the layer (1-based) after which the condition is awaited
+	AfterLayer int `yaml:"afterLayer"`
+	// Timeout is the duration the condition is awaited for, defaults to 10m
+	Timeout string    `yaml:"timeout,omitempty"`
+	HTTP    *HTTPWait `yaml:"http,omitempty"`
+	TCP     *TCPWait  `yaml:"tcp,omitempty"`
+	File    *FileWait `yaml:"file,omitempty"`
+}
+
+// HTTPWait waits for an endpoint of a pod to return the expected status
+type HTTPWait struct {
+	PodTemplate string `yaml:"podTemplate"`
+	// Port on which the container is listening within the pod
+	Port int    `yaml:"port"`
+	Path string `yaml:"path,omitempty"`
+	// ExpectStatus is the expected HTTP status code, defaults to 200
+	ExpectStatus int `yaml:"expectStatus,omitempty"`
+}
+
+// TCPWait waits for a port of a pod to accept connections
+type TCPWait struct {
+	PodTemplate string `yaml:"podTemplate"`
+	Port        int    `yaml:"port"`
+}
+
+// FileWait waits for a file to exist in a volume of a pod, Eg:- the index built by an ingestion job
+type FileWait struct {
+	PodTemplate string `yaml:"podTemplate"`
+	// Volume is the name of the volume in the pod template, either a host path or a claim
+	Volume string `yaml:"volume"`
+	// Path of the file, relative to the volume
+	Path string `yaml:"path"`
+}
+
+// WaitConditions returns the conditions awaited after the given layer (1-based)
+func (m *AppMetadata) WaitConditions(layer int) []WaitCondition {
+	var conditions []WaitCondition
+	for _, c := range m.WaitFor {
+		if c.AfterLayer == layer {
+			conditions = append(conditions, c)
+		}
+	}
+	return conditions
+}
+
+// ScalableComponent is a pod template of which several pods can be deployed, Eg:- stateless workers
+type ScalableComponent struct {
+	// Component is the name of the component, Eg:- worker
+	Component   string `yaml:"component"`
+	PodTemplate string `yaml:"podTemplate"`
+	// MinReplicas is the lower bound of the autoscaling, defaults to 1
+	MinReplicas int `yaml:"minReplicas,omitempty"`
+	// MaxReplicas caps the pods of the component, including the pod deployed by create. Unlimited if 0
+	MaxReplicas int `yaml:"maxReplicas,omitempty"`
+	// Autoscale if set, the component is scaled on the queue depth of its pods by 'application autoscale'
+	Autoscale *Autoscale `yaml:"autoscale,omitempty"`
+}
+
+// Autoscale scales a component on a queue depth metric scraped from its pods, Eg:- the pending requests of vLLM
+type Autoscale struct {
+	// Port on which the Prometheus metrics are served within the pod
+	Port int `yaml:"port"`
+	// Path of the Prometheus metrics, defaults to /metrics
+	Path string `yaml:"path,omitempty"`
+	// Metric is the queue depth, summed across the pods of the component. Defaults to vllm:num_requests_waiting
+	Metric string `yaml:"metric,omitempty"`
+	// TargetPerReplica is the queue depth a replica is expected to absorb, the component is scaled to
+	// ceil(queue depth / TargetPerReplica) replicas
+	TargetPerReplica float64 `yaml:"targetPerReplica"`
+}
+
+// CPUPinning dedicates physical cores to a container of a pod template, isolating it from the other workloads
+// of the host. All the online hardware threads of the cores (as per the SMT level) are assigned to the container.
+type CPUPinning struct {
+	PodTemplate string `yaml:"podTemplate"`
+	Container   string `yaml:"container"`
+	// Cores is the number of dedicated physical cores
+	Cores int `yaml:"cores"`
+}
+
+// SmokeTest declares a request sent to a pod of the application to verify it is serving
+type SmokeTest struct {
+	Name        string `yaml:"name"`
+	PodTemplate string `yaml:"podTemplate"`
+	// Port on which the container is listening within the pod
+	Port int `yaml:"port"`
+	// Method of the HTTP request, defaults to GET (POST if Body is set)
+	Method string `yaml:"method,omitempty"`
+	Path   string `yaml:"path,omitempty"`
+	Body   string `yaml:"body,omitempty"`
+	// ExpectStatus is the expected HTTP status code, defaults to 200
+	ExpectStatus int `yaml:"expectStatus,omitempty"`
+	// ExpectPattern if set, the response body must match the regular expression
+	ExpectPattern string `yaml:"expectPattern,omitempty"`
+	// Completion if set, an OpenAI compatible completion is requested instead of the HTTP request
+	Completion *SmokeTestCompletion `yaml:"completion,omitempty"`
+}
+
+// SmokeTestCompletion configures an OpenAI compatible completion request
+type SmokeTestCompletion struct {
+	Model     string `yaml:"model"`
+	Prompt    string `yaml:"prompt"`
+	MaxTokens int    `yaml:"maxTokens,omitempty"`
+}
+
+// ModelRequirement declares a model required by a container of a pod template
+type ModelRequirement struct {
+	// Name is either the Hugging Face model id or an oci:// or modelcar:// reference
+	Name        string `yaml:"name"`
+	PodTemplate string `yaml:"podTemplate"`
+	Container   string `yaml:"container"`
+	// MountPath is the path within the container where the model is mounted read-only, defaults to /models/<model>
+	MountPath string       `yaml:"mountPath,omitempty"`
+	Verify    *ModelVerify `yaml:"verify,omitempty"`
+}
+
+// ModelVerify configures a quick load test run against the container serving the model once it is ready
+type ModelVerify struct {
+	// Port on which the OpenAI compatible server is listening within the pod
+	Port int `yaml:"port"`
+	// ServedModelName is the name with which the model is served, defaults to the model name
+	ServedModelName string `yaml:"servedModelName,omitempty"`
+	// Prompt if set, a single token completion is requested with the prompt
+	Prompt string `yaml:"prompt,omitempty"`
+}
+
+// RequiredModels returns the models required by the given pod template
+func (m *AppMetadata) RequiredModels(podTemplate string) []ModelRequirement {
+	var reqModels []ModelRequirement
+	for _, model := range m.Models {
+		if model.PodTemplate == podTemplate {
+			reqModels = append(reqModels, model)
+		}
+	}
+	return reqModels
+}
+
+// PinnedContainers returns the CPU pinning of the containers of the given pod template
+func (m *AppMetadata) PinnedContainers(podTemplate string) []CPUPinning {
+	var pinned []CPUPinning
+	for _, p := range m.CPUPinning {
+		if p.PodTemplate == podTemplate {
+			pinned = append(pinned, p)
+		}
+	}
+	return pinned
 }
 
 type Vars struct {
@@ -40,12 +350,36 @@ type Template interface {
 	// LoadPodTemplate loads and renders a pod template with the given parameters
 	LoadPodTemplate(app, file string, params any) (*models.PodSpec, error)
 	// LoadPodTemplateWithValues loads and renders a pod template with values from application
-	LoadPodTemplateWithValues(app, file, appName string, valuesFileOverrides []string, cliOverrides map[string]string) (*models.PodSpec, error)
-	LoadValues(app string, valuesFileOverrides []string, cliOverrides map[string]string) (map[string]interface{}, error)
+	LoadPodTemplateWithValues(app, file, appName, environment string, valuesFileOverrides []string, cliOverrides map[string]string) (*models.PodSpec, error)
+	// LoadValues loads the default values of the application, overlaid by the values-<environment>.yaml of the
+	// environment if any, the values files and the CLI overrides, in that order
+	LoadValues(app, environment string, valuesFileOverrides []string, cliOverrides map[string]string) (map[string]interface{}, error)
+	// ListEnvironments lists the environments the application ships a values-<environment>.yaml overlay for
+	ListEnvironments(app string) ([]string, error)
 	// LoadMetadata loads the metadata for a given application template
 	LoadMetadata(app string) (*AppMetadata, error)
 	// LoadMdFiles loads all md files for a given application
 	LoadMdFiles(path string) (map[string]*template.Template, error)
 	// LoadVarsFile loads the var template file
 	LoadVarsFile(app string, params map[string]string) (*Vars, error)
+	// LoadExamples loads the examples shipped in the examples directory of the application, none if absent
+	LoadExamples(app string) ([]Example, error)
+}
+
+// Kinds of the examples of the applications
+const (
+	// ExampleKindGuide is a markdown guide with the end-to-end command sequences
+	ExampleKindGuide = "guide"
+	// ExampleKindValues is a sample values file, passed to 'application create --values'
+	ExampleKindValues = "values"
+)
+
+// Example is a file of the examples directory of an application, available offline with 'ai-services examples'
+type Example struct {
+	// Name is the file name, Eg:- quickstart.md
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+	// Description is the title of the guide or the first comment of the values file
+	Description string `json:"description,omitempty"`
+	Content     string `json:"content"`
 }
@@ -0,0 +1,111 @@
+// Package compliance records the results of the periodic validations of the host, so that a host drifting out of
+// its validated configuration (Eg:- SMT level reset, Spyre card detached, subscription expired) is reported
+package compliance
+
+import (
+	"time"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/state"
+)
+
+// stateName is the name of the state document holding the validation history
+const stateName = "compliance"
+
+// maxRecords caps the validation history, the oldest records are dropped first
+const maxRecords = 500
+
+// Compliance statuses of the host
+const (
+	StatusCompliant = "compliant"
+	StatusDrifted   = "drifted"
+	// StatusUnknown is reported until the host is validated
+	StatusUnknown = "unknown"
+)
+
+// Record is the result of a validation of the host
+type Record struct {
+	Time     time.Time `json:"time"`
+	Passed   int       `json:"passed"`
+	Failed   int       `json:"failed"`
+	Warnings int       `json:"warnings"`
+	// FailedChecks are the names of the failed checks
+	FailedChecks []string `json:"failedChecks,omitempty"`
+}
+
+// Compliant reports whether all the checks passed
+func (r Record) Compliant() bool {
+	return r.Failed == 0
+}
+
+// Status is the compliance status of the host
+type Status struct {
+	Status string `json:"status"`
+	// Since is the time of the first validation with the current status
+	Since *time.Time `json:"since,omitempty"`
+	// Last is the last validation of the host
+	Last *Record `json:"last,omitempty"`
+	// CompliantRatio is the ratio of the recorded validations which passed
+	CompliantRatio float64 `json:"compliantRatio"`
+	Validations    int     `json:"validations"`
+}
+
+// Add appends the record to the validation history. Returns true if the host drifted out of its validated
+// configuration, that is the previous validation passed and this one failed.
+func Add(record Record) (bool, error) {
+	if record.Time.IsZero() {
+		record.Time = time.Now().UTC()
+	}
+
+	var drifted bool
+	var records []Record
+	err := state.Default().Update(stateName, &records, func() error {
+		drifted = len(records) > 0 && records[len(records)-1].Compliant() && !record.Compliant()
+		records = append(records, record)
+		if len(records) > maxRecords {
+			records = records[len(records)-maxRecords:]
+		}
+		return nil
+	})
+	return drifted, err
+}
+
+// List returns the validation history, oldest first
+func List() ([]Record, error) {
+	var records []Record
+	if err := state.Default().Load(stateName, &records); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// Current returns the compliance status of the host from the validation history
+func Current() (*Status, error) {
+	records, err := List()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return &Status{Status: StatusUnknown}, nil
+	}
+
+	last := records[len(records)-1]
+	status := &Status{Status: StatusCompliant, Last: &last, Validations: len(records)}
+	if !last.Compliant() {
+		status.Status = StatusDrifted
+	}
+
+	// the status holds since the oldest validation of the latest run of validations with the same outcome
+	since := last.Time
+	for i := len(records) - 2; i >= 0 && records[i].Compliant() == last.Compliant(); i-- {
+		since = records[i].Time
+	}
+	compliant := 0
+	for _, r := range records {
+		if r.Compliant() {
+			compliant++
+		}
+	}
+	status.Since = &since
+	status.CompliantRatio = float64(compliant) / float64(len(records))
+	return status, nil
+}
@@ -0,0 +1,173 @@
+// Package config loads the CLI config file, holding the settings of ai-services on the host. Each feature is
+// configured in its own section of the file and validates it when loaded.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+// File is the CLI config file
+type File struct {
+	// Locale overrides the locale of the environment for the messages of the CLI, Eg:- de
+	Locale string `json:"locale,omitempty"`
+	// AllowedHostPaths are the sensitive host paths the templates are allowed to mount, along with the paths beneath
+	AllowedHostPaths []string `json:"allowedHostPaths,omitempty"`
+	// RegistryMirrors are the registry mirrors the images are pulled from
+	RegistryMirrors []RegistryMirror `json:"registryMirrors,omitempty"`
+	// ImageScan is the scanner of the container images, see the imagescan package
+	ImageScan *ImageScan `json:"imageScan,omitempty"`
+	// SecretProviders are the secret managers the template values are resolved from, see the secretrefs package
+	SecretProviders SecretProviders `json:"secretProviders"`
+	// Admission are the site policies the pods are evaluated against before they are played, see the admission
+	// package
+	Admission *Admission `json:"admission,omitempty"`
+	// LogAlerts apply to the containers of all the applications, along with the log alerts of their template
+	LogAlerts []LogAlert `json:"logAlerts,omitempty"`
+	// Firewall opens the host ports of the applications exposed externally, see the firewall package
+	Firewall Firewall `json:"firewall"`
+	// HealthAggregator serves the health of all the applications along with 'ai-services serve'
+	HealthAggregator HealthAggregator `json:"healthAggregator"`
+}
+
+// RegistryMirror declares the mirrors of a registry, tried in order before the registry itself
+type RegistryMirror struct {
+	// Registry is the registry or the repository namespace the mirrors apply to, Eg:- icr.io or icr.io/ai-services
+	Registry string `json:"registry"`
+	// Mirrors are the locations mirroring the registry, Eg:- mirror.example.com:5000/icr
+	Mirrors []string `json:"mirrors"`
+	// Insecure allows the mirrors to be reached over plain HTTP or with an unverified certificate
+	Insecure bool `json:"insecure,omitempty"`
+	// Blocked refuses the pulls from the registry itself, so that an air-gapped host never reaches it
+	Blocked bool `json:"blocked,omitempty"`
+}
+
+// ImageScan is the scanner of the container images
+type ImageScan struct {
+	// URL of the scanner API. The API receives a POST of {"image": "<reference>", "digest": "<manifest digest>"} and
+	// responds with {"vulnerabilities": [{"id": "CVE-...", "severity": "high", "package": "...", ...}]}
+	URL string `json:"url"`
+	// TokenFile holds the bearer token authenticating to the scanner API
+	TokenFile string `json:"tokenFile,omitempty"`
+	// FailOn is the lowest severity failing the scan, none by default
+	FailOn string `json:"failOn,omitempty"`
+	// OnCreate scans the images of the applications before they are deployed
+	OnCreate bool `json:"onCreate,omitempty"`
+	// Timeout of the scan of an image, defaults to 5m
+	Timeout string `json:"timeout,omitempty"`
+}
+
+// SecretProviders are the secret managers the template values are resolved from
+//
+//	secretProviders:
+//	  vault:
+//	    address: https://vault.example.com:8200
+//	    tokenFile: /etc/ai-services/vault-token
+//	  cyberark:
+//	    url: https://ccp.example.com
+//	    appID: ai-services
+type SecretProviders struct {
+	Vault    *Vault    `json:"vault,omitempty"`
+	CyberArk *CyberArk `json:"cyberark,omitempty"`
+}
+
+// Vault is the HashiCorp Vault provider, the VAULT_ADDR, VAULT_TOKEN and VAULT_NAMESPACE environment variables
+// taking precedence
+type Vault struct {
+	Address   string `json:"address,omitempty"`
+	TokenFile string `json:"tokenFile,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	CAFile    string `json:"caFile,omitempty"`
+}
+
+// CyberArk is the Central Credential Provider (CCP) of CyberArk
+type CyberArk struct {
+	URL   string `json:"url"`
+	AppID string `json:"appID"`
+	// CertFile and KeyFile authenticate the application to the CCP with a client certificate
+	CertFile string `json:"certFile,omitempty"`
+	KeyFile  string `json:"keyFile,omitempty"`
+	CAFile   string `json:"caFile,omitempty"`
+}
+
+// Admission are the site policies the pods are evaluated against
+//
+//	admission:
+//	  enforcement: deny
+//	  denyPrivileged: true
+//	  allowedRegistries:
+//	    - icr.io
+//	    - registry.example.com/ai
+//	  requireResourceLimits: true
+//	  rego:
+//	    policy: /etc/ai-services/admission.rego
+type Admission struct {
+	// Enforcement is deny (default) or warn
+	Enforcement    string `json:"enforcement,omitempty"`
+	DenyPrivileged bool   `json:"denyPrivileged,omitempty"`
+	// AllowedRegistries are the registries, or repository prefixes, the images must be pulled from
+	AllowedRegistries     []string       `json:"allowedRegistries,omitempty"`
+	RequireResourceLimits bool           `json:"requireResourceLimits,omitempty"`
+	Rego                  *AdmissionRego `json:"rego,omitempty"`
+}
+
+// AdmissionRego is an OPA rego policy, receiving the pod as input
+type AdmissionRego struct {
+	// Policy is the path of the rego file or of a directory of rego files
+	Policy string `json:"policy"`
+	// Query returns the messages of the violations, defaults to the deny rule of the aiservices.admission package
+	Query string `json:"query,omitempty"`
+}
+
+// Firewall opens the host ports of the applications exposed externally in firewalld
+type Firewall struct {
+	// Manage opens the host ports exposed externally by the applications in firewalld
+	Manage bool `json:"manage"`
+	// Zone is the firewalld zone the ports are opened in, the default zone of firewalld if empty
+	Zone string `json:"zone,omitempty"`
+	// Sources scope the opened ports to the networks, Eg:- 10.0.0.0/8. The ports are open to all if empty
+	Sources []string `json:"sources,omitempty"`
+}
+
+// HealthAggregator serves the health of all the applications, unauthenticated, on a single URL
+type HealthAggregator struct {
+	// Enabled serves the health of all the applications on a single URL along with 'ai-services serve'
+	Enabled bool `json:"enabled"`
+	// Listen is the address of the plain HTTP listener, Eg:- 127.0.0.1:8090. Defaults to :8090
+	Listen string `json:"listen,omitempty"`
+	// CacheTTL is the time the health is cached for, so that frequent probes don't load podman. Defaults to 5s
+	CacheTTL string `json:"cacheTTL,omitempty"`
+}
+
+// LogAlert raises an alert when a line of the logs of the containers matches its pattern, declared by the templates
+// and by the config file
+type LogAlert struct {
+	Name string `yaml:"name" json:"name"`
+	// Pattern is the regular expression matched against each log line, Eg:- CUDA out of memory|model load failed
+	Pattern string `yaml:"pattern" json:"pattern"`
+	// Containers restricts the alert to the containers of these names within their pod, all the containers if empty
+	Containers []string `yaml:"containers,omitempty" json:"containers,omitempty"`
+	// Cooldown is the minimal duration between two alerts of a container, defaults to 5m
+	Cooldown string `yaml:"cooldown,omitempty" json:"cooldown,omitempty"`
+}
+
+// Load returns the CLI config file, empty when the file does not exist
+func Load() (*File, error) {
+	f := &File{}
+	data, err := os.ReadFile(vars.ConfigFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return f, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", vars.ConfigFile, err)
+	}
+	if err := yaml.Unmarshal(data, f); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", vars.ConfigFile, err)
+	}
+	return f, nil
+}
@@ -4,4 +4,46 @@ const (
 	ModelAnnotationKey    = "ai-services.io/model"
 	PodStartAnnotationkey = "ai-services.io/start"
 	PodPortsAnnotationKey = "ai-services.io/ports"
+	// PodEndpointsAnnotationKey declares the endpoints served by the pod
+	PodEndpointsAnnotationKey = "ai-services.io/endpoints"
+	// PodAutoPortsAnnotationKey records the comma separated container ports published on a host port assigned by
+	// ai-services, declared with an 'auto' host port in the ports annotation
+	PodAutoPortsAnnotationKey = "ai-services.io/auto-ports"
+	// PortExposureAnnotationKey records the comma separated '<container port>=<exposure>' exposures of the ports
+	// of the pod declared by the template, Eg:- "3000=external,9090=local"
+	PortExposureAnnotationKey = "ai-services.io/exposure"
+	// CPUSetAnnotationPrefix pins a container of the pod to CPUs with kube play, followed by /<container name>
+	CPUSetAnnotationPrefix = "io.podman.annotations.cpuset/"
+	// ReadinessTimeoutAnnotationPrefix overrides the readiness timeout of a container, followed by /<container name>
+	ReadinessTimeoutAnnotationPrefix = "ai-services.io/readiness-timeout/"
+	// ReadinessProgressAnnotationPrefix is the log pattern reporting the loading progress of a container, followed by /<container name>
+	ReadinessProgressAnnotationPrefix = "ai-services.io/readiness-progress/"
+	// ConfigAnnotationPrefix declares the comma separated application config keys read by a container, followed
+	// by /<container name>. '*' for all the keys.
+	ConfigAnnotationPrefix = "ai-services.io/config/"
+	// SpyreDevicesAnnotationPrefix records the comma separated PCI addresses of the Spyre cards assigned to a
+	// container, followed by /<container name>
+	SpyreDevicesAnnotationPrefix = "ai-services.io/spyre-devices/"
+	// SecretsAnnotationPrefix records the comma separated podman secrets referenced by a container, followed by
+	// /<container name>
+	SecretsAnnotationPrefix = "ai-services.io/secrets/"
+	// DrainAnnotationPrefix declares the '<port>[/<path>]' endpoint POSTed to stop a container from accepting new
+	// requests before its pod is deleted, followed by /<container name>
+	DrainAnnotationPrefix = "ai-services.io/drain/"
+	// InflightAnnotationPrefix declares the '<port>:<metric>' Prometheus metric counting the requests in flight in a
+	// container, served at /metrics, followed by /<container name>. Eg:- '8000:vllm:num_requests_running'
+	InflightAnnotationPrefix = "ai-services.io/inflight/"
+	// DrainTimeoutAnnotationKey bounds the time to drain the pod before it is deleted, the containers being stopped
+	// with SIGTERM once drained
+	DrainTimeoutAnnotationKey = "ai-services.io/drain-timeout"
+	// JobAnnotationKey marks the pod as a job run to completion, Eg:- a vector DB index build. Its deployment waits
+	// for its containers to exit with code 0 instead of their health.
+	JobAnnotationKey = "ai-services.io/job"
+	// JobTimeoutAnnotationKey bounds the run of the job pod, Eg:- '2h'
+	JobTimeoutAnnotationKey = "ai-services.io/job-timeout"
+	// JobRetriesAnnotationKey is the number of times the failed job pod is played again, Eg:- '2'
+	JobRetriesAnnotationKey = "ai-services.io/job-retries"
+	// MaxRestartsAnnotationKey caps the restarts of each container of the pod by podman, the watcher stops the pod
+	// once a container exceeds it
+	MaxRestartsAnnotationKey = "ai-services.io/max-restarts"
 )
@@ -7,7 +7,41 @@ const (
 	PodStartOff = "off"
 )
 
+const (
+	// SharedModelVolume is the podman volume holding the models shared across applications
+	SharedModelVolume = "ai-services-models"
+	// AppConfigMountPath is where the config volume of the application is mounted, one file per config key
+	AppConfigMountPath = "/etc/ai-services/config"
+)
+
 const (
 	ValidationLevelWarning ValidationLevel = iota
 	ValidationLevelError
 )
+
+// ValidationCategory groups the validation checks in the summary
+type ValidationCategory string
+
+const (
+	ValidationCategorySystem      ValidationCategory = "system"
+	ValidationCategoryRuntime     ValidationCategory = "runtime"
+	ValidationCategoryAccelerator ValidationCategory = "accelerator"
+	ValidationCategoryLicense     ValidationCategory = "license"
+)
+
+// Minimum versions of the host platform supported by the CLI
+const (
+	MinRHELMajor     = 9
+	MinRHELMinor     = 6
+	MinRHELVersion   = "9.6"
+	MinPodmanVersion = "5.0"
+)
+
+// Range of the host ports assigned to the container ports declared with an 'auto' host port
+const (
+	AutoPortRangeStart = 30000
+	AutoPortRangeEnd   = 32767
+)
+
+// SpyreGeneration is the generation of the Spyre cards discovered by their PCI device ID 1014:06a7
+const SpyreGeneration = 1
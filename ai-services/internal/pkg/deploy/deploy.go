@@ -0,0 +1,332 @@
+// Package deploy is the deployment engine of the applications: it deploys the pod templates layer by layer with
+// kube play, waits for the containers of the pods to be ready, or to complete for the job pods, and retries the pods
+// failing on a transient error.
+// The engine is shared by the CLI, the servers and the TUI, which follow the deployment through an Observer.
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+)
+
+// EventKind is the kind of a deployment event
+type EventKind string
+
+const (
+	// EventLayerStarted is sent before the pod templates of a layer are deployed
+	EventLayerStarted EventKind = "layer-started"
+	// EventLayerDeployed is sent once the pod templates of a layer are deployed or one of them failed, with the
+	// elapsed time of the layer
+	EventLayerDeployed EventKind = "layer-deployed"
+	// EventLayerCompleted is sent once a layer is deployed and its conditions are met
+	EventLayerCompleted EventKind = "layer-completed"
+	// EventPodCreated is sent once kube play created a pod, with the elapsed time of kube play
+	EventPodCreated EventKind = "pod-created"
+	// EventContainerReady is sent once a container of a pod is ready
+	EventContainerReady EventKind = "container-ready"
+	// EventPodReady is sent once the containers of a pod are ready or one of them failed, with the elapsed time of
+	// the readiness checks
+	EventPodReady EventKind = "pod-ready"
+	// EventPodRetry is sent before a pod failing on a transient error is retried
+	EventPodRetry EventKind = "pod-retry"
+)
+
+// Event reports the progress of a deployment
+type Event struct {
+	Time time.Time
+	Kind EventKind
+	// Layer is the layer of the pod templates being deployed, starting from 1. 0 for the pods deployed outside of
+	// the layers, Eg:- the replicas of a scaled component
+	Layer       int
+	PodTemplate string
+	Pod         string
+	Container   string
+	Duration    time.Duration
+	// Err is the failure of the layer, the pod or the attempt being retried
+	Err error
+}
+
+// Observer receives the events of a deployment. The events are sent one at a time, the pods of a layer being
+// deployed concurrently.
+type Observer interface {
+	Observe(Event)
+}
+
+// ObserverFunc adapts a function to an Observer
+type ObserverFunc func(Event)
+
+func (f ObserverFunc) Observe(e Event) {
+	f(e)
+}
+
+// Options configure a Deployer
+type Options struct {
+	// Retry is the retry policy of the pods failing on a transient error
+	Retry RetryPolicy
+}
+
+// Pod is a rendered pod template to deploy
+type Pod struct {
+	// Template is the name of the pod template
+	Template string
+	Spec     *models.PodSpec
+	// Manifest is the rendered manifest, along with the objects created by kube play
+	Manifest []byte
+	// Options are the kube play options
+	Options map[string]string
+	// SpyreCards are the PCI addresses of the Spyre cards assigned to the containers, by container
+	SpyreCards map[string][]string
+}
+
+// Deployer deploys the pods of an application
+type Deployer struct {
+	runtime  runtime.Runtime
+	opts     Options
+	observer Observer
+	mu       sync.Mutex
+
+	resultMu sync.Mutex
+	result   Result
+}
+
+// New returns a Deployer notifying the observer, if any, of the progress of the deployments
+func New(rt runtime.Runtime, opts Options, observer Observer) *Deployer {
+	return &Deployer{runtime: rt, opts: opts, observer: observer}
+}
+
+func (d *Deployer) notify(e Event) {
+	if d.observer == nil {
+		return
+	}
+	e.Time = time.Now()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.observer.Observe(e)
+}
+
+// DeployFunc deploys a pod template of a layer
+type DeployFunc func(ctx context.Context, layer int, podTemplate string) error
+
+// LayerFunc runs once the pod templates of a layer are deployed, Eg:- to wait for the conditions of the layer
+type LayerFunc func(ctx context.Context, layer int) error
+
+// Run deploys the layers in order, the pod templates of a layer concurrently with deploy. A pod template listed in
+// dependsOn is only deployed once the pod templates of its layer it depends on are deployed and ready. The failure
+// of a pod template cancels the other pod templates of its layer and the later layers are not deployed. The layers
+// in flight are run to completion once ctx is cancelled. The result reports every pod template, even once the
+// deployment failed.
+func (d *Deployer) Run(ctx context.Context, layers [][]string, dependsOn map[string][]string, deploy DeployFunc, afterLayer LayerFunc) (*Result, error) {
+	err := d.run(ctx, layers, dependsOn, deploy, afterLayer)
+	return d.Result(), err
+}
+
+func (d *Deployer) run(ctx context.Context, layers [][]string, dependsOn map[string][]string, deploy DeployFunc, afterLayer LayerFunc) error {
+	for i, layer := range layers {
+		// do not start a new layer once cancelled, the layers in flight are run to completion
+		if err := ctx.Err(); err != nil {
+			d.recordPending(layers, i)
+			return err
+		}
+
+		logger.Infof("\n Executing Layer %d: %v\n", i+1, layer)
+		d.notify(Event{Kind: EventLayerStarted, Layer: i + 1})
+		logger.Infoln("-------")
+		// the pod templates of the layer are waited for concurrently, so that the layer is bounded by the readiness
+		// deadline of its slowest container. A failure of a pod template cancels the layer context, aborting the
+		// other pod templates of the layer
+		g, layerCtx := errgroup.WithContext(ctx)
+		start := time.Now()
+
+		// closed once the pod template is ready, releasing the pod templates depending on it
+		ready := make(map[string]chan struct{}, len(layer))
+		for _, podTemplate := range layer {
+			ready[podTemplate] = make(chan struct{})
+		}
+
+		for _, podTemplate := range layer {
+			g.Go(func() error {
+				err := awaitDependencies(layerCtx, podTemplate, dependsOn[podTemplate], ready)
+				if err == nil {
+					err = deploy(layerCtx, i+1, podTemplate)
+				}
+				if err == nil {
+					close(ready[podTemplate])
+				}
+				d.updatePod(i+1, podTemplate, func(p *PodResult) {
+					switch {
+					case err == nil && p.Status == "":
+						p.Status = StatusReady
+					case err != nil && p.Status != StatusFailed:
+						// Eg:- the pod failed to render, or its models failed the verification once ready
+						p.Status, p.Error = statusOf(err), err.Error()
+					}
+				})
+				return err
+			})
+		}
+
+		// If an error exist for a given layer, then return (do not process further layers)
+		err := g.Wait()
+		d.recordLayer(i+1, time.Since(start), err)
+		d.notify(Event{Kind: EventLayerDeployed, Layer: i + 1, Duration: time.Since(start), Err: err})
+		if err != nil {
+			d.recordPending(layers, i+1)
+			return fmt.Errorf("layer %d: %w", i+1, err)
+		}
+
+		if afterLayer != nil {
+			if err := afterLayer(ctx, i+1); err != nil {
+				d.recordPending(layers, i+1)
+				return fmt.Errorf("layer %d: %w", i+1, err)
+			}
+		}
+
+		logger.Infof("Layer %d completed\n", i+1, 0)
+		d.notify(Event{Kind: EventLayerCompleted, Layer: i + 1})
+	}
+
+	return nil
+}
+
+// awaitDependencies waits for the pod templates of the layer the pod template depends on to be ready. The wait ends
+// with the layer, once a pod template of the layer failed.
+func awaitDependencies(ctx context.Context, podTemplate string, deps []string, ready map[string]chan struct{}) error {
+	for _, dep := range deps {
+		ch, ok := ready[dep]
+		if !ok {
+			return fmt.Errorf("pod template %s depends on %s, which is not in its layer", podTemplate, dep)
+		}
+		select {
+		case <-ch:
+			continue
+		default:
+		}
+		logger.Infof("Pod template %s waiting for %s to be ready\n", podTemplate, dep)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ch:
+		}
+	}
+	return nil
+}
+
+// recordPending records the pod templates of the layers following the failed layer as pending
+func (d *Deployer) recordPending(layers [][]string, failed int) {
+	for i := failed; i < len(layers); i++ {
+		for _, podTemplate := range layers[i] {
+			d.updatePod(i+1, podTemplate, func(p *PodResult) { p.Status = StatusPending })
+		}
+	}
+}
+
+// DeployPod deploys the pod and checks its readiness, retrying with backoff while the deployment fails on a
+// transient error. The job pods are run to completion instead, and played again up to their retries once they
+// failed. The pod left behind by a failed attempt is removed before retrying.
+func (d *Deployer) DeployPod(ctx context.Context, layer int, pod Pod) error {
+	d.updatePod(layer, pod.Template, func(p *PodResult) {
+		p.Pod, p.SpyreCards = pod.Spec.Name, pod.SpyreCards
+	})
+	job, err := jobOf(pod.Spec)
+	if err != nil {
+		d.updatePod(layer, pod.Template, func(p *PodResult) { p.Status, p.Error = StatusFailed, err.Error() })
+		return err
+	}
+	done := StatusReady
+	if job != nil {
+		done = StatusCompleted
+	}
+
+	policy := d.opts.Retry
+	delay := policy.Backoff
+	for attempt := 0; ; attempt++ {
+		d.updatePod(layer, pod.Template, func(p *PodResult) {
+			p.Attempts, p.Containers, p.Status, p.Error = attempt+1, nil, "", ""
+		})
+		err := d.deployPodAndReadinessCheck(ctx, layer, pod, job)
+		var jobErr *jobError
+		transient := attempt < policy.Attempts && IsTransient(err)
+		jobFailed := job != nil && attempt < job.retries && errors.As(err, &jobErr)
+		if err == nil || !transient && !jobFailed || ctx.Err() != nil {
+			d.updatePod(layer, pod.Template, func(p *PodResult) {
+				p.Status = done
+				if err != nil {
+					p.Status, p.Error = statusOf(err), err.Error()
+				}
+			})
+			return err
+		}
+
+		if jobFailed {
+			logger.Warningf("Job pod %s failed, playing it again in %s (%d/%d): %v\n", pod.Spec.Name, delay, attempt+1, job.retries, err)
+			d.Warn(fmt.Sprintf("job pod %s played again once failed: %v", pod.Spec.Name, err))
+		} else {
+			logger.Warningf("Deployment of pod %s failed on a transient error, retrying in %s (%d/%d): %v\n",
+				pod.Spec.Name, delay, attempt+1, policy.Attempts, err)
+			d.Warn(fmt.Sprintf("pod %s retried on a transient error: %v", pod.Spec.Name, err))
+		}
+		d.notify(Event{Kind: EventPodRetry, Layer: layer, PodTemplate: pod.Template, Pod: pod.Spec.Name, Duration: delay, Err: err})
+		if exists, _ := d.runtime.PodExists(pod.Spec.Name); exists {
+			if err := d.runtime.DeletePod(pod.Spec.Name, utils.BoolPtr(true)); err != nil {
+				return fmt.Errorf("failed to remove pod %s before retrying: %w", pod.Spec.Name, err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+		if policy.MaxBackoff > 0 && delay > policy.MaxBackoff {
+			delay = policy.MaxBackoff
+		}
+	}
+}
+
+func (d *Deployer) deployPodAndReadinessCheck(ctx context.Context, layer int, pod Pod, job *jobSpec) error {
+	start := time.Now()
+	kubeReport, err := kubePlay(bytes.NewReader(pod.Manifest), pod.Options)
+	if err != nil {
+		return &kubePlayError{err: err}
+	}
+	d.updatePod(layer, pod.Template, func(p *PodResult) { p.KubePlaySeconds = time.Since(start).Seconds() })
+	d.notify(Event{Kind: EventPodCreated, Layer: layer, PodTemplate: pod.Template, Pod: pod.Spec.Name, Duration: time.Since(start)})
+
+	logger.Infof("Successfully ran podman kube play for %s\n", pod.Template)
+
+	readinessStart := time.Now()
+	if job != nil {
+		err = d.jobCompletionCheck(ctx, layer, pod, kubeReport, job)
+	} else {
+		err = d.podReadinessCheck(ctx, layer, pod, kubeReport)
+	}
+	d.updatePod(layer, pod.Template, func(p *PodResult) { p.ReadinessSeconds = time.Since(readinessStart).Seconds() })
+	d.notify(Event{Kind: EventPodReady, Layer: layer, PodTemplate: pod.Template, Pod: pod.Spec.Name, Duration: time.Since(readinessStart), Err: err})
+	if err != nil {
+		return err
+	}
+
+	for _, p := range kubeReport.Pods {
+		if job != nil {
+			logger.Infof("Job pod: %s has been successfully run to completion!\n", p.ID)
+			continue
+		}
+		logger.Infof("Pod: %s has been successfully deployed and ready!\n", p.ID)
+	}
+
+	logger.Infoln("-------\n-------")
+	return nil
+}
@@ -0,0 +1,152 @@
+package deploy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "github.com/containers/podman/v5/pkg/k8s.io/api/core/v1"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/helpers"
+	"github.com/project-ai-services/ai-services/internal/pkg/constants"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/podman"
+	"github.com/project-ai-services/ai-services/internal/pkg/specs"
+)
+
+// DefaultJobTimeout bounds the run of the job pods declaring no timeout
+var DefaultJobTimeout = time.Hour
+
+// jobSpec is the job declared by the annotations of a pod:
+//
+//	'ai-services.io/job': "true" runs the pod to completion
+//	'ai-services.io/job-timeout': "2h" bounds the run of the pod, defaults to DefaultJobTimeout
+//	'ai-services.io/job-retries': "2" plays the failed pod again, with the backoff of the retry policy
+type jobSpec struct {
+	timeout time.Duration
+	retries int
+}
+
+// jobOf returns the job declared by the annotations of the pod, nil if the pod is not a job
+func jobOf(podSpec *models.PodSpec) (*jobSpec, error) {
+	annotations := specs.FetchPodAnnotations(*podSpec)
+	val, ok := annotations[constants.JobAnnotationKey]
+	if !ok {
+		return nil, nil
+	}
+	isJob, err := strconv.ParseBool(val)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s annotation '%s', expected true or false", constants.JobAnnotationKey, val)
+	}
+	if !isJob {
+		return nil, nil
+	}
+	// the containers restarted once exited would never complete
+	if policy := podSpec.Spec.RestartPolicy; policy != v1.RestartPolicyOnFailure && policy != v1.RestartPolicyNever {
+		return nil, fmt.Errorf("job pod %s must have the restartPolicy OnFailure or Never", podSpec.Name)
+	}
+
+	job := &jobSpec{timeout: DefaultJobTimeout}
+	if val, ok := annotations[constants.JobTimeoutAnnotationKey]; ok {
+		if job.timeout, err = time.ParseDuration(val); err != nil || job.timeout <= 0 {
+			return nil, fmt.Errorf("invalid %s annotation '%s'", constants.JobTimeoutAnnotationKey, val)
+		}
+	}
+	if val, ok := annotations[constants.JobRetriesAnnotationKey]; ok {
+		if job.retries, err = strconv.Atoi(val); err != nil || job.retries < 0 {
+			return nil, fmt.Errorf("invalid %s annotation '%s'", constants.JobRetriesAnnotationKey, val)
+		}
+	}
+	return job, nil
+}
+
+// jobError is the failed run of a job pod, played again up to the retries of the job
+type jobError struct {
+	err error
+}
+
+func (e *jobError) Error() string {
+	return "job failed: " + e.err.Error()
+}
+
+func (e *jobError) Unwrap() error {
+	return e.err
+}
+
+// jobCompletionCheck waits for the containers of the job pods to exit with code 0, concurrently. Once a container
+// fails, the checks of the other containers are cancelled. The pods not started by kube play are not waited for.
+func (d *Deployer) jobCompletionCheck(ctx context.Context, layer int, pod Pod, kubeReport *podman.KubePlayOutput, job *jobSpec) error {
+	if specs.FetchPodAnnotations(*pod.Spec)[constants.PodStartAnnotationkey] == constants.PodStartOff {
+		logger.Infof("Job pod %s is not started, skipping its completion check\n", pod.Spec.Name)
+		return nil
+	}
+
+	doneCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var errs []error
+	for _, p := range kubeReport.Pods {
+		logger.Infof("Waiting for the completion of job pod...: %s\n", p.ID)
+		for _, containerID := range p.Containers {
+			info, err := d.runtime.InspectContainer(containerID.ID)
+			if err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("failed to inspect container: %w", err))
+				mu.Unlock()
+				cancel()
+				continue
+			}
+			if info.IsInfra {
+				continue
+			}
+			// kube play names the containers <pod>-<container>
+			name := strings.TrimPrefix(info.Name, pod.Spec.Name+"-")
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				err := helpers.WaitForContainerCompletion(doneCtx, d.runtime, containerID.ID, helpers.CompletionOptions{
+					Name: name, Timeout: job.timeout, MaxRestarts: maxContainerRestarts,
+				})
+				d.recordCompletion(layer, pod.Template, name, err)
+				if err == nil {
+					logger.Infof("Container: %s completed\n", name)
+					return
+				}
+				// skip the checks cancelled due to the failure of another container
+				if errors.Is(err, context.Canceled) && doneCtx.Err() != nil && ctx.Err() == nil {
+					return
+				}
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+				cancel()
+			}()
+		}
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		if ctx.Err() != nil {
+			return err
+		}
+		return &jobError{err: err}
+	}
+	return nil
+}
+
+// recordCompletion records the completion of a container of the job pod
+func (d *Deployer) recordCompletion(layer int, podTemplate, name string, err error) {
+	res := ContainerResult{Name: name, Status: StatusCompleted}
+	if err != nil {
+		res.Status, res.Error = statusOf(err), err.Error()
+	}
+	d.updatePod(layer, podTemplate, func(p *PodResult) { p.Containers = append(p.Containers, res) })
+}
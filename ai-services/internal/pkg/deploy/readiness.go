@@ -0,0 +1,148 @@
+package deploy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/helpers"
+	"github.com/project-ai-services/ai-services/internal/pkg/constants"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/podman"
+	"github.com/project-ai-services/ai-services/internal/pkg/specs"
+)
+
+var (
+	// ExtraContainerReadinessTimeout is added to the start period of the health check of a container to wait for
+	// its readiness, and is the time a container may report no loading progress
+	ExtraContainerReadinessTimeout = 5 * time.Minute
+	// maxContainerRestarts is the number of restarts after which a container being deployed is crash-looping
+	maxContainerRestarts = 3
+	// kubePlay creates the pods of the manifest
+	kubePlay = podman.RunPodmanKubePlay
+)
+
+// podReadinessCheck checks the containers of all the pods concurrently, so that the readiness of the pod takes as
+// long as its slowest container. Once a container fails, the readiness checks of the other containers are cancelled.
+func (d *Deployer) podReadinessCheck(ctx context.Context, layer int, pod Pod, kubeReport *podman.KubePlayOutput) error {
+	podAnnotations := specs.FetchPodAnnotations(*pod.Spec)
+
+	readyCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var errs []error
+	for _, p := range kubeReport.Pods {
+		logger.Infof("Performing Pod Readiness check...: %s\n", p.ID)
+		for _, containerID := range p.Containers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				name, err := containerReadinessCheck(readyCtx, d.runtime, pod.Spec.Name, containerID.ID, podAnnotations)
+				d.recordContainer(layer, pod.Template, name, err)
+				if err == nil {
+					d.notify(Event{Kind: EventContainerReady, Layer: layer, PodTemplate: pod.Template, Pod: pod.Spec.Name, Container: name})
+					return
+				}
+				// skip the checks cancelled due to the failure of another container
+				if errors.Is(err, context.Canceled) && readyCtx.Err() != nil && ctx.Err() == nil {
+					return
+				}
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+				cancel()
+			}()
+		}
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// containerReadinessCheck waits for the container to be ready, if it has a health check. Returns the name of the
+// container within its pod.
+func containerReadinessCheck(ctx context.Context, rt runtime.Runtime, podName, containerID string, podAnnotations map[string]string) (string, error) {
+	logger.Infof("Doing Container Readiness check...: %s\n", containerID)
+
+	// getting the Start Period set for a container
+	startPeriod, err := helpers.FetchContainerStartPeriod(rt, containerID)
+	if err != nil {
+		return containerID, fmt.Errorf("fetching container start period failed: %w", err)
+	}
+
+	if startPeriod == -1 {
+		logger.Infof("No container health check is set for %s. Hence skipping readiness check\n", containerID)
+		return containerID, nil
+	}
+
+	readinessOpts, err := readinessOptions(rt, podName, containerID, podAnnotations, startPeriod)
+	if err != nil {
+		return containerID, err
+	}
+
+	logger.Infof("Setting the Waiting Readiness Timeout of %s: %s\n", readinessOpts.Name, readinessOpts.Timeout)
+
+	if err := helpers.WatchContainerReadiness(ctx, rt, containerID, readinessOpts); err != nil {
+		return readinessOpts.Name, fmt.Errorf("readiness check failed!: %w", err)
+	}
+	logger.Infof("Container: %s is ready\n", readinessOpts.Name)
+	return readinessOpts.Name, nil
+}
+
+// readinessOptions configures the readiness watchdog of the container from the pod annotations:
+//
+//	'ai-services.io/readiness-timeout/<container>': "45m" overrides the readiness timeout, which defaults to the
+//	start period of the health check with an additional extra timeout
+//	'ai-services.io/readiness-progress/<container>': "<regex>" matches the log lines reporting the loading progress,
+//	the container is waited for past its timeout as long as it keeps reporting progress
+func readinessOptions(rt runtime.Runtime, podName, containerID string, podAnnotations map[string]string, startPeriod time.Duration) (helpers.ReadinessOptions, error) {
+	opts := helpers.ReadinessOptions{
+		Name: containerID,
+		// configure readiness timeout by appending start period with additional extra timeout
+		Timeout:      startPeriod + ExtraContainerReadinessTimeout,
+		StallTimeout: ExtraContainerReadinessTimeout,
+		MaxRestarts:  maxContainerRestarts,
+	}
+
+	info, err := rt.InspectContainer(containerID)
+	if err != nil {
+		return opts, fmt.Errorf("failed to inspect container: %w", err)
+	}
+	// kube play names the containers <pod>-<container>
+	containerName := strings.TrimPrefix(info.Name, podName+"-")
+	opts.Name = containerName
+
+	if val, ok := podAnnotations[constants.ReadinessTimeoutAnnotationPrefix+containerName]; ok {
+		timeout, err := time.ParseDuration(val)
+		if err != nil || timeout <= 0 {
+			return opts, fmt.Errorf("invalid readiness timeout '%s' for container %s", val, containerName)
+		}
+		opts.Timeout = timeout
+	}
+
+	if val, ok := podAnnotations[constants.ReadinessProgressAnnotationPrefix+containerName]; ok {
+		progress, err := regexp.Compile(val)
+		if err != nil {
+			return opts, fmt.Errorf("invalid readiness progress pattern for container %s: %w", containerName, err)
+		}
+		opts.Progress = progress
+	}
+
+	return opts, nil
+}
+
+// recordContainer records the readiness of a container of the pod
+func (d *Deployer) recordContainer(layer int, podTemplate, name string, err error) {
+	res := ContainerResult{Name: name, Status: StatusReady}
+	if err != nil {
+		res.Status, res.Error = statusOf(err), err.Error()
+	}
+	d.updatePod(layer, podTemplate, func(p *PodResult) { p.Containers = append(p.Containers, res) })
+}
@@ -0,0 +1,126 @@
+package deploy
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+)
+
+// Statuses of the layers, pods and containers of a deployment
+const (
+	StatusReady = "ready"
+	// StatusCompleted is the status of the job pods and their containers once run to completion
+	StatusCompleted = "completed"
+	StatusFailed    = "failed"
+	StatusSkipped   = "skipped"
+	StatusCancelled = "cancelled"
+	// StatusPending is the status of the pod templates of the layers not deployed due to a failure
+	StatusPending = "pending"
+)
+
+// ContainerResult is the readiness of a container
+type ContainerResult struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// PodResult is the outcome of the deployment of a pod template
+type PodResult struct {
+	Layer       int    `json:"layer,omitempty"`
+	PodTemplate string `json:"podTemplate"`
+	Pod         string `json:"pod,omitempty"`
+	Status      string `json:"status"`
+	// Attempts is the number of kube play attempts, more than 1 once retried on a transient error
+	Attempts   int               `json:"attempts,omitempty"`
+	Containers []ContainerResult `json:"containers,omitempty"`
+	// SpyreCards are the PCI addresses of the Spyre cards assigned to the containers, by container
+	SpyreCards       map[string][]string `json:"spyreCards,omitempty"`
+	KubePlaySeconds  float64             `json:"kubePlaySeconds,omitempty"`
+	ReadinessSeconds float64             `json:"readinessSeconds,omitempty"`
+	Error            string              `json:"error,omitempty"`
+}
+
+// LayerResult is the outcome of the deployment of a layer
+type LayerResult struct {
+	Layer           int     `json:"layer"`
+	Status          string  `json:"status"`
+	DurationSeconds float64 `json:"durationSeconds"`
+	Error           string  `json:"error,omitempty"`
+}
+
+// Result is the outcome of the deployments of a Deployer, recorded even once the deployment failed so that the
+// pods deployed, failed and not deployed are all reported
+type Result struct {
+	Layers   []LayerResult `json:"layers,omitempty"`
+	Pods     []PodResult   `json:"pods,omitempty"`
+	Warnings []string      `json:"warnings,omitempty"`
+}
+
+// Failed returns the pods which failed to deploy
+func (r *Result) Failed() []PodResult {
+	return slices.DeleteFunc(slices.Clone(r.Pods), func(p PodResult) bool { return p.Status != StatusFailed })
+}
+
+// Result returns a snapshot of the outcome of the deployments
+func (d *Deployer) Result() *Result {
+	d.resultMu.Lock()
+	defer d.resultMu.Unlock()
+
+	res := &Result{
+		Layers:   slices.Clone(d.result.Layers),
+		Warnings: slices.Clone(d.result.Warnings),
+	}
+	for _, pod := range d.result.Pods {
+		pod.Containers = slices.Clone(pod.Containers)
+		res.Pods = append(res.Pods, pod)
+	}
+	return res
+}
+
+// Warn records a warning of the deployment
+func (d *Deployer) Warn(msg string) {
+	d.resultMu.Lock()
+	defer d.resultMu.Unlock()
+	d.result.Warnings = append(d.result.Warnings, msg)
+}
+
+// Skip records a pod template which is not deployed, Eg:- as its pod already exists
+func (d *Deployer) Skip(layer int, podTemplate, pod string) {
+	d.updatePod(layer, podTemplate, func(p *PodResult) {
+		p.Pod, p.Status = pod, StatusSkipped
+	})
+}
+
+// updatePod updates the result of the pod template, recorded on its first update
+func (d *Deployer) updatePod(layer int, podTemplate string, fn func(*PodResult)) {
+	d.resultMu.Lock()
+	defer d.resultMu.Unlock()
+	for i := range d.result.Pods {
+		if p := &d.result.Pods[i]; p.Layer == layer && p.PodTemplate == podTemplate {
+			fn(p)
+			return
+		}
+	}
+	d.result.Pods = append(d.result.Pods, PodResult{Layer: layer, PodTemplate: podTemplate})
+	fn(&d.result.Pods[len(d.result.Pods)-1])
+}
+
+func (d *Deployer) recordLayer(layer int, duration time.Duration, err error) {
+	res := LayerResult{Layer: layer, Status: StatusReady, DurationSeconds: duration.Seconds()}
+	if err != nil {
+		res.Status, res.Error = statusOf(err), err.Error()
+	}
+	d.resultMu.Lock()
+	defer d.resultMu.Unlock()
+	d.result.Layers = append(d.result.Layers, res)
+}
+
+// statusOf returns the failed status, or cancelled for the deployments aborted by a cancellation
+func statusOf(err error) string {
+	if errors.Is(err, context.Canceled) {
+		return StatusCancelled
+	}
+	return StatusFailed
+}
@@ -0,0 +1,66 @@
+package deploy
+
+import (
+	"errors"
+	"net"
+	"slices"
+	"strings"
+	"time"
+)
+
+// RetryPolicy retries the deployment of the pods failing on a transient error
+type RetryPolicy struct {
+	// Attempts is the number of retries, 0 disables them
+	Attempts int `json:"attempts"`
+	// Backoff is the delay before the first retry, doubled at each retry
+	Backoff time.Duration `json:"backoff,omitempty"`
+	// MaxBackoff caps the delay between the retries, defaults to 2m
+	MaxBackoff time.Duration `json:"maxBackoff,omitempty"`
+}
+
+// DefaultRetryPolicy applies to the templates declaring no retry policy
+var DefaultRetryPolicy = RetryPolicy{Attempts: 2, Backoff: 10 * time.Second, MaxBackoff: 2 * time.Minute}
+
+// transientErrors are the fragments of the kube play errors worth retrying: the image pulls and the podman socket
+// failing on the network rather than on the manifest
+var transientErrors = []string{
+	"timeout",
+	"timed out",
+	"connection reset",
+	"connection refused",
+	"broken pipe",
+	"unexpected eof",
+	"temporary failure",
+	"too many requests",
+	"service unavailable",
+	"bad gateway",
+	"no route to host",
+}
+
+// kubePlayError is the failure of kube play to create a pod, as opposed to the pod failing to get ready
+type kubePlayError struct {
+	err error
+}
+
+func (e *kubePlayError) Error() string {
+	return "failed pod creation: " + e.err.Error()
+}
+
+func (e *kubePlayError) Unwrap() error {
+	return e.err
+}
+
+// IsTransient reports whether the deployment of a pod failed on a transient error. Only the kube play failures are
+// classified, a pod failing to get ready is not retried.
+func IsTransient(err error) bool {
+	var playErr *kubePlayError
+	if !errors.As(err, &playErr) {
+		return false
+	}
+	var netErr net.Error
+	if errors.As(playErr.err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	msg := strings.ToLower(playErr.err.Error())
+	return slices.ContainsFunc(transientErrors, func(fragment string) bool { return strings.Contains(msg, fragment) })
+}
@@ -0,0 +1,95 @@
+// Package faults injects simulated failures into ai-services, to validate that healing, alerts and rollback behave
+// correctly before a production rollout. The faults are stored in the state with an expiry, so that a forgotten
+// fault does not linger
+package faults
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/state"
+)
+
+// stateName is the name of the state document holding the injected faults
+const stateName = "faults"
+
+// loaded reads the active faults once per command, so that the production paths checking for a fault do not read
+// the state on every call. The faults injected once the command started apply to the next commands
+var loaded = sync.OnceValues(List)
+
+// Kinds of the injected faults
+const (
+	// KindSpyreUnavailable reports Count free Spyre cards as in use
+	KindSpyreUnavailable = "spyre-unavailable"
+	// KindReadinessDelay delays the readiness checks of the containers by Delay
+	KindReadinessDelay = "readiness-delay"
+)
+
+// Fault is a simulated failure, active until it expires
+type Fault struct {
+	Kind    string        `json:"kind"`
+	Count   int           `json:"count,omitempty"`
+	Delay   time.Duration `json:"delay,omitempty"`
+	Expires time.Time     `json:"expires"`
+}
+
+func (f Fault) active() bool {
+	return time.Now().Before(f.Expires)
+}
+
+// Inject activates the fault, replacing the fault of the same kind. The expired faults are dropped
+func Inject(fault Fault) error {
+	var faults []Fault
+	return state.Default().Update(stateName, &faults, func() error {
+		faults = slices.DeleteFunc(faults, func(f Fault) bool { return f.Kind == fault.Kind || !f.active() })
+		faults = append(faults, fault)
+		return nil
+	})
+}
+
+// Clear deactivates the fault of the kind, all the faults if kind is empty
+func Clear(kind string) error {
+	var faults []Fault
+	return state.Default().Update(stateName, &faults, func() error {
+		faults = slices.DeleteFunc(faults, func(f Fault) bool { return kind == "" || f.Kind == kind })
+		return nil
+	})
+}
+
+// List returns the active faults
+func List() ([]Fault, error) {
+	var faults []Fault
+	if err := state.Default().Load(stateName, &faults); err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(faults, func(f Fault) bool { return !f.active() }), nil
+}
+
+// Active returns the active fault of the kind, nil if none. Failing to read the faults never fails the caller
+func Active(kind string) *Fault {
+	faults, err := loaded()
+	if err != nil {
+		logger.Infof("Unable to read the injected faults: %v\n", err, 2)
+		return nil
+	}
+	for _, f := range faults {
+		if f.Kind == kind && f.active() {
+			logger.Warningf("Injected fault %s is active until %s\n", f.Kind, f.Expires.Local().Format(time.DateTime))
+			return &f
+		}
+	}
+	return nil
+}
+
+func (f Fault) String() string {
+	switch f.Kind {
+	case KindSpyreUnavailable:
+		return fmt.Sprintf("%s (%d cards)", f.Kind, f.Count)
+	case KindReadinessDelay:
+		return fmt.Sprintf("%s (%s)", f.Kind, f.Delay)
+	}
+	return f.Kind
+}
@@ -0,0 +1,337 @@
+// Package firewall opens the host ports of the applications exposed externally in firewalld, and closes them once
+// the applications are deleted. The ports of each application are tagged as the firewalld service
+// ai-services-<application>, added to the zone or scoped to the source networks of the config. Only the services of
+// ai-services are ever changed.
+package firewall
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/config"
+	"github.com/project-ai-services/ai-services/internal/pkg/state"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+// stateName is the name of the state document holding the zones of the services of the applications
+const stateName = "firewall"
+
+// servicePrefix prefixes the firewalld services of the applications
+const servicePrefix = "ai-services-"
+
+// commandTimeout bounds a single firewall-cmd invocation
+const commandTimeout = 30 * time.Second
+
+// Config is the 'firewall' section of the CLI config file
+type Config = config.Firewall
+
+// Load returns the 'firewall' section of the CLI config file
+func Load() (Config, error) {
+	c, err := config.Load()
+	if err != nil {
+		return Config{}, err
+	}
+	for _, src := range c.Firewall.Sources {
+		if src == "" || strings.ContainsAny(src, "\" ") {
+			return Config{}, fmt.Errorf("invalid firewall source '%s' in %s", src, vars.ConfigFile)
+		}
+	}
+	return c.Firewall, nil
+}
+
+// Active returns true if firewalld is running
+func Active() bool {
+	_, err := firewallCmd("--state")
+	return err == nil
+}
+
+// ServiceName returns the firewalld service tagging the ports of the application
+func ServiceName(appName string) string {
+	return servicePrefix + appName
+}
+
+// Change is a service of an application changed by Sync
+type Change struct {
+	Application string `json:"application"`
+	Service     string `json:"service"`
+	// Ports are the ports of the service, Eg:- 8000/tcp. Empty once the service is removed
+	Ports []string `json:"ports,omitempty"`
+}
+
+// Sync opens the TCP ports of the applications in the zone, as the service of each application, and removes the
+// services of the applications which publish no port anymore, Eg:- once deleted. The permanent configuration is
+// changed and firewalld is reloaded. Returns the services updated and removed.
+func Sync(cfg Config, ports map[string][]int) ([]Change, []Change, error) {
+	var updated, removed []Change
+	zones := map[string]string{}
+	err := state.Default().Update(stateName, &zones, func() error {
+		zone := cfg.Zone
+		if zone == "" {
+			var err error
+			if zone, err = firewallCmd("--get-default-zone"); err != nil {
+				return err
+			}
+		}
+		richRules, err := listRichRules(zone)
+		if err != nil {
+			return err
+		}
+
+		for app, previous := range zones {
+			if len(ports[app]) > 0 && previous == zone {
+				continue
+			}
+			svc := ServiceName(app)
+			if err := detachService(previous, svc); err != nil {
+				return err
+			}
+			if len(ports[app]) > 0 {
+				continue
+			}
+			if _, err := firewallCmd("--permanent", "--delete-service="+svc); err != nil {
+				return err
+			}
+			delete(zones, app)
+			removed = append(removed, Change{Application: app, Service: svc})
+		}
+
+		for _, app := range sortedKeys(ports) {
+			if len(ports[app]) == 0 {
+				continue
+			}
+			svc := ServiceName(app)
+			changed, err := syncService(app, svc, tcpPorts(ports[app]))
+			if err != nil {
+				return err
+			}
+			attached, err := attachService(zone, svc, cfg.Sources, richRules)
+			if err != nil {
+				return err
+			}
+			zones[app] = zone
+			if changed || attached {
+				updated = append(updated, Change{Application: app, Service: svc, Ports: tcpPorts(ports[app])})
+			}
+		}
+
+		if len(updated) == 0 && len(removed) == 0 {
+			return nil
+		}
+		_, err = firewallCmd("--reload")
+		return err
+	})
+	return updated, removed, err
+}
+
+// Blocked returns the ports which are open neither in the zone, the default zone if empty, nor by a service of the
+// zone, Eg:- the published ports of the applications unreachable from the network
+func Blocked(zone string, ports []int) ([]int, error) {
+	if zone == "" {
+		var err error
+		if zone, err = firewallCmd("--get-default-zone"); err != nil {
+			return nil, err
+		}
+	}
+	open := map[string]bool{}
+	out, err := firewallCmd("--zone="+zone, "--list-ports")
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range strings.Fields(out) {
+		open[p] = true
+	}
+
+	out, err = firewallCmd("--zone="+zone, "--list-services")
+	if err != nil {
+		return nil, err
+	}
+	services := strings.Fields(out)
+	rules, err := listRichRules(zone)
+	if err != nil {
+		return nil, err
+	}
+	// the ports scoped to source networks are open to those
+	for _, rule := range rules {
+		if _, rest, ok := strings.Cut(rule, `service name="`); ok {
+			if name, _, ok := strings.Cut(rest, `"`); ok {
+				services = append(services, name)
+			}
+		}
+	}
+	for _, svc := range services {
+		out, err := firewallCmd("--permanent", "--service="+svc, "--get-ports")
+		if err != nil {
+			continue
+		}
+		for _, p := range strings.Fields(out) {
+			open[p] = true
+		}
+	}
+
+	var blocked []int
+	for _, port := range ports {
+		if !open[strconv.Itoa(port)+"/tcp"] {
+			blocked = append(blocked, port)
+		}
+	}
+	slices.Sort(blocked)
+	return slices.Compact(blocked), nil
+}
+
+// syncService creates the service of the application if needed, and sets its ports. Returns true if changed.
+func syncService(app, svc string, ports []string) (bool, error) {
+	changed := false
+	if _, err := firewallCmd("--permanent", "--info-service="+svc); err != nil {
+		if _, err := firewallCmd("--permanent", "--new-service="+svc); err != nil {
+			return false, err
+		}
+		if _, err := firewallCmd("--permanent", "--service="+svc, "--set-description=Ports of the application "+app+", managed by ai-services"); err != nil {
+			return false, err
+		}
+		changed = true
+	}
+
+	out, err := firewallCmd("--permanent", "--service="+svc, "--get-ports")
+	if err != nil {
+		return false, err
+	}
+	current := strings.Fields(out)
+	for _, p := range current {
+		if !slices.Contains(ports, p) {
+			if _, err := firewallCmd("--permanent", "--service="+svc, "--remove-port="+p); err != nil {
+				return false, err
+			}
+			changed = true
+		}
+	}
+	for _, p := range ports {
+		if !slices.Contains(current, p) {
+			if _, err := firewallCmd("--permanent", "--service="+svc, "--add-port="+p); err != nil {
+				return false, err
+			}
+			changed = true
+		}
+	}
+	return changed, nil
+}
+
+// attachService adds the service to the zone, open to all or through a rich rule per source network. The rich
+// rules of the service no longer matching the sources are removed. Returns true if changed.
+func attachService(zone, svc string, sources []string, richRules []string) (bool, error) {
+	changed := false
+	var desired []string
+	for _, src := range sources {
+		desired = append(desired, richRule(src, svc))
+	}
+	for _, rule := range richRules {
+		if strings.Contains(rule, `service name="`+svc+`"`) && !slices.Contains(desired, rule) {
+			if _, err := firewallCmd("--permanent", "--zone="+zone, "--remove-rich-rule="+rule); err != nil {
+				return false, err
+			}
+			changed = true
+		}
+	}
+	for _, rule := range desired {
+		if !slices.Contains(richRules, rule) {
+			if _, err := firewallCmd("--permanent", "--zone="+zone, "--add-rich-rule="+rule); err != nil {
+				return false, err
+			}
+			changed = true
+		}
+	}
+
+	_, err := firewallCmd("--permanent", "--zone="+zone, "--query-service="+svc)
+	open := err == nil
+	switch {
+	case len(sources) == 0 && !open:
+		if _, err := firewallCmd("--permanent", "--zone="+zone, "--add-service="+svc); err != nil {
+			return false, err
+		}
+		changed = true
+	case len(sources) > 0 && open:
+		// scoped to the sources only
+		if _, err := firewallCmd("--permanent", "--zone="+zone, "--remove-service="+svc); err != nil {
+			return false, err
+		}
+		changed = true
+	}
+	return changed, nil
+}
+
+// detachService removes the service and its rich rules from the zone
+func detachService(zone, svc string) error {
+	richRules, err := listRichRules(zone)
+	if err != nil {
+		return err
+	}
+	for _, rule := range richRules {
+		if strings.Contains(rule, `service name="`+svc+`"`) {
+			if _, err := firewallCmd("--permanent", "--zone="+zone, "--remove-rich-rule="+rule); err != nil {
+				return err
+			}
+		}
+	}
+	if _, err := firewallCmd("--permanent", "--zone="+zone, "--query-service="+svc); err == nil {
+		if _, err := firewallCmd("--permanent", "--zone="+zone, "--remove-service="+svc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// richRule returns the rich rule opening the service to the source network, as listed by firewalld
+func richRule(source, svc string) string {
+	family := "ipv4"
+	if strings.Contains(source, ":") {
+		family = "ipv6"
+	}
+	return fmt.Sprintf(`rule family="%s" source address="%s" service name="%s" accept`, family, source, svc)
+}
+
+func listRichRules(zone string) ([]string, error) {
+	out, err := firewallCmd("--permanent", "--zone="+zone, "--list-rich-rules")
+	if err != nil {
+		return nil, err
+	}
+	var rules []string
+	for line := range strings.SplitSeq(out, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			rules = append(rules, line)
+		}
+	}
+	return rules, nil
+}
+
+func tcpPorts(ports []int) []string {
+	var out []string
+	for _, port := range ports {
+		out = append(out, strconv.Itoa(port)+"/tcp")
+	}
+	slices.Sort(out)
+	return slices.Compact(out)
+}
+
+func sortedKeys(m map[string][]int) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func firewallCmd(args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, "firewall-cmd", args...).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("firewall-cmd %s failed: %v, output: %s", strings.Join(args, " "), err, strings.TrimSpace(string(out)))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
@@ -0,0 +1,358 @@
+package gateway
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/project-ai-services/ai-services/assets"
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/helpers"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime"
+	"github.com/project-ai-services/ai-services/internal/pkg/state"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+const (
+	// PodName is the name of the managed gateway pod
+	PodName      = "ai-services--gateway"
+	DefaultImage = "docker.io/library/nginx:stable"
+
+	// stateName is the name of the state document holding the gateway configuration
+	stateName = "gateway"
+
+	// containerName is the name of the nginx container of the gateway pod
+	containerName = PodName + "-nginx"
+	// candidateConf is the name of the rendered configuration tested by nginx before it replaces nginx.conf
+	candidateConf = "nginx.conf.new"
+)
+
+var (
+	// hostnameRegex matches the RFC 1123 hostnames
+	hostnameRegex = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(\.[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$`)
+	// pathRegex matches the path prefixes safe to render unquoted into a location of nginx
+	pathRegex = regexp.MustCompile(`^/[a-zA-Z0-9._~%@:+,=/-]*$`)
+)
+
+// Route routes the requests matching the host and path to an application endpoint
+type Route struct {
+	// Host is the hostname to match, empty matches any hostname
+	Host        string `json:"host,omitempty"`
+	Path        string `json:"path"`
+	Application string `json:"application"`
+	Endpoint    string `json:"endpoint"`
+}
+
+// Config is the gateway configuration persisted in the state store
+type Config struct {
+	Enabled   bool   `json:"enabled"`
+	Image     string `json:"image"`
+	HTTPPort  int    `json:"httpPort"`
+	HTTPSPort int    `json:"httpsPort"`
+	// TLS is set when the certificate and key are present in the gateway directory
+	TLS bool `json:"tls"`
+	// Auth is set when the htpasswd file is present in the gateway directory
+	Auth   bool    `json:"auth"`
+	Routes []Route `json:"routes,omitempty"`
+}
+
+// Load reads the gateway configuration, defaults are returned if the gateway was never enabled
+func Load() (*Config, error) {
+	cfg := &Config{Image: DefaultImage, HTTPPort: 80, HTTPSPort: 443}
+	if err := state.Default().Load(stateName, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Update loads the gateway configuration, invokes fn to mutate it and saves it back
+func Update(fn func(cfg *Config) error) (*Config, error) {
+	cfg := &Config{Image: DefaultImage, HTTPPort: 80, HTTPSPort: 443}
+	if err := state.Default().Update(stateName, cfg, func() error { return fn(cfg) }); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// ValidateRoute checks the hostname and path of the route, which are rendered into the nginx configuration
+func ValidateRoute(route Route) error {
+	if route.Host != "" && (len(route.Host) > 253 || !hostnameRegex.MatchString(route.Host)) {
+		return fmt.Errorf("invalid host '%s', it must be a valid RFC 1123 hostname", route.Host)
+	}
+	if !pathRegex.MatchString(route.Path) {
+		return fmt.Errorf("invalid path '%s', it must start with '/' and contain only letters, digits and the characters %s", route.Path, "._~%@:+,=/-")
+	}
+	return nil
+}
+
+// InstallFile copies the file into the gateway directory with the given name (Eg:- tls.crt, htpasswd)
+func InstallFile(src, name string) error {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", src, err)
+	}
+	if err := os.MkdirAll(vars.GatewayDirectory, 0o755); err != nil {
+		return fmt.Errorf("failed to create gateway directory: %w", err)
+	}
+	// the files are read by the nginx workers running as non root user within the container
+	return os.WriteFile(filepath.Join(vars.GatewayDirectory, name), data, 0o644)
+}
+
+// Deploy renders the gateway configuration and (re)creates the gateway pod
+func Deploy(runtime runtime.Runtime, cfg *Config) error {
+	if err := writeNginxConf(runtime, cfg); err != nil {
+		return err
+	}
+
+	if err := Remove(runtime); err != nil {
+		return err
+	}
+
+	if err := runtime.PullImage(cfg.Image, nil); err != nil {
+		return err
+	}
+
+	podYAML, err := render("gateway/gateway.yaml.tmpl", map[string]any{
+		"PodName":   PodName,
+		"ConfigDir": vars.GatewayDirectory,
+		"Image":     cfg.Image,
+		"HTTPPort":  cfg.HTTPPort,
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err := runtime.CreatePod(bytes.NewReader(podYAML)); err != nil {
+		return fmt.Errorf("failed to create gateway pod: %w", err)
+	}
+
+	return nil
+}
+
+// Reload re-renders the gateway configuration with the current endpoints and restarts the gateway pod
+func Reload(runtime runtime.Runtime, cfg *Config) error {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	if err := writeNginxConf(runtime, cfg); err != nil {
+		return err
+	}
+
+	exists, err := runtime.PodExists(PodName)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return Deploy(runtime, cfg)
+	}
+
+	if err := runtime.StopPod(PodName); err != nil {
+		return err
+	}
+	return runtime.StartPod(PodName)
+}
+
+// Remove deletes the gateway pod if it exists
+func Remove(runtime runtime.Runtime) error {
+	exists, err := runtime.PodExists(PodName)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return nil
+	}
+	return runtime.DeletePod(PodName, utils.BoolPtr(true))
+}
+
+type server struct {
+	Host      string
+	Default   bool
+	Locations []location
+}
+
+type location struct {
+	Path        string
+	Backend     string
+	Application string
+	Endpoint    string
+}
+
+// upstream balances the requests of a route across the replicas of the endpoint
+type upstream struct {
+	Name    string
+	Servers []string
+}
+
+func writeNginxConf(runtime runtime.Runtime, cfg *Config) error {
+	servers, upstreams, err := buildServers(runtime, cfg.Routes)
+	if err != nil {
+		return err
+	}
+
+	conf, err := render("gateway/nginx.conf.tmpl", map[string]any{
+		"HTTPPort":  cfg.HTTPPort,
+		"HTTPSPort": cfg.HTTPSPort,
+		"TLS":       cfg.TLS,
+		"Auth":      cfg.Auth,
+		"Servers":   servers,
+		"Upstreams": upstreams,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(vars.GatewayDirectory, 0o755); err != nil {
+		return fmt.Errorf("failed to create gateway directory: %w", err)
+	}
+
+	candidate := filepath.Join(vars.GatewayDirectory, candidateConf)
+	if err := os.WriteFile(candidate, conf, 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", candidate, err)
+	}
+	defer os.Remove(candidate)
+	if err := testNginxConf(runtime); err != nil {
+		return err
+	}
+
+	// written in place, as the file is bind mounted into the gateway container
+	return os.WriteFile(filepath.Join(vars.GatewayDirectory, "nginx.conf"), conf, 0o644)
+}
+
+// testNginxConf runs 'nginx -t' on the candidate configuration within the running gateway container. It is not
+// tested when the gateway is not running yet, Eg:- when first enabled.
+func testNginxConf(runtime runtime.Runtime) error {
+	exists, err := runtime.ContainerExists(containerName)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return nil
+	}
+	ctr, err := runtime.InspectContainer(containerName)
+	if err != nil {
+		return err
+	}
+	if ctr.State == nil || !ctr.State.Running {
+		return nil
+	}
+
+	// the gateway directory is mounted at /etc/ai-services/gateway, see gateway.yaml.tmpl
+	if _, err := runtime.ExecContainer(containerName, []string{"nginx", "-t", "-c", "/etc/ai-services/gateway/" + candidateConf}); err != nil {
+		return fmt.Errorf("the gateway configuration is rejected by nginx: %w", err)
+	}
+	return nil
+}
+
+// buildServers groups the routes by host and resolves the backend of each route from the application endpoints.
+// The endpoints served by several replicas of a pod (see 'application scale') are balanced with an upstream.
+func buildServers(runtime runtime.Runtime, routes []Route) ([]server, []upstream, error) {
+	byHost := map[string]*server{"": {Host: "_", Default: true}}
+	var upstreams []upstream
+
+	endpoints := map[string][]helpers.Endpoint{}
+	for _, route := range routes {
+		if err := ValidateRoute(route); err != nil {
+			// saved before the routes were validated
+			logger.Warningf("Skipping the route of endpoint '%s' of application '%s': %v\n", route.Endpoint, route.Application, err)
+			continue
+		}
+		if _, ok := endpoints[route.Application]; !ok {
+			eps, err := helpers.ListEndpoints(runtime, route.Application)
+			if err != nil {
+				return nil, nil, err
+			}
+			endpoints[route.Application] = eps
+		}
+
+		idx := slices.IndexFunc(endpoints[route.Application], func(ep helpers.Endpoint) bool { return ep.Name == route.Endpoint })
+		if idx == -1 || endpoints[route.Application][idx].URL == "" {
+			// not failing the gateway for an application which is deleted or not running
+			logger.Warningf("Endpoint '%s' of application '%s' not found, skipping the route\n", route.Endpoint, route.Application)
+			continue
+		}
+
+		ep := endpoints[route.Application][idx]
+		if ep.Protocol != "http" && ep.Protocol != "https" {
+			logger.Warningf("Endpoint '%s' of application '%s' is not an HTTP endpoint, skipping the route\n", route.Endpoint, route.Application)
+			continue
+		}
+
+		backend := strings.TrimSuffix(ep.URL, "/") + "/"
+		if replicas := replicaAddresses(endpoints[route.Application], ep); len(replicas) > 1 {
+			u, err := url.Parse(ep.URL)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid URL of endpoint '%s' of application '%s': %w", route.Endpoint, route.Application, err)
+			}
+			name := fmt.Sprintf("%s_%s_%d", route.Application, route.Endpoint, len(upstreams))
+			upstreams = append(upstreams, upstream{Name: name, Servers: replicas})
+			backend = fmt.Sprintf("%s://%s%s/", u.Scheme, name, strings.TrimSuffix(u.Path, "/"))
+		}
+
+		srv, ok := byHost[route.Host]
+		if !ok {
+			srv = &server{Host: route.Host}
+			byHost[route.Host] = srv
+		}
+		srv.Locations = append(srv.Locations, location{
+			Path:        route.Path,
+			Backend:     backend,
+			Application: route.Application,
+			Endpoint:    route.Endpoint,
+		})
+	}
+
+	hosts := utils.ExtractMapKeys(byHost)
+	sort.Strings(hosts)
+
+	servers := make([]server, 0, len(hosts))
+	for _, host := range hosts {
+		servers = append(servers, *byHost[host])
+	}
+	return servers, upstreams, nil
+}
+
+// replicaAddresses returns the addresses (host:port) of the endpoints with the same name and protocol as ep, served
+// by the replicas of its pod
+func replicaAddresses(endpoints []helpers.Endpoint, ep helpers.Endpoint) []string {
+	var addresses []string
+	for _, e := range endpoints {
+		if e.Name != ep.Name || e.Protocol != ep.Protocol || e.URL == "" {
+			continue
+		}
+		if u, err := url.Parse(e.URL); err == nil && !slices.Contains(addresses, u.Host) {
+			addresses = append(addresses, u.Host)
+		}
+	}
+	return addresses
+}
+
+func render(path string, data any) ([]byte, error) {
+	tmpl, err := template.ParseFS(assets.GatewayFS, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, data); err != nil {
+		return nil, fmt.Errorf("failed to render %s: %w", path, err)
+	}
+	return out.Bytes(), nil
+}
+
+// NormalizePath makes sure the route path starts and ends with '/', so that the path prefix is stripped when proxying
+func NormalizePath(path string) string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return "/"
+	}
+	return "/" + path + "/"
+}
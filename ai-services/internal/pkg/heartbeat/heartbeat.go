@@ -0,0 +1,162 @@
+// Package heartbeat writes the status of the long-running operations to a file, rewritten periodically, so that the
+// external supervisors can detect a hung deployment or daemon and take action:
+//
+//   - the process is gone or wedged once Updated is older than a few intervals
+//   - the operation is stuck once Progress stops advancing while Updated does
+package heartbeat
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+)
+
+// States of an operation
+const (
+	StateRunning   = "running"
+	StateSucceeded = "succeeded"
+	StateFailed    = "failed"
+)
+
+// DefaultInterval is the interval the heartbeat file is rewritten at
+const DefaultInterval = 15 * time.Second
+
+// Status is the content of a heartbeat file
+type Status struct {
+	PID     int    `json:"pid"`
+	Command string `json:"command"`
+	State   string `json:"state"`
+	// Phase is the last progress reported by the operation, Eg:- the stage of a deployment
+	Phase   string    `json:"phase,omitempty"`
+	Started time.Time `json:"started"`
+	// Updated is the time of the last write of the file, every IntervalSeconds while the process is alive
+	Updated         time.Time `json:"updated"`
+	IntervalSeconds float64   `json:"intervalSeconds"`
+	// Progress is the time of the last progress reported by the operation
+	Progress time.Time `json:"progress"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// Writer rewrites the heartbeat file of an operation until stopped. The methods of a nil Writer do nothing, so that
+// the operations without heartbeat file need no special casing.
+type Writer struct {
+	path     string
+	interval time.Duration
+	mu       sync.Mutex
+	status   Status
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// Start writes the heartbeat file of the command and rewrites it every interval in the background. Returns a nil
+// Writer if path is empty.
+func Start(path, command string, interval time.Duration) (*Writer, error) {
+	if path == "" {
+		return nil, nil
+	}
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	now := time.Now().UTC()
+	w := &Writer{
+		path:     path,
+		interval: interval,
+		status: Status{
+			PID:             os.Getpid(),
+			Command:         command,
+			State:           StateRunning,
+			Started:         now,
+			IntervalSeconds: interval.Seconds(),
+			Progress:        now,
+		},
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create the directory of the heartbeat file: %w", err)
+	}
+	if err := w.write(); err != nil {
+		return nil, err
+	}
+
+	go func() {
+		defer close(w.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-w.stop:
+				return
+			case <-ticker.C:
+				if err := w.write(); err != nil {
+					logger.Warningf("failed to write the heartbeat file: %v\n", err)
+				}
+			}
+		}
+	}()
+	return w, nil
+}
+
+// Progress records the progress of the operation
+func (w *Writer) Progress(phase string) {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	w.status.Phase, w.status.Progress = phase, time.Now().UTC()
+	w.mu.Unlock()
+}
+
+// Stop stops the heartbeats and writes the final state of the operation, failed if err is not nil. The file is kept
+// for the supervisors to collect the outcome.
+func (w *Writer) Stop(err error) {
+	if w == nil {
+		return
+	}
+	close(w.stop)
+	<-w.done
+
+	w.mu.Lock()
+	w.status.State = StateSucceeded
+	if err != nil {
+		w.status.State, w.status.Error = StateFailed, err.Error()
+	}
+	w.mu.Unlock()
+	if err := w.write(); err != nil {
+		logger.Warningf("failed to write the heartbeat file: %v\n", err)
+	}
+}
+
+// write replaces the heartbeat file, through a temp file so that the supervisors never read a partial document
+func (w *Writer) write() error {
+	w.mu.Lock()
+	w.status.Updated = time.Now().UTC()
+	data, err := json.MarshalIndent(w.status, "", "  ")
+	w.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(w.path), "."+filepath.Base(w.path)+"-")
+	if err != nil {
+		return fmt.Errorf("failed to write the heartbeat file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write the heartbeat file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write the heartbeat file: %w", err)
+	}
+	// readable by the supervisors running as another user
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return fmt.Errorf("failed to write the heartbeat file: %w", err)
+	}
+	return os.Rename(tmp.Name(), w.path)
+}
@@ -0,0 +1,318 @@
+// Package hosts fans the commands of the CLI out over the Power LPARs of a hosts inventory. The commands run on each
+// host with the CLI installed there, over SSH, in machine mode so that their results can be aggregated per host
+package hosts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+)
+
+// DefaultInventory is the path of the hosts inventory, overridden by the AI_SERVICES_INVENTORY environment variable
+var DefaultInventory = "/etc/ai-services/hosts.yaml"
+
+// InventoryEnv overrides the path of the hosts inventory
+const InventoryEnv = "AI_SERVICES_INVENTORY"
+
+// Host is a Power LPAR of the inventory
+type Host struct {
+	Name string `yaml:"name" json:"name"`
+	// Address is the SSH destination of the host, [user@]host. The command runs locally if empty
+	Address string `yaml:"address,omitempty" json:"address,omitempty"`
+	Port    int    `yaml:"port,omitempty" json:"port,omitempty"`
+	// IdentityFile is the SSH private key used to connect, defaults to the SSH configuration
+	IdentityFile string `yaml:"identityFile,omitempty" json:"identityFile,omitempty"`
+	// Binary is the path of the CLI on the host, defaults to ai-services
+	Binary string `yaml:"binary,omitempty" json:"binary,omitempty"`
+}
+
+func (h Host) local() bool {
+	return h.Address == ""
+}
+
+// Inventory is the list of hosts managed from this CLI
+type Inventory struct {
+	Hosts []Host `yaml:"hosts" json:"hosts"`
+}
+
+// InventoryPath returns the path of the hosts inventory, path if set
+func InventoryPath(path string) string {
+	if path != "" {
+		return path
+	}
+	if v, ok := os.LookupEnv(InventoryEnv); ok && v != "" {
+		return v
+	}
+	return DefaultInventory
+}
+
+// Load reads the hosts inventory
+//
+//	hosts:
+//	  - name: lpar1
+//	    address: root@lpar1.example.com
+//	    identityFile: ~/.ssh/id_ed25519
+//	  - name: lpar2
+//	    address: root@lpar2.example.com
+//	    port: 2222
+func Load(path string) (*Inventory, error) {
+	path = InventoryPath(path)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read hosts inventory %s: %w", path, err)
+	}
+
+	var inv Inventory
+	if err := yaml.Unmarshal(data, &inv); err != nil {
+		return nil, fmt.Errorf("failed to parse hosts inventory %s: %w", path, err)
+	}
+
+	seen := map[string]bool{}
+	for i, h := range inv.Hosts {
+		if h.Name == "" {
+			return nil, fmt.Errorf("host %d of inventory %s has no name", i+1, path)
+		}
+		if seen[h.Name] {
+			return nil, fmt.Errorf("host %s is listed more than once in inventory %s", h.Name, path)
+		}
+		seen[h.Name] = true
+	}
+	return &inv, nil
+}
+
+// Select returns all the hosts of the inventory, or the named hosts in the given order
+func (inv *Inventory) Select(all bool, names []string) ([]Host, error) {
+	if all {
+		if len(inv.Hosts) == 0 {
+			return nil, errors.New("hosts inventory is empty")
+		}
+		return inv.Hosts, nil
+	}
+
+	var selected []Host
+	for _, name := range names {
+		i := slices.IndexFunc(inv.Hosts, func(h Host) bool { return h.Name == name })
+		if i < 0 {
+			return nil, fmt.Errorf("host %s not found in the hosts inventory", name)
+		}
+		selected = append(selected, inv.Hosts[i])
+	}
+	return selected, nil
+}
+
+// Command is a command of the CLI run on the hosts
+type Command struct {
+	// Args are the arguments of the CLI, Eg:- application ps
+	Args []string
+	// Files are local files referenced by Args, copied to each remote host and substituted in Args
+	Files []string
+	// Parallel runs the command on all the hosts at once instead of one host after the other
+	Parallel bool
+}
+
+// Result is the result of the command on a host, as emitted by the CLI in machine mode
+type Result struct {
+	Host     string          `json:"host"`
+	Success  bool            `json:"success"`
+	Changed  bool            `json:"changed"`
+	ExitCode int             `json:"exitCode"`
+	Error    string          `json:"error,omitempty"`
+	Data     json.RawMessage `json:"data,omitempty"`
+	// Output is the human readable output of the command
+	Output string `json:"-"`
+}
+
+// Run runs the command on the hosts and returns the results in the order of the hosts. Running sequentially does
+// not stop at the first failure, every host reports its result
+func Run(ctx context.Context, hosts []Host, cmd Command) []Result {
+	results := make([]Result, len(hosts))
+	if !cmd.Parallel {
+		for i, h := range hosts {
+			logger.Infof("Running on host %s...\n", h.Name)
+			results[i] = run(ctx, h, cmd)
+		}
+		return results
+	}
+
+	var wg sync.WaitGroup
+	for i, h := range hosts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = run(ctx, h, cmd)
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+func run(ctx context.Context, h Host, cmd Command) Result {
+	result := Result{Host: h.Name}
+	fail := func(err error) Result {
+		result.ExitCode = 1
+		result.Error = err.Error()
+		return result
+	}
+
+	args := append([]string{"--machine"}, cmd.Args...)
+	var execCmd *exec.Cmd
+	if h.local() {
+		binary, err := os.Executable()
+		if err != nil {
+			return fail(err)
+		}
+		execCmd = exec.CommandContext(ctx, binary, args...)
+	} else {
+		if len(cmd.Files) > 0 {
+			dir, err := h.upload(ctx, cmd.Files)
+			if err != nil {
+				return fail(fmt.Errorf("failed to copy files to host %s: %w", h.Name, err))
+			}
+			defer func() {
+				if _, err := h.ssh(ctx, "rm", "-rf", dir).Output(); err != nil {
+					logger.Warningf("failed to remove %s on host %s: %v\n", dir, h.Name, err)
+				}
+			}()
+			args = substituteFiles(args, cmd.Files, dir)
+		}
+		binary := h.Binary
+		if binary == "" {
+			binary = "ai-services"
+		}
+		execCmd = h.ssh(ctx, append([]string{binary}, args...)...)
+	}
+
+	var stdout, stderr bytes.Buffer
+	execCmd.Stdout, execCmd.Stderr = &stdout, &stderr
+	runErr := execCmd.Run()
+	result.Output = stderr.String()
+
+	// the CLI emits its result document even when failing
+	if err := json.Unmarshal(bytes.TrimSpace(stdout.Bytes()), &result); err != nil {
+		if runErr != nil {
+			return fail(fmt.Errorf("%w: %s", runErr, strings.TrimSpace(result.Output)))
+		}
+		return fail(fmt.Errorf("invalid result document: %w", err))
+	}
+	result.Host = h.Name
+	return result
+}
+
+// ssh returns the command running the arguments on the host, quoted for the remote shell
+func (h Host) ssh(ctx context.Context, args ...string) *exec.Cmd {
+	sshArgs := []string{"-o", "BatchMode=yes"}
+	if h.Port != 0 {
+		sshArgs = append(sshArgs, "-p", fmt.Sprint(h.Port))
+	}
+	if h.IdentityFile != "" {
+		sshArgs = append(sshArgs, "-i", h.IdentityFile)
+	}
+	quoted := make([]string, 0, len(args))
+	for _, arg := range args {
+		quoted = append(quoted, shellQuote(arg))
+	}
+	sshArgs = append(sshArgs, h.Address, "--", strings.Join(quoted, " "))
+	return exec.CommandContext(ctx, "ssh", sshArgs...)
+}
+
+// upload copies the files into a temporary directory of the host, returning the directory
+func (h Host) upload(ctx context.Context, files []string) (string, error) {
+	out, err := h.ssh(ctx, "mktemp", "-d").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	dir := strings.TrimSpace(string(out))
+
+	scpArgs := []string{"-q", "-o", "BatchMode=yes"}
+	if h.Port != 0 {
+		scpArgs = append(scpArgs, "-P", fmt.Sprint(h.Port))
+	}
+	if h.IdentityFile != "" {
+		scpArgs = append(scpArgs, "-i", h.IdentityFile)
+	}
+	for i, file := range files {
+		dest := fmt.Sprintf("%s:%s", h.Address, remoteFile(dir, i, file))
+		if out, err := exec.CommandContext(ctx, "scp", append(scpArgs, file, dest)...).CombinedOutput(); err != nil {
+			return "", fmt.Errorf("failed to copy %s: %w: %s", file, err, strings.TrimSpace(string(out)))
+		}
+	}
+	return dir, nil
+}
+
+// Mirror mirrors the local directories to the same paths of the host with rsync over SSH, deleting the files
+// removed locally. Nothing is copied to a local host, which shares the directories.
+func (h Host) Mirror(ctx context.Context, dirs []string) error {
+	if h.local() {
+		return nil
+	}
+	rsh := []string{"ssh", "-o", "BatchMode=yes"}
+	if h.Port != 0 {
+		rsh = append(rsh, "-p", fmt.Sprint(h.Port))
+	}
+	if h.IdentityFile != "" {
+		rsh = append(rsh, "-i", shellQuote(h.IdentityFile))
+	}
+	for _, dir := range dirs {
+		src := strings.TrimSuffix(dir, "/") + "/"
+		rsyncArgs := []string{"-aHAX", "--delete", "--mkpath", "-e", strings.Join(rsh, " "), src, h.Address + ":" + src}
+		if out, err := exec.CommandContext(ctx, "rsync", rsyncArgs...).CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to mirror %s to host %s: %w: %s", dir, h.Name, err, strings.TrimSpace(string(out)))
+		}
+	}
+	return nil
+}
+
+// remoteFile is the path of the copied file on the host, prefixed by its index as files may share a name
+func remoteFile(dir string, i int, file string) string {
+	return filepath.Join(dir, fmt.Sprintf("%d-%s", i, filepath.Base(file)))
+}
+
+// substituteFiles replaces the local file paths of the arguments, either as a flag value or a separate argument,
+// by their copies on the host
+func substituteFiles(args, files []string, dir string) []string {
+	substituted := make([]string, len(args))
+	for i, arg := range args {
+		substituted[i] = arg
+		for j, file := range files {
+			if arg == file {
+				substituted[i] = remoteFile(dir, j, file)
+			} else if flag, ok := strings.CutSuffix(arg, "="+file); ok && strings.HasPrefix(flag, "-") {
+				substituted[i] = flag + "=" + remoteFile(dir, j, file)
+			}
+		}
+	}
+	return substituted
+}
+
+// shellQuote quotes the argument for a POSIX shell
+func shellQuote(arg string) string {
+	if arg != "" && strings.IndexFunc(arg, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_./=,:@+", r))
+	}) < 0 {
+		return arg
+	}
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
+
+// Failed returns the names of the hosts the command failed on
+func Failed(results []Result) []string {
+	var failed []string
+	for _, r := range results {
+		if !r.Success {
+			failed = append(failed, r.Host)
+		}
+	}
+	return failed
+}
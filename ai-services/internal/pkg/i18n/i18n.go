@@ -0,0 +1,126 @@
+// Package i18n translates the user-facing messages of the CLI. The messages are looked up by key in the catalog of
+// the locale, falling back to English when the locale or the key is not translated.
+//
+// The locale is selected from the 'locale' of the CLI config file, then the LC_ALL, LC_MESSAGES and LANG
+// environment variables. Machine mode always uses English, the messages of the result documents being parsed by tools.
+package i18n
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"sync"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/config"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
+)
+
+// DefaultLocale is the locale of the messages in the source, the fallback of every other catalog
+const DefaultLocale = "en"
+
+//go:embed locales/*.yaml
+var locales embed.FS
+
+var (
+	once     sync.Once
+	locale   string
+	catalogs = map[string]map[string]string{}
+)
+
+// T returns the message of the key in the selected locale, formatted with the args if any
+func T(key string, args ...any) string {
+	once.Do(load)
+
+	msg, ok := catalogs[Locale()][key]
+	if !ok {
+		msg, ok = catalogs[DefaultLocale][key]
+	}
+	if !ok {
+		msg = key
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// Locale returns the selected locale, the language of which has a catalog, Eg:- 'de' for LANG=de_DE.UTF-8
+func Locale() string {
+	once.Do(load)
+
+	if machine.Enabled {
+		return DefaultLocale
+	}
+	return locale
+}
+
+// Locales returns the locales with a catalog
+func Locales() []string {
+	once.Do(load)
+
+	var names []string
+	for name := range catalogs {
+		names = append(names, name)
+	}
+	return names
+}
+
+func load() {
+	entries, err := locales.ReadDir("locales")
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		data, err := locales.ReadFile(path.Join("locales", entry.Name()))
+		if err != nil {
+			continue
+		}
+		catalog := map[string]string{}
+		if err := yaml.Unmarshal(data, &catalog); err != nil {
+			continue
+		}
+		catalogs[strings.TrimSuffix(entry.Name(), ".yaml")] = catalog
+	}
+
+	locale = DefaultLocale
+	for _, candidate := range []string{configLocale(), os.Getenv("LC_ALL"), os.Getenv("LC_MESSAGES"), os.Getenv("LANG")} {
+		if name := match(candidate); name != "" {
+			locale = name
+			return
+		}
+		// a set but untranslated locale stops the lookup, Eg:- LC_ALL=C overrides LANG
+		if candidate != "" {
+			return
+		}
+	}
+}
+
+// match returns the catalog of the POSIX locale, Eg:- 'pt_BR.UTF-8' matches the 'pt_BR' catalog, then the 'pt' one
+func match(posix string) string {
+	name, _, _ := strings.Cut(posix, ".")
+	name, _, _ = strings.Cut(name, "@")
+	name = strings.ReplaceAll(name, "-", "_")
+	if name == "" {
+		return ""
+	}
+	if _, ok := catalogs[name]; ok {
+		return name
+	}
+	language, _, _ := strings.Cut(name, "_")
+	if _, ok := catalogs[language]; ok {
+		return language
+	}
+	return ""
+}
+
+func configLocale() string {
+	c, err := config.Load()
+	if err != nil {
+		return ""
+	}
+	return c.Locale
+}
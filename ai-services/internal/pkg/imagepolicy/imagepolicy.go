@@ -0,0 +1,101 @@
+// Package imagepolicy enforces a containers signature policy (policy.json, see containers-policy.json(5))
+// on the container images, verifying their signatures (GPG or sigstore) against the trusted keys of the policy
+package imagepolicy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/containers/image/v5/docker"
+	"github.com/containers/image/v5/image"
+	"github.com/containers/image/v5/signature"
+	"github.com/containers/image/v5/types"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+)
+
+// Failure is an image rejected by the policy
+type Failure struct {
+	Image string
+	Err   error
+}
+
+// VerificationError lists the images rejected by the policy
+type VerificationError struct {
+	Policy   string
+	Failures []Failure
+}
+
+func (e *VerificationError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d image(s) rejected by the signature policy %s:", len(e.Failures), e.Policy)
+	for _, f := range e.Failures {
+		fmt.Fprintf(&b, "\n  - %s: %v", f.Image, f.Err)
+	}
+	return b.String()
+}
+
+// Verify checks the images against the signature policy file, fetching the manifests and signatures from the
+// registries. All the images are checked, the returned *VerificationError lists every rejected image.
+func Verify(ctx context.Context, policyPath string, images []string) error {
+	policy, err := signature.NewPolicyFromFile(policyPath)
+	if err != nil {
+		return fmt.Errorf("failed to load signature policy %s: %w", policyPath, err)
+	}
+
+	pc, err := signature.NewPolicyContext(policy)
+	if err != nil {
+		return fmt.Errorf("failed to load signature policy %s: %w", policyPath, err)
+	}
+	defer func() {
+		_ = pc.Destroy()
+	}()
+
+	sys := &types.SystemContext{}
+	verr := &VerificationError{Policy: policyPath}
+	for _, img := range images {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		logger.Infof("Verifying signature of image: %s\n", img, 2)
+		if err := verifyImage(ctx, pc, sys, img); err != nil {
+			verr.Failures = append(verr.Failures, Failure{Image: img, Err: err})
+		}
+	}
+
+	if len(verr.Failures) > 0 {
+		return verr
+	}
+	return nil
+}
+
+func verifyImage(ctx context.Context, pc *signature.PolicyContext, sys *types.SystemContext, img string) error {
+	ref, err := docker.ParseReference("//" + img)
+	if err != nil {
+		return fmt.Errorf("invalid image reference: %w", err)
+	}
+
+	src, err := ref.NewImageSource(ctx, sys)
+	if err != nil {
+		return fmt.Errorf("failed to access the image in the registry: %w", err)
+	}
+	defer func() {
+		_ = src.Close()
+	}()
+
+	allowed, err := pc.IsRunningImageAllowed(ctx, image.UnparsedInstance(src, nil))
+	if err != nil {
+		var reqErr signature.PolicyRequirementError
+		if errors.As(err, &reqErr) {
+			return fmt.Errorf("signature check failed: %w", err)
+		}
+		return err
+	}
+	if !allowed {
+		return errors.New("image is not allowed by the policy")
+	}
+
+	return nil
+}
@@ -0,0 +1,230 @@
+// Package imagescan scans the container images for known vulnerabilities (CVEs) with the scanner configured in the
+// 'imageScan' of the CLI config file. The images are resolved to their manifest digests in the registries (as
+// skopeo inspect does) and submitted to the scanner API, which reports the vulnerabilities of the digests.
+package imagescan
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/containers/image/v5/docker"
+	"github.com/containers/image/v5/manifest"
+	"github.com/containers/image/v5/types"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/config"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+// Severities of the vulnerabilities, from the lowest to the highest
+const (
+	SeverityUnknown  = "unknown"
+	SeverityLow      = "low"
+	SeverityMedium   = "medium"
+	SeverityHigh     = "high"
+	SeverityCritical = "critical"
+)
+
+// Severities lists the severities from the lowest to the highest
+var Severities = []string{SeverityUnknown, SeverityLow, SeverityMedium, SeverityHigh, SeverityCritical}
+
+// Config is the 'imageScan' of the CLI config file
+type Config = config.ImageScan
+
+// Vulnerability is a vulnerability of an image reported by the scanner
+type Vulnerability struct {
+	ID               string `json:"id"`
+	Severity         string `json:"severity"`
+	Package          string `json:"package,omitempty"`
+	InstalledVersion string `json:"installedVersion,omitempty"`
+	FixedVersion     string `json:"fixedVersion,omitempty"`
+}
+
+// ImageReport is the outcome of the scan of an image
+type ImageReport struct {
+	Image  string `json:"image"`
+	Digest string `json:"digest,omitempty"`
+	// Counts are the number of vulnerabilities per severity
+	Counts          map[string]int  `json:"counts"`
+	Vulnerabilities []Vulnerability `json:"vulnerabilities,omitempty"`
+	// Error is set when the image could not be scanned
+	Error string `json:"error,omitempty"`
+}
+
+// Report is the outcome of the scan of the images
+type Report struct {
+	Images []ImageReport `json:"images"`
+}
+
+// ThresholdError lists the images having vulnerabilities of the threshold severity or higher
+type ThresholdError struct {
+	Threshold string
+	Images    []string
+}
+
+func (e *ThresholdError) Error() string {
+	return fmt.Sprintf("%d image(s) have vulnerabilities of severity %s or higher: %s", len(e.Images), e.Threshold,
+		strings.Join(e.Images, ", "))
+}
+
+// Load returns the 'imageScan' of the CLI config file, nil when no scanner is configured
+func Load() (*Config, error) {
+	c, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	if c.ImageScan == nil || c.ImageScan.URL == "" {
+		return nil, nil
+	}
+	if c.ImageScan.FailOn != "" {
+		if err := ValidateSeverity(c.ImageScan.FailOn); err != nil {
+			return nil, fmt.Errorf("invalid imageScan.failOn in %s: %w", vars.ConfigFile, err)
+		}
+	}
+	if c.ImageScan.Timeout != "" {
+		if _, err := time.ParseDuration(c.ImageScan.Timeout); err != nil {
+			return nil, fmt.Errorf("invalid imageScan.timeout in %s: %w", vars.ConfigFile, err)
+		}
+	}
+	return c.ImageScan, nil
+}
+
+// ValidateSeverity refuses the unknown severities
+func ValidateSeverity(severity string) error {
+	if !slices.Contains(Severities, strings.ToLower(severity)) {
+		return fmt.Errorf("unknown severity %q, supported severities: %s", severity, strings.Join(Severities, ", "))
+	}
+	return nil
+}
+
+// Scan scans all the images, the images failing to be scanned are reported with their error
+func Scan(ctx context.Context, cfg *Config, images []string) (*Report, error) {
+	var token string
+	if cfg.TokenFile != "" {
+		data, err := os.ReadFile(cfg.TokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the scanner token: %w", err)
+		}
+		token = strings.TrimSpace(string(data))
+	}
+	timeout := 5 * time.Minute
+	if cfg.Timeout != "" {
+		timeout, _ = time.ParseDuration(cfg.Timeout)
+	}
+	client := &http.Client{Timeout: timeout}
+
+	report := &Report{}
+	for _, img := range images {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		logger.Infof("Scanning image: %s\n", img, 2)
+		entry := ImageReport{Image: img, Counts: map[string]int{}}
+		if err := scanImage(ctx, client, cfg.URL, token, &entry); err != nil {
+			entry.Error = err.Error()
+		}
+		report.Images = append(report.Images, entry)
+	}
+	return report, nil
+}
+
+func scanImage(ctx context.Context, client *http.Client, url, token string, entry *ImageReport) error {
+	digest, err := manifestDigest(ctx, entry.Image)
+	if err != nil {
+		return err
+	}
+	entry.Digest = digest
+
+	body, err := json.Marshal(map[string]string{"image": entry.Image, "digest": digest})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call the scanner: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("scanner responded with status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	var result struct {
+		Vulnerabilities []Vulnerability `json:"vulnerabilities"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to parse the scanner response: %w", err)
+	}
+	for _, v := range result.Vulnerabilities {
+		v.Severity = strings.ToLower(v.Severity)
+		if !slices.Contains(Severities, v.Severity) {
+			v.Severity = SeverityUnknown
+		}
+		entry.Counts[v.Severity]++
+		entry.Vulnerabilities = append(entry.Vulnerabilities, v)
+	}
+	return nil
+}
+
+// manifestDigest resolves the manifest digest of the image in its registry
+func manifestDigest(ctx context.Context, img string) (string, error) {
+	ref, err := docker.ParseReference("//" + img)
+	if err != nil {
+		return "", fmt.Errorf("invalid image reference: %w", err)
+	}
+	src, err := ref.NewImageSource(ctx, &types.SystemContext{})
+	if err != nil {
+		return "", fmt.Errorf("failed to access the image in the registry: %w", err)
+	}
+	defer func() {
+		_ = src.Close()
+	}()
+
+	raw, _, err := src.GetManifest(ctx, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the manifest: %w", err)
+	}
+	digest, err := manifest.Digest(raw)
+	if err != nil {
+		return "", fmt.Errorf("failed to digest the manifest: %w", err)
+	}
+	return digest.String(), nil
+}
+
+// Check returns a *ThresholdError once an image has vulnerabilities of the threshold severity or higher
+func (r *Report) Check(threshold string) error {
+	threshold = strings.ToLower(threshold)
+	minimum := slices.Index(Severities, threshold)
+	if minimum < 0 {
+		return ValidateSeverity(threshold)
+	}
+	terr := &ThresholdError{Threshold: threshold}
+	for _, img := range r.Images {
+		for _, severity := range Severities[minimum:] {
+			if img.Counts[severity] > 0 {
+				terr.Images = append(terr.Images, img.Image)
+				break
+			}
+		}
+	}
+	if len(terr.Images) > 0 {
+		return terr
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+// Package machine implements the machine mode of the CLI, enabled with the global --machine flag, meant for
+// wrapping the commands in automation (e.g. Ansible modules):
+//
+//   - every command emits a single JSON result document on stdout, the human readable output goes to stderr
+//   - commands never prompt, confirmations are assumed
+//   - the exit codes are stable, see the Exit* constants
+//   - 'changed' reports whether the command modified the host. Re-running a command for a state already reached
+//     (e.g. starting a running application) reports changed=false
+package machine
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// Stable exit codes of the CLI in machine mode
+const (
+	ExitOK = 0
+	// ExitError is any failure not covered by the other exit codes
+	ExitError = 1
+	// ExitUsage is an invalid command line (unknown command or flag, invalid arguments)
+	ExitUsage = 2
+	// ExitValidationFailed is a failed validation of the host
+	ExitValidationFailed = 3
+	// ExitNotFound is a missing application, pod or resource
+	ExitNotFound = 4
+)
+
+// Enabled is set by the global --machine flag
+var Enabled bool
+
+// Result is the JSON document emitted by every command in machine mode
+type Result struct {
+	Command  string `json:"command"`
+	Success  bool   `json:"success"`
+	Changed  bool   `json:"changed"`
+	ExitCode int    `json:"exitCode"`
+	Error    string `json:"error,omitempty"`
+	Data     any    `json:"data,omitempty"`
+}
+
+var (
+	mu      sync.Mutex
+	stdout  = os.Stdout
+	changed bool
+	data    any
+)
+
+// Init redirects the standard output to the standard error, reserving the standard output for the result document
+func Init() {
+	if !Enabled {
+		return
+	}
+	os.Stdout = os.Stderr
+}
+
+// SetData sets the data of the result document
+func SetData(v any) {
+	mu.Lock()
+	defer mu.Unlock()
+	data = v
+}
+
+// MarkChanged records that the command modified the host
+func MarkChanged() {
+	mu.Lock()
+	defer mu.Unlock()
+	changed = true
+}
+
+// CodedError carries the exit code for the error
+type CodedError struct {
+	Code int
+	Err  error
+}
+
+func (e *CodedError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *CodedError) Unwrap() error {
+	return e.Err
+}
+
+// WithExitCode attaches the exit code to the error
+func WithExitCode(code int, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &CodedError{Code: code, Err: err}
+}
+
+// ExitCode returns the exit code attached to the error, fallback otherwise
+func ExitCode(err error, fallback int) int {
+	if err == nil {
+		return ExitOK
+	}
+	var exitErr *CodedError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+	return fallback
+}
+
+// Emit writes the result document of the command to the standard output and returns the exit code
+func Emit(command string, err error, code int) int {
+	mu.Lock()
+	defer mu.Unlock()
+
+	result := Result{
+		Command:  command,
+		Success:  err == nil,
+		Changed:  changed,
+		ExitCode: code,
+		Data:     data,
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+
+	out, merr := json.Marshal(result)
+	if merr != nil {
+		out, _ = json.Marshal(Result{Command: command, ExitCode: ExitError, Error: fmt.Sprintf("failed to marshal result: %v", merr)})
+		code = ExitError
+	}
+	fmt.Fprintln(stdout, string(out))
+
+	return code
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDigest(t *testing.T) {
+	write := func(t *testing.T, path, content string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// a model with regular files
+	regular := t.TempDir()
+	write(t, filepath.Join(regular, "config.json"), "{}")
+	write(t, filepath.Join(regular, "model.safetensors"), "weights")
+
+	// the same model with a file linked to a blob of a download cache, and a hidden cache directory
+	linked := t.TempDir()
+	write(t, filepath.Join(linked, "config.json"), "{}")
+	write(t, filepath.Join(linked, ".cache", "download.lock"), "lock")
+	blob := filepath.Join(t.TempDir(), "blob")
+	write(t, blob, "weights")
+	if err := os.Symlink(blob, filepath.Join(linked, "model.safetensors")); err != nil {
+		t.Fatal(err)
+	}
+
+	want, files, err := Digest(regular)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if files != 2 {
+		t.Errorf("Digest() files = %d, want 2", files)
+	}
+
+	got, files, err := Digest(linked)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want || files != 2 {
+		t.Errorf("Digest() of the linked model = %s (%d files), want %s (2 files)", got, files, want)
+	}
+
+	write(t, filepath.Join(regular, "model.safetensors"), "tampered")
+	if got, _, _ := Digest(regular); got == want {
+		t.Errorf("Digest() did not change with the content of the files")
+	}
+}
@@ -0,0 +1,50 @@
+package models
+
+import "strings"
+
+const (
+	// OCIModelPrefix marks a model published as an OCI artifact (ORAS)
+	OCIModelPrefix = "oci://"
+	// ModelCarPrefix marks a model published as a ModelCar image, i.e. an image with the model files under /models
+	ModelCarPrefix = "modelcar://"
+)
+
+// IsOCIModel returns true if the model is pulled from an OCI registry instead of Hugging Face
+func IsOCIModel(model string) bool {
+	return strings.HasPrefix(model, OCIModelPrefix) || strings.HasPrefix(model, ModelCarPrefix)
+}
+
+// ModelPath returns the path of the model relative to the model directory
+//
+// Hugging Face models are stored as is (Eg:- ibm-granite/granite-3.3-8b-instruct).
+// For OCI models the registry host, tag and digest are dropped so that the templates can mount
+// the same path irrespective of where the model is pulled from
+// Eg:- oci://registry.example.com/ibm-granite/granite-3.3-8b-instruct:1.0 -> ibm-granite/granite-3.3-8b-instruct
+func ModelPath(model string) string {
+	if !IsOCIModel(model) {
+		return model
+	}
+
+	ref := strings.TrimPrefix(strings.TrimPrefix(model, OCIModelPrefix), ModelCarPrefix)
+
+	// drop the digest
+	if i := strings.Index(ref, "@"); i != -1 {
+		ref = ref[:i]
+	}
+	// drop the tag, colon after the last slash is a tag and not a registry port
+	if i := strings.LastIndex(ref, ":"); i > strings.LastIndex(ref, "/") {
+		ref = ref[:i]
+	}
+	// drop the registry host, the references without one (Eg:- org/model) are kept whole
+	if i := strings.Index(ref, "/"); i != -1 && isRegistryHost(ref[:i]) {
+		ref = ref[i+1:]
+	}
+
+	return ref
+}
+
+// isRegistryHost tells whether the first segment of a reference is a registry host rather than a namespace,
+// following the convention of the container image references
+func isRegistryHost(segment string) bool {
+	return strings.ContainsAny(segment, ".:") || segment == "localhost"
+}
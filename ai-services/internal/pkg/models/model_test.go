@@ -0,0 +1,60 @@
+package models
+
+import "testing"
+
+func TestModelPath(t *testing.T) {
+	tests := []struct {
+		name  string
+		model string
+		want  string
+	}{
+		{
+			name:  "hugging face model",
+			model: "ibm-granite/granite-3.3-8b-instruct",
+			want:  "ibm-granite/granite-3.3-8b-instruct",
+		},
+		{
+			name:  "oci artifact with registry host and tag",
+			model: "oci://registry.example.com/ibm-granite/granite-3.3-8b-instruct:1.0",
+			want:  "ibm-granite/granite-3.3-8b-instruct",
+		},
+		{
+			name:  "oci artifact with registry port",
+			model: "oci://registry:5000/org/model:1",
+			want:  "org/model",
+		},
+		{
+			name:  "oci artifact on localhost",
+			model: "oci://localhost/org/model",
+			want:  "org/model",
+		},
+		{
+			name:  "oci artifact with digest",
+			model: "oci://quay.io/org/model@sha256:0123456789abcdef",
+			want:  "org/model",
+		},
+		{
+			name:  "oci artifact without registry host",
+			model: "oci://org/model:1",
+			want:  "org/model",
+		},
+		{
+			name:  "oci artifact without namespace",
+			model: "oci://model:1",
+			want:  "model",
+		},
+		{
+			name:  "modelcar image",
+			model: "modelcar://quay.io/org/model:latest",
+			want:  "org/model",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ModelPath(tt.model); got != tt.want {
+				t.Errorf("ModelPath(%q) = %q, want %q", tt.model, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+// Package mounts validates the host paths bind mounted by the pod templates, and labels them for SELinux so that
+// the containers can access them.
+package mounts
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"syscall"
+
+	v1 "github.com/containers/podman/v5/pkg/k8s.io/api/core/v1"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/config"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+// SELinuxType is the SELinux type of the files the containers are allowed to access, as set by the 'z' mount option
+const SELinuxType = "container_file_t"
+
+// SensitivePaths are the host paths the templates are not allowed to mount, along with the paths beneath and above
+// them, unless explicitly allowed
+var SensitivePaths = []string{
+	"/bin",
+	"/boot",
+	"/dev",
+	"/etc",
+	"/lib",
+	"/lib64",
+	"/proc",
+	"/root",
+	"/run",
+	"/sbin",
+	"/sys",
+	"/usr",
+	"/var/lib/containers",
+	"/var/run",
+	vars.StateDirectory,
+}
+
+// selinuxEnforce is present when SELinux is enabled
+var selinuxEnforce = "/sys/fs/selinux/enforce"
+
+// Allowed returns the host paths allowed by the 'allowedHostPaths' of the CLI config file
+func Allowed() ([]string, error) {
+	c, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	return c.AllowedHostPaths, nil
+}
+
+// HostPaths returns the host paths bind mounted by the pod
+func HostPaths(podSpec *models.PodSpec) []string {
+	var paths []string
+	for _, volume := range podSpec.Spec.Volumes {
+		if volume.HostPath != nil {
+			paths = append(paths, volume.HostPath.Path)
+		}
+	}
+	return paths
+}
+
+// Validate refuses the host paths of the pod which are not absolute and clean, or which are sensitive and not
+// beneath one of the allowed paths
+func Validate(podSpec *models.PodSpec, allowed []string) error {
+	var errs []error
+	for _, volume := range podSpec.Spec.Volumes {
+		if volume.HostPath == nil {
+			continue
+		}
+		path := volume.HostPath.Path
+		if !filepath.IsAbs(path) || filepath.Clean(path) != path {
+			errs = append(errs, fmt.Errorf("volume %s: host path '%s' must be absolute and clean", volume.Name, path))
+			continue
+		}
+		if slices.ContainsFunc(allowed, func(a string) bool { return within(path, filepath.Clean(a)) }) {
+			continue
+		}
+		if i := slices.IndexFunc(SensitivePaths, func(s string) bool { return within(path, s) || within(s, path) }); i >= 0 {
+			errs = append(errs, fmt.Errorf("volume %s: host path '%s' overlaps the sensitive path %s", volume.Name, path, SensitivePaths[i]))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("pod %s mounts forbidden host paths, allow them with --allow-host-path or 'allowedHostPaths' in %s: %w",
+			podSpec.Name, vars.ConfigFile, errors.Join(errs...))
+	}
+	return nil
+}
+
+// within returns true if the path is the parent path or beneath it
+func within(path, parent string) bool {
+	return path == parent || parent == "/" || strings.HasPrefix(path, parent+"/")
+}
+
+// Relabel sets the SELinux type of the files and directories bind mounted by the pod to container_file_t, like
+// the 'z' mount option which kube play only supports for a single host path per pod. The directories created on
+// demand are created beforehand, as they would inherit the label of their parent otherwise.
+func Relabel(podSpec *models.PodSpec) error {
+	if _, err := os.Stat(selinuxEnforce); err != nil {
+		return nil
+	}
+
+	for _, volume := range podSpec.Spec.Volumes {
+		hostPath := volume.HostPath
+		if hostPath == nil {
+			continue
+		}
+		var pathType v1.HostPathType
+		if hostPath.Type != nil {
+			pathType = *hostPath.Type
+		}
+		switch pathType {
+		case v1.HostPathCharDev, v1.HostPathBlockDev, v1.HostPathSocket:
+			// device nodes keep their own labels, podman grants the containers access to them
+			continue
+		case v1.HostPathDirectoryOrCreate:
+			if err := os.MkdirAll(hostPath.Path, 0o755); err != nil {
+				return fmt.Errorf("failed to create host path %s: %w", hostPath.Path, err)
+			}
+		}
+
+		if _, err := os.Stat(hostPath.Path); err != nil {
+			// kube play reports the missing paths
+			continue
+		}
+		if labeled(hostPath.Path) {
+			continue
+		}
+		logger.Infof("Labeling host path %s for SELinux\n", hostPath.Path, 2)
+		if out, err := exec.Command("chcon", "-R", "-t", SELinuxType, hostPath.Path).CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to label host path %s for SELinux: %w: %s", hostPath.Path, err, strings.TrimSpace(string(out)))
+		}
+	}
+	return nil
+}
+
+// labeled returns true if the path already has the SELinux type of the containers. The files beneath are expected
+// to have been labeled along with it.
+func labeled(path string) bool {
+	buf := make([]byte, 256)
+	n, err := syscall.Getxattr(path, "security.selinux", buf)
+	if err != nil {
+		return false
+	}
+	return strings.Contains(string(buf[:n]), ":"+SELinuxType+":")
+}
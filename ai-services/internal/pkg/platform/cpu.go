@@ -0,0 +1,142 @@
+package platform
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// cpuSysfsPath is the sysfs directory describing the CPUs of the host
+const cpuSysfsPath = "/sys/devices/system/cpu"
+
+// Core is a physical core of the host along with its online hardware threads
+type Core struct {
+	// ID is the lowest logical CPU of the core, which stays online whatever the SMT level
+	ID int
+	// CPUs are the online logical CPUs (hardware threads) of the core
+	CPUs []int
+}
+
+// Cores returns the physical cores of the host, with their online hardware threads as per the current SMT level
+func Cores() ([]Core, error) {
+	data, err := os.ReadFile(filepath.Join(cpuSysfsPath, "online"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the online CPUs: %w", err)
+	}
+	online, err := ParseCPUList(string(data))
+	if err != nil {
+		return nil, err
+	}
+
+	byCore := map[string]*Core{}
+	for _, cpu := range online {
+		topology := filepath.Join(cpuSysfsPath, fmt.Sprintf("cpu%d", cpu), "topology")
+		pkg, err := readTrimmed(filepath.Join(topology, "physical_package_id"))
+		if err != nil {
+			return nil, err
+		}
+		coreID, err := readTrimmed(filepath.Join(topology, "core_id"))
+		if err != nil {
+			return nil, err
+		}
+
+		key := pkg + "/" + coreID
+		core, ok := byCore[key]
+		if !ok {
+			core = &Core{ID: cpu}
+			byCore[key] = core
+		}
+		core.CPUs = append(core.CPUs, cpu)
+		if cpu < core.ID {
+			core.ID = cpu
+		}
+	}
+
+	cores := make([]Core, 0, len(byCore))
+	for _, core := range byCore {
+		sort.Ints(core.CPUs)
+		cores = append(cores, *core)
+	}
+	sort.Slice(cores, func(i, j int) bool { return cores[i].ID < cores[j].ID })
+	return cores, nil
+}
+
+// CPUModel returns the model of the processors, the 'cpu' of /proc/cpuinfo on Power, Eg:- 'POWER10 (architected)'
+func CPUModel() string {
+	f, err := os.Open("/proc/cpuinfo")
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		key, val, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+		switch strings.TrimSpace(key) {
+		case "cpu", "model name":
+			return strings.TrimSpace(val)
+		}
+	}
+	return ""
+}
+
+func readTrimmed(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read CPU topology: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// ParseCPUList parses a kernel CPU list such as '0-3,8,10-11'
+func ParseCPUList(list string) ([]int, error) {
+	var cpus []int
+	for _, part := range strings.Split(strings.TrimSpace(list), ",") {
+		if part == "" {
+			continue
+		}
+		first, last, isRange := strings.Cut(part, "-")
+		start, err := strconv.Atoi(first)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CPU list '%s': %w", list, err)
+		}
+		end := start
+		if isRange {
+			if end, err = strconv.Atoi(last); err != nil {
+				return nil, fmt.Errorf("invalid CPU list '%s': %w", list, err)
+			}
+		}
+		for cpu := start; cpu <= end; cpu++ {
+			cpus = append(cpus, cpu)
+		}
+	}
+	return cpus, nil
+}
+
+// FormatCPUList formats the CPUs as a kernel CPU list, collapsing the consecutive CPUs into ranges
+func FormatCPUList(cpus []int) string {
+	sorted := append([]int(nil), cpus...)
+	sort.Ints(sorted)
+
+	var parts []string
+	for i := 0; i < len(sorted); {
+		j := i
+		for j+1 < len(sorted) && sorted[j+1] == sorted[j]+1 {
+			j++
+		}
+		if i == j {
+			parts = append(parts, strconv.Itoa(sorted[i]))
+		} else {
+			parts = append(parts, fmt.Sprintf("%d-%d", sorted[i], sorted[j]))
+		}
+		i = j + 1
+	}
+	return strings.Join(parts, ",")
+}
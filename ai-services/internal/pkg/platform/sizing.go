@@ -0,0 +1,91 @@
+package platform
+
+import (
+	"fmt"
+	goruntime "runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Processors of the Power hosts sized by ai-services
+const (
+	ProcessorPower10 = "Power10"
+	ProcessorPower11 = "Power11"
+)
+
+// Sizes are the abstract sizes of the containers, from the smallest
+var Sizes = []string{"small", "medium", "large"}
+
+// coreSizes are the physical cores of each size by processor, a Power11 core doing more work than a Power10 core.
+// The processors not listed, Eg:- the x86 development hosts, are sized as Power10.
+var coreSizes = map[string]map[string]int{
+	ProcessorPower10: {"small": 2, "medium": 4, "large": 8},
+	ProcessorPower11: {"small": 2, "medium": 3, "large": 6},
+}
+
+// memorySizes are the memory limits of each size, whatever the processor
+var memorySizes = map[string]string{"small": "16Gi", "medium": "32Gi", "large": "64Gi"}
+
+// Host is the processor of the host the sizes are resolved for
+type Host struct {
+	// Processor is Power10 or Power11, empty for the other processors
+	Processor string `json:"processor,omitempty"`
+	// SMTLevel is the number of hardware threads of each core
+	SMTLevel int `json:"smtLevel"`
+	// CPUs is the number of online logical CPUs, which caps the CPU limits
+	CPUs int `json:"cpus"`
+}
+
+// Resources are the limits of a container of an abstract size on the host
+type Resources struct {
+	Size string `json:"size"`
+	// Cores is the number of physical cores of the size
+	Cores int `json:"cores"`
+	// CPU is the CPU limit in logical CPUs, the cores times the SMT level
+	CPU string `json:"cpu"`
+	// Memory is the memory limit, Eg:- 32Gi
+	Memory string `json:"memory"`
+}
+
+// ProcessorOf returns the processor of the CPU model of /proc/cpuinfo, Eg:- Power10 for 'POWER10 (architected)',
+// empty if not a Power10 nor a Power11
+func ProcessorOf(model string) string {
+	model = strings.ToUpper(model)
+	switch {
+	case strings.HasPrefix(model, "POWER11"):
+		return ProcessorPower11
+	case strings.HasPrefix(model, "POWER10"):
+		return ProcessorPower10
+	}
+	return ""
+}
+
+// LocalHost returns the processor of the host, read once. The SMT level is the number of online hardware threads of
+// the cores, 1 if the topology can't be read.
+var LocalHost = sync.OnceValue(func() Host {
+	host := Host{Processor: ProcessorOf(CPUModel()), SMTLevel: 1, CPUs: goruntime.NumCPU()}
+	if cores, err := Cores(); err == nil && len(cores) > 0 {
+		host.SMTLevel = len(cores[0].CPUs)
+	}
+	return host
+})
+
+// ResourcesOf returns the limits of a container of the size on the host
+func (h Host) ResourcesOf(size string) (Resources, error) {
+	size = strings.ToLower(strings.TrimSpace(size))
+	if !slices.Contains(Sizes, size) {
+		return Resources{}, fmt.Errorf("invalid size '%s', supported: %v", size, Sizes)
+	}
+	cores, ok := coreSizes[h.Processor]
+	if !ok {
+		cores = coreSizes[ProcessorPower10]
+	}
+
+	cpus := cores[size] * max(h.SMTLevel, 1)
+	if h.CPUs > 0 && cpus > h.CPUs {
+		cpus = h.CPUs
+	}
+	return Resources{Size: size, Cores: cores[size], CPU: strconv.Itoa(cpus), Memory: memorySizes[size]}, nil
+}
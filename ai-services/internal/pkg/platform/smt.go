@@ -0,0 +1,227 @@
+// Package platform manages the host platform settings changed by ai-services, so that the higher level code
+// doesn't shell out to the platform tools directly
+package platform
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	goruntime "runtime"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+)
+
+// Error kinds of the SMT operations, to be matched with errors.Is
+var (
+	// ErrNotSupported is returned when the host doesn't support SMT management (ppc64_cpu missing)
+	ErrNotSupported = errors.New("SMT management is not supported on this host")
+	// ErrPermission is returned when changing the SMT level requires root privileges
+	ErrPermission = errors.New("permission denied")
+	// ErrInvalidLevel is returned for an SMT level the host doesn't support
+	ErrInvalidLevel = errors.New("invalid SMT level")
+	// ErrUnexpectedOutput is returned when the output of ppc64_cpu cannot be parsed
+	ErrUnexpectedOutput = errors.New("unexpected ppc64_cpu output")
+	// ErrCommandFailed is returned when ppc64_cpu fails for another reason
+	ErrCommandFailed = errors.New("ppc64_cpu failed")
+	// ErrVerification is returned when the SMT level doesn't match the level just set
+	ErrVerification = errors.New("SMT level verification failed")
+)
+
+// SMTLevels are the SMT levels supported by the Power processors
+var SMTLevels = []int{1, 2, 4, 8}
+
+// SMT manages the simultaneous multithreading level of the host
+type SMT interface {
+	// Get returns the current SMT level
+	Get() (int, error)
+	// Set changes the SMT level
+	Set(level int) error
+	// Verify checks that the current SMT level is level
+	Verify(level int) error
+}
+
+// SMTError is the error of an SMT operation, classified by Kind
+type SMTError struct {
+	Op     string
+	Kind   error
+	Output string
+	Err    error
+}
+
+func (e *SMTError) Error() string {
+	msg := fmt.Sprintf("%s SMT level: %v", e.Op, e.Kind)
+	if e.Err != nil {
+		msg += fmt.Sprintf(": %v", e.Err)
+	}
+	if e.Output != "" {
+		msg += fmt.Sprintf(", output: %s", e.Output)
+	}
+	return msg
+}
+
+func (e *SMTError) Unwrap() []error {
+	return []error{e.Kind, e.Err}
+}
+
+// SMTOptions configures the SMT manager
+type SMTOptions struct {
+	// DryRun reads the SMT level of the host, but only logs the changes
+	DryRun bool
+	// Simulate keeps the SMT level in memory instead of managing the host, used on non ppc64le hosts
+	Simulate bool
+}
+
+// NewSMT returns the SMT manager of the host. Hosts other than ppc64le are simulated.
+func NewSMT(opts SMTOptions) SMT {
+	if opts.Simulate || goruntime.GOARCH != "ppc64le" {
+		logger.Infof("Simulating the SMT level on %s host\n", goruntime.GOARCH, 2)
+		return &simulatedSMT{level: 8}
+	}
+
+	var smt SMT = &ppc64SMT{}
+	if opts.DryRun {
+		smt = &dryRunSMT{SMT: smt}
+	}
+	return smt
+}
+
+// ValidateLevel checks the SMT level is supported by the Power processors
+func ValidateLevel(level int) error {
+	for _, l := range SMTLevels {
+		if l == level {
+			return nil
+		}
+	}
+	return &SMTError{Op: "set", Kind: ErrInvalidLevel, Err: fmt.Errorf("%d, supported levels: %v", level, SMTLevels)}
+}
+
+// ppc64SMT manages the SMT level with ppc64_cpu (powerpc-utils)
+type ppc64SMT struct{}
+
+func (p *ppc64SMT) Get() (int, error) {
+	out, err := p.run("get", "--smt")
+	if err != nil {
+		return 0, err
+	}
+	return ParseSMTLevel(out)
+}
+
+func (p *ppc64SMT) Set(level int) error {
+	if err := ValidateLevel(level); err != nil {
+		return err
+	}
+	_, err := p.run("set", "--smt="+strconv.Itoa(level))
+	return err
+}
+
+func (p *ppc64SMT) Verify(level int) error {
+	return verify(p, level)
+}
+
+func (p *ppc64SMT) run(op string, arg string) (string, error) {
+	if _, err := exec.LookPath("ppc64_cpu"); err != nil {
+		return "", &SMTError{Op: op, Kind: ErrNotSupported, Err: err}
+	}
+
+	out, err := exec.Command("ppc64_cpu", arg).CombinedOutput()
+	if err != nil {
+		kind := ErrCommandFailed
+		if strings.Contains(strings.ToLower(string(out)), "permission denied") || strings.Contains(string(out), "must be run as root") {
+			kind = ErrPermission
+		}
+		return "", &SMTError{Op: op, Kind: kind, Output: strings.TrimSpace(string(out)), Err: err}
+	}
+	return string(out), nil
+}
+
+// ParseSMTLevel parses the 'SMT=<level>' output of ppc64_cpu --smt
+func ParseSMTLevel(output string) (int, error) {
+	out := strings.TrimSpace(output)
+
+	levelStr, ok := strings.CutPrefix(out, "SMT=")
+	if !ok {
+		// ppc64_cpu reports 'SMT is off' for SMT level 1
+		if out == "SMT is off" {
+			return 1, nil
+		}
+		return 0, &SMTError{Op: "get", Kind: ErrUnexpectedOutput, Output: out}
+	}
+
+	level, err := strconv.Atoi(levelStr)
+	if err != nil {
+		return 0, &SMTError{Op: "get", Kind: ErrUnexpectedOutput, Output: out, Err: err}
+	}
+	return level, nil
+}
+
+func verify(smt SMT, level int) error {
+	current, err := smt.Get()
+	if err != nil {
+		return err
+	}
+	if current != level {
+		return &SMTError{Op: "verify", Kind: ErrVerification, Err: fmt.Errorf("expected %d, got %d", level, current)}
+	}
+	return nil
+}
+
+// dryRunSMT reads the SMT level of the host, and logs the changes instead of applying them
+type dryRunSMT struct {
+	SMT
+	mu  sync.Mutex
+	set *int
+}
+
+func (d *dryRunSMT) Get() (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.set != nil {
+		return *d.set, nil
+	}
+	return d.SMT.Get()
+}
+
+func (d *dryRunSMT) Set(level int) error {
+	if err := ValidateLevel(level); err != nil {
+		return err
+	}
+	logger.Infof("[dry-run] Would set the SMT level to %d\n", level, 0)
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.set = &level
+	return nil
+}
+
+func (d *dryRunSMT) Verify(level int) error {
+	return verify(d, level)
+}
+
+// simulatedSMT keeps the SMT level in memory
+type simulatedSMT struct {
+	mu    sync.Mutex
+	level int
+}
+
+func (s *simulatedSMT) Get() (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.level, nil
+}
+
+func (s *simulatedSMT) Set(level int) error {
+	if err := ValidateLevel(level); err != nil {
+		return err
+	}
+	logger.Infof("[simulated] Setting the SMT level to %d\n", level, 0)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.level = level
+	return nil
+}
+
+func (s *simulatedSMT) Verify(level int) error {
+	return verify(s, level)
+}
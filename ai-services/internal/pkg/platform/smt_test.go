@@ -0,0 +1,103 @@
+package platform
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseSMTLevel(t *testing.T) {
+	tests := []struct {
+		name    string
+		output  string
+		want    int
+		wantErr error
+	}{
+		{name: "smt 8", output: "SMT=8\n", want: 8},
+		{name: "smt 4 with spaces", output: "  SMT=4  ", want: 4},
+		{name: "smt off", output: "SMT is off\n", want: 1},
+		{name: "unexpected output", output: "Machine is not SMT capable", wantErr: ErrUnexpectedOutput},
+		{name: "invalid level", output: "SMT=eight", wantErr: ErrUnexpectedOutput},
+		{name: "empty output", output: "", wantErr: ErrUnexpectedOutput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSMTLevel(tt.output)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("ParseSMTLevel(%q) error = %v, want %v", tt.output, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseSMTLevel(%q) unexpected error: %v", tt.output, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseSMTLevel(%q) = %d, want %d", tt.output, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateLevel(t *testing.T) {
+	tests := []struct {
+		level   int
+		wantErr bool
+	}{
+		{level: 1},
+		{level: 2},
+		{level: 4},
+		{level: 8},
+		{level: 0, wantErr: true},
+		{level: 3, wantErr: true},
+		{level: 16, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		err := ValidateLevel(tt.level)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ValidateLevel(%d) error = %v, wantErr %v", tt.level, err, tt.wantErr)
+		}
+		if err != nil && !errors.Is(err, ErrInvalidLevel) {
+			t.Errorf("ValidateLevel(%d) error = %v, want ErrInvalidLevel", tt.level, err)
+		}
+	}
+}
+
+func TestSimulatedSMT(t *testing.T) {
+	smt := NewSMT(SMTOptions{Simulate: true})
+
+	if err := smt.Verify(8); err != nil {
+		t.Fatalf("Verify(8) on a new simulated host: %v", err)
+	}
+	if err := smt.Set(3); !errors.Is(err, ErrInvalidLevel) {
+		t.Fatalf("Set(3) error = %v, want ErrInvalidLevel", err)
+	}
+	if err := smt.Set(2); err != nil {
+		t.Fatalf("Set(2): %v", err)
+	}
+	if level, err := smt.Get(); err != nil || level != 2 {
+		t.Fatalf("Get() = %d, %v, want 2", level, err)
+	}
+	if err := smt.Verify(4); !errors.Is(err, ErrVerification) {
+		t.Errorf("Verify(4) error = %v, want ErrVerification", err)
+	}
+}
+
+func TestDryRunSMT(t *testing.T) {
+	host := &simulatedSMT{level: 8}
+	smt := &dryRunSMT{SMT: host}
+
+	if err := smt.Set(4); err != nil {
+		t.Fatalf("Set(4): %v", err)
+	}
+	if level, _ := smt.Get(); level != 4 {
+		t.Errorf("Get() = %d after the dry-run Set(4), want 4", level)
+	}
+	if err := smt.Verify(4); err != nil {
+		t.Errorf("Verify(4): %v", err)
+	}
+	if level, _ := host.Get(); level != 8 {
+		t.Errorf("host SMT level = %d, the dry-run changed it from 8", level)
+	}
+}
@@ -0,0 +1,108 @@
+// Package plugins runs the external plugins of the CLI: executables named ai-services-<name> found on the PATH are
+// invoked as 'ai-services <name>', kubectl-style, so that teams can add site-specific subcommands without forking
+package plugins
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"syscall"
+)
+
+// Prefix of the plugin executables
+const Prefix = "ai-services-"
+
+// Plugin is an executable found on the PATH
+type Plugin struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+	// Shadowed is set when a built-in command or a plugin of the same name found earlier on the PATH takes precedence
+	Shadowed bool `json:"shadowed,omitempty"`
+}
+
+// Handshake is the context passed to the plugins as environment variables
+type Handshake struct {
+	// Binary is the path of the CLI, for the plugins calling back into it (preferably in machine mode)
+	Binary  string
+	Version string
+	// ContainerHost is the URI of the podman socket the CLI connects to
+	ContainerHost  string
+	StateDirectory string
+	ModelDirectory string
+	// Machine is set when the CLI runs in machine mode, the plugin is expected to emit a JSON result document
+	Machine bool
+}
+
+// Env returns the environment variables of the handshake
+func (h Handshake) Env() []string {
+	machine := "false"
+	if h.Machine {
+		machine = "true"
+	}
+	return []string{
+		"AI_SERVICES_BIN=" + h.Binary,
+		"AI_SERVICES_VERSION=" + h.Version,
+		"AI_SERVICES_STATE_DIR=" + h.StateDirectory,
+		"AI_SERVICES_MODEL_DIR=" + h.ModelDirectory,
+		"AI_SERVICES_MACHINE=" + machine,
+		"CONTAINER_HOST=" + h.ContainerHost,
+	}
+}
+
+// Find returns the plugin of the name, the first one found on the PATH. Returns nil if none
+func Find(name string) *Plugin {
+	// the plugin names are single words, never paths or flags
+	if name == "" || strings.HasPrefix(name, "-") || strings.ContainsRune(name, filepath.Separator) {
+		return nil
+	}
+	for _, p := range List() {
+		if p.Name == name && !p.Shadowed {
+			return &p
+		}
+	}
+	return nil
+}
+
+// List returns the plugins found on the PATH, in PATH order
+func List() []Plugin {
+	var plugins []Plugin
+	var seen []string
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if dir == "" {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name, ok := strings.CutPrefix(entry.Name(), Prefix)
+			if !ok || name == "" || entry.IsDir() {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			if !executable(path) {
+				continue
+			}
+			plugins = append(plugins, Plugin{Name: name, Path: path, Shadowed: slices.Contains(seen, name)})
+			seen = append(seen, name)
+		}
+	}
+	return plugins
+}
+
+func executable(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir() && info.Mode().Perm()&0o111 != 0
+}
+
+// Exec replaces the CLI with the plugin, passing the arguments and the handshake. Returns only on failure
+func Exec(p *Plugin, args []string, h Handshake) error {
+	env := append(os.Environ(), h.Env()...)
+	if err := syscall.Exec(p.Path, append([]string{p.Path}, args...), env); err != nil {
+		return fmt.Errorf("failed to run plugin %s: %w", p.Path, err)
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+// Package presets stores named sets of template values, saved from a create with 'create --save-preset' and
+// reused by the later creates with 'create --preset', Eg:- the sizing of a production deployment
+package presets
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+var nameRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// Preset is a named set of values of a template
+type Preset struct {
+	Name     string    `yaml:"name" json:"name"`
+	Template string    `yaml:"template" json:"template"`
+	Created  time.Time `yaml:"created" json:"created"`
+	// Environment is the environment overlay the values were resolved with, informative only as the values
+	// already include the overlay
+	Environment string `yaml:"environment,omitempty" json:"environment,omitempty"`
+	// Values are the resolved template values
+	Values map[string]any `yaml:"values" json:"values"`
+}
+
+// Directory returns the directory holding the presets, beneath the config directory of the CLI
+func Directory() string {
+	if vars.Rootless {
+		if config, err := os.UserConfigDir(); err == nil {
+			return filepath.Join(config, "ai-services", "presets")
+		}
+	}
+	return filepath.Join(filepath.Dir(vars.ConfigFile), "presets")
+}
+
+// ValidateName refuses the names which are not usable as file names
+func ValidateName(name string) error {
+	if !nameRegex.MatchString(name) {
+		return fmt.Errorf("invalid preset name %q, must consist of alphanumeric characters, '.', '_' or '-'", name)
+	}
+	return nil
+}
+
+func path(name string) string {
+	return filepath.Join(Directory(), name+".yaml")
+}
+
+// Save stores the preset, replacing the preset of the same name
+func Save(p *Preset) error {
+	if err := ValidateName(p.Name); err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("failed to marshal preset: %w", err)
+	}
+	if err := os.MkdirAll(Directory(), 0o755); err != nil {
+		return fmt.Errorf("failed to create the presets directory: %w", err)
+	}
+	if err := os.WriteFile(path(p.Name), data, 0o644); err != nil {
+		return fmt.Errorf("failed to write preset %s: %w", p.Name, err)
+	}
+	return nil
+}
+
+// Load returns the preset of the name
+func Load(name string) (*Preset, error) {
+	if err := ValidateName(name); err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path(name))
+	if errors.Is(err, os.ErrNotExist) {
+		available, _ := List()
+		return nil, fmt.Errorf("preset '%s' does not exist, available presets: %s", name, strings.Join(available, ", "))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read preset %s: %w", name, err)
+	}
+	var p Preset
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse preset %s: %w", name, err)
+	}
+	return &p, nil
+}
+
+// List returns the names of the presets
+func List() ([]string, error) {
+	entries, err := os.ReadDir(Directory())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, entry := range entries {
+		if name, ok := strings.CutSuffix(entry.Name(), ".yaml"); ok && !entry.IsDir() {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// ValuesFile writes the values of the preset to a temporary values file, applied beneath the values files and
+// params of the create. The returned func removes the file.
+func (p *Preset) ValuesFile() (string, func(), error) {
+	// the values files set the dotted keys, a nested map would replace the whole map of the template values
+	flat := map[string]any{}
+	flatten("", p.Values, flat)
+	data, err := yaml.Marshal(flat)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to marshal the values of preset %s: %w", p.Name, err)
+	}
+	f, err := os.CreateTemp("", "ai-services-preset-*.yaml")
+	if err != nil {
+		return "", nil, err
+	}
+	cleanup := func() {
+		_ = os.Remove(f.Name())
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		cleanup()
+		return "", nil, fmt.Errorf("failed to write the values of preset %s: %w", p.Name, err)
+	}
+	if err := f.Close(); err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	return f.Name(), cleanup, nil
+}
+
+// Changed returns the values of resolved differing from defaults, so that a preset only pins the values customized
+// by the user and follows the defaults of the upgraded templates, Eg:- the images
+func Changed(defaults, resolved map[string]any) map[string]any {
+	changed := map[string]any{}
+	for key, val := range resolved {
+		def, ok := defaults[key]
+		valMap, isMap := val.(map[string]any)
+		defMap, defIsMap := def.(map[string]any)
+		switch {
+		case isMap && defIsMap:
+			if nested := Changed(defMap, valMap); len(nested) > 0 {
+				changed[key] = nested
+			}
+		case !ok || fmt.Sprint(def) != fmt.Sprint(val):
+			changed[key] = val
+		}
+	}
+	return changed
+}
+
+func flatten(prefix string, values map[string]any, out map[string]any) {
+	for key, val := range values {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		if nested, ok := val.(map[string]any); ok {
+			flatten(key, nested, out)
+			continue
+		}
+		out[key] = val
+	}
+}
@@ -0,0 +1,99 @@
+// Package registries configures the registry mirrors the container images are pulled from, Eg:- in the
+// disconnected environments, as a drop-in of the containers registries.conf scoped to ai-services.
+package registries
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/config"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+// DropInFile is the registries.conf drop-in holding the mirrors configured by ai-services
+var DropInFile = "/etc/containers/registries.conf.d/50-ai-services.conf"
+
+// Mirror declares the mirrors of a registry, tried in order before the registry itself
+type Mirror = config.RegistryMirror
+
+// Load returns the 'registryMirrors' of the CLI config file
+func Load() ([]Mirror, error) {
+	c, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	for i, m := range c.RegistryMirrors {
+		if m.Registry == "" || len(m.Mirrors) == 0 {
+			return nil, fmt.Errorf("invalid registry mirror %d in %s: registry and mirrors are required", i+1, vars.ConfigFile)
+		}
+	}
+	return c.RegistryMirrors, nil
+}
+
+// Render returns the registries.conf (v2) drop-in declaring the mirrors
+func Render(mirrors []Mirror) []byte {
+	var b bytes.Buffer
+	b.WriteString("# Generated by ai-services from the 'registryMirrors' of " + vars.ConfigFile + ", do not edit\n")
+	for _, m := range mirrors {
+		b.WriteString("\n[[registry]]\n")
+		fmt.Fprintf(&b, "prefix = %s\n", strconv.Quote(m.Registry))
+		fmt.Fprintf(&b, "location = %s\n", strconv.Quote(m.Registry))
+		if m.Blocked {
+			b.WriteString("blocked = true\n")
+		}
+		for _, location := range m.Mirrors {
+			b.WriteString("\n[[registry.mirror]]\n")
+			fmt.Fprintf(&b, "location = %s\n", strconv.Quote(location))
+			if m.Insecure {
+				b.WriteString("insecure = true\n")
+			}
+		}
+	}
+	return b.Bytes()
+}
+
+// Configure writes the drop-in from the mirrors of the CLI config file, or removes it once no mirror is configured.
+// Returns whether the drop-in changed.
+func Configure() (bool, error) {
+	mirrors, err := Load()
+	if err != nil {
+		return false, err
+	}
+
+	current, err := os.ReadFile(DropInFile)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return false, fmt.Errorf("failed to read %s: %w", DropInFile, err)
+	}
+	exists := err == nil
+
+	if len(mirrors) == 0 {
+		if !exists {
+			return false, nil
+		}
+		if err := os.Remove(DropInFile); err != nil {
+			return false, fmt.Errorf("failed to remove %s: %w", DropInFile, err)
+		}
+		return true, nil
+	}
+
+	data := Render(mirrors)
+	if exists && bytes.Equal(current, data) {
+		return false, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(DropInFile), 0o755); err != nil {
+		return false, fmt.Errorf("failed to create %s: %w", filepath.Dir(DropInFile), err)
+	}
+	// write to a temp file and rename, so that podman never reads a partially written drop-in
+	tmp := DropInFile + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return false, fmt.Errorf("failed to write %s: %w", DropInFile, err)
+	}
+	if err := os.Rename(tmp, DropInFile); err != nil {
+		return false, fmt.Errorf("failed to write %s: %w", DropInFile, err)
+	}
+	return true, nil
+}
@@ -1,7 +1,9 @@
 package runtime
 
 import (
+	"context"
 	"io"
+	"time"
 
 	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/pkg/bindings/images"
@@ -11,16 +13,52 @@ import (
 type Runtime interface {
 	ListImages() ([]*types.ImageSummary, error)
 	PullImage(image string, options *images.PullOptions) error
+	InspectImage(nameOrID string) (*types.ImageInspectReport, error)
+	// RemoveImage removes the image, failing if a container uses it
+	RemoveImage(nameOrID string) error
 	ListPods(filters map[string][]string) (any, error)
 	CreatePod(body io.Reader) (*types.KubePlayReport, error)
 	DeletePod(id string, force *bool) error
+	// RemoveContainer removes the container, killing it first if force is set. Its volumes are kept.
+	RemoveContainer(nameOrID string, force bool) error
 	StopPod(id string) error
+	// StopPodWithTimeout stops the pod, killing the containers which did not exit within the timeout of SIGTERM
+	StopPodWithTimeout(id string, timeout time.Duration) error
 	StartPod(id string) error
+	PausePod(id string) error
+	UnpausePod(id string) error
 	InspectContainer(nameOrId string) (*define.InspectContainerData, error)
 	ListContainers(filters map[string][]string) (any, error)
 	InspectPod(nameOrId string) (*types.PodInspectReport, error)
+	// GenerateKube returns the kube YAML of the pods, as generated by 'podman kube generate'
+	GenerateKube(podNameOrIDs []string) ([]byte, error)
 	PodExists(nameOrID string) (bool, error)
 	PodLogs(nameOrID string) error
 	ContainerLogs(containerNameOrID string) error
+	StreamContainerLogs(ctx context.Context, containerNameOrID string, follow bool, stdoutChan, stderrChan chan string) error
+	// StreamContainerLogsSince streams the log lines written after since, all of them if since is zero
+	StreamContainerLogsSince(ctx context.Context, containerNameOrID string, since time.Time, follow bool, stdoutChan, stderrChan chan string) error
 	ContainerExists(nameOrID string) (bool, error)
+	RestartContainer(nameOrID string) error
+	// ExecContainer runs the command in the running container and returns its stdout, failing on a non-zero exit code
+	ExecContainer(nameOrID string, cmd []string) ([]byte, error)
+	// ContainerStats returns a single sample of the CPU, memory and I/O statistics of the running containers
+	ContainerStats(nameOrIDs []string) ([]define.ContainerStats, error)
+	KillContainer(nameOrID string, signal string) error
+	CreateVolume(name string, labels map[string]string) (*types.VolumeConfigResponse, error)
+	InspectVolume(nameOrID string) (*types.VolumeConfigResponse, error)
+	VolumeExists(nameOrID string) (bool, error)
+	RemoveVolume(nameOrID string) error
+	CreateSecret(name string, data []byte, labels map[string]string) error
+	SecretExists(nameOrID string) (bool, error)
+	SecretData(nameOrID string) ([]byte, error)
+	SecretLabels(nameOrID string) (map[string]string, error)
+	RemoveSecret(nameOrID string) error
+	// CreateNetwork creates a bridge network with DNS enabled, the containers resolving each other by name and alias
+	CreateNetwork(name string, labels map[string]string) error
+	NetworkExists(nameOrID string) (bool, error)
+	RemoveNetwork(nameOrID string) error
+	Version() (string, error)
+	// Info returns the host, storage and version information of the podman service
+	Info() (*define.Info, error)
 }
@@ -14,10 +14,12 @@ import (
 const (
 	startFlagTrue  = "--start=true"
 	startFlagFalse = "--start=false"
+	replaceFlag    = "--replace"
 )
 
 var (
 	publishFlag = "--publish=%s"
+	networkFlag = "--network=%s"
 )
 
 func RunPodmanKubePlay(body io.Reader, opts map[string]string) (*KubePlayOutput, error) {
@@ -67,16 +69,22 @@ func RunPodmanKubePlay(body io.Reader, opts map[string]string) (*KubePlayOutput,
 // Helper function to extract podIds from RunKubePlay stdout
 func extractPodIDsFromOutput(output string) []string {
 	var ids []string
+	// the volumes created from the claims of the manifest are listed before the pods
+	inPods := false
 	lines := strings.SplitSeq(output, "\n")
 	for line := range lines {
 		if strings.HasPrefix(line, "Pod") {
 			// Skip line with Pod prefix
+			inPods = true
 			continue
 		}
 		if strings.HasPrefix(line, "Container") {
 			// Break if we encounter Container prefix as it means we have collected the podIDs
 			break
 		}
+		if !inPods {
+			continue
+		}
 		// Read all the pod ids
 		id := strings.TrimSpace(line)
 		ids = append(ids, id)
@@ -108,5 +116,36 @@ func buildCmdArgs(opts map[string]string) []string {
 		}
 	}
 
+	if v, ok := opts["network"]; ok && v != "" {
+		cmdArgs = append(cmdArgs, fmt.Sprintf(networkFlag, v))
+	}
+
+	if v, ok := opts["replace"]; ok && v == "true" {
+		cmdArgs = append(cmdArgs, replaceFlag)
+	}
+
 	return append(cmdArgs, "-")
 }
+
+// CLIVersion returns the version of the local podman CLI, which plays the kube manifests
+func CLIVersion() (string, error) {
+	out, err := exec.Command("podman", "version", "--format", "{{.Client.Version}}").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to run podman version: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// KubePlayFlags reports which of the flags are supported by 'podman kube play' of the local podman CLI
+func KubePlayFlags(flags []string) (map[string]bool, error) {
+	out, err := exec.Command("podman", "kube", "play", "--help").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run podman kube play --help: %w", err)
+	}
+	help := string(out)
+	supported := make(map[string]bool, len(flags))
+	for _, flag := range flags {
+		supported[flag] = strings.Contains(help, "--"+flag+" ") || strings.Contains(help, "--"+flag+"=")
+	}
+	return supported, nil
+}
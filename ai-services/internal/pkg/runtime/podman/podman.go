@@ -1,6 +1,7 @@
 package podman
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -8,25 +9,36 @@ import (
 	"os"
 	"os/exec"
 	"os/signal"
+	"strings"
 	"syscall"
+	"time"
 
+	nettypes "github.com/containers/common/libnetwork/types"
 	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/api/handlers"
 	"github.com/containers/podman/v5/pkg/bindings"
 	"github.com/containers/podman/v5/pkg/bindings/containers"
+	"github.com/containers/podman/v5/pkg/bindings/generate"
 	"github.com/containers/podman/v5/pkg/bindings/images"
 	"github.com/containers/podman/v5/pkg/bindings/kube"
+	"github.com/containers/podman/v5/pkg/bindings/network"
 	"github.com/containers/podman/v5/pkg/bindings/pods"
+	"github.com/containers/podman/v5/pkg/bindings/secrets"
+	"github.com/containers/podman/v5/pkg/bindings/system"
+	"github.com/containers/podman/v5/pkg/bindings/volumes"
 	"github.com/containers/podman/v5/pkg/domain/entities/types"
+	dockerContainer "github.com/docker/docker/api/types/container"
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
 	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
 )
 
 type PodmanClient struct {
 	Context context.Context
 }
 
-// NewPodmanClient creates and returns a new PodmanClient instance
-func NewPodmanClient() (*PodmanClient, error) {
+// ConnectionURI returns the URI of the podman socket the client connects to
+func ConnectionURI() string {
 	// Default Podman socket URI is unix:///run/podman/podman.sock running on the local machine,
 	// but it can be overridden by the CONTAINER_HOST and CONTAINER_SSHKEY environment variable to support remote connections.
 	// Please use `podman system connection list` to see available connections.
@@ -35,10 +47,18 @@ func NewPodmanClient() (*PodmanClient, error) {
 	// export CONTAINER_HOST=ssh://root@127.0.0.1:62904/run/podman/podman.sock
 	// export CONTAINER_SSHKEY=/Users/manjunath/.local/share/containers/podman/machine/machine
 	uri := "unix:///run/podman/podman.sock"
+	if vars.Rootless {
+		uri = "unix://" + vars.RootlessSocket()
+	}
 	if v, found := os.LookupEnv("CONTAINER_HOST"); found {
 		uri = v
 	}
-	ctx, err := bindings.NewConnection(context.Background(), uri)
+	return uri
+}
+
+// NewPodmanClient creates and returns a new PodmanClient instance
+func NewPodmanClient() (*PodmanClient, error) {
+	ctx, err := bindings.NewConnection(context.Background(), ConnectionURI())
 	if err != nil {
 		return nil, err
 	}
@@ -50,6 +70,15 @@ func (pc *PodmanClient) ListImages() ([]*types.ImageSummary, error) {
 	return images.List(pc.Context, nil)
 }
 
+func (pc *PodmanClient) RemoveImage(nameOrID string) error {
+	_, errs := images.Remove(pc.Context, []string{nameOrID}, nil)
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to remove image %s: %w", nameOrID, errors.Join(errs...))
+	}
+
+	return nil
+}
+
 func (pc *PodmanClient) PullImage(image string, options *images.PullOptions) error {
 	logger.Infof("Pulling image %s...\n", image)
 	_, err := images.Pull(pc.Context, image, options)
@@ -60,6 +89,14 @@ func (pc *PodmanClient) PullImage(image string, options *images.PullOptions) err
 	return nil
 }
 
+func (pc *PodmanClient) InspectImage(nameOrID string) (*types.ImageInspectReport, error) {
+	image, err := images.GetImage(pc.Context, nameOrID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect image: %w", err)
+	}
+	return image, nil
+}
+
 func (pc *PodmanClient) ListPods(filters map[string][]string) (any, error) {
 	var listOpts pods.ListOptions
 
@@ -85,10 +122,27 @@ func (pc *PodmanClient) CreatePod(body io.Reader) (*types.KubePlayReport, error)
 }
 
 func (pc *PodmanClient) DeletePod(id string, force *bool) error {
-	_, err := pods.Remove(pc.Context, id, &pods.RemoveOptions{Force: force})
+	report, err := pods.Remove(pc.Context, id, &pods.RemoveOptions{Force: force})
 	if err != nil {
 		return fmt.Errorf("failed to delete the pod: %w", err)
 	}
+	if report != nil && report.Err != nil {
+		return fmt.Errorf("failed to delete the pod: %w", report.Err)
+	}
+
+	return nil
+}
+
+func (pc *PodmanClient) RemoveContainer(nameOrID string, force bool) error {
+	reports, err := containers.Remove(pc.Context, nameOrID, new(containers.RemoveOptions).WithForce(force).WithVolumes(false))
+	if err != nil {
+		return fmt.Errorf("failed to remove the container: %w", err)
+	}
+	for _, r := range reports {
+		if r.Err != nil {
+			return fmt.Errorf("failed to remove the container: %w", r.Err)
+		}
+	}
 
 	return nil
 }
@@ -130,6 +184,15 @@ func (pc *PodmanClient) StopPod(id string) error {
 	return nil
 }
 
+func (pc *PodmanClient) StopPodWithTimeout(id string, timeout time.Duration) error {
+	_, err := pods.Stop(pc.Context, id, new(pods.StopOptions).WithTimeout(int(timeout.Seconds())))
+	if err != nil {
+		return fmt.Errorf("failed to stop the pod: %w", err)
+	}
+
+	return nil
+}
+
 func (pc *PodmanClient) StartPod(id string) error {
 	_, err := pods.Start(pc.Context, id, &pods.StartOptions{})
 	if err != nil {
@@ -139,6 +202,31 @@ func (pc *PodmanClient) StartPod(id string) error {
 	return nil
 }
 
+// PausePod freezes the running containers of the pod, their state and devices are kept
+func (pc *PodmanClient) PausePod(id string) error {
+	report, err := pods.Pause(pc.Context, id, &pods.PauseOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to pause the pod: %w", err)
+	}
+	if len(report.Errs) > 0 {
+		return fmt.Errorf("failed to pause the pod: %w", errors.Join(report.Errs...))
+	}
+
+	return nil
+}
+
+func (pc *PodmanClient) UnpausePod(id string) error {
+	report, err := pods.Unpause(pc.Context, id, &pods.UnpauseOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to unpause the pod: %w", err)
+	}
+	if len(report.Errs) > 0 {
+		return fmt.Errorf("failed to unpause the pod: %w", errors.Join(report.Errs...))
+	}
+
+	return nil
+}
+
 func (pc *PodmanClient) InspectPod(nameOrID string) (*types.PodInspectReport, error) {
 	podInspectReport, err := pods.Inspect(pc.Context, nameOrID, nil)
 	if err != nil {
@@ -147,6 +235,15 @@ func (pc *PodmanClient) InspectPod(nameOrID string) (*types.PodInspectReport, er
 	return podInspectReport, nil
 }
 
+func (pc *PodmanClient) GenerateKube(podNameOrIDs []string) ([]byte, error) {
+	report, err := generate.Kube(pc.Context, podNameOrIDs, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate the kube YAML: %w", err)
+	}
+
+	return io.ReadAll(report.Reader)
+}
+
 func (pc *PodmanClient) PodLogs(podNameOrID string) error {
 	if podNameOrID == "" {
 		return errors.New("pod name or ID cannot be empty")
@@ -224,6 +321,215 @@ func (pc *PodmanClient) ContainerLogs(containerNameOrID string) error {
 	return err
 }
 
+// StreamContainerLogs sends the log lines of the container to the channels until the logs end (or ctx is done, when following)
+func (pc *PodmanClient) StreamContainerLogs(ctx context.Context, containerNameOrID string, follow bool, stdoutChan, stderrChan chan string) error {
+	return pc.StreamContainerLogsSince(ctx, containerNameOrID, time.Time{}, follow, stdoutChan, stderrChan)
+}
+
+func (pc *PodmanClient) StreamContainerLogsSince(ctx context.Context, containerNameOrID string, since time.Time, follow bool, stdoutChan, stderrChan chan string) error {
+	connCtx, cancel := context.WithCancel(pc.Context)
+	defer cancel()
+	stop := context.AfterFunc(ctx, cancel)
+	defer stop()
+
+	opts := &containers.LogOptions{
+		Follow: utils.BoolPtr(follow),
+		Stderr: utils.BoolPtr(true),
+		Stdout: utils.BoolPtr(true),
+	}
+	if !since.IsZero() {
+		since := since.Format(time.RFC3339Nano)
+		opts.Since = &since
+	}
+
+	err := containers.Logs(connCtx, containerNameOrID, opts, stdoutChan, stderrChan)
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	return err
+}
+
 func (pc *PodmanClient) ContainerExists(nameOrID string) (bool, error) {
 	return containers.Exists(pc.Context, nameOrID, nil)
 }
+
+func (pc *PodmanClient) RestartContainer(nameOrID string) error {
+	if err := containers.Restart(pc.Context, nameOrID, nil); err != nil {
+		return fmt.Errorf("failed to restart the container: %w", err)
+	}
+
+	return nil
+}
+
+// ExecContainer runs the command in the container, attached to its stdout and stderr
+func (pc *PodmanClient) ExecContainer(nameOrID string, cmd []string) ([]byte, error) {
+	session, err := containers.ExecCreate(pc.Context, nameOrID, &handlers.ExecCreateConfig{ExecOptions: dockerContainer.ExecOptions{
+		Cmd: cmd, AttachStdout: true, AttachStderr: true,
+	}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the exec session: %w", err)
+	}
+	defer func() {
+		_ = containers.ExecRemove(pc.Context, session, new(containers.ExecRemoveOptions).WithForce(true))
+	}()
+
+	var stdout, stderr bytes.Buffer
+	var out, errOut io.Writer = &stdout, &stderr
+	opts := new(containers.ExecStartAndAttachOptions).WithOutputStream(out).WithAttachOutput(true).
+		WithErrorStream(errOut).WithAttachError(true)
+	if err := containers.ExecStartAndAttach(pc.Context, session, opts); err != nil {
+		return nil, fmt.Errorf("failed to run the command: %w", err)
+	}
+
+	info, err := containers.ExecInspect(pc.Context, session, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect the exec session: %w", err)
+	}
+	if info.ExitCode != 0 {
+		return nil, fmt.Errorf("command exited with code %d: %s", info.ExitCode, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.Bytes(), nil
+}
+
+// ContainerStats returns a single sample of the statistics of the running containers
+func (pc *PodmanClient) ContainerStats(nameOrIDs []string) ([]define.ContainerStats, error) {
+	if len(nameOrIDs) == 0 {
+		return nil, nil
+	}
+	reports, err := containers.Stats(pc.Context, nameOrIDs, new(containers.StatsOptions).WithStream(false))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the container statistics: %w", err)
+	}
+
+	var stats []define.ContainerStats
+	for report := range reports {
+		if report.Error != nil {
+			return nil, fmt.Errorf("failed to get the container statistics: %w", report.Error)
+		}
+		stats = append(stats, report.Stats...)
+	}
+	return stats, nil
+}
+
+func (pc *PodmanClient) KillContainer(nameOrID string, signal string) error {
+	if err := containers.Kill(pc.Context, nameOrID, new(containers.KillOptions).WithSignal(signal)); err != nil {
+		return fmt.Errorf("failed to kill the container: %w", err)
+	}
+
+	return nil
+}
+
+func (pc *PodmanClient) CreateVolume(name string, labels map[string]string) (*types.VolumeConfigResponse, error) {
+	volume, err := volumes.Create(pc.Context, types.VolumeCreateOptions{Name: name, Labels: labels}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the volume: %w", err)
+	}
+
+	return volume, nil
+}
+
+func (pc *PodmanClient) InspectVolume(nameOrID string) (*types.VolumeConfigResponse, error) {
+	volume, err := volumes.Inspect(pc.Context, nameOrID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect the volume: %w", err)
+	}
+
+	return volume, nil
+}
+
+func (pc *PodmanClient) VolumeExists(nameOrID string) (bool, error) {
+	return volumes.Exists(pc.Context, nameOrID, nil)
+}
+
+func (pc *PodmanClient) RemoveVolume(nameOrID string) error {
+	if err := volumes.Remove(pc.Context, nameOrID, nil); err != nil {
+		return fmt.Errorf("failed to remove the volume: %w", err)
+	}
+
+	return nil
+}
+
+func (pc *PodmanClient) CreateNetwork(name string, labels map[string]string) error {
+	if _, err := network.Create(pc.Context, &nettypes.Network{Name: name, Driver: "bridge", DNSEnabled: true, Labels: labels}); err != nil {
+		return fmt.Errorf("failed to create the network: %w", err)
+	}
+
+	return nil
+}
+
+func (pc *PodmanClient) NetworkExists(nameOrID string) (bool, error) {
+	return network.Exists(pc.Context, nameOrID, nil)
+}
+
+func (pc *PodmanClient) RemoveNetwork(nameOrID string) error {
+	if _, err := network.Remove(pc.Context, nameOrID, nil); err != nil {
+		return fmt.Errorf("failed to remove the network: %w", err)
+	}
+
+	return nil
+}
+
+// CreateSecret creates the podman secret, replacing the existing secret with the same name
+func (pc *PodmanClient) CreateSecret(name string, data []byte, labels map[string]string) error {
+	opts := new(secrets.CreateOptions).WithName(name).WithLabels(labels).WithReplace(true)
+	if _, err := secrets.Create(pc.Context, bytes.NewReader(data), opts); err != nil {
+		return fmt.Errorf("failed to create the secret: %w", err)
+	}
+
+	return nil
+}
+
+func (pc *PodmanClient) SecretExists(nameOrID string) (bool, error) {
+	return secrets.Exists(pc.Context, nameOrID)
+}
+
+// SecretData returns the data stored in the podman secret
+func (pc *PodmanClient) SecretData(nameOrID string) ([]byte, error) {
+	report, err := secrets.Inspect(pc.Context, nameOrID, new(secrets.InspectOptions).WithShowSecret(true))
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect the secret: %w", err)
+	}
+
+	return []byte(report.SecretData), nil
+}
+
+// SecretLabels returns the labels of the podman secret
+func (pc *PodmanClient) SecretLabels(nameOrID string) (map[string]string, error) {
+	report, err := secrets.Inspect(pc.Context, nameOrID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect the secret: %w", err)
+	}
+
+	return report.Spec.Labels, nil
+}
+
+func (pc *PodmanClient) RemoveSecret(nameOrID string) error {
+	if err := secrets.Remove(pc.Context, nameOrID); err != nil {
+		return fmt.Errorf("failed to remove the secret: %w", err)
+	}
+
+	return nil
+}
+
+// Version returns the version of the podman service
+func (pc *PodmanClient) Version() (string, error) {
+	report, err := system.Version(pc.Context, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to get the podman version: %w", err)
+	}
+	if report.Server == nil {
+		return "", fmt.Errorf("failed to get the podman version: no server version")
+	}
+	return report.Server.Version, nil
+}
+
+// Info returns the host, storage and version information of the podman service
+func (pc *PodmanClient) Info() (*define.Info, error) {
+	info, err := system.Info(pc.Context, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the podman info: %w", err)
+	}
+	return info, nil
+}
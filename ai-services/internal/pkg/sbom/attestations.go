@@ -0,0 +1,91 @@
+package sbom
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/containers/image/v5/docker"
+	"github.com/containers/image/v5/manifest"
+	"github.com/containers/image/v5/types"
+)
+
+// attestationSuffixes are the tag suffixes cosign attaches the SBOMs ('cosign attach sbom') and the
+// in-toto attestations ('cosign attest') with, as <repository>:sha256-<digest>.<suffix>
+var attestationSuffixes = []string{".sbom", ".att"}
+
+// findAttestations looks up the SBOM artifacts attached to the image digests in the registry
+func findAttestations(ctx context.Context, img *Image) ([]Attestation, error) {
+	repo := repository(img.Name)
+	if repo == "" {
+		return nil, nil
+	}
+
+	digests := []string{}
+	seen := map[string]bool{}
+	for _, rd := range append([]string{repo + "@" + img.Digest}, img.RepoDigests...) {
+		_, d, ok := strings.Cut(rd, "@")
+		if !ok || d == "" || seen[d] || repository(rd) != repo {
+			continue
+		}
+		seen[d] = true
+		digests = append(digests, d)
+	}
+
+	sys := &types.SystemContext{}
+	var attestations []Attestation
+	for _, d := range digests {
+		for _, suffix := range attestationSuffixes {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			ref := fmt.Sprintf("%s:%s%s", repo, strings.Replace(d, ":", "-", 1), suffix)
+			found, err := fetchAttachedLayers(ctx, sys, ref)
+			if err != nil {
+				return nil, err
+			}
+			attestations = append(attestations, found...)
+		}
+	}
+
+	return attestations, nil
+}
+
+// fetchAttachedLayers returns the layers of the attached artifact, none if nothing is attached at ref
+func fetchAttachedLayers(ctx context.Context, sys *types.SystemContext, ref string) ([]Attestation, error) {
+	iref, err := docker.ParseReference("//" + ref)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reference %s: %w", ref, err)
+	}
+
+	src, err := iref.NewImageSource(ctx, sys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to access the registry: %w", err)
+	}
+	defer func() {
+		_ = src.Close()
+	}()
+
+	raw, mt, err := src.GetManifest(ctx, nil)
+	if err != nil {
+		// the tag is missing when nothing is attached
+		return nil, nil
+	}
+
+	m, err := manifest.FromBlob(raw, mt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the manifest of %s: %w", ref, err)
+	}
+
+	var attestations []Attestation
+	for _, layer := range m.LayerInfos() {
+		attestations = append(attestations, Attestation{
+			Reference: ref,
+			MediaType: layer.MediaType,
+			Digest:    layer.Digest.String(),
+		})
+	}
+
+	return attestations, nil
+}
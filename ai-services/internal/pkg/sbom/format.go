@@ -0,0 +1,266 @@
+package sbom
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+const toolName = "ai-services"
+
+// Render encodes the report in the given format
+func Render(report *Report, format string) ([]byte, error) {
+	var doc any
+	switch format {
+	case FormatSPDX:
+		doc = toSPDX(report)
+	case FormatCycloneDX:
+		doc = toCycloneDX(report)
+	default:
+		return nil, fmt.Errorf("unsupported SBOM format %q, supported formats: %s, %s", format, FormatSPDX, FormatCycloneDX)
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// purl returns the package URL of the image, see https://github.com/package-url/purl-spec (oci type)
+func purl(img *Image) string {
+	repo := repository(img.Name)
+	if repo == "" || img.Digest == "" {
+		return ""
+	}
+	q := url.Values{}
+	q.Set("repository_url", repo)
+	if t := tag(img.Name); t != "" {
+		q.Set("tag", t)
+	}
+	return fmt.Sprintf("pkg:oci/%s@%s?%s", path.Base(repo), url.QueryEscape(img.Digest), q.Encode())
+}
+
+func sortedLabels(labels map[string]string) []string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// SPDX 2.3 JSON, see https://spdx.github.io/spdx-spec/v2.3/
+
+type spdxDocument struct {
+	SPDXVersion       string             `json:"spdxVersion"`
+	DataLicense       string             `json:"dataLicense"`
+	SPDXID            string             `json:"SPDXID"`
+	Name              string             `json:"name"`
+	DocumentNamespace string             `json:"documentNamespace"`
+	CreationInfo      spdxCreationInfo   `json:"creationInfo"`
+	Packages          []spdxPackage      `json:"packages"`
+	Relationships     []spdxRelationship `json:"relationships"`
+}
+
+type spdxCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type spdxPackage struct {
+	SPDXID                string            `json:"SPDXID"`
+	Name                  string            `json:"name"`
+	VersionInfo           string            `json:"versionInfo,omitempty"`
+	DownloadLocation      string            `json:"downloadLocation"`
+	FilesAnalyzed         bool              `json:"filesAnalyzed"`
+	PrimaryPackagePurpose string            `json:"primaryPackagePurpose"`
+	Checksums             []spdxChecksum    `json:"checksums,omitempty"`
+	ExternalRefs          []spdxExternalRef `json:"externalRefs,omitempty"`
+	Comment               string            `json:"comment,omitempty"`
+}
+
+type spdxChecksum struct {
+	Algorithm     string `json:"algorithm"`
+	ChecksumValue string `json:"checksumValue"`
+}
+
+type spdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+	Comment           string `json:"comment,omitempty"`
+}
+
+type spdxRelationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+}
+
+func toSPDX(report *Report) *spdxDocument {
+	doc := &spdxDocument{
+		SPDXVersion:       "SPDX-2.3",
+		DataLicense:       "CC0-1.0",
+		SPDXID:            "SPDXRef-DOCUMENT",
+		Name:              report.Application,
+		DocumentNamespace: fmt.Sprintf("https://project-ai-services.io/spdx/%s-%s", report.Application, uuid()),
+		CreationInfo: spdxCreationInfo{
+			Created:  report.Created.Format(time.RFC3339),
+			Creators: []string{"Tool: " + toolName},
+		},
+	}
+
+	appID := "SPDXRef-Application"
+	doc.Packages = append(doc.Packages, spdxPackage{
+		SPDXID:                appID,
+		Name:                  report.Application,
+		VersionInfo:           report.Version,
+		DownloadLocation:      "NOASSERTION",
+		PrimaryPackagePurpose: "APPLICATION",
+		Comment:               "Application deployed from the template " + report.Template,
+	})
+	doc.Relationships = append(doc.Relationships, spdxRelationship{
+		SPDXElementID: doc.SPDXID, RelationshipType: "DESCRIBES", RelatedSPDXElement: appID,
+	})
+
+	for i, img := range report.Images {
+		pkg := spdxPackage{
+			SPDXID:                fmt.Sprintf("SPDXRef-Image-%d", i+1),
+			Name:                  repository(img.Name),
+			VersionInfo:           tag(img.Name),
+			DownloadLocation:      "NOASSERTION",
+			PrimaryPackagePurpose: "CONTAINER",
+		}
+		if hex, ok := strings.CutPrefix(img.Digest, "sha256:"); ok {
+			pkg.Checksums = []spdxChecksum{{Algorithm: "SHA256", ChecksumValue: hex}}
+		}
+		if p := purl(img); p != "" {
+			pkg.ExternalRefs = append(pkg.ExternalRefs, spdxExternalRef{
+				ReferenceCategory: "PACKAGE-MANAGER", ReferenceType: "purl", ReferenceLocator: p,
+			})
+		}
+		for _, a := range img.Attestations {
+			pkg.ExternalRefs = append(pkg.ExternalRefs, spdxExternalRef{
+				ReferenceCategory: "OTHER", ReferenceType: "attestation", ReferenceLocator: a.Reference + "@" + a.Digest, Comment: a.MediaType,
+			})
+		}
+
+		var comment []string
+		comment = append(comment, "containers: "+strings.Join(img.Containers, ", "))
+		for _, k := range sortedLabels(img.Labels) {
+			comment = append(comment, fmt.Sprintf("label %s=%s", k, img.Labels[k]))
+		}
+		pkg.Comment = strings.Join(comment, "\n")
+
+		doc.Packages = append(doc.Packages, pkg)
+		doc.Relationships = append(doc.Relationships, spdxRelationship{
+			SPDXElementID: appID, RelationshipType: "CONTAINS", RelatedSPDXElement: pkg.SPDXID,
+		})
+	}
+
+	return doc
+}
+
+// CycloneDX 1.5 JSON, see https://cyclonedx.org/docs/1.5/json/
+
+type cdxBOM struct {
+	BOMFormat    string         `json:"bomFormat"`
+	SpecVersion  string         `json:"specVersion"`
+	SerialNumber string         `json:"serialNumber"`
+	Version      int            `json:"version"`
+	Metadata     cdxMetadata    `json:"metadata"`
+	Components   []cdxComponent `json:"components"`
+}
+
+type cdxMetadata struct {
+	Timestamp string       `json:"timestamp"`
+	Tools     cdxTools     `json:"tools"`
+	Component cdxComponent `json:"component"`
+}
+
+type cdxTools struct {
+	Components []cdxComponent `json:"components"`
+}
+
+type cdxComponent struct {
+	Type               string        `json:"type"`
+	BOMRef             string        `json:"bom-ref,omitempty"`
+	Name               string        `json:"name"`
+	Version            string        `json:"version,omitempty"`
+	Hashes             []cdxHash     `json:"hashes,omitempty"`
+	PURL               string        `json:"purl,omitempty"`
+	Properties         []cdxProperty `json:"properties,omitempty"`
+	ExternalReferences []cdxExtRef   `json:"externalReferences,omitempty"`
+}
+
+type cdxHash struct {
+	Alg     string `json:"alg"`
+	Content string `json:"content"`
+}
+
+type cdxProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type cdxExtRef struct {
+	Type    string `json:"type"`
+	URL     string `json:"url"`
+	Comment string `json:"comment,omitempty"`
+}
+
+func toCycloneDX(report *Report) *cdxBOM {
+	bom := &cdxBOM{
+		BOMFormat:    "CycloneDX",
+		SpecVersion:  "1.5",
+		SerialNumber: "urn:uuid:" + uuid(),
+		Version:      1,
+		Metadata: cdxMetadata{
+			Timestamp: report.Created.Format(time.RFC3339),
+			Tools:     cdxTools{Components: []cdxComponent{{Type: "application", Name: toolName}}},
+			Component: cdxComponent{
+				Type:       "application",
+				BOMRef:     "application:" + report.Application,
+				Name:       report.Application,
+				Version:    report.Version,
+				Properties: []cdxProperty{{Name: "ai-services:template", Value: report.Template}},
+			},
+		},
+		Components: []cdxComponent{},
+	}
+
+	for _, img := range report.Images {
+		c := cdxComponent{
+			Type:    "container",
+			BOMRef:  img.ID,
+			Name:    repository(img.Name),
+			Version: tag(img.Name),
+			PURL:    purl(img),
+		}
+		if hex, ok := strings.CutPrefix(img.Digest, "sha256:"); ok {
+			c.Hashes = []cdxHash{{Alg: "SHA-256", Content: hex}}
+		}
+		for _, container := range img.Containers {
+			c.Properties = append(c.Properties, cdxProperty{Name: "ai-services:container", Value: container})
+		}
+		for _, k := range sortedLabels(img.Labels) {
+			c.Properties = append(c.Properties, cdxProperty{Name: "oci:label:" + k, Value: img.Labels[k]})
+		}
+		for _, a := range img.Attestations {
+			c.ExternalReferences = append(c.ExternalReferences, cdxExtRef{Type: "bom", URL: a.Reference + "@" + a.Digest, Comment: a.MediaType})
+		}
+		bom.Components = append(bom.Components, c)
+	}
+
+	return bom
+}
+
+// uuid returns a random (version 4) UUID
+func uuid() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
@@ -0,0 +1,175 @@
+// Package sbom collects the provenance of the container images of an application (digests, labels and the
+// SBOM attestations attached in the registries) and renders it as SPDX or CycloneDX documents
+package sbom
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/containers/podman/v5/pkg/domain/entities/types"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime"
+)
+
+// Supported output formats
+const (
+	FormatSPDX      = "spdx"
+	FormatCycloneDX = "cyclonedx"
+)
+
+// Report is the provenance of the images of an application
+type Report struct {
+	Application string    `json:"application"`
+	Template    string    `json:"template,omitempty"`
+	Version     string    `json:"version,omitempty"`
+	Created     time.Time `json:"created"`
+	Images      []*Image  `json:"images"`
+}
+
+// Image is a container image used by the application
+type Image struct {
+	// Name is the image reference the containers were created from
+	Name        string            `json:"name"`
+	ID          string            `json:"id"`
+	Digest      string            `json:"digest,omitempty"`
+	RepoDigests []string          `json:"repoDigests,omitempty"`
+	Created     *time.Time        `json:"created,omitempty"`
+	Os          string            `json:"os,omitempty"`
+	Arch        string            `json:"arch,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	// Containers are the containers running the image, as <pod>/<container>
+	Containers   []string      `json:"containers"`
+	Attestations []Attestation `json:"attestations,omitempty"`
+}
+
+// Attestation is an SBOM attached to the image in its registry
+type Attestation struct {
+	// Reference is the registry reference of the attached artifact
+	Reference string `json:"reference"`
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+}
+
+// Options configures the collection of the report
+type Options struct {
+	// Attestations looks up the SBOM attestations attached to the images in their registries
+	Attestations bool
+}
+
+// Collect gathers the images of the containers of the application
+func Collect(ctx context.Context, rt runtime.Runtime, appName string, opts Options) (*Report, error) {
+	resp, err := rt.ListPods(map[string][]string{"label": {fmt.Sprintf("ai-services.io/application=%s", appName)}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	var pods []*types.ListPodsReport
+	if val, ok := resp.([]*types.ListPodsReport); ok {
+		pods = val
+	}
+	if len(pods) == 0 {
+		return nil, fmt.Errorf("application '%s' does not exist", appName)
+	}
+
+	report := &Report{Application: appName, Created: time.Now().UTC()}
+	images := map[string]*Image{}
+	for _, pod := range pods {
+		if report.Template == "" {
+			report.Template = pod.Labels["ai-services.io/template"]
+			report.Version = pod.Labels["ai-services.io/version"]
+		}
+
+		pInfo, err := rt.InspectPod(pod.Id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to inspect pod %s: %w", pod.Name, err)
+		}
+
+		for _, container := range pInfo.Containers {
+			if container.ID == pInfo.InfraContainerID {
+				continue
+			}
+			cInfo, err := rt.InspectContainer(container.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			img, ok := images[cInfo.Image]
+			if !ok {
+				img, err = inspectImage(rt, cInfo.Image, cInfo.ImageName)
+				if err != nil {
+					return nil, err
+				}
+				images[cInfo.Image] = img
+				report.Images = append(report.Images, img)
+			}
+			img.Containers = append(img.Containers, pod.Name+"/"+container.Name)
+		}
+	}
+
+	sort.Slice(report.Images, func(i, j int) bool { return report.Images[i].Name < report.Images[j].Name })
+
+	if opts.Attestations {
+		for _, img := range report.Images {
+			attestations, err := findAttestations(ctx, img)
+			if err != nil {
+				// attestations are optional, the registry may not be reachable (air-gapped hosts)
+				logger.Warningf("failed to look up the SBOM attestations of image %s: %v\n", img.Name, err)
+				continue
+			}
+			img.Attestations = attestations
+		}
+	}
+
+	return report, nil
+}
+
+func inspectImage(rt runtime.Runtime, id, name string) (*Image, error) {
+	info, err := rt.InspectImage(id)
+	if err != nil {
+		return nil, err
+	}
+
+	img := &Image{Name: name, ID: id}
+	if info.ImageData == nil {
+		return img, nil
+	}
+
+	img.Digest = info.Digest.String()
+	img.RepoDigests = info.RepoDigests
+	img.Created = info.Created
+	img.Os = info.Os
+	img.Arch = info.Architecture
+	img.Labels = info.Labels
+	if img.Name == "" && len(info.RepoTags) > 0 {
+		img.Name = info.RepoTags[0]
+	}
+
+	return img, nil
+}
+
+// repository returns the repository of the image reference, without tag and digest
+func repository(ref string) string {
+	if idx := strings.Index(ref, "@"); idx != -1 {
+		ref = ref[:idx]
+	}
+	// a colon after the last slash separates the tag, otherwise it is the registry port
+	if idx := strings.LastIndex(ref, ":"); idx > strings.LastIndex(ref, "/") {
+		ref = ref[:idx]
+	}
+	return ref
+}
+
+// tag returns the tag of the image reference, empty if not tagged
+func tag(ref string) string {
+	if idx := strings.Index(ref, "@"); idx != -1 {
+		ref = ref[:idx]
+	}
+	if idx := strings.LastIndex(ref, ":"); idx > strings.LastIndex(ref, "/") {
+		return ref[idx+1:]
+	}
+	return ""
+}
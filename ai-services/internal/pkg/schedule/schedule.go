@@ -0,0 +1,289 @@
+// Package schedule runs recurring actions on the applications, Eg:- restarting nightly or stopping off-hours to free
+// the Spyre cards. The schedules are persisted in the state and executed by generated systemd timers, each running
+// the CLI in machine mode
+package schedule
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/state"
+)
+
+// stateName is the name of the state document holding the schedules
+const stateName = "schedules"
+
+// UnitDirectory is the directory of the generated systemd units
+var UnitDirectory = "/etc/systemd/system"
+
+// Actions run by the schedules
+const (
+	ActionStart   = "start"
+	ActionStop    = "stop"
+	ActionRestart = "restart"
+	// ActionModelRefresh downloads the models of the template again and restarts the application to load them
+	ActionModelRefresh = "model-refresh"
+)
+
+// Actions are the supported actions
+var Actions = []string{ActionStart, ActionStop, ActionRestart, ActionModelRefresh}
+
+// nameRegex is the application and template names allowed in the units, which are written as is in their Description=
+// and ExecStart= lines
+var nameRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// Schedule is a recurring action on an application
+type Schedule struct {
+	ID          string `json:"id"`
+	Application string `json:"application"`
+	Action      string `json:"action"`
+	// Template of the application, the models of which are refreshed
+	Template string `json:"template,omitempty"`
+	// Calendar is the systemd calendar event of the runs, Eg:- 'daily', 'Sat 02:00' or '*-*-* 22:00'
+	Calendar string    `json:"calendar"`
+	Created  time.Time `json:"created"`
+}
+
+// UnitName returns the name of the systemd units of the schedule, without the .service/.timer suffix
+func (s Schedule) UnitName() string {
+	return "ai-services-schedule-" + s.ID
+}
+
+// commands returns the CLI arguments run for the action, in order
+func (s Schedule) commands() [][]string {
+	stop := []string{"--machine", "application", "stop", s.Application}
+	start := []string{"--machine", "application", "start", s.Application, "--skip-logs"}
+	switch s.Action {
+	case ActionStart:
+		return [][]string{start}
+	case ActionStop:
+		return [][]string{stop}
+	case ActionRestart:
+		return [][]string{stop, start}
+	case ActionModelRefresh:
+		// the models are resolved for the application, into the shared model volume if its template uses it
+		download := []string{"--machine", "application", "model", "download", "--template", s.Template, "--app", s.Application}
+		return [][]string{download, stop, start}
+	}
+	return nil
+}
+
+// unitFuncs escape the values written in the units
+var unitFuncs = template.FuncMap{"specifiers": escapeSpecifiers}
+
+var serviceTemplate = template.Must(template.New("service").Funcs(unitFuncs).Parse(`# Generated by ai-services, do not edit. Managed with 'ai-services application schedule'
+[Unit]
+Description=ai-services {{ specifiers .Schedule.Action }} of application {{ specifiers .Schedule.Application }}
+After=podman.socket
+
+[Service]
+Type=oneshot
+{{- range .Commands }}
+ExecStart={{ . }}
+{{- end }}
+`))
+
+var timerTemplate = template.Must(template.New("timer").Funcs(unitFuncs).Parse(`# Generated by ai-services, do not edit. Managed with 'ai-services application schedule'
+[Unit]
+Description=ai-services {{ specifiers .Schedule.Action }} of application {{ specifiers .Schedule.Application }} on {{ specifiers .Schedule.Calendar }}
+
+[Timer]
+OnCalendar={{ .Schedule.Calendar }}
+Persistent=false
+
+[Install]
+WantedBy=timers.target
+`))
+
+// ValidateCalendar checks the calendar event is understood by systemd
+func ValidateCalendar(calendar string) error {
+	out, err := exec.Command("systemd-analyze", "calendar", calendar).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("invalid calendar event '%s': %s", calendar, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// Add persists the schedule and installs its systemd timer. The ID is generated from the application and action
+func Add(s Schedule) (*Schedule, error) {
+	if !slices.Contains(Actions, s.Action) {
+		return nil, fmt.Errorf("unsupported action '%s', supported actions: %s", s.Action, strings.Join(Actions, ", "))
+	}
+	if !nameRegex.MatchString(s.Application) {
+		return nil, fmt.Errorf("invalid application name %q, must consist of alphanumeric characters, '.', '_' or '-'", s.Application)
+	}
+	if s.Action == ActionModelRefresh && s.Template == "" {
+		return nil, errors.New("the template of the application is required to refresh its models")
+	}
+	if s.Template != "" && !nameRegex.MatchString(s.Template) {
+		return nil, fmt.Errorf("invalid template name %q, must consist of alphanumeric characters, '.', '_' or '-'", s.Template)
+	}
+	if err := ValidateCalendar(s.Calendar); err != nil {
+		return nil, err
+	}
+	binary, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate the ai-services binary: %w", err)
+	}
+
+	var schedules []Schedule
+	if err := state.Default().Update(stateName, &schedules, func() error {
+		for n := 1; ; n++ {
+			id := fmt.Sprintf("%s-%s-%d", s.Application, s.Action, n)
+			if !slices.ContainsFunc(schedules, func(o Schedule) bool { return o.ID == id }) {
+				s.ID = id
+				break
+			}
+		}
+		s.Created = time.Now().UTC()
+		if err := install(s, binary); err != nil {
+			return err
+		}
+		schedules = append(schedules, s)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// Remove uninstalls the systemd timer of the schedule and drops it
+func Remove(id string) (*Schedule, error) {
+	var removed *Schedule
+	var schedules []Schedule
+	if err := state.Default().Update(stateName, &schedules, func() error {
+		i := slices.IndexFunc(schedules, func(s Schedule) bool { return s.ID == id })
+		if i < 0 {
+			return fmt.Errorf("schedule %s not found", id)
+		}
+		s := schedules[i]
+		if err := uninstall(s); err != nil {
+			return err
+		}
+		removed = &s
+		schedules = slices.Delete(schedules, i, i+1)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return removed, nil
+}
+
+// RemoveAll drops the schedules of the application, Eg:- once deleted
+func RemoveAll(app string) error {
+	schedules, err := List(app)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, s := range schedules {
+		if _, err := Remove(s.ID); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// List returns the schedules of the application, all the schedules if app is empty
+func List(app string) ([]Schedule, error) {
+	var schedules []Schedule
+	if err := state.Default().Load(stateName, &schedules); err != nil {
+		return nil, err
+	}
+	if app == "" {
+		return schedules, nil
+	}
+
+	var filtered []Schedule
+	for _, s := range schedules {
+		if s.Application == app {
+			filtered = append(filtered, s)
+		}
+	}
+	return filtered, nil
+}
+
+// NextRun returns the next run of the schedule as reported by systemd, empty if unknown
+func NextRun(s Schedule) string {
+	out, err := exec.Command("systemctl", "show", s.UnitName()+".timer", "--property=NextElapseUSecRealtime", "--value").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// install writes the systemd service and timer of the schedule, and enables the timer
+func install(s Schedule, binary string) error {
+	var commands []string
+	for _, args := range s.commands() {
+		commands = append(commands, execLine(append([]string{binary}, args...)))
+	}
+	data := map[string]any{"Schedule": s, "Commands": commands}
+
+	for suffix, tmpl := range map[string]*template.Template{".service": serviceTemplate, ".timer": timerTemplate} {
+		var b bytes.Buffer
+		if err := tmpl.Execute(&b, data); err != nil {
+			return fmt.Errorf("failed to render %s unit: %w", suffix, err)
+		}
+		if err := os.WriteFile(filepath.Join(UnitDirectory, s.UnitName()+suffix), b.Bytes(), 0o644); err != nil {
+			return fmt.Errorf("failed to write %s unit: %w", suffix, err)
+		}
+	}
+
+	if err := systemctl("daemon-reload"); err != nil {
+		return err
+	}
+	return systemctl("enable", "--now", s.UnitName()+".timer")
+}
+
+// uninstall disables the timer of the schedule and removes its units
+func uninstall(s Schedule) error {
+	// the timer may already be gone, removing the units is what matters
+	_ = systemctl("disable", "--now", s.UnitName()+".timer")
+	for _, suffix := range []string{".service", ".timer"} {
+		if err := os.Remove(filepath.Join(UnitDirectory, s.UnitName()+suffix)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove %s unit: %w", suffix, err)
+		}
+	}
+	return systemctl("daemon-reload")
+}
+
+// execLine returns the command line of an ExecStart= setting, quoting the arguments with spaces or quotes and
+// escaping the specifiers and the variables systemd would expand, Eg:- a binary installed under a path with a space
+func execLine(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		arg = strings.ReplaceAll(escapeSpecifiers(arg), "$", "$$")
+		if strings.ContainsAny(arg, " \t\"'\\") {
+			arg = `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(arg) + `"`
+		}
+		quoted[i] = arg
+	}
+	return strings.Join(quoted, " ")
+}
+
+// escapeSpecifiers escapes the % specifiers expanded by systemd in the unit settings
+func escapeSpecifiers(value string) string {
+	return strings.ReplaceAll(value, "%", "%%")
+}
+
+func systemctl(args ...string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, "systemctl", args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to run systemctl %s: %v, output: %s", strings.Join(args, " "), err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
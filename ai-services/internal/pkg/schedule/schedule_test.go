@@ -0,0 +1,120 @@
+package schedule
+
+import (
+	"os/exec"
+	"reflect"
+	"testing"
+)
+
+func TestValidateCalendar(t *testing.T) {
+	if _, err := exec.LookPath("systemd-analyze"); err != nil {
+		t.Skip("systemd-analyze is not installed")
+	}
+
+	tests := []struct {
+		calendar string
+		wantErr  bool
+	}{
+		{calendar: "daily"},
+		{calendar: "weekly"},
+		{calendar: "Sat 02:00"},
+		{calendar: "Mon..Fri 20:00"},
+		{calendar: "*-*-* 22:00"},
+		{calendar: "*-*-01 03:30:00"},
+		{calendar: "", wantErr: true},
+		{calendar: "nightly", wantErr: true},
+		{calendar: "25:00", wantErr: true},
+		{calendar: "Sat 02:00\nOnBootSec=1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.calendar, func(t *testing.T) {
+			err := ValidateCalendar(tt.calendar)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCalendar(%q) error = %v, wantErr %v", tt.calendar, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCommands(t *testing.T) {
+	stop := []string{"--machine", "application", "stop", "my-app"}
+	start := []string{"--machine", "application", "start", "my-app", "--skip-logs"}
+
+	tests := []struct {
+		action string
+		want   [][]string
+	}{
+		{action: ActionStart, want: [][]string{start}},
+		{action: ActionStop, want: [][]string{stop}},
+		{action: ActionRestart, want: [][]string{stop, start}},
+		{
+			action: ActionModelRefresh,
+			want:   [][]string{{"--machine", "application", "model", "download", "--template", "rag", "--app", "my-app"}, stop, start},
+		},
+		{action: "pause", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.action, func(t *testing.T) {
+			s := Schedule{Application: "my-app", Action: tt.action, Template: "rag"}
+			if got := s.commands(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("commands() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecLine(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{
+			name: "plain",
+			args: []string{"/usr/bin/ai-services", "--machine", "application", "stop", "my-app"},
+			want: "/usr/bin/ai-services --machine application stop my-app",
+		},
+		{
+			name: "space",
+			args: []string{"/opt/ai services/ai-services", "application"},
+			want: `"/opt/ai services/ai-services" application`,
+		},
+		{
+			name: "quotes",
+			args: []string{`/opt/a"b\c`},
+			want: `"/opt/a\"b\\c"`,
+		},
+		{
+			name: "specifiers and variables",
+			args: []string{"/opt/100%/$HOME/ai-services"},
+			want: "/opt/100%%/$$HOME/ai-services",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := execLine(tt.args); got != tt.want {
+				t.Errorf("execLine(%q) = %s, want %s", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddInvalidNames(t *testing.T) {
+	tests := []Schedule{
+		{Application: "my-app\nExecStartPre=/bin/sh", Action: ActionRestart, Calendar: "daily"},
+		{Application: "my app", Action: ActionRestart, Calendar: "daily"},
+		{Application: "-app", Action: ActionRestart, Calendar: "daily"},
+		{Application: "my-app", Action: ActionModelRefresh, Template: "rag; rm", Calendar: "daily"},
+	}
+
+	for _, s := range tests {
+		t.Run(s.Application+"/"+s.Template, func(t *testing.T) {
+			if _, err := Add(s); err == nil {
+				t.Errorf("Add(%+v) error = nil, want an invalid name error", s)
+			}
+		})
+	}
+}
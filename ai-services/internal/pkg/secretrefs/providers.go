@@ -0,0 +1,225 @@
+package secretrefs
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/config"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+// requestTimeout bounds the requests to the secret managers
+const requestTimeout = 30 * time.Second
+
+// VaultConfig is the 'secretProviders.vault' of the CLI config file, the VAULT_ADDR, VAULT_TOKEN and VAULT_NAMESPACE
+// environment variables taking precedence
+type VaultConfig = config.Vault
+
+// CyberArkConfig is the 'secretProviders.cyberark' of the CLI config file, to retrieve the secrets from the
+// Central Credential Provider (CCP) of CyberArk
+type CyberArkConfig = config.CyberArk
+
+// Config is the 'secretProviders' of the CLI config file
+type Config = config.SecretProviders
+
+// Load returns the 'secretProviders' of the CLI config file
+func Load() (*Config, error) {
+	c, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	return &c.SecretProviders, nil
+}
+
+// envProvider resolves env://<variable> from the environment of the CLI
+type envProvider struct{}
+
+func (envProvider) Resolve(_ context.Context, ref Ref) ([]byte, error) {
+	val, ok := os.LookupEnv(ref.Path)
+	if !ok {
+		return nil, fmt.Errorf("environment variable %s is not set", ref.Path)
+	}
+	return []byte(val), nil
+}
+
+// fileProvider resolves file:///<path> from a file of the host, without its trailing newline
+type fileProvider struct{}
+
+func (fileProvider) Resolve(_ context.Context, ref Ref) ([]byte, error) {
+	data, err := os.ReadFile(ref.Path)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(strings.TrimRight(string(data), "\r\n")), nil
+}
+
+// vaultProvider resolves vault://<path>#<key> from the HashiCorp Vault API, <path> being the API path of the secret,
+// Eg:- secret/data/rag for the KV version 2 engine mounted at secret
+type vaultProvider struct{}
+
+func (vaultProvider) Resolve(ctx context.Context, ref Ref) ([]byte, error) {
+	if ref.Key == "" {
+		return nil, errors.New("the key of the vault secret is required, Eg:- vault://secret/data/rag#token")
+	}
+	cfg, err := Load()
+	if err != nil {
+		return nil, err
+	}
+	vault := VaultConfig{}
+	if cfg.Vault != nil {
+		vault = *cfg.Vault
+	}
+	address := envOr("VAULT_ADDR", vault.Address)
+	if address == "" {
+		return nil, fmt.Errorf("the vault address is not configured, set VAULT_ADDR or secretProviders.vault.address in %s", vars.ConfigFile)
+	}
+	token := os.Getenv("VAULT_TOKEN")
+	if token == "" && vault.TokenFile != "" {
+		data, err := os.ReadFile(vault.TokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read vault token: %w", err)
+		}
+		token = strings.TrimSpace(string(data))
+	}
+	if token == "" {
+		return nil, fmt.Errorf("the vault token is not configured, set VAULT_TOKEN or secretProviders.vault.tokenFile in %s", vars.ConfigFile)
+	}
+
+	client, err := httpClient(vault.CAFile, "", "")
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(address, "/")+"/v1/"+strings.TrimPrefix(ref.Path, "/"), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", token)
+	if namespace := envOr("VAULT_NAMESPACE", vault.Namespace); namespace != "" {
+		req.Header.Set("X-Vault-Namespace", namespace)
+	}
+
+	var resp struct {
+		Data map[string]any `json:"data"`
+	}
+	if err := getJSON(client, req, &resp); err != nil {
+		return nil, err
+	}
+	// the KV version 2 engine nests the keys of the secret under data.data
+	data := resp.Data
+	if nested, ok := data["data"].(map[string]any); ok {
+		data = nested
+	}
+	return field(data, ref.Key)
+}
+
+// cyberArkProvider resolves cyberark://<safe>/<object>[#<property>] from the Central Credential Provider, the
+// password of the account by default
+type cyberArkProvider struct{}
+
+func (cyberArkProvider) Resolve(ctx context.Context, ref Ref) ([]byte, error) {
+	safe, object, ok := strings.Cut(ref.Path, "/")
+	if !ok || safe == "" || object == "" {
+		return nil, errors.New("the reference must be cyberark://<safe>/<object>[#<property>]")
+	}
+	cfg, err := Load()
+	if err != nil {
+		return nil, err
+	}
+	if cfg.CyberArk == nil || cfg.CyberArk.URL == "" || cfg.CyberArk.AppID == "" {
+		return nil, fmt.Errorf("the CyberArk provider is not configured, set secretProviders.cyberark.url and appID in %s", vars.ConfigFile)
+	}
+	cyberArk := cfg.CyberArk
+
+	client, err := httpClient(cyberArk.CAFile, cyberArk.CertFile, cyberArk.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	query := url.Values{"AppID": {cyberArk.AppID}, "Safe": {safe}, "Object": {object}}
+	endpoint := strings.TrimSuffix(cyberArk.URL, "/") + "/AIMWebService/api/Accounts?" + query.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	account := map[string]any{}
+	if err := getJSON(client, req, &account); err != nil {
+		return nil, err
+	}
+	property := ref.Key
+	if property == "" {
+		property = "Content"
+	}
+	return field(account, property)
+}
+
+func envOr(name, fallback string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+	return fallback
+}
+
+// field returns the string field of the secret
+func field(data map[string]any, key string) ([]byte, error) {
+	val, ok := data[key]
+	if !ok {
+		return nil, fmt.Errorf("the secret has no key '%s'", key)
+	}
+	s, ok := val.(string)
+	if !ok {
+		return nil, fmt.Errorf("the key '%s' of the secret is not a string", key)
+	}
+	return []byte(s), nil
+}
+
+// httpClient returns the client trusting the CA file, authenticating with the client certificate if set
+func httpClient(caFile, certFile, keyFile string) (*http.Client, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificate found in CA file %s", caFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if certFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return &http.Client{Timeout: requestTimeout, Transport: &http.Transport{TLSClientConfig: tlsConfig, Proxy: http.ProxyFromEnvironment}}, nil
+}
+
+// getJSON sends the request and decodes the JSON response. The body of an error response is not reported, as it may
+// echo the secret
+func getJSON(client *http.Client, req *http.Request, out any) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return fmt.Errorf("%s responded with %s", req.URL.Host, resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("invalid response from %s: %w", req.URL.Host, err)
+	}
+	return nil
+}
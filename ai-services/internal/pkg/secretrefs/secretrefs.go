@@ -0,0 +1,159 @@
+// Package secretrefs resolves the secrets referenced from the template values as 'secretRef:' URIs, Eg:-
+//
+//	hfToken: secretRef:env://HF_TOKEN
+//	db.password: secretRef:file:///run/secrets/db-password
+//	apiToken: secretRef:vault://secret/data/rag#token
+//	llm.password: secretRef:cyberark://RAG-Safe/llm-account
+//
+// The references are resolved just-in-time when the application is rendered, by the provider of their scheme. The
+// resolved value is stored as a podman secret under the key 'value', and the template value is replaced by the name
+// of the podman secret, to be referenced by the templates as a secretKeyRef or a secret volume:
+//
+//	valueFrom:
+//	  secretKeyRef:
+//	    name: {{ .Values.hfToken }}
+//	    key: value
+//
+// so that the clear text is never written to the rendered manifests, the revisions or any file.
+package secretrefs
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Prefix marks a template value as a secret reference
+const Prefix = "secretRef:"
+
+// DataKey is the key of the podman secret holding the resolved value
+const DataKey = "value"
+
+// Ref is a parsed secret reference, <scheme>://<path>[#<key>]
+type Ref struct {
+	Scheme string
+	Path   string
+	// Key selects a field of the secret, Eg:- of a Vault secret holding several keys
+	Key string
+}
+
+func (r Ref) String() string {
+	s := r.Scheme + "://" + r.Path
+	if r.Key != "" {
+		s += "#" + r.Key
+	}
+	return s
+}
+
+// Provider resolves the secret references of a scheme
+type Provider interface {
+	Resolve(ctx context.Context, ref Ref) ([]byte, error)
+}
+
+var (
+	mu        sync.RWMutex
+	providers = map[string]Provider{}
+)
+
+// Register makes the provider resolve the references of the scheme, replacing the provider registered for it
+func Register(scheme string, provider Provider) {
+	mu.Lock()
+	defer mu.Unlock()
+	providers[scheme] = provider
+}
+
+// Schemes returns the sorted schemes having a provider
+func Schemes() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return slices.Sorted(maps.Keys(providers))
+}
+
+func init() {
+	Register("env", envProvider{})
+	Register("file", fileProvider{})
+	Register("vault", vaultProvider{})
+	Register("cyberark", cyberArkProvider{})
+}
+
+// IsRef reports whether the template value is a secret reference
+func IsRef(value any) bool {
+	s, ok := value.(string)
+	return ok && strings.HasPrefix(s, Prefix)
+}
+
+// Parse parses the secret reference, with or without its 'secretRef:' prefix
+func Parse(value string) (Ref, error) {
+	raw := strings.TrimPrefix(value, Prefix)
+	scheme, rest, ok := strings.Cut(raw, "://")
+	if !ok || scheme == "" || rest == "" {
+		return Ref{}, fmt.Errorf("invalid secret reference %q, expected %s<scheme>://<path>[#<key>]", value, Prefix)
+	}
+	path, key, _ := strings.Cut(rest, "#")
+	if path == "" {
+		return Ref{}, fmt.Errorf("invalid secret reference %q, the path is empty", value)
+	}
+	return Ref{Scheme: scheme, Path: path, Key: key}, nil
+}
+
+// Resolve returns the value of the secret reference from the provider of its scheme
+func Resolve(ctx context.Context, value string) ([]byte, error) {
+	ref, err := Parse(value)
+	if err != nil {
+		return nil, err
+	}
+	mu.RLock()
+	provider, ok := providers[ref.Scheme]
+	mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unsupported secret reference scheme '%s', supported schemes: %s", ref.Scheme, strings.Join(Schemes(), ", "))
+	}
+
+	data, err := provider.Resolve(ctx, ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve secret reference %s: %w", ref, err)
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("secret reference %s resolved to an empty value", ref)
+	}
+	return data, nil
+}
+
+// Find returns the secret references of the values, by their dotted keys
+func Find(values map[string]any) map[string]string {
+	refs := map[string]string{}
+	find("", values, refs)
+	return refs
+}
+
+func find(prefix string, values map[string]any, refs map[string]string) {
+	for key, val := range values {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		if nested, ok := val.(map[string]any); ok {
+			find(key, nested, refs)
+			continue
+		}
+		if IsRef(val) {
+			refs[key] = val.(string)
+		}
+	}
+}
+
+// SecretName returns the name of the podman secret holding the resolved value of the dotted key of the application
+func SecretName(appName, key string) string {
+	name := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-' {
+			return r
+		}
+		if r >= 'A' && r <= 'Z' {
+			return r + 'a' - 'A'
+		}
+		return '-'
+	}, key)
+	return appName + "--ref-" + name
+}
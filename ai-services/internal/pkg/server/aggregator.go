@@ -0,0 +1,118 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/config"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+	"github.com/project-ai-services/ai-services/pkg/aiservices"
+)
+
+// Defaults of the health aggregator
+const (
+	DefaultAggregatorListen = ":8090"
+	defaultAggregatorTTL    = 5 * time.Second
+)
+
+// AggregatorConfig is the 'healthAggregator' section of the CLI config file
+type AggregatorConfig struct {
+	config.HealthAggregator
+
+	ttl time.Duration
+}
+
+// LoadAggregatorConfig returns the 'healthAggregator' section of the CLI config file
+func LoadAggregatorConfig() (AggregatorConfig, error) {
+	c, err := config.Load()
+	if err != nil {
+		return AggregatorConfig{}, err
+	}
+	cfg := AggregatorConfig{HealthAggregator: c.HealthAggregator}
+
+	if cfg.Listen == "" {
+		cfg.Listen = DefaultAggregatorListen
+	}
+	cfg.ttl = defaultAggregatorTTL
+	if cfg.CacheTTL != "" {
+		if cfg.ttl, err = time.ParseDuration(cfg.CacheTTL); err != nil || cfg.ttl < 0 {
+			return cfg, fmt.Errorf("invalid healthAggregator.cacheTTL '%s' in %s", cfg.CacheTTL, vars.ConfigFile)
+		}
+	}
+	return cfg, nil
+}
+
+// Aggregator serves the health of all the deployed applications, unauthenticated, for the external load balancers
+// and monitors to probe a single URL per host. The response is 200 while the applications are serving, healthy or
+// degraded, and 503 otherwise, with the health of each component.
+type Aggregator struct {
+	client *aiservices.Client
+	ttl    time.Duration
+
+	mu      sync.Mutex
+	summary *aiservices.HealthSummary
+	expires time.Time
+}
+
+// NewAggregator returns the aggregator of the config
+func NewAggregator(client *aiservices.Client, cfg AggregatorConfig) *Aggregator {
+	return &Aggregator{client: client, ttl: cfg.ttl}
+}
+
+// Handler returns the routes of the aggregator
+//
+//	GET /health         health of all the applications
+//	GET /health/{name}  health of the application
+func (a *Aggregator) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /health", a.health)
+	mux.HandleFunc("GET /health/{name}", a.applicationHealth)
+	return mux
+}
+
+func (a *Aggregator) health(w http.ResponseWriter, r *http.Request) {
+	summary, err := a.cached(r)
+	if err != nil {
+		writeError(w, http.StatusServiceUnavailable, err)
+		return
+	}
+	writeJSON(w, healthStatusCode(summary), summary)
+}
+
+func (a *Aggregator) applicationHealth(w http.ResponseWriter, r *http.Request) {
+	summary, err := a.client.HealthSummary(r.Context(), r.PathValue("name"))
+	if errors.Is(err, aiservices.ErrApplicationNotFound) {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusServiceUnavailable, err)
+		return
+	}
+	writeJSON(w, healthStatusCode(summary), summary)
+}
+
+// cached returns the health of all the applications, collected at most once per TTL
+func (a *Aggregator) cached(r *http.Request) (*aiservices.HealthSummary, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.summary != nil && time.Now().Before(a.expires) {
+		return a.summary, nil
+	}
+	summary, err := a.client.HealthSummary(r.Context(), "")
+	if err != nil {
+		return nil, err
+	}
+	a.summary, a.expires = summary, time.Now().Add(a.ttl)
+	return summary, nil
+}
+
+func healthStatusCode(summary *aiservices.HealthSummary) int {
+	if summary.Available() {
+		return http.StatusOK
+	}
+	return http.StatusServiceUnavailable
+}
@@ -0,0 +1,300 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/pkg/aiservices"
+	apiv1 "github.com/project-ai-services/ai-services/pkg/api/v1"
+)
+
+// ServiceName is the name of the gRPC management service, defined by pkg/api/v1/management.proto
+const ServiceName = "aiservices.v1.Management"
+
+// GRPCServer returns the gRPC server of the management service, requiring the bearer token of the server
+// in the 'authorization' metadata on every call
+func (s *Server) GRPCServer(opts ...grpc.ServerOption) *grpc.Server {
+	opts = append(opts,
+		grpc.UnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			if err := s.authenticateRPC(ctx, info.FullMethod); err != nil {
+				return nil, err
+			}
+			resp, err := handler(ctx, req)
+			return resp, rpcError(err)
+		}),
+		grpc.StreamInterceptor(func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			if err := s.authenticateRPC(ss.Context(), info.FullMethod); err != nil {
+				return err
+			}
+			return rpcError(handler(srv, ss))
+		}),
+	)
+
+	gs := grpc.NewServer(opts...)
+	apiv1.RegisterManagementServer(gs, &managementServer{s: s})
+	return gs
+}
+
+// managementServer implements the gRPC management service on top of the server
+type managementServer struct {
+	apiv1.UnimplementedManagementServer
+	s *Server
+}
+
+func (m *managementServer) ListApplications(ctx context.Context, _ *apiv1.ListApplicationsRequest) (*apiv1.ListApplicationsResponse, error) {
+	apps, err := m.s.client.ListApplications(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp := &apiv1.ListApplicationsResponse{}
+	for _, app := range apps {
+		resp.Applications = append(resp.Applications, toApplication(app))
+	}
+	return resp, nil
+}
+
+func (m *managementServer) GetApplication(ctx context.Context, req *apiv1.ApplicationRequest) (*apiv1.Application, error) {
+	app, err := m.s.client.GetApplication(ctx, req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	return toApplication(*app), nil
+}
+
+func (m *managementServer) DeleteApplication(ctx context.Context, req *apiv1.DeleteApplicationRequest) (*apiv1.Operation, error) {
+	if _, err := m.s.client.GetApplication(ctx, req.GetName()); err != nil {
+		return nil, err
+	}
+	op, err := m.s.startDelete(req.GetName(), aiservices.DeleteOptions{
+		KeepSMTLevel: req.GetKeepSmtLevel(),
+		Purge:        req.GetPurge(),
+		GracePeriod:  req.GetGracePeriod().AsDuration(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toOperation(op)
+}
+
+func (m *managementServer) GetOperation(_ context.Context, req *apiv1.OperationRequest) (*apiv1.Operation, error) {
+	op, ok := m.s.ops.get(req.GetId())
+	if !ok {
+		return nil, ErrOperationNotFound
+	}
+	return toOperation(op)
+}
+
+func (m *managementServer) ListTemplates(ctx context.Context, _ *apiv1.ListTemplatesRequest) (*apiv1.ListTemplatesResponse, error) {
+	tmpls, err := m.s.client.ListTemplates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp := &apiv1.ListTemplatesResponse{}
+	for _, tmpl := range tmpls {
+		resp.Templates = append(resp.Templates, &apiv1.Template{Name: tmpl.Name, Version: tmpl.Version, Parameters: tmpl.Parameters})
+	}
+	return resp, nil
+}
+
+func (m *managementServer) Validate(ctx context.Context, req *apiv1.ValidateRequest) (*apiv1.ValidateResponse, error) {
+	results, passed, err := m.s.client.Validate(ctx, req.GetSkip())
+	if err != nil {
+		return nil, err
+	}
+	resp := &apiv1.ValidateResponse{Passed: passed}
+	for _, r := range results {
+		resp.Results = append(resp.Results, &apiv1.ValidationResult{
+			Name:    r.Name,
+			Passed:  r.Passed,
+			Skipped: r.Skipped,
+			Warning: r.Warning,
+			Message: r.Message,
+			Hint:    r.Hint,
+		})
+	}
+	return resp, nil
+}
+
+// CreateApplication starts the deployment and streams its progress until it finishes.
+// The deployment carries on if the client goes away, it can be watched again with WatchOperation.
+func (m *managementServer) CreateApplication(req *apiv1.CreateApplicationRequest, stream grpc.ServerStreamingServer[apiv1.OperationUpdate]) error {
+	opts := fromCreateRequest(req)
+	if err := validateRemoteCreate(opts); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if _, err := m.s.client.GetTemplate(stream.Context(), opts.Template); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	op, err := m.s.startCreate(opts)
+	if err != nil {
+		return err
+	}
+	return m.watchOperation(op.ID, stream)
+}
+
+func (m *managementServer) WatchOperation(req *apiv1.OperationRequest, stream grpc.ServerStreamingServer[apiv1.OperationUpdate]) error {
+	return m.watchOperation(req.GetId(), stream)
+}
+
+func (m *managementServer) StreamLogs(req *apiv1.StreamLogsRequest, stream grpc.ServerStreamingServer[apiv1.LogLine]) error {
+	opts := aiservices.LogsOptions{Pod: req.GetPod(), Container: req.GetContainer(), Follow: req.GetFollow()}
+	return m.s.client.StreamLogs(stream.Context(), req.GetApplication(), opts, func(line aiservices.LogLine) error {
+		return stream.Send(&apiv1.LogLine{Pod: line.Pod, Container: line.Container, Stream: line.Stream, Line: line.Line})
+	})
+}
+
+// watchOperation streams the progress events of the operation, followed by the finished operation
+func (m *managementServer) watchOperation(id string, stream grpc.ServerStreamingServer[apiv1.OperationUpdate]) error {
+	op, err := m.s.ops.watch(stream.Context(), id, func(event aiservices.ProgressEvent) error {
+		return stream.Send(&apiv1.OperationUpdate{Update: &apiv1.OperationUpdate_Event{Event: toProgressEvent(event)}})
+	})
+	if err != nil {
+		return err
+	}
+	op.Events = nil
+	update, err := toOperation(op)
+	if err != nil {
+		return err
+	}
+	return stream.Send(&apiv1.OperationUpdate{Update: &apiv1.OperationUpdate_Operation{Operation: update}})
+}
+
+func (s *Server) authenticateRPC(ctx context.Context, method string) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, auth := range md.Get("authorization") {
+		token, ok := strings.CutPrefix(auth, "Bearer ")
+		if ok && subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) == 1 {
+			logger.Infof("gRPC %s\n", method, 2)
+			return nil
+		}
+	}
+	return status.Error(codes.Unauthenticated, "missing or invalid bearer token")
+}
+
+// rpcError maps the errors to the gRPC status codes
+func rpcError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	switch {
+	case errors.Is(err, aiservices.ErrApplicationNotFound), errors.Is(err, ErrOperationNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, ErrOperationInProgress):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// fromCreateRequest returns the create options of the request, which carries none of the options referring to the
+// files of the host
+func fromCreateRequest(req *apiv1.CreateApplicationRequest) aiservices.CreateOptions {
+	opts := aiservices.CreateOptions{
+		Name:               req.GetName(),
+		Template:           req.GetTemplate(),
+		Environment:        req.GetEnvironment(),
+		Params:             req.GetParams(),
+		Labels:             req.GetLabels(),
+		Annotations:        req.GetAnnotations(),
+		SkipImageDownload:  req.GetSkipImageDownload(),
+		SkipModelDownload:  req.GetSkipModelDownload(),
+		SkipSmokeTests:     req.GetSkipSmokeTests(),
+		SkipResourceCheck:  req.GetSkipResourceCheck(),
+		Force:              req.GetForce(),
+		ForceSMTLevel:      req.GetForceSmtLevel(),
+		ScanImages:         req.GetScanImages(),
+		ScanFailOn:         req.GetScanFailOn(),
+		AcceptModelLicense: req.GetAcceptModelLicense(),
+		Health: aiservices.HealthOverrides{
+			ReadinessTimeout: req.GetHealth().GetReadinessTimeout().AsDuration(),
+			Interval:         req.GetHealth().GetInterval().AsDuration(),
+			Disabled:         req.GetHealth().GetDisabled(),
+		},
+		TLS:            aiservices.TLSOptions{Enabled: req.GetTls().GetEnabled(), Hosts: req.GetTls().GetHosts()},
+		GenerateAPIKey: req.GetGenerateApiKey(),
+	}
+	if retry := req.GetRetry(); retry != nil {
+		opts.Retry = &aiservices.RetryPolicy{
+			Attempts:   int(retry.GetAttempts()),
+			Backoff:    retry.GetBackoff().AsDuration(),
+			MaxBackoff: retry.GetMaxBackoff().AsDuration(),
+		}
+	}
+	return opts
+}
+
+func toApplication(app aiservices.Application) *apiv1.Application {
+	a := &apiv1.Application{Name: app.Name, Template: app.Template, Version: app.Version}
+	for _, pod := range app.Pods {
+		a.Pods = append(a.Pods, &apiv1.Pod{Id: pod.ID, Name: pod.Name, Status: pod.Status})
+	}
+	return a
+}
+
+// toOperation converts the operation, its result is encoded with the same schema as the REST API
+func toOperation(op Operation) (*apiv1.Operation, error) {
+	o := &apiv1.Operation{
+		Id:          op.ID,
+		Type:        op.Type,
+		Application: op.Application,
+		Status:      string(op.Status),
+		Error:       op.Error,
+		StartedAt:   timestamppb.New(op.StartedAt),
+	}
+	if op.FinishedAt != nil {
+		o.FinishedAt = timestamppb.New(*op.FinishedAt)
+	}
+	for _, event := range op.Events {
+		o.Events = append(o.Events, toProgressEvent(event))
+	}
+	if op.Result != nil {
+		data, err := json.Marshal(op.Result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode the result of the operation: %w", err)
+		}
+		o.Result = &structpb.Value{}
+		if err := protojson.Unmarshal(data, o.Result); err != nil {
+			return nil, fmt.Errorf("failed to encode the result of the operation: %w", err)
+		}
+	}
+	return o, nil
+}
+
+func toProgressEvent(event aiservices.ProgressEvent) *apiv1.ProgressEvent {
+	e := &apiv1.ProgressEvent{
+		Time:    timestamppb.New(event.Time),
+		Stage:   string(event.Stage),
+		Message: event.Message,
+		Layer:   int32(event.Layer),
+		Pod:     event.Pod,
+	}
+	for _, t := range event.Timings {
+		e.Timings = append(e.Timings, &apiv1.Timing{
+			Stage:    t.Stage,
+			Layer:    int32(t.Layer),
+			Pod:      t.Pod,
+			Image:    t.Image,
+			Duration: durationpb.New(t.Duration),
+		})
+	}
+	return e
+}
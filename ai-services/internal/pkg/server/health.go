@@ -0,0 +1,45 @@
+package server
+
+import (
+	"net/http"
+	"time"
+)
+
+// Health is the liveness of the server, served unauthenticated on /healthz for the supervisors to detect a hung
+// server or deployment
+type Health struct {
+	Status  string    `json:"status"`
+	Started time.Time `json:"started"`
+	// Operation is the running operation, if any
+	Operation *OperationHealth `json:"operation,omitempty"`
+}
+
+// OperationHealth is the progress of the running operation, stuck once LastProgress stops advancing
+type OperationHealth struct {
+	ID           string    `json:"id"`
+	Type         string    `json:"type"`
+	Application  string    `json:"application"`
+	StartedAt    time.Time `json:"startedAt"`
+	LastProgress time.Time `json:"lastProgress"`
+	// Phase is the last progress event of the operation
+	Phase string `json:"phase,omitempty"`
+}
+
+func (s *Server) healthz(w http.ResponseWriter, r *http.Request) {
+	health := Health{Status: "ok", Started: s.started}
+	if op, ok := s.ops.current(); ok {
+		opHealth := &OperationHealth{
+			ID:           op.ID,
+			Type:         op.Type,
+			Application:  op.Application,
+			StartedAt:    op.StartedAt,
+			LastProgress: op.StartedAt,
+		}
+		if len(op.Events) > 0 {
+			last := op.Events[len(op.Events)-1]
+			opHealth.LastProgress, opHealth.Phase = last.Time, string(last.Stage)+": "+last.Message
+		}
+		health.Operation = opHealth
+	}
+	writeJSON(w, http.StatusOK, health)
+}
@@ -0,0 +1,198 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/project-ai-services/ai-services/pkg/aiservices"
+)
+
+// ErrOperationInProgress is returned when an operation is started while another one is running.
+// Deployments mutate host wide state (SMT level, Spyre card allocation), hence are run one at a time.
+var ErrOperationInProgress = errors.New("another operation is in progress")
+
+// ErrOperationNotFound is returned for unknown operation IDs, including the operations expired after finishing
+var ErrOperationNotFound = errors.New("operation not found")
+
+// operationRetention is how long the finished operations are kept for their callers to fetch the outcome
+const operationRetention = time.Hour
+
+// OperationStatus is the status of a long running operation
+type OperationStatus string
+
+const (
+	OperationRunning   OperationStatus = "running"
+	OperationSucceeded OperationStatus = "succeeded"
+	OperationFailed    OperationStatus = "failed"
+)
+
+// Operation is a long running operation, e.g. the deployment of an application
+type Operation struct {
+	ID          string                     `json:"id"`
+	Type        string                     `json:"type"`
+	Application string                     `json:"application"`
+	Status      OperationStatus            `json:"status"`
+	Error       string                     `json:"error,omitempty"`
+	StartedAt   time.Time                  `json:"startedAt"`
+	FinishedAt  *time.Time                 `json:"finishedAt,omitempty"`
+	Events      []aiservices.ProgressEvent `json:"events,omitempty"`
+	// Result is the outcome reported by the operation, Eg:- the deployment result of a create
+	Result any `json:"result,omitempty"`
+}
+
+// trackedOperation is an operation along with the channel closed on its next change
+type trackedOperation struct {
+	Operation
+	changed chan struct{}
+}
+
+// operations tracks the operations started by the server, in memory
+type operations struct {
+	mu      sync.Mutex
+	ctx     context.Context
+	ops     map[string]*trackedOperation
+	running bool
+}
+
+func newOperations(ctx context.Context) *operations {
+	return &operations{ctx: ctx, ops: map[string]*trackedOperation{}}
+}
+
+// start runs fn in the background as a new operation, recording the progress events and the result reported by fn
+func (o *operations) start(opType, appName string, fn func(ctx context.Context, progress aiservices.ProgressFunc) (any, error)) (Operation, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.running {
+		return Operation{}, ErrOperationInProgress
+	}
+	o.prune()
+
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return Operation{}, err
+	}
+
+	op := &trackedOperation{
+		Operation: Operation{
+			ID:          hex.EncodeToString(id),
+			Type:        opType,
+			Application: appName,
+			Status:      OperationRunning,
+			StartedAt:   time.Now(),
+		},
+		changed: make(chan struct{}),
+	}
+	o.ops[op.ID] = op
+	o.running = true
+
+	go func() {
+		result, err := fn(o.ctx, func(event aiservices.ProgressEvent) {
+			o.update(op, func() { op.Events = append(op.Events, event) })
+		})
+
+		o.update(op, func() {
+			now := time.Now()
+			op.FinishedAt = &now
+			op.Status = OperationSucceeded
+			op.Result = result
+			if err != nil {
+				op.Status = OperationFailed
+				op.Error = err.Error()
+			}
+			o.running = false
+		})
+	}()
+
+	return op.snapshot(), nil
+}
+
+// prune drops the operations finished for longer than the retention. The caller holds the lock.
+func (o *operations) prune() {
+	cutoff := time.Now().Add(-operationRetention)
+	for id, op := range o.ops {
+		if op.FinishedAt != nil && op.FinishedAt.Before(cutoff) {
+			delete(o.ops, id)
+		}
+	}
+}
+
+// update mutates the operation and wakes up its watchers
+func (o *operations) update(op *trackedOperation, fn func()) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	fn()
+	close(op.changed)
+	op.changed = make(chan struct{})
+}
+
+// get returns a copy of the operation
+func (o *operations) get(id string) (Operation, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.prune()
+
+	op, ok := o.ops[id]
+	if !ok {
+		return Operation{}, false
+	}
+	return op.snapshot(), true
+}
+
+// watch invokes fn for every progress event of the operation, past and future, until the operation finishes.
+// Returns the finished operation.
+func (o *operations) watch(ctx context.Context, id string, fn func(aiservices.ProgressEvent) error) (Operation, error) {
+	sent := 0
+	for {
+		o.mu.Lock()
+		op, ok := o.ops[id]
+		if !ok {
+			o.mu.Unlock()
+			return Operation{}, ErrOperationNotFound
+		}
+		snapshot := op.snapshot()
+		changed := op.changed
+		o.mu.Unlock()
+
+		for ; sent < len(snapshot.Events); sent++ {
+			if err := fn(snapshot.Events[sent]); err != nil {
+				return Operation{}, err
+			}
+		}
+		if snapshot.Status != OperationRunning {
+			return snapshot, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return Operation{}, ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+// snapshot copies the operation, to be read without holding the lock
+func (op *trackedOperation) snapshot() Operation {
+	cp := op.Operation
+	cp.Events = append([]aiservices.ProgressEvent(nil), op.Events...)
+	return cp
+}
+
+// current returns the running operation, if any
+func (o *operations) current() (Operation, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for _, op := range o.ops {
+		if op.Status == OperationRunning {
+			return op.snapshot(), true
+		}
+	}
+	return Operation{}, false
+}
@@ -0,0 +1,237 @@
+// Package server exposes the ai-services operations over an authenticated REST API
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/pkg/aiservices"
+)
+
+// Server serves the REST API backed by the aiservices client
+type Server struct {
+	client *aiservices.Client
+	token  string
+	ops    *operations
+	// started is the time the server was created, reported by /healthz
+	started time.Time
+}
+
+// New creates a server requiring the given bearer token on every request.
+// Operations started by the server are cancelled once ctx is done.
+func New(ctx context.Context, client *aiservices.Client, token string) *Server {
+	return &Server{client: client, token: token, ops: newOperations(ctx), started: time.Now().UTC()}
+}
+
+// Handler returns the http handler of the REST API
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/applications", s.listApplications)
+	mux.HandleFunc("POST /api/v1/applications", s.createApplication)
+	mux.HandleFunc("GET /api/v1/applications/{name}", s.getApplication)
+	mux.HandleFunc("DELETE /api/v1/applications/{name}", s.deleteApplication)
+	mux.HandleFunc("GET /api/v1/operations/{id}", s.getOperation)
+	mux.HandleFunc("GET /api/v1/templates", s.listTemplates)
+	mux.HandleFunc("GET /api/v1/templates/{name}", s.getTemplate)
+	mux.HandleFunc("POST /api/v1/validate", s.validate)
+
+	// the liveness probe is served unauthenticated, for the supervisors not holding the API token
+	root := http.NewServeMux()
+	root.HandleFunc("GET /healthz", s.healthz)
+	root.Handle("/", s.authenticate(mux))
+	return root
+}
+
+// authenticate rejects the requests without the expected bearer token
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+			writeError(w, http.StatusUnauthorized, errors.New("missing or invalid bearer token"))
+			return
+		}
+		logger.Infof("%s %s from %s\n", r.Method, r.URL.Path, r.RemoteAddr, 2)
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) listApplications(w http.ResponseWriter, r *http.Request) {
+	apps, err := s.client.ListApplications(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, apps)
+}
+
+func (s *Server) getApplication(w http.ResponseWriter, r *http.Request) {
+	app, err := s.client.GetApplication(r.Context(), r.PathValue("name"))
+	if err != nil {
+		writeError(w, statusFor(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, app)
+}
+
+func (s *Server) createApplication(w http.ResponseWriter, r *http.Request) {
+	var opts aiservices.CreateOptions
+	if err := json.NewDecoder(r.Body).Decode(&opts); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	if err := validateRemoteCreate(opts); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if _, err := s.client.GetTemplate(r.Context(), opts.Template); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	op, err := s.startCreate(opts)
+	if err != nil {
+		writeError(w, statusFor(err), err)
+		return
+	}
+	writeJSON(w, http.StatusAccepted, op)
+}
+
+func (s *Server) deleteApplication(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	if _, err := s.client.GetApplication(r.Context(), name); err != nil {
+		writeError(w, statusFor(err), err)
+		return
+	}
+
+	opts := aiservices.DeleteOptions{
+		KeepSMTLevel: r.URL.Query().Get("keepSMT") == "true",
+		Purge:        r.URL.Query().Get("purge") == "true",
+	}
+	if val := r.URL.Query().Get("gracePeriod"); val != "" {
+		d, err := time.ParseDuration(val)
+		if err != nil || d <= 0 {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid gracePeriod '%s'", val))
+			return
+		}
+		opts.GracePeriod = d
+	}
+	op, err := s.startDelete(name, opts)
+	if err != nil {
+		writeError(w, statusFor(err), err)
+		return
+	}
+	writeJSON(w, http.StatusAccepted, op)
+}
+
+func (s *Server) getOperation(w http.ResponseWriter, r *http.Request) {
+	op, ok := s.ops.get(r.PathValue("id"))
+	if !ok {
+		writeError(w, http.StatusNotFound, ErrOperationNotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, op)
+}
+
+func (s *Server) listTemplates(w http.ResponseWriter, r *http.Request) {
+	tmpls, err := s.client.ListTemplates(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, tmpls)
+}
+
+func (s *Server) getTemplate(w http.ResponseWriter, r *http.Request) {
+	tmpl, err := s.client.GetTemplate(r.Context(), r.PathValue("name"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, tmpl)
+}
+
+// validate runs the validation checks of the host, skipping the checks in the 'skip' query param
+func (s *Server) validate(w http.ResponseWriter, r *http.Request) {
+	results, passed, err := s.client.Validate(r.Context(), r.URL.Query()["skip"])
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"passed": passed, "results": results})
+}
+
+// validateRemoteCreate checks the create options received from a remote caller. The options naming files of the
+// server host (values files, signature policy, TLS certificate) or widening the host paths the templates may mount
+// are refused, the server would read or expose them on behalf of the caller otherwise
+func validateRemoteCreate(opts aiservices.CreateOptions) error {
+	if opts.Name == "" || opts.Template == "" {
+		return errors.New("name and template are required")
+	}
+
+	var refused []string
+	if len(opts.ValuesFiles) > 0 {
+		refused = append(refused, "valuesFiles")
+	}
+	if opts.SignaturePolicy != "" {
+		refused = append(refused, "signaturePolicy")
+	}
+	if opts.TLS.CertFile != "" || opts.TLS.KeyFile != "" {
+		refused = append(refused, "tls.certFile", "tls.keyFile")
+	}
+	if len(opts.AllowedHostPaths) > 0 {
+		refused = append(refused, "allowedHostPaths")
+	}
+	if len(refused) > 0 {
+		return fmt.Errorf("%s cannot be set through the API, pass the values as params instead", strings.Join(refused, ", "))
+	}
+	return nil
+}
+
+// startCreate deploys the application in the background
+func (s *Server) startCreate(opts aiservices.CreateOptions) (Operation, error) {
+	return s.ops.start("create", opts.Name, func(ctx context.Context, progress aiservices.ProgressFunc) (any, error) {
+		opts.Progress = progress
+		result, err := s.client.Create(ctx, opts)
+		if result == nil {
+			return nil, err
+		}
+		return result, err
+	})
+}
+
+// startDelete deletes the application in the background
+func (s *Server) startDelete(name string, opts aiservices.DeleteOptions) (Operation, error) {
+	return s.ops.start("delete", name, func(ctx context.Context, _ aiservices.ProgressFunc) (any, error) {
+		return nil, s.client.DeleteApplication(ctx, name, opts)
+	})
+}
+
+func statusFor(err error) int {
+	switch {
+	case errors.Is(err, aiservices.ErrApplicationNotFound), errors.Is(err, ErrOperationNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrOperationInProgress):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logger.Warningf("failed to write response: %v\n", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,54 @@
+package server
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/state"
+)
+
+// stateName is the name of the state document holding the API token of the server
+const stateName = "server"
+
+type serverState struct {
+	Token string `json:"token"`
+}
+
+// LoadToken returns the API token read from tokenFile. When no file is given, the token persisted in the state
+// store is returned, generating it on first use. Returns true if the token has just been generated.
+func LoadToken(tokenFile string) (string, bool, error) {
+	if tokenFile != "" {
+		data, err := os.ReadFile(tokenFile)
+		if err != nil {
+			return "", false, fmt.Errorf("failed to read token file: %w", err)
+		}
+		token := strings.TrimSpace(string(data))
+		if token == "" {
+			return "", false, fmt.Errorf("token file '%s' is empty", tokenFile)
+		}
+		return token, false, nil
+	}
+
+	var st serverState
+	generated := false
+	err := state.Default().Update(stateName, &st, func() error {
+		if st.Token != "" {
+			return nil
+		}
+		raw := make([]byte, 32)
+		if _, err := rand.Read(raw); err != nil {
+			return fmt.Errorf("failed to generate token: %w", err)
+		}
+		st.Token = hex.EncodeToString(raw)
+		generated = true
+		return nil
+	})
+	if err != nil {
+		return "", false, err
+	}
+
+	return st.Token, generated, nil
+}
@@ -0,0 +1,39 @@
+package specs
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// ValidateKinds checks every YAML document of the rendered template parses, and is one of the expected kinds
+func ValidateKinds(data []byte, kinds ...string) error {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for i := 1; ; i++ {
+		var doc struct {
+			APIVersion string `yaml:"apiVersion"`
+			Kind       string `yaml:"kind"`
+		}
+		err := decoder.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			if i == 1 {
+				return errors.New("rendered template is empty")
+			}
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("document %d is not valid YAML: %w", i, err)
+		}
+
+		if doc.APIVersion == "" || doc.Kind == "" {
+			return fmt.Errorf("document %d is missing apiVersion or kind", i)
+		}
+		if !slices.Contains(kinds, doc.Kind) {
+			return fmt.Errorf("document %d is of kind %s, expected one of: %v", i, doc.Kind, kinds)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package specs
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// Kinds of the documents of a pod template
+const (
+	KindPod                   = "Pod"
+	KindConfigMap             = "ConfigMap"
+	KindPersistentVolumeClaim = "PersistentVolumeClaim"
+)
+
+// PodTemplateKinds are the kinds a pod template can declare: its Pod, and the ConfigMaps and PersistentVolumeClaims
+// kube play creates along with it
+var PodTemplateKinds = []string{KindPod, KindConfigMap, KindPersistentVolumeClaim}
+
+// Object is a ConfigMap or a PersistentVolumeClaim declared by a pod template
+type Object struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+// SplitPod separates the Pod document of the manifest from the other documents, returned as a multi-document YAML
+// in their original order. The manifest must hold a single Pod.
+func SplitPod(data []byte) ([]byte, []byte, error) {
+	var pod []byte
+	var objects bytes.Buffer
+	err := eachDocument(data, func(kind string, node *yaml.Node) error {
+		out, err := yaml.Marshal(node)
+		if err != nil {
+			return err
+		}
+		if kind == KindPod {
+			if pod != nil {
+				return errors.New("manifest declares more than one Pod")
+			}
+			pod = out
+			return nil
+		}
+		objects.WriteString("---\n")
+		objects.Write(out)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if pod == nil {
+		return nil, nil, errors.New("manifest declares no Pod")
+	}
+	// a manifest without object is returned untouched, as most of them
+	if objects.Len() == 0 {
+		return data, nil, nil
+	}
+	return pod, objects.Bytes(), nil
+}
+
+// ParseObjects returns the kind and name of the documents of the objects, filtered by kinds if any given
+func ParseObjects(data []byte, kinds ...string) ([]Object, error) {
+	var objects []Object
+	err := eachDocument(data, func(kind string, node *yaml.Node) error {
+		if len(kinds) > 0 && !slices.Contains(kinds, kind) {
+			return nil
+		}
+		var doc struct {
+			Metadata struct {
+				Name string `yaml:"name"`
+			} `yaml:"metadata"`
+		}
+		if err := node.Decode(&doc); err != nil {
+			return err
+		}
+		objects = append(objects, Object{Kind: kind, Name: doc.Metadata.Name})
+		return nil
+	})
+	return objects, err
+}
+
+// FilterObjects returns the documents of the objects of the given kinds
+func FilterObjects(data []byte, kinds ...string) ([]byte, error) {
+	var filtered bytes.Buffer
+	err := eachDocument(data, func(kind string, node *yaml.Node) error {
+		if !slices.Contains(kinds, kind) {
+			return nil
+		}
+		out, err := yaml.Marshal(node)
+		if err != nil {
+			return err
+		}
+		filtered.WriteString("---\n")
+		filtered.Write(out)
+		return nil
+	})
+	return filtered.Bytes(), err
+}
+
+// eachDocument calls fn with the kind and the node of every non empty YAML document
+func eachDocument(data []byte, fn func(kind string, node *yaml.Node) error) error {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for i := 1; ; i++ {
+		var node yaml.Node
+		err := decoder.Decode(&node)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("document %d is not valid YAML: %w", i, err)
+		}
+		if len(node.Content) == 0 {
+			continue
+		}
+		var doc struct {
+			Kind string `yaml:"kind"`
+		}
+		if err := node.Decode(&doc); err != nil {
+			return fmt.Errorf("document %d: %w", i, err)
+		}
+		if err := fn(doc.Kind, &node); err != nil {
+			return fmt.Errorf("document %d: %w", i, err)
+		}
+	}
+}
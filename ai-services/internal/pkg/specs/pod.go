@@ -1,6 +1,14 @@
 package specs
 
-import "github.com/project-ai-services/ai-services/internal/pkg/models"
+import (
+	"fmt"
+	"slices"
+
+	v1 "github.com/containers/podman/v5/pkg/k8s.io/api/core/v1"
+	k8syaml "sigs.k8s.io/yaml"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/models"
+)
 
 func FetchPodAnnotations(podspec models.PodSpec) map[string]string {
 	return podspec.Annotations
@@ -13,3 +21,38 @@ func FetchContainerNames(podspec models.PodSpec) []string {
 	}
 	return containerNames
 }
+
+// ParsePodSpec parses the rendered pod template into pod spec
+func ParsePodSpec(data []byte) (*models.PodSpec, error) {
+	var spec models.PodSpec
+	if err := k8syaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("unable to read YAML as Kube Pod: %w", err)
+	}
+	return &spec, nil
+}
+
+// MarshalPodSpec converts the pod spec back to yaml, ready to be consumed by kube play
+func MarshalPodSpec(podSpec *models.PodSpec) ([]byte, error) {
+	data, err := k8syaml.Marshal(podSpec)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal Kube Pod: %w", err)
+	}
+	return data, nil
+}
+
+// AddVolumeMount adds the volume to the pod (if not present already) and mounts it in the given container
+func AddVolumeMount(podSpec *models.PodSpec, containerName string, volume v1.Volume, mount v1.VolumeMount) error {
+	idx := slices.IndexFunc(podSpec.Spec.Containers, func(c v1.Container) bool { return c.Name == containerName })
+	if idx == -1 {
+		return fmt.Errorf("container '%s' not found in pod '%s'", containerName, podSpec.Name)
+	}
+
+	if !slices.ContainsFunc(podSpec.Spec.Volumes, func(v v1.Volume) bool { return v.Name == volume.Name }) {
+		podSpec.Spec.Volumes = append(podSpec.Spec.Volumes, volume)
+	}
+
+	mount.Name = volume.Name
+	podSpec.Spec.Containers[idx].VolumeMounts = append(podSpec.Spec.Containers[idx].VolumeMounts, mount)
+
+	return nil
+}
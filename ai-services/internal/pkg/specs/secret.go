@@ -0,0 +1,46 @@
+package specs
+
+import (
+	"fmt"
+
+	v1 "github.com/containers/podman/v5/pkg/k8s.io/api/core/v1"
+	k8syaml "sigs.k8s.io/yaml"
+)
+
+const (
+	SecretTypeOpaque v1.SecretType = "Opaque"
+	SecretTypeTLS    v1.SecretType = "kubernetes.io/tls"
+)
+
+// MarshalSecret builds a kube Secret. When stored as a podman secret, kube play mounts the keys of
+// the secret as files of a secret volume, and resolves the secretKeyRef env of the containers
+func MarshalSecret(name string, secretType v1.SecretType, data map[string][]byte) ([]byte, error) {
+	secret := v1.Secret{Type: secretType, Data: data}
+	secret.APIVersion = "v1"
+	secret.Kind = "Secret"
+	secret.Name = name
+
+	out, err := k8syaml.Marshal(secret)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal Kube Secret: %w", err)
+	}
+	return out, nil
+}
+
+// ParseSecret reads the kube Secret
+func ParseSecret(data []byte) (*v1.Secret, error) {
+	var secret v1.Secret
+	if err := k8syaml.Unmarshal(data, &secret); err != nil {
+		return nil, fmt.Errorf("unable to read YAML as Kube Secret: %w", err)
+	}
+	return &secret, nil
+}
+
+// ParseSecretData returns the data of the kube Secret
+func ParseSecretData(data []byte) (map[string][]byte, error) {
+	secret, err := ParseSecret(data)
+	if err != nil {
+		return nil, err
+	}
+	return secret.Data, nil
+}
@@ -0,0 +1,83 @@
+// Package spyre holds the ledger of the Spyre cards allocated manually, Eg:- to workloads not managed by
+// ai-services on shared hosts. The cards of the ledger are never assigned to the applications until deallocated.
+package spyre
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/state"
+)
+
+// stateName is the name of the state document holding the ledger
+const stateName = "spyre"
+
+// Allocation is a Spyre card allocated manually
+type Allocation struct {
+	// Address is the PCI address of the card
+	Address string `json:"address"`
+	// Owner is the workload the card is allocated to
+	Owner     string    `json:"owner"`
+	Reason    string    `json:"reason,omitempty"`
+	Allocated time.Time `json:"allocated"`
+}
+
+// NormalizeAddress returns the PCI address in the lspci format, Eg:- '0381:50:00.0'
+func NormalizeAddress(address string) string {
+	return strings.ToLower(strings.TrimSpace(address))
+}
+
+// Allocate adds the card to the ledger
+func Allocate(address, owner, reason string) (*Allocation, error) {
+	a := Allocation{Address: NormalizeAddress(address), Owner: owner, Reason: reason, Allocated: time.Now().UTC()}
+
+	var ledger []Allocation
+	if err := state.Default().Update(stateName, &ledger, func() error {
+		if i := slices.IndexFunc(ledger, func(o Allocation) bool { return o.Address == a.Address }); i >= 0 {
+			return fmt.Errorf("spyre card %s is already allocated to %s", a.Address, ledger[i].Owner)
+		}
+		ledger = append(ledger, a)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// Deallocate removes the card from the ledger, returning its allocation
+func Deallocate(address string) (*Allocation, error) {
+	address = NormalizeAddress(address)
+
+	var removed *Allocation
+	var ledger []Allocation
+	if err := state.Default().Update(stateName, &ledger, func() error {
+		i := slices.IndexFunc(ledger, func(a Allocation) bool { return a.Address == address })
+		if i < 0 {
+			return fmt.Errorf("spyre card %s is not allocated", address)
+		}
+		a := ledger[i]
+		removed = &a
+		ledger = slices.Delete(ledger, i, i+1)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return removed, nil
+}
+
+// List returns the allocations of the ledger
+func List() ([]Allocation, error) {
+	var ledger []Allocation
+	if err := state.Default().Load(stateName, &ledger); err != nil {
+		return nil, err
+	}
+	return ledger, nil
+}
+
+// Allocated reports whether the card is allocated in the ledger
+func Allocated(ledger []Allocation, address string) bool {
+	address = NormalizeAddress(address)
+	return slices.ContainsFunc(ledger, func(a Allocation) bool { return a.Address == address })
+}
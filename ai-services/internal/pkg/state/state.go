@@ -0,0 +1,123 @@
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+// Store persists the ai-services state as json documents under a directory.
+// Each document is stored in its own file: <dir>/<name>.json
+type Store struct {
+	dir string
+}
+
+// New creates a store rooted at dir
+func New(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Default returns the store rooted at the default state directory
+func Default() *Store {
+	return New(vars.StateDirectory)
+}
+
+// Load reads the document into v. If the document doesn't exist yet, v is left untouched.
+func (s *Store) Load(name string, v any) error {
+	data, err := os.ReadFile(s.path(name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read state %s: %w", name, err)
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to parse state %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// Save writes v as the document atomically
+func (s *Store) Save(name string, v any) error {
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal state %s: %w", name, err)
+	}
+
+	// write to a temp file and rename, so that a crash never leaves a partially written document
+	tmp, err := os.CreateTemp(s.dir, "."+name+"-")
+	if err != nil {
+		return fmt.Errorf("failed to write state %s: %w", name, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write state %s: %w", name, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write state %s: %w", name, err)
+	}
+
+	if err := os.Rename(tmp.Name(), s.path(name)); err != nil {
+		return fmt.Errorf("failed to write state %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// Update loads the document into v, invokes fn to mutate it and saves it back.
+// The store is locked for the duration of the update to guard against concurrent ai-services processes.
+func (s *Store) Update(name string, v any, fn func() error) error {
+	unlock, err := s.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := s.Load(name, v); err != nil {
+		return err
+	}
+
+	if err := fn(); err != nil {
+		return err
+	}
+
+	return s.Save(name, v)
+}
+
+func (s *Store) path(name string) string {
+	return filepath.Join(s.dir, name+".json")
+}
+
+func (s *Store) lock() (func(), error) {
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	f, err := os.OpenFile(filepath.Join(s.dir, ".lock"), os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open state lock: %w", err)
+	}
+
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to lock state: %w", err)
+	}
+
+	return func() {
+		_ = syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+		f.Close()
+	}, nil
+}
@@ -0,0 +1,157 @@
+// Package telemetry reports anonymized usage of the CLI to a configurable endpoint, helping the maintainers
+// prioritize. Telemetry is strictly opt-in, disabled until enabled with 'ai-services telemetry on'.
+//
+// Only the command path (never its arguments), the name of the embedded template deployed, the failure category,
+// the duration, the CLI version and the platform are reported, along with a random installation ID. Application
+// names, values, hostnames and error messages are never reported.
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"runtime"
+	"time"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/state"
+)
+
+// stateName is the name of the state document holding the telemetry settings
+const stateName = "telemetry"
+
+// DisableEnv disables the telemetry for a single invocation when set to 'off', regardless of the settings
+const DisableEnv = "AI_SERVICES_TELEMETRY"
+
+// sendTimeout bounds the time spent reporting, the telemetry never slows down the CLI noticeably
+var sendTimeout = 2 * time.Second
+
+// Settings are the telemetry settings of the host
+type Settings struct {
+	Enabled  bool   `json:"enabled"`
+	Endpoint string `json:"endpoint,omitempty"`
+	// InstallID is a random ID of the installation, distinguishing the hosts without identifying them
+	InstallID string `json:"installID,omitempty"`
+}
+
+// Failure categories of the events
+const (
+	FailureUsage      = "usage"
+	FailureValidation = "validation"
+	FailureNotFound   = "not-found"
+	FailureError      = "error"
+)
+
+// Event is the usage of a command
+type Event struct {
+	InstallID       string  `json:"installID"`
+	Command         string  `json:"command"`
+	Template        string  `json:"template,omitempty"`
+	Success         bool    `json:"success"`
+	FailureCategory string  `json:"failureCategory,omitempty"`
+	DurationSeconds float64 `json:"durationSeconds"`
+	Version         string  `json:"version"`
+	Platform        string  `json:"platform"`
+	Time            string  `json:"time"`
+}
+
+// Load returns the telemetry settings, disabled if never configured
+func Load() (*Settings, error) {
+	settings := &Settings{}
+	if err := state.Default().Load(stateName, settings); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// Enable opts in the telemetry, reporting to the endpoint. The endpoint of the previous opt-in is kept if empty
+func Enable(endpoint string) (*Settings, error) {
+	var settings Settings
+	if err := state.Default().Update(stateName, &settings, func() error {
+		if endpoint != "" {
+			settings.Endpoint = endpoint
+		}
+		if settings.Endpoint == "" {
+			return errors.New("an endpoint is required to enable the telemetry")
+		}
+		if u, err := url.Parse(settings.Endpoint); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return fmt.Errorf("invalid telemetry endpoint '%s', expected an http(s) URL", settings.Endpoint)
+		}
+		if settings.InstallID == "" {
+			id := make([]byte, 16)
+			if _, err := rand.Read(id); err != nil {
+				return fmt.Errorf("failed to generate installation ID: %w", err)
+			}
+			settings.InstallID = hex.EncodeToString(id)
+		}
+		settings.Enabled = true
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// Disable opts out of the telemetry, the installation ID is dropped so that a later opt-in is not linked to the
+// previous reports
+func Disable() error {
+	var settings Settings
+	return state.Default().Update(stateName, &settings, func() error {
+		settings.Enabled = false
+		settings.InstallID = ""
+		return nil
+	})
+}
+
+// Report sends the event if the telemetry is enabled. Failures are never surfaced to the user
+func Report(event Event) {
+	if os.Getenv(DisableEnv) == "off" {
+		return
+	}
+	settings, err := Load()
+	if err != nil || !settings.Enabled || settings.Endpoint == "" {
+		return
+	}
+
+	event.InstallID = settings.InstallID
+	event.Platform = runtime.GOOS + "/" + runtime.GOARCH
+	event.Time = time.Now().UTC().Format(time.RFC3339)
+
+	if err := send(settings.Endpoint, event); err != nil {
+		logger.Infof("Unable to report telemetry: %v\n", err, 2)
+	}
+}
+
+func send(endpoint string, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("endpoint responded with %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,351 @@
+// Package updater updates the ai-services binary from a release endpoint, or through the RPM repositories
+// when the binary is installed by an RPM package.
+//
+// The release endpoint serves a JSON manifest of the latest release:
+//
+//	{
+//	  "version": "v0.3.0",
+//	  "binaries": {
+//	    "linux/ppc64le": {"url": "https://.../ai-services", "sha256": "<hex digest>", "signature": "<base64 ed25519 signature>"}
+//	  }
+//	}
+//
+// The signature is the ed25519 signature of the release statement of the binary (see signedPayload), binding its
+// digest to the version and platform of the release, verified against the public key built into the binary. A
+// binary of an older release cannot be served under a newer version, and the releases not newer than the running
+// version are refused.
+package updater
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+)
+
+var (
+	// Below values will be overriden during build
+	// DefaultEndpoint is the URL of the release manifest
+	DefaultEndpoint string = ""
+	// PublicKey is the base64 encoded ed25519 public key the release binaries are signed with
+	PublicKey string = ""
+)
+
+const rpmPackage = "ai-services"
+
+// Manifest describes the latest release served by the release endpoint
+type Manifest struct {
+	Version  string            `json:"version"`
+	Binaries map[string]Binary `json:"binaries"`
+}
+
+// Binary is the release binary of a platform
+type Binary struct {
+	URL       string `json:"url"`
+	SHA256    string `json:"sha256"`
+	Signature string `json:"signature"`
+}
+
+// Release is the outcome of an update check
+type Release struct {
+	Current         string `json:"current"`
+	Latest          string `json:"latest"`
+	UpdateAvailable bool   `json:"updateAvailable"`
+	// Source is either the release endpoint URL or 'rpm'
+	Source string `json:"source"`
+
+	platform string
+	binary   Binary
+}
+
+// Options configures the update
+type Options struct {
+	// Endpoint is the URL of the release manifest, defaults to DefaultEndpoint
+	Endpoint string
+}
+
+// Check looks for a newer release than the current version
+func Check(ctx context.Context, current string, opts Options) (*Release, error) {
+	if InstalledByRPM() {
+		return checkRPM(ctx, current)
+	}
+
+	endpoint := opts.Endpoint
+	if endpoint == "" {
+		endpoint = DefaultEndpoint
+	}
+	if endpoint == "" {
+		return nil, errors.New("no release endpoint configured, provide it with --endpoint")
+	}
+
+	manifest, err := fetchManifest(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	platform := runtime.GOOS + "/" + runtime.GOARCH
+	binary, ok := manifest.Binaries[platform]
+	if !ok {
+		return nil, fmt.Errorf("release %s has no binary for platform %s", manifest.Version, platform)
+	}
+
+	return &Release{
+		Current:         current,
+		Latest:          manifest.Version,
+		UpdateAvailable: IsNewer(manifest.Version, current),
+		Source:          endpoint,
+		platform:        platform,
+		binary:          binary,
+	}, nil
+}
+
+// Update replaces the running binary with the release binary, once its checksum and signature are verified
+func Update(ctx context.Context, release *Release) error {
+	if release.Source == "rpm" {
+		return updateRPM(ctx)
+	}
+
+	if PublicKey == "" {
+		return errors.New("no trusted public key built into this binary to verify the release signature")
+	}
+	publicKey, err := decodePublicKey(PublicKey)
+	if err != nil {
+		return err
+	}
+
+	// the version is only trusted once the signature is verified below, refuse the downgrades upfront though
+	if !IsNewer(release.Latest, release.Current) {
+		return fmt.Errorf("release %s is not newer than the running version %s", release.Latest, release.Current)
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate the running binary: %w", err)
+	}
+	exe, err = filepath.EvalSymlinks(exe)
+	if err != nil {
+		return fmt.Errorf("failed to locate the running binary: %w", err)
+	}
+
+	// download next to the binary, so that the rename replacing it is atomic
+	tmp, err := os.CreateTemp(filepath.Dir(exe), ".ai-services-update-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+	}()
+
+	logger.Infof("Downloading %s\n", release.binary.URL)
+	data, err := download(ctx, release.binary.URL)
+	if err != nil {
+		return err
+	}
+
+	if err := verify(data, release, publicKey); err != nil {
+		return fmt.Errorf("failed to verify release %s: %w", release.Latest, err)
+	}
+	logger.Infoln("Checksum and signature of the release binary verified", 2)
+
+	if _, err := tmp.Write(data); err != nil {
+		return fmt.Errorf("failed to write the release binary: %w", err)
+	}
+	if err := tmp.Chmod(0755); err != nil {
+		return fmt.Errorf("failed to set permissions of the release binary: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write the release binary: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), exe); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", exe, err)
+	}
+
+	return nil
+}
+
+// InstalledByRPM tells whether the running binary is owned by the RPM package
+func InstalledByRPM() bool {
+	exe, err := os.Executable()
+	if err != nil {
+		return false
+	}
+	if _, err := exec.LookPath("rpm"); err != nil {
+		return false
+	}
+	return exec.Command("rpm", "-qf", exe).Run() == nil
+}
+
+// IsNewer compares the dotted versions (with an optional 'v' prefix and pre-release suffix).
+// An unknown current version is always older.
+func IsNewer(latest, current string) bool {
+	l, lok := parseVersion(latest)
+	c, cok := parseVersion(current)
+	if !lok {
+		return false
+	}
+	if !cok {
+		return true
+	}
+	for i := range l {
+		if l[i] != c[i] {
+			return l[i] > c[i]
+		}
+	}
+	return false
+}
+
+func parseVersion(v string) ([3]int, bool) {
+	var parsed [3]int
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	// drop pre-release and build metadata, Eg:- 0.3.0-rc1, 0.3.0-4-gabcdef
+	if idx := strings.IndexAny(v, "-+"); idx != -1 {
+		v = v[:idx]
+	}
+	parts := strings.Split(v, ".")
+	if len(parts) == 0 || len(parts) > 3 {
+		return parsed, false
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return parsed, false
+		}
+		parsed[i] = n
+	}
+	return parsed, true
+}
+
+// signedPayload is the release statement signed for a release binary
+//
+//	ai-services <version> <os>/<arch> sha256:<hex digest>
+func signedPayload(version, platform, digest string) []byte {
+	return []byte(fmt.Sprintf("ai-services %s %s sha256:%s", version, platform, strings.ToLower(digest)))
+}
+
+// verify checks the binary against the digest of the manifest, and the signature of the release statement binding
+// the digest to the version and platform of the release
+func verify(data []byte, release *Release, publicKey ed25519.PublicKey) error {
+	binary := release.binary
+	sum := sha256.Sum256(data)
+	if !strings.EqualFold(hex.EncodeToString(sum[:]), binary.SHA256) {
+		return fmt.Errorf("checksum mismatch: expected %s, got %s", binary.SHA256, hex.EncodeToString(sum[:]))
+	}
+
+	if binary.Signature == "" {
+		return errors.New("release binary is not signed")
+	}
+	signature, err := base64.StdEncoding.DecodeString(binary.Signature)
+	if err != nil {
+		return fmt.Errorf("invalid signature encoding: %w", err)
+	}
+	if !ed25519.Verify(publicKey, signedPayload(release.Latest, release.platform, binary.SHA256), signature) {
+		return errors.New("signature of the release does not match the trusted public key")
+	}
+
+	return nil
+}
+
+func decodePublicKey(key string) (ed25519.PublicKey, error) {
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(key))
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key encoding: %w", err)
+	}
+	if len(raw) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid public key: expected %d bytes ed25519 key, got %d bytes", ed25519.PublicKeySize, len(raw))
+	}
+	return ed25519.PublicKey(raw), nil
+}
+
+func fetchManifest(ctx context.Context, endpoint string) (*Manifest, error) {
+	data, err := download(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse release manifest: %w", err)
+	}
+	if manifest.Version == "" {
+		return nil, errors.New("release manifest has no version")
+	}
+
+	return &manifest, nil
+}
+
+func download(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch %s: unexpected status %s", url, resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", url, err)
+	}
+
+	return data, nil
+}
+
+// checkRPM checks the RPM repositories for a newer package, the package signatures are verified by dnf
+func checkRPM(ctx context.Context, current string) (*Release, error) {
+	release := &Release{Current: current, Latest: current, Source: "rpm"}
+
+	out, err := exec.CommandContext(ctx, "dnf", "-q", "check-update", rpmPackage).Output()
+	if err == nil {
+		// exit code 0: no update available
+		return release, nil
+	}
+
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 100 {
+		return nil, fmt.Errorf("failed to check the RPM repositories for updates: %w", err)
+	}
+
+	// exit code 100: updates available, listed as '<name>.<arch> <version> <repo>'
+	for _, line := range strings.Split(string(out), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && strings.HasPrefix(fields[0], rpmPackage+".") {
+			release.Latest = fields[1]
+			release.UpdateAvailable = true
+		}
+	}
+
+	return release, nil
+}
+
+func updateRPM(ctx context.Context) error {
+	cmd := exec.CommandContext(ctx, "dnf", "-y", "upgrade", rpmPackage)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to upgrade the %s package: %w", rpmPackage, err)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package updater
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		want    [3]int
+		ok      bool
+	}{
+		{version: "v0.3.0", want: [3]int{0, 3, 0}, ok: true},
+		{version: "1.2.3", want: [3]int{1, 2, 3}, ok: true},
+		{version: " v1.2 ", want: [3]int{1, 2, 0}, ok: true},
+		{version: "2", want: [3]int{2, 0, 0}, ok: true},
+		{version: "v0.3.0-rc1", want: [3]int{0, 3, 0}, ok: true},
+		{version: "v0.3.0-4-gabcdef", want: [3]int{0, 3, 0}, ok: true},
+		{version: "v0.3.0+build.7", want: [3]int{0, 3, 0}, ok: true},
+		{version: "1.2.3.4", ok: false},
+		{version: "unknown", ok: false},
+		{version: "", ok: false},
+		{version: "v1.x.0", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			got, ok := parseVersion(tt.version)
+			if ok != tt.ok {
+				t.Fatalf("parseVersion(%q) ok = %v, want %v", tt.version, ok, tt.ok)
+			}
+			if ok && got != tt.want {
+				t.Errorf("parseVersion(%q) = %v, want %v", tt.version, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsNewer(t *testing.T) {
+	tests := []struct {
+		latest  string
+		current string
+		want    bool
+	}{
+		{latest: "v0.3.0", current: "v0.2.9", want: true},
+		{latest: "v1.0.0", current: "v0.9.9", want: true},
+		{latest: "v0.2.10", current: "v0.2.9", want: true},
+		{latest: "v0.3.0", current: "v0.3.0", want: false},
+		{latest: "v0.3.0", current: "v0.3.0-rc1", want: false},
+		{latest: "v0.2.0", current: "v0.3.0", want: false},
+		{latest: "v0.3.0", current: "unknown", want: true},
+		{latest: "unknown", current: "v0.3.0", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.latest+"_"+tt.current, func(t *testing.T) {
+			if got := IsNewer(tt.latest, tt.current); got != tt.want {
+				t.Errorf("IsNewer(%q, %q) = %v, want %v", tt.latest, tt.current, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := []byte("ai-services binary")
+	sum := sha256.Sum256(data)
+	digest := hex.EncodeToString(sum[:])
+	sign := func(version string) string {
+		return base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, signedPayload(version, "linux/ppc64le", digest)))
+	}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		release *Release
+		wantErr bool
+	}{
+		{
+			name:    "signed release",
+			data:    data,
+			release: &Release{Latest: "v0.3.0", platform: "linux/ppc64le", binary: Binary{SHA256: digest, Signature: sign("v0.3.0")}},
+		},
+		{
+			name:    "older binary served under a newer version",
+			data:    data,
+			release: &Release{Latest: "v0.4.0", platform: "linux/ppc64le", binary: Binary{SHA256: digest, Signature: sign("v0.3.0")}},
+			wantErr: true,
+		},
+		{
+			name:    "binary of another platform",
+			data:    data,
+			release: &Release{Latest: "v0.3.0", platform: "linux/amd64", binary: Binary{SHA256: digest, Signature: sign("v0.3.0")}},
+			wantErr: true,
+		},
+		{
+			name:    "checksum mismatch",
+			data:    []byte("tampered binary"),
+			release: &Release{Latest: "v0.3.0", platform: "linux/ppc64le", binary: Binary{SHA256: digest, Signature: sign("v0.3.0")}},
+			wantErr: true,
+		},
+		{
+			name:    "unsigned release",
+			data:    data,
+			release: &Release{Latest: "v0.3.0", platform: "linux/ppc64le", binary: Binary{SHA256: digest}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verify(tt.data, tt.release, publicKey)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("verify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffLine is a line of the edit script turning the old text into the new one
+type diffLine struct {
+	op   byte // ' ' kept, '-' removed, '+' added
+	text string
+	// oldPos and newPos are the 0-based positions in the old and new lines before the line is applied
+	oldPos, newPos int
+}
+
+// UnifiedDiff returns the unified diff of the old and new texts, line by line, with context unchanged lines around
+// each change. Returns an empty string if the texts are identical
+func UnifiedDiff(oldName, newName, oldText, newText string, context int) string {
+	if oldText == newText {
+		return ""
+	}
+	oldLines, newLines := splitLines(oldText), splitLines(newText)
+	script := editScript(oldLines, newLines)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", oldName, newName)
+	for i := 0; i < len(script); {
+		if script[i].op == ' ' {
+			i++
+			continue
+		}
+
+		// extend the hunk over the changes separated by less than twice the context
+		last := i
+		for j := i; j < len(script) && j-last <= 2*context; j++ {
+			if script[j].op != ' ' {
+				last = j
+			}
+		}
+		start, end := max(0, i-context), min(len(script), last+context+1)
+		hunk := script[start:end]
+
+		oldCount, newCount := 0, 0
+		for _, l := range hunk {
+			if l.op != '+' {
+				oldCount++
+			}
+			if l.op != '-' {
+				newCount++
+			}
+		}
+		fmt.Fprintf(&b, "@@ -%s +%s @@\n", hunkRange(hunk[0].oldPos, oldCount), hunkRange(hunk[0].newPos, newCount))
+		for _, l := range hunk {
+			b.WriteByte(l.op)
+			b.WriteString(l.text)
+			b.WriteByte('\n')
+		}
+		i = end
+	}
+	return b.String()
+}
+
+// editScript computes the shortest edit script from the longest common subsequence of the lines, the manifests
+// being small enough for the quadratic table
+func editScript(oldLines, newLines []string) []diffLine {
+	// lcs[i][j] is the length of the longest common subsequence of oldLines[i:] and newLines[j:]
+	lcs := make([][]int, len(oldLines)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(newLines)+1)
+	}
+	for i := len(oldLines) - 1; i >= 0; i-- {
+		for j := len(newLines) - 1; j >= 0; j-- {
+			if oldLines[i] == newLines[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var script []diffLine
+	i, j := 0, 0
+	for i < len(oldLines) || j < len(newLines) {
+		switch {
+		case i < len(oldLines) && j < len(newLines) && oldLines[i] == newLines[j]:
+			script = append(script, diffLine{' ', oldLines[i], i, j})
+			i++
+			j++
+		case i < len(oldLines) && (j == len(newLines) || lcs[i+1][j] >= lcs[i][j+1]):
+			script = append(script, diffLine{'-', oldLines[i], i, j})
+			i++
+		default:
+			script = append(script, diffLine{'+', newLines[j], i, j})
+			j++
+		}
+	}
+	return script
+}
+
+// hunkRange formats the 1-based line range of a hunk, an empty range refers to the line before it
+func hunkRange(pos, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", pos)
+	case 1:
+		return fmt.Sprintf("%d", pos+1)
+	default:
+		return fmt.Sprintf("%d,%d", pos+1, count)
+	}
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
@@ -2,14 +2,23 @@ package utils
 
 import (
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/charmbracelet/huh"
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/machine"
 )
 
 func ConfirmAction(prompt string) (bool, error) {
 	var confirmed bool
 
+	// machine mode is non-interactive, invoking the command is the confirmation
+	if machine.Enabled {
+		logger.Infoln(fmt.Sprintf("%s %v", prompt, true))
+		return true, nil
+	}
+
 	form := huh.NewForm(
 		huh.NewGroup(
 			huh.NewConfirm().
@@ -27,3 +36,33 @@ func ConfirmAction(prompt string) (bool, error) {
 
 	return confirmed, nil
 }
+
+// PromptInput prompts for a line of input, empty in machine mode
+func PromptInput(prompt string) (string, error) {
+	var value string
+
+	// machine mode is non-interactive, there is no input to prompt for
+	if machine.Enabled {
+		return "", nil
+	}
+
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewInput().
+				Title(prompt).
+				Value(&value),
+		),
+	)
+
+	if err := form.Run(); err != nil {
+		return "", fmt.Errorf("failed to run input prompt: %w", err)
+	}
+
+	return strings.TrimSpace(value), nil
+}
+
+// Interactive returns whether the standard input is a terminal the prompts can be answered on
+func Interactive() bool {
+	info, err := os.Stdin.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0 && !machine.Enabled
+}
@@ -5,7 +5,9 @@ import (
 	"maps"
 	"net"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
 	"go.yaml.in/yaml/v3"
@@ -222,3 +224,15 @@ func SetNestedValue(out map[string]any, dottedKey string, value any) {
 	last := parts[len(parts)-1]
 	current[last] = value
 }
+
+// ParseDuration parses the duration as time.ParseDuration, along with a number of days, Eg:- 7d
+func ParseDuration(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(s)
+}
@@ -0,0 +1,107 @@
+package firewall
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/containers/podman/v5/pkg/domain/entities/types"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/constants"
+	fw "github.com/project-ai-services/ai-services/internal/pkg/firewall"
+	"github.com/project-ai-services/ai-services/internal/pkg/i18n"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/podman"
+)
+
+type FirewallRule struct{}
+
+func NewFirewallRule() *FirewallRule {
+	return &FirewallRule{}
+}
+
+func (r *FirewallRule) Name() string {
+	return "firewall"
+}
+
+// Verify checks the host ports published on all the interfaces by the applications are open in firewalld. Nothing
+// is blocked when firewalld is not running, nor checked when podman is unreachable.
+func (r *FirewallRule) Verify() error {
+	logger.Infoln("Validating the published ports are open in the firewall...", 2)
+	if !fw.Active() {
+		return nil
+	}
+	ports, err := publishedPorts()
+	if err != nil {
+		logger.Infof("Skipping the firewall check: %v\n", err, 2)
+		return nil
+	}
+	if len(ports) == 0 {
+		return nil
+	}
+
+	cfg, err := fw.Load()
+	if err != nil {
+		return err
+	}
+	blocked, err := fw.Blocked(cfg.Zone, ports)
+	if err != nil {
+		return fmt.Errorf("failed to check the firewall: %w", err)
+	}
+	if len(blocked) > 0 {
+		list := make([]string, 0, len(blocked))
+		for _, port := range blocked {
+			list = append(list, strconv.Itoa(port))
+		}
+		return fmt.Errorf(i18n.T("validation.firewall.error"), strings.Join(list, ", "))
+	}
+	return nil
+}
+
+func (r *FirewallRule) Message() string {
+	return i18n.T("validation.firewall.message")
+}
+
+func (r *FirewallRule) Level() constants.ValidationLevel {
+	return constants.ValidationLevelWarning
+}
+
+func (r *FirewallRule) Category() constants.ValidationCategory {
+	return constants.ValidationCategoryRuntime
+}
+
+func (r *FirewallRule) Hint() string {
+	return i18n.T("validation.firewall.hint")
+}
+
+// publishedPorts returns the host ports published on all the interfaces by the pods of the applications
+func publishedPorts() ([]int, error) {
+	client, err := podman.NewPodmanClient()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.ListPods(map[string][]string{"label": {"ai-services.io/application"}})
+	if err != nil {
+		return nil, err
+	}
+	pods, _ := resp.([]*types.ListPodsReport)
+
+	var ports []int
+	for _, pod := range pods {
+		info, err := client.InspectPod(pod.Id)
+		if err != nil || info.InfraConfig == nil {
+			continue
+		}
+		for _, bindings := range info.InfraConfig.PortBindings {
+			for _, b := range bindings {
+				if b.HostIP != "" && b.HostIP != "0.0.0.0" && b.HostIP != "::" {
+					continue
+				}
+				if port, err := strconv.Atoi(b.HostPort); err == nil && port > 0 {
+					ports = append(ports, port)
+				}
+			}
+		}
+	}
+	return ports, nil
+}
@@ -1,12 +1,14 @@
 package numa
 
 import (
+	"errors"
 	"fmt"
 	"os/exec"
 	"strconv"
 	"strings"
 
 	"github.com/project-ai-services/ai-services/internal/pkg/constants"
+	"github.com/project-ai-services/ai-services/internal/pkg/i18n"
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
 )
 
@@ -40,20 +42,24 @@ func (r *NumaRule) Verify() error {
 	}
 
 	if numaCount != 1 {
-		return fmt.Errorf("the current NUMA node configuration (%d) is not aligned for maximum efficiency", numaCount)
+		return errors.New(i18n.T("validation.numa.error", numaCount))
 	}
 
 	return nil
 }
 
 func (r *NumaRule) Message() string {
-	return "NUMA node alignment on LPAR: 1"
+	return i18n.T("validation.numa.message")
 }
 
 func (r *NumaRule) Level() constants.ValidationLevel {
 	return constants.ValidationLevelWarning
 }
 
+func (r *NumaRule) Category() constants.ValidationCategory {
+	return constants.ValidationCategorySystem
+}
+
 func (r *NumaRule) Hint() string {
-	return "This tools requires numa node set on 1 on LPAR"
+	return i18n.T("validation.numa.hint")
 }
@@ -1,12 +1,13 @@
 package platform
 
 import (
-	"fmt"
+	"errors"
 	"os"
 	"strconv"
 	"strings"
 
 	"github.com/project-ai-services/ai-services/internal/pkg/constants"
+	"github.com/project-ai-services/ai-services/internal/pkg/i18n"
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
 )
 
@@ -35,13 +36,13 @@ func (r *PlatformRule) Verify() error {
 		strings.Contains(osInfo, `ID=rhel`)
 
 	if !isRHEL {
-		return fmt.Errorf("unsupported operating system: only RHEL is supported")
+		return errors.New(i18n.T("validation.platform.error.os"))
 	}
 
-	// verify if version is 9.6 or higher
+	// verify if version is the minimum supported version or higher
 	idx := strings.Index(osInfo, "VERSION_ID=")
 	if idx == -1 {
-		return fmt.Errorf("unable to determine OS version")
+		return errors.New(i18n.T("validation.platform.error.version-unknown"))
 	}
 	rest := osInfo[idx+len("VERSION_ID="):]
 	if end := strings.IndexByte(rest, '\n'); end != -1 {
@@ -56,8 +57,8 @@ func (r *PlatformRule) Verify() error {
 		minor, _ = strconv.Atoi(parts[1])
 	}
 
-	if major < 9 || (major == 9 && minor < 6) {
-		return fmt.Errorf("unsupported RHEL version: %s. Minimum required version is 9.6", version)
+	if major < constants.MinRHELMajor || (major == constants.MinRHELMajor && minor < constants.MinRHELMinor) {
+		return errors.New(i18n.T("validation.platform.error.version", version, constants.MinRHELVersion))
 	}
 
 	return nil
@@ -65,13 +66,17 @@ func (r *PlatformRule) Verify() error {
 }
 
 func (r *PlatformRule) Message() string {
-	return "Operating system is RHEL with version 9.6"
+	return i18n.T("validation.platform.message", constants.MinRHELVersion)
 }
 
 func (r *PlatformRule) Level() constants.ValidationLevel {
 	return constants.ValidationLevelError
 }
 
+func (r *PlatformRule) Category() constants.ValidationCategory {
+	return constants.ValidationCategorySystem
+}
+
 func (r *PlatformRule) Hint() string {
-	return "This tool requires RHEL version 9.6, please install or upgrade to a supported platform"
+	return i18n.T("validation.platform.hint", constants.MinRHELVersion)
 }
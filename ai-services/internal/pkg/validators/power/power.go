@@ -1,12 +1,13 @@
 package power
 
 import (
-	"fmt"
+	"errors"
 	"os"
 	"runtime"
 	"strings"
 
 	"github.com/project-ai-services/ai-services/internal/pkg/constants"
+	"github.com/project-ai-services/ai-services/internal/pkg/i18n"
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
 )
 
@@ -24,7 +25,7 @@ func (r *PowerRule) Verify() error {
 	logger.Infoln("Validating IBM Power version...", 2)
 
 	if runtime.GOARCH != "ppc64le" {
-		return fmt.Errorf("unsupported architecture: %s. IBM Power architecture (ppc64le) is required", runtime.GOARCH)
+		return errors.New(i18n.T("validation.power.error.arch", runtime.GOARCH))
 	}
 
 	data, err := os.ReadFile("/proc/cpuinfo")
@@ -32,17 +33,21 @@ func (r *PowerRule) Verify() error {
 		return nil
 	}
 
-	return fmt.Errorf("unsupported IBM Power version: Power11 is required")
+	return errors.New(i18n.T("validation.power.error.version"))
 }
 
 func (r *PowerRule) Message() string {
-	return "System is running on IBM Power11 (ppc64le)"
+	return i18n.T("validation.power.message")
 }
 
 func (r *PowerRule) Level() constants.ValidationLevel {
 	return constants.ValidationLevelError
 }
 
+func (r *PowerRule) Category() constants.ValidationCategory {
+	return constants.ValidationCategorySystem
+}
+
 func (r *PowerRule) Hint() string {
-	return "This tools requires IBM Power11 (ppc64le)"
+	return i18n.T("validation.power.hint")
 }
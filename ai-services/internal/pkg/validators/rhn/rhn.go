@@ -1,11 +1,13 @@
 package rhn
 
 import (
+	"errors"
 	"fmt"
 	"os/exec"
 	"strings"
 
 	"github.com/project-ai-services/ai-services/internal/pkg/constants"
+	"github.com/project-ai-services/ai-services/internal/pkg/i18n"
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
 )
 
@@ -28,7 +30,7 @@ func (r *RHNRule) Verify() error {
 	// even when the system is registered
 	outputStr := string(output)
 	if strings.Contains(outputStr, "This system is not registered") {
-		return fmt.Errorf("system is not registered with RHN")
+		return errors.New(i18n.T("validation.rhn.error"))
 	}
 
 	if err != nil {
@@ -39,13 +41,17 @@ func (r *RHNRule) Verify() error {
 }
 
 func (r *RHNRule) Message() string {
-	return "System is registered with RHN"
+	return i18n.T("validation.rhn.message")
 }
 
 func (r *RHNRule) Level() constants.ValidationLevel {
 	return constants.ValidationLevelError
 }
 
+func (r *RHNRule) Category() constants.ValidationCategory {
+	return constants.ValidationCategoryLicense
+}
+
 func (r *RHNRule) Hint() string {
-	return "Register your system with Red Hat Network using: subscription-manager register --username <username> --password <password> "
+	return i18n.T("validation.rhn.hint")
 }
@@ -1,10 +1,11 @@
 package root
 
 import (
-	"fmt"
+	"errors"
 	"os"
 
 	"github.com/project-ai-services/ai-services/internal/pkg/constants"
+	"github.com/project-ai-services/ai-services/internal/pkg/i18n"
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
 )
 
@@ -24,20 +25,24 @@ func (r *RootRule) Verify() error {
 	logger.Infoln("Checking root privileges", 2)
 
 	if euid != 0 {
-		return fmt.Errorf("current user is not root (EUID: %d)", euid)
+		return errors.New(i18n.T("validation.root.error", euid))
 	}
 
 	return nil
 }
 
 func (r *RootRule) Message() string {
-	return "Current user is root"
+	return i18n.T("validation.root.message")
 }
 
 func (r *RootRule) Level() constants.ValidationLevel {
 	return constants.ValidationLevelError
 }
 
+func (r *RootRule) Category() constants.ValidationCategory {
+	return constants.ValidationCategorySystem
+}
+
 func (r *RootRule) Hint() string {
-	return "Run this command with root privileges using 'sudo' or as the root user"
+	return i18n.T("validation.root.hint")
 }
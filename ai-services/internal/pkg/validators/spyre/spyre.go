@@ -1,11 +1,13 @@
 package spyre
 
 import (
+	"errors"
 	"fmt"
 	"os/exec"
 	"strings"
 
 	"github.com/project-ai-services/ai-services/internal/pkg/constants"
+	"github.com/project-ai-services/ai-services/internal/pkg/i18n"
 	"k8s.io/klog/v2"
 )
 
@@ -27,20 +29,24 @@ func (r *SpyreRule) Verify() error {
 	}
 
 	if !strings.Contains(string(out), "IBM Spyre Accelerator") {
-		return fmt.Errorf("IBM Spyre Accelerator is not attached to the LPAR")
+		return errors.New(i18n.T("validation.spyre.error"))
 	}
 
 	return nil
 }
 
 func (r *SpyreRule) Message() string {
-	return "IBM Spyre Accelerator is attached to the LPAR"
+	return i18n.T("validation.spyre.message")
 }
 
 func (r *SpyreRule) Level() constants.ValidationLevel {
 	return constants.ValidationLevelError
 }
 
+func (r *SpyreRule) Category() constants.ValidationCategory {
+	return constants.ValidationCategoryAccelerator
+}
+
 func (r *SpyreRule) Hint() string {
-	return "IBM Spyre Accelerator hardware is required but not detected."
+	return i18n.T("validation.spyre.hint")
 }
@@ -4,6 +4,7 @@ import (
 	"sync"
 
 	"github.com/project-ai-services/ai-services/internal/pkg/constants"
+	"github.com/project-ai-services/ai-services/internal/pkg/validators/firewall"
 	"github.com/project-ai-services/ai-services/internal/pkg/validators/numa"
 	"github.com/project-ai-services/ai-services/internal/pkg/validators/platform"
 	"github.com/project-ai-services/ai-services/internal/pkg/validators/power"
@@ -21,6 +22,7 @@ func init() {
 	DefaultRegistry.Register(power.NewPowerRule())
 	DefaultRegistry.Register(rhn.NewRHNRule())
 	DefaultRegistry.Register(spyre.NewSpyreRule())
+	DefaultRegistry.Register(firewall.NewFirewallRule())
 }
 
 // Rule defines the interface for validation rules
@@ -29,6 +31,7 @@ type Rule interface {
 	Message() string
 	Name() string
 	Level() constants.ValidationLevel
+	Category() constants.ValidationCategory
 	Hint() string
 }
 
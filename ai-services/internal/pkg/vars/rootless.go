@@ -0,0 +1,59 @@
+package vars
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// RootfulDataDirectory is the directory holding the models, state and application volumes when running as root
+const RootfulDataDirectory = "/var/lib/ai-services"
+
+var (
+	// Rootless is set when a non root user runs the CLI against their rootless podman socket, unless CONTAINER_HOST
+	// selects another podman. The root only steps (Eg:- SMT level, Spyre device passthrough, CPU pinning) are
+	// skipped, so that developers can deploy the templates without accelerators.
+	Rootless = os.Geteuid() != 0 && os.Getenv("CONTAINER_HOST") == "" && rootlessSocketExists()
+	// DataDirectory is the directory holding the models, state and application volumes, within the home directory
+	// of the user in rootless mode
+	DataDirectory = dataDirectory()
+	// AppLogDirectory is the directory the container logs of the applications are collected into, one directory
+	// per application
+	AppLogDirectory = appLogDirectory()
+)
+
+// RootlessSocket returns the path of the rootless podman socket of the user
+func RootlessSocket() string {
+	runtimeDir := os.Getenv("XDG_RUNTIME_DIR")
+	if runtimeDir == "" {
+		runtimeDir = filepath.Join("/run/user", strconv.Itoa(os.Getuid()))
+	}
+	return filepath.Join(runtimeDir, "podman", "podman.sock")
+}
+
+func rootlessSocketExists() bool {
+	_, err := os.Stat(RootlessSocket())
+	return err == nil
+}
+
+func dataDirectory() string {
+	if !Rootless {
+		return RootfulDataDirectory
+	}
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return RootfulDataDirectory
+		}
+		dataHome = filepath.Join(home, ".local", "share")
+	}
+	return filepath.Join(dataHome, "ai-services")
+}
+
+func appLogDirectory() string {
+	if !Rootless {
+		return "/var/log/ai-services/apps"
+	}
+	return filepath.Join(DataDirectory, "logs", "apps")
+}
@@ -6,7 +6,14 @@ var (
 	// SpyreCardAnnotationRegex -> ai-services.io/<containerName>--spyre-cards
 	SpyreCardAnnotationRegex = regexp.MustCompile(`^ai-services\.io\/([A-Za-z0-9][-A-Za-z0-9_.]*)--sypre-cards$`)
 	ToolImage                = "icr.io/ai-services-cicd/tools:0.2"
-	ModelDirectory           = "/var/lib/ai-services/models"
+	ModelDirectory           = DataDirectory + "/models"
+	StateDirectory           = DataDirectory + "/state"
+	GatewayDirectory         = DataDirectory + "/gateway"
+	// ConfigFile is the CLI config file, see the config package
+	ConfigFile = "/etc/ai-services/config.yaml"
+	// CLIVersion is the version of the running CLI, set by the CLI at startup. The templates requiring a minimum
+	// CLI version are not checked against an unknown version.
+	CLIVersion = "unknown"
 )
 
 type Label string
@@ -14,4 +21,11 @@ type Label string
 var (
 	TemplateLabel Label = "ai-services.io/template"
 	VersionLabel  Label = "ai-services.io/version"
+	// ManagedLabel marks the resources created and managed by ai-services
+	ManagedLabel Label = "ai-services.io/managed"
+	// VolumeTypeLabel describes the content of the volumes created by ai-services
+	VolumeTypeLabel Label = "ai-services.io/volume-type"
+	// ComponentLabel and ReplicaLabel mark the replica pods of a scalable component, see 'application scale'
+	ComponentLabel Label = "ai-services.io/component"
+	ReplicaLabel   Label = "ai-services.io/replica"
 )
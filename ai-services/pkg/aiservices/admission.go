@@ -0,0 +1,53 @@
+package aiservices
+
+import (
+	"context"
+	"strings"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/admission"
+	"github.com/project-ai-services/ai-services/internal/pkg/audit"
+	"github.com/project-ai-services/ai-services/internal/pkg/models"
+)
+
+// admit evaluates the rendered pod against the admission policies before it is played. The violations are recorded
+// in the audit history, and refuse the pod unless the policies only warn
+func (cr *creator) admit(ctx context.Context, podSpec *models.PodSpec) error {
+	cr.admissionOnce.Do(func() {
+		cr.admission, cr.admissionErr = admission.Load()
+	})
+	if cr.admissionErr != nil {
+		return cr.admissionErr
+	}
+	if cr.admission == nil {
+		return nil
+	}
+
+	violations, err := cr.admission.Evaluate(ctx, podSpec)
+	if err != nil {
+		return err
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+
+	details := make([]string, 0, len(violations))
+	for _, v := range violations {
+		details = append(details, v.String())
+	}
+	action := "admission denied"
+	if cr.admission.Enforcement == admission.EnforceWarn {
+		action = "admission warning"
+	}
+	if err := audit.Record(audit.Entry{Application: cr.opts.Name, Action: action,
+		Details: "pod " + podSpec.Name + ": " + strings.Join(details, "; ")}); err != nil {
+		cr.warn("failed to record the admission violations in the audit history: %v", err)
+	}
+
+	if cr.admission.Enforcement == admission.EnforceWarn {
+		for _, v := range violations {
+			cr.warn("pod %s violates the admission policy %s", podSpec.Name, v)
+		}
+		return nil
+	}
+	return &admission.DeniedError{Pod: podSpec.Name, Violations: violations}
+}
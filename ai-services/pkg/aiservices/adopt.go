@@ -0,0 +1,185 @@
+package aiservices
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/containers/podman/v5/pkg/domain/entities/types"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/audit"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/specs"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+// AdoptedTemplate is the template recorded for the applications adopted without template
+const AdoptedTemplate = "unmanaged"
+
+// AdoptOptions select the unmanaged pods to adopt
+type AdoptOptions struct {
+	// Selector are the labels the pods must all match, Eg:- app=rag
+	Selector map[string]string `json:"selector"`
+	// Template is recorded as the template of the application, defaults to AdoptedTemplate
+	Template string `json:"template,omitempty"`
+	// DryRun only detects the pods to adopt
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// DetectUnmanagedPods returns the pods matching all the labels of the selector which are not managed by ai-services,
+// Eg:- created by hand with 'podman kube play'
+func (c *Client) DetectUnmanagedPods(ctx context.Context, selector map[string]string) ([]Pod, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	filters := map[string][]string{}
+	for key, val := range selector {
+		filters["label"] = append(filters["label"], key+"="+val)
+	}
+	resp, err := c.runtime.ListPods(filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	var reports []*types.ListPodsReport
+	if val, ok := resp.([]*types.ListPodsReport); ok {
+		reports = val
+	}
+
+	var pods []Pod
+	for _, pod := range reports {
+		if pod.Labels["ai-services.io/application"] != "" {
+			continue
+		}
+		pods = append(pods, Pod{ID: pod.Id, Name: pod.Name, Status: pod.Status})
+	}
+	sort.Slice(pods, func(i, j int) bool { return pods[i].Name < pods[j].Name })
+	return pods, nil
+}
+
+// Adopt brings the unmanaged pods matching the selector under the management of ai-services as the application.
+// The labels of a podman pod are immutable, hence each pod is recreated from its generated kube YAML with the
+// ai-services labels: its containers restart, the named volumes are kept. A pod failing to be recreated is restored
+// from its original kube YAML. The adopted pods are recorded as a revision of the application, so that 'history' and
+// 'rollback' apply to them. Returns the pods adopted, or to adopt with opts.DryRun.
+func (c *Client) Adopt(ctx context.Context, appName string, opts AdoptOptions) ([]Pod, error) {
+	if len(opts.Selector) == 0 {
+		return nil, fmt.Errorf("a selector is required to adopt pods")
+	}
+	if opts.Template == "" {
+		opts.Template = AdoptedTemplate
+	}
+
+	// pods may be adopted into an application gradually, as long as it remains of the same template
+	app, err := c.GetApplication(ctx, appName)
+	if err != nil && !errors.Is(err, ErrApplicationNotFound) {
+		return nil, err
+	}
+	if app != nil && app.Template != opts.Template {
+		return nil, fmt.Errorf("application '%s' exists with template '%s', cannot adopt pods as template '%s'", appName, app.Template, opts.Template)
+	}
+
+	pods, err := c.DetectUnmanagedPods(ctx, opts.Selector)
+	if err != nil {
+		return nil, err
+	}
+	if len(pods) == 0 {
+		return nil, fmt.Errorf("no unmanaged pods match the selector %s", formatSelector(opts.Selector))
+	}
+	if opts.DryRun {
+		return pods, nil
+	}
+
+	labels := map[string]string{
+		"ai-services.io/application": appName,
+		string(vars.TemplateLabel):   opts.Template,
+	}
+
+	rev := &Revision{Template: opts.Template, Manifests: map[string]string{}, Outcome: OutcomeDeployed}
+	var adopted []Pod
+	var adoptErr error
+	for _, pod := range pods {
+		if adoptErr = ctx.Err(); adoptErr != nil {
+			break
+		}
+		manifest, id, err := c.adoptPod(pod, labels)
+		if err != nil {
+			adoptErr = err
+			break
+		}
+		pod.ID = id
+		logger.Infof("Adopted pod %s\n", pod.Name)
+		rev.Manifests[pod.Name] = string(manifest)
+		adopted = append(adopted, pod)
+	}
+	if len(adopted) == 0 {
+		return nil, adoptErr
+	}
+
+	// record the pods adopted before a failure, which are managed from now on
+	names := make([]string, 0, len(adopted))
+	for _, pod := range adopted {
+		names = append(names, pod.Name)
+	}
+	rev.Layers = [][]string{names}
+	if err := recordRevision(appName, rev); err != nil {
+		logger.Warningf("failed to record the revision of application '%s': %v\n", appName, err)
+	}
+	if err := audit.Record(audit.Entry{Application: appName, Action: "adopt",
+		Details: fmt.Sprintf("pods %s matching %s", strings.Join(names, ", "), formatSelector(opts.Selector))}); err != nil {
+		logger.Warningf("failed to record the adoption in the audit history: %v\n", err)
+	}
+
+	return adopted, adoptErr
+}
+
+// adoptPod recreates the pod with the labels, restoring the original pod on failure. Returns the manifest and the
+// ID of the adopted pod.
+func (c *Client) adoptPod(pod Pod, labels map[string]string) ([]byte, string, error) {
+	original, err := c.runtime.GenerateKube([]string{pod.ID})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate the kube YAML of pod %s: %w", pod.Name, err)
+	}
+	podManifest, _, err := specs.SplitPod(original)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to parse the kube YAML of pod %s: %w", pod.Name, err)
+	}
+	manifest, err := injectMetadata(podManifest, labels, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to label pod %s: %w", pod.Name, err)
+	}
+
+	if err := c.runtime.DeletePod(pod.ID, utils.BoolPtr(true)); err != nil {
+		return nil, "", fmt.Errorf("failed to remove pod %s: %w", pod.Name, err)
+	}
+	report, err := c.runtime.CreatePod(bytes.NewReader(manifest))
+	if err != nil {
+		if exists, _ := c.runtime.PodExists(pod.Name); exists {
+			_ = c.runtime.DeletePod(pod.Name, utils.BoolPtr(true))
+		}
+		if _, restoreErr := c.runtime.CreatePod(bytes.NewReader(original)); restoreErr != nil {
+			return nil, "", fmt.Errorf("failed to recreate pod %s: %w, and to restore it: %v", pod.Name, err, restoreErr)
+		}
+		return nil, "", fmt.Errorf("failed to recreate pod %s, restored it unmanaged: %w", pod.Name, err)
+	}
+	id := pod.ID
+	if len(report.Pods) > 0 {
+		id = report.Pods[0].ID
+	}
+	return manifest, id, nil
+}
+
+// formatSelector formats the selector as sorted key=value pairs
+func formatSelector(selector map[string]string) string {
+	pairs := make([]string, 0, len(selector))
+	for key, val := range selector {
+		pairs = append(pairs, key+"="+val)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
@@ -0,0 +1,204 @@
+package aiservices
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/containers/podman/v5/pkg/domain/entities/types"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/apikeys"
+	"github.com/project-ai-services/ai-services/internal/pkg/boot"
+	"github.com/project-ai-services/ai-services/internal/pkg/certs"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/schedule"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+// ErrApplicationNotFound is returned when no pods exist for the application
+var ErrApplicationNotFound = errors.New("application not found")
+
+// Application is a deployed application
+type Application struct {
+	Name     string `json:"name"`
+	Template string `json:"template"`
+	Version  string `json:"version"`
+	Pods     []Pod  `json:"pods"`
+}
+
+// Pod is a pod of a deployed application
+type Pod struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+// ListApplications returns the deployed applications sorted by name
+func (c *Client) ListApplications(ctx context.Context) ([]Application, error) {
+	return c.listApplications(ctx, map[string][]string{"label": {"ai-services.io/application"}})
+}
+
+// GetApplication returns the deployed application, ErrApplicationNotFound if it doesn't exist
+func (c *Client) GetApplication(ctx context.Context, name string) (*Application, error) {
+	apps, err := c.listApplications(ctx, map[string][]string{
+		"label": {fmt.Sprintf("ai-services.io/application=%s", name)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(apps) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrApplicationNotFound, name)
+	}
+	return &apps[0], nil
+}
+
+// DeleteOptions are the options to delete an application
+type DeleteOptions struct {
+	// KeepSMTLevel keeps the SMT level of the host, even if no deployed application requires it anymore
+	KeepSMTLevel bool `json:"keepSMTLevel,omitempty"`
+	// Purge removes the volumes of the claims of the application too, which are kept by default to preserve its data
+	Purge bool `json:"purge,omitempty"`
+	// GracePeriod is the time the containers are given to exit on SIGTERM before they are killed, defaults to
+	// DefaultGracePeriod
+	GracePeriod time.Duration `json:"gracePeriod,omitempty"`
+}
+
+// DeleteApplication removes all the pods and the secrets of the application. The original SMT level of the host
+// is restored once the last application requiring an SMT level is deleted, unless opts.KeepSMTLevel is set. The
+// volumes of the claims of the application are only removed with opts.Purge. The pods failing to delete are
+// reported by a *DeleteError, see deletePod.
+func (c *Client) DeleteApplication(ctx context.Context, name string, opts DeleteOptions) error {
+	if opts.GracePeriod <= 0 {
+		opts.GracePeriod = DefaultGracePeriod
+	}
+	app, err := c.GetApplication(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	// the objects are recorded by the revisions, released along with the pods
+	objects, err := c.ApplicationObjects(ctx, name)
+	if err != nil {
+		logger.Warningf("failed to list the objects of the application: %v\n", err)
+	}
+
+	var errs []error
+	var failures []PodDeleteFailure
+	for _, pod := range app.Pods {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		// the pod is deleted even if the drain failed
+		if err := c.drainPod(ctx, pod.ID); err != nil {
+			logger.Warningf("%v\n", err)
+		}
+		if failure := c.deletePod(ctx, pod, opts.GracePeriod); failure != nil {
+			failures = append(failures, *failure)
+		}
+	}
+	if len(failures) > 0 {
+		errs = append(errs, &DeleteError{Failures: failures})
+	}
+
+	// remove the TLS certificate and the API keys of the application, if provisioned
+	for _, secretName := range []string{certs.SecretName(name), apikeys.SecretName(name)} {
+		if exists, err := c.runtime.SecretExists(secretName); err == nil && exists {
+			if err := c.runtime.RemoveSecret(secretName); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", secretName, err))
+			}
+		}
+	}
+
+	if len(errs) == 0 {
+		if opts.Purge {
+			errs = append(errs, c.purgeObjects(objects)...)
+		} else {
+			for _, obj := range objects {
+				if obj.Exists {
+					logger.Infof("Keeping volume %s, purge the application to remove it\n", obj.Name)
+				}
+			}
+		}
+	}
+
+	// the pods are gone, the application doesn't require its SMT level, resources, cores, host ports, network, config, revisions,
+	// schedules and boot units anymore
+	if len(errs) == 0 {
+		if err := releaseSMTLevel(c.smt, name, opts.KeepSMTLevel); err != nil {
+			errs = append(errs, fmt.Errorf("smt: %w", err))
+		}
+		if err := releaseResources(name); err != nil {
+			errs = append(errs, fmt.Errorf("resources: %w", err))
+		}
+		if err := releaseCPUSets(name); err != nil {
+			errs = append(errs, fmt.Errorf("cpusets: %w", err))
+		}
+		if err := releasePorts(name); err != nil {
+			errs = append(errs, fmt.Errorf("ports: %w", err))
+		}
+		if err := releaseNetwork(c, name); err != nil {
+			errs = append(errs, fmt.Errorf("network: %w", err))
+		}
+		if err := releaseConfig(c, name); err != nil {
+			errs = append(errs, fmt.Errorf("config: %w", err))
+		}
+		if err := releaseRevisions(name); err != nil {
+			errs = append(errs, fmt.Errorf("revisions: %w", err))
+		}
+		if err := schedule.RemoveAll(name); err != nil {
+			errs = append(errs, fmt.Errorf("schedules: %w", err))
+		}
+		if err := boot.Disable(name); err != nil {
+			errs = append(errs, fmt.Errorf("boot: %w", err))
+		}
+		if err := c.SyncFirewall(ctx); err != nil {
+			logger.Warningf("failed to close the ports of the application in the firewall: %v\n", err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (c *Client) listApplications(ctx context.Context, filters map[string][]string) ([]Application, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.runtime.ListPods(filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	var pods []*types.ListPodsReport
+	if val, ok := resp.([]*types.ListPodsReport); ok {
+		pods = val
+	}
+
+	byName := map[string]*Application{}
+	for _, pod := range pods {
+		name := pod.Labels["ai-services.io/application"]
+		if name == "" {
+			continue
+		}
+		app, ok := byName[name]
+		if !ok {
+			app = &Application{
+				Name:     name,
+				Template: pod.Labels[string(vars.TemplateLabel)],
+				Version:  pod.Labels[string(vars.VersionLabel)],
+			}
+			byName[name] = app
+		}
+		app.Pods = append(app.Pods, Pod{ID: pod.Id, Name: pod.Name, Status: pod.Status})
+	}
+
+	apps := make([]Application, 0, len(byName))
+	for _, app := range byName {
+		apps = append(apps, *app)
+	}
+	sort.Slice(apps, func(i, j int) bool { return apps[i].Name < apps[j].Name })
+
+	return apps, nil
+}
@@ -0,0 +1,113 @@
+package aiservices
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"text/template"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/templates"
+	"github.com/project-ai-services/ai-services/internal/pkg/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/validators"
+)
+
+// artifacts caches the parsed template artifacts of a deployment, which are read by several steps (SMT level,
+// Spyre cards, resources, config, health checks and the deployment itself)
+type artifacts struct {
+	mu       sync.Mutex
+	tmpls    map[string]*template.Template
+	metadata *templates.AppMetadata
+	// podSpecs are the parsed pod templates by pod template, rendered with the params of the creator
+	podSpecs map[string]*models.PodSpec
+}
+
+// podSpec returns the cached pod spec of the pod template
+func (a *artifacts) podSpec(podTemplateName string) (*models.PodSpec, bool) {
+	if a == nil {
+		return nil, false
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	podSpec, ok := a.podSpecs[podTemplateName]
+	return podSpec, ok
+}
+
+func (a *artifacts) storePodSpec(podTemplateName string, podSpec *models.PodSpec) {
+	if a == nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.podSpecs == nil {
+		a.podSpecs = map[string]*models.PodSpec{}
+	}
+	a.podSpecs[podTemplateName] = podSpec
+}
+
+// invalidatePodSpecs drops the cached pod specs, to be called once the params of the creator change
+func (a *artifacts) invalidatePodSpecs() {
+	if a == nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.podSpecs = nil
+}
+
+// loadArtifacts validates the template, and loads its pod templates and metadata concurrently. Then the pod
+// templates are parsed concurrently, so that the later steps read them from the cache.
+func (cr *creator) loadArtifacts(ctx context.Context) (map[string]*template.Template, *templates.AppMetadata, error) {
+	if cr.cache == nil {
+		cr.cache = &artifacts{}
+	}
+	if cr.cache.tmpls != nil {
+		return cr.cache.tmpls, cr.cache.metadata, nil
+	}
+
+	templateName := cr.opts.Template
+	// validate whether the provided template name is correct
+	if err := validators.ValidateAppTemplateExist(cr.templates, templateName); err != nil {
+		return nil, nil, err
+	}
+
+	var tmpls map[string]*template.Template
+	var appMetadata *templates.AppMetadata
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		var err error
+		if tmpls, err = cr.templates.LoadAllTemplates(templateName + "/templates"); err != nil {
+			return fmt.Errorf("failed to parse the templates: %w", err)
+		}
+		return nil
+	})
+	g.Go(func() error {
+		var err error
+		// load metadata.yml to read the app metadata
+		if appMetadata, err = cr.templates.LoadMetadata(templateName); err != nil {
+			return fmt.Errorf("failed to read the app metadata: %w", err)
+		}
+		return nil
+	})
+	if err := g.Wait(); err != nil {
+		return nil, nil, err
+	}
+
+	g, gctx = errgroup.WithContext(gctx)
+	for podTemplateName := range tmpls {
+		g.Go(func() error {
+			if err := gctx.Err(); err != nil {
+				return err
+			}
+			_, err := cr.fetchPodSpec(podTemplateName)
+			return err
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, nil, err
+	}
+
+	cr.cache.tmpls, cr.cache.metadata = tmpls, appMetadata
+	return tmpls, appMetadata, nil
+}
@@ -0,0 +1,187 @@
+package aiservices
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/helpers"
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/templates"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/specs"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+// defaultQueueMetric is the number of requests waiting to be scheduled by vLLM
+const defaultQueueMetric = "vllm:num_requests_waiting"
+
+// scrapeTimeout bounds the scraping of the metrics of a pod
+var scrapeTimeout = 5 * time.Second
+
+// AutoscaleDecision is the outcome of an evaluation of an autoscaled component
+type AutoscaleDecision struct {
+	Time        time.Time `json:"time"`
+	Application string    `json:"application"`
+	Component   string    `json:"component"`
+	// Queue is the queue depth summed across the pods of the component
+	Queue    float64 `json:"queue"`
+	Replicas int     `json:"replicas"`
+	Desired  int     `json:"desired"`
+	// Reason explains why the desired replicas were bounded, if they were
+	Reason string `json:"reason,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Autoscale evaluates the autoscaled components of the application every interval until ctx is done, and scales
+// them to absorb their queue depth. The failed evaluations are reported and retried at the next interval.
+func (c *Client) Autoscale(ctx context.Context, appName string, interval time.Duration, report func(AutoscaleDecision)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		decisions, err := c.AutoscaleOnce(ctx, appName)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			logger.Warningf("failed to autoscale application '%s': %v\n", appName, err)
+		}
+		for _, d := range decisions {
+			report(d)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// AutoscaleOnce evaluates the autoscaled components of the application once. A component is scaled up to
+// ceil(queue depth / target per replica) replicas at once, bounded by its min and max replicas and by the free Spyre
+// cards, and scaled down by a single replica per evaluation to avoid flapping.
+func (c *Client) AutoscaleOnce(ctx context.Context, appName string) ([]AutoscaleDecision, error) {
+	rev, appMetadata, err := c.deployedRevision(ctx, appName)
+	if err != nil {
+		return nil, err
+	}
+
+	var decisions []AutoscaleDecision
+	for _, component := range appMetadata.Scalable {
+		if component.Autoscale == nil {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return decisions, err
+		}
+		d, err := c.autoscaleComponent(ctx, appName, rev, component)
+		if err != nil {
+			d.Error = err.Error()
+		}
+		decisions = append(decisions, d)
+	}
+	if len(decisions) == 0 {
+		return nil, fmt.Errorf("template %s has no autoscaled component", appMetadata.Name)
+	}
+	return decisions, nil
+}
+
+func (c *Client) autoscaleComponent(ctx context.Context, appName string, rev *Revision, component templates.ScalableComponent) (AutoscaleDecision, error) {
+	d := AutoscaleDecision{Time: time.Now().UTC(), Application: appName, Component: component.Component}
+	policy := component.Autoscale
+	if policy.TargetPerReplica <= 0 || policy.Port == 0 {
+		return d, fmt.Errorf("component %s: the autoscaling requires a port and a positive targetPerReplica", component.Component)
+	}
+
+	manifest, ok := rev.Manifests[component.PodTemplate]
+	if !ok {
+		return d, fmt.Errorf("pod template %s of component %s is not deployed", component.PodTemplate, component.Component)
+	}
+	base, err := specs.ParsePodSpec([]byte(manifest))
+	if err != nil {
+		return d, fmt.Errorf("revision %d: %w", rev.Number, err)
+	}
+	replicas, err := c.listReplicas(appName, component.Component)
+	if err != nil {
+		return d, err
+	}
+
+	pods := []string{base.Name}
+	for _, r := range replicas {
+		pods = append(pods, r.pod)
+	}
+	for _, pod := range pods {
+		queue, err := c.scrapeQueue(ctx, pod, policy)
+		if err != nil {
+			// the pods starting or stopping do not report their queue
+			logger.Infof("Skipping the queue of pod %s: %v\n", pod, err, 2)
+			continue
+		}
+		d.Queue += queue
+	}
+	d.Replicas = len(pods)
+
+	minReplicas := max(component.MinReplicas, 1)
+	d.Desired = max(int(math.Ceil(d.Queue/policy.TargetPerReplica)), minReplicas)
+	if component.MaxReplicas > 0 && d.Desired > component.MaxReplicas {
+		d.Desired, d.Reason = component.MaxReplicas, "bounded by the max replicas"
+	}
+	if d.Desired < d.Replicas-1 {
+		d.Desired, d.Reason = d.Replicas-1, "scaling down one replica at a time"
+	}
+	if d.Desired > d.Replicas {
+		if d.Desired, err = c.boundBySpyreCards(base.Annotations, d.Replicas, d.Desired); err != nil {
+			return d, err
+		}
+		if d.Desired == d.Replicas {
+			d.Reason = "no free Spyre cards for another replica"
+		}
+	}
+	if d.Desired == d.Replicas {
+		return d, nil
+	}
+
+	logger.Infof("Scaling component %s of application '%s' from %d to %d replicas, queue depth %g\n",
+		component.Component, appName, d.Replicas, d.Desired, d.Queue)
+	_, err = c.Scale(ctx, appName, ScaleOptions{Component: component.Component, Replicas: d.Desired})
+	return d, err
+}
+
+// boundBySpyreCards bounds the desired replicas by the replicas the free Spyre cards can serve
+func (c *Client) boundBySpyreCards(annotations map[string]string, current, desired int) (int, error) {
+	perReplica, _, err := fetchSpyreCardsFromPodAnnotations(annotations)
+	if err != nil {
+		return 0, err
+	}
+	// the rootless replicas run without accelerator
+	if perReplica == 0 || vars.Rootless {
+		return desired, nil
+	}
+	free, err := helpers.FindFreeSpyreCards(c.runtime)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find free Spyre Cards: %w", err)
+	}
+	return min(desired, current+len(free)/perReplica), nil
+}
+
+// scrapeQueue returns the queue depth metric reported by the pod, summed across its series
+func (c *Client) scrapeQueue(ctx context.Context, pod string, policy *templates.Autoscale) (float64, error) {
+	ip, err := helpers.FetchPodIP(c.runtime, pod)
+	if err != nil {
+		return 0, err
+	}
+	path := policy.Path
+	if path == "" {
+		path = "/metrics"
+	}
+	metric := policy.Metric
+	if metric == "" {
+		metric = defaultQueueMetric
+	}
+
+	return scrapeMetric(ctx, "http://"+net.JoinHostPort(ip, strconv.Itoa(policy.Port))+path, metric)
+}
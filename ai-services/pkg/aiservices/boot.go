@@ -0,0 +1,74 @@
+package aiservices
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/boot"
+	"github.com/project-ai-services/ai-services/internal/pkg/specs"
+)
+
+// EnableOnBoot installs the systemd units starting the pods of the application on boot, in the layers of its last
+// deployed revision. The pods deployed outside of the revision, Eg:- the replicas, are started in a last layer.
+// Returns the names of the installed units.
+func (c *Client) EnableOnBoot(ctx context.Context, appName string) ([]string, error) {
+	app, err := c.GetApplication(ctx, appName)
+	if err != nil {
+		return nil, err
+	}
+	rev, _, err := c.deployedRevision(ctx, appName)
+	if err != nil {
+		return nil, err
+	}
+
+	deployed := map[string]bool{}
+	for _, pod := range app.Pods {
+		deployed[pod.Name] = true
+	}
+
+	var layers [][]string
+	placed := map[string]bool{}
+	for _, layer := range rev.Layers {
+		var pods []string
+		for _, podTemplateName := range layer {
+			manifest, ok := rev.Manifests[podTemplateName]
+			if !ok {
+				continue
+			}
+			podSpec, err := specs.ParsePodSpec([]byte(manifest))
+			if err != nil {
+				return nil, fmt.Errorf("revision %d: %w", rev.Number, err)
+			}
+			if deployed[podSpec.Name] {
+				pods = append(pods, podSpec.Name)
+				placed[podSpec.Name] = true
+			}
+		}
+		if len(pods) > 0 {
+			layers = append(layers, pods)
+		}
+	}
+
+	var rest []string
+	for _, pod := range app.Pods {
+		if !placed[pod.Name] {
+			rest = append(rest, pod.Name)
+		}
+	}
+	if len(rest) > 0 {
+		layers = append(layers, rest)
+	}
+
+	return boot.Enable(appName, layers)
+}
+
+// DisableOnBoot removes the systemd units starting the application on boot
+func (c *Client) DisableOnBoot(ctx context.Context, appName string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if !boot.Enabled(appName) {
+		return fmt.Errorf("application '%s' is not started on boot", appName)
+	}
+	return boot.Disable(appName)
+}
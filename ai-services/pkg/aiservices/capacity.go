@@ -0,0 +1,186 @@
+package aiservices
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/helpers"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime"
+	"github.com/project-ai-services/ai-services/internal/pkg/state"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+	"github.com/project-ai-services/ai-services/internal/pkg/validators"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+// HostCapacity is the capacity of the host left for new applications
+type HostCapacity struct {
+	Host           string `json:"host"`
+	SpyreCards     int    `json:"spyreCards"`
+	FreeSpyreCards int    `json:"freeSpyreCards"`
+	// Capacity is the online CPUs and the memory of the host
+	Capacity Resources `json:"capacity"`
+	// Reserved are the resources reserved by the deployed applications
+	Reserved  Resources `json:"reserved"`
+	Available Resources `json:"available"`
+	// DiskTotal and DiskAvailable are the size and the free space of the model directory, in bytes
+	DiskTotal     int64 `json:"diskTotal"`
+	DiskAvailable int64 `json:"diskAvailable"`
+	// Fit is whether the template fits the host, when requested
+	Fit *TemplateFit `json:"fit,omitempty"`
+}
+
+// TemplateFit is whether the host can fit the requirements of a template
+type TemplateFit struct {
+	Template   string    `json:"template"`
+	Required   Resources `json:"required"`
+	SpyreCards int       `json:"spyreCards"`
+	Fits       bool      `json:"fits"`
+	// Reasons lists the requirements the host cannot fit
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+// CapacityOptions are the options of the capacity report
+type CapacityOptions struct {
+	// Fit checks whether the template fits the host
+	Fit string `json:"fit,omitempty"`
+	// ValuesFiles and Params override the template values of the checked template
+	ValuesFiles []string          `json:"valuesFiles,omitempty"`
+	Params      map[string]string `json:"params,omitempty"`
+}
+
+// Capacity reports the free Spyre cards, CPU, memory and disk of the host, and whether the template fits
+func (c *Client) Capacity(ctx context.Context, opts CapacityOptions) (*HostCapacity, error) {
+	report := &HostCapacity{}
+	report.Host, _ = os.Hostname()
+
+	capacity, err := hostCapacity()
+	if err != nil {
+		return nil, err
+	}
+	report.Capacity = capacity
+
+	var reservations map[string]Resources
+	if err := state.Default().Load(resourcesStateName, &reservations); err != nil {
+		return nil, err
+	}
+	for app, res := range reservations {
+		// skip the applications deleted outside of ai-services
+		if _, err := c.GetApplication(ctx, app); errors.Is(err, ErrApplicationNotFound) {
+			continue
+		}
+		report.Reserved.add(res)
+	}
+	report.Available = Resources{
+		MilliCPU: max(0, capacity.MilliCPU-report.Reserved.MilliCPU),
+		Memory:   max(0, capacity.Memory-report.Reserved.Memory),
+	}
+
+	report.SpyreCards, report.FreeSpyreCards = spyreCardCounts(c.runtime)
+
+	report.DiskTotal, report.DiskAvailable, err = diskSpace(vars.ModelDirectory)
+	if err != nil {
+		logger.Warningf("failed to check the disk space of %s: %v\n", vars.ModelDirectory, err)
+	}
+
+	if opts.Fit != "" {
+		report.Fit, err = c.templateFit(opts, report)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return report, nil
+}
+
+// templateFit sums the resources and Spyre cards required by all the pods of the template, and checks them against
+// the capacity of the host
+func (c *Client) templateFit(opts CapacityOptions, report *HostCapacity) (*TemplateFit, error) {
+	if err := validators.ValidateAppTemplateExist(c.templates, opts.Fit); err != nil {
+		return nil, err
+	}
+	tmpls, err := c.templates.LoadAllTemplates(opts.Fit + "/templates")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the templates: %w", err)
+	}
+
+	// the pods are rendered under the template name, the application name does not change their requirements
+	cr := &creator{
+		Client: c,
+		opts:   CreateOptions{Name: opts.Fit, Template: opts.Fit, ValuesFiles: opts.ValuesFiles},
+		params: utils.CopyMap(opts.Params),
+	}
+	if cr.params == nil {
+		cr.params = map[string]string{}
+	}
+
+	fit := &TemplateFit{Template: opts.Fit}
+	for _, tmpl := range utils.ExtractMapKeys(tmpls) {
+		podSpec, err := cr.fetchPodSpec(tmpl)
+		if err != nil {
+			return nil, err
+		}
+		fit.Required.add(podResources(podSpec.Spec))
+		spyreCards, _, err := fetchSpyreCardsFromPodAnnotations(podSpec.Annotations)
+		if err != nil {
+			return nil, err
+		}
+		fit.SpyreCards += spyreCards
+	}
+
+	if fit.SpyreCards > report.FreeSpyreCards {
+		fit.Reasons = append(fit.Reasons, fmt.Sprintf("requires %d Spyre cards, %d free", fit.SpyreCards, report.FreeSpyreCards))
+	}
+	if fit.Required.MilliCPU > report.Available.MilliCPU {
+		fit.Reasons = append(fit.Reasons, fmt.Sprintf("requires cpu=%s, %s available", FormatMilliCPU(fit.Required.MilliCPU), FormatMilliCPU(report.Available.MilliCPU)))
+	}
+	if fit.Required.Memory > report.Available.Memory {
+		fit.Reasons = append(fit.Reasons, fmt.Sprintf("requires memory=%s, %s available", FormatBytes(fit.Required.Memory), FormatBytes(report.Available.Memory)))
+	}
+	fit.Fits = len(fit.Reasons) == 0
+
+	return fit, nil
+}
+
+// spyreCardCounts returns the Spyre cards attached to the host and the ones not in use, 0 if none can be detected
+func spyreCardCounts(rt runtime.Runtime) (int, int) {
+	cards, err := helpers.ListSpyreCards()
+	if err != nil {
+		logger.Infof("Unable to list the Spyre cards: %v\n", err, 2)
+		return 0, 0
+	}
+	// the VFIO devices are only present once the cards are configured
+	if _, err := os.Stat("/dev/vfio"); err != nil {
+		return len(cards), 0
+	}
+	free, err := helpers.FindFreeSpyreCards(rt)
+	if err != nil {
+		logger.Infof("Unable to find the free Spyre cards: %v\n", err, 2)
+		return len(cards), 0
+	}
+	return len(cards), len(free)
+}
+
+// diskSpace returns the size and the free space of the filesystem holding the path, or its closest existing parent
+func diskSpace(path string) (int64, int64, error) {
+	for {
+		if _, err := os.Stat(path); err == nil {
+			break
+		}
+		parent := filepath.Dir(path)
+		if parent == path {
+			break
+		}
+		path = parent
+	}
+
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, 0, err
+	}
+	return int64(stat.Blocks) * int64(stat.Bsize), int64(stat.Bavail) * int64(stat.Bsize), nil
+}
@@ -0,0 +1,50 @@
+// Package aiservices is the client library to drive the ai-services deployments programmatically,
+// i.e. without shelling out to the ai-services CLI. The CLI itself is built on top of this package.
+//
+//	client, err := aiservices.NewClient(ctx)
+//	if err != nil {
+//		return err
+//	}
+//	result, err := client.Create(ctx, aiservices.CreateOptions{Name: "rag", Template: "RAG"})
+package aiservices
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/templates"
+	"github.com/project-ai-services/ai-services/internal/pkg/platform"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/podman"
+)
+
+// Client drives the ai-services deployments on the host
+type Client struct {
+	runtime   runtime.Runtime
+	templates templates.Template
+	smt       platform.SMT
+}
+
+// NewClient creates a client connected to the podman socket of the host.
+// The podman connection can be overridden by the CONTAINER_HOST environment variable.
+func NewClient(ctx context.Context) (*Client, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	runtimeClient, err := podman.NewPodmanClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to podman: %w", err)
+	}
+
+	return newClient(runtimeClient), nil
+}
+
+// newClient creates a client using the given container runtime
+func newClient(runtime runtime.Runtime) *Client {
+	return &Client{
+		runtime:   runtime,
+		templates: templates.NewEmbedTemplateProvider(templates.EmbedOptions{}),
+		smt:       platform.NewSMT(smtOptions(false)),
+	}
+}
@@ -0,0 +1,98 @@
+package aiservices
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/helpers"
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/templates"
+	"github.com/project-ai-services/ai-services/internal/pkg/constants"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/updater"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+// CompatibilityError lists the requirements of a template the host does not meet
+type CompatibilityError struct {
+	Template string
+	Unmet    []string
+}
+
+func (e *CompatibilityError) Error() string {
+	return fmt.Sprintf("template %s is not compatible with this host:\n  - %s", e.Template, strings.Join(e.Unmet, "\n  - "))
+}
+
+// checkCompatibility verifies the host meets the requirements declared by the template: the minimum CLI, podman and
+// RHEL versions and the Spyre generation. All the unmet requirements are returned in a *CompatibilityError.
+func (c *Client) checkCompatibility(templateName string, appMetadata *templates.AppMetadata) error {
+	if appMetadata == nil || appMetadata.Requires == nil {
+		return nil
+	}
+	req := appMetadata.Requires
+	var unmet []string
+
+	if req.CLIVersion != "" {
+		if vars.CLIVersion == "unknown" {
+			logger.Warningf("The version of the CLI is unknown, the template requirement of CLI %s or later is not checked\n", req.CLIVersion)
+		} else if updater.IsNewer(req.CLIVersion, vars.CLIVersion) {
+			unmet = append(unmet, fmt.Sprintf("requires ai-services CLI %s or later, running %s: run 'ai-services self-update'", req.CLIVersion, vars.CLIVersion))
+		}
+	}
+
+	if req.PodmanVersion != "" {
+		podmanVersion, err := c.runtime.Version()
+		switch {
+		case err != nil:
+			unmet = append(unmet, fmt.Sprintf("requires podman %s or later, failed to get the podman version: %v", req.PodmanVersion, err))
+		case updater.IsNewer(req.PodmanVersion, podmanVersion):
+			unmet = append(unmet, fmt.Sprintf("requires podman %s or later, running %s", req.PodmanVersion, podmanVersion))
+		}
+	}
+
+	if req.RHELVersion != "" {
+		release := osRelease("VERSION_ID")
+		switch {
+		case osRelease("ID") != "rhel":
+			unmet = append(unmet, fmt.Sprintf("requires RHEL %s or later, running %s", req.RHELVersion, orUnknown(osRelease("PRETTY_NAME"))))
+		case updater.IsNewer(req.RHELVersion, release):
+			unmet = append(unmet, fmt.Sprintf("requires RHEL %s or later, running RHEL %s", req.RHELVersion, orUnknown(release)))
+		}
+	}
+
+	if req.AcceleratorGeneration > 0 {
+		if reason := acceleratorUnmet(req.AcceleratorGeneration); reason != "" {
+			// the Spyre cards are not passed through in rootless mode, the containers run without accelerator
+			if vars.Rootless {
+				logger.Warningf("Rootless mode: the template %s, ignored\n", reason)
+			} else {
+				unmet = append(unmet, reason)
+			}
+		}
+	}
+
+	if len(unmet) > 0 {
+		return &CompatibilityError{Template: templateName, Unmet: unmet}
+	}
+	return nil
+}
+
+// acceleratorUnmet returns why the Spyre cards of the host do not meet the required generation, empty if they do
+func acceleratorUnmet(generation int) string {
+	cards, err := helpers.ListSpyreCards()
+	switch {
+	case err != nil:
+		return fmt.Sprintf("requires Spyre generation %d or later, failed to discover the Spyre cards: %v", generation, err)
+	case len(cards) == 0:
+		return fmt.Sprintf("requires Spyre generation %d or later, no Spyre card is attached", generation)
+	case constants.SpyreGeneration < generation:
+		return fmt.Sprintf("requires Spyre generation %d or later, the attached cards are generation %d", generation, constants.SpyreGeneration)
+	}
+	return ""
+}
+
+func orUnknown(s string) string {
+	if s == "" {
+		return "unknown"
+	}
+	return s
+}
@@ -0,0 +1,329 @@
+package aiservices
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	v1 "github.com/containers/podman/v5/pkg/k8s.io/api/core/v1"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/audit"
+	"github.com/project-ai-services/ai-services/internal/pkg/constants"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/specs"
+	"github.com/project-ai-services/ai-services/internal/pkg/state"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+// configStateName is the name of the state document holding the runtime config of the applications
+const configStateName = "config"
+
+// configKeyRegex restricts the config keys to valid file names, as each key is stored in its own file
+var configKeyRegex = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// validateConfigKey checks the config key names a file of the config volume, other than the temporary files the
+// values are written to
+func validateConfigKey(key string) error {
+	if !configKeyRegex.MatchString(key) {
+		return fmt.Errorf("invalid config key '%s', allowed characters: letters, digits, '_', '.' and '-'", key)
+	}
+	if key == "." || key == ".." || strings.HasSuffix(key, ".tmp") {
+		return fmt.Errorf("invalid config key '%s', must not be '.', '..' or end with '.tmp'", key)
+	}
+	return nil
+}
+
+// AppConfig is the runtime config of an application. The config is stored in a podman volume, one file per key,
+// mounted read-only at /etc/ai-services/config in the containers declaring the keys they read with the
+// 'ai-services.io/config/<container>' annotation.
+type AppConfig struct {
+	Values map[string]string `json:"values,omitempty"`
+	// Consumers maps the containers (<pod>-<container>) to the config keys they read, '*' for all the keys
+	Consumers map[string][]string `json:"consumers,omitempty"`
+}
+
+// ConfigVolumeName returns the name of the podman volume holding the config of the application
+func ConfigVolumeName(appName string) string {
+	return appName + "--config"
+}
+
+// configConsumers returns the config keys read by the containers of the pod, by container name
+func configConsumers(podAnnotations map[string]string) map[string][]string {
+	consumers := map[string][]string{}
+	for key, val := range podAnnotations {
+		container, ok := strings.CutPrefix(key, constants.ConfigAnnotationPrefix)
+		if !ok {
+			continue
+		}
+		var keys []string
+		for k := range strings.SplitSeq(val, ",") {
+			if k = strings.TrimSpace(k); k != "" {
+				keys = append(keys, k)
+			}
+		}
+		consumers[container] = keys
+	}
+	return consumers
+}
+
+// prepareAppConfig provisions the config volume if any container of the application reads the config, and
+// records the consumers of the config keys, replacing the consumers of the previous deployment
+func (cr *creator) prepareAppConfig(tmpls []string) error {
+	allConsumers := map[string][]string{}
+	for _, tmpl := range tmpls {
+		podSpec, err := cr.fetchPodSpec(tmpl)
+		if err != nil {
+			return err
+		}
+		for container, keys := range configConsumers(podSpec.Annotations) {
+			allConsumers[podSpec.Name+"-"+container] = keys
+		}
+	}
+	if len(allConsumers) == 0 {
+		// forget the consumers of the containers removed from the templates since the previous deployment
+		configs := map[string]*AppConfig{}
+		if err := state.Default().Update(configStateName, &configs, func() error {
+			if cfg, ok := configs[cr.opts.Name]; ok {
+				cfg.Consumers = nil
+			}
+			return nil
+		}); err != nil {
+			return fmt.Errorf("failed to record the application config: %w", err)
+		}
+		return nil
+	}
+
+	var cfg AppConfig
+	if err := updateAppConfig(cr.opts.Name, func(c *AppConfig) error {
+		c.Consumers = allConsumers
+		cfg = *c
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to record the application config: %w", err)
+	}
+
+	mountpoint, err := ensureConfigVolume(cr.Client, cr.opts.Name)
+	if err != nil {
+		return err
+	}
+	// the config set before a re-deployment is kept
+	return writeConfigFiles(mountpoint, cfg.Values, nil)
+}
+
+// injectConfigMount mounts the config volume of the application read-only into the containers reading the config
+func injectConfigMount(manifest []byte, appName string, consumers map[string][]string) ([]byte, error) {
+	if len(consumers) == 0 {
+		return manifest, nil
+	}
+
+	podSpec, err := specs.ParsePodSpec(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	// kube play maps the claim to the podman volume with the same name
+	volume := v1.Volume{
+		Name: "ai-services-config",
+		VolumeSource: v1.VolumeSource{
+			PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+				ClaimName: ConfigVolumeName(appName),
+				ReadOnly:  true,
+			},
+		},
+	}
+	for container := range consumers {
+		mount := v1.VolumeMount{MountPath: constants.AppConfigMountPath, ReadOnly: true}
+		if err := specs.AddVolumeMount(podSpec, container, volume, mount); err != nil {
+			return nil, fmt.Errorf("failed to mount the application config: %w", err)
+		}
+	}
+
+	return specs.MarshalPodSpec(podSpec)
+}
+
+// GetConfig returns the runtime config of the application
+func (c *Client) GetConfig(ctx context.Context, appName string) (*AppConfig, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	configs := map[string]*AppConfig{}
+	if err := state.Default().Load(configStateName, &configs); err != nil {
+		return nil, err
+	}
+	if cfg, ok := configs[appName]; ok {
+		return cfg, nil
+	}
+	return &AppConfig{}, nil
+}
+
+// SetConfig updates the runtime config of the application, an empty value removes the key. Only the containers
+// reading the changed keys are restarted to pick up the change, which is recorded in the audit history.
+// Returns the restarted containers.
+func (c *Client) SetConfig(ctx context.Context, appName string, values map[string]string) ([]string, error) {
+	if len(values) == 0 {
+		return nil, errors.New("no config values provided")
+	}
+	for key := range values {
+		if err := validateConfigKey(key); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := c.GetApplication(ctx, appName); err != nil {
+		return nil, err
+	}
+
+	var cfg AppConfig
+	var removed []string
+	if err := updateAppConfig(appName, func(ac *AppConfig) error {
+		if ac.Values == nil {
+			ac.Values = map[string]string{}
+		}
+		for key, val := range values {
+			if val == "" {
+				delete(ac.Values, key)
+				removed = append(removed, key)
+				continue
+			}
+			ac.Values[key] = val
+		}
+		cfg = *ac
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to update the application config: %w", err)
+	}
+
+	mountpoint, err := ensureConfigVolume(c, appName)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeConfigFiles(mountpoint, cfg.Values, removed); err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	// only the keys are recorded, the values are commonly credentials
+	if err := audit.Record(audit.Entry{Application: appName, Action: "config set", Details: strings.Join(keys, " ")}); err != nil {
+		logger.Warningf("failed to record the config change in the audit history: %v\n", err)
+	}
+
+	// restart only the containers reading the changed keys
+	var affected []string
+	for container, consumed := range cfg.Consumers {
+		if slices.Contains(consumed, "*") || slices.ContainsFunc(keys, func(k string) bool { return slices.Contains(consumed, k) }) {
+			affected = append(affected, container)
+		}
+	}
+	sort.Strings(affected)
+	if len(affected) == 0 {
+		logger.Infof("No container of application %s reads the changed keys, the config is applied once a container reads them\n", appName)
+		return nil, nil
+	}
+
+	var restarted []string
+	var errs []error
+	for _, container := range affected {
+		if err := ctx.Err(); err != nil {
+			return restarted, err
+		}
+		logger.Infof("Restarting container %s to apply the config\n", container)
+		if err := c.runtime.RestartContainer(container); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", container, err))
+			continue
+		}
+		restarted = append(restarted, container)
+	}
+
+	return restarted, errors.Join(errs...)
+}
+
+// updateAppConfig updates the config of the application in the state
+func updateAppConfig(appName string, fn func(*AppConfig) error) error {
+	configs := map[string]*AppConfig{}
+	return state.Default().Update(configStateName, &configs, func() error {
+		cfg, ok := configs[appName]
+		if !ok {
+			cfg = &AppConfig{}
+			configs[appName] = cfg
+		}
+		return fn(cfg)
+	})
+}
+
+// ensureConfigVolume creates the config volume of the application if missing, and returns its mountpoint
+func ensureConfigVolume(c *Client, appName string) (string, error) {
+	name := ConfigVolumeName(appName)
+	exists, err := c.runtime.VolumeExists(name)
+	if err != nil {
+		return "", fmt.Errorf("failed to check if config volume exists: %w", err)
+	}
+
+	if !exists {
+		logger.Infof("Creating config volume %s\n", name, 2)
+		if _, err := c.runtime.CreateVolume(name, map[string]string{
+			string(vars.ManagedLabel):    "true",
+			string(vars.VolumeTypeLabel): "config",
+			"ai-services.io/application": appName,
+		}); err != nil {
+			return "", err
+		}
+	}
+
+	volume, err := c.runtime.InspectVolume(name)
+	if err != nil {
+		return "", err
+	}
+	if volume.Mountpoint == "" {
+		return "", fmt.Errorf("config volume %s does not have a mountpoint", name)
+	}
+	return volume.Mountpoint, nil
+}
+
+// writeConfigFiles writes each config key to its own file in the config volume, and removes the removed keys
+func writeConfigFiles(mountpoint string, values map[string]string, removed []string) error {
+	for key, val := range values {
+		path := filepath.Join(mountpoint, key)
+		tmp := path + ".tmp"
+		if err := os.WriteFile(tmp, []byte(val), 0o644); err != nil {
+			return fmt.Errorf("failed to write config %s: %w", key, err)
+		}
+		// rename so that the containers never read a partially written value
+		if err := os.Rename(tmp, path); err != nil {
+			return fmt.Errorf("failed to write config %s: %w", key, err)
+		}
+	}
+	for _, key := range removed {
+		if err := os.Remove(filepath.Join(mountpoint, key)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove config %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// releaseConfig removes the config of the deleted application along with its volume
+func releaseConfig(c *Client, appName string) error {
+	configs := map[string]*AppConfig{}
+	if err := state.Default().Update(configStateName, &configs, func() error {
+		delete(configs, appName)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	name := ConfigVolumeName(appName)
+	if exists, err := c.runtime.VolumeExists(name); err == nil && exists {
+		return c.runtime.RemoveVolume(name)
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package aiservices
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/containers/podman/v5/libpod/define"
+)
+
+// Container is a container of a pod of a deployed application
+type Container struct {
+	ID  string `json:"id"`
+	Pod string `json:"pod"`
+	// Name is the name of the container within its pod, kube play naming the containers <pod>-<name>
+	Name  string `json:"name"`
+	Image string `json:"image"`
+	State string `json:"state"`
+	// ExitCode is the exit code of the exited container, Eg:- of a job pod
+	ExitCode int32 `json:"exitCode,omitempty"`
+	// Health is the status of the health check, empty for the containers without health check
+	Health    string    `json:"health,omitempty"`
+	Restarts  int       `json:"restarts"`
+	StartedAt time.Time `json:"startedAt"`
+}
+
+// ListContainers returns the containers of the pods of the application, the infra containers excluded
+func (c *Client) ListContainers(ctx context.Context, appName string) ([]Container, error) {
+	app, err := c.GetApplication(ctx, appName)
+	if err != nil {
+		return nil, err
+	}
+
+	var containers []Container
+	for _, pod := range app.Pods {
+		report, err := c.runtime.InspectPod(pod.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to inspect pod %s: %w", pod.Name, err)
+		}
+		for _, ctr := range report.Containers {
+			if ctr.ID == report.InfraContainerID {
+				continue
+			}
+			info, err := c.runtime.InspectContainer(ctr.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to inspect container %s: %w", ctr.Name, err)
+			}
+			containers = append(containers, containerOf(pod.Name, info))
+		}
+	}
+	return containers, nil
+}
+
+// ContainerDetails is the inspect data of a container of a deployed application. The environment of the container
+// is left out, as it commonly carries credentials.
+type ContainerDetails struct {
+	Container
+	ImageDigest string            `json:"imageDigest,omitempty"`
+	Command     []string          `json:"command"`
+	WorkingDir  string            `json:"workingDir,omitempty"`
+	User        string            `json:"user,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Mounts      []ContainerMount  `json:"mounts,omitempty"`
+	CreatedAt   time.Time         `json:"createdAt"`
+	FinishedAt  time.Time         `json:"finishedAt,omitempty"`
+	OOMKilled   bool              `json:"oomKilled,omitempty"`
+	// Error is the error of the runtime starting the container, if any
+	Error string `json:"error,omitempty"`
+	// HealthLog are the results of the last health checks, the oldest first
+	HealthLog []HealthCheck `json:"healthLog,omitempty"`
+}
+
+// ContainerMount is a volume or host path mounted into a container
+type ContainerMount struct {
+	// Type is either volume or bind
+	Type string `json:"type"`
+	// Name is the name of the volume, empty for a bind mount
+	Name        string `json:"name,omitempty"`
+	Source      string `json:"source"`
+	Destination string `json:"destination"`
+	ReadOnly    bool   `json:"readOnly,omitempty"`
+}
+
+// HealthCheck is the result of a health check of a container
+type HealthCheck struct {
+	Start    string `json:"start"`
+	End      string `json:"end"`
+	ExitCode int    `json:"exitCode"`
+	Output   string `json:"output,omitempty"`
+}
+
+// InspectContainer returns the inspect data of the container of the application, see FindContainer
+func (c *Client) InspectContainer(ctx context.Context, appName, nameOrID string) (*ContainerDetails, error) {
+	ctr, err := c.FindContainer(ctx, appName, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	info, err := c.runtime.InspectContainer(ctr.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect container %s/%s: %w", ctr.Pod, ctr.Name, err)
+	}
+
+	details := &ContainerDetails{
+		Container:   containerOf(ctr.Pod, info),
+		ImageDigest: info.ImageDigest,
+		Command:     append([]string{info.Path}, info.Args...),
+		CreatedAt:   info.Created,
+	}
+	if info.Config != nil {
+		details.WorkingDir, details.User, details.Labels = info.Config.WorkingDir, info.Config.User, info.Config.Labels
+	}
+	for _, m := range info.Mounts {
+		details.Mounts = append(details.Mounts, ContainerMount{
+			Type: m.Type, Name: m.Name, Source: m.Source, Destination: m.Destination, ReadOnly: !m.RW,
+		})
+	}
+	if info.State != nil {
+		details.FinishedAt, details.OOMKilled, details.Error = info.State.FinishedAt, info.State.OOMKilled, info.State.Error
+		if info.State.Health != nil {
+			for _, l := range info.State.Health.Log {
+				details.HealthLog = append(details.HealthLog, HealthCheck{Start: l.Start, End: l.End, ExitCode: l.ExitCode, Output: l.Output})
+			}
+		}
+	}
+	return details, nil
+}
+
+// RestartContainer restarts the container of the application, see FindContainer. The other containers of its pod
+// keep running.
+func (c *Client) RestartContainer(ctx context.Context, appName, nameOrID string) (*Container, error) {
+	ctr, err := c.FindContainer(ctx, appName, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.runtime.RestartContainer(ctr.ID); err != nil {
+		return nil, fmt.Errorf("failed to restart container %s/%s: %w", ctr.Pod, ctr.Name, err)
+	}
+	return ctr, nil
+}
+
+// FindContainer returns the container of the application matching nameOrID: its name within its pod, its podman
+// name <pod>-<name>, or a prefix of its ID. A name shared by the containers of several pods, Eg:- the replicas of a
+// scaled component, is ambiguous and has to be qualified with the pod.
+func (c *Client) FindContainer(ctx context.Context, appName, nameOrID string) (*Container, error) {
+	containers, err := c.ListContainers(ctx, appName)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []Container
+	for _, ctr := range containers {
+		if ctr.Name == nameOrID || ctr.Pod+"-"+ctr.Name == nameOrID || strings.HasPrefix(ctr.ID, nameOrID) {
+			matches = append(matches, ctr)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("container '%s' not found in application '%s'", nameOrID, appName)
+	case 1:
+		return &matches[0], nil
+	}
+	names := make([]string, 0, len(matches))
+	for _, ctr := range matches {
+		names = append(names, ctr.Pod+"-"+ctr.Name)
+	}
+	return nil, fmt.Errorf("container '%s' is ambiguous in application '%s', use one of: %s", nameOrID, appName, strings.Join(names, ", "))
+}
+
+func containerOf(podName string, info *define.InspectContainerData) Container {
+	ctr := Container{
+		ID:       info.ID,
+		Pod:      podName,
+		Name:     strings.TrimPrefix(info.Name, podName+"-"),
+		Image:    info.ImageName,
+		Restarts: int(info.RestartCount),
+	}
+	if info.State != nil {
+		ctr.State, ctr.StartedAt, ctr.ExitCode = info.State.Status, info.State.StartedAt, info.State.ExitCode
+		if info.State.Health != nil {
+			ctr.Health = info.State.Health.Status
+		}
+	}
+	return ctr
+}
@@ -0,0 +1,161 @@
+package aiservices
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	v1 "github.com/containers/podman/v5/pkg/k8s.io/api/core/v1"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/templates"
+	"github.com/project-ai-services/ai-services/internal/pkg/constants"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/platform"
+	"github.com/project-ai-services/ai-services/internal/pkg/specs"
+	"github.com/project-ai-services/ai-services/internal/pkg/state"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+// cpusetsStateName is the name of the state document holding the physical cores dedicated to the applications
+const cpusetsStateName = "cpusets"
+
+// coreAllocations maps the applications to the physical cores (by core ID) dedicated to their containers,
+// keyed by <pod template>/<container>
+type coreAllocations map[string]map[string][]int
+
+// allocateCPUSets dedicates physical cores to the pinned containers of the application, excluding the cores
+// dedicated to the other applications. Cores already allocated to the application are kept, so that re-running
+// create doesn't move the containers. Returns the cpusets of the pinned containers by pod template and container.
+func (cr *creator) allocateCPUSets(ctx context.Context, appMetadata *templates.AppMetadata) (map[string]map[string]string, error) {
+	if len(appMetadata.CPUPinning) == 0 {
+		return nil, nil
+	}
+	if vars.Rootless {
+		logger.Warningf("Rootless mode: the containers are not pinned to dedicated cores\n")
+		return nil, nil
+	}
+
+	// the cores are read once the SMT level is set, hence the online threads of the cores match the SMT level
+	cores, err := platform.Cores()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the CPU topology: %w", err)
+	}
+	byID := make(map[int]platform.Core, len(cores))
+	for _, core := range cores {
+		byID[core.ID] = core
+	}
+
+	cpusets := map[string]map[string]string{}
+	allocations := coreAllocations{}
+	err = state.Default().Update(cpusetsStateName, &allocations, func() error {
+		used := map[int]string{}
+		for app, containers := range allocations {
+			if app == cr.opts.Name {
+				continue
+			}
+			// release the cores of the applications deleted outside of ai-services
+			if _, err := cr.GetApplication(ctx, app); errors.Is(err, ErrApplicationNotFound) {
+				delete(allocations, app)
+				continue
+			}
+			for _, ids := range containers {
+				for _, id := range ids {
+					used[id] = app
+				}
+			}
+		}
+
+		previous := allocations[cr.opts.Name]
+		current := map[string][]int{}
+		// keep the cores already dedicated to the application first
+		for _, pin := range appMetadata.CPUPinning {
+			key := pin.PodTemplate + "/" + pin.Container
+			ids := previous[key]
+			if len(ids) != pin.Cores || slices.ContainsFunc(ids, func(id int) bool { _, ok := byID[id]; return !ok || used[id] != "" }) {
+				continue
+			}
+			current[key] = ids
+			for _, id := range ids {
+				used[id] = cr.opts.Name
+			}
+		}
+
+		for _, pin := range appMetadata.CPUPinning {
+			if pin.Cores <= 0 {
+				return fmt.Errorf("invalid CPU pinning of container '%s' in '%s': cores must be positive", pin.Container, pin.PodTemplate)
+			}
+			key := pin.PodTemplate + "/" + pin.Container
+			if _, ok := current[key]; !ok {
+				var ids []int
+				for _, core := range cores {
+					if len(ids) == pin.Cores {
+						break
+					}
+					if used[core.ID] == "" {
+						ids = append(ids, core.ID)
+					}
+				}
+				if len(ids) < pin.Cores {
+					return fmt.Errorf("%w: container '%s' in '%s' requires %d dedicated cores, %d free out of %d",
+						ErrInsufficientResources, pin.Container, pin.PodTemplate, pin.Cores, len(ids), len(cores))
+				}
+				current[key] = ids
+				for _, id := range ids {
+					used[id] = cr.opts.Name
+				}
+			}
+
+			var cpus []int
+			for _, id := range current[key] {
+				cpus = append(cpus, byID[id].CPUs...)
+			}
+			if cpusets[pin.PodTemplate] == nil {
+				cpusets[pin.PodTemplate] = map[string]string{}
+			}
+			cpusets[pin.PodTemplate][pin.Container] = platform.FormatCPUList(cpus)
+			logger.Infof("Pinning container %s of %s to CPUs %s\n", pin.Container, pin.PodTemplate, cpusets[pin.PodTemplate][pin.Container], 2)
+		}
+
+		allocations[cr.opts.Name] = current
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to allocate dedicated cores: %w", err)
+	}
+
+	return cpusets, nil
+}
+
+// injectCPUSets pins the containers of the rendered pod template to their cpusets
+func injectCPUSets(manifest []byte, cpusets map[string]string) ([]byte, error) {
+	if len(cpusets) == 0 {
+		return manifest, nil
+	}
+
+	podSpec, err := specs.ParsePodSpec(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	if podSpec.Annotations == nil {
+		podSpec.Annotations = map[string]string{}
+	}
+	for container, cpuset := range cpusets {
+		if !slices.ContainsFunc(podSpec.Spec.Containers, func(c v1.Container) bool { return c.Name == container }) {
+			return nil, fmt.Errorf("failed to pin container '%s': not found in pod '%s'", container, podSpec.Name)
+		}
+		podSpec.Annotations[constants.CPUSetAnnotationPrefix+container] = cpuset
+	}
+
+	return specs.MarshalPodSpec(podSpec)
+}
+
+// releaseCPUSets releases the physical cores dedicated to the deleted application
+func releaseCPUSets(appName string) error {
+	allocations := coreAllocations{}
+	return state.Default().Update(cpusetsStateName, &allocations, func() error {
+		delete(allocations, appName)
+		return nil
+	})
+}
@@ -0,0 +1,643 @@
+package aiservices
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/admission"
+	"github.com/project-ai-services/ai-services/internal/pkg/apikeys"
+	"github.com/project-ai-services/ai-services/internal/pkg/certs"
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/helpers"
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/templates"
+	"github.com/project-ai-services/ai-services/internal/pkg/deploy"
+	"github.com/project-ai-services/ai-services/internal/pkg/imagepolicy"
+	"github.com/project-ai-services/ai-services/internal/pkg/imagescan"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/mounts"
+	"github.com/project-ai-services/ai-services/internal/pkg/secretrefs"
+	"github.com/project-ai-services/ai-services/internal/pkg/specs"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+var (
+	retryCount    = 3
+	retryInterval = 5 * time.Second
+)
+
+// CreateOptions are the options to deploy an application
+type CreateOptions struct {
+	// Name of the application
+	Name string `json:"name"`
+	// Template is the application template to deploy
+	Template string `json:"template"`
+	// Environment selects the values-<environment>.yaml overlay of the template, Eg:- dev or prod, merged over the
+	// default template values and beneath ValuesFiles and Params
+	Environment string `json:"environment,omitempty"`
+	// ValuesFiles override the default template values, later files override earlier ones
+	ValuesFiles []string `json:"valuesFiles,omitempty"`
+	// Params override the template values, taking precedence over ValuesFiles
+	Params map[string]string `json:"params,omitempty"`
+	// Labels and Annotations are merged into all the rendered pods, overriding the ones of the templates. The keys
+	// prefixed with ai-services.io/ are reserved.
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// SkipImageDownload requires the container images to be present locally
+	SkipImageDownload bool `json:"skipImageDownload,omitempty"`
+	// SkipModelDownload requires the models to be present in the model directory
+	SkipModelDownload bool `json:"skipModelDownload,omitempty"`
+	// SkipSmokeTests skips the smoke tests declared by the template once deployed
+	SkipSmokeTests bool `json:"skipSmokeTests,omitempty"`
+	// AllowedHostPaths are the sensitive host paths the templates are allowed to bind mount, in addition to the
+	// 'allowedHostPaths' of the CLI config file
+	AllowedHostPaths []string `json:"allowedHostPaths,omitempty"`
+	// SkipResourceCheck deploys the application even if the host cannot fit its CPU and memory requests
+	SkipResourceCheck bool `json:"skipResourceCheck,omitempty"`
+	// Force recreates the existing pods whose definition changed since the last deployed revision, replaced by kube
+	// play, instead of skipping them. The unchanged pods are kept, Eg:- for the dev loops on a template
+	Force bool `json:"force,omitempty"`
+	// ForceSMTLevel changes the SMT level of the host even if deployed applications require another SMT level
+	ForceSMTLevel bool `json:"forceSMTLevel,omitempty"`
+	// SignaturePolicy is the path of a containers signature policy (policy.json) the template images must
+	// satisfy, Eg:- being signed by trusted keys. The images are verified against their registries before deploying
+	SignaturePolicy string `json:"signaturePolicy,omitempty"`
+	// ScanImages scans the template images with the scanner of the CLI config file before deploying, as the
+	// 'imageScan.onCreate' of the CLI config file does
+	ScanImages bool `json:"scanImages,omitempty"`
+	// ScanFailOn is the lowest severity of the vulnerabilities failing the deployment, overriding the
+	// 'imageScan.failOn' of the CLI config file
+	ScanFailOn string `json:"scanFailOn,omitempty"`
+	// AcceptModelLicense accepts the license of the gated models being downloaded
+	AcceptModelLicense bool `json:"acceptModelLicense,omitempty"`
+	// Health overrides the health checks of the containers
+	Health HealthOverrides `json:"health"`
+	// Retry overrides the retry policy of the template for the pods failing to deploy on a transient error
+	Retry *RetryPolicy `json:"retry,omitempty"`
+
+	// TLS for the exposed services
+	TLS TLSOptions `json:"tls"`
+	// GenerateAPIKey generates an API key required by the serving endpoints
+	GenerateAPIKey bool `json:"generateAPIKey,omitempty"`
+
+	// Progress receives the progress events of the deployment
+	Progress ProgressFunc `json:"-"`
+}
+
+// TLSOptions configure the TLS certificate of the exposed services.
+// A self-signed certificate is generated unless CertFile and KeyFile are provided
+type TLSOptions struct {
+	Enabled  bool     `json:"enabled"`
+	CertFile string   `json:"certFile,omitempty"`
+	KeyFile  string   `json:"keyFile,omitempty"`
+	Hosts    []string `json:"hosts,omitempty"`
+}
+
+// creator carries the state of a single application deployment
+type creator struct {
+	*Client
+	opts CreateOptions
+	// params are the template params, extended with the provisioned secrets
+	params   map[string]string
+	progress *progressReporter
+	// cpusets are the CPUs dedicated to the pinned containers, by pod template and container
+	cpusets map[string]map[string]string
+	timings *timings
+	// rendered are the manifests deployed, recorded as a revision once the application is deployed
+	rendered renderedManifests
+	// cache holds the parsed template artifacts, see loadArtifacts
+	cache *artifacts
+	// engine deploys the pods, see deployer
+	engine     *deploy.Deployer
+	engineOnce sync.Once
+	// warnings are the warnings of the deployment, reported in its result
+	warnings   []string
+	warningsMu sync.Mutex
+	// admission are the admission policies of the rendered pods, see admit
+	admission     *admission.Config
+	admissionErr  error
+	admissionOnce sync.Once
+	// outputs are the outputs captured from the layers deployed so far, see captureOutputs
+	outputs capturedOutputs
+	// previous is the last deployed revision, the existing pods are compared to with Force
+	previous *Revision
+	// modelDirectory holds the models of the application, the mountpoint of the shared model volume when the
+	// template requires it
+	modelDirectory string
+	// smtLevel is the SMT level required by the template, recorded once the application is deployed
+	smtLevel *int
+}
+
+// Create deploys the application from the template. Pods of the application which already exist are skipped,
+// hence Create can be re-run to complete a partially deployed application. With Force, the existing pods whose
+// definition changed are recreated instead.
+// The host is expected to have been validated with Validate beforehand.
+// Once the options are valid, the result reports the pods deployed, failed and not deployed, even on failure.
+func (c *Client) Create(ctx context.Context, opts CreateOptions) (*DeploymentResult, error) {
+	if opts.Name == "" || opts.Template == "" {
+		return nil, fmt.Errorf("application name and template are required")
+	}
+	if (opts.TLS.CertFile == "") != (opts.TLS.KeyFile == "") {
+		return nil, fmt.Errorf("TLS certificate and key must be provided together")
+	}
+	if opts.TLS.CertFile != "" {
+		opts.TLS.Enabled = true
+	}
+	if err := validateMetadata(opts.Labels, opts.Annotations); err != nil {
+		return nil, err
+	}
+	if opts.ScanFailOn != "" {
+		if err := imagescan.ValidateSeverity(opts.ScanFailOn); err != nil {
+			return nil, err
+		}
+	}
+	allowed, err := mounts.Allowed()
+	if err != nil {
+		return nil, err
+	}
+	opts.AllowedHostPaths = append(allowed, opts.AllowedHostPaths...)
+
+	cr := &creator{
+		Client:   c,
+		opts:     opts,
+		params:   utils.CopyMap(opts.Params),
+		progress: &progressReporter{fn: opts.Progress},
+		timings:  &timings{},
+		cache:    &artifacts{},
+
+		modelDirectory: vars.ModelDirectory,
+	}
+
+	err = cr.create(ctx)
+	cr.notifyWebhooks(ctx, err)
+	return cr.result(err), err
+}
+
+func (cr *creator) create(ctx context.Context) error {
+	appName, templateName := cr.opts.Name, cr.opts.Template
+	logger.Infof("Creating application '%s' using template '%s'\n", appName, templateName)
+	if vars.Rootless {
+		logger.Infof("Running rootless, the SMT level is simulated and the root only steps are skipped\n")
+	}
+	cr.progress.report(ProgressEvent{Stage: StagePrepare, Message: "Checking SMT level and Spyre cards"})
+
+	// the templates are parsed once, the later steps read them from the cache
+	tmpls, appMetadata, err := cr.loadArtifacts(ctx)
+	if err != nil {
+		return err
+	}
+
+	// refuse the templates requiring a newer host before changing anything
+	if err := cr.checkCompatibility(templateName, appMetadata); err != nil {
+		return err
+	}
+
+	// set SMT level to target value, assuming it is running with root privileges (part of validation in bootstrap)
+	logger.Infoln("Checking SMT level")
+	if err := cr.setSMTLevel(); err != nil {
+		return fmt.Errorf("failed to set SMT level: %w", err)
+	}
+	logger.Infoln("SMT level configured successfully")
+
+	if err := verifyPodTemplateExists(tmpls, appMetadata); err != nil {
+		return fmt.Errorf("failed to verify pod template: %w", err)
+	}
+
+	if err := cr.validateHealthOverrides(utils.ExtractMapKeys(tmpls)); err != nil {
+		return err
+	}
+	if err := validateRestartPolicies(appMetadata, utils.ExtractMapKeys(tmpls)); err != nil {
+		return err
+	}
+	if err := validateOutputs(appMetadata, utils.ExtractMapKeys(tmpls)); err != nil {
+		return err
+	}
+	if err := validateExposure(appMetadata, utils.ExtractMapKeys(tmpls)); err != nil {
+		return err
+	}
+	if err := validateDependencies(appMetadata); err != nil {
+		return err
+	}
+
+	// ---- Validate Spyre card Requirements ----
+
+	// calculate the required spyre cards of only those pods which are not deployed yet
+	reqSpyreCardsCount, err := cr.calculateReqSpyreCards(utils.ExtractMapKeys(tmpls))
+	if err != nil {
+		return fmt.Errorf("failed to calculateReqSpyreCards: %w", err)
+	}
+
+	// the free Spyre cards are discovered while the CPU and memory are checked
+	var pciAddresses []string
+	var discovery errgroup.Group
+	if reqSpyreCardsCount > 0 && vars.Rootless {
+		cr.warn("Rootless mode: the %d Spyre cards required by the template are not passed through, the containers requiring them run without accelerator", reqSpyreCardsCount)
+	} else if reqSpyreCardsCount > 0 {
+		discovery.Go(func() error {
+			// calculate the actual available spyre cards
+			var err error
+			pciAddresses, err = helpers.FindFreeSpyreCards(cr.runtime)
+			return err
+		})
+	}
+
+	// ---- Validate CPU and memory ----
+	reserved, resourcesErr := cr.checkResources(ctx, utils.ExtractMapKeys(tmpls))
+
+	if err := discovery.Wait(); err != nil {
+		return fmt.Errorf("failed to find free Spyre Cards: %w", err)
+	}
+	if reqSpyreCardsCount > 0 && !vars.Rootless {
+		// validate spyre card requirements
+		if err := validateSpyreCardRequirements(reqSpyreCardsCount, len(pciAddresses)); err != nil {
+			return err
+		}
+	}
+	if resourcesErr != nil {
+		return resourcesErr
+	}
+
+	// dedicate physical cores to the pinned containers
+	cr.cpusets, err = cr.allocateCPUSets(ctx, appMetadata)
+	if err != nil {
+		return err
+	}
+
+	// provision the runtime config of the containers reading it
+	if err := cr.prepareAppConfig(utils.ExtractMapKeys(tmpls)); err != nil {
+		return fmt.Errorf("failed to prepare the application config: %w", err)
+	}
+
+	// models are stored in the podman volume shared across applications, instead of the model directory
+	if appMetadata.SharedModelVolume {
+		mountpoint, err := helpers.EnsureSharedModelVolume(cr.runtime)
+		if err != nil {
+			return fmt.Errorf("failed to provision shared model volume: %w", err)
+		}
+		cr.modelDirectory = mountpoint
+	}
+
+	// ---- Download Container Images ----
+	cr.progress.report(ProgressEvent{Stage: StageImages, Message: "Downloading container images"})
+	if err := cr.downloadImagesForTemplate(ctx); err != nil {
+		return err
+	}
+
+	// Download models unless skipped
+	cr.progress.report(ProgressEvent{Stage: StageModels, Message: "Downloading models"})
+	if !cr.opts.SkipModelDownload {
+		models, err := helpers.ListModels(templateName, appName)
+		if err != nil {
+			return fmt.Errorf("failed to list models: %w", err)
+		}
+		logger.Infoln("Downloading models required for application template " + templateName + ":")
+		for _, model := range models {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			logger.Infoln("Downloading model: " + model + "...")
+			cr.progress.report(ProgressEvent{Stage: StageModels, Message: "Downloading model " + model})
+			err = utils.Retry(retryCount, retryInterval, nil, func() error {
+				return helpers.DownloadModel(model, cr.modelDirectory, cr.opts.AcceptModelLicense)
+			})
+			if err != nil {
+				return fmt.Errorf("failed to download model: %w", err)
+			}
+		}
+		logger.Infoln("Model download completed.")
+	}
+
+	// Ensure the models required by the containers are present in the model cache
+	if err := cr.ensureRequiredModels(ctx, appMetadata); err != nil {
+		return err
+	}
+
+	// ---- API Keys ----
+	cr.progress.report(ProgressEvent{Stage: StageSecrets, Message: "Configuring API keys and TLS certificate"})
+	if err := cr.resolveSecretRefs(ctx); err != nil {
+		return err
+	}
+	if err := cr.configureAPIKeys(); err != nil {
+		return err
+	}
+
+	// ---- TLS Certificate ----
+	if cr.opts.TLS.Enabled {
+		if err := cr.provisionTLS(); err != nil {
+			return err
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// Loop through all pod templates, render and run kube play
+	logger.Infof("Total Pod Templates to be processed: %d\n", len(tmpls), 0)
+
+	/*
+		Pod Execution Logic:
+		1. Check if pods already exists with the given application name
+		2. If doesn't exists, proceed to create all pods
+		3. Else, skip existing pods, and create missing pods
+	*/
+
+	existingPods, err := helpers.CheckExistingPodsForApplication(cr.runtime, appName)
+	if err != nil {
+		return fmt.Errorf("failed while checking existing pods for application: %w", err)
+	}
+	if cr.opts.Force && len(existingPods) > 0 {
+		cr.loadPreviousRevision(ctx)
+	}
+
+	logger.Infoln("Deploying application '" + appName + "'...")
+	cr.progress.report(ProgressEvent{Stage: StageDeploy, Message: fmt.Sprintf("Deploying %d pod templates", len(tmpls))})
+	// execute the pod Templates
+	if err := cr.executePodTemplates(ctx, appMetadata, tmpls, pciAddresses, existingPods); err != nil {
+		rev := cr.rendered.revision(templateName, appMetadata.Version, appMetadata.PodTemplateExecutions)
+		rev.Outcome, rev.Error = OutcomeFailed, err.Error()
+		if err := recordRevision(appName, rev); err != nil {
+			cr.warn("failed to record the revision of application '%s': %v", appName, err)
+		}
+		return err
+	}
+	logger.Infoln("Application '" + appName + "' deployed successfully")
+	logger.Infoln(cr.timings.String())
+
+	rev := cr.rendered.revision(templateName, appMetadata.Version, appMetadata.PodTemplateExecutions)
+	rev.Outcome = OutcomeDeployed
+	if err := recordRevision(appName, rev); err != nil {
+		cr.warn("failed to record the revision of application '%s': %v", appName, err)
+	}
+
+	if err := cr.recordSMTRequirement(); err != nil {
+		cr.warn("failed to record the SMT level required by application '%s': %v", appName, err)
+	}
+
+	if err := reserveResources(appName, reserved); err != nil {
+		cr.warn("failed to record the resources of application '%s': %v", appName, err)
+	}
+
+	if err := cr.SyncFirewall(ctx); err != nil {
+		cr.warn("failed to open the external ports of application '%s' in the firewall: %v", appName, err)
+	}
+
+	// ---- Smoke Tests ----
+	if !cr.opts.SkipSmokeTests && len(appMetadata.SmokeTests) > 0 {
+		logger.Infof("Running smoke tests for application '%s'...\n", appName)
+		cr.progress.report(ProgressEvent{Stage: StageSmokeTest, Message: "Running smoke tests"})
+		results := helpers.RunSmokeTests(cr.runtime, cr.templates, templateName, appName, appMetadata.SmokeTests)
+		if err := helpers.PrintSmokeTestResults(results); err != nil {
+			// application is deployed, hence not failing the create. Smoke tests can be re-run with 'application smoke-test'
+			cr.warn("%v. Re-run with 'ai-services application smoke-test %s' once the issue is resolved", err, appName)
+			cr.progress.report(ProgressEvent{Stage: StageSmokeTest, Message: err.Error()})
+		}
+	}
+
+	cr.progress.report(ProgressEvent{Stage: StageCompleted, Message: "Application '" + appName + "' deployed successfully", Timings: cr.timings.list()})
+
+	return nil
+}
+
+// configureAPIKeys generates an API key if requested, and requires the API keys of the application
+// on the serving containers, if the application has any
+func (cr *creator) configureAPIKeys() error {
+	appName := cr.opts.Name
+	if cr.opts.GenerateAPIKey {
+		apiKey, key, err := apikeys.Create(cr.runtime, appName, "default")
+		if err != nil {
+			return fmt.Errorf("failed to create API key: %w", err)
+		}
+		logger.Infof("API key %s created for application '%s': %s\n", key.ID, appName, apiKey)
+		logger.Warningf("Store the API key safely, it cannot be retrieved later\n")
+	}
+
+	enabled, err := apikeys.Enabled(cr.runtime, appName)
+	if err != nil {
+		return fmt.Errorf("failed to check API keys: %w", err)
+	}
+	if !enabled {
+		return nil
+	}
+
+	if _, ok := cr.params["apiKey.secretName"]; !ok {
+		cr.params["apiKey.secretName"] = apikeys.SecretName(appName)
+		cr.cache.invalidatePodSpecs()
+	}
+
+	return nil
+}
+
+// resolveSecretRefs resolves the 'secretRef:' values just-in-time into podman secrets, the values being replaced by
+// the names of the secrets so that the clear text is never rendered
+func (cr *creator) resolveSecretRefs(ctx context.Context) error {
+	appName := cr.opts.Name
+	values, err := cr.templates.LoadValues(cr.opts.Template, cr.opts.Environment, cr.opts.ValuesFiles, cr.params)
+	if err != nil {
+		return fmt.Errorf("failed to load values: %w", err)
+	}
+	refs := secretrefs.Find(values)
+	if len(refs) == 0 {
+		return nil
+	}
+
+	labels := map[string]string{
+		"ai-services.io/application": appName,
+		string(vars.ManagedLabel):    "true",
+	}
+	for _, key := range slices.Sorted(maps.Keys(refs)) {
+		data, err := secretrefs.Resolve(ctx, refs[key])
+		if err != nil {
+			return fmt.Errorf("failed to resolve value %s: %w", key, err)
+		}
+		name := secretrefs.SecretName(appName, key)
+		secret, err := specs.MarshalSecret(name, specs.SecretTypeOpaque, map[string][]byte{secretrefs.DataKey: data})
+		if err != nil {
+			return err
+		}
+		if err := cr.runtime.CreateSecret(name, secret, labels); err != nil {
+			return fmt.Errorf("failed to store the secret of value %s: %w", key, err)
+		}
+		logger.Infof("Value %s resolved into podman secret %s\n", key, name)
+		cr.params[key] = name
+	}
+	cr.cache.invalidatePodSpecs()
+
+	return nil
+}
+
+// provisionTLS stores the TLS certificate of the application as a podman secret and passes it to the templates
+func (cr *creator) provisionTLS() error {
+	tls := cr.opts.TLS
+	opts := certs.Options{CertFile: tls.CertFile, KeyFile: tls.KeyFile, Hosts: tls.Hosts}
+	if tls.CertFile == "" {
+		if len(opts.Hosts) == 0 {
+			opts.Hosts = certs.DefaultHosts()
+		}
+		logger.Infof("Generating self-signed TLS certificate for hosts: %v\n", opts.Hosts)
+	}
+
+	params, err := certs.Provision(cr.runtime, cr.opts.Name, opts)
+	if err != nil {
+		return fmt.Errorf("failed to provision TLS certificate: %w", err)
+	}
+
+	for key, val := range params {
+		// explicitly provided params take precedence
+		if _, ok := cr.params[key]; !ok {
+			cr.params[key] = val
+		}
+	}
+	cr.cache.invalidatePodSpecs()
+
+	return nil
+}
+
+func (cr *creator) downloadImagesForTemplate(ctx context.Context) error {
+	templateName := cr.opts.Template
+
+	// Fetch all images required for a given template
+	images, err := helpers.ListImages(templateName, cr.opts.Name)
+	if err != nil {
+		return fmt.Errorf("failed to list container images: %w", err)
+	}
+
+	if cr.opts.SignaturePolicy != "" {
+		logger.Infof("Verifying the container images against the signature policy %s\n", cr.opts.SignaturePolicy)
+		cr.progress.report(ProgressEvent{Stage: StageImages, Message: "Verifying image signatures"})
+		if err := imagepolicy.Verify(ctx, cr.opts.SignaturePolicy, images); err != nil {
+			return fmt.Errorf("image signature verification failed: %w", err)
+		}
+		logger.Infoln("Container images verified against the signature policy.")
+	}
+
+	if err := cr.scanImages(ctx, images); err != nil {
+		return err
+	}
+
+	if !cr.opts.SkipImageDownload {
+		logger.Infoln("Downloading container images required for application template " + templateName + ":")
+		for _, image := range images {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			logger.Infoln("Downloading image: " + image + "...")
+			cr.progress.report(ProgressEvent{Stage: StageImages, Message: "Downloading image " + image})
+			start := time.Now()
+			if err := utils.Retry(retryCount, retryInterval, nil, func() error {
+				return cr.runtime.PullImage(image, nil)
+			}); err != nil {
+				return fmt.Errorf("failed to download image: %w", err)
+			}
+			cr.timings.since(start, Timing{Stage: TimingImagePull, Image: image})
+		}
+		logger.Infoln("Downloading container images completed.")
+		return nil
+	}
+
+	logger.Infoln("Skipping container image download as per the flag --skip-image-download=true")
+	// Verify that images exist locally
+	lImages, err := cr.runtime.ListImages()
+	if err != nil {
+		return fmt.Errorf("failed to list local images: %w", err)
+	}
+	// Populate a map with all existing local images (tags and digests)
+	existingImages := make(map[string]bool)
+
+	for _, lImage := range lImages {
+		for _, tag := range lImage.RepoTags {
+			existingImages[tag] = true
+		}
+		for _, digest := range lImage.RepoDigests {
+			existingImages[digest] = true
+		}
+	}
+
+	// Filter the requested images against the map
+	var notfoundImages []string
+
+	for _, image := range images {
+		if !existingImages[image] {
+			notfoundImages = append(notfoundImages, image)
+		}
+	}
+	if len(notfoundImages) > 0 {
+		return fmt.Errorf("some required images are not present locally: %v. Either pull the image manually or rerun create command without --skip-image-download flag", notfoundImages)
+	}
+	logger.Infoln("All required container images are present locally.")
+
+	return nil
+}
+
+// ensureRequiredModels makes sure all the models declared in metadata are present in the model cache,
+// downloading the missing ones unless model download is skipped
+func (cr *creator) ensureRequiredModels(ctx context.Context, appMetadata *templates.AppMetadata) error {
+	for _, model := range appMetadata.Models {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if utils.FileExists(filepath.Join(cr.modelDirectory, models.ModelPath(model.Name))) {
+			continue
+		}
+
+		if cr.opts.SkipModelDownload {
+			return fmt.Errorf("model %s required by container %s is not present in %s. Either download the model manually or rerun create command without --skip-model-download flag", model.Name, model.Container, cr.modelDirectory)
+		}
+
+		if err := utils.Retry(retryCount, retryInterval, nil, func() error {
+			return helpers.DownloadModel(model.Name, cr.modelDirectory, cr.opts.AcceptModelLicense)
+		}); err != nil {
+			return fmt.Errorf("failed to download model: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// scanImages scans the images with the configured scanner when requested, failing once an image has
+// vulnerabilities of the threshold severity or higher
+func (cr *creator) scanImages(ctx context.Context, images []string) error {
+	cfg, err := imagescan.Load()
+	if err != nil {
+		return err
+	}
+	if !cr.opts.ScanImages && (cfg == nil || !cfg.OnCreate) {
+		return nil
+	}
+	if cfg == nil {
+		return fmt.Errorf("no image scanner configured, set the 'imageScan' of %s", vars.ConfigFile)
+	}
+
+	logger.Infoln("Scanning the container images for vulnerabilities")
+	cr.progress.report(ProgressEvent{Stage: StageImages, Message: "Scanning images"})
+	report, err := imagescan.Scan(ctx, cfg, images)
+	if err != nil {
+		return fmt.Errorf("image scan failed: %w", err)
+	}
+	for _, img := range report.Images {
+		if img.Error != "" {
+			return fmt.Errorf("image scan failed for %s: %s", img.Image, img.Error)
+		}
+	}
+
+	threshold := cfg.FailOn
+	if cr.opts.ScanFailOn != "" {
+		threshold = cr.opts.ScanFailOn
+	}
+	if threshold != "" {
+		if err := report.Check(threshold); err != nil {
+			return fmt.Errorf("image scan failed: %w", err)
+		}
+	}
+	logger.Infoln("Container images scanned.")
+	return nil
+}
@@ -0,0 +1,180 @@
+package aiservices
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/podman"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+// DefaultGracePeriod is the time the containers are given to exit on SIGTERM before they are killed, as podman
+const DefaultGracePeriod = 10 * time.Second
+
+// deleteAttempts is the number of attempts of the force removal of a pod, before its containers are removed
+var deleteAttempts = 3
+
+// deleteRetryInterval is the delay between two attempts of the force removal of a pod
+var deleteRetryInterval = 2 * time.Second
+
+// Escalation stages of the deletion of a pod, the stage at which a pod failed to delete is reported
+const (
+	DeleteStageStop       = "stop"
+	DeleteStageRemove     = "remove"
+	DeleteStageContainers = "remove containers"
+)
+
+// PodDeleteFailure is a pod which could not be deleted, with the escalation stage it failed at and the reason
+type PodDeleteFailure struct {
+	Pod   string `json:"pod"`
+	Stage string `json:"stage"`
+	// Attempts is the number of removals of the pod attempted
+	Attempts int    `json:"attempts"`
+	Reason   string `json:"reason"`
+	// Containers are the containers of the pod left behind
+	Containers []string `json:"containers,omitempty"`
+	// Mounts are the mounts of the containers left behind still mounted on the host, which usually keep the pod
+	// from being removed. Eg:- the overlay of a container or a busy NFS volume
+	Mounts []string `json:"mounts,omitempty"`
+}
+
+// DeleteError is returned by DeleteApplication when pods could not be deleted, even after escalation
+type DeleteError struct {
+	Failures []PodDeleteFailure `json:"failures"`
+}
+
+func (e *DeleteError) Error() string {
+	msgs := make([]string, 0, len(e.Failures))
+	for _, f := range e.Failures {
+		msg := fmt.Sprintf("%s: failed at %s after %d attempt(s): %s", f.Pod, f.Stage, f.Attempts, f.Reason)
+		if len(f.Mounts) > 0 {
+			msg += fmt.Sprintf(" (stuck mounts: %s)", strings.Join(f.Mounts, ", "))
+		}
+		msgs = append(msgs, msg)
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// deletePod deletes the pod, escalating until it is gone: the pod is stopped within the grace period, force removed
+// with retries, then its containers are force removed one by one before the pod is removed again. The failure is
+// reported with the mounts of the containers left behind, nil once the pod is deleted.
+func (c *Client) deletePod(ctx context.Context, pod Pod, gracePeriod time.Duration) *PodDeleteFailure {
+	failure := &PodDeleteFailure{Pod: pod.Name, Stage: DeleteStageStop}
+
+	// a pod failing to stop is removed by force anyway
+	if err := c.runtime.StopPodWithTimeout(pod.ID, gracePeriod); err != nil {
+		logger.Infof("Failed to stop pod %s, removing it by force: %v\n", pod.Name, err, 2)
+	}
+
+	failure.Stage = DeleteStageRemove
+	err := utils.Retry(deleteAttempts-1, deleteRetryInterval, nil, func() error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		failure.Attempts++
+		return c.removePod(pod)
+	})
+	if err == nil {
+		return nil
+	}
+	if ctx.Err() != nil {
+		failure.Reason = ctx.Err().Error()
+		return failure
+	}
+	logger.Warningf("failed to remove pod %s, removing its containers: %v\n", pod.Name, err)
+
+	failure.Stage = DeleteStageContainers
+	info, inspectErr := c.runtime.InspectPod(pod.ID)
+	if inspectErr != nil {
+		failure.Reason = err.Error()
+		return failure
+	}
+	for _, ctr := range info.Containers {
+		if rmErr := c.runtime.RemoveContainer(ctr.ID, true); rmErr != nil {
+			logger.Infof("Failed to remove container %s: %v\n", ctr.Name, rmErr, 2)
+		}
+	}
+	failure.Attempts++
+	if err = c.removePod(pod); err == nil {
+		return nil
+	}
+
+	failure.Reason = err.Error()
+	failure.Containers, failure.Mounts = c.leftovers(pod.ID)
+	return failure
+}
+
+// removePod force removes the pod, a pod already gone is removed
+func (c *Client) removePod(pod Pod) error {
+	err := c.runtime.DeletePod(pod.ID, utils.BoolPtr(true))
+	if err == nil {
+		return nil
+	}
+	if exists, existsErr := c.runtime.PodExists(pod.ID); existsErr == nil && !exists {
+		return nil
+	}
+	return err
+}
+
+// leftovers returns the containers of the pod left behind and their mounts still mounted on the host. The mounts
+// can't be checked with a remote podman, nor in rootless mode where they live in the user namespace of podman: all
+// the mounts of the containers are then returned.
+func (c *Client) leftovers(podID string) ([]string, []string) {
+	info, err := c.runtime.InspectPod(podID)
+	if err != nil {
+		return nil, nil
+	}
+	var mounted map[string]bool
+	if !vars.Rootless && strings.HasPrefix(podman.ConnectionURI(), "unix://") {
+		if mounted, err = hostMountPoints(); err != nil {
+			logger.Infof("Failed to read the mount points of the host: %v\n", err, 2)
+		}
+	}
+
+	var containers, stuck []string
+	for _, ctr := range info.Containers {
+		containers = append(containers, ctr.Name)
+		ctrInfo, err := c.runtime.InspectContainer(ctr.ID)
+		if err != nil {
+			continue
+		}
+		var candidates []string
+		if ctrInfo.GraphDriver != nil && ctrInfo.GraphDriver.Data["MergedDir"] != "" {
+			candidates = append(candidates, ctrInfo.GraphDriver.Data["MergedDir"])
+		}
+		for _, m := range ctrInfo.Mounts {
+			candidates = append(candidates, m.Source)
+		}
+		for _, dir := range candidates {
+			if mounted == nil || mounted[dir] {
+				stuck = append(stuck, dir)
+			}
+		}
+	}
+	return containers, stuck
+}
+
+// hostMountPoints returns the mount points of the host, read from the mountinfo of the process
+func hostMountPoints() (map[string]bool, error) {
+	f, err := os.Open("/proc/self/mountinfo")
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	mounted := map[string]bool{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// the mount point is the fifth field
+		if fields := strings.Fields(scanner.Text()); len(fields) > 4 {
+			mounted[fields[4]] = true
+		}
+	}
+	return mounted, scanner.Err()
+}
@@ -0,0 +1,82 @@
+package aiservices
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/templates"
+)
+
+// validateDependencies checks the dependencies of the template are between pod templates of the same layer, without
+// cycle, which would deadlock the layer
+func validateDependencies(appMetadata *templates.AppMetadata) error {
+	layerOf := map[string]int{}
+	for i, layer := range appMetadata.PodTemplateExecutions {
+		for _, podTemplate := range layer {
+			layerOf[podTemplate] = i + 1
+		}
+	}
+
+	deps := podDependencies(appMetadata)
+	for _, d := range appMetadata.Dependencies {
+		layer, ok := layerOf[d.PodTemplate]
+		if !ok {
+			return fmt.Errorf("invalid dependency: pod template %s not found in podTemplateExecutions", d.PodTemplate)
+		}
+		if len(d.DependsOn) == 0 {
+			return fmt.Errorf("dependencies of pod template %s are empty", d.PodTemplate)
+		}
+		for _, dep := range d.DependsOn {
+			depLayer, ok := layerOf[dep]
+			switch {
+			case !ok:
+				return fmt.Errorf("invalid dependency of pod template %s: pod template %s not found in podTemplateExecutions", d.PodTemplate, dep)
+			case dep == d.PodTemplate:
+				return fmt.Errorf("pod template %s depends on itself", d.PodTemplate)
+			case depLayer != layer:
+				// the earlier layers are ready anyway, the later ones would never be
+				return fmt.Errorf("pod template %s of layer %d can only depend on the pod templates of its layer, %s is in layer %d",
+					d.PodTemplate, layer, dep, depLayer)
+			}
+		}
+	}
+
+	// the pod templates are visited depth first, a pod template met again on the path closes a cycle
+	visited := map[string]bool{}
+	var visit func(podTemplate string, path []string) error
+	visit = func(podTemplate string, path []string) error {
+		if i := slices.Index(path, podTemplate); i >= 0 {
+			return fmt.Errorf("dependency cycle between pod templates: %s", strings.Join(append(path[i:], podTemplate), " -> "))
+		}
+		if visited[podTemplate] {
+			return nil
+		}
+		for _, dep := range deps[podTemplate] {
+			if err := visit(dep, append(path, podTemplate)); err != nil {
+				return err
+			}
+		}
+		visited[podTemplate] = true
+		return nil
+	}
+	for _, d := range appMetadata.Dependencies {
+		if err := visit(d.PodTemplate, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// podDependencies returns the pod templates each pod template depends on
+func podDependencies(appMetadata *templates.AppMetadata) map[string][]string {
+	deps := map[string][]string{}
+	for _, d := range appMetadata.Dependencies {
+		for _, dep := range d.DependsOn {
+			if !slices.Contains(deps[d.PodTemplate], dep) {
+				deps[d.PodTemplate] = append(deps[d.PodTemplate], dep)
+			}
+		}
+	}
+	return deps
+}
@@ -0,0 +1,263 @@
+package aiservices
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/constants"
+	"github.com/project-ai-services/ai-services/internal/pkg/mounts"
+	"github.com/project-ai-services/ai-services/internal/pkg/specs"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+	"github.com/project-ai-services/ai-services/internal/pkg/validators"
+)
+
+// diffContext is the number of unchanged lines shown around each change
+const diffContext = 3
+
+// carriedOverParams are the params of the secrets provisioned for the deployed revision, kept in the proposed
+// render unless overridden
+var carriedOverParams = []string{"apiKey.secretName", "tls.secretName"}
+
+// DiffOptions are the options to compare the deployed manifests of an application
+type DiffOptions struct {
+	// Revision to compare the deployed manifests with. When unset, the deployed manifests are compared with a
+	// render of the template with the ValuesFiles and Params, as an upgrade would deploy them
+	Revision int `json:"revision,omitempty"`
+	// Template to render, defaults to the template of the deployed revision
+	Template string `json:"template,omitempty"`
+	// Environment selects the values-<environment>.yaml overlay of the template for the render
+	Environment string `json:"environment,omitempty"`
+	// ValuesFiles override the default template values of the render, later files override earlier ones
+	ValuesFiles []string `json:"valuesFiles,omitempty"`
+	// Params override the template values of the render, taking precedence over ValuesFiles
+	Params map[string]string `json:"params,omitempty"`
+}
+
+// ManifestDiff is the unified diff of the manifest of a pod template
+type ManifestDiff struct {
+	PodTemplate string `json:"podTemplate"`
+	Diff        string `json:"diff"`
+}
+
+// DiffResult is the difference between the deployed revision of an application and the compared manifests
+type DiffResult struct {
+	// Deployed is the number of the deployed revision
+	Deployed int `json:"deployed"`
+	// Compared is the revision compared with, 'proposed' for a render of the template
+	Compared string `json:"compared"`
+	// Values is the unified diff of the template values
+	Values string `json:"values,omitempty"`
+	// Manifests are the unified diffs of the changed manifests, by pod template
+	Manifests []ManifestDiff `json:"manifests,omitempty"`
+}
+
+// Empty reports whether the compared manifests and values are identical to the deployed ones
+func (d *DiffResult) Empty() bool {
+	return d.Values == "" && len(d.Manifests) == 0
+}
+
+// String renders the unified diffs, the values first
+func (d *DiffResult) String() string {
+	var b strings.Builder
+	b.WriteString(d.Values)
+	for _, m := range d.Manifests {
+		b.WriteString(m.Diff)
+	}
+	return b.String()
+}
+
+// Diff compares the manifests and values of the deployed revision of the application with either a historical
+// revision or a render of the template, without changing the application
+func (c *Client) Diff(ctx context.Context, appName string, opts DiffOptions) (*DiffResult, error) {
+	history, err := c.ListRevisions(ctx, appName)
+	if err != nil {
+		return nil, err
+	}
+	i := lastDeployed(history)
+	if i < 0 {
+		return nil, fmt.Errorf("no deployed revisions recorded for application %s", appName)
+	}
+	deployed := &history[i]
+
+	var compared *Revision
+	name := "proposed"
+	if opts.Revision != 0 {
+		for i := range history {
+			if history[i].Number == opts.Revision {
+				compared = &history[i]
+			}
+		}
+		if compared == nil {
+			return nil, fmt.Errorf("revision %d of application %s not found, %d most recent revisions are kept", opts.Revision, appName, MaxRevisions)
+		}
+		name = fmt.Sprintf("revision-%d", compared.Number)
+	} else {
+		compared, err = c.renderProposed(ctx, appName, deployed, opts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return diffRevisions(deployed, compared, name)
+}
+
+// diffRevisions computes the unified diffs of the values and manifests of the revisions
+func diffRevisions(deployed, compared *Revision, name string) (*DiffResult, error) {
+	from := fmt.Sprintf("revision-%d", deployed.Number)
+	result := &DiffResult{Deployed: deployed.Number, Compared: name}
+
+	oldValues, err := marshalValues(deployed.Values)
+	if err != nil {
+		return nil, err
+	}
+	newValues, err := marshalValues(compared.Values)
+	if err != nil {
+		return nil, err
+	}
+	result.Values = utils.UnifiedDiff(from+"/values.yaml", name+"/values.yaml", oldValues, newValues, diffContext)
+
+	tmpls := utils.UniqueSlice(append(utils.ExtractMapKeys(deployed.Manifests), utils.ExtractMapKeys(compared.Manifests)...))
+	slices.Sort(tmpls)
+	for _, tmpl := range tmpls {
+		// the objects declared along with the pod are compared as played
+		old := withObjects([]byte(deployed.Objects[tmpl]), []byte(deployed.Manifests[tmpl]))
+		cur := withObjects([]byte(compared.Objects[tmpl]), []byte(compared.Manifests[tmpl]))
+		diff := utils.UnifiedDiff(from+"/"+tmpl, name+"/"+tmpl, string(old), string(cur), diffContext)
+		if diff != "" {
+			result.Manifests = append(result.Manifests, ManifestDiff{PodTemplate: tmpl, Diff: diff})
+		}
+	}
+	return result, nil
+}
+
+func marshalValues(values map[string]any) (string, error) {
+	if len(values) == 0 {
+		return "", nil
+	}
+	data, err := yaml.Marshal(values)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal values: %w", err)
+	}
+	return string(data), nil
+}
+
+// renderProposed renders the manifests of the template as an upgrade of the application would deploy them. The
+// Spyre cards, pinned cores and secrets of the deployed revision are kept, so that only the changes of the template
+// and values show up
+func (c *Client) renderProposed(ctx context.Context, appName string, deployed *Revision, opts DiffOptions) (*Revision, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	templateName := opts.Template
+	if templateName == "" {
+		templateName = deployed.Template
+	}
+	cr := &creator{
+		Client:   c,
+		opts:     CreateOptions{Name: appName, Template: templateName, Environment: opts.Environment, ValuesFiles: opts.ValuesFiles},
+		params:   utils.CopyMap(opts.Params),
+		progress: &progressReporter{},
+		timings:  &timings{},
+		cpusets:  map[string]map[string]string{},
+	}
+	if cr.params == nil {
+		cr.params = map[string]string{}
+	}
+	// the host paths mounted by the deployed revision were already allowed
+	allowed, err := mounts.Allowed()
+	if err != nil {
+		return nil, err
+	}
+	for _, manifest := range deployed.Manifests {
+		podSpec, err := specs.ParsePodSpec([]byte(manifest))
+		if err != nil {
+			return nil, fmt.Errorf("revision %d: %w", deployed.Number, err)
+		}
+		allowed = append(allowed, mounts.HostPaths(podSpec)...)
+	}
+	cr.opts.AllowedHostPaths = allowed
+
+	deployedValues := map[string]string{}
+	flattenValues("", deployed.Values, deployedValues)
+	for _, key := range carriedOverParams {
+		if val, ok := deployedValues[key]; ok {
+			if _, ok := cr.params[key]; !ok {
+				cr.params[key] = val
+			}
+		}
+	}
+
+	if err := validators.ValidateAppTemplateExist(cr.templates, templateName); err != nil {
+		return nil, err
+	}
+	tmpls, err := cr.templates.LoadAllTemplates(templateName + "/templates")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the templates: %w", err)
+	}
+	appMetadata, err := cr.templates.LoadMetadata(templateName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the app metadata: %w", err)
+	}
+	if err := verifyPodTemplateExists(tmpls, appMetadata); err != nil {
+		return nil, fmt.Errorf("failed to verify pod template: %w", err)
+	}
+
+	globalParams, err := cr.templateParams(appMetadata)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, podTemplateName := range utils.FlattenArray(appMetadata.PodTemplateExecutions) {
+		podSpec, err := cr.fetchPodSpec(podTemplateName)
+		if err != nil {
+			return nil, err
+		}
+
+		spyreAssignments, cpusets, err := deployedAssignments(deployed.Manifests[podTemplateName])
+		if err != nil {
+			return nil, fmt.Errorf("revision %d: %w", deployed.Number, err)
+		}
+		cr.cpusets[podTemplateName] = cpusets
+
+		manifest, objects, err := cr.renderPod(podTemplateName, tmpls[podTemplateName], globalParams, podSpec, spyreAssignments, appMetadata)
+		if err != nil {
+			return nil, err
+		}
+		cr.rendered.add(podTemplateName, manifest, objects, constructPodDeployOptions(fetchPodAnnotations(podSpec)))
+	}
+
+	return cr.rendered.revision(templateName, appMetadata.Version, appMetadata.PodTemplateExecutions), nil
+}
+
+// deployedAssignments returns the Spyre cards and the cpusets of the containers in the deployed manifest
+func deployedAssignments(manifest string) (map[string][]string, map[string]string, error) {
+	if manifest == "" {
+		return nil, nil, nil
+	}
+	podSpec, err := specs.ParsePodSpec([]byte(manifest))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	spyreAssignments := map[string][]string{}
+	for _, container := range podSpec.Spec.Containers {
+		for _, env := range container.Env {
+			if env.Name == string(constants.PCIAddressKey) && env.Value != "" {
+				spyreAssignments[container.Name] = strings.Fields(env.Value)
+			}
+		}
+	}
+
+	cpusets := map[string]string{}
+	for key, val := range podSpec.Annotations {
+		if container, ok := strings.CutPrefix(key, constants.CPUSetAnnotationPrefix); ok {
+			cpusets[container] = val
+		}
+	}
+	return spyreAssignments, cpusets, nil
+}
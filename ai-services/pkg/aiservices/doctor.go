@@ -0,0 +1,300 @@
+package aiservices
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/audit"
+	"github.com/project-ai-services/ai-services/internal/pkg/compliance"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/podman"
+	"github.com/project-ai-services/ai-services/internal/pkg/spyre"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+// Severities of the problems, from the most to the least severe
+const (
+	// SeverityCritical problems prevent any application from being deployed or managed
+	SeverityCritical = "critical"
+	// SeverityError problems break an application or a host requirement
+	SeverityError = "error"
+	// SeverityWarning problems may degrade the applications
+	SeverityWarning = "warning"
+)
+
+// Areas of the problems, in the order they are checked
+const (
+	AreaRuntime      = "runtime"
+	AreaValidation   = "validation"
+	AreaState        = "state"
+	AreaSpyre        = "spyre"
+	AreaApplications = "applications"
+	AreaEvents       = "events"
+)
+
+// minDiskFreeRatio is the ratio of free space of the podman storage below which it is reported
+const minDiskFreeRatio = 0.1
+
+// maxHealthyRestarts is the restart count of a container above which it is reported as crash looping
+const maxHealthyRestarts = 3
+
+// eventActions are the actions of the audit history reported as recent events
+var eventActions = []string{"log alert", "restart limit", "admission denied", "admission warning", "compliance drift", "failover"}
+
+// Problem is a problem detected by Diagnose, with the command remediating it
+type Problem struct {
+	Severity string `json:"severity"`
+	Area     string `json:"area"`
+	// Subject is the application, container, check or file the problem is about
+	Subject     string `json:"subject,omitempty"`
+	Summary     string `json:"summary"`
+	Remediation string `json:"remediation,omitempty"`
+}
+
+// Diagnosis is the result of Diagnose
+type Diagnosis struct {
+	Time    time.Time    `json:"time"`
+	Runtime *RuntimeInfo `json:"runtime"`
+	// Problems are ranked by severity, then by area
+	Problems []Problem `json:"problems"`
+}
+
+// Healthy reports whether no critical problem nor error was detected
+func (d *Diagnosis) Healthy() bool {
+	return !slices.ContainsFunc(d.Problems, func(p Problem) bool { return p.Severity != SeverityWarning })
+}
+
+// DiagnoseOptions are the options of Diagnose
+type DiagnoseOptions struct {
+	// Skip are the validation checks not run
+	Skip []string
+	// Since is the period of the audit history analyzed for recent events, 24h if zero
+	Since time.Duration
+}
+
+// Diagnose inspects the runtime, validates the host, verifies the state documents, the Spyre cards and the
+// containers of the applications, and analyzes the recent events of the audit history. The checks requiring the
+// podman connection are skipped when podman is unreachable, which is reported as a critical problem.
+func Diagnose(ctx context.Context, opts DiagnoseOptions) (*Diagnosis, error) {
+	if opts.Since == 0 {
+		opts.Since = 24 * time.Hour
+	}
+	d := &Diagnosis{Time: time.Now().UTC()}
+	add := func(severity, area, subject, remediation, format string, args ...any) {
+		d.Problems = append(d.Problems, Problem{Severity: severity, Area: area, Subject: subject,
+			Summary: fmt.Sprintf(format, args...), Remediation: remediation})
+	}
+
+	var err error
+	if d.Runtime, err = InspectRuntime(ctx); err != nil {
+		return nil, err
+	}
+	diagnoseRuntime(d.Runtime, add)
+
+	skip := opts.Skip
+	if vars.Rootless {
+		// the steps these checks validate are skipped in rootless mode
+		skip = append(slices.Clone(skip), "root", "rhn", "spyre")
+	}
+	results, _, err := validate(ctx, skip)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range results {
+		if r.Passed || r.Skipped {
+			continue
+		}
+		severity := SeverityError
+		if r.Warning {
+			severity = SeverityWarning
+		}
+		remediation := r.Hint
+		if remediation == "" {
+			remediation = "ai-services bootstrap configure"
+		}
+		add(severity, AreaValidation, r.Name, remediation, "validation check %s failed: %s", r.Name, r.Message)
+	}
+	if st, err := compliance.Current(); err != nil {
+		add(SeverityWarning, AreaState, "compliance", "", "failed to read the compliance history: %v", err)
+	} else if st.Status == compliance.StatusDrifted && st.Since != nil {
+		add(SeverityWarning, AreaValidation, "compliance", "ai-services bootstrap validate",
+			"the host drifted out of its validated configuration on %s", st.Since.Local().Format(time.RFC1123))
+	}
+
+	diagnoseState(add)
+	diagnoseSpyre(add)
+
+	if d.Runtime.Connected {
+		runtimeClient, err := podman.NewPodmanClient()
+		if err != nil {
+			add(SeverityCritical, AreaRuntime, d.Runtime.Connection, "ai-services runtime info", "failed to connect to podman: %v", err)
+		} else if err := newClient(runtimeClient).diagnoseApplications(ctx, add); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := diagnoseEvents(opts.Since, add); err != nil {
+		add(SeverityWarning, AreaState, "audit", "", "failed to read the audit history: %v", err)
+	}
+
+	severities := []string{SeverityCritical, SeverityError, SeverityWarning}
+	areas := []string{AreaRuntime, AreaValidation, AreaState, AreaSpyre, AreaApplications, AreaEvents}
+	slices.SortStableFunc(d.Problems, func(a, b Problem) int {
+		if s := slices.Index(severities, a.Severity) - slices.Index(severities, b.Severity); s != 0 {
+			return s
+		}
+		return slices.Index(areas, a.Area) - slices.Index(areas, b.Area)
+	})
+	if d.Problems == nil {
+		d.Problems = []Problem{}
+	}
+	return d, nil
+}
+
+type addProblem func(severity, area, subject, remediation, format string, args ...any)
+
+// diagnoseRuntime reports the podman connection, kube play and storage problems
+func diagnoseRuntime(info *RuntimeInfo, add addProblem) {
+	socket := "systemctl enable --now podman.socket"
+	if vars.Rootless {
+		socket = "systemctl --user enable --now podman.socket"
+	}
+	if !info.Connected {
+		add(SeverityCritical, AreaRuntime, info.Connection, socket, "podman is unreachable at %s", info.Connection)
+	}
+	if !info.KubePlay.Available {
+		add(SeverityCritical, AreaRuntime, "kube play", "dnf install podman", "the podman CLI or its kube play command is unavailable")
+	} else {
+		for _, flag := range requiredKubePlayFlags {
+			if !info.KubePlay.Flags[flag] {
+				add(SeverityCritical, AreaRuntime, "kube play", "dnf update podman", "podman kube play doesn't support the --%s flag", flag)
+			}
+		}
+	}
+	if info.DiskTotal > 0 && float64(info.DiskFree) < minDiskFreeRatio*float64(info.DiskTotal) {
+		add(SeverityWarning, AreaRuntime, info.GraphRoot, "podman image prune",
+			"the podman storage %s is almost full, %.1fGi free of %.1fGi", info.GraphRoot, float64(info.DiskFree)/(1<<30), float64(info.DiskTotal)/(1<<30))
+	}
+}
+
+// diagnoseState reports the state documents which cannot be parsed
+func diagnoseState(add addProblem) {
+	paths, err := filepath.Glob(filepath.Join(vars.StateDirectory, "*.json"))
+	if err != nil {
+		add(SeverityWarning, AreaState, vars.StateDirectory, "", "failed to list the state documents: %v", err)
+		return
+	}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			add(SeverityError, AreaState, path, "ls -l "+path, "failed to read the state document: %v", err)
+			continue
+		}
+		if !json.Valid(data) {
+			add(SeverityError, AreaState, path, fmt.Sprintf("mv %s %s.corrupted", path, path),
+				"the state document %s is corrupted, restore it from a backup or move it aside", filepath.Base(path))
+		}
+	}
+}
+
+// diagnoseSpyre reports the Spyre cards of the ledger which are no longer attached to the host
+func diagnoseSpyre(add addProblem) {
+	cards, err := spyreCards()
+	if err != nil {
+		add(SeverityWarning, AreaSpyre, "", "ai-services bootstrap validate", "failed to list the spyre cards: %v", err)
+		return
+	}
+	ledger, err := spyre.List()
+	if err != nil {
+		add(SeverityError, AreaSpyre, "", "", "failed to read the spyre ledger: %v", err)
+		return
+	}
+	for _, a := range ledger {
+		if !slices.ContainsFunc(cards, func(c SpyreCard) bool { return spyre.NormalizeAddress(c.Address) == a.Address }) {
+			add(SeverityWarning, AreaSpyre, a.Address, "ai-services spyre deallocate "+a.Address,
+				"spyre card %s allocated to %s is not attached to the host", a.Address, a.Owner)
+		}
+	}
+}
+
+// diagnoseApplications reports the containers of the applications which are not running, unhealthy or crash
+// looping
+func (c *Client) diagnoseApplications(ctx context.Context, add addProblem) error {
+	apps, err := c.ListApplications(ctx)
+	if err != nil {
+		add(SeverityError, AreaApplications, "", "ai-services runtime info", "failed to list the applications: %v", err)
+		return nil
+	}
+	for _, app := range apps {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		containers, err := c.ListContainers(ctx, app.Name)
+		if err != nil {
+			add(SeverityError, AreaApplications, app.Name, "ai-services application ps "+app.Name,
+				"failed to list the containers of application %s: %v", app.Name, err)
+			continue
+		}
+		for _, ctr := range containers {
+			logs := fmt.Sprintf("ai-services container logs %s --app %s", ctr.Name, app.Name)
+			subject := app.Name + "/" + ctr.Name
+			switch {
+			case ctr.State == "created" || ctr.State == "exited" && ctr.ExitCode == 0:
+				// not started yet, Eg:- the pods started on demand, or run to completion, Eg:- the job pods
+			case ctr.State != "running":
+				add(SeverityError, AreaApplications, subject, logs, "container %s of application %s is %s", ctr.Name, app.Name, ctr.State)
+			case ctr.Health == "unhealthy":
+				add(SeverityError, AreaApplications, subject, logs, "container %s of application %s is unhealthy", ctr.Name, app.Name)
+			case ctr.Restarts > maxHealthyRestarts:
+				add(SeverityWarning, AreaApplications, subject, logs, "container %s of application %s restarted %d times", ctr.Name, app.Name, ctr.Restarts)
+			}
+		}
+	}
+	return nil
+}
+
+// diagnoseEvents reports the notable events of the audit history over the period, grouped by application and action
+func diagnoseEvents(since time.Duration, add addProblem) error {
+	entries, err := audit.List("")
+	if err != nil {
+		return err
+	}
+
+	type group struct {
+		app, action string
+	}
+	counts := map[group]int{}
+	latest := map[group]audit.Entry{}
+	var order []group
+	cutoff := time.Now().Add(-since)
+	for _, e := range entries {
+		if e.Time.Before(cutoff) || !slices.Contains(eventActions, e.Action) {
+			continue
+		}
+		g := group{e.Application, e.Action}
+		if counts[g] == 0 {
+			order = append(order, g)
+		}
+		counts[g]++
+		latest[g] = e
+	}
+
+	for _, g := range order {
+		subject, remediation := g.app, "ai-services application config show "+g.app+" --history"
+		if g.app == "" {
+			subject, remediation = "host", "ai-services status"
+		}
+		details := strings.TrimSpace(latest[g].Details)
+		if details == "" {
+			details = "no details"
+		}
+		add(SeverityWarning, AreaEvents, subject, remediation, "%d %s event(s) of %s in the last %s, latest at %s: %s",
+			counts[g], g.action, subject, since, latest[g].Time.Local().Format(time.RFC1123), details)
+	}
+	return nil
+}
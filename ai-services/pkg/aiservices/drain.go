@@ -0,0 +1,206 @@
+package aiservices
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/helpers"
+	"github.com/project-ai-services/ai-services/internal/pkg/constants"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+)
+
+var (
+	// defaultDrainTimeout bounds the drain of the pods declaring a drain endpoint or in-flight metric without
+	// a drain timeout
+	defaultDrainTimeout = 2 * time.Minute
+	drainPollInterval   = 2 * time.Second
+)
+
+// drainSpec is the drain declared by the annotations of a pod
+type drainSpec struct {
+	timeout time.Duration
+	// endpoints are the drain endpoints by container, '<port>[/<path>]'
+	endpoints map[string]string
+	// inflight are the in-flight metrics by container, '<port>:<metric>'
+	inflight map[string]string
+}
+
+// drainPod stops the serving pod gracefully before it is deleted or replaced, so that the inference requests in
+// flight are not dropped: the drain endpoints of the containers are called to stop accepting new requests, then the
+// in-flight requests are awaited until the drain timeout, and the pod is stopped with SIGTERM for the time left.
+// The pods declaring no drain are left untouched.
+func (c *Client) drainPod(ctx context.Context, podNameOrID string) error {
+	info, err := c.runtime.InspectPod(podNameOrID)
+	if err != nil {
+		return fmt.Errorf("failed to inspect pod %s: %w", podNameOrID, err)
+	}
+	if info.State != "Running" {
+		return nil
+	}
+
+	spec := drainSpec{endpoints: map[string]string{}, inflight: map[string]string{}}
+	for _, ctr := range info.Containers {
+		if ctr.ID == info.InfraContainerID {
+			continue
+		}
+		ctrInfo, err := c.runtime.InspectContainer(ctr.ID)
+		if err != nil || ctrInfo.Config == nil {
+			continue
+		}
+		// kube play names the containers <pod>-<container>, and sets the pod annotations on all of them
+		container := strings.TrimPrefix(ctrInfo.Name, info.Name+"-")
+		annotations := ctrInfo.Config.Annotations
+		if val, ok := annotations[constants.DrainAnnotationPrefix+container]; ok {
+			spec.endpoints[container] = val
+		}
+		if val, ok := annotations[constants.InflightAnnotationPrefix+container]; ok {
+			spec.inflight[container] = val
+		}
+		if val, ok := annotations[constants.DrainTimeoutAnnotationKey]; ok {
+			if spec.timeout, err = time.ParseDuration(val); err != nil || spec.timeout <= 0 {
+				return fmt.Errorf("invalid drain timeout '%s' of pod %s", val, info.Name)
+			}
+		}
+	}
+	if spec.timeout == 0 && len(spec.endpoints) == 0 && len(spec.inflight) == 0 {
+		return nil
+	}
+	if spec.timeout == 0 {
+		spec.timeout = defaultDrainTimeout
+	}
+
+	logger.Infof("Draining pod %s for up to %s\n", info.Name, spec.timeout)
+	deadline := time.Now().Add(spec.timeout)
+	ip, err := helpers.FetchPodIP(c.runtime, info.ID)
+	if err != nil {
+		// the pod is only stopped gracefully
+		logger.Warningf("Unable to drain the requests of pod %s: %v\n", info.Name, err)
+	} else {
+		for container, endpoint := range spec.endpoints {
+			if err := postDrain(ctx, ip, endpoint); err != nil {
+				logger.Warningf("failed to drain container %s of pod %s: %v\n", container, info.Name, err)
+			}
+		}
+		c.awaitInflight(ctx, info.Name, ip, spec.inflight, deadline)
+	}
+
+	// SIGTERM lets the servers complete the requests left, until the drain timeout
+	grace := max(time.Until(deadline), time.Second)
+	if err := c.runtime.StopPodWithTimeout(info.ID, grace); err != nil {
+		return fmt.Errorf("failed to stop pod %s: %w", info.Name, err)
+	}
+	return nil
+}
+
+// awaitInflight waits for the in-flight requests of the containers to complete, until the deadline
+func (c *Client) awaitInflight(ctx context.Context, pod, ip string, inflight map[string]string, deadline time.Time) {
+	if len(inflight) == 0 {
+		return
+	}
+	for {
+		var total float64
+		for container, val := range inflight {
+			port, metric, _ := strings.Cut(val, ":")
+			n, err := scrapeMetric(ctx, "http://"+net.JoinHostPort(ip, port)+"/metrics", metric)
+			if err != nil {
+				logger.Infof("Unable to read the requests in flight of container %s: %v\n", container, err, 2)
+				continue
+			}
+			total += n
+		}
+		if total == 0 {
+			logger.Infof("Pod %s drained\n", pod)
+			return
+		}
+		if time.Now().Add(drainPollInterval).After(deadline) {
+			logger.Warningf("Pod %s still has %g requests in flight after the drain timeout\n", pod, total)
+			return
+		}
+		logger.Infof("Waiting for %g requests in flight of pod %s\n", total, pod, 2)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(drainPollInterval):
+		}
+	}
+}
+
+// postDrain calls the drain endpoint '<port>[/<path>]' of the container
+func postDrain(ctx context.Context, ip, endpoint string) error {
+	port, path, _ := strings.Cut(endpoint, "/")
+	if _, err := strconv.Atoi(port); err != nil {
+		return fmt.Errorf("invalid drain endpoint '%s'", endpoint)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, scrapeTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://"+net.JoinHostPort(ip, port)+"/"+path, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("drain endpoint returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// scrapeMetric returns the Prometheus metric served at the URL, summed across its series
+func scrapeMetric(ctx context.Context, url, metric string) (float64, error) {
+	ctx, cancel := context.WithTimeout(ctx, scrapeTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("%s returned status %d", url, resp.StatusCode)
+	}
+
+	// Prometheus text format, Eg:- 'vllm:num_requests_waiting{model_name="granite"} 3.0'
+	var total float64
+	var found bool
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, rest, _ := strings.Cut(line, " ")
+		if i := strings.IndexByte(line, '{'); i >= 0 {
+			name, rest = line[:i], line[strings.LastIndexByte(line, '}')+1:]
+		}
+		fields := strings.Fields(rest)
+		if name != metric || len(fields) == 0 {
+			continue
+		}
+		value, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid value of metric %s: %w", metric, err)
+		}
+		total += value
+		found = true
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+	if !found {
+		return 0, fmt.Errorf("metric %s not found at %s", metric, url)
+	}
+	return total, nil
+}
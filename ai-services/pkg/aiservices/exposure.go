@@ -0,0 +1,173 @@
+package aiservices
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/containers/podman/v5/pkg/domain/entities/types"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/templates"
+	"github.com/project-ai-services/ai-services/internal/pkg/constants"
+	"github.com/project-ai-services/ai-services/internal/pkg/firewall"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/specs"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+var exposures = []string{templates.ExposureExternal, templates.ExposureLocal, templates.ExposureInternal}
+
+// loopbackAddress is the host address the local ports are published on
+const loopbackAddress = "127.0.0.1"
+
+// validateExposure checks the exposures of the template refer to its pod templates
+func validateExposure(appMetadata *templates.AppMetadata, podTemplateFileNames []string) error {
+	seen := map[string]bool{}
+	for _, e := range appMetadata.Exposure {
+		if !slices.Contains(podTemplateFileNames, e.PodTemplate) {
+			return fmt.Errorf("invalid exposure: pod template %s not found", e.PodTemplate)
+		}
+		if e.Port <= 0 || e.Port > 65535 {
+			return fmt.Errorf("invalid exposure of pod template %s: invalid port %d", e.PodTemplate, e.Port)
+		}
+		if !slices.Contains(exposures, e.Exposure) {
+			return fmt.Errorf("invalid exposure '%s' of port %d of pod template %s, supported: %v", e.Exposure, e.Port, e.PodTemplate, exposures)
+		}
+		key := e.PodTemplate + "/" + strconv.Itoa(e.Port)
+		if seen[key] {
+			return fmt.Errorf("duplicate exposure of port %d of pod template %s", e.Port, e.PodTemplate)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// injectExposure records the exposures of the ports of the pod template in the exposure annotation, and removes
+// the internal ports from the ports annotation so that they are not published, nor assigned a host port.
+func (cr *creator) injectExposure(manifest []byte, podTemplateName string, appMetadata *templates.AppMetadata) ([]byte, error) {
+	declared := map[string]string{}
+	for _, e := range appMetadata.Exposure {
+		if e.PodTemplate == podTemplateName {
+			declared[strconv.Itoa(e.Port)] = e.Exposure
+		}
+	}
+	if len(declared) == 0 {
+		return manifest, nil
+	}
+
+	podSpec, err := specs.ParsePodSpec(manifest)
+	if err != nil {
+		return nil, err
+	}
+	if podSpec.Annotations == nil {
+		podSpec.Annotations = map[string]string{}
+	}
+
+	var entries []string
+	for entry := range strings.SplitSeq(podSpec.Annotations[constants.PodPortsAnnotationKey], ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		// the container port follows the colon, if any
+		containerPort := entry[strings.Index(entry, ":")+1:]
+		if declared[strings.TrimSpace(containerPort)] == templates.ExposureInternal {
+			logger.Infof("Port %s of %s is internal, not publishing it\n", containerPort, podTemplateName, 2)
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) == 0 {
+		delete(podSpec.Annotations, constants.PodPortsAnnotationKey)
+	} else {
+		podSpec.Annotations[constants.PodPortsAnnotationKey] = strings.Join(entries, ",")
+	}
+
+	pairs := make([]string, 0, len(declared))
+	for port, exposure := range declared {
+		pairs = append(pairs, port+"="+exposure)
+	}
+	sort.Strings(pairs)
+	podSpec.Annotations[constants.PortExposureAnnotationKey] = strings.Join(pairs, ",")
+
+	return specs.MarshalPodSpec(podSpec)
+}
+
+// SyncFirewall opens the host ports published on all the interfaces by the applications in firewalld, tagged as
+// the firewalld service of each application, and removes the services of the applications which publish no port
+// anymore. Nothing is done unless the 'firewall' section of the CLI config enables it.
+func (c *Client) SyncFirewall(ctx context.Context) error {
+	cfg, err := firewall.Load()
+	if err != nil {
+		return err
+	}
+	if !cfg.Manage {
+		return nil
+	}
+	if vars.Rootless {
+		logger.Infof("Rootless mode: the firewall is not managed\n", 2)
+		return nil
+	}
+	if !firewall.Active() {
+		return fmt.Errorf("firewalld is not running, start it or disable the firewall management in the CLI config")
+	}
+
+	ports, err := c.externalPorts(ctx)
+	if err != nil {
+		return err
+	}
+	updated, removed, err := firewall.Sync(cfg, ports)
+	if err != nil {
+		return fmt.Errorf("failed to sync the firewall: %w", err)
+	}
+	for _, change := range updated {
+		logger.Infof("Opened the ports %s of application %s in the firewall, as service %s\n",
+			strings.Join(change.Ports, ", "), change.Application, change.Service)
+	}
+	for _, change := range removed {
+		logger.Infof("Closed the ports of application %s in the firewall, removed service %s\n", change.Application, change.Service)
+	}
+	return nil
+}
+
+// externalPorts returns the host ports published on all the interfaces by the pods of the applications, by
+// application
+func (c *Client) externalPorts(ctx context.Context) (map[string][]int, error) {
+	apps, err := c.ListApplications(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ports := map[string][]int{}
+	for _, app := range apps {
+		for _, pod := range app.Pods {
+			info, err := c.runtime.InspectPod(pod.ID)
+			if err != nil {
+				return nil, err
+			}
+			ports[app.Name] = append(ports[app.Name], publishedPorts(info)...)
+		}
+	}
+	return ports, nil
+}
+
+// publishedPorts returns the host ports of the pod bound on all the interfaces
+func publishedPorts(info *types.PodInspectReport) []int {
+	if info.InfraConfig == nil {
+		return nil
+	}
+	var ports []int
+	for _, bindings := range info.InfraConfig.PortBindings {
+		for _, b := range bindings {
+			if b.HostIP != "" && b.HostIP != "0.0.0.0" && b.HostIP != "::" {
+				continue
+			}
+			if port, err := strconv.Atoi(b.HostPort); err == nil && port > 0 {
+				ports = append(ports, port)
+			}
+		}
+	}
+	return ports
+}
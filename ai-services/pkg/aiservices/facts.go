@@ -0,0 +1,248 @@
+package aiservices
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	goruntime "runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/helpers"
+	"github.com/project-ai-services/ai-services/internal/pkg/platform"
+	"github.com/project-ai-services/ai-services/internal/pkg/spyre"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+// nodeSysfsPath is the sysfs directory describing the NUMA nodes of the host
+const nodeSysfsPath = "/sys/devices/system/node"
+
+// HostFacts is the inventory of the host, consumed by the templates as .Facts, the placement decisions and the
+// support bundles. The facts which cannot be collected are left empty and reported in Errors.
+type HostFacts struct {
+	Collected time.Time `json:"collected"`
+	Hostname  string    `json:"hostname"`
+	Arch      string    `json:"arch"`
+	OS        string    `json:"os,omitempty"`
+	Kernel    string    `json:"kernel,omitempty"`
+	CPU       CPUFacts  `json:"cpu"`
+	// Memory is the total memory in bytes
+	Memory  int64         `json:"memory"`
+	NUMA    []NUMANode    `json:"numa,omitempty"`
+	Spyre   []SpyreCard   `json:"spyre"`
+	Podman  PodmanFacts   `json:"podman"`
+	Storage []StorageFact `json:"storage,omitempty"`
+	Errors  []string      `json:"errors,omitempty"`
+}
+
+// CPUFacts describes the processors of the host
+type CPUFacts struct {
+	Model string `json:"model,omitempty"`
+	// Cores is the number of physical cores
+	Cores int `json:"cores"`
+	// Threads is the number of online logical CPUs
+	Threads  int `json:"threads"`
+	SMTLevel int `json:"smtLevel,omitempty"`
+	// Processor is Power10 or Power11, empty for the other processors
+	Processor string `json:"processor,omitempty"`
+	// Sizes are the limits of the abstract sizes of the containers on the host, as returned by the cpuLimit and
+	// memoryLimit functions of the templates
+	Sizes []platform.Resources `json:"sizes,omitempty"`
+}
+
+// NUMANode is a NUMA node of the host
+type NUMANode struct {
+	ID   int    `json:"id"`
+	CPUs string `json:"cpus"`
+	// Memory is the total memory of the node in bytes
+	Memory int64 `json:"memory"`
+}
+
+// SpyreCard is a Spyre card attached to the host
+type SpyreCard struct {
+	Address    string `json:"address"`
+	IOMMUGroup string `json:"iommuGroup,omitempty"`
+	NUMANode   *int   `json:"numaNode,omitempty"`
+	// AllocatedTo is the owner of the card in the ledger of the manually allocated cards
+	AllocatedTo string `json:"allocatedTo,omitempty"`
+}
+
+// PodmanFacts describes the container runtime
+type PodmanFacts struct {
+	Version  string `json:"version,omitempty"`
+	Rootless bool   `json:"rootless"`
+}
+
+// StorageFact is the usage of the filesystem holding a directory of ai-services
+type StorageFact struct {
+	Path string `json:"path"`
+	// Total and Free are in bytes
+	Total int64 `json:"total"`
+	Free  int64 `json:"free"`
+}
+
+// Facts collects the inventory of the host
+func (c *Client) Facts(ctx context.Context) (*HostFacts, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	facts := &HostFacts{Collected: time.Now().UTC(), Arch: goruntime.GOARCH}
+	failed := func(what string, err error) {
+		facts.Errors = append(facts.Errors, fmt.Sprintf("%s: %v", what, err))
+	}
+
+	var err error
+	if facts.Hostname, err = os.Hostname(); err != nil {
+		failed("hostname", err)
+	}
+	facts.OS = osRelease("PRETTY_NAME")
+	if data, err := os.ReadFile("/proc/sys/kernel/osrelease"); err == nil {
+		facts.Kernel = strings.TrimSpace(string(data))
+	}
+
+	facts.CPU.Model = platform.CPUModel()
+	host := platform.LocalHost()
+	facts.CPU.Processor = host.Processor
+	for _, size := range platform.Sizes {
+		if r, err := host.ResourcesOf(size); err == nil {
+			facts.CPU.Sizes = append(facts.CPU.Sizes, r)
+		}
+	}
+	if cores, err := platform.Cores(); err != nil {
+		failed("cpu", err)
+	} else {
+		facts.CPU.Cores = len(cores)
+		for _, core := range cores {
+			facts.CPU.Threads += len(core.CPUs)
+		}
+	}
+	if level, err := c.smt.Get(); err != nil {
+		failed("smt", err)
+	} else {
+		facts.CPU.SMTLevel = level
+	}
+
+	if capacity, err := hostCapacity(); err != nil {
+		failed("memory", err)
+	} else {
+		facts.Memory = capacity.Memory
+	}
+
+	if facts.NUMA, err = numaNodes(); err != nil {
+		failed("numa", err)
+	}
+
+	if facts.Spyre, err = spyreCards(); err != nil {
+		failed("spyre", err)
+	}
+
+	facts.Podman.Rootless = vars.Rootless
+	if facts.Podman.Version, err = c.runtime.Version(); err != nil {
+		failed("podman", err)
+	}
+
+	for _, dir := range []string{vars.DataDirectory, vars.ModelDirectory} {
+		var fs syscall.Statfs_t
+		if err := syscall.Statfs(dir, &fs); err != nil {
+			failed("storage", err)
+			continue
+		}
+		facts.Storage = append(facts.Storage, StorageFact{
+			Path:  dir,
+			Total: int64(fs.Blocks) * fs.Bsize,
+			Free:  int64(fs.Bavail) * fs.Bsize,
+		})
+	}
+
+	return facts, nil
+}
+
+// osRelease returns the field of /etc/os-release, Eg:- 'Red Hat Enterprise Linux 9.6 (Plow)' for PRETTY_NAME
+func osRelease(field string) string {
+	f, err := os.Open("/etc/os-release")
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if val, ok := strings.CutPrefix(scanner.Text(), field+"="); ok {
+			return strings.Trim(val, `"`)
+		}
+	}
+	return ""
+}
+
+// numaNodes returns the NUMA nodes of the host with their CPUs and memory
+func numaNodes() ([]NUMANode, error) {
+	dirs, err := filepath.Glob(filepath.Join(nodeSysfsPath, "node[0-9]*"))
+	if err != nil {
+		return nil, err
+	}
+
+	var nodes []NUMANode
+	for _, dir := range dirs {
+		id, err := strconv.Atoi(strings.TrimPrefix(filepath.Base(dir), "node"))
+		if err != nil {
+			continue
+		}
+		node := NUMANode{ID: id}
+		if data, err := os.ReadFile(filepath.Join(dir, "cpulist")); err == nil {
+			node.CPUs = strings.TrimSpace(string(data))
+		}
+		if data, err := os.ReadFile(filepath.Join(dir, "meminfo")); err == nil {
+			// Eg:- 'Node 0 MemTotal:       263535168 kB'
+			for line := range strings.SplitSeq(string(data), "\n") {
+				fields := strings.Fields(line)
+				if len(fields) >= 4 && fields[2] == "MemTotal:" {
+					if kb, err := strconv.ParseInt(fields[3], 10, 64); err == nil {
+						node.Memory = kb * 1024
+					}
+				}
+			}
+		}
+		nodes = append(nodes, node)
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID < nodes[j].ID })
+	return nodes, nil
+}
+
+// spyreCards returns the Spyre cards attached to the host, with their IOMMU group, NUMA node and allocation
+func spyreCards() ([]SpyreCard, error) {
+	addresses, err := helpers.ListSpyreCards()
+	if err != nil {
+		return nil, err
+	}
+	ledger, err := spyre.List()
+	if err != nil {
+		return nil, err
+	}
+
+	cards := make([]SpyreCard, 0, len(addresses))
+	for _, address := range addresses {
+		card := SpyreCard{Address: strings.TrimSpace(address)}
+		if group, err := helpers.IOMMUGroup(card.Address); err == nil {
+			card.IOMMUGroup = group
+		}
+		if data, err := os.ReadFile(filepath.Join("/sys/bus/pci/devices", card.Address, "numa_node")); err == nil {
+			// -1 when the platform doesn't report the node of the device
+			if node, err := strconv.Atoi(strings.TrimSpace(string(data))); err == nil && node >= 0 {
+				card.NUMANode = &node
+			}
+		}
+		for _, a := range ledger {
+			if a.Address == spyre.NormalizeAddress(card.Address) {
+				card.AllocatedTo = a.Owner
+			}
+		}
+		cards = append(cards, card)
+	}
+	return cards, nil
+}
@@ -0,0 +1,93 @@
+package aiservices
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	v1 "github.com/containers/podman/v5/pkg/k8s.io/api/core/v1"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/constants"
+	"github.com/project-ai-services/ai-services/internal/pkg/specs"
+)
+
+// HealthOverrides rewrite the health checks of the rendered pod templates, Eg:- to debug images with broken
+// built-in health checks
+type HealthOverrides struct {
+	// ReadinessTimeout overrides the readiness timeout of all the containers, including the timeouts set by the
+	// template annotations
+	ReadinessTimeout time.Duration `json:"readinessTimeout,omitempty"`
+	// Interval overrides the period of the health checks
+	Interval time.Duration `json:"interval,omitempty"`
+	// Disabled are the containers the health checks of which are removed, they are considered ready once running
+	Disabled []string `json:"disabled,omitempty"`
+}
+
+// validateHealthOverrides checks the containers the health checks of which are disabled exist in the pod templates
+func (cr *creator) validateHealthOverrides(podTemplateFileNames []string) error {
+	h := cr.opts.Health
+	if h.ReadinessTimeout < 0 {
+		return fmt.Errorf("invalid readiness timeout %s", h.ReadinessTimeout)
+	}
+	if h.Interval != 0 && h.Interval < time.Second {
+		return fmt.Errorf("invalid health check interval %s, must be at least 1s", h.Interval)
+	}
+	if len(h.Disabled) == 0 {
+		return nil
+	}
+
+	var containers []string
+	for _, podTemplateFileName := range podTemplateFileNames {
+		podSpec, err := cr.fetchPodSpec(podTemplateFileName)
+		if err != nil {
+			return err
+		}
+		containers = append(containers, specs.FetchContainerNames(*podSpec)...)
+	}
+	for _, container := range h.Disabled {
+		if !slices.Contains(containers, container) {
+			return fmt.Errorf("cannot disable the health check of container %s: not found in template %s", container, cr.opts.Template)
+		}
+	}
+	return nil
+}
+
+// injectHealthOverrides rewrites the health checks of the containers of the rendered pod template
+func injectHealthOverrides(manifest []byte, h HealthOverrides) ([]byte, error) {
+	if h.ReadinessTimeout == 0 && h.Interval == 0 && len(h.Disabled) == 0 {
+		return manifest, nil
+	}
+
+	podSpec, err := specs.ParsePodSpec(manifest)
+	if err != nil {
+		return nil, err
+	}
+	if podSpec.Annotations == nil {
+		podSpec.Annotations = map[string]string{}
+	}
+
+	for i := range podSpec.Spec.Containers {
+		container := &podSpec.Spec.Containers[i]
+		if slices.Contains(h.Disabled, container.Name) {
+			// kube play only creates a health check from the liveness probe, the others are dropped for clarity
+			container.LivenessProbe = nil
+			container.ReadinessProbe = nil
+			container.StartupProbe = nil
+			continue
+		}
+		if h.Interval > 0 {
+			period := int32(math.Ceil(h.Interval.Seconds()))
+			for _, probe := range []*v1.Probe{container.LivenessProbe, container.ReadinessProbe, container.StartupProbe} {
+				if probe != nil {
+					probe.PeriodSeconds = period
+				}
+			}
+		}
+		if h.ReadinessTimeout > 0 {
+			podSpec.Annotations[constants.ReadinessTimeoutAnnotationPrefix+container.Name] = h.ReadinessTimeout.String()
+		}
+	}
+
+	return specs.MarshalPodSpec(podSpec)
+}
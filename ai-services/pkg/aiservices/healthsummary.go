@@ -0,0 +1,121 @@
+package aiservices
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Health statuses of the containers, the applications and the host, from the best
+const (
+	HealthHealthy = "healthy"
+	// HealthDegraded is serving, with containers crash looping
+	HealthDegraded = "degraded"
+	// HealthStarting is not serving yet, with health checks not passed yet
+	HealthStarting  = "starting"
+	HealthUnhealthy = "unhealthy"
+)
+
+// healthRank orders the health statuses, the status of an application being the worst of its components
+var healthRank = map[string]int{HealthHealthy: 0, HealthDegraded: 1, HealthStarting: 2, HealthUnhealthy: 3}
+
+// HealthSummary is the health of all the deployed applications, probed by the load balancers and monitors
+type HealthSummary struct {
+	// Status is the worst status of the applications, healthy without application
+	Status       string              `json:"status"`
+	Time         time.Time           `json:"time"`
+	Applications []ApplicationHealth `json:"applications"`
+}
+
+// ApplicationHealth is the health of an application and of its components
+type ApplicationHealth struct {
+	Name     string `json:"name"`
+	Template string `json:"template,omitempty"`
+	// Status is the worst status of the components
+	Status     string            `json:"status"`
+	Components []ComponentHealth `json:"components"`
+	// Error is the failure to inspect the application, reported as unhealthy
+	Error string `json:"error,omitempty"`
+}
+
+// ComponentHealth is the health of a container of the application
+type ComponentHealth struct {
+	Pod       string `json:"pod"`
+	Container string `json:"container"`
+	State     string `json:"state"`
+	// Health is the status of the health check, empty for the containers without health check
+	Health   string `json:"health,omitempty"`
+	Restarts int    `json:"restarts"`
+	Status   string `json:"status"`
+	// Reason explains a status other than healthy
+	Reason string `json:"reason,omitempty"`
+}
+
+// Available returns true if the applications are serving, healthy or degraded
+func (s *HealthSummary) Available() bool {
+	return s.Status == HealthHealthy || s.Status == HealthDegraded
+}
+
+// HealthSummary returns the health of the components of all the deployed applications, or of the application if
+// appName is set. The containers not started yet or run to completion, Eg:- the job pods, are healthy; the
+// containers crash looping are degraded.
+func (c *Client) HealthSummary(ctx context.Context, appName string) (*HealthSummary, error) {
+	var apps []Application
+	if appName != "" {
+		app, err := c.GetApplication(ctx, appName)
+		if err != nil {
+			return nil, err
+		}
+		apps = []Application{*app}
+	} else {
+		var err error
+		if apps, err = c.ListApplications(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	summary := &HealthSummary{Status: HealthHealthy, Time: time.Now().UTC(), Applications: []ApplicationHealth{}}
+	for _, app := range apps {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		appHealth := ApplicationHealth{Name: app.Name, Template: app.Template, Status: HealthHealthy, Components: []ComponentHealth{}}
+		containers, err := c.ListContainers(ctx, app.Name)
+		if err != nil {
+			appHealth.Status, appHealth.Error = HealthUnhealthy, err.Error()
+		}
+		for _, ctr := range containers {
+			component := componentHealth(ctr)
+			appHealth.Components = append(appHealth.Components, component)
+			appHealth.Status = worstHealth(appHealth.Status, component.Status)
+		}
+		summary.Applications = append(summary.Applications, appHealth)
+		summary.Status = worstHealth(summary.Status, appHealth.Status)
+	}
+	return summary, nil
+}
+
+// componentHealth rates the container as diagnoseApplications does
+func componentHealth(ctr Container) ComponentHealth {
+	h := ComponentHealth{Pod: ctr.Pod, Container: ctr.Name, State: ctr.State, Health: ctr.Health, Restarts: ctr.Restarts, Status: HealthHealthy}
+	switch {
+	case ctr.State == "created" || ctr.State == "exited" && ctr.ExitCode == 0:
+		// not started yet, Eg:- the pods started on demand, or run to completion
+	case ctr.State != "running":
+		h.Status, h.Reason = HealthUnhealthy, "container is "+ctr.State
+	case ctr.Health == "unhealthy":
+		h.Status, h.Reason = HealthUnhealthy, "health check failing"
+	case ctr.Health == "starting":
+		h.Status, h.Reason = HealthStarting, "health check not passed yet"
+	case ctr.Restarts > maxHealthyRestarts:
+		h.Status, h.Reason = HealthDegraded, fmt.Sprintf("restarted %d times", ctr.Restarts)
+	}
+	return h
+}
+
+func worstHealth(a, b string) string {
+	if healthRank[b] > healthRank[a] {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,73 @@
+package aiservices
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/helpers"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+)
+
+// ContainerImage is the image run by a container of an application
+type ContainerImage struct {
+	Pod       string `json:"pod"`
+	Container string `json:"container"`
+	// Image is the image reference the container was created from, Eg:- the tag declared by the template
+	Image   string `json:"image"`
+	ImageID string `json:"imageId"`
+	// Digest is the manifest digest of the running image
+	Digest string `json:"digest,omitempty"`
+	// Declared is whether the image is one of the images declared by the template with its default values, nil
+	// if the template is not known to the CLI
+	Declared *bool `json:"declared,omitempty"`
+}
+
+// ApplicationImages resolves the images run by the containers of the application, and whether they differ from
+// the images declared by its template
+func (c *Client) ApplicationImages(ctx context.Context, app *Application) ([]ContainerImage, error) {
+	// the template images are only known for the embedded templates, the images of the application cannot be
+	// compared otherwise
+	declared, err := helpers.ListImages(app.Template, app.Name)
+	if err != nil {
+		logger.Infof("Unable to list the images of template %s: %v\n", app.Template, err, 2)
+		declared = nil
+	}
+
+	var images []ContainerImage
+	for _, pod := range app.Pods {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		info, err := c.runtime.InspectPod(pod.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to inspect pod %s: %w", pod.Name, err)
+		}
+
+		for _, ctr := range info.Containers {
+			if ctr.ID == info.InfraContainerID {
+				continue
+			}
+			ctrInfo, err := c.runtime.InspectContainer(ctr.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to inspect container %s: %w", ctr.Name, err)
+			}
+			image := ContainerImage{
+				Pod: pod.Name,
+				// kube play names the containers <pod>-<container>
+				Container: strings.TrimPrefix(ctrInfo.Name, pod.Name+"-"),
+				Image:     ctrInfo.ImageName,
+				ImageID:   ctrInfo.Image,
+				Digest:    ctrInfo.ImageDigest,
+			}
+			if declared != nil {
+				image.Declared = new(bool)
+				*image.Declared = slices.Contains(declared, image.Image)
+			}
+			images = append(images, image)
+		}
+	}
+
+	return images, nil
+}
@@ -0,0 +1,79 @@
+package aiservices
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"strings"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/specs"
+)
+
+// reservedMetadataPrefix is the prefix of the labels and annotations managed by ai-services, which cannot be set
+// with CreateOptions.Labels and CreateOptions.Annotations
+const reservedMetadataPrefix = "ai-services.io/"
+
+var (
+	// metadataNameRegex is the name of a label or annotation key, without its optional DNS prefix
+	metadataNameRegex = regexp.MustCompile(`^[A-Za-z0-9]([-A-Za-z0-9_.]{0,61}[A-Za-z0-9])?$`)
+	// labelValueRegex is a label value, empty or a name
+	labelValueRegex = regexp.MustCompile(`^([A-Za-z0-9]([-A-Za-z0-9_.]{0,61}[A-Za-z0-9])?)?$`)
+)
+
+// validateMetadata checks the user labels and annotations are valid kubernetes keys and values, outside of the
+// keys reserved for ai-services
+func validateMetadata(labels, annotations map[string]string) error {
+	for key, val := range labels {
+		if err := validateMetadataKey(key); err != nil {
+			return fmt.Errorf("invalid label %q: %w", key, err)
+		}
+		if !labelValueRegex.MatchString(val) {
+			return fmt.Errorf("invalid value %q of label %q: at most 63 alphanumeric characters, '-', '_' or '.'", val, key)
+		}
+	}
+	for key := range annotations {
+		if err := validateMetadataKey(key); err != nil {
+			return fmt.Errorf("invalid annotation %q: %w", key, err)
+		}
+	}
+	return nil
+}
+
+func validateMetadataKey(key string) error {
+	if strings.HasPrefix(key, reservedMetadataPrefix) {
+		return fmt.Errorf("the prefix %s is reserved for ai-services", reservedMetadataPrefix)
+	}
+	name := key
+	if prefix, rest, ok := strings.Cut(key, "/"); ok {
+		if prefix == "" || len(prefix) > 253 {
+			return fmt.Errorf("the prefix must be a DNS subdomain")
+		}
+		name = rest
+	}
+	if !metadataNameRegex.MatchString(name) {
+		return fmt.Errorf("the name must be at most 63 alphanumeric characters, '-', '_' or '.'")
+	}
+	return nil
+}
+
+// injectMetadata merges the user labels and annotations into the pod, overriding the ones of the template
+func injectMetadata(manifest []byte, labels, annotations map[string]string) ([]byte, error) {
+	if len(labels) == 0 && len(annotations) == 0 {
+		return manifest, nil
+	}
+
+	podSpec, err := specs.ParsePodSpec(manifest)
+	if err != nil {
+		return nil, err
+	}
+	if podSpec.Labels == nil {
+		podSpec.Labels = map[string]string{}
+	}
+	if podSpec.Annotations == nil {
+		podSpec.Annotations = map[string]string{}
+	}
+	maps.Copy(podSpec.Labels, labels)
+	maps.Copy(podSpec.Annotations, annotations)
+
+	return specs.MarshalPodSpec(podSpec)
+}
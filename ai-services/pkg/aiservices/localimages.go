@@ -0,0 +1,207 @@
+package aiservices
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/helpers"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+)
+
+// LocalImage is an image of the local podman storage, along with the applications and templates referencing it
+type LocalImage struct {
+	ID    string   `json:"id"`
+	Names []string `json:"names"`
+	Size  int64    `json:"size"`
+	// SharedSize is the size of the layers shared with the other local images, as computed by podman
+	SharedSize int64 `json:"sharedSize"`
+	Layers     int   `json:"layers"`
+	// SharedLayers is the number of layers shared with the other local images referenced by ai-services
+	SharedLayers int `json:"sharedLayers"`
+	// Applications are the deployed applications running the image
+	Applications []string `json:"applications,omitempty"`
+	// Templates are the templates declaring the image, cached ahead of their deployment
+	Templates []string `json:"templates,omitempty"`
+	// Containers is the number of containers using the image, including the ones not managed by ai-services
+	Containers int `json:"containers"`
+}
+
+// Referenced returns true if a deployed application or a template references the image
+func (i LocalImage) Referenced() bool {
+	return len(i.Applications) > 0 || len(i.Templates) > 0
+}
+
+// PruneResult lists the images removed by PruneImages and the space reclaimed
+type PruneResult struct {
+	Removed   []LocalImage `json:"removed"`
+	Reclaimed int64        `json:"reclaimed"`
+	// Errors are the images which could not be removed
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// ListImages returns the local images referenced by the deployed applications or declared by the templates, sorted
+// by name
+func (c *Client) ListImages(ctx context.Context) ([]LocalImage, error) {
+	inventory, _, err := c.imageInventory(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var referenced []LocalImage
+	for _, img := range inventory {
+		if img.Referenced() {
+			referenced = append(referenced, img)
+		}
+	}
+	return referenced, nil
+}
+
+// PruneImages removes the local images of the repositories of the templates which are neither referenced by a
+// deployed application nor declared by a template anymore, Eg:- the images of the previous versions of the
+// templates. The images of the other repositories and the images used by any container are never removed. With
+// dryRun, the images are only reported.
+func (c *Client) PruneImages(ctx context.Context, dryRun bool) (*PruneResult, error) {
+	inventory, repositories, err := c.imageInventory(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PruneResult{Removed: []LocalImage{}}
+	for _, img := range inventory {
+		if img.Referenced() || img.Containers > 0 {
+			continue
+		}
+		if !slices.ContainsFunc(img.Names, func(name string) bool { return repositories[imageRepository(name)] }) {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if !dryRun {
+			if err := c.runtime.RemoveImage(img.ID); err != nil {
+				if result.Errors == nil {
+					result.Errors = map[string]string{}
+				}
+				result.Errors[img.ID] = err.Error()
+				continue
+			}
+		}
+		result.Removed = append(result.Removed, img)
+		result.Reclaimed += img.Size - img.SharedSize
+	}
+	return result, nil
+}
+
+// imageInventory returns all the local images along with their references, and the repositories of the images
+// declared by the templates
+func (c *Client) imageInventory(ctx context.Context) ([]LocalImage, map[string]bool, error) {
+	summaries, err := c.runtime.ListImages()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list the images: %w", err)
+	}
+
+	byID := map[string]*LocalImage{}
+	var inventory []*LocalImage
+	for _, s := range summaries {
+		img := &LocalImage{ID: s.ID, Names: s.RepoTags, Size: s.Size, SharedSize: int64(max(s.SharedSize, 0)), Containers: s.Containers}
+		if len(img.Names) == 0 {
+			img.Names = s.Names
+		}
+		byID[s.ID] = img
+		inventory = append(inventory, img)
+	}
+
+	// the images run by the deployed applications
+	apps, err := c.ListApplications(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, app := range apps {
+		images, err := c.ApplicationImages(ctx, &app)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, ci := range images {
+			if img, ok := byID[ci.ImageID]; ok && !slices.Contains(img.Applications, app.Name) {
+				img.Applications = append(img.Applications, app.Name)
+			}
+		}
+	}
+
+	// the images declared by the templates which are present locally
+	repositories := map[string]bool{}
+	names, err := c.templates.ListApplications()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list application templates: %w", err)
+	}
+	for _, name := range names {
+		declared, err := helpers.ListImages(name, "")
+		if err != nil {
+			logger.Infof("Unable to list the images of template %s: %v\n", name, err, 2)
+			continue
+		}
+		for _, ref := range declared {
+			repositories[imageRepository(ref)] = true
+			info, err := c.runtime.InspectImage(ref)
+			if err != nil {
+				continue
+			}
+			if img, ok := byID[info.ID]; ok && !slices.Contains(img.Templates, name) {
+				img.Templates = append(img.Templates, name)
+			}
+		}
+	}
+
+	// the layers shared between the images referenced by ai-services
+	layerUsers := map[digest.Digest]int{}
+	layers := map[string][]digest.Digest{}
+	for _, img := range inventory {
+		if !img.Referenced() {
+			continue
+		}
+		info, err := c.runtime.InspectImage(img.ID)
+		if err != nil || info.RootFS == nil {
+			continue
+		}
+		layers[img.ID] = info.RootFS.Layers
+		for _, layer := range info.RootFS.Layers {
+			layerUsers[layer]++
+		}
+	}
+
+	out := make([]LocalImage, 0, len(inventory))
+	for _, img := range inventory {
+		img.Layers = len(layers[img.ID])
+		for _, layer := range layers[img.ID] {
+			if layerUsers[layer] > 1 {
+				img.SharedLayers++
+			}
+		}
+		sort.Strings(img.Applications)
+		sort.Strings(img.Templates)
+		out = append(out, *img)
+	}
+	sort.Slice(out, func(i, j int) bool { return imageName(out[i]) < imageName(out[j]) })
+	return out, repositories, nil
+}
+
+// imageRepository returns the repository of the image reference, without its tag or digest
+func imageRepository(ref string) string {
+	ref, _, _ = strings.Cut(ref, "@")
+	if i := strings.LastIndex(ref, ":"); i > strings.LastIndex(ref, "/") {
+		ref = ref[:i]
+	}
+	return ref
+}
+
+// imageName returns the first name of the image, its ID if untagged
+func imageName(img LocalImage) string {
+	if len(img.Names) > 0 {
+		return img.Names[0]
+	}
+	return img.ID
+}
@@ -0,0 +1,250 @@
+package aiservices
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/audit"
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/templates"
+	"github.com/project-ai-services/ai-services/internal/pkg/config"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+// defaultLogAlertCooldown is the minimal duration between two alerts of a container
+const defaultLogAlertCooldown = 5 * time.Minute
+
+// maxAlertLine truncates the log line reported by an alert
+const maxAlertLine = 512
+
+// LogAlertEvent is a log line of a container matching a log alert
+type LogAlertEvent struct {
+	Time        time.Time `json:"time"`
+	Application string    `json:"application"`
+	Pod         string    `json:"pod"`
+	Container   string    `json:"container"`
+	Alert       string    `json:"alert"`
+	Line        string    `json:"line"`
+}
+
+type logAlertRule struct {
+	config.LogAlert
+	re       *regexp.Regexp
+	cooldown time.Duration
+}
+
+// appLogAlerts are the compiled log alerts of the deployed revision of an application
+type appLogAlerts struct {
+	revision int
+	rules    []logAlertRule
+	webhooks []templates.Webhook
+	values   map[string]string
+	template string
+	version  string
+}
+
+// WatchLogAlerts follows the logs of the running containers of all the applications until ctx is done, matching
+// each line against the log alerts of their template and the 'logAlerts' of the CLI config file. The alerts are
+// recorded in the audit history, sent to the webhooks of the template subscribed to the log-alert event, and
+// reported. Only the lines logged once the watch started are matched. The new containers are discovered every
+// interval.
+func (c *Client) WatchLogAlerts(ctx context.Context, interval time.Duration, report func(LogAlertEvent)) error {
+	global, err := loadLogAlertsConfig()
+	if err != nil {
+		return err
+	}
+	globalRules, err := compileLogAlerts(global)
+	if err != nil {
+		return fmt.Errorf("invalid logAlerts in %s: %w", vars.ConfigFile, err)
+	}
+
+	var (
+		mu       sync.Mutex
+		followed = map[string]bool{}
+		// resume is the time the containers stopped being followed, so that the lines are not matched twice
+		resume = map[string]time.Time{}
+		alerts = map[string]*appLogAlerts{}
+		wg     sync.WaitGroup
+	)
+	defer wg.Wait()
+	started := time.Now()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		apps, err := c.ListApplications(ctx)
+		if err != nil && ctx.Err() == nil {
+			logger.Warningf("failed to discover the containers to match the log alerts of: %v\n", err)
+		}
+		for _, app := range apps {
+			appAlerts, err := c.appLogAlerts(ctx, app.Name, alerts[app.Name])
+			if err != nil {
+				// Eg:- the application is being deployed, retried at the next interval
+				logger.Infof("Skipping the log alerts of application %s: %v\n", app.Name, err, 2)
+				continue
+			}
+			alerts[app.Name] = appAlerts
+			if len(appAlerts.rules)+len(globalRules) == 0 {
+				continue
+			}
+
+			containers, err := c.ListContainers(ctx, app.Name)
+			if err != nil {
+				logger.Infof("Failed to list the containers of application %s: %v\n", app.Name, err, 2)
+				continue
+			}
+			for _, ctr := range containers {
+				var rules []logAlertRule
+				for _, rule := range slices.Concat(appAlerts.rules, globalRules) {
+					if len(rule.Containers) == 0 || slices.Contains(rule.Containers, ctr.Name) {
+						rules = append(rules, rule)
+					}
+				}
+
+				mu.Lock()
+				skip := ctr.State != "running" || followed[ctr.ID] || len(rules) == 0
+				since, ok := resume[ctr.ID]
+				if !ok {
+					since = started
+				}
+				if !skip {
+					followed[ctr.ID] = true
+				}
+				mu.Unlock()
+				if skip {
+					continue
+				}
+
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					err := c.matchLogs(ctx, app.Name, ctr, since, rules, func(event LogAlertEvent) {
+						c.raiseLogAlert(ctx, appAlerts, event)
+						report(event)
+					})
+					if err != nil {
+						logger.Warningf("failed to follow the logs of container %s: %v\n", ctr.Name, err)
+					}
+					mu.Lock()
+					delete(followed, ctr.ID)
+					resume[ctr.ID] = time.Now()
+					mu.Unlock()
+				}()
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// appLogAlerts returns the log alerts of the deployed revision of the application, cached while it is deployed
+func (c *Client) appLogAlerts(ctx context.Context, appName string, cached *appLogAlerts) (*appLogAlerts, error) {
+	rev, appMetadata, err := c.deployedRevision(ctx, appName)
+	if err != nil {
+		return nil, err
+	}
+	if cached != nil && cached.revision == rev.Number {
+		return cached, nil
+	}
+
+	rules, err := compileLogAlerts(appMetadata.LogAlerts)
+	if err != nil {
+		return nil, fmt.Errorf("invalid logAlerts of template %s: %w", rev.Template, err)
+	}
+	values := map[string]string{}
+	flattenValues("", rev.Values, values)
+	return &appLogAlerts{
+		revision: rev.Number,
+		rules:    rules,
+		webhooks: appMetadata.Webhooks,
+		values:   values,
+		template: rev.Template,
+		version:  appMetadata.Version,
+	}, nil
+}
+
+// matchLogs matches the log lines of the container since the time against the rules until the container stops or
+// ctx is done, the alerts of a rule being raised at most once per cooldown
+func (c *Client) matchLogs(ctx context.Context, appName string, ctr Container, since time.Time, rules []logAlertRule, raise func(LogAlertEvent)) error {
+	lines := make(chan string)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		last := map[string]time.Time{}
+		for line := range lines {
+			for _, rule := range rules {
+				if !rule.re.MatchString(line) || time.Since(last[rule.Name]) < rule.cooldown {
+					continue
+				}
+				last[rule.Name] = time.Now()
+				reported := strings.TrimRight(line, "\n")
+				if len(reported) > maxAlertLine {
+					reported = reported[:maxAlertLine]
+				}
+				raise(LogAlertEvent{Time: time.Now().UTC(), Application: appName, Pod: ctr.Pod, Container: ctr.Name, Alert: rule.Name, Line: reported})
+			}
+		}
+	}()
+
+	// both streams are matched alike
+	err := c.runtime.StreamContainerLogsSince(ctx, ctr.ID, since, true, lines, lines)
+	close(lines)
+	<-done
+	return err
+}
+
+// raiseLogAlert records the alert in the audit history and sends it to the webhooks of the template
+func (c *Client) raiseLogAlert(ctx context.Context, appAlerts *appLogAlerts, alert LogAlertEvent) {
+	if err := audit.Record(audit.Entry{Application: alert.Application, Action: "log alert",
+		Details: fmt.Sprintf("%s: container %s of pod %s: %s", alert.Alert, alert.Container, alert.Pod, alert.Line)}); err != nil {
+		logger.Warningf("failed to record the log alert in the audit history: %v\n", err)
+	}
+	sendWebhooks(ctx, appAlerts.webhooks, appAlerts.values, WebhookEvent{
+		Event:       templates.WebhookEventLogAlert,
+		Application: alert.Application,
+		Template:    appAlerts.template,
+		Version:     appAlerts.version,
+		Time:        alert.Time,
+		Alert:       &alert,
+	})
+}
+
+// compileLogAlerts compiles the patterns of the log alerts
+func compileLogAlerts(alerts []config.LogAlert) ([]logAlertRule, error) {
+	rules := make([]logAlertRule, 0, len(alerts))
+	for i, alert := range alerts {
+		if alert.Name == "" || alert.Pattern == "" {
+			return nil, fmt.Errorf("log alert %d: name and pattern are required", i+1)
+		}
+		re, err := regexp.Compile(alert.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("log alert %s: invalid pattern: %w", alert.Name, err)
+		}
+		cooldown := defaultLogAlertCooldown
+		if alert.Cooldown != "" {
+			if cooldown, err = time.ParseDuration(alert.Cooldown); err != nil {
+				return nil, fmt.Errorf("log alert %s: invalid cooldown: %w", alert.Name, err)
+			}
+		}
+		rules = append(rules, logAlertRule{LogAlert: alert, re: re, cooldown: cooldown})
+	}
+	return rules, nil
+}
+
+// loadLogAlertsConfig returns the 'logAlerts' of the CLI config file
+func loadLogAlertsConfig() ([]config.LogAlert, error) {
+	c, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	return c.LogAlerts, nil
+}
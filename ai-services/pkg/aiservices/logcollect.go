@@ -0,0 +1,226 @@
+package aiservices
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/containers/podman/v5/pkg/domain/entities/types"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+// LogCollectOptions configure the collection of the container logs of the applications into files
+type LogCollectOptions struct {
+	// Directory holds a directory per application with a log file per container, defaults to
+	// /var/log/ai-services/apps
+	Directory string
+	// MaxSize is the size in bytes a log file is rotated at, defaults to 50MiB
+	MaxSize int64
+	// MaxFiles is the number of rotated files kept per container, defaults to 5
+	MaxFiles int
+	// Interval is the interval the new containers are discovered at, defaults to 10s
+	Interval time.Duration
+}
+
+// CollectLogs follows the logs of the running containers of all the applications into
+// <Directory>/<application>/<container>.log until ctx is done, each line written as '<timestamp> <stream> <line>'.
+// The files are named after the containers, hence the logs of a recreated container are appended to the same file,
+// and the collection resumes from the last write of the file once restarted.
+func (c *Client) CollectLogs(ctx context.Context, opts LogCollectOptions) error {
+	if opts.Directory == "" {
+		opts.Directory = vars.AppLogDirectory
+	}
+	if opts.MaxSize <= 0 {
+		opts.MaxSize = 50 << 20
+	}
+	if opts.MaxFiles <= 0 {
+		opts.MaxFiles = 5
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = 10 * time.Second
+	}
+	if err := os.MkdirAll(opts.Directory, 0o755); err != nil {
+		return fmt.Errorf("failed to create the log directory: %w", err)
+	}
+
+	var (
+		mu       sync.Mutex
+		followed = map[string]bool{}
+		wg       sync.WaitGroup
+	)
+	defer wg.Wait()
+
+	ticker := time.NewTicker(opts.Interval)
+	defer ticker.Stop()
+	for {
+		pods, err := c.listApplicationPods()
+		if err != nil {
+			logger.Warningf("Failed to discover the containers to collect the logs of: %v\n", err)
+		}
+		for _, pod := range pods {
+			appName := pod.Labels["ai-services.io/application"]
+			for _, ctr := range pod.Containers {
+				mu.Lock()
+				skip := ctr.Id == pod.InfraId || ctr.Status != "running" || followed[ctr.Id]
+				if !skip {
+					followed[ctr.Id] = true
+				}
+				mu.Unlock()
+				if skip {
+					continue
+				}
+
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					path := filepath.Join(opts.Directory, appName, ctr.Names+".log")
+					if err := c.followLogs(ctx, ctr.Id, path, opts); err != nil {
+						logger.Warningf("Failed to collect the logs of container %s: %v\n", ctr.Names, err)
+					}
+					mu.Lock()
+					delete(followed, ctr.Id)
+					mu.Unlock()
+				}()
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *Client) listApplicationPods() ([]*types.ListPodsReport, error) {
+	resp, err := c.runtime.ListPods(map[string][]string{"label": {"ai-services.io/application"}})
+	if err != nil {
+		return nil, err
+	}
+	pods, _ := resp.([]*types.ListPodsReport)
+	return pods, nil
+}
+
+// followLogs appends the log lines of the container to the file until the container stops or ctx is done
+func (c *Client) followLogs(ctx context.Context, containerID, path string, opts LogCollectOptions) error {
+	// resume after the last line collected, the lines are not collected twice once the collection is restarted
+	var since time.Time
+	if info, err := os.Stat(path); err == nil {
+		since = info.ModTime()
+	}
+
+	out, err := openRotatingFile(path, opts.MaxSize, opts.MaxFiles)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	stdoutChan, stderrChan := make(chan string), make(chan string)
+	done := make(chan struct{})
+	var writeErr error
+
+	// drain the channels until the runtime is done sending, even once a write failed
+	go func() {
+		defer close(done)
+		write := func(stream, line string) {
+			if writeErr != nil {
+				return
+			}
+			_, writeErr = fmt.Fprintf(out, "%s %s %s\n", time.Now().UTC().Format(time.RFC3339Nano), stream, strings.TrimRight(line, "\n"))
+		}
+		for stdoutChan != nil || stderrChan != nil {
+			select {
+			case line, ok := <-stdoutChan:
+				if !ok {
+					stdoutChan = nil
+					continue
+				}
+				write("stdout", line)
+			case line, ok := <-stderrChan:
+				if !ok {
+					stderrChan = nil
+					continue
+				}
+				write("stderr", line)
+			}
+		}
+	}()
+
+	err = c.runtime.StreamContainerLogsSince(ctx, containerID, since, true, stdoutChan, stderrChan)
+	close(stdoutChan)
+	close(stderrChan)
+	<-done
+	return errors.Join(err, writeErr)
+}
+
+// rotatingFile appends to a file, rotated to <path>.1 once it exceeds maxSize. The rotated files are shifted up to
+// <path>.<maxFiles>, the older ones are removed.
+type rotatingFile struct {
+	path     string
+	maxSize  int64
+	maxFiles int
+	f        *os.File
+	size     int64
+}
+
+func openRotatingFile(path string, maxSize int64, maxFiles int) (*rotatingFile, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create the log directory: %w", err)
+	}
+	r := &rotatingFile{path: path, maxSize: maxSize, maxFiles: maxFiles}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *rotatingFile) open() error {
+	f, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open the log file: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("failed to open the log file: %w", err)
+	}
+	r.f, r.size = f, info.Size()
+	return nil
+}
+
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	if r.size > 0 && r.size+int64(len(p)) > r.maxSize {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := r.f.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+func (r *rotatingFile) rotate() error {
+	if err := r.f.Close(); err != nil {
+		return err
+	}
+	_ = os.Remove(r.path + "." + strconv.Itoa(r.maxFiles))
+	for i := r.maxFiles - 1; i >= 1; i-- {
+		_ = os.Rename(r.path+"."+strconv.Itoa(i), r.path+"."+strconv.Itoa(i+1))
+	}
+	if err := os.Rename(r.path, r.path+".1"); err != nil {
+		return fmt.Errorf("failed to rotate the log file: %w", err)
+	}
+	return r.open()
+}
+
+func (r *rotatingFile) Close() error {
+	return r.f.Close()
+}
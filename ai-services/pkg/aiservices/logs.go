@@ -0,0 +1,132 @@
+package aiservices
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// LogLine is a log line of a container of an application
+type LogLine struct {
+	Pod       string `json:"pod"`
+	Container string `json:"container"`
+	// Stream is either stdout or stderr
+	Stream string `json:"stream"`
+	Line   string `json:"line"`
+}
+
+// LogsOptions select the containers of the application to stream the logs of
+type LogsOptions struct {
+	// Pod restricts the logs to the pod, all the pods of the application if empty
+	Pod string
+	// Container restricts the logs to the container, all the containers of the pods if empty
+	Container string
+	// Follow keeps streaming the new log lines until ctx is done
+	Follow bool
+}
+
+// StreamLogs streams the log lines of the containers of the application to fn. The containers are streamed concurrently,
+// fn is invoked one line at a time. Streaming stops at the first error returned by fn.
+func (c *Client) StreamLogs(ctx context.Context, appName string, opts LogsOptions, fn func(LogLine) error) error {
+	app, err := c.GetApplication(ctx, appName)
+	if err != nil {
+		return err
+	}
+
+	type target struct{ pod, container, id string }
+	var targets []target
+	for _, pod := range app.Pods {
+		if opts.Pod != "" && pod.Name != opts.Pod {
+			continue
+		}
+		report, err := c.runtime.InspectPod(pod.ID)
+		if err != nil {
+			return fmt.Errorf("failed to inspect pod %s: %w", pod.Name, err)
+		}
+		for _, ctr := range report.Containers {
+			if ctr.ID == report.InfraContainerID {
+				continue
+			}
+			if opts.Container != "" && ctr.Name != opts.Container && ctr.ID != opts.Container {
+				continue
+			}
+			targets = append(targets, target{pod: pod.Name, container: ctr.Name, id: ctr.ID})
+		}
+	}
+	if len(targets) == 0 {
+		return fmt.Errorf("no containers found for application '%s' matching pod '%s' and container '%s'", appName, opts.Pod, opts.Container)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu    sync.Mutex
+		fnErr error
+		wg    sync.WaitGroup
+	)
+	emit := func(line LogLine) {
+		mu.Lock()
+		defer mu.Unlock()
+		if fnErr != nil {
+			return
+		}
+		if err := fn(line); err != nil {
+			fnErr = err
+			cancel()
+		}
+	}
+
+	errCh := make(chan error, len(targets))
+	for _, t := range targets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			stdoutChan, stderrChan := make(chan string), make(chan string)
+			done := make(chan struct{})
+
+			// drain the channels until the runtime is done sending, even once fn failed
+			go func() {
+				defer close(done)
+				for stdoutChan != nil || stderrChan != nil {
+					select {
+					case line, ok := <-stdoutChan:
+						if !ok {
+							stdoutChan = nil
+							continue
+						}
+						emit(LogLine{Pod: t.pod, Container: t.container, Stream: "stdout", Line: strings.TrimRight(line, "\n")})
+					case line, ok := <-stderrChan:
+						if !ok {
+							stderrChan = nil
+							continue
+						}
+						emit(LogLine{Pod: t.pod, Container: t.container, Stream: "stderr", Line: strings.TrimRight(line, "\n")})
+					}
+				}
+			}()
+
+			if err := c.runtime.StreamContainerLogs(ctx, t.id, opts.Follow, stdoutChan, stderrChan); err != nil {
+				errCh <- fmt.Errorf("%s/%s: %w", t.pod, t.container, err)
+			}
+			close(stdoutChan)
+			close(stderrChan)
+			<-done
+		}()
+	}
+
+	wg.Wait()
+	close(errCh)
+
+	if fnErr != nil {
+		return fnErr
+	}
+
+	var errs []error
+	for err := range errCh {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,103 @@
+package aiservices
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/apikeys"
+	"github.com/project-ai-services/ai-services/internal/pkg/certs"
+	"github.com/project-ai-services/ai-services/internal/pkg/gateway"
+	"github.com/project-ai-services/ai-services/internal/pkg/specs"
+)
+
+// ApplicationExport is the state of a deployed application required to deploy it on another host
+type ApplicationExport struct {
+	Name     string `json:"name"`
+	Template string `json:"template"`
+	// Values are the template values of the last deployed revision, without the names of the secrets provisioned
+	// by the CLI which are provisioned again on the other host
+	Values map[string]any `json:"values,omitempty"`
+	// Config is the runtime config of the application
+	Config map[string]string `json:"config,omitempty"`
+	// TLSCert and TLSKey are the PEM encoded TLS certificate of the exposed services, if TLS is enabled
+	TLSCert []byte `json:"-"`
+	TLSKey  []byte `json:"-"`
+	// APIKeys is whether the serving endpoints require API keys
+	APIKeys bool `json:"apiKeys"`
+	// Routes are the gateway routes to the endpoints of the application
+	Routes []gateway.Route `json:"routes,omitempty"`
+}
+
+// ExportApplication returns the state of the application required to deploy it on another host
+func (c *Client) ExportApplication(ctx context.Context, name string) (*ApplicationExport, error) {
+	app, err := c.GetApplication(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	export := &ApplicationExport{Name: name, Template: app.Template}
+
+	history, err := c.ListRevisions(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if i := lastDeployed(history); i >= 0 {
+		export.Values = history[i].Values
+		for _, param := range carriedOverParams {
+			deleteValue(export.Values, param)
+		}
+	}
+
+	config, err := c.GetConfig(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the application config: %w", err)
+	}
+	export.Config = config.Values
+
+	if exists, err := c.runtime.SecretExists(certs.SecretName(name)); err != nil {
+		return nil, err
+	} else if exists {
+		data, err := c.runtime.SecretData(certs.SecretName(name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the TLS certificate: %w", err)
+		}
+		secretData, err := specs.ParseSecretData(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the TLS certificate: %w", err)
+		}
+		export.TLSCert, export.TLSKey = secretData[certs.CertFile], secretData[certs.KeyFile]
+		if len(export.TLSCert) == 0 || len(export.TLSKey) == 0 {
+			return nil, errors.New("the TLS certificate of the application is incomplete")
+		}
+	}
+
+	if export.APIKeys, err = apikeys.Enabled(c.runtime, name); err != nil {
+		return nil, err
+	}
+
+	cfg, err := gateway.Load()
+	if err != nil {
+		return nil, err
+	}
+	for _, route := range cfg.Routes {
+		if route.Application == name {
+			export.Routes = append(export.Routes, route)
+		}
+	}
+
+	return export, nil
+}
+
+// deleteValue removes the dotted key, Eg:- 'tls.secretName', from the nested values
+func deleteValue(values map[string]any, key string) {
+	parts := strings.Split(key, ".")
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := values[part].(map[string]any)
+		if !ok {
+			return
+		}
+		values = next
+	}
+	delete(values, parts[len(parts)-1])
+}
@@ -0,0 +1,57 @@
+package aiservices
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+// NetworkName returns the name of the podman network of the application, on which its pods resolve each other
+func NetworkName(appName string) string {
+	return "ai-services-" + appName
+}
+
+// ServiceName returns the logical name of the pods of a pod template on the network of the application,
+// Eg:- 'milvus' for milvus.yaml.tmpl
+func ServiceName(podTemplateName string) string {
+	return strings.TrimSuffix(podTemplateName, ".yaml.tmpl")
+}
+
+// withNetwork returns the deploy options attaching the pod to the network of the application, created if missing.
+// The pod is reachable by the logical name of its pod template and by its pod name, the replicas of a scalable
+// component sharing the logical name.
+func (cr *creator) withNetwork(podTemplateName, podName string, opts map[string]string) (map[string]string, error) {
+	name := NetworkName(cr.opts.Name)
+	exists, err := cr.runtime.NetworkExists(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check if network exists: %w", err)
+	}
+	if !exists {
+		logger.Infof("Creating network %s\n", name, 2)
+		if err := cr.runtime.CreateNetwork(name, map[string]string{
+			string(vars.ManagedLabel):    "true",
+			"ai-services.io/application": cr.opts.Name,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	withNet := maps.Clone(opts)
+	if withNet == nil {
+		withNet = map[string]string{}
+	}
+	withNet["network"] = fmt.Sprintf("%s:alias=%s,alias=%s", name, ServiceName(podTemplateName), podName)
+	return withNet, nil
+}
+
+// releaseNetwork removes the network of the deleted application
+func releaseNetwork(c *Client, appName string) error {
+	name := NetworkName(appName)
+	if exists, err := c.runtime.NetworkExists(name); err == nil && exists {
+		return c.runtime.RemoveNetwork(name)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package aiservices
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/constants"
+	"github.com/project-ai-services/ai-services/internal/pkg/specs"
+)
+
+// AppObject is a ConfigMap or a PersistentVolumeClaim of an application, created by kube play along with its pods
+type AppObject struct {
+	Kind        string `json:"kind"`
+	Name        string `json:"name"`
+	PodTemplate string `json:"podTemplate"`
+	// Declared is set for the objects declared by the pod template, unset for the claims of the pods kube play
+	// created a volume for
+	Declared bool `json:"declared"`
+	// Exists reports whether the volume of the claim exists. Podman does not persist the ConfigMaps, they only exist
+	// within the pods they were played with.
+	Exists bool `json:"exists"`
+}
+
+// ApplicationObjects returns the ConfigMaps and PersistentVolumeClaims of the application, as recorded by its last
+// deployed revision. The volumes managed by ai-services, the shared model volume and the config volume, are left out.
+func (c *Client) ApplicationObjects(ctx context.Context, appName string) ([]AppObject, error) {
+	rev, _, err := c.deployedRevision(ctx, appName)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []AppObject
+	seen := map[string]bool{}
+	add := func(obj AppObject) {
+		key := obj.Kind + "/" + obj.Name
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		if obj.Kind == specs.KindPersistentVolumeClaim {
+			obj.Exists, _ = c.runtime.VolumeExists(obj.Name)
+		}
+		objects = append(objects, obj)
+	}
+
+	for _, podTemplateName := range slices.Sorted(maps.Keys(rev.Manifests)) {
+		declared, err := specs.ParseObjects([]byte(rev.Objects[podTemplateName]))
+		if err != nil {
+			return nil, fmt.Errorf("revision %d: %w", rev.Number, err)
+		}
+		for _, obj := range declared {
+			add(AppObject{Kind: obj.Kind, Name: obj.Name, PodTemplate: podTemplateName, Declared: true})
+		}
+
+		podSpec, err := specs.ParsePodSpec([]byte(rev.Manifests[podTemplateName]))
+		if err != nil {
+			return nil, fmt.Errorf("revision %d: %w", rev.Number, err)
+		}
+		for _, volume := range podSpec.Spec.Volumes {
+			claim := volume.PersistentVolumeClaim
+			if claim == nil || claim.ClaimName == constants.SharedModelVolume || claim.ClaimName == ConfigVolumeName(appName) {
+				continue
+			}
+			add(AppObject{Kind: specs.KindPersistentVolumeClaim, Name: claim.ClaimName, PodTemplate: podTemplateName})
+		}
+	}
+	return objects, nil
+}
+
+// purgeObjects removes the volumes of the claims of the application
+func (c *Client) purgeObjects(objects []AppObject) []error {
+	var errs []error
+	for _, obj := range objects {
+		if obj.Kind != specs.KindPersistentVolumeClaim || !obj.Exists {
+			continue
+		}
+		if err := c.runtime.RemoveVolume(obj.Name); err != nil {
+			errs = append(errs, fmt.Errorf("volume %s: %w", obj.Name, err))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,272 @@
+package aiservices
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/templates"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/secretrefs"
+	"github.com/project-ai-services/ai-services/internal/pkg/specs"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+// defaultOutputTimeout bounds the capture of the outputs declaring no timeout
+var defaultOutputTimeout = 5 * time.Minute
+
+// outputNamePattern restricts the names of the outputs to the identifiers usable as {{ .Outputs.<name> }}
+var outputNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// capturedOutputs are the outputs captured from the layers deployed so far, by name
+type capturedOutputs struct {
+	mu     sync.Mutex
+	values map[string]string
+}
+
+func (o *capturedOutputs) set(name, value string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.values == nil {
+		o.values = map[string]string{}
+	}
+	o.values[name] = value
+}
+
+// snapshot returns a copy of the outputs, the later layers being rendered concurrently with the captures
+func (o *capturedOutputs) snapshot() map[string]string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	values := make(map[string]string, len(o.values))
+	for k, v := range o.values {
+		values[k] = v
+	}
+	return values
+}
+
+// OutputSecretName returns the name of the podman secret holding the output of the application
+func OutputSecretName(appName, output string) string {
+	return appName + "--output-" + strings.ToLower(strings.ReplaceAll(output, "_", "-"))
+}
+
+// validateOutputs checks the outputs of the template refer to its pod templates, with exactly one source
+func validateOutputs(appMetadata *templates.AppMetadata, podTemplateFileNames []string) error {
+	seen := map[string]bool{}
+	for _, o := range appMetadata.Outputs {
+		if !outputNamePattern.MatchString(o.Name) {
+			return fmt.Errorf("invalid output name '%s', expected letters, digits and underscores", o.Name)
+		}
+		if seen[o.Name] {
+			return fmt.Errorf("duplicate output %s", o.Name)
+		}
+		seen[o.Name] = true
+		if !slices.Contains(podTemplateFileNames, o.PodTemplate) {
+			return fmt.Errorf("invalid output %s: pod template %s not found", o.Name, o.PodTemplate)
+		}
+		if o.Timeout != "" {
+			if d, err := time.ParseDuration(o.Timeout); err != nil || d <= 0 {
+				return fmt.Errorf("invalid timeout '%s' of output %s", o.Timeout, o.Name)
+			}
+		}
+		switch {
+		case o.Log != nil && o.Exec == nil && o.File == nil:
+			re, err := regexp.Compile(o.Log.Pattern)
+			if err != nil {
+				return fmt.Errorf("invalid pattern of output %s: %w", o.Name, err)
+			}
+			if re.NumSubexp() < 1 {
+				return fmt.Errorf("pattern of output %s must have a capture group", o.Name)
+			}
+		case o.Exec != nil && o.Log == nil && o.File == nil:
+			if len(o.Exec.Command) == 0 {
+				return fmt.Errorf("command of output %s is empty", o.Name)
+			}
+		case o.File != nil && o.Log == nil && o.Exec == nil:
+			if o.File.Volume == "" || o.File.Path == "" {
+				return fmt.Errorf("volume and path of output %s must be set", o.Name)
+			}
+		default:
+			return fmt.Errorf("output %s: exactly one of log, exec and file must be set", o.Name)
+		}
+	}
+	return nil
+}
+
+// layerOutputs returns the outputs captured from the pod templates of the layer (1-based)
+func layerOutputs(appMetadata *templates.AppMetadata, layer int) []templates.Output {
+	if layer < 1 || layer > len(appMetadata.PodTemplateExecutions) {
+		return nil
+	}
+	var outputs []templates.Output
+	for _, o := range appMetadata.Outputs {
+		if slices.Contains(appMetadata.PodTemplateExecutions[layer-1], o.PodTemplate) {
+			outputs = append(outputs, o)
+		}
+	}
+	return outputs
+}
+
+// captureOutputs captures the outputs of the pods of the layer once ready, for the pod templates of the later layers
+// to be rendered with them. podSpec returns the deployed pod of a pod template.
+func (cr *creator) captureOutputs(ctx context.Context, layer int, appMetadata *templates.AppMetadata,
+	podSpec func(podTemplate string) (*models.PodSpec, error)) error {
+	for _, o := range layerOutputs(appMetadata, layer) {
+		pod, err := podSpec(o.PodTemplate)
+		if err != nil {
+			return err
+		}
+		value, err := cr.captureOutput(ctx, o, pod)
+		if err != nil {
+			return fmt.Errorf("output %s: %w", o.Name, err)
+		}
+		if o.Secret {
+			if value, err = cr.storeOutput(o.Name, value); err != nil {
+				return fmt.Errorf("output %s: %w", o.Name, err)
+			}
+		}
+		cr.outputs.set(o.Name, value)
+		logger.Infof("Output %s captured from pod %s\n", o.Name, pod.Name)
+	}
+	return nil
+}
+
+// captureOutput polls the source of the output until it yields a value, up to the timeout of the output
+func (cr *creator) captureOutput(ctx context.Context, o templates.Output, pod *models.PodSpec) (string, error) {
+	timeout := defaultOutputTimeout
+	if o.Timeout != "" {
+		timeout, _ = time.ParseDuration(o.Timeout)
+	}
+
+	var capture func(ctx context.Context) (string, error)
+	switch {
+	case o.Log != nil:
+		capture = cr.captureLog(o.Log, pod)
+	case o.Exec != nil:
+		capture = cr.captureExec(o.Exec, pod)
+	default:
+		capture = cr.captureFile(o.File, pod)
+	}
+
+	logger.Infof("Capturing output %s, up to %s\n", o.Name, timeout)
+	deadline := time.Now().Add(timeout)
+	for {
+		probeCtx, cancel := context.WithTimeout(ctx, waitProbeTimeout)
+		value, err := capture(probeCtx)
+		cancel()
+		if err == nil {
+			return value, nil
+		}
+		if time.Now().Add(waitPollInterval).After(deadline) {
+			return "", fmt.Errorf("not captured after %s: %w", timeout, err)
+		}
+		logger.Infof("Output %s not captured yet: %v\n", o.Name, err, 2)
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(waitPollInterval):
+		}
+	}
+}
+
+// captureLog returns the first capture group of the first log line of the container matching the pattern
+func (cr *creator) captureLog(l *templates.LogOutput, pod *models.PodSpec) func(context.Context) (string, error) {
+	re := regexp.MustCompile(l.Pattern)
+	return func(ctx context.Context) (string, error) {
+		stdoutChan, stderrChan := make(chan string, 100), make(chan string, 100)
+		found := make(chan string, 1)
+		go func(stdoutChan, stderrChan chan string) {
+			defer close(found)
+			for stdoutChan != nil || stderrChan != nil {
+				var line string
+				var ok bool
+				select {
+				case line, ok = <-stdoutChan:
+					if !ok {
+						stdoutChan = nil
+						continue
+					}
+				case line, ok = <-stderrChan:
+					if !ok {
+						stderrChan = nil
+						continue
+					}
+				}
+				if m := re.FindStringSubmatch(line); m != nil && len(found) == 0 {
+					found <- strings.TrimSpace(m[1])
+				}
+			}
+		}(stdoutChan, stderrChan)
+
+		// kube play names the containers <pod>-<container>
+		err := cr.runtime.StreamContainerLogs(ctx, pod.Name+"-"+l.Container, false, stdoutChan, stderrChan)
+		close(stdoutChan)
+		close(stderrChan)
+		value, ok := <-found
+		if ok {
+			return value, nil
+		}
+		if err != nil {
+			return "", err
+		}
+		return "", errors.New("no log line matches the pattern")
+	}
+}
+
+// captureExec returns the stdout of the command run in the container
+func (cr *creator) captureExec(e *templates.ExecOutput, pod *models.PodSpec) func(context.Context) (string, error) {
+	return func(context.Context) (string, error) {
+		out, err := cr.runtime.ExecContainer(pod.Name+"-"+e.Container, e.Command)
+		if err != nil {
+			return "", err
+		}
+		value := strings.TrimSpace(string(out))
+		if value == "" {
+			return "", errors.New("the command printed nothing")
+		}
+		return value, nil
+	}
+}
+
+// captureFile returns the content of the file in the volume of the pod, resolved as the files of the wait conditions
+func (cr *creator) captureFile(f *templates.FileOutput, pod *models.PodSpec) func(context.Context) (string, error) {
+	return func(context.Context) (string, error) {
+		dir, err := cr.volumeDir(pod, f.Volume)
+		if err != nil {
+			return "", err
+		}
+		data, err := os.ReadFile(filepath.Join(dir, f.Path))
+		if err != nil {
+			return "", err
+		}
+		value := strings.TrimSpace(string(data))
+		if value == "" {
+			return "", fmt.Errorf("file %s is empty", f.Path)
+		}
+		return value, nil
+	}
+}
+
+// storeOutput stores the value of the output in a podman secret of the application and returns the secret name
+func (cr *creator) storeOutput(name, value string) (string, error) {
+	secretName := OutputSecretName(cr.opts.Name, name)
+	secret, err := specs.MarshalSecret(secretName, specs.SecretTypeOpaque, map[string][]byte{secretrefs.DataKey: []byte(value)})
+	if err != nil {
+		return "", err
+	}
+	labels := map[string]string{
+		"ai-services.io/application": cr.opts.Name,
+		string(vars.ManagedLabel):    "true",
+	}
+	if err := cr.runtime.CreateSecret(secretName, secret, labels); err != nil {
+		return "", fmt.Errorf("failed to store the output: %w", err)
+	}
+	return secretName, nil
+}
@@ -0,0 +1,528 @@
+package aiservices
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	v1 "github.com/containers/podman/v5/pkg/k8s.io/api/core/v1"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/apikeys"
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/helpers"
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/templates"
+	"github.com/project-ai-services/ai-services/internal/pkg/constants"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/mounts"
+	"github.com/project-ai-services/ai-services/internal/pkg/specs"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+func verifyPodTemplateExists(tmpls map[string]*template.Template, appMetadata *templates.AppMetadata) error {
+	flattenPodTemplateExecutions := utils.FlattenArray(appMetadata.PodTemplateExecutions)
+
+	if len(flattenPodTemplateExecutions) != len(tmpls) {
+		return errors.New("number of values specified in podTemplateExecutions under metadata.yml is mismatched. Please ensure all the pod template file names are specified")
+	}
+
+	// Make sure the podTemplateExecution mentioned in metadata.yaml is valid (corresponding pod template is present)
+	for _, podTemplate := range flattenPodTemplateExecutions {
+		if _, ok := tmpls[podTemplate]; !ok {
+			return fmt.Errorf("value: %s specified in podTemplateExecutions under metadata.yml is invalid. Please ensure corresponding template file exists", podTemplate)
+		}
+	}
+
+	return nil
+}
+
+func (cr *creator) executePodTemplates(ctx context.Context, appMetadata *templates.AppMetadata,
+	tmpls map[string]*template.Template, pciAddresses []string, existingPods []string) error {
+	appName := cr.opts.Name
+
+	globalParams, err := cr.templateParams(appMetadata)
+	if err != nil {
+		return err
+	}
+	retry, err := cr.retryPolicy(appMetadata)
+	if err != nil {
+		return err
+	}
+
+	// assign the Spyre cards to the containers upfront, the layers only read the assignments
+	spyreAssignments, err := cr.assignSpyreCards(appMetadata, existingPods, pciAddresses)
+	if err != nil {
+		return fmt.Errorf("failed to assign spyre cards: %w", err)
+	}
+
+	deployPodTemplate := func(layerCtx context.Context, layer int, podTemplateName string) error {
+		logger.Infof("Processing template: %s...\n", podTemplateName)
+		renderStart := time.Now()
+
+		// fetch pod Spec
+		podSpec, err := cr.fetchPodSpec(podTemplateName)
+		if err != nil {
+			return err
+		}
+
+		exists := slices.Contains(existingPods, podSpec.Name)
+		if exists && (!cr.opts.Force || !cr.recreatable(podTemplateName, podSpec)) {
+			logger.Infof("Skipping pod: %s as it already exists", podSpec.Name)
+			cr.deployer(retry).Skip(layer, podTemplateName, podSpec.Name)
+			return nil
+		}
+
+		// fetch annotations from pod Spec
+		podAnnotations := fetchPodAnnotations(podSpec)
+
+		manifest, objects, err := cr.renderPod(podTemplateName, tmpls[podTemplateName], globalParams, podSpec, spyreAssignments[podTemplateName], appMetadata)
+		if err != nil {
+			return err
+		}
+
+		cr.timings.since(renderStart, Timing{Stage: TimingRender, Layer: layer, Pod: podSpec.Name})
+
+		// do not deploy the pod if another pod of the layer failed in the meantime
+		if err := layerCtx.Err(); err != nil {
+			return err
+		}
+
+		// the site policies are evaluated on the pod as played
+		renderedSpec, err := specs.ParsePodSpec(manifest)
+		if err != nil {
+			return err
+		}
+		if err := cr.admit(layerCtx, renderedSpec); err != nil {
+			return err
+		}
+
+		if err := relabelHostPaths(manifest); err != nil {
+			return err
+		}
+
+		deployOpts := constructPodDeployOptions(podAnnotations)
+		cr.rendered.add(podTemplateName, manifest, objects, deployOpts)
+
+		// with Force, the existing pods are recreated once their definition changed
+		if exists {
+			if !cr.definitionChanged(podTemplateName, manifest, objects, deployOpts) {
+				logger.Infof("Skipping pod: %s as its definition is unchanged\n", podSpec.Name)
+				cr.deployer(retry).Skip(layer, podTemplateName, podSpec.Name)
+				return nil
+			}
+			if deployOpts, err = cr.prepareReplace(layerCtx, podSpec, deployOpts); err != nil {
+				return err
+			}
+		}
+
+		// Deploy the Pod and do Readiness check, kube play creates the objects along with the pod
+		if err := cr.deployPodWithRetry(layerCtx, retry, layer, podTemplateName, podSpec, withObjects(objects, manifest), deployOpts); err != nil {
+			return err
+		}
+
+		// verify the models are loaded and serving before marking the pod ready
+		for _, model := range appMetadata.RequiredModels(podTemplateName) {
+			if model.Verify == nil {
+				continue
+			}
+			logger.Infof("Verifying model %s served by container %s...\n", model.Name, model.Container)
+			if err := helpers.VerifyModelServing(cr.runtime, podSpec.Name, apikeys.Token(cr.runtime, appName), model); err != nil {
+				return fmt.Errorf("model verification failed for %s: %w", model.Name, err)
+			}
+			logger.Infof("Model %s verified successfully\n", model.Name)
+		}
+		cr.progress.report(ProgressEvent{Stage: StageDeploy, Layer: layer, Pod: podTemplateName, Message: "Pod " + podSpec.Name + " is ready"})
+		return nil
+	}
+
+	// the next layer may depend on more than the health of the containers, Eg:- an index being built, and on the
+	// outputs of the layer, Eg:- a generated admin password
+	awaitConditions := func(ctx context.Context, layer int) error {
+		if err := cr.awaitConditions(ctx, layer, appMetadata, cr.deployedPodSpec); err != nil {
+			return err
+		}
+		return cr.captureOutputs(ctx, layer, appMetadata, cr.deployedPodSpec)
+	}
+
+	_, err = cr.deployer(retry).Run(ctx, appMetadata.PodTemplateExecutions, podDependencies(appMetadata), deployPodTemplate, awaitConditions)
+	return err
+}
+
+// deployedPodSpec returns the pod deployed for the pod template, as rendered by the deployment or else as rendered
+// by the template for the pods already existing
+func (cr *creator) deployedPodSpec(podTemplate string) (*models.PodSpec, error) {
+	cr.rendered.mu.Lock()
+	manifest, ok := cr.rendered.manifests[podTemplate]
+	cr.rendered.mu.Unlock()
+	if ok {
+		return specs.ParsePodSpec([]byte(manifest))
+	}
+	return cr.fetchPodSpec(podTemplate)
+}
+
+// templateParams loads the template values and returns the params shared by all the pod templates
+func (cr *creator) templateParams(appMetadata *templates.AppMetadata) (map[string]any, error) {
+	values, err := cr.templates.LoadValues(cr.opts.Template, cr.opts.Environment, cr.opts.ValuesFiles, cr.params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load params for application: %w", err)
+	}
+	cr.rendered.values = values
+
+	// the templates can size the containers after the host, Eg:- {{ .Facts.CPU.Cores }}
+	facts, err := cr.Facts(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]any{
+		"AppName":         cr.opts.Name,
+		"AppTemplateName": appMetadata.Name,
+		"Version":         appMetadata.Version,
+		"Values":          values,
+		"Facts":           facts,
+		// Key -> container name
+		// Value -> range of key-value env pairs
+		"env": map[string]map[string]string{},
+	}, nil
+}
+
+// renderPod renders the pod template with the Spyre cards assigned to its containers, and injects the model
+// mounts, the CPU pinning, the secret references and the config mount into the manifest. The ConfigMaps and
+// PersistentVolumeClaims declared along with the Pod are returned apart, as a multi-document YAML.
+func (cr *creator) renderPod(podTemplateName string, podTemplate *template.Template, globalParams map[string]any,
+	podSpec *models.PodSpec, spyreAssignments map[string][]string, appMetadata *templates.AppMetadata) ([]byte, []byte, error) {
+	// Shallow Copy globalParams Map
+	params := utils.CopyMap(globalParams)
+
+	// get the env params for a given pod
+	params["env"] = returnEnvParamsForPod(podSpec, spyreAssignments)
+	// the outputs of the earlier layers, Eg:- {{ .Outputs.adminPassword }}
+	params["Outputs"] = cr.outputs.snapshot()
+
+	var rendered bytes.Buffer
+	if err := podTemplate.Execute(&rendered, params); err != nil {
+		return nil, nil, fmt.Errorf("failed to render pod template %s: %w", podTemplateName, err)
+	}
+	if err := specs.ValidateKinds(rendered.Bytes(), specs.PodTemplateKinds...); err != nil {
+		return nil, nil, fmt.Errorf("invalid pod template %s: %w", podTemplateName, err)
+	}
+	manifest, objects, err := specs.SplitPod(rendered.Bytes())
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid pod template %s: %w", podTemplateName, err)
+	}
+
+	// refuse the sensitive host paths, before injecting the mounts managed by ai-services
+	renderedSpec, err := specs.ParsePodSpec(manifest)
+	if err != nil {
+		return nil, nil, err
+	}
+	if vars.Rootless {
+		relocateHostPaths(renderedSpec)
+	}
+	if err := mounts.Validate(renderedSpec, cr.opts.AllowedHostPaths); err != nil {
+		return nil, nil, err
+	}
+	if vars.Rootless {
+		if manifest, err = specs.MarshalPodSpec(renderedSpec); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// mount the models required by the containers of the pod
+	reqModels := appMetadata.RequiredModels(podTemplateName)
+	manifest, err = injectModelMounts(manifest, reqModels, cr.modelDirectory, appMetadata.SharedModelVolume)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// pass the device nodes of the assigned Spyre cards
+	manifest, err = injectSpyreDevices(manifest, spyreAssignments)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// pin the containers to their dedicated cores
+	manifest, err = injectCPUSets(manifest, cr.cpusets[podTemplateName])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// rewrite the health checks as requested
+	manifest, err = injectHealthOverrides(manifest, cr.opts.Health)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// rewrite the restart policy as declared by the template
+	manifest, err = cr.injectRestartPolicy(manifest, podTemplateName, appMetadata)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// record the secrets referenced by the containers, to restart them once a secret is rotated
+	manifest, err = injectSecretRefs(manifest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// mount the runtime config into the containers reading it
+	manifest, err = injectConfigMount(manifest, cr.opts.Name, configConsumers(fetchPodAnnotations(podSpec)))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// merge the labels and annotations of the user, Eg:- for the inventory and billing systems
+	manifest, err = injectMetadata(manifest, cr.opts.Labels, cr.opts.Annotations)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// keep the internal ports from being published, and record the exposure of the others
+	manifest, err = cr.injectExposure(manifest, podTemplateName, appMetadata)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// assign the host ports of the logical ports
+	manifest, err = cr.injectAutoPorts(podTemplateName, manifest)
+	if err != nil {
+		return nil, nil, err
+	}
+	return manifest, objects, nil
+}
+
+// withObjects prepends the ConfigMaps and PersistentVolumeClaims to the pod manifest, for kube play to create them
+// before the pod
+func withObjects(objects, manifest []byte) []byte {
+	if len(objects) == 0 {
+		return manifest
+	}
+	return slices.Concat(objects, []byte("---\n"), manifest)
+}
+
+// injectModelMounts mounts the required models of modelDirectory read-only into their containers in the rendered
+// pod template. When shared is set, the models are mounted from the shared model volume instead
+func injectModelMounts(manifest []byte, reqModels []templates.ModelRequirement, modelDirectory string, shared bool) ([]byte, error) {
+	if len(reqModels) == 0 {
+		return manifest, nil
+	}
+
+	podSpec, err := specs.ParsePodSpec(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	hostPathType := v1.HostPathDirectory
+	for i, model := range reqModels {
+		modelPath := models.ModelPath(model.Name)
+		mountPath := model.MountPath
+		if mountPath == "" {
+			mountPath = "/models/" + modelPath
+		}
+
+		volume := v1.Volume{
+			Name: fmt.Sprintf("ai-services-model-%d", i),
+			VolumeSource: v1.VolumeSource{
+				HostPath: &v1.HostPathVolumeSource{
+					Path: filepath.Join(modelDirectory, modelPath),
+					Type: &hostPathType,
+				},
+			},
+		}
+		mount := v1.VolumeMount{
+			MountPath: mountPath,
+			ReadOnly:  true,
+		}
+
+		if shared {
+			// kube play maps the claim to the podman volume with the same name
+			volume = v1.Volume{
+				Name: constants.SharedModelVolume,
+				VolumeSource: v1.VolumeSource{
+					PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+						ClaimName: constants.SharedModelVolume,
+						ReadOnly:  true,
+					},
+				},
+			}
+			mount.SubPath = modelPath
+		}
+
+		if err := specs.AddVolumeMount(podSpec, model.Container, volume, mount); err != nil {
+			return nil, fmt.Errorf("failed to mount model %s: %w", model.Name, err)
+		}
+	}
+
+	return specs.MarshalPodSpec(podSpec)
+}
+
+// relocateHostPaths moves the host paths beneath the data directory of ai-services to the data directory of the
+// user in rootless mode, as the rootless containers cannot write to the directories owned by root
+func relocateHostPaths(podSpec *models.PodSpec) {
+	for _, volume := range podSpec.Spec.Volumes {
+		if volume.HostPath == nil {
+			continue
+		}
+		if rel, ok := strings.CutPrefix(volume.HostPath.Path, vars.RootfulDataDirectory+"/"); ok {
+			volume.HostPath.Path = filepath.Join(vars.DataDirectory, rel)
+		}
+	}
+}
+
+// relabelHostPaths labels the host paths bind mounted by the rendered pod for SELinux
+func relabelHostPaths(manifest []byte) error {
+	podSpec, err := specs.ParsePodSpec(manifest)
+	if err != nil {
+		return err
+	}
+	return mounts.Relabel(podSpec)
+}
+
+func (cr *creator) fetchPodSpec(podTemplateFileName string) (*models.PodSpec, error) {
+	if podSpec, ok := cr.cache.podSpec(podTemplateFileName); ok {
+		return podSpec, nil
+	}
+
+	appTemplateName := cr.opts.Template
+	podSpec, err := cr.templates.LoadPodTemplateWithValues(appTemplateName, podTemplateFileName, cr.opts.Name, cr.opts.Environment, cr.opts.ValuesFiles, cr.params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load pod Template: '%s' for appTemplate: '%s' with error: %w", podTemplateFileName, appTemplateName, err)
+	}
+	cr.cache.storePodSpec(podTemplateFileName, podSpec)
+
+	return podSpec, nil
+}
+
+func fetchPodAnnotations(podSpec *models.PodSpec) map[string]string {
+	return specs.FetchPodAnnotations(*podSpec)
+}
+
+func checkForPodStartAnnotation(podAnnotations map[string]string) string {
+	if val, ok := podAnnotations[constants.PodStartAnnotationkey]; ok {
+		if val == constants.PodStartOff || val == constants.PodStartOn {
+			return val
+		}
+	}
+	return ""
+}
+
+// fetchHostPortMappingFromAnnotation returns the hostPortMappings from the pod port annotations for a given pod template
+// Returns:
+//
+//	hostPortMapping: Key -> containerPort, Value -> hostPort
+//
+// port annotation takes comma seperated values of 'hostPort:containerPort' combination
+// port annotation syntax: 'ai-services.io/ports': "<hostPart1>:<containerPort1>,<hostPart2>:<containerPort2>"
+//
+// Below are the hostPortMapping values based on different combinations
+//  1. 'ai-services.io/ports': "8000:3000"
+//     hostPortMapping = {"3000": "8000"}
+//  2. 'ai-services.io/ports': "8000:3000, 8001:3001"
+//     hostPortMapping = {"3000": "8000", "3001": "8001"}
+//  3. 'ai-services.io/ports': ":3000"
+//     hostPortMapping = {"3000": ""}
+//  4. 'ai-services.io/ports': "3000:"
+//     hostPortMapping = {} // Skip such values
+//  5. 'ai-services.io/ports': "3000"
+//     hostPortMapping = {"3000": ""}
+//
+// The 'auto' host ports, Eg:- "auto:3000", are replaced by their assigned host ports when the pod is rendered
+func fetchHostPortMappingFromAnnotation(podAnnotations map[string]string) map[string]string {
+	// key -> containerPort and value -> hostPort
+	hostPortMapping := map[string]string{}
+
+	portMappings, ok := podAnnotations[constants.PodPortsAnnotationKey]
+	if !ok {
+		// return empty map if port annotation is not present
+		return hostPortMapping
+	}
+
+	portMapping := strings.SplitSeq(portMappings, ",")
+	for p := range portMapping {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+
+		// Find colon
+		i := strings.Index(p, ":")
+		if i == -1 {
+			// No colon → whole thing is the containerPort
+			hostPortMapping[p] = ""
+			continue
+		}
+
+		// Before colon string is hostPort
+		hostPort := strings.TrimSpace(p[:i])
+		// After colon string is containerPort
+		containerPort := strings.TrimSpace(p[i+1:])
+
+		// If colon exists but NO value after the colon (containerPort) → then skip
+		if containerPort == "" {
+			continue
+		}
+
+		hostPortMapping[containerPort] = hostPort
+	}
+
+	return hostPortMapping
+}
+
+// privilegedPort returns true if the host port is below the first port non root users can bind
+func privilegedPort(hostPort string) bool {
+	port, err := strconv.Atoi(hostPort)
+	if err != nil || port == 0 {
+		return false
+	}
+	start := 1024
+	if data, err := os.ReadFile("/proc/sys/net/ipv4/ip_unprivileged_port_start"); err == nil {
+		if v, err := strconv.Atoi(strings.TrimSpace(string(data))); err == nil {
+			start = v
+		}
+	}
+	return port < start
+}
+
+func constructPodDeployOptions(podAnnotations map[string]string) map[string]string {
+	podStart := checkForPodStartAnnotation(podAnnotations)
+
+	// construct start option
+	podDeployOptions := map[string]string{}
+	if podStart != "" {
+		podDeployOptions["start"] = podStart
+	}
+
+	// construct publish option
+	hostPortMappings := fetchHostPortMappingFromAnnotation(podAnnotations)
+	exposures := helpers.ParseExposureAnnotation(podAnnotations[constants.PortExposureAnnotationKey])
+	podDeployOptions["publish"] = ""
+
+	// loop over each of the hostPortMappings to construct the 'publish' option
+	for containerPort, hostPort := range hostPortMappings {
+		if vars.Rootless && privilegedPort(hostPort) {
+			logger.Warningf("Rootless mode: host port %s is privileged, publishing container port %s on a random host port\n", hostPort, containerPort)
+			hostPort = ""
+		}
+		if exposures[containerPort] == templates.ExposureLocal {
+			// bind the local ports on the loopback interface only, Eg:- 127.0.0.1:8000:3000 or 127.0.0.1::3000
+			podDeployOptions["publish"] += loopbackAddress + ":" + hostPort + ":" + containerPort
+		} else if hostPort != "" {
+			// if the host port is present
+			podDeployOptions["publish"] += hostPort + ":" + containerPort
+		} else {
+			// else just populate the containerPort, so that dynamically podman will populate
+			podDeployOptions["publish"] += containerPort
+		}
+		podDeployOptions["publish"] += ","
+	}
+
+	return podDeployOptions
+}
@@ -0,0 +1,141 @@
+package aiservices
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/constants"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/specs"
+	"github.com/project-ai-services/ai-services/internal/pkg/state"
+)
+
+// portsStateName is the name of the state document holding the host ports assigned to the applications
+const portsStateName = "ports"
+
+// autoHostPort is the host part of the ports annotation requesting a free host port assigned at deploy time, so that
+// several applications of the same template can coexist on a host, Eg:- 'ai-services.io/ports': "auto:3000"
+const autoHostPort = "auto"
+
+// portAssignments maps the applications to the host ports assigned to their logical ports, keyed by
+// <pod template>/<container port>
+type portAssignments map[string]map[string]int
+
+// injectAutoPorts assigns free host ports to the container ports of the pod declared with an 'auto' host port, and
+// rewrites the ports annotation with the assigned ports. The assigned container ports are recorded in the auto ports
+// annotation, reported by 'application endpoints'.
+func (cr *creator) injectAutoPorts(podTemplateName string, manifest []byte) ([]byte, error) {
+	podSpec, err := specs.ParsePodSpec(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := strings.Split(podSpec.Annotations[constants.PodPortsAnnotationKey], ",")
+	var autoPorts []string
+	for _, entry := range entries {
+		if containerPort, ok := autoContainerPort(entry); ok {
+			autoPorts = append(autoPorts, containerPort)
+		}
+	}
+	if len(autoPorts) == 0 {
+		return manifest, nil
+	}
+
+	assigned, err := assignHostPorts(cr.opts.Name, podTemplateName, autoPorts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to assign the host ports of %s: %w", podTemplateName, err)
+	}
+	for i, entry := range entries {
+		if containerPort, ok := autoContainerPort(entry); ok {
+			entries[i] = strconv.Itoa(assigned[containerPort]) + ":" + containerPort
+			logger.Infof("Publishing container port %s of %s on host port %d\n", containerPort, podTemplateName, assigned[containerPort], 2)
+		}
+	}
+	podSpec.Annotations[constants.PodPortsAnnotationKey] = strings.Join(entries, ",")
+	podSpec.Annotations[constants.PodAutoPortsAnnotationKey] = strings.Join(autoPorts, ",")
+
+	return specs.MarshalPodSpec(podSpec)
+}
+
+// autoContainerPort returns the container port of an entry of the ports annotation with an 'auto' host port
+func autoContainerPort(entry string) (string, bool) {
+	hostPort, containerPort, ok := strings.Cut(strings.TrimSpace(entry), ":")
+	containerPort = strings.TrimSpace(containerPort)
+	return containerPort, ok && strings.TrimSpace(hostPort) == autoHostPort && containerPort != ""
+}
+
+// assignHostPorts returns the host ports of the container ports of the pod template, by container port. Ports
+// already assigned to the application are kept, so that re-running create doesn't move its endpoints, the others
+// are picked from the free ports of the auto port range which are assigned to no application.
+func assignHostPorts(appName, podTemplateName string, containerPorts []string) (map[string]int, error) {
+	assigned := map[string]int{}
+	assignments := portAssignments{}
+	err := state.Default().Update(portsStateName, &assignments, func() error {
+		used := map[int]string{}
+		for app, ports := range assignments {
+			if app == appName {
+				continue
+			}
+			for _, port := range ports {
+				used[port] = app
+			}
+		}
+
+		current := assignments[appName]
+		if current == nil {
+			current = map[string]int{}
+		}
+		for key, port := range current {
+			if !strings.HasPrefix(key, podTemplateName+"/") {
+				used[port] = appName
+			}
+		}
+
+		for _, containerPort := range containerPorts {
+			key := podTemplateName + "/" + containerPort
+			if port, ok := current[key]; ok && used[port] == "" {
+				assigned[containerPort] = port
+				used[port] = appName
+				continue
+			}
+			port, err := freeHostPort(used)
+			if err != nil {
+				return err
+			}
+			current[key] = port
+			assigned[containerPort] = port
+			used[port] = appName
+		}
+
+		assignments[appName] = current
+		return nil
+	})
+	return assigned, err
+}
+
+// freeHostPort returns the first port of the auto port range which is neither used nor bound on the host
+func freeHostPort(used map[int]string) (int, error) {
+	for port := constants.AutoPortRangeStart; port <= constants.AutoPortRangeEnd; port++ {
+		if used[port] != "" {
+			continue
+		}
+		l, err := net.Listen("tcp", ":"+strconv.Itoa(port))
+		if err != nil {
+			continue
+		}
+		_ = l.Close()
+		return port, nil
+	}
+	return 0, fmt.Errorf("no free host port left in %d-%d", constants.AutoPortRangeStart, constants.AutoPortRangeEnd)
+}
+
+// releasePorts releases the host ports assigned to the deleted application
+func releasePorts(appName string) error {
+	assignments := portAssignments{}
+	return state.Default().Update(portsStateName, &assignments, func() error {
+		delete(assignments, appName)
+		return nil
+	})
+}
@@ -0,0 +1,52 @@
+package aiservices
+
+import (
+	"sync"
+	"time"
+)
+
+// Stage is a stage of the deployment of an application
+type Stage string
+
+const (
+	StagePrepare   Stage = "prepare"
+	StageImages    Stage = "images"
+	StageModels    Stage = "models"
+	StageSecrets   Stage = "secrets"
+	StageDeploy    Stage = "deploy"
+	StageSmokeTest Stage = "smoke-test"
+	StageCompleted Stage = "completed"
+)
+
+// ProgressEvent reports the progress of the deployment of an application
+type ProgressEvent struct {
+	Time    time.Time `json:"time"`
+	Stage   Stage     `json:"stage"`
+	Message string    `json:"message"`
+	// Layer is the pod template execution layer being deployed, starting from 1
+	Layer int `json:"layer,omitempty"`
+	// Pod is the pod template being deployed
+	Pod string `json:"pod,omitempty"`
+	// Timings are the elapsed times of the deployment stages, set once completed
+	Timings []Timing `json:"timings,omitempty"`
+}
+
+// ProgressFunc receives the progress events of a deployment. It is invoked synchronously, one event at a time.
+type ProgressFunc func(ProgressEvent)
+
+// progressReporter serializes the progress events, as the pods of a layer are deployed concurrently
+type progressReporter struct {
+	mu sync.Mutex
+	fn ProgressFunc
+}
+
+func (p *progressReporter) report(event ProgressEvent) {
+	if p.fn == nil {
+		return
+	}
+	event.Time = time.Now()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.fn(event)
+}
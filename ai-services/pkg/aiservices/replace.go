@@ -0,0 +1,85 @@
+package aiservices
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"sync"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/podman"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+// replaceSupported reports whether the local kube play replaces the existing pods, checked once
+var replaceSupported = sync.OnceValue(func() bool {
+	flags, err := podman.KubePlayFlags([]string{"replace"})
+	if err != nil {
+		logger.Infof("Unable to check the kube play flags, removing the pods before replacing them: %v\n", err, 2)
+		return false
+	}
+	return flags["replace"]
+})
+
+// loadPreviousRevision loads the last deployed revision of the application, the definitions of the existing pods
+// are compared to for Force. Without a revision, all the existing pods are recreated.
+func (cr *creator) loadPreviousRevision(ctx context.Context) {
+	history, err := cr.ListRevisions(ctx, cr.opts.Name)
+	if err != nil {
+		logger.Warningf("failed to load the revisions of application '%s', recreating all its pods: %v\n", cr.opts.Name, err)
+		return
+	}
+	if i := lastDeployed(history); i >= 0 {
+		cr.previous = &history[i]
+	}
+}
+
+// recreatable reports whether the existing pod of the pod template can be recreated with Force. The pods holding
+// Spyre cards are kept, as their cards are only assigned to the pods not deployed yet.
+func (cr *creator) recreatable(podTemplate string, podSpec *models.PodSpec) bool {
+	if vars.Rootless {
+		return true
+	}
+	_, cards, err := fetchSpyreCardsFromPodAnnotations(podSpec.Annotations)
+	if err != nil {
+		return false
+	}
+	for _, count := range cards {
+		if count > 0 {
+			cr.warn("pod %s of pod template %s holds Spyre cards and is not recreated, delete the application to recreate it", podSpec.Name, podTemplate)
+			return false
+		}
+	}
+	return true
+}
+
+// definitionChanged reports whether the rendered pod differs from the pod of the previous revision: its manifest,
+// its objects or its kube play options
+func (cr *creator) definitionChanged(podTemplate string, manifest, objects []byte, opts map[string]string) bool {
+	if cr.previous == nil {
+		return true
+	}
+	previous, ok := cr.previous.Manifests[podTemplate]
+	if !ok {
+		return true
+	}
+	return previous != string(manifest) || cr.previous.Objects[podTemplate] != string(objects) ||
+		!maps.Equal(cr.previous.DeployOptions[podTemplate], opts)
+}
+
+// prepareReplace prepares the recreation of the existing pod: kube play replaces it when supported, otherwise the
+// pod is deleted first. Returns the kube play options of the recreation.
+func (cr *creator) prepareReplace(ctx context.Context, podSpec *models.PodSpec, opts map[string]string) (map[string]string, error) {
+	logger.Infof("Recreating pod %s as its definition changed\n", podSpec.Name)
+	if replaceSupported() {
+		// the replace flag is not recorded in the revision, so that the options of the next deployments compare
+		opts = maps.Clone(opts)
+		opts["replace"] = "true"
+		return opts, nil
+	}
+	if failure := cr.deletePod(ctx, Pod{ID: podSpec.Name, Name: podSpec.Name}, DefaultGracePeriod); failure != nil {
+		return nil, fmt.Errorf("failed to remove pod %s before recreating it: %s", podSpec.Name, failure.Reason)
+	}
+	return opts, nil
+}
@@ -0,0 +1,218 @@
+package aiservices
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	goruntime "runtime"
+	"sort"
+	"strconv"
+	"strings"
+
+	v1 "github.com/containers/podman/v5/pkg/k8s.io/api/core/v1"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/state"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+)
+
+// resourcesStateName is the name of the state document holding the resources reserved by the applications
+const resourcesStateName = "resources"
+
+// Resources are the CPU and memory of the containers
+type Resources struct {
+	// MilliCPU is the CPU in thousandths of a (logical) CPU
+	MilliCPU int64 `json:"milliCPU"`
+	// Memory in bytes
+	Memory int64 `json:"memory"`
+}
+
+func (r *Resources) add(o Resources) {
+	r.MilliCPU += o.MilliCPU
+	r.Memory += o.Memory
+}
+
+func (r Resources) String() string {
+	return fmt.Sprintf("cpu=%s memory=%s", FormatMilliCPU(r.MilliCPU), FormatBytes(r.Memory))
+}
+
+// ErrInsufficientResources is returned when the host cannot fit the resources of the application
+var ErrInsufficientResources = errors.New("insufficient host resources")
+
+// podResources sums the resources of the containers of the pod, the requests or the limits when not requested
+func podResources(spec v1.PodSpec) Resources {
+	var total Resources
+	for _, c := range spec.Containers {
+		total.add(containerResources(c.Resources))
+	}
+	return total
+}
+
+func containerResources(req v1.ResourceRequirements) Resources {
+	var r Resources
+	if q, ok := req.Requests[v1.ResourceCPU]; ok {
+		r.MilliCPU = q.MilliValue()
+	} else if q, ok := req.Limits[v1.ResourceCPU]; ok {
+		r.MilliCPU = q.MilliValue()
+	}
+	if q, ok := req.Requests[v1.ResourceMemory]; ok {
+		r.Memory = q.Value()
+	} else if q, ok := req.Limits[v1.ResourceMemory]; ok {
+		r.Memory = q.Value()
+	}
+	return r
+}
+
+// hostCapacity returns the online logical CPUs and the total memory of the host
+func hostCapacity() (Resources, error) {
+	capacity := Resources{MilliCPU: int64(goruntime.NumCPU()) * 1000}
+
+	f, err := os.Open("/proc/meminfo")
+	if err != nil {
+		return capacity, fmt.Errorf("failed to read memory info: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) >= 2 && fields[0] == "MemTotal:" {
+			kb, err := strconv.ParseInt(fields[1], 10, 64)
+			if err != nil {
+				return capacity, fmt.Errorf("failed to parse memory info: %w", err)
+			}
+			capacity.Memory = kb * 1024
+			return capacity, nil
+		}
+	}
+
+	return capacity, errors.New("failed to read memory info: MemTotal not found")
+}
+
+// sizingReport is the resources required by an application against the host capacity
+type sizingReport struct {
+	pods     map[string]Resources
+	required Resources
+	others   map[string]Resources
+	reserved Resources
+	capacity Resources
+}
+
+func (r *sizingReport) fits() bool {
+	available := Resources{MilliCPU: r.capacity.MilliCPU - r.reserved.MilliCPU, Memory: r.capacity.Memory - r.reserved.Memory}
+	// containers without CPU requests/limits share the CPUs, only the requested CPUs are checked
+	return r.required.MilliCPU <= available.MilliCPU && r.required.Memory <= available.Memory
+}
+
+func (r *sizingReport) String() string {
+	var b strings.Builder
+	b.WriteString("Sizing report:\n")
+	for _, pod := range sortedKeys(r.pods) {
+		fmt.Fprintf(&b, "  pod %-40s %s\n", pod, r.pods[pod])
+	}
+	fmt.Fprintf(&b, "  %-44s %s\n", "required", r.required)
+	for _, app := range sortedKeys(r.others) {
+		fmt.Fprintf(&b, "  reserved by %-32s %s\n", app, r.others[app])
+	}
+	fmt.Fprintf(&b, "  %-44s %s\n", "host capacity", r.capacity)
+	fmt.Fprintf(&b, "  %-44s cpu=%s memory=%s", "available",
+		FormatMilliCPU(r.capacity.MilliCPU-r.reserved.MilliCPU), FormatBytes(r.capacity.Memory-r.reserved.Memory))
+	return b.String()
+}
+
+// checkResources sums the resources of all the pods of the application and compares them against the host
+// capacity, minus the resources reserved by the other applications
+func (cr *creator) checkResources(ctx context.Context, tmpls []string) (Resources, error) {
+	report := &sizingReport{pods: map[string]Resources{}, others: map[string]Resources{}}
+
+	for _, tmpl := range tmpls {
+		podSpec, err := cr.fetchPodSpec(tmpl)
+		if err != nil {
+			return Resources{}, err
+		}
+		res := podResources(podSpec.Spec)
+		report.pods[podSpec.Name] = res
+		report.required.add(res)
+	}
+
+	capacity, err := hostCapacity()
+	if err != nil {
+		return Resources{}, err
+	}
+	report.capacity = capacity
+
+	var reservations map[string]Resources
+	if err := state.Default().Load(resourcesStateName, &reservations); err != nil {
+		return Resources{}, err
+	}
+	for app, res := range reservations {
+		if app == cr.opts.Name {
+			continue
+		}
+		// skip the applications deleted outside of ai-services
+		if _, err := cr.GetApplication(ctx, app); errors.Is(err, ErrApplicationNotFound) {
+			continue
+		}
+		report.others[app] = res
+		report.reserved.add(res)
+	}
+
+	logger.Infoln(report.String(), 2)
+	if !report.fits() {
+		err := fmt.Errorf("%w for application '%s'. %s", ErrInsufficientResources, cr.opts.Name, report)
+		if !cr.opts.SkipResourceCheck {
+			return Resources{}, err
+		}
+		logger.Warningf("%v\nOversubscribing the host as the resource check is skipped\n", err)
+	}
+
+	return report.required, nil
+}
+
+// reserveResources records the resources of the deployed application
+func reserveResources(appName string, res Resources) error {
+	reservations := map[string]Resources{}
+	return state.Default().Update(resourcesStateName, &reservations, func() error {
+		reservations[appName] = res
+		return nil
+	})
+}
+
+// releaseResources drops the resources of the deleted application
+func releaseResources(appName string) error {
+	reservations := map[string]Resources{}
+	return state.Default().Update(resourcesStateName, &reservations, func() error {
+		delete(reservations, appName)
+		return nil
+	})
+}
+
+func sortedKeys(m map[string]Resources) []string {
+	keys := utils.ExtractMapKeys(m)
+	sort.Strings(keys)
+	return keys
+}
+
+// FormatMilliCPU formats the thousandths of a CPU, whole CPUs without unit
+func FormatMilliCPU(m int64) string {
+	if m%1000 == 0 {
+		return strconv.FormatInt(m/1000, 10)
+	}
+	return strconv.FormatInt(m, 10) + "m"
+}
+
+// FormatBytes formats the bytes in Gi or Mi
+func FormatBytes(b int64) string {
+	const gi = 1 << 30
+	const mi = 1 << 20
+	switch {
+	case b >= gi:
+		return strconv.FormatFloat(float64(b)/gi, 'f', -1, 64) + "Gi"
+	case b >= mi:
+		return strconv.FormatFloat(float64(b)/mi, 'f', 0, 64) + "Mi"
+	default:
+		return strconv.FormatInt(b, 10)
+	}
+}
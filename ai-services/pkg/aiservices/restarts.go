@@ -0,0 +1,227 @@
+package aiservices
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	v1 "github.com/containers/podman/v5/pkg/k8s.io/api/core/v1"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/audit"
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/templates"
+	"github.com/project-ai-services/ai-services/internal/pkg/constants"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/specs"
+	"github.com/project-ai-services/ai-services/internal/pkg/state"
+)
+
+// restartsStateName is the state document of the restarts of the containers with a restart limit
+const restartsStateName = "restarts"
+
+// restartResetAfter is the time a container has to run for its restarts to be forgotten by the watcher
+const restartResetAfter = 10 * time.Minute
+
+var restartPolicies = []string{templates.RestartAlways, templates.RestartOnFailure, templates.RestartNever}
+
+// RestartEvent is a restart of a container by podman, or the container reaching its restart limit
+type RestartEvent struct {
+	Time        time.Time `json:"time"`
+	Application string    `json:"application"`
+	Pod         string    `json:"pod"`
+	Container   string    `json:"container"`
+	ExitCode    int32     `json:"exitCode"`
+	// Restarts is the number of restarts of the container since it last ran for 10 minutes
+	Restarts    int `json:"restarts"`
+	MaxRestarts int `json:"maxRestarts"`
+	// LimitReached is set once the container exceeded MaxRestarts restarts, its pod is stopped
+	LimitReached bool `json:"limitReached,omitempty"`
+}
+
+// validateRestartPolicies checks the restart policies of the template refer to its pod templates. The policies read
+// from the values are checked once the values are loaded.
+func validateRestartPolicies(appMetadata *templates.AppMetadata, podTemplateFileNames []string) error {
+	for _, p := range appMetadata.RestartPolicies {
+		if !slices.Contains(podTemplateFileNames, p.PodTemplate) {
+			return fmt.Errorf("invalid restart policy: pod template %s not found", p.PodTemplate)
+		}
+		if p.Policy != "" && !slices.Contains(restartPolicies, p.Policy) {
+			return fmt.Errorf("invalid restart policy '%s' of pod template %s, supported: %v", p.Policy, p.PodTemplate, restartPolicies)
+		}
+		if p.MaxRestarts < 0 {
+			return fmt.Errorf("invalid max restarts %d of pod template %s", p.MaxRestarts, p.PodTemplate)
+		}
+	}
+	return nil
+}
+
+// injectRestartPolicy rewrites the restart policy of the rendered pod template as declared by the template, the value
+// of its PolicyValue taking precedence. The containers are restarted by podman, with a restart limit recorded in the
+// annotations for the watcher to stop the pod once a container exceeds it.
+func (cr *creator) injectRestartPolicy(manifest []byte, podTemplateName string, appMetadata *templates.AppMetadata) ([]byte, error) {
+	i := slices.IndexFunc(appMetadata.RestartPolicies, func(p templates.RestartPolicy) bool { return p.PodTemplate == podTemplateName })
+	if i < 0 {
+		return manifest, nil
+	}
+	policy := appMetadata.RestartPolicies[i]
+	if policy.PolicyValue != "" {
+		values := map[string]string{}
+		flattenValues("", cr.rendered.values, values)
+		if val := values[policy.PolicyValue]; val != "" {
+			if !slices.Contains(restartPolicies, val) {
+				return nil, fmt.Errorf("invalid restart policy '%s' of value %s, supported: %v", val, policy.PolicyValue, restartPolicies)
+			}
+			policy.Policy = val
+		}
+	}
+	if policy.Policy == "" && policy.MaxRestarts == 0 {
+		return manifest, nil
+	}
+
+	podSpec, err := specs.ParsePodSpec(manifest)
+	if err != nil {
+		return nil, err
+	}
+	if policy.Policy == "" {
+		policy.Policy = string(podSpec.Spec.RestartPolicy)
+	}
+	if policy.Policy == "" {
+		// the default of kube play
+		policy.Policy = templates.RestartAlways
+	}
+
+	podSpec.Spec.RestartPolicy = v1.RestartPolicy(policy.Policy)
+	if policy.MaxRestarts > 0 && policy.Policy != templates.RestartNever {
+		if podSpec.Annotations == nil {
+			podSpec.Annotations = map[string]string{}
+		}
+		podSpec.Annotations[constants.MaxRestartsAnnotationKey] = strconv.Itoa(policy.MaxRestarts)
+	}
+
+	return specs.MarshalPodSpec(podSpec)
+}
+
+// containerRestarts tracks the restarts of a container with a restart limit, persisted across the runs of the
+// watcher. The restarts are counted by podman, which resets its count when the container is started by hand.
+type containerRestarts struct {
+	// Baseline is the restart count of podman the restarts are counted from, moved forward once the container ran
+	// for 10 minutes
+	Baseline int `json:"baseline"`
+	// Reported is the restart count of podman last reported
+	Reported int `json:"reported"`
+	// LimitReached is set once the pod is stopped for the container exceeding its limit
+	LimitReached bool `json:"limitReached,omitempty"`
+}
+
+// WatchRestarts enforces the restart limits of the containers of all the applications until ctx is done: the pod of
+// a container restarted by podman more than its limit is stopped, and the container is recorded in the audit
+// history. The restarts of a container are forgotten once it runs for 10 minutes, or is started by hand.
+func (c *Client) WatchRestarts(ctx context.Context, interval time.Duration, report func(RestartEvent)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		apps, err := c.ListApplications(ctx)
+		if err != nil && ctx.Err() == nil {
+			logger.Warningf("failed to discover the containers to enforce the restart limits of: %v\n", err)
+		}
+		if err == nil {
+			var events []RestartEvent
+			tracked := map[string]*containerRestarts{}
+			err := state.Default().Update(restartsStateName, &tracked, func() error {
+				seen := map[string]bool{}
+				// the containers of the pods failing to inspect are kept
+				prune := true
+				for _, app := range apps {
+					for _, pod := range app.Pods {
+						podEvents, err := c.enforceRestarts(app.Name, pod, tracked, seen)
+						if err != nil {
+							prune = false
+							logger.Infof("Skipping the restart limits of pod %s: %v\n", pod.Name, err, 2)
+						}
+						events = append(events, podEvents...)
+					}
+				}
+				for id := range tracked {
+					if prune && !seen[id] {
+						delete(tracked, id)
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				logger.Warningf("failed to record the restarts of the containers: %v\n", err)
+			}
+			for _, event := range events {
+				report(event)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// enforceRestarts stops the pod once one of its containers with a restart limit exceeded it, returning the
+// restarts of its containers since they were last reported
+func (c *Client) enforceRestarts(appName string, pod Pod, tracked map[string]*containerRestarts, seen map[string]bool) ([]RestartEvent, error) {
+	info, err := c.runtime.InspectPod(pod.ID)
+	if err != nil {
+		return nil, err
+	}
+	var events []RestartEvent
+	for _, ctr := range info.Containers {
+		if ctr.ID == info.InfraContainerID {
+			continue
+		}
+		ctrInfo, err := c.runtime.InspectContainer(ctr.ID)
+		if err != nil || ctrInfo.Config == nil || ctrInfo.State == nil {
+			continue
+		}
+		// kube play sets the pod annotations on all its containers
+		maxRestarts, err := strconv.Atoi(ctrInfo.Config.Annotations[constants.MaxRestartsAnnotationKey])
+		if err != nil || maxRestarts <= 0 {
+			continue
+		}
+
+		seen[ctr.ID] = true
+		t, ok := tracked[ctr.ID]
+		if !ok {
+			t = &containerRestarts{}
+			tracked[ctr.ID] = t
+		}
+		count := int(ctrInfo.RestartCount)
+		ctrState := ctrInfo.State
+		if count < t.Baseline || t.LimitReached && ctrState.Status == "running" {
+			// started by hand, podman reset its restart count
+			*t = containerRestarts{}
+		}
+		if ctrState.Status == "running" && time.Since(ctrState.StartedAt) >= restartResetAfter {
+			t.Baseline, t.Reported = count, count
+			continue
+		}
+		if t.LimitReached || count == t.Reported {
+			continue
+		}
+		t.Reported = count
+
+		event := RestartEvent{Time: time.Now().UTC(), Application: appName, Pod: pod.Name, Container: containerOf(pod.Name, ctrInfo).Name,
+			ExitCode: ctrState.ExitCode, Restarts: count - t.Baseline, MaxRestarts: maxRestarts}
+		if event.Restarts > maxRestarts {
+			if err := c.runtime.StopPod(pod.ID); err != nil {
+				return events, fmt.Errorf("failed to stop pod %s: %w", pod.Name, err)
+			}
+			t.LimitReached = true
+			event.LimitReached = true
+			if err := audit.Record(audit.Entry{Application: appName, Action: "restart limit",
+				Details: fmt.Sprintf("container %s of pod %s exited with code %d after %d restarts, the pod is stopped", event.Container, pod.Name, ctrState.ExitCode, maxRestarts)}); err != nil {
+				logger.Warningf("failed to record the restart limit in the audit history: %v\n", err)
+			}
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
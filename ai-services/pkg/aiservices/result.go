@@ -0,0 +1,103 @@
+package aiservices
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/constants"
+	"github.com/project-ai-services/ai-services/internal/pkg/deploy"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/specs"
+)
+
+// Statuses of a deployment
+const (
+	DeploymentDeployed  = "deployed"
+	DeploymentFailed    = "failed"
+	DeploymentCancelled = "cancelled"
+)
+
+// PodResult is the outcome of the deployment of a pod template: its status (ready, failed, skipped once existing,
+// cancelled or pending once an earlier layer failed), the readiness of its containers and its assigned Spyre cards
+type PodResult = deploy.PodResult
+
+// ContainerResult is the readiness of a container of a pod
+type ContainerResult = deploy.ContainerResult
+
+// LayerResult is the outcome of the deployment of a layer of pod templates
+type LayerResult = deploy.LayerResult
+
+// DeploymentResult is the outcome of the deployment of an application. It is returned even once the deployment
+// failed, reporting the pods deployed before the failure and the pods not deployed.
+type DeploymentResult struct {
+	Application string `json:"application"`
+	Template    string `json:"template"`
+	Version     string `json:"version,omitempty"`
+	// Status is deployed, failed or cancelled
+	Status   string        `json:"status"`
+	Error    string        `json:"error,omitempty"`
+	Layers   []LayerResult `json:"layers,omitempty"`
+	Pods     []PodResult   `json:"pods,omitempty"`
+	Timings  []Timing      `json:"timings,omitempty"`
+	Warnings []string      `json:"warnings,omitempty"`
+}
+
+// result returns the outcome of the deployment failed with err, if not nil
+func (cr *creator) result(err error) *DeploymentResult {
+	res := &DeploymentResult{
+		Application: cr.opts.Name,
+		Template:    cr.opts.Template,
+		Status:      DeploymentDeployed,
+		Timings:     cr.timings.list(),
+		Warnings:    cr.warnings,
+	}
+	if cr.cache != nil && cr.cache.metadata != nil {
+		res.Version = cr.cache.metadata.Version
+	}
+	if cr.engine != nil {
+		engine := cr.engine.Result()
+		res.Layers, res.Pods = engine.Layers, engine.Pods
+		res.Warnings = append(res.Warnings, engine.Warnings...)
+	}
+	if err != nil {
+		res.Status, res.Error = DeploymentFailed, err.Error()
+		if errors.Is(err, context.Canceled) {
+			res.Status = DeploymentCancelled
+		}
+	}
+	return res
+}
+
+// warn logs the warning and records it in the result of the deployment
+func (cr *creator) warn(format string, args ...any) {
+	msg := fmt.Sprintf(format, args...)
+	logger.Warningf("%s\n", msg)
+	// the pods of a layer are deployed concurrently
+	cr.warningsMu.Lock()
+	defer cr.warningsMu.Unlock()
+	cr.warnings = append(cr.warnings, msg)
+}
+
+// spyreCardsOf returns the Spyre cards assigned to the containers of the rendered pod, recorded in its annotations
+func spyreCardsOf(body []byte) map[string][]string {
+	pod, _, err := specs.SplitPod(body)
+	if err != nil {
+		return nil
+	}
+	podSpec, err := specs.ParsePodSpec(pod)
+	if err != nil {
+		return nil
+	}
+	cards := map[string][]string{}
+	for key, val := range podSpec.Annotations {
+		if container, ok := strings.CutPrefix(key, constants.SpyreDevicesAnnotationPrefix); ok && val != "" {
+			cards[container] = strings.Split(val, ",")
+		}
+	}
+	if len(cards) == 0 {
+		return nil
+	}
+	return cards
+}
@@ -0,0 +1,90 @@
+package aiservices
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/templates"
+	"github.com/project-ai-services/ai-services/internal/pkg/deploy"
+	"github.com/project-ai-services/ai-services/internal/pkg/models"
+)
+
+// RetryPolicy retries the deployment of the pods failing on a transient error
+type RetryPolicy = deploy.RetryPolicy
+
+// defaultRetryPolicy applies to the templates declaring no retry policy
+var defaultRetryPolicy = deploy.DefaultRetryPolicy
+
+// retryPolicy returns the retry policy of the deployment: the options override the template, which overrides the
+// default policy
+func (cr *creator) retryPolicy(appMetadata *templates.AppMetadata) (RetryPolicy, error) {
+	if cr.opts.Retry != nil {
+		policy := *cr.opts.Retry
+		if policy.MaxBackoff == 0 {
+			policy.MaxBackoff = max(defaultRetryPolicy.MaxBackoff, policy.Backoff)
+		}
+		return policy, nil
+	}
+	if appMetadata == nil || appMetadata.Retry == nil {
+		return defaultRetryPolicy, nil
+	}
+
+	policy := RetryPolicy{Attempts: appMetadata.Retry.Attempts, Backoff: defaultRetryPolicy.Backoff, MaxBackoff: defaultRetryPolicy.MaxBackoff}
+	var err error
+	if val := appMetadata.Retry.Backoff; val != "" {
+		if policy.Backoff, err = time.ParseDuration(val); err != nil {
+			return policy, fmt.Errorf("invalid retry backoff '%s': %w", val, err)
+		}
+	}
+	if val := appMetadata.Retry.MaxBackoff; val != "" {
+		if policy.MaxBackoff, err = time.ParseDuration(val); err != nil {
+			return policy, fmt.Errorf("invalid retry max backoff '%s': %w", val, err)
+		}
+	}
+	return policy, nil
+}
+
+// deployPodWithRetry deploys the pod on the network of the application and checks its readiness, retrying with
+// backoff while the deployment fails on a transient error
+func (cr *creator) deployPodWithRetry(ctx context.Context, policy RetryPolicy, layer int, name string, podSpec *models.PodSpec,
+	body []byte, opts map[string]string) error {
+	opts, err := cr.withNetwork(name, podSpec.Name, opts)
+	if err != nil {
+		return err
+	}
+	return cr.deployer(policy).DeployPod(ctx, layer, deploy.Pod{
+		Template:   name,
+		Spec:       podSpec,
+		Manifest:   body,
+		Options:    opts,
+		SpyreCards: spyreCardsOf(body),
+	})
+}
+
+// deployer returns the deployment engine of the creator, recording the timings and reporting the progress of the
+// deployment. The engine is created once, so that its result covers all the pods deployed by the creator.
+func (cr *creator) deployer(policy RetryPolicy) *deploy.Deployer {
+	cr.engineOnce.Do(func() {
+		cr.engine = deploy.New(cr.runtime, deploy.Options{Retry: policy}, deploy.ObserverFunc(cr.observe))
+	})
+	return cr.engine
+}
+
+// observe records the timings of the deployment events and reports the progress of the layers
+func (cr *creator) observe(e deploy.Event) {
+	switch e.Kind {
+	case deploy.EventLayerStarted:
+		cr.progress.report(ProgressEvent{Stage: StageDeploy, Layer: e.Layer, Message: fmt.Sprintf("Executing layer %d", e.Layer)})
+	case deploy.EventLayerDeployed:
+		cr.timings.add(Timing{Stage: TimingLayer, Layer: e.Layer, Duration: e.Duration})
+	case deploy.EventLayerCompleted:
+		cr.progress.report(ProgressEvent{Stage: StageDeploy, Layer: e.Layer, Message: fmt.Sprintf("Layer %d completed", e.Layer)})
+	case deploy.EventPodCreated:
+		cr.timings.add(Timing{Stage: TimingKubePlay, Layer: e.Layer, Pod: e.Pod, Duration: e.Duration})
+	case deploy.EventPodReady:
+		cr.timings.add(Timing{Stage: TimingReadiness, Layer: e.Layer, Pod: e.Pod, Duration: e.Duration})
+	case deploy.EventPodRetry:
+		cr.progress.report(ProgressEvent{Stage: StageDeploy, Layer: e.Layer, Pod: e.PodTemplate, Message: fmt.Sprintf("Retrying pod %s in %s", e.Pod, e.Duration)})
+	}
+}
@@ -0,0 +1,417 @@
+package aiservices
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/audit"
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/templates"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/mounts"
+	"github.com/project-ai-services/ai-services/internal/pkg/specs"
+	"github.com/project-ai-services/ai-services/internal/pkg/state"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+)
+
+// revisionsStateName is the name of the state document holding the deployed revisions of the applications
+const revisionsStateName = "revisions"
+
+// MaxRevisions is the number of revisions kept per application, the oldest revisions are dropped first
+var MaxRevisions = 5
+
+// Outcomes of a revision
+const (
+	OutcomeDeployed   = "deployed"
+	OutcomeRolledBack = "rolled-back"
+	OutcomeFailed     = "failed"
+)
+
+// Revision is a deployment of an application, with the manifests as rendered and sent to kube play
+type Revision struct {
+	Number   int       `json:"number"`
+	Time     time.Time `json:"time"`
+	Template string    `json:"template"`
+	Version  string    `json:"version,omitempty"`
+	// Values are the template values the manifests were rendered with
+	Values map[string]any `json:"values,omitempty"`
+	// Layers are the pod templates in deployment order
+	Layers [][]string `json:"layers"`
+	// Manifests are the rendered manifests by pod template
+	Manifests map[string]string `json:"manifests"`
+	// Objects are the ConfigMaps and PersistentVolumeClaims declared along with the pods, by pod template
+	Objects map[string]string `json:"objects,omitempty"`
+	// DeployOptions are the kube play options by pod template
+	DeployOptions map[string]map[string]string `json:"deployOptions,omitempty"`
+	// RolledBackFrom is the revision number the application was rolled back from, if deployed by a rollback
+	RolledBackFrom int `json:"rolledBackFrom,omitempty"`
+	// Outcome is deployed, rolled-back (deployed by a rollback) or failed
+	Outcome string `json:"outcome"`
+	// Error is the deployment error of a failed revision
+	Error string `json:"error,omitempty"`
+}
+
+func (r *Revision) failed() bool {
+	return r.Outcome == OutcomeFailed
+}
+
+// lastDeployed returns the index of the most recent revision which is not failed, -1 if none
+func lastDeployed(history []Revision) int {
+	for i := len(history) - 1; i >= 0; i-- {
+		if !history[i].failed() {
+			return i
+		}
+	}
+	return -1
+}
+
+// renderedManifests collects the manifests rendered during a deployment, the pods of a layer being rendered
+// concurrently
+type renderedManifests struct {
+	mu        sync.Mutex
+	values    map[string]any
+	manifests map[string]string
+	objects   map[string]string
+	options   map[string]map[string]string
+}
+
+func (r *renderedManifests) add(podTemplate string, manifest, objects []byte, opts map[string]string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.manifests == nil {
+		r.manifests = map[string]string{}
+		r.objects = map[string]string{}
+		r.options = map[string]map[string]string{}
+	}
+	r.manifests[podTemplate] = string(manifest)
+	if len(objects) > 0 {
+		r.objects[podTemplate] = string(objects)
+	}
+	r.options[podTemplate] = opts
+}
+
+// revision returns the revision of the deployed manifests
+func (r *renderedManifests) revision(template, version string, layers [][]string) *Revision {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return &Revision{
+		Template:      template,
+		Version:       version,
+		Values:        r.values,
+		Layers:        layers,
+		Manifests:     utils.CopyMap(r.manifests),
+		Objects:       utils.CopyMap(r.objects),
+		DeployOptions: utils.CopyMap(r.options),
+	}
+}
+
+// recordRevision records the deployed manifests as a new revision of the application, numbering it. The manifests
+// of the pods skipped as already existing are taken over from the previous revision.
+func recordRevision(appName string, rev *Revision) error {
+	if rev.Manifests == nil {
+		rev.Manifests = map[string]string{}
+	}
+	if rev.DeployOptions == nil {
+		rev.DeployOptions = map[string]map[string]string{}
+	}
+	if rev.Objects == nil {
+		rev.Objects = map[string]string{}
+	}
+
+	revisions := map[string][]Revision{}
+	return state.Default().Update(revisionsStateName, &revisions, func() error {
+		history := revisions[appName]
+		if len(history) > 0 {
+			rev.Number = history[len(history)-1].Number + 1
+		} else {
+			rev.Number = 1
+		}
+		if i := lastDeployed(history); i >= 0 {
+			prev := history[i]
+			for tmpl, manifest := range prev.Manifests {
+				if _, ok := rev.Manifests[tmpl]; !ok {
+					rev.Manifests[tmpl] = manifest
+					rev.DeployOptions[tmpl] = prev.DeployOptions[tmpl]
+					if objects, ok := prev.Objects[tmpl]; ok {
+						rev.Objects[tmpl] = objects
+					}
+				}
+			}
+		}
+		rev.Time = time.Now().UTC()
+
+		history = append(history, *rev)
+		if len(history) > MaxRevisions {
+			history = history[len(history)-MaxRevisions:]
+		}
+		revisions[appName] = history
+		return nil
+	})
+}
+
+// releaseRevisions drops the revisions of the deleted application
+func releaseRevisions(appName string) error {
+	revisions := map[string][]Revision{}
+	return state.Default().Update(revisionsStateName, &revisions, func() error {
+		delete(revisions, appName)
+		return nil
+	})
+}
+
+// ListRevisions returns the revisions of the application kept in the state, oldest first
+func (c *Client) ListRevisions(ctx context.Context, appName string) ([]Revision, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	revisions := map[string][]Revision{}
+	if err := state.Default().Load(revisionsStateName, &revisions); err != nil {
+		return nil, err
+	}
+	return revisions[appName], nil
+}
+
+// RollbackOptions are the options to roll back an application
+type RollbackOptions struct {
+	// Revision to roll back to, defaults to the revision before the current one
+	Revision int `json:"revision,omitempty"`
+	// Progress receives the progress events of the redeployment
+	Progress ProgressFunc `json:"-"`
+}
+
+// Rollback redeploys the manifests of a previous revision of the application: the pods of the application are
+// removed and the manifests of the revision are played layer by layer, like create. The secrets, SMT level and
+// reserved resources of the application are kept. The rollback is recorded as a new revision.
+func (c *Client) Rollback(ctx context.Context, appName string, opts RollbackOptions) (*Revision, error) {
+	history, err := c.ListRevisions(ctx, appName)
+	if err != nil {
+		return nil, err
+	}
+	// failed revisions are never rolled back to
+	var deployed []Revision
+	for _, rev := range history {
+		if !rev.failed() {
+			deployed = append(deployed, rev)
+		}
+	}
+	if len(deployed) == 0 {
+		return nil, fmt.Errorf("no deployed revisions recorded for application %s", appName)
+	}
+	current := deployed[len(deployed)-1]
+
+	var target *Revision
+	if opts.Revision == 0 {
+		if len(deployed) < 2 {
+			return nil, fmt.Errorf("application %s has no previous revision to roll back to", appName)
+		}
+		target = &deployed[len(deployed)-2]
+	} else {
+		for i := range deployed {
+			if deployed[i].Number == opts.Revision {
+				target = &deployed[i]
+			}
+		}
+		if target == nil {
+			return nil, fmt.Errorf("revision %d of application %s not found or failed, %d most recent revisions are kept", opts.Revision, appName, MaxRevisions)
+		}
+	}
+	if target.Number == current.Number {
+		return nil, fmt.Errorf("revision %d is the current revision of application %s", target.Number, appName)
+	}
+
+	app, err := c.GetApplication(ctx, appName)
+	if err != nil && !errors.Is(err, ErrApplicationNotFound) {
+		return nil, err
+	}
+
+	logger.Infof("Rolling back application '%s' from revision %d to revision %d\n", appName, current.Number, target.Number, 0)
+	cr := &creator{
+		Client:   c,
+		opts:     CreateOptions{Name: appName, Template: target.Template},
+		progress: &progressReporter{fn: opts.Progress},
+		timings:  &timings{},
+	}
+
+	// ---- Remove the pods of the current revision ----
+	cr.progress.report(ProgressEvent{Stage: StagePrepare, Message: "Removing the pods of the current revision"})
+	if app != nil {
+		for _, pod := range app.Pods {
+			logger.Infof("Removing pod %s\n", pod.Name)
+			if err := c.drainPod(ctx, pod.ID); err != nil {
+				return nil, err
+			}
+			if err := c.runtime.DeletePod(pod.ID, utils.BoolPtr(true)); err != nil {
+				return nil, fmt.Errorf("failed to remove pod %s: %w", pod.Name, err)
+			}
+		}
+	}
+
+	rolledBack := *target
+	rolledBack.RolledBackFrom = current.Number
+	rolledBack.Outcome = OutcomeRolledBack
+	rolledBack.Manifests = utils.CopyMap(target.Manifests)
+	rolledBack.DeployOptions = utils.CopyMap(target.DeployOptions)
+	rolledBack.Objects = utils.CopyMap(target.Objects)
+
+	// ---- Redeploy the manifests of the target revision, layer by layer ----
+	if err := cr.redeploy(ctx, target); err != nil {
+		rolledBack.Outcome = OutcomeFailed
+		rolledBack.Error = err.Error()
+		if err := recordRevision(appName, &rolledBack); err != nil {
+			logger.Warningf("failed to record the revision of application '%s': %v\n", appName, err)
+		}
+		return nil, err
+	}
+
+	if err := recordRevision(appName, &rolledBack); err != nil {
+		logger.Warningf("failed to record the revision of application '%s': %v\n", appName, err)
+	}
+	if err := audit.Record(audit.Entry{Application: appName, Action: "rollback",
+		Details: fmt.Sprintf("from revision %d to revision %d", current.Number, target.Number)}); err != nil {
+		logger.Warningf("failed to record the rollback in the audit history: %v\n", err)
+	}
+
+	cr.progress.report(ProgressEvent{Stage: StageCompleted, Message: fmt.Sprintf("Application '%s' rolled back to revision %d", appName, target.Number), Timings: cr.timings.list()})
+
+	return &rolledBack, nil
+}
+
+// redeploy plays the manifests of the revision layer by layer, waiting for the readiness of each layer
+func (cr *creator) redeploy(ctx context.Context, rev *Revision) error {
+	// the wait conditions are best effort, the template of the revision may not be available anymore
+	appMetadata, err := cr.templates.LoadMetadata(rev.Template)
+	if err != nil {
+		logger.Warningf("Skipping the wait conditions of template %s: %v\n", rev.Template, err)
+		appMetadata = &templates.AppMetadata{}
+	}
+	retry, err := cr.retryPolicy(appMetadata)
+	if err != nil {
+		return err
+	}
+	deployedPodSpec := func(podTemplate string) (*models.PodSpec, error) {
+		manifest, ok := rev.Manifests[podTemplate]
+		if !ok {
+			return nil, fmt.Errorf("pod template %s is not part of revision %d", podTemplate, rev.Number)
+		}
+		return specs.ParsePodSpec([]byte(manifest))
+	}
+
+	for i, layer := range rev.Layers {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		cr.progress.report(ProgressEvent{Stage: StageDeploy, Layer: i + 1, Message: fmt.Sprintf("Executing layer %d", i+1)})
+		for _, podTemplateName := range layer {
+			manifest, ok := rev.Manifests[podTemplateName]
+			if !ok {
+				continue
+			}
+			podSpec, err := specs.ParsePodSpec([]byte(manifest))
+			if err != nil {
+				return fmt.Errorf("revision %d: %w", rev.Number, err)
+			}
+			logger.Infof("Deploying pod %s of revision %d\n", podSpec.Name, rev.Number, 0)
+			if err := mounts.Relabel(podSpec); err != nil {
+				return err
+			}
+			body := withObjects([]byte(rev.Objects[podTemplateName]), []byte(manifest))
+			if err := cr.deployPodWithRetry(ctx, retry, i+1, podTemplateName, podSpec, body, rev.DeployOptions[podTemplateName]); err != nil {
+				return fmt.Errorf("layer %d: %w", i+1, err)
+			}
+		}
+		if err := cr.awaitConditions(ctx, i+1, appMetadata, deployedPodSpec); err != nil {
+			return fmt.Errorf("layer %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// RevisionSummary is a revision of the application without its manifests, along with the changes of its values
+// compared to the previous revision
+type RevisionSummary struct {
+	Number         int       `json:"number"`
+	Time           time.Time `json:"time"`
+	Template       string    `json:"template"`
+	Version        string    `json:"version,omitempty"`
+	Outcome        string    `json:"outcome"`
+	Error          string    `json:"error,omitempty"`
+	RolledBackFrom int       `json:"rolledBackFrom,omitempty"`
+	Pods           int       `json:"pods"`
+	// ValuesDiff lists the values added (+key=value), removed (-key) or changed (~key: old -> new)
+	ValuesDiff []string `json:"valuesDiff,omitempty"`
+}
+
+// History returns the revisions of the application, oldest first
+func (c *Client) History(ctx context.Context, appName string) ([]RevisionSummary, error) {
+	history, err := c.ListRevisions(ctx, appName)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]RevisionSummary, 0, len(history))
+	var prevValues map[string]any
+	for i, rev := range history {
+		outcome := rev.Outcome
+		if outcome == "" {
+			outcome = OutcomeDeployed
+		}
+		summary := RevisionSummary{
+			Number:         rev.Number,
+			Time:           rev.Time,
+			Template:       rev.Template,
+			Version:        rev.Version,
+			Outcome:        outcome,
+			Error:          rev.Error,
+			RolledBackFrom: rev.RolledBackFrom,
+			Pods:           len(rev.Manifests),
+		}
+		if i > 0 {
+			summary.ValuesDiff = diffValues(prevValues, rev.Values)
+		}
+		prevValues = rev.Values
+		summaries = append(summaries, summary)
+	}
+	return summaries, nil
+}
+
+// diffValues compares the values as flattened dotted keys
+func diffValues(oldValues, newValues map[string]any) []string {
+	oldFlat, newFlat := map[string]string{}, map[string]string{}
+	flattenValues("", oldValues, oldFlat)
+	flattenValues("", newValues, newFlat)
+
+	var diff []string
+	for _, key := range slices.Sorted(maps.Keys(newFlat)) {
+		oldVal, ok := oldFlat[key]
+		switch {
+		case !ok:
+			diff = append(diff, fmt.Sprintf("+%s=%s", key, newFlat[key]))
+		case oldVal != newFlat[key]:
+			diff = append(diff, fmt.Sprintf("~%s: %s -> %s", key, oldVal, newFlat[key]))
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(oldFlat)) {
+		if _, ok := newFlat[key]; !ok {
+			diff = append(diff, "-"+key)
+		}
+	}
+	return diff
+}
+
+func flattenValues(prefix string, values map[string]any, out map[string]string) {
+	for key, val := range values {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		if nested, ok := val.(map[string]any); ok {
+			flattenValues(key, nested, out)
+			continue
+		}
+		out[key] = fmt.Sprint(val)
+	}
+}
@@ -0,0 +1,127 @@
+package aiservices
+
+import (
+	"context"
+	"fmt"
+	"syscall"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/podman"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+// kubePlayFlags are the flags of 'podman kube play' the deployments rely on
+var kubePlayFlags = []string{"start", "publish", "replace", "annotation"}
+
+// requiredKubePlayFlags are the kube play flags without which the applications cannot be deployed
+var requiredKubePlayFlags = []string{"start", "publish"}
+
+// RuntimeInfo describes the podman runtime the CLI drives. The information which cannot be collected is left empty
+// and reported in Errors.
+type RuntimeInfo struct {
+	// Connection is the URI of the resolved podman socket
+	Connection string `json:"connection"`
+	Connected  bool   `json:"connected"`
+	// Remote is set when the podman service runs on another host than the CLI
+	Remote        bool   `json:"remote"`
+	Rootless      bool   `json:"rootless"`
+	ServerVersion string `json:"serverVersion,omitempty"`
+	APIVersion    string `json:"apiVersion,omitempty"`
+	// ClientVersion is the version of the local podman CLI, which plays the kube manifests
+	ClientVersion string `json:"clientVersion,omitempty"`
+	StorageDriver string `json:"storageDriver,omitempty"`
+	GraphRoot     string `json:"graphRoot,omitempty"`
+	// DiskTotal and DiskFree are the size and the available space of the filesystem of the graph root, in bytes
+	DiskTotal int64    `json:"diskTotal,omitempty"`
+	DiskFree  int64    `json:"diskFree,omitempty"`
+	KubePlay  KubePlay `json:"kubePlay"`
+	Errors    []string `json:"errors,omitempty"`
+}
+
+// KubePlay describes the kube play capabilities of the local podman CLI
+type KubePlay struct {
+	Available bool `json:"available"`
+	// Flags reports whether each of the kube play flags used by the deployments is supported
+	Flags map[string]bool `json:"flags,omitempty"`
+}
+
+// Healthy reports whether the applications can be deployed with the runtime: podman is reachable and its kube play
+// supports the required flags
+func (r *RuntimeInfo) Healthy() bool {
+	if !r.Connected || !r.KubePlay.Available {
+		return false
+	}
+	for _, flag := range requiredKubePlayFlags {
+		if !r.KubePlay.Flags[flag] {
+			return false
+		}
+	}
+	return true
+}
+
+// InspectRuntime connects to the resolved podman socket and describes the runtime. A failed connection is reported
+// in the returned info rather than as an error, as the info is the first troubleshooting step.
+func InspectRuntime(ctx context.Context) (*RuntimeInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	info := &RuntimeInfo{Connection: podman.ConnectionURI()}
+	failed := func(what string, err error) {
+		info.Errors = append(info.Errors, fmt.Sprintf("%s: %v", what, err))
+	}
+
+	var err error
+	if info.ClientVersion, err = podman.CLIVersion(); err != nil {
+		failed("client", err)
+	}
+	if info.KubePlay.Flags, err = podman.KubePlayFlags(kubePlayFlags); err != nil {
+		failed("kube play", err)
+	} else {
+		info.KubePlay.Available = true
+	}
+
+	runtimeClient, err := podman.NewPodmanClient()
+	if err != nil {
+		info.Rootless = vars.Rootless
+		failed("connection", err)
+		return info, nil
+	}
+	info.Connected = true
+	inspectServer(runtimeClient, info, failed)
+
+	return info, nil
+}
+
+// inspectServer fills the info reported by the podman service
+func inspectServer(rt runtime.Runtime, info *RuntimeInfo, failed func(string, error)) {
+	serverInfo, err := rt.Info()
+	if err != nil {
+		failed("server", err)
+		return
+	}
+	info.ServerVersion = serverInfo.Version.Version
+	info.APIVersion = serverInfo.Version.APIVersion
+	if host := serverInfo.Host; host != nil {
+		info.Remote = host.ServiceIsRemote
+		info.Rootless = host.Security.Rootless
+	}
+	store := serverInfo.Store
+	if store == nil {
+		return
+	}
+	info.StorageDriver = store.GraphDriverName
+	info.GraphRoot = store.GraphRoot
+
+	// the local filesystem is more accurate than the allocation reported by the service
+	var fs syscall.Statfs_t
+	if !info.Remote && syscall.Statfs(store.GraphRoot, &fs) == nil {
+		info.DiskTotal = int64(fs.Blocks) * fs.Bsize
+		info.DiskFree = int64(fs.Bavail) * fs.Bsize
+		return
+	}
+	if store.GraphRootAllocated > 0 {
+		info.DiskTotal = int64(store.GraphRootAllocated)
+		info.DiskFree = int64(store.GraphRootAllocated - min(store.GraphRootUsed, store.GraphRootAllocated))
+	}
+}
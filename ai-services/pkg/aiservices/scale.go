@@ -0,0 +1,379 @@
+package aiservices
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/containers/podman/v5/pkg/domain/entities/types"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/audit"
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/helpers"
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/templates"
+	"github.com/project-ai-services/ai-services/internal/pkg/constants"
+	"github.com/project-ai-services/ai-services/internal/pkg/gateway"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/mounts"
+	"github.com/project-ai-services/ai-services/internal/pkg/specs"
+	"github.com/project-ai-services/ai-services/internal/pkg/state"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+// ScaleOptions are the options to scale a component of an application
+type ScaleOptions struct {
+	// Component is the scalable component declared by the metadata of the template
+	Component string `json:"component"`
+	// Replicas is the number of pods of the component, including the pod deployed by create
+	Replicas int `json:"replicas"`
+}
+
+// ScaleResult is the outcome of scaling a component
+type ScaleResult struct {
+	Application string `json:"application"`
+	Component   string `json:"component"`
+	Replicas    int    `json:"replicas"`
+	// Added and Removed are the replica pods deployed and deleted
+	Added   []string `json:"added,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+}
+
+// replica is a pod deployed by scale, along with its replica number
+type replica struct {
+	number int
+	pod    string
+}
+
+// Scale deploys or deletes the replicas of a scalable component of the application, so that the component runs
+// opts.Replicas pods. The replicas are rendered from the pod template of the component with the values of the last
+// deployed revision, with their own name and Spyre cards, and their ports are published on random host ports.
+// The gateway is reloaded to balance the routed endpoints across the replicas.
+func (c *Client) Scale(ctx context.Context, appName string, opts ScaleOptions) (*ScaleResult, error) {
+	if opts.Replicas < 1 {
+		return nil, fmt.Errorf("invalid replicas %d, must be at least 1", opts.Replicas)
+	}
+	rev, appMetadata, err := c.deployedRevision(ctx, appName)
+	if err != nil {
+		return nil, err
+	}
+	component, err := scalableComponent(appMetadata, opts.Component)
+	if err != nil {
+		return nil, err
+	}
+	if component.MaxReplicas > 0 && opts.Replicas > component.MaxReplicas {
+		return nil, fmt.Errorf("component %s is limited to %d replicas", component.Component, component.MaxReplicas)
+	}
+
+	manifest, ok := rev.Manifests[component.PodTemplate]
+	if !ok {
+		return nil, fmt.Errorf("pod template %s of component %s is not deployed", component.PodTemplate, component.Component)
+	}
+	base, err := specs.ParsePodSpec([]byte(manifest))
+	if err != nil {
+		return nil, fmt.Errorf("revision %d: %w", rev.Number, err)
+	}
+
+	replicas, err := c.listReplicas(appName, component.Component)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ScaleResult{Application: appName, Component: component.Component, Replicas: opts.Replicas}
+	current := len(replicas) + 1
+	switch {
+	case opts.Replicas > current:
+		result.Added, err = c.addReplicas(ctx, appName, rev, appMetadata, component, base, replicas, opts.Replicas-current)
+	case opts.Replicas < current:
+		result.Removed, err = c.removeReplicas(ctx, appName, replicas[opts.Replicas-1:], podResources(base.Spec))
+	default:
+		logger.Infof("Component %s of application '%s' already runs %d replicas\n", component.Component, appName, current, 0)
+		return result, nil
+	}
+	// record the replicas changed before a failure
+	if len(result.Added) > 0 || len(result.Removed) > 0 {
+		if err := audit.Record(audit.Entry{Application: appName, Action: "scale",
+			Details: fmt.Sprintf("%s from %d to %d replicas", component.Component, current, current+len(result.Added)-len(result.Removed))}); err != nil {
+			logger.Warningf("failed to record the scaling in the audit history: %v\n", err)
+		}
+		if err := reloadGateway(c, appName); err != nil {
+			logger.Warningf("failed to reload the gateway: %v\n", err)
+		}
+	}
+	if err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// deployedRevision returns the last deployed revision of the application, along with the metadata of its template
+func (c *Client) deployedRevision(ctx context.Context, appName string) (*Revision, *templates.AppMetadata, error) {
+	if _, err := c.GetApplication(ctx, appName); err != nil {
+		return nil, nil, err
+	}
+
+	history, err := c.ListRevisions(ctx, appName)
+	if err != nil {
+		return nil, nil, err
+	}
+	i := lastDeployed(history)
+	if i < 0 {
+		return nil, nil, fmt.Errorf("no deployed revision found for application '%s'", appName)
+	}
+	rev := history[i]
+
+	appMetadata, err := c.templates.LoadMetadata(rev.Template)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read the app metadata: %w", err)
+	}
+	return &rev, appMetadata, nil
+}
+
+// scalableComponent returns the scalable component declared by the metadata
+func scalableComponent(appMetadata *templates.AppMetadata, name string) (*templates.ScalableComponent, error) {
+	var names []string
+	for _, s := range appMetadata.Scalable {
+		if s.Component == name {
+			return &s, nil
+		}
+		names = append(names, s.Component)
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("template %s has no scalable component", appMetadata.Name)
+	}
+	return nil, fmt.Errorf("component %s is not scalable, scalable components: %s", name, strings.Join(names, ", "))
+}
+
+// listReplicas returns the replica pods of the component, sorted by replica number
+func (c *Client) listReplicas(appName, component string) ([]replica, error) {
+	resp, err := c.runtime.ListPods(map[string][]string{
+		"label": {fmt.Sprintf("ai-services.io/application=%s", appName), fmt.Sprintf("%s=%s", vars.ComponentLabel, component)},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	var pods []*types.ListPodsReport
+	if val, ok := resp.([]*types.ListPodsReport); ok {
+		pods = val
+	}
+
+	var replicas []replica
+	for _, pod := range pods {
+		n, err := strconv.Atoi(pod.Labels[string(vars.ReplicaLabel)])
+		if err != nil {
+			continue
+		}
+		replicas = append(replicas, replica{number: n, pod: pod.Name})
+	}
+	sort.Slice(replicas, func(i, j int) bool { return replicas[i].number < replicas[j].number })
+	return replicas, nil
+}
+
+// addReplicas renders and deploys count replicas of the component, numbered after the existing ones. Each replica
+// is deployed once the previous one is ready.
+func (c *Client) addReplicas(ctx context.Context, appName string, rev *Revision, appMetadata *templates.AppMetadata,
+	component *templates.ScalableComponent, base *models.PodSpec, existing []replica, count int) ([]string, error) {
+	podTemplateName := component.PodTemplate
+	if len(appMetadata.PinnedContainers(podTemplateName)) > 0 {
+		logger.Warningf("The replicas of component %s are not pinned to dedicated cores\n", component.Component)
+	}
+
+	tmpls, err := c.templates.LoadAllTemplates(rev.Template + "/templates")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the templates: %w", err)
+	}
+	tmpl, ok := tmpls[podTemplateName]
+	if !ok {
+		return nil, fmt.Errorf("pod template %s not found in template %s", podTemplateName, rev.Template)
+	}
+
+	// the host paths of the deployed pod were allowed when it was created
+	allowed, err := mounts.Allowed()
+	if err != nil {
+		return nil, err
+	}
+	cr := &creator{
+		Client:   c,
+		opts:     CreateOptions{Name: appName, Template: rev.Template, AllowedHostPaths: append(allowed, mounts.HostPaths(base)...)},
+		progress: &progressReporter{},
+		cpusets:  map[string]map[string]string{},
+		timings:  &timings{},
+	}
+	retry, err := cr.retryPolicy(appMetadata)
+	if err != nil {
+		return nil, err
+	}
+
+	facts, err := c.Facts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	globalParams := map[string]any{
+		"AppName":         appName,
+		"AppTemplateName": appMetadata.Name,
+		"Version":         appMetadata.Version,
+		"Values":          rev.Values,
+		"Facts":           facts,
+	}
+
+	// the Spyre cards of the replicas are assigned upfront, like the cards of the pods deployed by create
+	_, spyreCardContainerMap, err := fetchSpyreCardsFromPodAnnotations(base.Annotations)
+	if err != nil {
+		return nil, err
+	}
+	perReplica := 0
+	for _, n := range spyreCardContainerMap {
+		perReplica += n
+	}
+	var pciAddresses []string
+	if perReplica > 0 && vars.Rootless {
+		logger.Warningf("Rootless mode: the Spyre cards required by the replicas are not passed through, the containers requiring them run without accelerator\n")
+	} else if perReplica > 0 {
+		if pciAddresses, err = helpers.FindFreeSpyreCards(c.runtime); err != nil {
+			return nil, fmt.Errorf("failed to find free Spyre Cards: %w", err)
+		}
+		if err := validateSpyreCardRequirements(perReplica*count, len(pciAddresses)); err != nil {
+			return nil, err
+		}
+	}
+
+	baseName := base.Name
+	next := 2
+	if len(existing) > 0 {
+		next = existing[len(existing)-1].number + 1
+	}
+
+	var added []string
+	for i := range count {
+		if err := ctx.Err(); err != nil {
+			return added, err
+		}
+		number := next + i
+
+		assignments := map[string][]string{}
+		if !vars.Rootless {
+			for _, container := range slices.Sorted(maps.Keys(spyreCardContainerMap)) {
+				if n := spyreCardContainerMap[container]; n > 0 {
+					assignments[container], pciAddresses = pciAddresses[:n], pciAddresses[n:]
+				}
+			}
+		}
+
+		rendered, objects, err := cr.renderPod(podTemplateName, tmpl, globalParams, base, assignments, appMetadata)
+		if err != nil {
+			return added, err
+		}
+		// the replicas share the claims of the first pod, only the ConfigMaps are played along with them
+		if objects, err = specs.FilterObjects(objects, specs.KindConfigMap); err != nil {
+			return added, err
+		}
+		podSpec, err := specs.ParsePodSpec(rendered)
+		if err != nil {
+			return added, err
+		}
+		podSpec.Name = fmt.Sprintf("%s-%d", baseName, number)
+		if podSpec.Labels == nil {
+			podSpec.Labels = map[string]string{}
+		}
+		podSpec.Labels[string(vars.ComponentLabel)] = component.Component
+		podSpec.Labels[string(vars.ReplicaLabel)] = strconv.Itoa(number)
+		// the replicas are published on random host ports, not on the host ports assigned to the first pod
+		delete(podSpec.Annotations, constants.PodAutoPortsAnnotationKey)
+		if rendered, err = specs.MarshalPodSpec(podSpec); err != nil {
+			return added, err
+		}
+
+		if err := cr.admit(ctx, podSpec); err != nil {
+			return added, err
+		}
+		if err := mounts.Relabel(podSpec); err != nil {
+			return added, err
+		}
+		logger.Infof("Deploying replica %d of component %s: %s\n", number, component.Component, podSpec.Name, 0)
+		deployOpts := replicaDeployOptions(constructPodDeployOptions(fetchPodAnnotations(podSpec)))
+		if err := cr.deployPodWithRetry(ctx, retry, 0, podTemplateName, podSpec, withObjects(objects, rendered), deployOpts); err != nil {
+			return added, fmt.Errorf("replica %d of component %s: %w", number, component.Component, err)
+		}
+		added = append(added, podSpec.Name)
+
+		if err := reserveReplicaResources(appName, podResources(podSpec.Spec)); err != nil {
+			logger.Warningf("failed to record the resources of replica %s: %v\n", podSpec.Name, err)
+		}
+	}
+
+	return added, nil
+}
+
+// removeReplicas drains and deletes the replica pods, the highest replica first, releasing the resources of each
+// replica
+func (c *Client) removeReplicas(ctx context.Context, appName string, replicas []replica, res Resources) ([]string, error) {
+	released := Resources{MilliCPU: -res.MilliCPU, Memory: -res.Memory}
+	var removed []string
+	for i := len(replicas) - 1; i >= 0; i-- {
+		pod := replicas[i].pod
+		logger.Infof("Deleting replica %d: %s\n", replicas[i].number, pod, 0)
+		if err := c.drainPod(ctx, pod); err != nil {
+			return removed, err
+		}
+		if err := c.runtime.DeletePod(pod, utils.BoolPtr(true)); err != nil {
+			return removed, fmt.Errorf("failed to delete replica %s: %w", pod, err)
+		}
+		removed = append(removed, pod)
+
+		if err := reserveReplicaResources(appName, released); err != nil {
+			logger.Warningf("failed to release the resources of replica %s: %v\n", pod, err)
+		}
+	}
+	return removed, nil
+}
+
+// replicaDeployOptions publishes the ports of a replica on random host ports, the host ports of the template being
+// taken by the pod deployed by create
+func replicaDeployOptions(opts map[string]string) map[string]string {
+	opts = utils.CopyMap(opts)
+	var publish []string
+	for p := range strings.SplitSeq(opts["publish"], ",") {
+		if p == "" {
+			continue
+		}
+		containerPort := p[strings.LastIndex(p, ":")+1:]
+		// the local ports stay on the loopback interface
+		if strings.HasPrefix(p, loopbackAddress+":") {
+			containerPort = loopbackAddress + "::" + containerPort
+		}
+		publish = append(publish, containerPort)
+	}
+	opts["publish"] = strings.Join(publish, ",")
+	return opts
+}
+
+// reserveReplicaResources adds the resources of a replica to the resources reserved by the application, negative
+// resources release them
+func reserveReplicaResources(appName string, res Resources) error {
+	reservations := map[string]Resources{}
+	return state.Default().Update(resourcesStateName, &reservations, func() error {
+		total := reservations[appName]
+		total.add(res)
+		reservations[appName] = total
+		return nil
+	})
+}
+
+// reloadGateway reloads the gateway if it routes to an endpoint of the application
+func reloadGateway(c *Client, appName string) error {
+	cfg, err := gateway.Load()
+	if err != nil {
+		return err
+	}
+	if !slices.ContainsFunc(cfg.Routes, func(r gateway.Route) bool { return r.Application == appName }) {
+		return nil
+	}
+	logger.Infof("Reloading the gateway to route to the replicas of application '%s'\n", appName)
+	return gateway.Reload(c.runtime, cfg)
+}
@@ -0,0 +1,232 @@
+package aiservices
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/audit"
+	"github.com/project-ai-services/ai-services/internal/pkg/certs"
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/helpers"
+	"github.com/project-ai-services/ai-services/internal/pkg/constants"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/specs"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+// RotateSecretOptions are the options to rotate a podman secret
+type RotateSecretOptions struct {
+	// Data are the keys of the secret to update, the other keys are kept
+	Data map[string][]byte
+	// Remove are the keys to remove from the secret
+	Remove []string
+	// ReadinessTimeout is the time to wait for a restarted container to be ready before restarting the next one
+	ReadinessTimeout time.Duration
+}
+
+// RotatedSecret is the result of a secret rotation
+type RotatedSecret struct {
+	Secret string `json:"secret"`
+	// Keys are the keys of the secret after the rotation
+	Keys []string `json:"keys"`
+	// Restarted are the containers restarted to pick up the rotated secret
+	Restarted []string `json:"restarted,omitempty"`
+}
+
+// injectSecretRefs records the podman secrets referenced by each container of the pod, either as env or as a
+// mounted secret volume, with the 'ai-services.io/secrets/<container>' annotation
+func injectSecretRefs(manifest []byte) ([]byte, error) {
+	podSpec, err := specs.ParsePodSpec(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	volumes := map[string]string{}
+	for _, volume := range podSpec.Spec.Volumes {
+		if volume.Secret != nil {
+			volumes[volume.Name] = volume.Secret.SecretName
+		}
+	}
+
+	refs := map[string][]string{}
+	for _, container := range podSpec.Spec.Containers {
+		var secrets []string
+		for _, env := range container.Env {
+			if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil {
+				secrets = append(secrets, env.ValueFrom.SecretKeyRef.Name)
+			}
+		}
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.SecretRef != nil {
+				secrets = append(secrets, envFrom.SecretRef.Name)
+			}
+		}
+		for _, mount := range container.VolumeMounts {
+			if secret, ok := volumes[mount.Name]; ok {
+				secrets = append(secrets, secret)
+			}
+		}
+		if len(secrets) > 0 {
+			secrets = utils.UniqueSlice(secrets)
+			sort.Strings(secrets)
+			refs[container.Name] = secrets
+		}
+	}
+	if len(refs) == 0 {
+		return manifest, nil
+	}
+
+	if podSpec.Annotations == nil {
+		podSpec.Annotations = map[string]string{}
+	}
+	for container, secrets := range refs {
+		podSpec.Annotations[constants.SecretsAnnotationPrefix+container] = strings.Join(secrets, ",")
+	}
+
+	return specs.MarshalPodSpec(podSpec)
+}
+
+// RotateSecret updates the keys of the podman secret, and performs a rolling restart of the containers referencing
+// it: each container is restarted once the previous one is ready again, so that the applications keep serving from
+// their other containers during the rotation. Returns the restarted containers.
+func (c *Client) RotateSecret(ctx context.Context, name string, opts RotateSecretOptions) (*RotatedSecret, error) {
+	if len(opts.Data) == 0 && len(opts.Remove) == 0 {
+		return nil, errors.New("no secret keys provided")
+	}
+
+	exists, err := c.runtime.SecretExists(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check if secret exists: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("secret %s not found", name)
+	}
+	labels, err := c.runtime.SecretLabels(name)
+	if err != nil {
+		return nil, err
+	}
+	if labels[string(vars.ManagedLabel)] != "true" {
+		return nil, fmt.Errorf("secret %s is not managed by ai-services", name)
+	}
+
+	data, err := c.runtime.SecretData(name)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := specs.ParseSecret(data)
+	if err != nil {
+		return nil, err
+	}
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	maps.Copy(secret.Data, opts.Data)
+	for _, key := range opts.Remove {
+		delete(secret.Data, key)
+	}
+	if len(secret.Data) == 0 {
+		return nil, fmt.Errorf("secret %s would be left without keys", name)
+	}
+
+	// kube play fails to mount a TLS secret without a valid certificate and key pair
+	if secret.Type == specs.SecretTypeTLS {
+		if _, err := tls.X509KeyPair(secret.Data[certs.CertFile], secret.Data[certs.KeyFile]); err != nil {
+			return nil, fmt.Errorf("invalid certificate/key pair: %w", err)
+		}
+	}
+
+	// find the consumers before replacing the secret, so that a failure leaves the secret untouched
+	consumers, err := c.secretConsumers(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := specs.MarshalSecret(name, secret.Type, secret.Data)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.runtime.CreateSecret(name, out, labels); err != nil {
+		return nil, err
+	}
+
+	keys := utils.ExtractMapKeys(secret.Data)
+	sort.Strings(keys)
+	result := &RotatedSecret{Secret: name, Keys: keys}
+
+	// the values are never recorded
+	changed := append(utils.ExtractMapKeys(opts.Data), opts.Remove...)
+	sort.Strings(changed)
+	if err := audit.Record(audit.Entry{Application: labels["ai-services.io/application"], Action: "secret rotate",
+		Details: fmt.Sprintf("%s keys %s", name, strings.Join(changed, ","))}); err != nil {
+		logger.Warningf("failed to record the secret rotation in the audit history: %v\n", err)
+	}
+
+	if len(consumers) == 0 {
+		logger.Infof("No container references secret %s, the secret is picked up once a container references it\n", name)
+		return result, nil
+	}
+
+	for _, container := range consumers {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		logger.Infof("Restarting container %s to pick up secret %s\n", container, name)
+		if err := c.runtime.RestartContainer(container); err != nil {
+			return result, fmt.Errorf("failed to restart container %s: %w", container, err)
+		}
+		result.Restarted = append(result.Restarted, container)
+
+		// stop the rollout on a container not coming back, the remaining ones keep the previous secret
+		if err := helpers.WaitForContainerReadiness(c.runtime, container, opts.ReadinessTimeout); err != nil {
+			return result, fmt.Errorf("container %s is not ready after the restart, the remaining containers were not restarted: %w", container, err)
+		}
+	}
+
+	return result, nil
+}
+
+// secretConsumers returns the containers (<pod>-<container>) of the applications referencing the secret
+func (c *Client) secretConsumers(ctx context.Context, name string) ([]string, error) {
+	apps, err := c.ListApplications(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var consumers []string
+	for _, app := range apps {
+		for _, pod := range app.Pods {
+			info, err := c.runtime.InspectPod(pod.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to inspect pod %s: %w", pod.Name, err)
+			}
+			for _, ctr := range info.Containers {
+				if ctr.ID == info.InfraContainerID {
+					continue
+				}
+				ctrInfo, err := c.runtime.InspectContainer(ctr.ID)
+				if err != nil {
+					return nil, fmt.Errorf("failed to inspect container %s: %w", ctr.Name, err)
+				}
+				if ctrInfo.Config == nil {
+					continue
+				}
+				// kube play names the containers <pod>-<container>, and sets the pod annotations on all of them
+				container := strings.TrimPrefix(ctrInfo.Name, pod.Name+"-")
+				refs := strings.Split(ctrInfo.Config.Annotations[constants.SecretsAnnotationPrefix+container], ",")
+				if slices.Contains(refs, name) {
+					consumers = append(consumers, ctrInfo.Name)
+				}
+			}
+		}
+	}
+	sort.Strings(consumers)
+
+	return consumers, nil
+}
@@ -0,0 +1,260 @@
+package aiservices
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/platform"
+	"github.com/project-ai-services/ai-services/internal/pkg/state"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+// smtStateName is the name of the state document tracking the SMT level changes
+const smtStateName = "smt"
+
+// SMTState tracks the SMT level the host had before ai-services changed it, and the SMT level required
+// by each deployed application
+type SMTState struct {
+	// Original is the SMT level of the host before the first change, 0 if the host was never changed
+	Original int `json:"original,omitempty"`
+	// Applications maps the deployed applications to the SMT level they require
+	Applications map[string]int `json:"applications,omitempty"`
+}
+
+// applySMTLevel sets the SMT level of the host and verifies it
+func applySMTLevel(smt platform.SMT, level int) error {
+	if err := smt.Set(level); err != nil {
+		return err
+	}
+	return smt.Verify(level)
+}
+
+func (cr *creator) setSMTLevel() error {
+
+	/*
+		1. Fetch current SMT level
+		2. Fetch the target SMT level
+		3. Refuse the level conflicting with the deployed applications
+		4. Check if SMT level is already set to target value
+		5. If not, set it to target value, verify it and record the original SMT level
+	*/
+
+	// 1. Fetch Current SMT level
+	currentSMTlevel, err := cr.smt.Get()
+	if err != nil {
+		return fmt.Errorf("failed to get current SMT level: %w", err)
+	}
+
+	// 2. Fetch the target SMT level
+	targetSMTLevel, err := cr.getTargetSMTLevel()
+	if err != nil {
+		return fmt.Errorf("failed to get target SMT level: %w", err)
+	}
+
+	if targetSMTLevel == nil {
+		// No SMT level specified in metadata.yaml
+		logger.Infof("No SMT level specified in metadata.yaml. Keeping it to current level: %d\n", currentSMTlevel, 0)
+		return nil
+	}
+	// the requirement of the application is recorded once deployed, see recordSMTRequirement
+	cr.smtLevel = targetSMTLevel
+
+	// 3. Changing the SMT level degrades the deployed applications requiring another level, refuse unless forced
+	var st SMTState
+	if err := state.Default().Load(smtStateName, &st); err != nil {
+		return fmt.Errorf("failed to read SMT state: %w", err)
+	}
+	if conflicts := cr.smtConflicts(&st, *targetSMTLevel); len(conflicts) > 0 {
+		msg := fmt.Sprintf("template %s requires SMT level %d, conflicting with the deployed applications: %s",
+			cr.opts.Template, *targetSMTLevel, strings.Join(conflicts, ", "))
+		if !cr.opts.ForceSMTLevel {
+			return fmt.Errorf("%s. Use --force-smt to change the SMT level anyway", msg)
+		}
+		logger.Warningf("%s. Changing the SMT level anyway as forced\n", msg)
+	}
+
+	// 4. Check if SMT level is already set to target value
+	if currentSMTlevel == *targetSMTLevel {
+		// already set
+		logger.Infof("SMT level is already set to %d\n", *targetSMTLevel, 0)
+		return nil
+	}
+
+	// 5. Set SMT level to target value and verify it, then record the original level, so that it is restored once
+	// the applications are deleted
+	logger.Infof("Changing SMT level from %d to %d\n", currentSMTlevel, *targetSMTLevel, 0)
+	if err := applySMTLevel(cr.smt, *targetSMTLevel); err != nil {
+		return err
+	}
+	if err := state.Default().Update(smtStateName, &st, func() error {
+		if st.Original == 0 {
+			st.Original = currentSMTlevel
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to record the original SMT level %d: %w", currentSMTlevel, err)
+	}
+	return nil
+}
+
+// recordSMTRequirement records the SMT level required by the deployed application, if any
+func (cr *creator) recordSMTRequirement() error {
+	if cr.smtLevel == nil {
+		return nil
+	}
+
+	var st SMTState
+	return state.Default().Update(smtStateName, &st, func() error {
+		// drops the applications deleted outside of ai-services
+		cr.smtConflicts(&st, *cr.smtLevel)
+		if st.Applications == nil {
+			st.Applications = map[string]int{}
+		}
+		st.Applications[cr.opts.Name] = *cr.smtLevel
+		return nil
+	})
+}
+
+// smtConflicts returns the deployed applications requiring another SMT level than target. Applications whose
+// pods are gone (deleted outside of ai-services) are dropped from the state.
+func (cr *creator) smtConflicts(st *SMTState, target int) []string {
+	var conflicts []string
+	for app, level := range st.Applications {
+		if app == cr.opts.Name {
+			continue
+		}
+		if _, err := cr.GetApplication(context.Background(), app); errors.Is(err, ErrApplicationNotFound) {
+			delete(st.Applications, app)
+			continue
+		}
+		if level != target {
+			conflicts = append(conflicts, fmt.Sprintf("%s (SMT=%d)", app, level))
+		}
+	}
+	sort.Strings(conflicts)
+	return conflicts
+}
+
+func (cr *creator) getTargetSMTLevel() (*int, error) {
+	// the metadata is read from the cache once loaded
+	_, appMetadata, err := cr.loadArtifacts(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	return appMetadata.SMTLevel, nil
+}
+
+// releaseSMTLevel drops the SMT requirement of the deleted application. Once no deployed application requires
+// an SMT level anymore, the original SMT level of the host is restored unless keep is set.
+func releaseSMTLevel(smt platform.SMT, appName string, keep bool) error {
+	var st SMTState
+	return state.Default().Update(smtStateName, &st, func() error {
+		if _, ok := st.Applications[appName]; !ok {
+			return nil
+		}
+		delete(st.Applications, appName)
+
+		if len(st.Applications) > 0 || st.Original == 0 {
+			return nil
+		}
+		if keep {
+			logger.Infof("Keeping the SMT level, the original SMT level %d can be restored with 'ai-services smt restore'\n", st.Original, 0)
+			return nil
+		}
+
+		if err := restoreSMTLevel(smt, st.Original); err != nil {
+			return err
+		}
+		st.Original = 0
+		return nil
+	})
+}
+
+func restoreSMTLevel(smt platform.SMT, original int) error {
+	current, err := smt.Get()
+	if err != nil {
+		return fmt.Errorf("failed to get current SMT level: %w", err)
+	}
+	if current == original {
+		return nil
+	}
+
+	logger.Infof("Restoring the original SMT level %d (current: %d)\n", original, current, 0)
+	if err := applySMTLevel(smt, original); err != nil {
+		return fmt.Errorf("failed to restore the original SMT level: %w", err)
+	}
+	return nil
+}
+
+// smtOptions are the options of the SMT manager of the host, the SMT level being simulated when running rootless
+func smtOptions(dryRun bool) platform.SMTOptions {
+	return platform.SMTOptions{DryRun: dryRun, Simulate: vars.Rootless}
+}
+
+// SMTStatus returns the tracked SMT state along with the current SMT level of the host
+func SMTStatus(ctx context.Context) (*SMTState, int, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	var st SMTState
+	if err := state.Default().Load(smtStateName, &st); err != nil {
+		return nil, 0, err
+	}
+
+	current, err := platform.NewSMT(smtOptions(false)).Get()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return &st, current, nil
+}
+
+// RestoreSMTLevel restores the SMT level the host had before ai-services changed it, even if deployed
+// applications still require another SMT level. Returns the restored level, 0 if there is nothing to restore.
+// With dryRun, the SMT level and the state are left untouched.
+func RestoreSMTLevel(ctx context.Context, dryRun bool) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	smt := platform.NewSMT(smtOptions(dryRun))
+	var st SMTState
+	var restored int
+	restore := func() error {
+		if st.Original == 0 {
+			return nil
+		}
+
+		if len(st.Applications) > 0 {
+			apps := make([]string, 0, len(st.Applications))
+			for app, level := range st.Applications {
+				apps = append(apps, fmt.Sprintf("%s (SMT=%d)", app, level))
+			}
+			sort.Strings(apps)
+			logger.Warningf("Applications still requiring an SMT level: %s\n", strings.Join(apps, ", "))
+		}
+
+		if err := restoreSMTLevel(smt, st.Original); err != nil {
+			return err
+		}
+		restored = st.Original
+		st.Original = 0
+		return nil
+	}
+
+	if dryRun {
+		if err := state.Default().Load(smtStateName, &st); err != nil {
+			return 0, err
+		}
+		return restored, restore()
+	}
+	err := state.Default().Update(smtStateName, &st, restore)
+
+	return restored, err
+}
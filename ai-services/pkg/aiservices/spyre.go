@@ -0,0 +1,228 @@
+package aiservices
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	v1 "github.com/containers/podman/v5/pkg/k8s.io/api/core/v1"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/helpers"
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/templates"
+	"github.com/project-ai-services/ai-services/internal/pkg/constants"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/specs"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+// FreeSpyreCards returns the PCI addresses of the Spyre cards not allocated to any container
+func (c *Client) FreeSpyreCards(ctx context.Context) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return helpers.FindFreeSpyreCards(c.runtime)
+}
+
+func validateSpyreCardRequirements(req int, actual int) error {
+	if actual < req {
+		return fmt.Errorf("insufficient spyre cards. Require: %d spyre cards to proceed", req)
+	}
+	return nil
+}
+
+func (cr *creator) calculateReqSpyreCards(podTemplateFileNames []string) (int, error) {
+	totalReqSpyreCounts := 0
+	appTemplateName := cr.opts.Template
+
+	// Calculate Req Spyre Counts
+	for _, podTemplateFileName := range podTemplateFileNames {
+		// fetch pod spec
+		podSpec, err := cr.fetchPodSpec(podTemplateFileName)
+		if err != nil {
+			return totalReqSpyreCounts, fmt.Errorf("failed to load pod Template: '%s' for appTemplate: '%s' with error: %w", podTemplateFileName, appTemplateName, err)
+		}
+
+		// check if pod already exists and skip counting if it does exists
+		exists, err := cr.runtime.PodExists(podSpec.Name)
+		if err != nil {
+			return totalReqSpyreCounts, fmt.Errorf("failed to check pod status: %w", err)
+		}
+
+		if exists {
+			logger.Infof("Pod %s already exists, skipping spyre cards calculation\n", podSpec.Name, 2)
+			continue
+		}
+
+		// fetch the spyreCount for all containers from the annotations
+		spyreCount, _, err := fetchSpyreCardsFromPodAnnotations(podSpec.Annotations)
+		if err != nil {
+			return totalReqSpyreCounts, err
+		}
+
+		totalReqSpyreCounts += spyreCount
+	}
+
+	return totalReqSpyreCounts, nil
+}
+
+func fetchSpyreCardsFromPodAnnotations(annotations map[string]string) (int, map[string]int, error) {
+	var spyreCards int
+	// spyreCardContainerMap: Key -> containerName, Value -> SpyreCardCounts
+	spyreCardContainerMap := map[string]int{}
+
+	isSpyreCardAnnotation := func(annotation string) (string, bool) {
+		matches := vars.SpyreCardAnnotationRegex.FindStringSubmatch(annotation)
+		if matches == nil {
+			return "", false
+		}
+		return matches[1], true
+	}
+
+	for annotationKey, val := range annotations {
+		if containerName, ok := isSpyreCardAnnotation(annotationKey); ok {
+			valInt, err := strconv.Atoi(val)
+			if err != nil {
+				return 0, spyreCardContainerMap, fmt.Errorf("failed to convert to int. Provided val: %s is not of int type", val)
+			}
+			// Replace with container name
+			spyreCardContainerMap[containerName] = valInt
+			spyreCards += valInt
+		}
+	}
+
+	return spyreCards, spyreCardContainerMap, nil
+}
+
+// assignSpyreCards assigns the free Spyre cards to the containers of the pod templates which are not deployed yet,
+// before any pod is deployed. The pod templates are assigned in the order of podTemplateExecutions and the
+// containers by name, so that the same containers get the same cards across runs.
+// Returns the PCI addresses by pod template and container.
+func (cr *creator) assignSpyreCards(appMetadata *templates.AppMetadata, existingPods []string, pciAddresses []string) (map[string]map[string][]string, error) {
+	assignments := map[string]map[string][]string{}
+	// the devices cannot be passed through to rootless containers
+	if vars.Rootless {
+		return assignments, nil
+	}
+	next := 0
+
+	for _, podTemplateName := range utils.FlattenArray(appMetadata.PodTemplateExecutions) {
+		podSpec, err := cr.fetchPodSpec(podTemplateName)
+		if err != nil {
+			return nil, err
+		}
+		if slices.Contains(existingPods, podSpec.Name) {
+			continue
+		}
+
+		_, spyreCardContainerMap, err := fetchSpyreCardsFromPodAnnotations(podSpec.Annotations)
+		if err != nil {
+			return nil, fmt.Errorf("pod template %s: %w", podTemplateName, err)
+		}
+
+		containerNames := specs.FetchContainerNames(*podSpec)
+		for _, container := range slices.Sorted(maps.Keys(spyreCardContainerMap)) {
+			count := spyreCardContainerMap[container]
+			if count < 0 {
+				return nil, fmt.Errorf("pod template %s: invalid spyre card count %d for container %s", podTemplateName, count, container)
+			}
+			if count == 0 {
+				continue
+			}
+			if !slices.Contains(containerNames, container) {
+				return nil, fmt.Errorf("pod template %s: spyre cards requested for unknown container %s", podTemplateName, container)
+			}
+			if next+count > len(pciAddresses) {
+				return nil, fmt.Errorf("insufficient spyre cards: container %s of pod template %s requires %d spyre cards, %d left",
+					container, podTemplateName, count, len(pciAddresses)-next)
+			}
+
+			if assignments[podTemplateName] == nil {
+				assignments[podTemplateName] = map[string][]string{}
+			}
+			assignments[podTemplateName][container] = pciAddresses[next : next+count]
+			next += count
+			logger.Infof("Assigning spyre cards %v to container %s of pod template %s\n", assignments[podTemplateName][container], container, podTemplateName, 2)
+		}
+	}
+
+	return assignments, nil
+}
+
+// returnEnvParamsForPod returns the env of the containers of the pod, with the PCI addresses of the Spyre cards
+// assigned to them
+func returnEnvParamsForPod(podSpec *models.PodSpec, assignments map[string][]string) map[string]map[string]string {
+	env := map[string]map[string]string{}
+
+	// populate env with empty map
+	for _, containerName := range specs.FetchContainerNames(*podSpec) {
+		env[containerName] = map[string]string{}
+	}
+
+	for container, addresses := range assignments {
+		env[container] = map[string]string{string(constants.PCIAddressKey): strings.Join(addresses, " ")}
+	}
+
+	return env
+}
+
+// injectSpyreDevices mounts the VFIO device nodes of the Spyre cards assigned to the containers of the rendered pod
+// template, along with the VFIO container device, so that the containers expecting device nodes work without a
+// custom entrypoint. The PCI addresses of the cards are recorded in the annotations of the pod
+func injectSpyreDevices(manifest []byte, assignments map[string][]string) ([]byte, error) {
+	if len(assignments) == 0 {
+		return manifest, nil
+	}
+
+	podSpec, err := specs.ParsePodSpec(manifest)
+	if err != nil {
+		return nil, err
+	}
+	if podSpec.Annotations == nil {
+		podSpec.Annotations = map[string]string{}
+	}
+
+	for _, container := range slices.Sorted(maps.Keys(assignments)) {
+		addresses := assignments[container]
+		if len(addresses) == 0 {
+			continue
+		}
+		podSpec.Annotations[constants.SpyreDevicesAnnotationPrefix+container] = strings.Join(addresses, ",")
+
+		devices := []string{vfioContainerDevice}
+		for _, address := range addresses {
+			group, err := helpers.IOMMUGroup(address)
+			if err != nil {
+				return nil, err
+			}
+			devices = append(devices, "/dev/vfio/"+group)
+		}
+
+		for _, device := range devices {
+			if err := specs.AddVolumeMount(podSpec, container, deviceVolume(device), v1.VolumeMount{MountPath: device}); err != nil {
+				return nil, fmt.Errorf("failed to mount spyre device %s: %w", device, err)
+			}
+		}
+	}
+
+	return specs.MarshalPodSpec(podSpec)
+}
+
+// vfioContainerDevice is the VFIO container device, required along with the device nodes of the groups
+const vfioContainerDevice = "/dev/vfio/vfio"
+
+// deviceVolume returns the volume of the device node, kube play passes the char devices to the containers as devices
+func deviceVolume(device string) v1.Volume {
+	charDevice := v1.HostPathCharDev
+	return v1.Volume{
+		Name: "ai-services-" + strings.ReplaceAll(strings.TrimPrefix(device, "/dev/"), "/", "-"),
+		VolumeSource: v1.VolumeSource{
+			HostPath: &v1.HostPathVolumeSource{Path: device, Type: &charDevice},
+		},
+	}
+}
@@ -0,0 +1,132 @@
+package aiservices
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "github.com/containers/podman/v5/pkg/k8s.io/api/core/v1"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/templates"
+	"github.com/project-ai-services/ai-services/internal/pkg/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+// testPodSpec returns the pod spec of the containers, requesting the spyre cards by container
+func testPodSpec(name string, spyreCards map[string]string, containers ...string) *models.PodSpec {
+	podSpec := &models.PodSpec{}
+	podSpec.Name = name
+	podSpec.Annotations = map[string]string{}
+	for container, count := range spyreCards {
+		podSpec.Annotations["ai-services.io/"+container+"--sypre-cards"] = count
+	}
+	for _, container := range containers {
+		podSpec.Spec.Containers = append(podSpec.Spec.Containers, v1.Container{Name: container})
+	}
+	return podSpec
+}
+
+func TestAssignSpyreCards(t *testing.T) {
+	rootless := vars.Rootless
+	vars.Rootless = false
+	t.Cleanup(func() { vars.Rootless = rootless })
+
+	cards := []string{"0000:01:00.0", "0000:02:00.0", "0000:03:00.0", "0000:04:00.0"}
+	podSpecs := map[string]*models.PodSpec{
+		"vllm":     testPodSpec("app--vllm", map[string]string{"instruct": "2", "embedding": "1"}, "instruct", "embedding"),
+		"reranker": testPodSpec("app--reranker", map[string]string{"reranker": "1"}, "reranker"),
+		"ui":       testPodSpec("app--ui", nil, "ui"),
+		"idle":     testPodSpec("app--idle", map[string]string{"idle": "0"}, "idle"),
+		"unknown":  testPodSpec("app--unknown", map[string]string{"missing": "1"}, "server"),
+		"negative": testPodSpec("app--negative", map[string]string{"server": "-1"}, "server"),
+		"invalid":  testPodSpec("app--invalid", map[string]string{"server": "two"}, "server"),
+	}
+
+	tests := []struct {
+		name         string
+		executions   [][]string
+		existingPods []string
+		cards        []string
+		want         map[string]map[string][]string
+		wantErr      bool
+	}{
+		{
+			name:       "containers assigned by name in the order of the layers",
+			executions: [][]string{{"ui", "vllm"}, {"reranker"}},
+			cards:      cards,
+			want: map[string]map[string][]string{
+				"vllm":     {"embedding": {"0000:01:00.0"}, "instruct": {"0000:02:00.0", "0000:03:00.0"}},
+				"reranker": {"reranker": {"0000:04:00.0"}},
+			},
+		},
+		{
+			name:         "existing pods keep their cards",
+			executions:   [][]string{{"vllm"}, {"reranker"}},
+			existingPods: []string{"app--vllm"},
+			cards:        cards[3:],
+			want: map[string]map[string][]string{
+				"reranker": {"reranker": {"0000:04:00.0"}},
+			},
+		},
+		{
+			name:       "no cards requested",
+			executions: [][]string{{"ui", "idle"}},
+			want:       map[string]map[string][]string{},
+		},
+		{
+			name:       "insufficient cards",
+			executions: [][]string{{"vllm"}, {"reranker"}},
+			cards:      cards[:3],
+			wantErr:    true,
+		},
+		{
+			name:       "unknown container",
+			executions: [][]string{{"unknown"}},
+			cards:      cards,
+			wantErr:    true,
+		},
+		{
+			name:       "negative count",
+			executions: [][]string{{"negative"}},
+			cards:      cards,
+			wantErr:    true,
+		},
+		{
+			name:       "invalid count",
+			executions: [][]string{{"invalid"}},
+			cards:      cards,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cr := &creator{cache: &artifacts{podSpecs: podSpecs}}
+			appMetadata := &templates.AppMetadata{PodTemplateExecutions: tt.executions}
+
+			got, err := cr.assignSpyreCards(appMetadata, tt.existingPods, tt.cards)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("assignSpyreCards() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("assignSpyreCards() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAssignSpyreCardsRootless(t *testing.T) {
+	rootless := vars.Rootless
+	vars.Rootless = true
+	t.Cleanup(func() { vars.Rootless = rootless })
+
+	cr := &creator{cache: &artifacts{podSpecs: map[string]*models.PodSpec{
+		"vllm": testPodSpec("app--vllm", map[string]string{"instruct": "1"}, "instruct"),
+	}}}
+	got, err := cr.assignSpyreCards(&templates.AppMetadata{PodTemplateExecutions: [][]string{{"vllm"}}}, nil, nil)
+	if err != nil {
+		t.Fatalf("assignSpyreCards() unexpected error: %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("assignSpyreCards() = %v, want no assignment in rootless mode", got)
+	}
+}
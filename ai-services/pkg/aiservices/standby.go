@@ -0,0 +1,139 @@
+package aiservices
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/specs"
+	"github.com/project-ai-services/ai-services/internal/pkg/state"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+// standbyStateName is the name of the state document holding the standby pairs of the applications
+const standbyStateName = "standby"
+
+// Synchronization methods of the volumes of a standby pair
+const (
+	// SyncRsync mirrors the model directory and the volumes of the application to the standby host over SSH
+	SyncRsync = "rsync"
+	// SyncShared runs the hook of the pair instead, the volumes being on a storage shared by both hosts, Eg:- to
+	// snapshot or replicate them
+	SyncShared = "shared"
+)
+
+// Roles of the hosts of a standby pair
+const (
+	StandbyWarm     = "standby"
+	StandbyPromoted = "promoted"
+)
+
+// StandbyPair is an application deployed in active/standby across this host and a standby host of the hosts
+// inventory, the application being deployed but stopped on the standby host
+type StandbyPair struct {
+	Application string `json:"application"`
+	// Host is the standby host of the hosts inventory
+	Host string `json:"host"`
+	// Sync is the synchronization method of the volumes, rsync or shared
+	Sync string `json:"sync"`
+	// Hook is the shell command synchronizing the volumes with the shared method, run with the
+	// AI_SERVICES_APPLICATION, AI_SERVICES_STANDBY_HOST and AI_SERVICES_SYNC_PATHS environment variables. It is run
+	// as is with 'sh -c', by the user synchronizing the standby
+	Hook string `json:"hook,omitempty"`
+	// State is standby while this host is active, promoted once the standby host took over
+	State     string    `json:"state"`
+	Enabled   time.Time `json:"enabled"`
+	LastSync  time.Time `json:"lastSync,omitempty"`
+	SyncError string    `json:"syncError,omitempty"`
+	Promoted  time.Time `json:"promoted,omitempty"`
+}
+
+// ValidateSyncMethod checks the synchronization method of a standby pair
+func ValidateSyncMethod(method string) error {
+	if method != SyncRsync && method != SyncShared {
+		return fmt.Errorf("invalid sync method '%s', supported methods: %s, %s", method, SyncRsync, SyncShared)
+	}
+	return nil
+}
+
+// RecordStandby records the standby pair of the application, replacing its previous pair
+func RecordStandby(pair StandbyPair) error {
+	pairs := map[string]StandbyPair{}
+	return state.Default().Update(standbyStateName, &pairs, func() error {
+		pairs[pair.Application] = pair
+		return nil
+	})
+}
+
+// UpdateStandby mutates the standby pair of the application with fn
+func UpdateStandby(appName string, fn func(*StandbyPair)) error {
+	pairs := map[string]StandbyPair{}
+	return state.Default().Update(standbyStateName, &pairs, func() error {
+		pair, ok := pairs[appName]
+		if !ok {
+			return fmt.Errorf("application '%s' has no standby, enable it with 'application standby enable'", appName)
+		}
+		fn(&pair)
+		pairs[appName] = pair
+		return nil
+	})
+}
+
+// ListStandby returns the standby pairs sorted by application
+func ListStandby() ([]StandbyPair, error) {
+	pairs := map[string]StandbyPair{}
+	if err := state.Default().Load(standbyStateName, &pairs); err != nil {
+		return nil, err
+	}
+	list := make([]StandbyPair, 0, len(pairs))
+	for _, pair := range pairs {
+		list = append(list, pair)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Application < list[j].Application })
+	return list, nil
+}
+
+// GetStandby returns the standby pair of the application
+func GetStandby(appName string) (*StandbyPair, error) {
+	pairs := map[string]StandbyPair{}
+	if err := state.Default().Load(standbyStateName, &pairs); err != nil {
+		return nil, err
+	}
+	pair, ok := pairs[appName]
+	if !ok {
+		return nil, fmt.Errorf("application '%s' has no standby, enable it with 'application standby enable'", appName)
+	}
+	return &pair, nil
+}
+
+// ReleaseStandby drops the standby pair of the application
+func ReleaseStandby(appName string) error {
+	pairs := map[string]StandbyPair{}
+	return state.Default().Update(standbyStateName, &pairs, func() error {
+		delete(pairs, appName)
+		return nil
+	})
+}
+
+// StandbySyncPaths returns the host paths to synchronize to the standby host of the application: the model
+// directory and the mount points of the volumes of its claims
+func (c *Client) StandbySyncPaths(ctx context.Context, appName string) ([]string, error) {
+	objects, err := c.ApplicationObjects(ctx, appName)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := []string{vars.ModelDirectory}
+	for _, obj := range objects {
+		if obj.Kind != specs.KindPersistentVolumeClaim || !obj.Exists {
+			continue
+		}
+		volume, err := c.runtime.InspectVolume(obj.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to inspect volume %s: %w", obj.Name, err)
+		}
+		paths = append(paths, volume.Mountpoint)
+	}
+	return paths, nil
+}
@@ -0,0 +1,59 @@
+package aiservices
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/validators"
+)
+
+// Template describes an application template offered by ai-services
+type Template struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+	// Parameters are the supported parameters along with their description
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
+// ListTemplates returns the offered application templates sorted by name
+func (c *Client) ListTemplates(ctx context.Context) ([]Template, error) {
+	names, err := c.templates.ListApplications()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list application templates: %w", err)
+	}
+	sort.Strings(names)
+
+	tmpls := make([]Template, 0, len(names))
+	for _, name := range names {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		tmpl, err := c.GetTemplate(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		tmpls = append(tmpls, *tmpl)
+	}
+
+	return tmpls, nil
+}
+
+// GetTemplate returns the application template with its supported parameters
+func (c *Client) GetTemplate(ctx context.Context, name string) (*Template, error) {
+	if err := validators.ValidateAppTemplateExist(c.templates, name); err != nil {
+		return nil, err
+	}
+
+	params, err := c.templates.ListApplicationTemplateValues(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list application template values: %w", err)
+	}
+
+	appMetadata, err := c.templates.LoadMetadata(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the app metadata: %w", err)
+	}
+
+	return &Template{Name: name, Version: appMetadata.Version, Parameters: params}, nil
+}
@@ -0,0 +1,92 @@
+package aiservices
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Timing stages of a deployment
+const (
+	TimingRender    = "render"
+	TimingImagePull = "image-pull"
+	TimingKubePlay  = "kube-play"
+	TimingReadiness = "readiness"
+	TimingLayer     = "layer"
+	TimingWait      = "wait"
+)
+
+// Timing is the elapsed time of a stage of the deployment, for a pod, an image or a whole layer
+type Timing struct {
+	Stage    string        `json:"stage"`
+	Layer    int           `json:"layer,omitempty"`
+	Pod      string        `json:"pod,omitempty"`
+	Image    string        `json:"image,omitempty"`
+	Duration time.Duration `json:"-"`
+}
+
+func (t Timing) MarshalJSON() ([]byte, error) {
+	type timing Timing
+	return json.Marshal(struct {
+		timing
+		DurationSeconds float64 `json:"durationSeconds"`
+	}{timing(t), t.Duration.Seconds()})
+}
+
+// timings records the timings of a deployment, the pods of a layer being deployed concurrently
+type timings struct {
+	mu      sync.Mutex
+	entries []Timing
+}
+
+// since records the time elapsed since start for the stage
+func (t *timings) since(start time.Time, timing Timing) {
+	timing.Duration = time.Since(start)
+	t.add(timing)
+}
+
+// add records the timing of the stage
+func (t *timings) add(timing Timing) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.entries = append(t.entries, timing)
+}
+
+func (t *timings) list() []Timing {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]Timing(nil), t.entries...)
+}
+
+// String renders the deployment summary, the images first then each layer followed by its pods
+func (t *timings) String() string {
+	entries := t.list()
+
+	var b strings.Builder
+	b.WriteString("Deployment timings:\n")
+	for _, e := range entries {
+		if e.Stage == TimingImagePull {
+			fmt.Fprintf(&b, "  %-12s %-60s %s\n", e.Stage, e.Image, e.Duration.Round(time.Millisecond))
+		}
+	}
+
+	layers := 0
+	for _, e := range entries {
+		layers = max(layers, e.Layer)
+	}
+	for layer := 1; layer <= layers; layer++ {
+		for _, e := range entries {
+			if e.Layer == layer && e.Stage == TimingLayer {
+				fmt.Fprintf(&b, "  layer %-6d %-60s %s\n", layer, "", e.Duration.Round(time.Millisecond))
+			}
+		}
+		for _, e := range entries {
+			if e.Layer == layer && e.Stage != TimingLayer {
+				fmt.Fprintf(&b, "    %-10s %-60s %s\n", e.Stage, e.Pod, e.Duration.Round(time.Millisecond))
+			}
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
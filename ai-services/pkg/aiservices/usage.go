@@ -0,0 +1,289 @@
+package aiservices
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/state"
+)
+
+// usageStateName is the name of the state document holding the usage samples of the applications
+const usageStateName = "usage"
+
+// UsageRetention is the age of the usage samples dropped from the history
+const UsageRetention = 90 * 24 * time.Hour
+
+// ProjectLabel is the label of the pods attributing the usage of the application to a project, set with
+// 'application create --label project=<name>'
+const ProjectLabel = "project"
+
+// maxSampleGap bounds the period a usage sample accounts for, the usage is not accounted while it was not sampled
+const maxSampleGap = time.Hour
+
+// maxUsageSamples caps the usage samples of an application, the oldest samples are dropped first
+const maxUsageSamples = 20000
+
+// UsageSample is the resource usage of an application at a time
+type UsageSample struct {
+	Time time.Time `json:"time"`
+	// MilliCPU is the CPU used by the containers in thousandths of a (logical) CPU
+	MilliCPU int64 `json:"milliCPU"`
+	// Memory used by the containers in bytes
+	Memory int64 `json:"memory"`
+	// SpyreCards are the Spyre cards passed through to the running containers
+	SpyreCards int `json:"spyreCards"`
+	// Project is the ProjectLabel of the pods of the application
+	Project string `json:"project,omitempty"`
+}
+
+// UsageSummary summarizes the usage samples of an application over a period
+type UsageSummary struct {
+	Application string    `json:"application"`
+	Samples     int       `json:"samples"`
+	From        time.Time `json:"from"`
+	To          time.Time `json:"to"`
+	// Reserved are the resources currently reserved by the application, to compare with its usage
+	Reserved       Resources `json:"reserved"`
+	AvgMilliCPU    int64     `json:"avgMilliCPU"`
+	PeakMilliCPU   int64     `json:"peakMilliCPU"`
+	AvgMemory      int64     `json:"avgMemory"`
+	PeakMemory     int64     `json:"peakMemory"`
+	AvgSpyreCards  float64   `json:"avgSpyreCards"`
+	PeakSpyreCards int       `json:"peakSpyreCards"`
+	// CPUTrend and MemoryTrend are the change of the average usage from the first to the second half of the period,
+	// in percent
+	CPUTrend    float64 `json:"cpuTrend"`
+	MemoryTrend float64 `json:"memoryTrend"`
+}
+
+// SampleUsage samples the CPU, memory and Spyre cards used by the running containers of each deployed application
+func (c *Client) SampleUsage(ctx context.Context) (map[string]UsageSample, error) {
+	apps, err := c.ListApplications(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	samples := map[string]UsageSample{}
+	now := time.Now().UTC()
+	for _, app := range apps {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		sample := UsageSample{Time: now}
+		var running []string
+		groups := map[string]bool{}
+		for _, pod := range app.Pods {
+			report, err := c.runtime.InspectPod(pod.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to inspect pod %s: %w", pod.Name, err)
+			}
+			if sample.Project == "" {
+				sample.Project = report.Labels[ProjectLabel]
+			}
+			for _, ctr := range report.Containers {
+				if ctr.ID == report.InfraContainerID || ctr.State != "running" {
+					continue
+				}
+				running = append(running, ctr.ID)
+				info, err := c.runtime.InspectContainer(ctr.ID)
+				if err != nil || info.HostConfig == nil {
+					// the container exited meanwhile
+					continue
+				}
+				for _, device := range info.HostConfig.Devices {
+					if group, ok := strings.CutPrefix(device.PathOnHost, "/dev/vfio/"); ok && group != "vfio" {
+						groups[group] = true
+					}
+				}
+			}
+		}
+		sample.SpyreCards = len(groups)
+
+		stats, err := c.runtime.ContainerStats(running)
+		if err != nil {
+			return nil, fmt.Errorf("application %s: %w", app.Name, err)
+		}
+		for _, s := range stats {
+			// the CPU is reported in percent of a CPU
+			sample.MilliCPU += int64(s.CPU * 10)
+			sample.Memory += int64(s.MemUsage)
+		}
+		samples[app.Name] = sample
+	}
+	return samples, nil
+}
+
+// RecordUsage samples the usage of the applications and appends it to their usage history, dropping the samples
+// older than UsageRetention
+func (c *Client) RecordUsage(ctx context.Context) (map[string]UsageSample, error) {
+	samples, err := c.SampleUsage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	history := map[string][]UsageSample{}
+	err = state.Default().Update(usageStateName, &history, func() error {
+		cutoff := time.Now().Add(-UsageRetention)
+		for app, sample := range samples {
+			history[app] = append(history[app], sample)
+		}
+		for app, appSamples := range history {
+			i := sort.Search(len(appSamples), func(i int) bool { return appSamples[i].Time.After(cutoff) })
+			appSamples = appSamples[i:]
+			if len(appSamples) > maxUsageSamples {
+				appSamples = appSamples[len(appSamples)-maxUsageSamples:]
+			}
+			if len(appSamples) == 0 {
+				delete(history, app)
+				continue
+			}
+			history[app] = appSamples
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to record the usage samples: %w", err)
+	}
+	return samples, nil
+}
+
+// UsageReport summarizes the usage history of the applications over the period ending now, sorted by application
+func UsageReport(since time.Duration) ([]UsageSummary, error) {
+	history := map[string][]UsageSample{}
+	if err := state.Default().Load(usageStateName, &history); err != nil {
+		return nil, err
+	}
+	reservations := map[string]Resources{}
+	if err := state.Default().Load(resourcesStateName, &reservations); err != nil {
+		return nil, err
+	}
+
+	cutoff := time.Now().Add(-since)
+	summaries := []UsageSummary{}
+	for app, appSamples := range history {
+		i := sort.Search(len(appSamples), func(i int) bool { return !appSamples[i].Time.Before(cutoff) })
+		if summary := summarizeUsage(appSamples[i:]); summary != nil {
+			summary.Application = app
+			summary.Reserved = reservations[app]
+			summaries = append(summaries, *summary)
+		}
+	}
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].Application < summaries[j].Application })
+	return summaries, nil
+}
+
+// summarizeUsage summarizes the samples sorted by time, nil if there are none
+func summarizeUsage(samples []UsageSample) *UsageSummary {
+	if len(samples) == 0 {
+		return nil
+	}
+	summary := &UsageSummary{Samples: len(samples), From: samples[0].Time, To: samples[len(samples)-1].Time}
+	var cpu, memory int64
+	var spyreCards int
+	for _, s := range samples {
+		cpu += s.MilliCPU
+		memory += s.Memory
+		spyreCards += s.SpyreCards
+		summary.PeakMilliCPU = max(summary.PeakMilliCPU, s.MilliCPU)
+		summary.PeakMemory = max(summary.PeakMemory, s.Memory)
+		summary.PeakSpyreCards = max(summary.PeakSpyreCards, s.SpyreCards)
+	}
+	n := int64(len(samples))
+	summary.AvgMilliCPU, summary.AvgMemory = cpu/n, memory/n
+	summary.AvgSpyreCards = float64(spyreCards) / float64(n)
+
+	if len(samples) >= 2 {
+		half := len(samples) / 2
+		first, second := samples[:half], samples[half:]
+		summary.CPUTrend = trend(first, second, func(s UsageSample) int64 { return s.MilliCPU })
+		summary.MemoryTrend = trend(first, second, func(s UsageSample) int64 { return s.Memory })
+	}
+	return summary
+}
+
+// trend returns the change of the average value from the first to the second samples, in percent
+func trend(first, second []UsageSample, value func(UsageSample) int64) float64 {
+	avg := func(samples []UsageSample) float64 {
+		var total int64
+		for _, s := range samples {
+			total += value(s)
+		}
+		return float64(total) / float64(len(samples))
+	}
+	before, after := avg(first), avg(second)
+	if before == 0 {
+		return 0
+	}
+	return (after - before) / before * 100
+}
+
+// Chargeback groupings
+const (
+	ChargebackByApplication = "application"
+	ChargebackByProject     = "project"
+)
+
+// ChargebackLine is the usage attributed to an application or a project over the month
+type ChargebackLine struct {
+	// Owner is the application or the project, empty for the usage of the applications without project
+	Owner          string  `json:"owner"`
+	CPUHours       float64 `json:"cpuHours"`
+	MemoryGiBHours float64 `json:"memoryGiBHours"`
+	SpyreCardHours float64 `json:"spyreCardHours"`
+}
+
+// Chargeback attributes the CPU-hours, memory-hours and Spyre-card-hours of the usage history of the month to the
+// applications or to their projects, sorted by owner. Each sample accounts for the usage until the next sample, at
+// most for maxSampleGap.
+func Chargeback(month time.Time, by string) ([]ChargebackLine, error) {
+	if by != ChargebackByApplication && by != ChargebackByProject {
+		return nil, fmt.Errorf("invalid grouping '%s', supported: %s, %s", by, ChargebackByApplication, ChargebackByProject)
+	}
+	history := map[string][]UsageSample{}
+	if err := state.Default().Load(usageStateName, &history); err != nil {
+		return nil, err
+	}
+
+	start := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+	lines := map[string]*ChargebackLine{}
+	for app, samples := range history {
+		for i, s := range samples {
+			if s.Time.Before(start) || !s.Time.Before(end) {
+				continue
+			}
+			// the last sample accounts for as long as the previous one
+			var gap time.Duration
+			if i+1 < len(samples) {
+				gap = samples[i+1].Time.Sub(s.Time)
+			} else if i > 0 {
+				gap = s.Time.Sub(samples[i-1].Time)
+			}
+			gap = min(gap, maxSampleGap, end.Sub(s.Time))
+			hours := gap.Hours()
+
+			owner := app
+			if by == ChargebackByProject {
+				owner = s.Project
+			}
+			line, ok := lines[owner]
+			if !ok {
+				line = &ChargebackLine{Owner: owner}
+				lines[owner] = line
+			}
+			line.CPUHours += float64(s.MilliCPU) / 1000 * hours
+			line.MemoryGiBHours += float64(s.Memory) / (1 << 30) * hours
+			line.SpyreCardHours += float64(s.SpyreCards) * hours
+		}
+	}
+
+	result := make([]ChargebackLine, 0, len(lines))
+	for _, line := range lines {
+		result = append(result, *line)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Owner < result[j].Owner })
+	return result, nil
+}
@@ -0,0 +1,66 @@
+package aiservices
+
+import (
+	"context"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/helpers"
+	"github.com/project-ai-services/ai-services/internal/pkg/constants"
+	"github.com/project-ai-services/ai-services/internal/pkg/validators"
+)
+
+// ValidationResult is the outcome of a single validation check of the host
+type ValidationResult struct {
+	Name    string `json:"name"`
+	Passed  bool   `json:"passed"`
+	Skipped bool   `json:"skipped,omitempty"`
+	// Warning is set when a failed check doesn't block the deployments
+	Warning bool   `json:"warning,omitempty"`
+	Message string `json:"message"`
+	Hint    string `json:"hint,omitempty"`
+}
+
+// Validate runs the validation checks of the host, except the skipped ones.
+// Returns the results of all the checks, and true if none of the blocking checks failed.
+func (c *Client) Validate(ctx context.Context, skip []string) ([]ValidationResult, bool, error) {
+	return validate(ctx, skip)
+}
+
+// validate runs the validation checks of the host, which don't require the podman connection
+func validate(ctx context.Context, skip []string) ([]ValidationResult, bool, error) {
+	skipped := helpers.ParseSkipChecks(skip)
+
+	passed := true
+	var results []ValidationResult
+	for _, rule := range validators.DefaultRegistry.Rules() {
+		if err := ctx.Err(); err != nil {
+			return nil, false, err
+		}
+
+		result := ValidationResult{Name: rule.Name()}
+		if skipped[rule.Name()] {
+			result.Skipped = true
+			results = append(results, result)
+			continue
+		}
+
+		if err := rule.Verify(); err != nil {
+			result.Message = err.Error()
+			result.Hint = rule.Hint()
+			result.Warning = rule.Level() == constants.ValidationLevelWarning
+			if !result.Warning {
+				passed = false
+			}
+		} else {
+			result.Passed = true
+			result.Message = rule.Message()
+		}
+		results = append(results, result)
+
+		// other checks require root privileges
+		if rule.Name() == "root" && !result.Passed {
+			return results, false, nil
+		}
+	}
+
+	return results, passed, nil
+}
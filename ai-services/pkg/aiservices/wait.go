@@ -0,0 +1,166 @@
+package aiservices
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/helpers"
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/templates"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/models"
+)
+
+var (
+	// defaultWaitTimeout bounds the wait conditions declaring no timeout
+	defaultWaitTimeout = 10 * time.Minute
+	waitPollInterval   = 3 * time.Second
+	// waitProbeTimeout bounds a single evaluation of a condition
+	waitProbeTimeout = 5 * time.Second
+)
+
+// awaitConditions awaits the wait conditions declared after the layer, before the next layer is started. podSpec
+// returns the deployed pod of a pod template.
+func (cr *creator) awaitConditions(ctx context.Context, layer int, appMetadata *templates.AppMetadata,
+	podSpec func(podTemplate string) (*models.PodSpec, error)) error {
+	for _, cond := range appMetadata.WaitConditions(layer) {
+		start := time.Now()
+		if err := cr.awaitCondition(ctx, cond, podSpec); err != nil {
+			return fmt.Errorf("wait condition %s: %w", cond.Name, err)
+		}
+		cr.timings.since(start, Timing{Stage: TimingWait, Layer: layer})
+	}
+	return nil
+}
+
+func (cr *creator) awaitCondition(ctx context.Context, cond templates.WaitCondition, podSpec func(string) (*models.PodSpec, error)) error {
+	timeout := defaultWaitTimeout
+	if cond.Timeout != "" {
+		var err error
+		if timeout, err = time.ParseDuration(cond.Timeout); err != nil || timeout <= 0 {
+			return fmt.Errorf("invalid timeout '%s'", cond.Timeout)
+		}
+	}
+
+	var podTemplate string
+	var probe func(ctx context.Context, pod *models.PodSpec) error
+	switch {
+	case cond.HTTP != nil && cond.TCP == nil && cond.File == nil:
+		podTemplate, probe = cond.HTTP.PodTemplate, cr.probeHTTP(cond.HTTP)
+	case cond.TCP != nil && cond.HTTP == nil && cond.File == nil:
+		podTemplate, probe = cond.TCP.PodTemplate, cr.probeTCP(cond.TCP)
+	case cond.File != nil && cond.HTTP == nil && cond.TCP == nil:
+		podTemplate, probe = cond.File.PodTemplate, cr.probeFile(cond.File)
+	default:
+		return errors.New("exactly one of http, tcp and file must be set")
+	}
+	pod, err := podSpec(podTemplate)
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("Waiting for %s, up to %s\n", cond.Name, timeout)
+	deadline := time.Now().Add(timeout)
+	for {
+		probeCtx, cancel := context.WithTimeout(ctx, waitProbeTimeout)
+		err := probe(probeCtx, pod)
+		cancel()
+		if err == nil {
+			logger.Infof("Condition %s met\n", cond.Name)
+			return nil
+		}
+		if time.Now().Add(waitPollInterval).After(deadline) {
+			return fmt.Errorf("not met after %s: %w", timeout, err)
+		}
+		logger.Infof("Condition %s not met yet: %v\n", cond.Name, err, 2)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(waitPollInterval):
+		}
+	}
+}
+
+// probeHTTP checks the endpoint of the pod returns the expected status
+func (cr *creator) probeHTTP(w *templates.HTTPWait) func(context.Context, *models.PodSpec) error {
+	return func(ctx context.Context, pod *models.PodSpec) error {
+		ip, err := helpers.FetchPodIP(cr.runtime, pod.Name)
+		if err != nil {
+			return err
+		}
+		url := "http://" + net.JoinHostPort(ip, strconv.Itoa(w.Port)) + "/" + strings.TrimPrefix(w.Path, "/")
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		expected := w.ExpectStatus
+		if expected == 0 {
+			expected = http.StatusOK
+		}
+		if resp.StatusCode != expected {
+			return fmt.Errorf("%s returned status %d, expected %d", url, resp.StatusCode, expected)
+		}
+		return nil
+	}
+}
+
+// probeTCP checks the port of the pod accepts connections
+func (cr *creator) probeTCP(w *templates.TCPWait) func(context.Context, *models.PodSpec) error {
+	return func(ctx context.Context, pod *models.PodSpec) error {
+		ip, err := helpers.FetchPodIP(cr.runtime, pod.Name)
+		if err != nil {
+			return err
+		}
+		var d net.Dialer
+		conn, err := d.DialContext(ctx, "tcp", net.JoinHostPort(ip, strconv.Itoa(w.Port)))
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+}
+
+// probeFile checks the file exists in the volume of the pod
+func (cr *creator) probeFile(w *templates.FileWait) func(context.Context, *models.PodSpec) error {
+	return func(_ context.Context, pod *models.PodSpec) error {
+		dir, err := cr.volumeDir(pod, w.Volume)
+		if err != nil {
+			return err
+		}
+		_, err = os.Stat(filepath.Join(dir, w.Path))
+		return err
+	}
+}
+
+// volumeDir resolves the volume of the pod to its host path or to the mountpoint of the podman volume of its claim
+func (cr *creator) volumeDir(pod *models.PodSpec, name string) (string, error) {
+	for _, volume := range pod.Spec.Volumes {
+		if volume.Name != name {
+			continue
+		}
+		switch {
+		case volume.HostPath != nil:
+			return volume.HostPath.Path, nil
+		case volume.PersistentVolumeClaim != nil:
+			info, err := cr.runtime.InspectVolume(volume.PersistentVolumeClaim.ClaimName)
+			if err != nil {
+				return "", err
+			}
+			return info.Mountpoint, nil
+		}
+	}
+	return "", fmt.Errorf("volume %s of pod %s is not found, or neither a host path nor a claim", name, pod.Name)
+}
@@ -0,0 +1,149 @@
+package aiservices
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/helpers"
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/templates"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+)
+
+// WebhookSignatureHeader holds the hex encoded HMAC-SHA256 of the body, keyed by the secret of the webhook,
+// Eg:- 'sha256=5d41...'
+const WebhookSignatureHeader = "X-AI-Services-Signature"
+
+var (
+	webhookTimeout  = 10 * time.Second
+	webhookAttempts = 3
+	webhookBackoff  = 2 * time.Second
+)
+
+// WebhookEvent is the body of the webhook calls
+type WebhookEvent struct {
+	// Event is ready, failed or log-alert
+	Event       string    `json:"event"`
+	Application string    `json:"application"`
+	Template    string    `json:"template"`
+	Version     string    `json:"version,omitempty"`
+	Time        time.Time `json:"time"`
+	// Error is the deployment error of a failed event
+	Error string `json:"error,omitempty"`
+	// Endpoints are the endpoints of a ready application
+	Endpoints []helpers.Endpoint `json:"endpoints,omitempty"`
+	// Alert is the log alert of a log-alert event
+	Alert *LogAlertEvent `json:"alert,omitempty"`
+}
+
+// notifyWebhooks calls the webhooks of the template with the outcome of the deployment. The webhooks never fail the
+// deployment, their failures are logged.
+func (cr *creator) notifyWebhooks(ctx context.Context, deployErr error) {
+	if cr.cache == nil || cr.cache.metadata == nil || len(cr.cache.metadata.Webhooks) == 0 {
+		return
+	}
+	appMetadata := cr.cache.metadata
+
+	event := WebhookEvent{
+		Event:       templates.WebhookEventReady,
+		Application: cr.opts.Name,
+		Template:    cr.opts.Template,
+		Version:     appMetadata.Version,
+		Time:        time.Now().UTC(),
+	}
+	if deployErr != nil {
+		event.Event, event.Error = templates.WebhookEventFailed, deployErr.Error()
+	} else if endpoints, err := helpers.ListEndpoints(cr.runtime, cr.opts.Name); err == nil {
+		event.Endpoints = endpoints
+	}
+
+	values := map[string]string{}
+	if raw, err := cr.templates.LoadValues(cr.opts.Template, cr.opts.Environment, cr.opts.ValuesFiles, cr.params); err == nil {
+		flattenValues("", raw, values)
+	}
+
+	// the webhooks are called even once the deployment is cancelled
+	sendWebhooks(context.WithoutCancel(ctx), appMetadata.Webhooks, values, event)
+}
+
+// sendWebhooks calls the webhooks subscribed to the event, the URLs and secrets set by the values being resolved
+// from the flattened values. The failures are logged.
+func sendWebhooks(ctx context.Context, hooks []templates.Webhook, values map[string]string, event WebhookEvent) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		logger.Warningf("Failed to marshal the webhook event: %v\n", err)
+		return
+	}
+
+	for i, hook := range hooks {
+		events := hook.Events
+		if len(events) == 0 {
+			events = []string{templates.WebhookEventReady, templates.WebhookEventFailed}
+		}
+		if !slices.Contains(events, event.Event) {
+			continue
+		}
+		url := hook.URL
+		if hook.URLValue != "" && values[hook.URLValue] != "" {
+			url = values[hook.URLValue]
+		}
+		if url == "" {
+			continue
+		}
+		var secret string
+		if hook.SecretValue != "" {
+			if secret = values[hook.SecretValue]; secret == "" {
+				logger.Warningf("Skipping webhook %d: its secret %s is not set\n", i+1, hook.SecretValue)
+				continue
+			}
+		}
+
+		if err := callWebhook(ctx, url, secret, event.Event, body); err != nil {
+			logger.Warningf("Failed to call webhook %d on %s event: %v\n", i+1, event.Event, err)
+			continue
+		}
+		logger.Infof("Webhook %d notified of %s event\n", i+1, event.Event, 2)
+	}
+}
+
+// callWebhook posts the event, retried with backoff until the URL responds with a 2xx status
+func callWebhook(ctx context.Context, url, secret, event string, body []byte) error {
+	client := &http.Client{Timeout: webhookTimeout}
+	var err error
+	for attempt := 1; attempt <= webhookAttempts; attempt++ {
+		if attempt > 1 {
+			time.Sleep(webhookBackoff * time.Duration(attempt-1))
+		}
+		var req *http.Request
+		req, err = http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-AI-Services-Event", event)
+		if secret != "" {
+			mac := hmac.New(sha256.New, []byte(secret))
+			mac.Write(body)
+			req.Header.Set(WebhookSignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+		}
+
+		var resp *http.Response
+		resp, err = client.Do(req)
+		if err != nil {
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return nil
+		}
+		err = fmt.Errorf("%s responded with status %d", url, resp.StatusCode)
+	}
+	return err
+}
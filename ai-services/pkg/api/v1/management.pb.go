@@ -0,0 +1,1878 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: management.proto
+
+package apiv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ListApplicationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListApplicationsRequest) Reset() {
+	*x = ListApplicationsRequest{}
+	mi := &file_management_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListApplicationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListApplicationsRequest) ProtoMessage() {}
+
+func (x *ListApplicationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_management_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListApplicationsRequest.ProtoReflect.Descriptor instead.
+func (*ListApplicationsRequest) Descriptor() ([]byte, []int) {
+	return file_management_proto_rawDescGZIP(), []int{0}
+}
+
+type ListApplicationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Applications  []*Application         `protobuf:"bytes,1,rep,name=applications,proto3" json:"applications,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListApplicationsResponse) Reset() {
+	*x = ListApplicationsResponse{}
+	mi := &file_management_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListApplicationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListApplicationsResponse) ProtoMessage() {}
+
+func (x *ListApplicationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_management_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListApplicationsResponse.ProtoReflect.Descriptor instead.
+func (*ListApplicationsResponse) Descriptor() ([]byte, []int) {
+	return file_management_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListApplicationsResponse) GetApplications() []*Application {
+	if x != nil {
+		return x.Applications
+	}
+	return nil
+}
+
+type ApplicationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplicationRequest) Reset() {
+	*x = ApplicationRequest{}
+	mi := &file_management_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplicationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplicationRequest) ProtoMessage() {}
+
+func (x *ApplicationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_management_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplicationRequest.ProtoReflect.Descriptor instead.
+func (*ApplicationRequest) Descriptor() ([]byte, []int) {
+	return file_management_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ApplicationRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// Application is a deployed application
+type Application struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Template      string                 `protobuf:"bytes,2,opt,name=template,proto3" json:"template,omitempty"`
+	Version       string                 `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	Pods          []*Pod                 `protobuf:"bytes,4,rep,name=pods,proto3" json:"pods,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Application) Reset() {
+	*x = Application{}
+	mi := &file_management_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Application) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Application) ProtoMessage() {}
+
+func (x *Application) ProtoReflect() protoreflect.Message {
+	mi := &file_management_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Application.ProtoReflect.Descriptor instead.
+func (*Application) Descriptor() ([]byte, []int) {
+	return file_management_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Application) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Application) GetTemplate() string {
+	if x != nil {
+		return x.Template
+	}
+	return ""
+}
+
+func (x *Application) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *Application) GetPods() []*Pod {
+	if x != nil {
+		return x.Pods
+	}
+	return nil
+}
+
+// Pod is a pod of a deployed application
+type Pod struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Pod) Reset() {
+	*x = Pod{}
+	mi := &file_management_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pod) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pod) ProtoMessage() {}
+
+func (x *Pod) ProtoReflect() protoreflect.Message {
+	mi := &file_management_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pod.ProtoReflect.Descriptor instead.
+func (*Pod) Descriptor() ([]byte, []int) {
+	return file_management_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Pod) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Pod) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Pod) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type DeleteApplicationRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// keep_smt_level keeps the SMT level of the host, even if no deployed application requires it anymore
+	KeepSmtLevel bool `protobuf:"varint,2,opt,name=keep_smt_level,json=keepSmtLevel,proto3" json:"keep_smt_level,omitempty"`
+	// purge removes the volumes of the claims of the application too, which are kept by default to preserve its data
+	Purge bool `protobuf:"varint,3,opt,name=purge,proto3" json:"purge,omitempty"`
+	// grace_period is the time the containers are given to exit on SIGTERM before they are killed
+	GracePeriod   *durationpb.Duration `protobuf:"bytes,4,opt,name=grace_period,json=gracePeriod,proto3" json:"grace_period,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteApplicationRequest) Reset() {
+	*x = DeleteApplicationRequest{}
+	mi := &file_management_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteApplicationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteApplicationRequest) ProtoMessage() {}
+
+func (x *DeleteApplicationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_management_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteApplicationRequest.ProtoReflect.Descriptor instead.
+func (*DeleteApplicationRequest) Descriptor() ([]byte, []int) {
+	return file_management_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DeleteApplicationRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DeleteApplicationRequest) GetKeepSmtLevel() bool {
+	if x != nil {
+		return x.KeepSmtLevel
+	}
+	return false
+}
+
+func (x *DeleteApplicationRequest) GetPurge() bool {
+	if x != nil {
+		return x.Purge
+	}
+	return false
+}
+
+func (x *DeleteApplicationRequest) GetGracePeriod() *durationpb.Duration {
+	if x != nil {
+		return x.GracePeriod
+	}
+	return nil
+}
+
+// CreateApplicationRequest are the options to deploy an application. The options referring to the files of the
+// host (values files, signature policy, TLS certificate and allowed host paths) cannot be set through the API.
+type CreateApplicationRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// name of the application
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// template is the application template to deploy
+	Template string `protobuf:"bytes,2,opt,name=template,proto3" json:"template,omitempty"`
+	// environment selects the values-<environment>.yaml overlay of the template, Eg:- dev or prod
+	Environment string `protobuf:"bytes,3,opt,name=environment,proto3" json:"environment,omitempty"`
+	// params override the template values
+	Params map[string]string `protobuf:"bytes,4,rep,name=params,proto3" json:"params,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// labels and annotations are merged into all the rendered pods, the keys prefixed with ai-services.io/ are
+	// reserved
+	Labels            map[string]string `protobuf:"bytes,5,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Annotations       map[string]string `protobuf:"bytes,6,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	SkipImageDownload bool              `protobuf:"varint,7,opt,name=skip_image_download,json=skipImageDownload,proto3" json:"skip_image_download,omitempty"`
+	SkipModelDownload bool              `protobuf:"varint,8,opt,name=skip_model_download,json=skipModelDownload,proto3" json:"skip_model_download,omitempty"`
+	SkipSmokeTests    bool              `protobuf:"varint,9,opt,name=skip_smoke_tests,json=skipSmokeTests,proto3" json:"skip_smoke_tests,omitempty"`
+	SkipResourceCheck bool              `protobuf:"varint,10,opt,name=skip_resource_check,json=skipResourceCheck,proto3" json:"skip_resource_check,omitempty"`
+	// force recreates the existing pods whose definition changed since the last deployed revision
+	Force bool `protobuf:"varint,11,opt,name=force,proto3" json:"force,omitempty"`
+	// force_smt_level changes the SMT level of the host even if deployed applications require another SMT level
+	ForceSmtLevel bool `protobuf:"varint,12,opt,name=force_smt_level,json=forceSmtLevel,proto3" json:"force_smt_level,omitempty"`
+	// scan_images scans the template images with the scanner of the CLI config file before deploying
+	ScanImages bool `protobuf:"varint,13,opt,name=scan_images,json=scanImages,proto3" json:"scan_images,omitempty"`
+	// scan_fail_on is the lowest severity of the vulnerabilities failing the deployment
+	ScanFailOn string `protobuf:"bytes,14,opt,name=scan_fail_on,json=scanFailOn,proto3" json:"scan_fail_on,omitempty"`
+	// accept_model_license accepts the license of the gated models being downloaded
+	AcceptModelLicense bool `protobuf:"varint,15,opt,name=accept_model_license,json=acceptModelLicense,proto3" json:"accept_model_license,omitempty"`
+	// health overrides the health checks of the containers
+	Health *HealthOverrides `protobuf:"bytes,16,opt,name=health,proto3" json:"health,omitempty"`
+	// retry overrides the retry policy of the template for the pods failing to deploy on a transient error
+	Retry *RetryPolicy `protobuf:"bytes,17,opt,name=retry,proto3" json:"retry,omitempty"`
+	// tls of the exposed services, with a self-signed certificate
+	Tls *TLSOptions `protobuf:"bytes,18,opt,name=tls,proto3" json:"tls,omitempty"`
+	// generate_api_key generates an API key required by the serving endpoints
+	GenerateApiKey bool `protobuf:"varint,19,opt,name=generate_api_key,json=generateApiKey,proto3" json:"generate_api_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateApplicationRequest) Reset() {
+	*x = CreateApplicationRequest{}
+	mi := &file_management_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateApplicationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateApplicationRequest) ProtoMessage() {}
+
+func (x *CreateApplicationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_management_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateApplicationRequest.ProtoReflect.Descriptor instead.
+func (*CreateApplicationRequest) Descriptor() ([]byte, []int) {
+	return file_management_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CreateApplicationRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateApplicationRequest) GetTemplate() string {
+	if x != nil {
+		return x.Template
+	}
+	return ""
+}
+
+func (x *CreateApplicationRequest) GetEnvironment() string {
+	if x != nil {
+		return x.Environment
+	}
+	return ""
+}
+
+func (x *CreateApplicationRequest) GetParams() map[string]string {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+func (x *CreateApplicationRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *CreateApplicationRequest) GetAnnotations() map[string]string {
+	if x != nil {
+		return x.Annotations
+	}
+	return nil
+}
+
+func (x *CreateApplicationRequest) GetSkipImageDownload() bool {
+	if x != nil {
+		return x.SkipImageDownload
+	}
+	return false
+}
+
+func (x *CreateApplicationRequest) GetSkipModelDownload() bool {
+	if x != nil {
+		return x.SkipModelDownload
+	}
+	return false
+}
+
+func (x *CreateApplicationRequest) GetSkipSmokeTests() bool {
+	if x != nil {
+		return x.SkipSmokeTests
+	}
+	return false
+}
+
+func (x *CreateApplicationRequest) GetSkipResourceCheck() bool {
+	if x != nil {
+		return x.SkipResourceCheck
+	}
+	return false
+}
+
+func (x *CreateApplicationRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+func (x *CreateApplicationRequest) GetForceSmtLevel() bool {
+	if x != nil {
+		return x.ForceSmtLevel
+	}
+	return false
+}
+
+func (x *CreateApplicationRequest) GetScanImages() bool {
+	if x != nil {
+		return x.ScanImages
+	}
+	return false
+}
+
+func (x *CreateApplicationRequest) GetScanFailOn() string {
+	if x != nil {
+		return x.ScanFailOn
+	}
+	return ""
+}
+
+func (x *CreateApplicationRequest) GetAcceptModelLicense() bool {
+	if x != nil {
+		return x.AcceptModelLicense
+	}
+	return false
+}
+
+func (x *CreateApplicationRequest) GetHealth() *HealthOverrides {
+	if x != nil {
+		return x.Health
+	}
+	return nil
+}
+
+func (x *CreateApplicationRequest) GetRetry() *RetryPolicy {
+	if x != nil {
+		return x.Retry
+	}
+	return nil
+}
+
+func (x *CreateApplicationRequest) GetTls() *TLSOptions {
+	if x != nil {
+		return x.Tls
+	}
+	return nil
+}
+
+func (x *CreateApplicationRequest) GetGenerateApiKey() bool {
+	if x != nil {
+		return x.GenerateApiKey
+	}
+	return false
+}
+
+// HealthOverrides override the health checks of the containers
+type HealthOverrides struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// readiness_timeout overrides the readiness timeout of all the containers
+	ReadinessTimeout *durationpb.Duration `protobuf:"bytes,1,opt,name=readiness_timeout,json=readinessTimeout,proto3" json:"readiness_timeout,omitempty"`
+	// interval overrides the period of the health checks
+	Interval *durationpb.Duration `protobuf:"bytes,2,opt,name=interval,proto3" json:"interval,omitempty"`
+	// disabled are the containers the health checks of which are removed
+	Disabled      []string `protobuf:"bytes,3,rep,name=disabled,proto3" json:"disabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HealthOverrides) Reset() {
+	*x = HealthOverrides{}
+	mi := &file_management_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HealthOverrides) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthOverrides) ProtoMessage() {}
+
+func (x *HealthOverrides) ProtoReflect() protoreflect.Message {
+	mi := &file_management_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthOverrides.ProtoReflect.Descriptor instead.
+func (*HealthOverrides) Descriptor() ([]byte, []int) {
+	return file_management_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *HealthOverrides) GetReadinessTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.ReadinessTimeout
+	}
+	return nil
+}
+
+func (x *HealthOverrides) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+func (x *HealthOverrides) GetDisabled() []string {
+	if x != nil {
+		return x.Disabled
+	}
+	return nil
+}
+
+// RetryPolicy is the retry policy of the pods failing to deploy on a transient error
+type RetryPolicy struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// attempts is the number of retries, 0 disables them
+	Attempts int32 `protobuf:"varint,1,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	// backoff is the delay before the first retry, doubled at each retry
+	Backoff *durationpb.Duration `protobuf:"bytes,2,opt,name=backoff,proto3" json:"backoff,omitempty"`
+	// max_backoff caps the delay between the retries
+	MaxBackoff    *durationpb.Duration `protobuf:"bytes,3,opt,name=max_backoff,json=maxBackoff,proto3" json:"max_backoff,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetryPolicy) Reset() {
+	*x = RetryPolicy{}
+	mi := &file_management_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetryPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetryPolicy) ProtoMessage() {}
+
+func (x *RetryPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_management_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetryPolicy.ProtoReflect.Descriptor instead.
+func (*RetryPolicy) Descriptor() ([]byte, []int) {
+	return file_management_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RetryPolicy) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *RetryPolicy) GetBackoff() *durationpb.Duration {
+	if x != nil {
+		return x.Backoff
+	}
+	return nil
+}
+
+func (x *RetryPolicy) GetMaxBackoff() *durationpb.Duration {
+	if x != nil {
+		return x.MaxBackoff
+	}
+	return nil
+}
+
+// TLSOptions configure the TLS certificate of the exposed services
+type TLSOptions struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enabled       bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Hosts         []string               `protobuf:"bytes,2,rep,name=hosts,proto3" json:"hosts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TLSOptions) Reset() {
+	*x = TLSOptions{}
+	mi := &file_management_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TLSOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TLSOptions) ProtoMessage() {}
+
+func (x *TLSOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_management_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TLSOptions.ProtoReflect.Descriptor instead.
+func (*TLSOptions) Descriptor() ([]byte, []int) {
+	return file_management_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *TLSOptions) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *TLSOptions) GetHosts() []string {
+	if x != nil {
+		return x.Hosts
+	}
+	return nil
+}
+
+type OperationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OperationRequest) Reset() {
+	*x = OperationRequest{}
+	mi := &file_management_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OperationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperationRequest) ProtoMessage() {}
+
+func (x *OperationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_management_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperationRequest.ProtoReflect.Descriptor instead.
+func (*OperationRequest) Descriptor() ([]byte, []int) {
+	return file_management_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *OperationRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// Operation is a long running operation, e.g. the deployment of an application
+type Operation struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type        string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Application string                 `protobuf:"bytes,3,opt,name=application,proto3" json:"application,omitempty"`
+	// status is either running, succeeded or failed
+	Status     string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Error      string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	StartedAt  *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	Events     []*ProgressEvent       `protobuf:"bytes,8,rep,name=events,proto3" json:"events,omitempty"`
+	// result is the outcome reported by the operation, Eg:- the deployment result of a create, with the same schema
+	// as the REST API
+	Result        *structpb.Value `protobuf:"bytes,9,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Operation) Reset() {
+	*x = Operation{}
+	mi := &file_management_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Operation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Operation) ProtoMessage() {}
+
+func (x *Operation) ProtoReflect() protoreflect.Message {
+	mi := &file_management_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Operation.ProtoReflect.Descriptor instead.
+func (*Operation) Descriptor() ([]byte, []int) {
+	return file_management_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Operation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Operation) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Operation) GetApplication() string {
+	if x != nil {
+		return x.Application
+	}
+	return ""
+}
+
+func (x *Operation) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Operation) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *Operation) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *Operation) GetFinishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return nil
+}
+
+func (x *Operation) GetEvents() []*ProgressEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *Operation) GetResult() *structpb.Value {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+// OperationUpdate is streamed while watching an operation: the progress events, then the operation once finished
+type OperationUpdate struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Update:
+	//
+	//	*OperationUpdate_Event
+	//	*OperationUpdate_Operation
+	Update        isOperationUpdate_Update `protobuf_oneof:"update"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OperationUpdate) Reset() {
+	*x = OperationUpdate{}
+	mi := &file_management_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OperationUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperationUpdate) ProtoMessage() {}
+
+func (x *OperationUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_management_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperationUpdate.ProtoReflect.Descriptor instead.
+func (*OperationUpdate) Descriptor() ([]byte, []int) {
+	return file_management_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *OperationUpdate) GetUpdate() isOperationUpdate_Update {
+	if x != nil {
+		return x.Update
+	}
+	return nil
+}
+
+func (x *OperationUpdate) GetEvent() *ProgressEvent {
+	if x != nil {
+		if x, ok := x.Update.(*OperationUpdate_Event); ok {
+			return x.Event
+		}
+	}
+	return nil
+}
+
+func (x *OperationUpdate) GetOperation() *Operation {
+	if x != nil {
+		if x, ok := x.Update.(*OperationUpdate_Operation); ok {
+			return x.Operation
+		}
+	}
+	return nil
+}
+
+type isOperationUpdate_Update interface {
+	isOperationUpdate_Update()
+}
+
+type OperationUpdate_Event struct {
+	Event *ProgressEvent `protobuf:"bytes,1,opt,name=event,proto3,oneof"`
+}
+
+type OperationUpdate_Operation struct {
+	Operation *Operation `protobuf:"bytes,2,opt,name=operation,proto3,oneof"`
+}
+
+func (*OperationUpdate_Event) isOperationUpdate_Update() {}
+
+func (*OperationUpdate_Operation) isOperationUpdate_Update() {}
+
+// ProgressEvent reports the progress of a deployment
+type ProgressEvent struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Time    *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Stage   string                 `protobuf:"bytes,2,opt,name=stage,proto3" json:"stage,omitempty"`
+	Message string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	// layer is the pod template execution layer being deployed, starting from 1
+	Layer int32 `protobuf:"varint,4,opt,name=layer,proto3" json:"layer,omitempty"`
+	// pod is the pod template being deployed
+	Pod string `protobuf:"bytes,5,opt,name=pod,proto3" json:"pod,omitempty"`
+	// timings are the elapsed times of the deployment stages, set once completed
+	Timings       []*Timing `protobuf:"bytes,6,rep,name=timings,proto3" json:"timings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProgressEvent) Reset() {
+	*x = ProgressEvent{}
+	mi := &file_management_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProgressEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProgressEvent) ProtoMessage() {}
+
+func (x *ProgressEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_management_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProgressEvent.ProtoReflect.Descriptor instead.
+func (*ProgressEvent) Descriptor() ([]byte, []int) {
+	return file_management_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ProgressEvent) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *ProgressEvent) GetStage() string {
+	if x != nil {
+		return x.Stage
+	}
+	return ""
+}
+
+func (x *ProgressEvent) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ProgressEvent) GetLayer() int32 {
+	if x != nil {
+		return x.Layer
+	}
+	return 0
+}
+
+func (x *ProgressEvent) GetPod() string {
+	if x != nil {
+		return x.Pod
+	}
+	return ""
+}
+
+func (x *ProgressEvent) GetTimings() []*Timing {
+	if x != nil {
+		return x.Timings
+	}
+	return nil
+}
+
+// Timing is the elapsed time of a deployment stage
+type Timing struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stage         string                 `protobuf:"bytes,1,opt,name=stage,proto3" json:"stage,omitempty"`
+	Layer         int32                  `protobuf:"varint,2,opt,name=layer,proto3" json:"layer,omitempty"`
+	Pod           string                 `protobuf:"bytes,3,opt,name=pod,proto3" json:"pod,omitempty"`
+	Image         string                 `protobuf:"bytes,4,opt,name=image,proto3" json:"image,omitempty"`
+	Duration      *durationpb.Duration   `protobuf:"bytes,5,opt,name=duration,proto3" json:"duration,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Timing) Reset() {
+	*x = Timing{}
+	mi := &file_management_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Timing) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Timing) ProtoMessage() {}
+
+func (x *Timing) ProtoReflect() protoreflect.Message {
+	mi := &file_management_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Timing.ProtoReflect.Descriptor instead.
+func (*Timing) Descriptor() ([]byte, []int) {
+	return file_management_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Timing) GetStage() string {
+	if x != nil {
+		return x.Stage
+	}
+	return ""
+}
+
+func (x *Timing) GetLayer() int32 {
+	if x != nil {
+		return x.Layer
+	}
+	return 0
+}
+
+func (x *Timing) GetPod() string {
+	if x != nil {
+		return x.Pod
+	}
+	return ""
+}
+
+func (x *Timing) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *Timing) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+type ListTemplatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTemplatesRequest) Reset() {
+	*x = ListTemplatesRequest{}
+	mi := &file_management_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTemplatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTemplatesRequest) ProtoMessage() {}
+
+func (x *ListTemplatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_management_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTemplatesRequest.ProtoReflect.Descriptor instead.
+func (*ListTemplatesRequest) Descriptor() ([]byte, []int) {
+	return file_management_proto_rawDescGZIP(), []int{15}
+}
+
+type ListTemplatesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Templates     []*Template            `protobuf:"bytes,1,rep,name=templates,proto3" json:"templates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTemplatesResponse) Reset() {
+	*x = ListTemplatesResponse{}
+	mi := &file_management_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTemplatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTemplatesResponse) ProtoMessage() {}
+
+func (x *ListTemplatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_management_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTemplatesResponse.ProtoReflect.Descriptor instead.
+func (*ListTemplatesResponse) Descriptor() ([]byte, []int) {
+	return file_management_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListTemplatesResponse) GetTemplates() []*Template {
+	if x != nil {
+		return x.Templates
+	}
+	return nil
+}
+
+// Template is an application template
+type Template struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Name    string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	// parameters are the supported parameters along with their description
+	Parameters    map[string]string `protobuf:"bytes,3,rep,name=parameters,proto3" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Template) Reset() {
+	*x = Template{}
+	mi := &file_management_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Template) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Template) ProtoMessage() {}
+
+func (x *Template) ProtoReflect() protoreflect.Message {
+	mi := &file_management_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Template.ProtoReflect.Descriptor instead.
+func (*Template) Descriptor() ([]byte, []int) {
+	return file_management_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Template) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Template) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *Template) GetParameters() map[string]string {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+type ValidateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// skip are the validation checks to skip
+	Skip          []string `protobuf:"bytes,1,rep,name=skip,proto3" json:"skip,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateRequest) Reset() {
+	*x = ValidateRequest{}
+	mi := &file_management_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateRequest) ProtoMessage() {}
+
+func (x *ValidateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_management_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateRequest.ProtoReflect.Descriptor instead.
+func (*ValidateRequest) Descriptor() ([]byte, []int) {
+	return file_management_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ValidateRequest) GetSkip() []string {
+	if x != nil {
+		return x.Skip
+	}
+	return nil
+}
+
+type ValidateResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// passed is true if none of the blocking checks failed
+	Passed        bool                `protobuf:"varint,1,opt,name=passed,proto3" json:"passed,omitempty"`
+	Results       []*ValidationResult `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateResponse) Reset() {
+	*x = ValidateResponse{}
+	mi := &file_management_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateResponse) ProtoMessage() {}
+
+func (x *ValidateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_management_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateResponse.ProtoReflect.Descriptor instead.
+func (*ValidateResponse) Descriptor() ([]byte, []int) {
+	return file_management_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ValidateResponse) GetPassed() bool {
+	if x != nil {
+		return x.Passed
+	}
+	return false
+}
+
+func (x *ValidateResponse) GetResults() []*ValidationResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// ValidationResult is the result of a validation check of the host
+type ValidationResult struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Name    string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Passed  bool                   `protobuf:"varint,2,opt,name=passed,proto3" json:"passed,omitempty"`
+	Skipped bool                   `protobuf:"varint,3,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	// warning is set when a failed check doesn't block the deployments
+	Warning       bool   `protobuf:"varint,4,opt,name=warning,proto3" json:"warning,omitempty"`
+	Message       string `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	Hint          string `protobuf:"bytes,6,opt,name=hint,proto3" json:"hint,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidationResult) Reset() {
+	*x = ValidationResult{}
+	mi := &file_management_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidationResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidationResult) ProtoMessage() {}
+
+func (x *ValidationResult) ProtoReflect() protoreflect.Message {
+	mi := &file_management_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidationResult.ProtoReflect.Descriptor instead.
+func (*ValidationResult) Descriptor() ([]byte, []int) {
+	return file_management_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ValidationResult) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ValidationResult) GetPassed() bool {
+	if x != nil {
+		return x.Passed
+	}
+	return false
+}
+
+func (x *ValidationResult) GetSkipped() bool {
+	if x != nil {
+		return x.Skipped
+	}
+	return false
+}
+
+func (x *ValidationResult) GetWarning() bool {
+	if x != nil {
+		return x.Warning
+	}
+	return false
+}
+
+func (x *ValidationResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ValidationResult) GetHint() string {
+	if x != nil {
+		return x.Hint
+	}
+	return ""
+}
+
+type StreamLogsRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Application string                 `protobuf:"bytes,1,opt,name=application,proto3" json:"application,omitempty"`
+	// pod restricts the logs to the pod, all the pods of the application if empty
+	Pod string `protobuf:"bytes,2,opt,name=pod,proto3" json:"pod,omitempty"`
+	// container restricts the logs to the container, all the containers of the pods if empty
+	Container string `protobuf:"bytes,3,opt,name=container,proto3" json:"container,omitempty"`
+	// follow keeps streaming the new log lines
+	Follow        bool `protobuf:"varint,4,opt,name=follow,proto3" json:"follow,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamLogsRequest) Reset() {
+	*x = StreamLogsRequest{}
+	mi := &file_management_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamLogsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamLogsRequest) ProtoMessage() {}
+
+func (x *StreamLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_management_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamLogsRequest.ProtoReflect.Descriptor instead.
+func (*StreamLogsRequest) Descriptor() ([]byte, []int) {
+	return file_management_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *StreamLogsRequest) GetApplication() string {
+	if x != nil {
+		return x.Application
+	}
+	return ""
+}
+
+func (x *StreamLogsRequest) GetPod() string {
+	if x != nil {
+		return x.Pod
+	}
+	return ""
+}
+
+func (x *StreamLogsRequest) GetContainer() string {
+	if x != nil {
+		return x.Container
+	}
+	return ""
+}
+
+func (x *StreamLogsRequest) GetFollow() bool {
+	if x != nil {
+		return x.Follow
+	}
+	return false
+}
+
+// LogLine is a log line of a container
+type LogLine struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Pod       string                 `protobuf:"bytes,1,opt,name=pod,proto3" json:"pod,omitempty"`
+	Container string                 `protobuf:"bytes,2,opt,name=container,proto3" json:"container,omitempty"`
+	// stream is either stdout or stderr
+	Stream        string `protobuf:"bytes,3,opt,name=stream,proto3" json:"stream,omitempty"`
+	Line          string `protobuf:"bytes,4,opt,name=line,proto3" json:"line,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogLine) Reset() {
+	*x = LogLine{}
+	mi := &file_management_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogLine) ProtoMessage() {}
+
+func (x *LogLine) ProtoReflect() protoreflect.Message {
+	mi := &file_management_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogLine.ProtoReflect.Descriptor instead.
+func (*LogLine) Descriptor() ([]byte, []int) {
+	return file_management_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *LogLine) GetPod() string {
+	if x != nil {
+		return x.Pod
+	}
+	return ""
+}
+
+func (x *LogLine) GetContainer() string {
+	if x != nil {
+		return x.Container
+	}
+	return ""
+}
+
+func (x *LogLine) GetStream() string {
+	if x != nil {
+		return x.Stream
+	}
+	return ""
+}
+
+func (x *LogLine) GetLine() string {
+	if x != nil {
+		return x.Line
+	}
+	return ""
+}
+
+var File_management_proto protoreflect.FileDescriptor
+
+const file_management_proto_rawDesc = "" +
+	"\n" +
+	"\x10management.proto\x12\raiservices.v1\x1a\x1egoogle/protobuf/duration.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x19\n" +
+	"\x17ListApplicationsRequest\"Z\n" +
+	"\x18ListApplicationsResponse\x12>\n" +
+	"\fapplications\x18\x01 \x03(\v2\x1a.aiservices.v1.ApplicationR\fapplications\"(\n" +
+	"\x12ApplicationRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\x7f\n" +
+	"\vApplication\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\btemplate\x18\x02 \x01(\tR\btemplate\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\tR\aversion\x12&\n" +
+	"\x04pods\x18\x04 \x03(\v2\x12.aiservices.v1.PodR\x04pods\"A\n" +
+	"\x03Pod\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\"\xa8\x01\n" +
+	"\x18DeleteApplicationRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12$\n" +
+	"\x0ekeep_smt_level\x18\x02 \x01(\bR\fkeepSmtLevel\x12\x14\n" +
+	"\x05purge\x18\x03 \x01(\bR\x05purge\x12<\n" +
+	"\fgrace_period\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\vgracePeriod\"\xc6\b\n" +
+	"\x18CreateApplicationRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\btemplate\x18\x02 \x01(\tR\btemplate\x12 \n" +
+	"\venvironment\x18\x03 \x01(\tR\venvironment\x12K\n" +
+	"\x06params\x18\x04 \x03(\v23.aiservices.v1.CreateApplicationRequest.ParamsEntryR\x06params\x12K\n" +
+	"\x06labels\x18\x05 \x03(\v23.aiservices.v1.CreateApplicationRequest.LabelsEntryR\x06labels\x12Z\n" +
+	"\vannotations\x18\x06 \x03(\v28.aiservices.v1.CreateApplicationRequest.AnnotationsEntryR\vannotations\x12.\n" +
+	"\x13skip_image_download\x18\a \x01(\bR\x11skipImageDownload\x12.\n" +
+	"\x13skip_model_download\x18\b \x01(\bR\x11skipModelDownload\x12(\n" +
+	"\x10skip_smoke_tests\x18\t \x01(\bR\x0eskipSmokeTests\x12.\n" +
+	"\x13skip_resource_check\x18\n" +
+	" \x01(\bR\x11skipResourceCheck\x12\x14\n" +
+	"\x05force\x18\v \x01(\bR\x05force\x12&\n" +
+	"\x0fforce_smt_level\x18\f \x01(\bR\rforceSmtLevel\x12\x1f\n" +
+	"\vscan_images\x18\r \x01(\bR\n" +
+	"scanImages\x12 \n" +
+	"\fscan_fail_on\x18\x0e \x01(\tR\n" +
+	"scanFailOn\x120\n" +
+	"\x14accept_model_license\x18\x0f \x01(\bR\x12acceptModelLicense\x126\n" +
+	"\x06health\x18\x10 \x01(\v2\x1e.aiservices.v1.HealthOverridesR\x06health\x120\n" +
+	"\x05retry\x18\x11 \x01(\v2\x1a.aiservices.v1.RetryPolicyR\x05retry\x12+\n" +
+	"\x03tls\x18\x12 \x01(\v2\x19.aiservices.v1.TLSOptionsR\x03tls\x12(\n" +
+	"\x10generate_api_key\x18\x13 \x01(\bR\x0egenerateApiKey\x1a9\n" +
+	"\vParamsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a>\n" +
+	"\x10AnnotationsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xac\x01\n" +
+	"\x0fHealthOverrides\x12F\n" +
+	"\x11readiness_timeout\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\x10readinessTimeout\x125\n" +
+	"\binterval\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\binterval\x12\x1a\n" +
+	"\bdisabled\x18\x03 \x03(\tR\bdisabled\"\x9a\x01\n" +
+	"\vRetryPolicy\x12\x1a\n" +
+	"\battempts\x18\x01 \x01(\x05R\battempts\x123\n" +
+	"\abackoff\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\abackoff\x12:\n" +
+	"\vmax_backoff\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\n" +
+	"maxBackoff\"<\n" +
+	"\n" +
+	"TLSOptions\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x14\n" +
+	"\x05hosts\x18\x02 \x03(\tR\x05hosts\"\"\n" +
+	"\x10OperationRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xdd\x02\n" +
+	"\tOperation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12 \n" +
+	"\vapplication\x18\x03 \x01(\tR\vapplication\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\x129\n" +
+	"\n" +
+	"started_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12;\n" +
+	"\vfinished_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"finishedAt\x124\n" +
+	"\x06events\x18\b \x03(\v2\x1c.aiservices.v1.ProgressEventR\x06events\x12.\n" +
+	"\x06result\x18\t \x01(\v2\x16.google.protobuf.ValueR\x06result\"\x8b\x01\n" +
+	"\x0fOperationUpdate\x124\n" +
+	"\x05event\x18\x01 \x01(\v2\x1c.aiservices.v1.ProgressEventH\x00R\x05event\x128\n" +
+	"\toperation\x18\x02 \x01(\v2\x18.aiservices.v1.OperationH\x00R\toperationB\b\n" +
+	"\x06update\"\xc8\x01\n" +
+	"\rProgressEvent\x12.\n" +
+	"\x04time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x14\n" +
+	"\x05stage\x18\x02 \x01(\tR\x05stage\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12\x14\n" +
+	"\x05layer\x18\x04 \x01(\x05R\x05layer\x12\x10\n" +
+	"\x03pod\x18\x05 \x01(\tR\x03pod\x12/\n" +
+	"\atimings\x18\x06 \x03(\v2\x15.aiservices.v1.TimingR\atimings\"\x93\x01\n" +
+	"\x06Timing\x12\x14\n" +
+	"\x05stage\x18\x01 \x01(\tR\x05stage\x12\x14\n" +
+	"\x05layer\x18\x02 \x01(\x05R\x05layer\x12\x10\n" +
+	"\x03pod\x18\x03 \x01(\tR\x03pod\x12\x14\n" +
+	"\x05image\x18\x04 \x01(\tR\x05image\x125\n" +
+	"\bduration\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\bduration\"\x16\n" +
+	"\x14ListTemplatesRequest\"N\n" +
+	"\x15ListTemplatesResponse\x125\n" +
+	"\ttemplates\x18\x01 \x03(\v2\x17.aiservices.v1.TemplateR\ttemplates\"\xc0\x01\n" +
+	"\bTemplate\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12G\n" +
+	"\n" +
+	"parameters\x18\x03 \x03(\v2'.aiservices.v1.Template.ParametersEntryR\n" +
+	"parameters\x1a=\n" +
+	"\x0fParametersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"%\n" +
+	"\x0fValidateRequest\x12\x12\n" +
+	"\x04skip\x18\x01 \x03(\tR\x04skip\"e\n" +
+	"\x10ValidateResponse\x12\x16\n" +
+	"\x06passed\x18\x01 \x01(\bR\x06passed\x129\n" +
+	"\aresults\x18\x02 \x03(\v2\x1f.aiservices.v1.ValidationResultR\aresults\"\xa0\x01\n" +
+	"\x10ValidationResult\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06passed\x18\x02 \x01(\bR\x06passed\x12\x18\n" +
+	"\askipped\x18\x03 \x01(\bR\askipped\x12\x18\n" +
+	"\awarning\x18\x04 \x01(\bR\awarning\x12\x18\n" +
+	"\amessage\x18\x05 \x01(\tR\amessage\x12\x12\n" +
+	"\x04hint\x18\x06 \x01(\tR\x04hint\"}\n" +
+	"\x11StreamLogsRequest\x12 \n" +
+	"\vapplication\x18\x01 \x01(\tR\vapplication\x12\x10\n" +
+	"\x03pod\x18\x02 \x01(\tR\x03pod\x12\x1c\n" +
+	"\tcontainer\x18\x03 \x01(\tR\tcontainer\x12\x16\n" +
+	"\x06follow\x18\x04 \x01(\bR\x06follow\"e\n" +
+	"\aLogLine\x12\x10\n" +
+	"\x03pod\x18\x01 \x01(\tR\x03pod\x12\x1c\n" +
+	"\tcontainer\x18\x02 \x01(\tR\tcontainer\x12\x16\n" +
+	"\x06stream\x18\x03 \x01(\tR\x06stream\x12\x12\n" +
+	"\x04line\x18\x04 \x01(\tR\x04line2\x8d\x06\n" +
+	"\n" +
+	"Management\x12c\n" +
+	"\x10ListApplications\x12&.aiservices.v1.ListApplicationsRequest\x1a'.aiservices.v1.ListApplicationsResponse\x12O\n" +
+	"\x0eGetApplication\x12!.aiservices.v1.ApplicationRequest\x1a\x1a.aiservices.v1.Application\x12V\n" +
+	"\x11DeleteApplication\x12'.aiservices.v1.DeleteApplicationRequest\x1a\x18.aiservices.v1.Operation\x12I\n" +
+	"\fGetOperation\x12\x1f.aiservices.v1.OperationRequest\x1a\x18.aiservices.v1.Operation\x12Z\n" +
+	"\rListTemplates\x12#.aiservices.v1.ListTemplatesRequest\x1a$.aiservices.v1.ListTemplatesResponse\x12K\n" +
+	"\bValidate\x12\x1e.aiservices.v1.ValidateRequest\x1a\x1f.aiservices.v1.ValidateResponse\x12^\n" +
+	"\x11CreateApplication\x12'.aiservices.v1.CreateApplicationRequest\x1a\x1e.aiservices.v1.OperationUpdate0\x01\x12S\n" +
+	"\x0eWatchOperation\x12\x1f.aiservices.v1.OperationRequest\x1a\x1e.aiservices.v1.OperationUpdate0\x01\x12H\n" +
+	"\n" +
+	"StreamLogs\x12 .aiservices.v1.StreamLogsRequest\x1a\x16.aiservices.v1.LogLine0\x01B=Z;github.com/project-ai-services/ai-services/pkg/api/v1;apiv1b\x06proto3"
+
+var (
+	file_management_proto_rawDescOnce sync.Once
+	file_management_proto_rawDescData []byte
+)
+
+func file_management_proto_rawDescGZIP() []byte {
+	file_management_proto_rawDescOnce.Do(func() {
+		file_management_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_management_proto_rawDesc), len(file_management_proto_rawDesc)))
+	})
+	return file_management_proto_rawDescData
+}
+
+var file_management_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
+var file_management_proto_goTypes = []any{
+	(*ListApplicationsRequest)(nil),  // 0: aiservices.v1.ListApplicationsRequest
+	(*ListApplicationsResponse)(nil), // 1: aiservices.v1.ListApplicationsResponse
+	(*ApplicationRequest)(nil),       // 2: aiservices.v1.ApplicationRequest
+	(*Application)(nil),              // 3: aiservices.v1.Application
+	(*Pod)(nil),                      // 4: aiservices.v1.Pod
+	(*DeleteApplicationRequest)(nil), // 5: aiservices.v1.DeleteApplicationRequest
+	(*CreateApplicationRequest)(nil), // 6: aiservices.v1.CreateApplicationRequest
+	(*HealthOverrides)(nil),          // 7: aiservices.v1.HealthOverrides
+	(*RetryPolicy)(nil),              // 8: aiservices.v1.RetryPolicy
+	(*TLSOptions)(nil),               // 9: aiservices.v1.TLSOptions
+	(*OperationRequest)(nil),         // 10: aiservices.v1.OperationRequest
+	(*Operation)(nil),                // 11: aiservices.v1.Operation
+	(*OperationUpdate)(nil),          // 12: aiservices.v1.OperationUpdate
+	(*ProgressEvent)(nil),            // 13: aiservices.v1.ProgressEvent
+	(*Timing)(nil),                   // 14: aiservices.v1.Timing
+	(*ListTemplatesRequest)(nil),     // 15: aiservices.v1.ListTemplatesRequest
+	(*ListTemplatesResponse)(nil),    // 16: aiservices.v1.ListTemplatesResponse
+	(*Template)(nil),                 // 17: aiservices.v1.Template
+	(*ValidateRequest)(nil),          // 18: aiservices.v1.ValidateRequest
+	(*ValidateResponse)(nil),         // 19: aiservices.v1.ValidateResponse
+	(*ValidationResult)(nil),         // 20: aiservices.v1.ValidationResult
+	(*StreamLogsRequest)(nil),        // 21: aiservices.v1.StreamLogsRequest
+	(*LogLine)(nil),                  // 22: aiservices.v1.LogLine
+	nil,                              // 23: aiservices.v1.CreateApplicationRequest.ParamsEntry
+	nil,                              // 24: aiservices.v1.CreateApplicationRequest.LabelsEntry
+	nil,                              // 25: aiservices.v1.CreateApplicationRequest.AnnotationsEntry
+	nil,                              // 26: aiservices.v1.Template.ParametersEntry
+	(*durationpb.Duration)(nil),      // 27: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil),    // 28: google.protobuf.Timestamp
+	(*structpb.Value)(nil),           // 29: google.protobuf.Value
+}
+var file_management_proto_depIdxs = []int32{
+	3,  // 0: aiservices.v1.ListApplicationsResponse.applications:type_name -> aiservices.v1.Application
+	4,  // 1: aiservices.v1.Application.pods:type_name -> aiservices.v1.Pod
+	27, // 2: aiservices.v1.DeleteApplicationRequest.grace_period:type_name -> google.protobuf.Duration
+	23, // 3: aiservices.v1.CreateApplicationRequest.params:type_name -> aiservices.v1.CreateApplicationRequest.ParamsEntry
+	24, // 4: aiservices.v1.CreateApplicationRequest.labels:type_name -> aiservices.v1.CreateApplicationRequest.LabelsEntry
+	25, // 5: aiservices.v1.CreateApplicationRequest.annotations:type_name -> aiservices.v1.CreateApplicationRequest.AnnotationsEntry
+	7,  // 6: aiservices.v1.CreateApplicationRequest.health:type_name -> aiservices.v1.HealthOverrides
+	8,  // 7: aiservices.v1.CreateApplicationRequest.retry:type_name -> aiservices.v1.RetryPolicy
+	9,  // 8: aiservices.v1.CreateApplicationRequest.tls:type_name -> aiservices.v1.TLSOptions
+	27, // 9: aiservices.v1.HealthOverrides.readiness_timeout:type_name -> google.protobuf.Duration
+	27, // 10: aiservices.v1.HealthOverrides.interval:type_name -> google.protobuf.Duration
+	27, // 11: aiservices.v1.RetryPolicy.backoff:type_name -> google.protobuf.Duration
+	27, // 12: aiservices.v1.RetryPolicy.max_backoff:type_name -> google.protobuf.Duration
+	28, // 13: aiservices.v1.Operation.started_at:type_name -> google.protobuf.Timestamp
+	28, // 14: aiservices.v1.Operation.finished_at:type_name -> google.protobuf.Timestamp
+	13, // 15: aiservices.v1.Operation.events:type_name -> aiservices.v1.ProgressEvent
+	29, // 16: aiservices.v1.Operation.result:type_name -> google.protobuf.Value
+	13, // 17: aiservices.v1.OperationUpdate.event:type_name -> aiservices.v1.ProgressEvent
+	11, // 18: aiservices.v1.OperationUpdate.operation:type_name -> aiservices.v1.Operation
+	28, // 19: aiservices.v1.ProgressEvent.time:type_name -> google.protobuf.Timestamp
+	14, // 20: aiservices.v1.ProgressEvent.timings:type_name -> aiservices.v1.Timing
+	27, // 21: aiservices.v1.Timing.duration:type_name -> google.protobuf.Duration
+	17, // 22: aiservices.v1.ListTemplatesResponse.templates:type_name -> aiservices.v1.Template
+	26, // 23: aiservices.v1.Template.parameters:type_name -> aiservices.v1.Template.ParametersEntry
+	20, // 24: aiservices.v1.ValidateResponse.results:type_name -> aiservices.v1.ValidationResult
+	0,  // 25: aiservices.v1.Management.ListApplications:input_type -> aiservices.v1.ListApplicationsRequest
+	2,  // 26: aiservices.v1.Management.GetApplication:input_type -> aiservices.v1.ApplicationRequest
+	5,  // 27: aiservices.v1.Management.DeleteApplication:input_type -> aiservices.v1.DeleteApplicationRequest
+	10, // 28: aiservices.v1.Management.GetOperation:input_type -> aiservices.v1.OperationRequest
+	15, // 29: aiservices.v1.Management.ListTemplates:input_type -> aiservices.v1.ListTemplatesRequest
+	18, // 30: aiservices.v1.Management.Validate:input_type -> aiservices.v1.ValidateRequest
+	6,  // 31: aiservices.v1.Management.CreateApplication:input_type -> aiservices.v1.CreateApplicationRequest
+	10, // 32: aiservices.v1.Management.WatchOperation:input_type -> aiservices.v1.OperationRequest
+	21, // 33: aiservices.v1.Management.StreamLogs:input_type -> aiservices.v1.StreamLogsRequest
+	1,  // 34: aiservices.v1.Management.ListApplications:output_type -> aiservices.v1.ListApplicationsResponse
+	3,  // 35: aiservices.v1.Management.GetApplication:output_type -> aiservices.v1.Application
+	11, // 36: aiservices.v1.Management.DeleteApplication:output_type -> aiservices.v1.Operation
+	11, // 37: aiservices.v1.Management.GetOperation:output_type -> aiservices.v1.Operation
+	16, // 38: aiservices.v1.Management.ListTemplates:output_type -> aiservices.v1.ListTemplatesResponse
+	19, // 39: aiservices.v1.Management.Validate:output_type -> aiservices.v1.ValidateResponse
+	12, // 40: aiservices.v1.Management.CreateApplication:output_type -> aiservices.v1.OperationUpdate
+	12, // 41: aiservices.v1.Management.WatchOperation:output_type -> aiservices.v1.OperationUpdate
+	22, // 42: aiservices.v1.Management.StreamLogs:output_type -> aiservices.v1.LogLine
+	34, // [34:43] is the sub-list for method output_type
+	25, // [25:34] is the sub-list for method input_type
+	25, // [25:25] is the sub-list for extension type_name
+	25, // [25:25] is the sub-list for extension extendee
+	0,  // [0:25] is the sub-list for field type_name
+}
+
+func init() { file_management_proto_init() }
+func file_management_proto_init() {
+	if File_management_proto != nil {
+		return
+	}
+	file_management_proto_msgTypes[12].OneofWrappers = []any{
+		(*OperationUpdate_Event)(nil),
+		(*OperationUpdate_Operation)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_management_proto_rawDesc), len(file_management_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   27,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_management_proto_goTypes,
+		DependencyIndexes: file_management_proto_depIdxs,
+		MessageInfos:      file_management_proto_msgTypes,
+	}.Build()
+	File_management_proto = out.File
+	file_management_proto_goTypes = nil
+	file_management_proto_depIdxs = nil
+}